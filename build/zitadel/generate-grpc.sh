@@ -93,6 +93,20 @@ protoc \
 mv ${ZITADEL_PATH}/pkg/grpc/auth/zitadel/* ${ZITADEL_PATH}/pkg/grpc/auth
 rm -r ${ZITADEL_PATH}/pkg/grpc/auth/zitadel
 
+protoc \
+  -I=/proto/include \
+  --grpc-gateway_out ${GOPATH}/src \
+  --grpc-gateway_opt logtostderr=true \
+  --openapiv2_out ${OPENAPI_PATH} \
+  --openapiv2_opt logtostderr=true \
+  --authoption_out ${GRPC_PATH}/session \
+  --validate_out=lang=go:${GOPATH}/src \
+  ${PROTO_PATH}/session.proto
+
+# authoptions are generated into the wrong folder
+mv ${ZITADEL_PATH}/pkg/grpc/session/zitadel/* ${ZITADEL_PATH}/pkg/grpc/session
+rm -r ${ZITADEL_PATH}/pkg/grpc/session/zitadel
+
 ## generate docs
 protoc \
   -I=/proto/include \
@@ -170,6 +184,10 @@ protoc \
   -I=/proto/include \
   --doc_out=${DOCS_PATH} --doc_opt=${PROTO_PATH}/docs/zitadel-md.tmpl,settings.md \
   ${PROTO_PATH}/settings.proto
+protoc \
+  -I=/proto/include \
+  --doc_out=${DOCS_PATH} --doc_opt=${PROTO_PATH}/docs/zitadel-md.tmpl,session.md \
+  ${PROTO_PATH}/session.proto
 protoc \
   -I=/proto/include \
   --doc_out=${DOCS_PATH} --doc_opt=${PROTO_PATH}/docs/zitadel-md.tmpl,text.md \
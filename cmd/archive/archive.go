@@ -0,0 +1,130 @@
+package archive
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/archive"
+)
+
+const (
+	flagInstance      = "instance"
+	flagOlderThan     = "older-than"
+	flagBefore        = "before"
+	flagAggregateType = "aggregate-type"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "moves old events into archive files and back",
+		Long: `moves the events of removed aggregates which didn't change since a cutoff into gzip compressed JSONL files
+only removed users (user) and user grants (usergrant) are archived, their unique constraints were released on the removal
+the files are written to the configured directory or S3 compatible storage together with a manifest containing a checksum
+only complete aggregates are archived, projections and write models of the remaining aggregates are not affected
+archived aggregates are no longer available until they are imported again
+Requirements:
+- cockroachdb`,
+	}
+	cmd.PersistentFlags().String(flagInstance, "", "id of the instance")
+	cobra.MarkFlagRequired(cmd.PersistentFlags(), flagInstance)
+	cmd.AddCommand(
+		newExport(),
+		newImport(),
+	)
+	return cmd
+}
+
+func newExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "exports the events older than the cutoff and removes them from the eventstore",
+		Example: `export --instance 170123456789 --older-than 8760h
+export --instance 170123456789 --before 2021-01-01T00:00:00Z --aggregate-type user --aggregate-type usergrant`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cutoff, err := cutoff(cmd)
+			if err != nil {
+				return err
+			}
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+			aggregateTypes, _ := cmd.Flags().GetStringArray(flagAggregateType)
+
+			return withArchiver(func(archiver *archive.Archiver) error {
+				manifest, err := archiver.Export(cmd.Context(), instanceID, cutoff, aggregateTypes...)
+				if err != nil {
+					return err
+				}
+				if manifest.EventCount == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "no events to archive")
+					return nil
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "archived %d events (sequence %d to %d) to %s\nchecksum: %s\n",
+					manifest.EventCount, manifest.MinSequence, manifest.MaxSequence, manifest.Location, manifest.Checksum)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().Duration(flagOlderThan, 0, "archive the aggregates which didn't change within the duration")
+	cmd.Flags().String(flagBefore, "", "archive the aggregates which didn't change since the timestamp (RFC3339)")
+	cmd.Flags().StringArray(flagAggregateType, nil, "only archive aggregates of the type (user or usergrant)")
+	return cmd
+}
+
+func newImport() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [archive name]",
+		Short: "imports the events of an archive into the eventstore again",
+		Long: `imports the events of an archive into the eventstore again
+the checksum of the archive is verified against its manifest before any event is imported
+events which are already in the eventstore are skipped
+rebuild the projections afterwards to include the imported aggregates`,
+		Example: `import --instance 170123456789 events_1234-5678`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+
+			return withArchiver(func(archiver *archive.Archiver) error {
+				manifest, imported, err := archiver.Import(cmd.Context(), instanceID, args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "imported %d of %d events of %s\n", imported, manifest.EventCount, manifest.Name)
+				return nil
+			})
+		},
+	}
+}
+
+func withArchiver(fn func(*archive.Archiver) error) error {
+	config := MustNewConfig(viper.GetViper())
+	client, err := database.Connect(config.Database, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	storage, err := config.Archive.NewStorage()
+	if err != nil {
+		return err
+	}
+	return fn(archive.NewArchiver(client, storage))
+}
+
+func cutoff(cmd *cobra.Command) (time.Time, error) {
+	olderThan, _ := cmd.Flags().GetDuration(flagOlderThan)
+	before, _ := cmd.Flags().GetString(flagBefore)
+	switch {
+	case olderThan > 0 && before != "":
+		return time.Time{}, fmt.Errorf("only one of --%s and --%s can be set", flagOlderThan, flagBefore)
+	case olderThan > 0:
+		return time.Now().Add(-olderThan), nil
+	case before != "":
+		return time.Parse(time.RFC3339, before)
+	default:
+		return time.Time{}, fmt.Errorf("either --%s or --%s is required", flagOlderThan, flagBefore)
+	}
+}
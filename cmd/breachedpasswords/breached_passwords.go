@@ -0,0 +1,124 @@
+package breachedpasswords
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/breach"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	flagInput             = "input"
+	flagOutput            = "output"
+	flagFalsePositiveRate = "false-positive-rate"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "breached-passwords",
+		Short: "manage the offline corpus of breached passwords",
+	}
+	cmd.AddCommand(newBuildFilter())
+	return cmd
+}
+
+func newBuildFilter() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build-filter -i hashes.txt -o breached.bloom",
+		Short: "build a bloom filter file of breached password hashes",
+		Long: `build a bloom filter file of breached password hashes
+the input contains one SHA-1 hash (hex) per line, optionally followed by :count as in the HaveIBeenPwned downloads
+set the path of the output as SystemDefaults.BreachedPasswords.BloomFilterPath to check passwords offline`,
+		Example: `build-filter -i pwned-passwords-sha1-ordered-by-hash-v8.txt -o breached.bloom --false-positive-rate 0.0001`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, _ := cmd.Flags().GetString(flagInput)
+			output, _ := cmd.Flags().GetString(flagOutput)
+			falsePositiveRate, _ := cmd.Flags().GetFloat64(flagFalsePositiveRate)
+			if input == "" || output == "" {
+				return caos_errs.ThrowInvalidArgument(nil, "BREAC-Ub3sx", "input and output are required")
+			}
+			if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+				return caos_errs.ThrowInvalidArgument(nil, "BREAC-Gm6vd", "false positive rate must be between 0 and 1")
+			}
+			return buildFilter(input, output, falsePositiveRate)
+		},
+	}
+	cmd.Flags().StringP(flagInput, "i", "", "path to the file of SHA-1 hashes")
+	cmd.Flags().StringP(flagOutput, "o", "", "path of the bloom filter file to write")
+	cmd.Flags().Float64(flagFalsePositiveRate, 0.001, "rate of safe passwords reported as breached")
+	return cmd
+}
+
+// buildFilter reads the input twice:
+// once to size the filter and once to add the hashes
+func buildFilter(input, output string, falsePositiveRate float64) error {
+	file, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := countHashes(file)
+	if err != nil {
+		return err
+	}
+	logging.WithFields("hashes", count).Info("building bloom filter")
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	filter := breach.NewBloomFilter(count, falsePositiveRate)
+	if err = addHashes(filter, file); err != nil {
+		return err
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(out)
+	if _, err = filter.WriteTo(writer); err != nil {
+		out.Close()
+		return err
+	}
+	if err = writer.Flush(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func countHashes(r io.Reader) (count uint64, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if hashOfLine(scanner.Text()) != "" {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
+
+func addHashes(filter *breach.BloomFilter, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		hash := hashOfLine(scanner.Text())
+		if hash == "" {
+			continue
+		}
+		if err := filter.AddHex(hash); err != nil {
+			logging.WithFields("line", line).Error("invalid SHA-1 hash")
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func hashOfLine(line string) string {
+	hash, _, _ := strings.Cut(line, ":")
+	return strings.TrimSpace(hash)
+}
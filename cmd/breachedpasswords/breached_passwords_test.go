@@ -0,0 +1,44 @@
+package breachedpasswords
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/breach"
+)
+
+func Test_buildFilter(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "hashes.txt")
+	output := filepath.Join(dir, "breached.bloom")
+	// SHA-1 of "password" and "123456"
+	hashes := "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n\r\n7c4a8d09ca3762af61e59520943dc26494f8941b\n"
+	require.NoError(t, os.WriteFile(input, []byte(hashes), 0600))
+
+	require.NoError(t, buildFilter(input, output, 0.001))
+
+	filter, err := breach.LoadBloomFilter(output)
+	require.NoError(t, err)
+	for _, password := range []string{"password", "123456"} {
+		breached, err := filter.IsBreached(context.Background(), password)
+		require.NoError(t, err)
+		assert.True(t, breached, password)
+	}
+}
+
+func Test_countHashes(t *testing.T) {
+	count, err := countHashes(strings.NewReader("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:1\n\n7C4A8D09CA3762AF61E59520943DC26494F8941B\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func Test_addHashes_invalid(t *testing.T) {
+	err := addHashes(breach.NewBloomFilter(1, 0.001), strings.NewReader("5BAA61:1\n"))
+	assert.Error(t, err)
+}
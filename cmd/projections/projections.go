@@ -0,0 +1,208 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	flagProjection = "projection"
+	flagInstance   = "instance"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "manage the projections",
+		Long: `manage the projections of ZITADEL
+Requirements:
+- cockroachdb`,
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.AddCommand(
+		newList(),
+		newPause(),
+		newResume(),
+		newRebuild(),
+	)
+	return cmd
+}
+
+func newList() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "lists the state of the projections per instance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			client, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			queries := new(query.ProjectionStateSearchQueries)
+			if projectionName, _ := cmd.Flags().GetString(flagProjection); projectionName != "" {
+				q, err := query.NewProjectionStateProjectionNameSearchQuery(projectionName)
+				if err != nil {
+					return err
+				}
+				queries.Queries = append(queries.Queries, q)
+			}
+			if instanceID, _ := cmd.Flags().GetString(flagInstance); instanceID != "" {
+				q, err := query.NewProjectionStateInstanceIDSearchQuery(instanceID)
+				if err != nil {
+					return err
+				}
+				queries.Queries = append(queries.Queries, q)
+			}
+			states, err := query.SearchProjectionStates(cmd.Context(), client, queries)
+			if err != nil {
+				return err
+			}
+			return printStates(states)
+		},
+	}
+	cmd.Flags().String(flagProjection, "", "only list the states of the projection")
+	cmd.Flags().String(flagInstance, "", "only list the states of the instance")
+	return cmd
+}
+
+func newPause() *cobra.Command {
+	return &cobra.Command{
+		Use:     "pause [projection name]",
+		Short:   "stops the processing of events of the projection until it's resumed",
+		Example: `pause projections.users2`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withProjections(cmd, func(ctx context.Context) error {
+				return projection.Pause(ctx, args[0])
+			})
+		},
+	}
+}
+
+func newResume() *cobra.Command {
+	return &cobra.Command{
+		Use:     "resume [projection name]",
+		Short:   "continues the processing of events of a paused projection",
+		Example: `resume projections.users2`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withProjections(cmd, func(ctx context.Context) error {
+				return projection.Resume(ctx, args[0])
+			})
+		},
+	}
+}
+
+func newRebuild() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild [projection name]",
+		Short: "rebuilds the projection from the events",
+		Long: `rebuilds the projection into shadow tables which replace the tables of the projection as soon as they caught up
+the projection stays available during the rebuild
+the command returns as soon as the rebuild is done`,
+		Example: `rebuild projections.users2`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withProjections(cmd, func(ctx context.Context) error {
+				return projection.Rebuild(ctx, args[0])
+			})
+		},
+	}
+}
+
+// withProjections creates the projections without starting them
+func withProjections(cmd *cobra.Command, fn func(context.Context) error) error {
+	config := MustNewConfig(viper.GetViper())
+	masterKey, err := key.MasterKey(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := database.Connect(config.Database, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	keyEncryption, certEncryption, err := encryptionAlgorithms(client, config.EncryptionKeys, masterKey)
+	if err != nil {
+		return err
+	}
+	es, err := eventstore.Start(client)
+	if err != nil {
+		return err
+	}
+	dataKeyStorage, err := cryptoDB.NewDataKeyStorage(client, masterKey)
+	if err != nil {
+		return err
+	}
+	es.SetPersonalDataCrypto(crypto.NewPersonalDataCrypto(dataKeyStorage, false))
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	if err = projection.Create(ctx, client, es, config.Projections, keyEncryption, certEncryption); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+func encryptionAlgorithms(client *sql.DB, config *encryptionKeyConfig, masterKey string) (keyEncryption, certEncryption crypto.EncryptionAlgorithm, err error) {
+	keyStorage, err := cryptoDB.NewKeyStorage(client, masterKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyEncryption, err = crypto.NewAESCrypto(config.OIDC, keyStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+	certEncryption, err = crypto.NewAESCrypto(config.SAML, keyStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keyEncryption, certEncryption, nil
+}
+
+func printStates(states *query.ProjectionStates) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECTION\tINSTANCE\tSEQUENCE\tLATEST\tLAG\tLAST EVENT\tPAUSED\tREBUILD")
+	for _, state := range states.ProjectionStates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%t\t%s\n",
+			state.ProjectionName,
+			state.InstanceID,
+			state.CurrentSequence,
+			state.LatestSequence,
+			state.Lag,
+			state.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+			state.Paused,
+			rebuildStateString(state.RebuildState),
+		)
+	}
+	return w.Flush()
+}
+
+func rebuildStateString(state query.ProjectionRebuildState) string {
+	switch state {
+	case query.ProjectionRebuildStateRunning:
+		return "running"
+	case query.ProjectionRebuildStateDone:
+		return "done"
+	case query.ProjectionRebuildStateFailed:
+		return "failed"
+	default:
+		return "-"
+	}
+}
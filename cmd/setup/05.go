@@ -0,0 +1,33 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createProjectionStates = `
+CREATE TABLE projections.projection_states (
+    projection_name TEXT,
+    paused BOOLEAN NOT NULL DEFAULT false,
+    rebuild_state SMALLINT NOT NULL DEFAULT 0,
+    claimed_at TIMESTAMPTZ,
+    change_date TIMESTAMPTZ,
+
+    PRIMARY KEY (projection_name)
+);
+`
+)
+
+type ProjectionStatesTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *ProjectionStatesTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createProjectionStates)
+	return err
+}
+
+func (mig *ProjectionStatesTable) String() string {
+	return "05_projection_states"
+}
@@ -0,0 +1,31 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	dropAuthViews = `
+DROP TABLE IF EXISTS auth.tokens;
+DROP TABLE IF EXISTS auth.refresh_tokens;
+DROP TABLE IF EXISTS auth.user_sessions;
+DROP TABLE IF EXISTS auth.org_project_mapping;
+
+DELETE FROM auth.current_sequences WHERE view_name IN ('auth.tokens', 'auth.refresh_tokens', 'auth.user_sessions', 'auth.org_project_mapping');
+DELETE FROM auth.failed_events WHERE view_name IN ('auth.tokens', 'auth.refresh_tokens', 'auth.user_sessions', 'auth.org_project_mapping');
+`
+)
+
+type DropAuthViews struct {
+	dbClient *sql.DB
+}
+
+func (mig *DropAuthViews) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, dropAuthViews)
+	return err
+}
+
+func (mig *DropAuthViews) String() string {
+	return "06_drop_auth_views"
+}
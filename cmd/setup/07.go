@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addOTPCodeColumns = `
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS otp_sms_added BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS otp_email_added BOOLEAN NOT NULL DEFAULT false;
+`
+)
+
+type OTPCodeColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *OTPCodeColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOTPCodeColumns)
+	return err
+}
+
+func (mig *OTPCodeColumns) String() string {
+	return "07_otp_code_columns"
+}
@@ -0,0 +1,35 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createThrottles = `
+CREATE TABLE IF NOT EXISTS auth.throttles (
+    instance_id TEXT NOT NULL,
+    kind INT2 NOT NULL,
+    key TEXT NOT NULL,
+    failures INT8 NOT NULL,
+    last_failure TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, kind, key)
+);
+CREATE INDEX IF NOT EXISTS throttles_last_failure_idx ON auth.throttles (instance_id, last_failure);
+CREATE INDEX IF NOT EXISTS throttles_expired_idx ON auth.throttles (last_failure);
+`
+)
+
+type ThrottlesTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *ThrottlesTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createThrottles)
+	return err
+}
+
+func (mig *ThrottlesTable) String() string {
+	return "08_throttles"
+}
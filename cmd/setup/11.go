@@ -0,0 +1,41 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createDataEncryptionKeysTable = `
+CREATE TABLE IF NOT EXISTS system.data_encryption_keys (
+	id TEXT NOT NULL
+	, key TEXT NOT NULL
+
+	, PRIMARY KEY (id)
+);
+`
+	moveDataEncryptionKeys = `
+INSERT INTO system.data_encryption_keys (id, key)
+	SELECT id, key FROM system.encryption_keys WHERE id LIKE 'personal\_data\_%'
+	ON CONFLICT (id) DO NOTHING;
+DELETE FROM system.encryption_keys WHERE id LIKE 'personal\_data\_%';
+`
+)
+
+// DataEncryptionKeysTable separates the personal data keys of the users from the encryption keys,
+// which are all loaded on start
+type DataEncryptionKeysTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *DataEncryptionKeysTable) Execute(ctx context.Context) error {
+	if _, err := mig.dbClient.ExecContext(ctx, createDataEncryptionKeysTable); err != nil {
+		return err
+	}
+	_, err := mig.dbClient.ExecContext(ctx, moveDataEncryptionKeys)
+	return err
+}
+
+func (mig *DataEncryptionKeysTable) String() string {
+	return "11_data_encryption_keys_table"
+}
@@ -0,0 +1,32 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createDPoPProofs = `
+CREATE TABLE IF NOT EXISTS auth.dpop_proofs (
+    id TEXT NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS dpop_proofs_expiration_idx ON auth.dpop_proofs (expiration);
+`
+)
+
+// DPoPProofsTable stores the used DPoP proofs, so they cannot be replayed against any instance of ZITADEL
+type DPoPProofsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *DPoPProofsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDPoPProofs)
+	return err
+}
+
+func (mig *DPoPProofsTable) String() string {
+	return "12_dpop_proofs"
+}
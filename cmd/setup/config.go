@@ -54,10 +54,16 @@ func MustNewConfig(v *viper.Viper) *Config {
 }
 
 type Steps struct {
-	s1ProjectionTable   *ProjectionTable
-	s2AssetsTable       *AssetTable
-	FirstInstance       *FirstInstance
-	s4EventstoreIndexes *EventstoreIndexes
+	s1ProjectionTable     *ProjectionTable
+	s2AssetsTable         *AssetTable
+	FirstInstance         *FirstInstance
+	s4EventstoreIndexes   *EventstoreIndexes
+	s5ProjectionStates    *ProjectionStatesTable
+	s6DropAuthViews       *DropAuthViews
+	s7OTPCodeColumns      *OTPCodeColumns
+	s8ThrottlesTable      *ThrottlesTable
+	s11DataEncryptionKeys *DataEncryptionKeysTable
+	s12DPoPProofs         *DPoPProofsTable
 }
 
 type encryptionKeyConfig struct {
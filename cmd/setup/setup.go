@@ -11,6 +11,8 @@ import (
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/tls"
+	"github.com/zitadel/zitadel/internal/crypto"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/migration"
@@ -64,6 +66,9 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 
 	eventstoreClient, err := eventstore.Start(dbClient)
 	logging.OnError(err).Fatal("unable to start eventstore")
+	dataKeyStorage, err := crypto_db.NewDataKeyStorage(dbClient, masterKey)
+	logging.OnError(err).Fatal("unable to start data key storage")
+	eventstoreClient.SetPersonalDataCrypto(crypto.NewPersonalDataCrypto(dataKeyStorage, false))
 	migration.RegisterMappers(eventstoreClient)
 
 	steps.s1ProjectionTable = &ProjectionTable{dbClient: dbClient}
@@ -82,6 +87,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.FirstInstance.externalPort = config.ExternalPort
 
 	steps.s4EventstoreIndexes = &EventstoreIndexes{dbClient: dbClient, dbType: config.Database.Type()}
+	steps.s5ProjectionStates = &ProjectionStatesTable{dbClient: dbClient}
+	steps.s6DropAuthViews = &DropAuthViews{dbClient: dbClient}
+	steps.s7OTPCodeColumns = &OTPCodeColumns{dbClient: dbClient}
+	steps.s8ThrottlesTable = &ThrottlesTable{dbClient: dbClient}
+	steps.s11DataEncryptionKeys = &DataEncryptionKeysTable{dbClient: dbClient}
+	steps.s12DPoPProofs = &DPoPProofsTable{dbClient: dbClient}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -107,6 +118,18 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 3")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s4EventstoreIndexes)
 	logging.OnError(err).Fatal("unable to migrate step 4")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s5ProjectionStates)
+	logging.OnError(err).Fatal("unable to migrate step 5")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s6DropAuthViews)
+	logging.OnError(err).Fatal("unable to migrate step 6")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s7OTPCodeColumns)
+	logging.OnError(err).Fatal("unable to migrate step 7")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s8ThrottlesTable)
+	logging.OnError(err).Fatal("unable to migrate step 8")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11DataEncryptionKeys)
+	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12DPoPProofs)
+	logging.OnError(err).Fatal("unable to migrate step 12")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
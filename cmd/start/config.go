@@ -22,11 +22,13 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/expiry"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/throttle"
 )
 
 type Config struct {
@@ -36,6 +38,7 @@ type Config struct {
 	ExternalDomain    string
 	ExternalSecure    bool
 	TLS               network.TLS
+	TrustedProxies    []string
 	HTTP2HostHeader   string
 	HTTP1HostHeader   string
 	WebAuthNName      string
@@ -54,12 +57,15 @@ type Config struct {
 	InternalAuthZ     internal_authz.Config
 	SystemDefaults    systemdefaults.SystemDefaults
 	EncryptionKeys    *encryptionKeyConfig
+	PersonalData      *personalDataConfig
 	DefaultInstance   command.InstanceSetup
 	AuditLogRetention time.Duration
 	SystemAPIUsers    map[string]*internal_authz.SystemAPIUser
 	CustomerPortal    string
 	Machine           *id.Config
 	Actions           *actions.Config
+	Expiry            expiry.Config
+	Throttle          throttle.Config
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -104,3 +110,8 @@ type encryptionKeyConfig struct {
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
+
+type personalDataConfig struct {
+	// Encrypt enables the encryption of the personal data of new user events
+	Encrypt bool
+}
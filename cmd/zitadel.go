@@ -12,9 +12,12 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/cmd/admin"
+	"github.com/zitadel/zitadel/cmd/archive"
+	"github.com/zitadel/zitadel/cmd/breachedpasswords"
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/initialise"
 	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/cmd/projections"
 	"github.com/zitadel/zitadel/cmd/setup"
 	"github.com/zitadel/zitadel/cmd/start"
 )
@@ -55,6 +58,9 @@ func New(out io.Writer, in io.Reader, args []string) *cobra.Command {
 		start.NewStartFromInit(),
 		start.NewStartFromSetup(),
 		key.New(),
+		projections.New(),
+		archive.New(),
+		breachedpasswords.New(),
 	)
 
 	cmd.InitDefaultVersionFlag()
@@ -157,6 +157,7 @@ export class LoginPolicyComponent implements OnInit {
             mgmtreq.setSecondFactorsList(this.loginData.secondFactorsList);
             mgmtreq.setDisableLoginWithEmail(this.loginData.disableLoginWithEmail);
             mgmtreq.setDisableLoginWithPhone(this.loginData.disableLoginWithPhone);
+            mgmtreq.setAllowMagicLink(this.loginData.allowMagicLink);
 
             const pcl = new Duration().setSeconds((this.passwordCheckLifetime?.value ?? 0) * 60 * 60);
             mgmtreq.setPasswordCheckLifetime(pcl);
@@ -188,6 +189,7 @@ export class LoginPolicyComponent implements OnInit {
             mgmtreq.setHidePasswordReset(this.loginData.hidePasswordReset);
             mgmtreq.setDisableLoginWithEmail(this.loginData.disableLoginWithEmail);
             mgmtreq.setDisableLoginWithPhone(this.loginData.disableLoginWithPhone);
+            mgmtreq.setAllowMagicLink(this.loginData.allowMagicLink);
 
             const pcl = new Duration().setSeconds((this.passwordCheckLifetime?.value ?? 0) * 60 * 60);
             mgmtreq.setPasswordCheckLifetime(pcl);
@@ -220,6 +222,7 @@ export class LoginPolicyComponent implements OnInit {
           adminreq.setHidePasswordReset(this.loginData.hidePasswordReset);
           adminreq.setDisableLoginWithEmail(this.loginData.disableLoginWithEmail);
           adminreq.setDisableLoginWithPhone(this.loginData.disableLoginWithPhone);
+          adminreq.setAllowMagicLink(this.loginData.allowMagicLink);
 
           const admin_pcl = new Duration().setSeconds((this.passwordCheckLifetime?.value ?? 0) * 60 * 60);
           adminreq.setPasswordCheckLifetime(admin_pcl);
@@ -202,6 +202,7 @@ module.exports = {
             "apis/proto/management",
             "apis/proto/admin",
             "apis/proto/system",
+            "apis/proto/session",
             "apis/proto/instance",
             "apis/proto/org",
             "apis/proto/user",
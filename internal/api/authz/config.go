@@ -2,6 +2,9 @@ package authz
 
 type Config struct {
 	RolePermissionMappings []RoleMapping
+	// ClientCertificateHeader is the header a TLS terminating proxy forwards the client certificate in (url escaped PEM).
+	// It is used to verify certificate-bound access tokens (RFC 8705) if the connection is not terminated by ZITADEL itself
+	ClientCertificateHeader string
 }
 
 type RoleMapping struct {
@@ -35,24 +35,48 @@ func getUserMethodPermissions(ctx context.Context, t *TokenVerifier, requiredPer
 			return nil, nil, nil
 		}
 	}
-	requestedPermissions, allPermissions = mapMembershipsToPermissions(requiredPerm, memberships, authConfig)
+	customRolePermissions, err := t.CustomRolePermissions(ctx, unmappedRoles(memberships, authConfig)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	requestedPermissions, allPermissions = mapMembershipsToPermissions(requiredPerm, memberships, authConfig, customRolePermissions)
 	return requestedPermissions, allPermissions, nil
 }
 
-func mapMembershipsToPermissions(requiredPerm string, memberships []*Membership, authConfig Config) (requestPermissions, allPermissions []string) {
+// unmappedRoles returns the roles of the memberships which are not defined in the static role mapping
+// they might be custom roles of the instance
+func unmappedRoles(memberships []*Membership, authConfig Config) []string {
+	roles := make([]string, 0)
+	for _, membership := range memberships {
+		if membership.MemberType != MemberTypeIam && membership.MemberType != MemberTypeOrganisation {
+			continue
+		}
+		for _, role := range membership.Roles {
+			if authConfig.getPermissionsFromRole(role) == nil && !ExistsPerm(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+func mapMembershipsToPermissions(requiredPerm string, memberships []*Membership, authConfig Config, customRolePermissions map[string][]string) (requestPermissions, allPermissions []string) {
 	requestPermissions = make([]string, 0)
 	allPermissions = make([]string, 0)
 	for _, membership := range memberships {
-		requestPermissions, allPermissions = mapMembershipToPerm(requiredPerm, membership, authConfig, requestPermissions, allPermissions)
+		requestPermissions, allPermissions = mapMembershipToPerm(requiredPerm, membership, authConfig, customRolePermissions, requestPermissions, allPermissions)
 	}
 
 	return requestPermissions, allPermissions
 }
 
-func mapMembershipToPerm(requiredPerm string, membership *Membership, authConfig Config, requestPermissions, allPermissions []string) ([]string, []string) {
+func mapMembershipToPerm(requiredPerm string, membership *Membership, authConfig Config, customRolePermissions map[string][]string, requestPermissions, allPermissions []string) ([]string, []string) {
 	roleNames, roleContextID := roleWithContext(membership)
 	for _, roleName := range roleNames {
 		perms := authConfig.getPermissionsFromRole(roleName)
+		if perms == nil {
+			perms = customRolePermissions[roleName]
+		}
 
 		for _, p := range perms {
 			permWithCtx := addRoleContextIDToPerm(p, roleContextID)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/api/pop"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -12,16 +13,28 @@ func getTestCtx(userID, orgID string) context.Context {
 }
 
 type testVerifier struct {
-	memberships []*Membership
+	memberships  []*Membership
+	customRoles  map[string][]string
+	confirmation *pop.Confirmation
 }
 
-func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
-	return "userID", "agentID", "clientID", "de", "orgID", nil
+func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, *pop.Confirmation, error) {
+	return "userID", "agentID", "clientID", "de", "orgID", v.confirmation, nil
 }
 func (v *testVerifier) SearchMyMemberships(ctx context.Context) ([]*Membership, error) {
 	return v.memberships, nil
 }
 
+func (v *testVerifier) CustomRolePermissions(ctx context.Context, roles ...string) (map[string][]string, error) {
+	permissions := make(map[string][]string)
+	for _, role := range roles {
+		if perms, ok := v.customRoles[role]; ok {
+			permissions[role] = perms
+		}
+	}
+	return permissions, nil
+}
+
 func (v *testVerifier) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (string, []string, error) {
 	return "", nil, nil
 }
@@ -68,7 +81,7 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 					{
 						Roles: []string{"ORG_OWNER"},
 					},
-				}}, "", nil),
+				}}, "", nil, nil),
 				requiredPerm: "project.read",
 				authConfig: Config{
 					RolePermissionMappings: []RoleMapping{
@@ -91,7 +104,7 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 			name: "No Grants",
 			args: args{
 				ctxData:      CtxData{},
-				verifier:     Start(&testVerifier{memberships: []*Membership{}}, "", nil),
+				verifier:     Start(&testVerifier{memberships: []*Membership{}}, "", nil, nil),
 				requiredPerm: "project.read",
 				authConfig: Config{
 					RolePermissionMappings: []RoleMapping{
@@ -119,7 +132,7 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 						MemberType:  MemberTypeIam,
 						Roles:       []string{"IAM_OWNER"},
 					},
-				}}, "", nil),
+				}}, "", nil, nil),
 				requiredPerm: "project.read",
 				authConfig: Config{
 					RolePermissionMappings: []RoleMapping{
@@ -136,6 +149,35 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 			},
 			result: []string{"project.read"},
 		},
+		{
+			name: "Get Permissions of custom role",
+			args: args{
+				ctxData: CtxData{UserID: "userID", OrgID: "orgID"},
+				verifier: Start(&testVerifier{
+					memberships: []*Membership{
+						{
+							AggregateID: "orgID",
+							ObjectID:    "orgID",
+							MemberType:  MemberTypeOrganisation,
+							Roles:       []string{"ORG_SUPPORT"},
+						},
+					},
+					customRoles: map[string][]string{
+						"ORG_SUPPORT": {"user.read"},
+					},
+				}, "", nil, nil),
+				requiredPerm: "user.read",
+				authConfig: Config{
+					RolePermissionMappings: []RoleMapping{
+						{
+							Role:        "ORG_OWNER",
+							Permissions: []string{"org.read", "user.read"},
+						},
+					},
+				},
+			},
+			result: []string{"user.read"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -158,9 +200,10 @@ func Test_GetUserMethodPermissions(t *testing.T) {
 
 func Test_MapMembershipToPermissions(t *testing.T) {
 	type args struct {
-		requiredPerm string
-		membership   []*Membership
-		authConfig   Config
+		requiredPerm          string
+		membership            []*Membership
+		authConfig            Config
+		customRolePermissions map[string][]string
 	}
 	tests := []struct {
 		name         string
@@ -292,10 +335,37 @@ func Test_MapMembershipToPermissions(t *testing.T) {
 			requestPerms: []string{"project.read", "project.read:1"},
 			allPerms:     []string{"org.read", "project.read", "project.read:1"},
 		},
+		{
+			name: "Multiple Roles, default and custom",
+			args: args{
+				requiredPerm: "user.write",
+				membership: []*Membership{
+					{
+						AggregateID: "1",
+						ObjectID:    "1",
+						MemberType:  MemberTypeOrganisation,
+						Roles:       []string{"ORG_USER_MANAGER", "ORG_SUPPORT"},
+					},
+				},
+				authConfig: Config{
+					RolePermissionMappings: []RoleMapping{
+						{
+							Role:        "ORG_USER_MANAGER",
+							Permissions: []string{"org.read"},
+						},
+					},
+				},
+				customRolePermissions: map[string][]string{
+					"ORG_SUPPORT": {"org.read", "user.write"},
+				},
+			},
+			requestPerms: []string{"user.write"},
+			allPerms:     []string{"org.read", "user.write"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			requestPerms, allPerms := mapMembershipsToPermissions(tt.args.requiredPerm, tt.args.membership, tt.args.authConfig)
+			requestPerms, allPerms := mapMembershipsToPermissions(tt.args.requiredPerm, tt.args.membership, tt.args.authConfig, tt.args.customRolePermissions)
 			if !equalStringArray(requestPerms, tt.requestPerms) {
 				t.Errorf("got wrong requestPerms, expecting: %v, actual: %v ", tt.requestPerms, requestPerms)
 			}
@@ -435,7 +505,7 @@ func Test_MapMembershipToPerm(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			requestPerms, allPerms := mapMembershipToPerm(tt.args.requiredPerm, tt.args.membership, tt.args.authConfig, tt.args.requestPerms, tt.args.allPerms)
+			requestPerms, allPerms := mapMembershipToPerm(tt.args.requiredPerm, tt.args.membership, tt.args.authConfig, nil, tt.args.requestPerms, tt.args.allPerms)
 			if !equalStringArray(requestPerms, tt.requestPerms) {
 				t.Errorf("got wrong requestPerms, expecting: %v, actual: %v ", tt.requestPerms, requestPerms)
 			}
@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"gopkg.in/square/go-jose.v2"
 
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/crypto"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -25,19 +26,22 @@ type TokenVerifier struct {
 	clients          sync.Map
 	authMethods      MethodMapping
 	systemJWTProfile op.JWTProfileVerifier
+	usedProofs       pop.UsedProofs
 }
 
 type authZRepo interface {
-	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, confirmation *pop.Confirmation, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context) ([]*Membership, error)
+	CustomRolePermissions(ctx context.Context, roles ...string) (map[string][]string, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	ExistsOrg(ctx context.Context, orgID string) error
 }
 
-func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser) (v *TokenVerifier) {
+func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser, usedProofs pop.UsedProofs) (v *TokenVerifier) {
 	return &TokenVerifier{
-		authZRepo: authZRepo,
+		authZRepo:  authZRepo,
+		usedProofs: usedProofs,
 		systemJWTProfile: op.NewJWTProfileVerifier(
 			&systemJWTStorage{
 				keys:       keys,
@@ -58,8 +62,15 @@ func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, met
 		}
 		return userID, "", "", "", "", nil
 	}
-	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
-	return userID, clientID, agentID, prefLang, resourceOwner, err
+	userID, agentID, clientID, prefLang, resourceOwner, confirmation, err := v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	// sender-constrained tokens are only accepted along with the proof of possession of the bound key
+	if err = confirmation.Verify(ctx, v.usedProofs, pop.ProofFromContext(ctx)); err != nil {
+		return "", "", "", "", "", err
+	}
+	return userID, clientID, agentID, prefLang, resourceOwner, nil
 }
 
 func (v *TokenVerifier) verifySystemToken(ctx context.Context, token string) (string, error) {
@@ -133,6 +144,12 @@ func (v *TokenVerifier) SearchMyMemberships(ctx context.Context) (_ []*Membershi
 	return v.authZRepo.SearchMyMemberships(ctx)
 }
 
+func (v *TokenVerifier) CustomRolePermissions(ctx context.Context, roles ...string) (_ map[string][]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return v.authZRepo.CustomRolePermissions(ctx, roles...)
+}
+
 func (v *TokenVerifier) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (_ string, _ []string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -155,9 +172,15 @@ func verifyAccessToken(ctx context.Context, token string, t *TokenVerifier, meth
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	parts := strings.Split(token, BearerPrefix)
-	if len(parts) != 2 {
+	scheme, accessToken, ok := pop.SplitAuthorization(token)
+	if !ok {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
 	}
-	return t.VerifyAccessToken(ctx, parts[1], method)
+	proof := new(pop.Proof)
+	if presented := pop.ProofFromContext(ctx); presented != nil {
+		*proof = *presented
+	}
+	proof.Scheme = scheme
+	proof.AccessToken = accessToken
+	return t.VerifyAccessToken(pop.WithProof(ctx, proof), accessToken, method)
 }
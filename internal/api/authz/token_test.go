@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -55,6 +56,42 @@ func Test_VerifyAccessToken(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "DPoP scheme for unbound token",
+			args: args{
+				ctx:   context.Background(),
+				token: "DPoP AUTH",
+				verifier: &TokenVerifier{
+					authZRepo: &testVerifier{memberships: []*Membership{}},
+				},
+				method: "/service/method",
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate bound token without certificate",
+			args: args{
+				ctx:   context.Background(),
+				token: "Bearer AUTH",
+				verifier: &TokenVerifier{
+					authZRepo: &testVerifier{confirmation: &pop.Confirmation{X5TS256: "thumbprint"}},
+				},
+				method: "/service/method",
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate bound token with certificate",
+			args: args{
+				ctx:   pop.WithProof(context.Background(), &pop.Proof{CertificateThumbprint: "thumbprint"}),
+				token: "Bearer AUTH",
+				verifier: &TokenVerifier{
+					authZRepo: &testVerifier{confirmation: &pop.Confirmation{X5TS256: "thumbprint"}},
+				},
+				method: "/service/method",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -17,6 +17,8 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeExternalAuthentication
 	case domain.FlowTypeCustomiseToken.ID():
 		return domain.FlowTypeCustomiseToken
+	case domain.FlowTypeSecurityEvent.ID():
+		return domain.FlowTypeSecurityEvent
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -45,6 +47,8 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypeRefreshTokenReused.ID():
+		return domain.TriggerTypeRefreshTokenReused
 	default:
 		return domain.TriggerTypeUnspecified
 	}
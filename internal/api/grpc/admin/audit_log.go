@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"context"
+
+	change_grpc "github.com/zitadel/zitadel/internal/api/grpc/change"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListAuditLog(ctx context.Context, req *admin_pb.ListAuditLogRequest) (*admin_pb.ListAuditLogResponse, error) {
+	queries := change_grpc.AuditLogQueryToQuery(req.Query)
+	queries.ResourceOwner = req.OrgId
+	auditLog, err := s.query.SearchAuditLog(ctx, queries, s.auditLogRetention)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListAuditLogResponse{
+		Result: change_grpc.AuditLogEventsToPb(auditLog.Events),
+	}, nil
+}
+
+func (s *Server) ExportAuditLog(ctx context.Context, req *admin_pb.ExportAuditLogRequest) (*admin_pb.ExportAuditLogResponse, error) {
+	queries := change_grpc.AuditLogQueryToQuery(req.Query)
+	queries.ResourceOwner = req.OrgId
+	auditLog, err := s.query.SearchAuditLog(ctx, queries, s.auditLogRetention)
+	if err != nil {
+		return nil, err
+	}
+	data, contentType, err := change_grpc.ExportAuditLog(auditLog, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ExportAuditLogResponse{
+		Data:        data,
+		ContentType: contentType,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetCaptchaPolicy(ctx context.Context, req *admin_pb.GetCaptchaPolicyRequest) (*admin_pb.GetCaptchaPolicyResponse, error) {
+	policy, err := s.query.DefaultCaptchaPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCaptchaPolicyResponse{Policy: policy_grpc.ModelCaptchaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCaptchaPolicy(ctx context.Context, req *admin_pb.AddCaptchaPolicyRequest) (*admin_pb.AddCaptchaPolicyResponse, error) {
+	policy, err := s.command.AddDefaultCaptchaPolicy(ctx, AddCaptchaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddCaptchaPolicyResponse{
+		Details: object.AddToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCaptchaPolicy(ctx context.Context, req *admin_pb.UpdateCaptchaPolicyRequest) (*admin_pb.UpdateCaptchaPolicyResponse, error) {
+	policy, err := s.command.ChangeDefaultCaptchaPolicy(ctx, UpdateCaptchaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateCaptchaPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func AddCaptchaPolicyToDomain(p *admin.AddCaptchaPolicyRequest) *domain.CaptchaPolicy {
+	return &domain.CaptchaPolicy{
+		ProviderType:           policy_grpc.CaptchaProviderTypeToDomain(p.ProviderType),
+		VerifyURL:              p.VerifyUrl,
+		SiteKey:                p.SiteKey,
+		SecretString:           p.Secret,
+		RequireOnRegistration:  p.RequireOnRegistration,
+		RequireOnPasswordReset: p.RequireOnPasswordReset,
+		FailedLoginsThreshold:  uint64(p.FailedLoginsThreshold),
+	}
+}
+
+func UpdateCaptchaPolicyToDomain(p *admin.UpdateCaptchaPolicyRequest) *domain.CaptchaPolicy {
+	return &domain.CaptchaPolicy{
+		ProviderType:           policy_grpc.CaptchaProviderTypeToDomain(p.ProviderType),
+		VerifyURL:              p.VerifyUrl,
+		SiteKey:                p.SiteKey,
+		SecretString:           p.Secret,
+		RequireOnRegistration:  p.RequireOnRegistration,
+		RequireOnPasswordReset: p.RequireOnPasswordReset,
+		FailedLoginsThreshold:  uint64(p.FailedLoginsThreshold),
+	}
+}
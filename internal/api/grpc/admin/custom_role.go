@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListCustomRoles(ctx context.Context, req *admin_pb.ListCustomRolesRequest) (*admin_pb.ListCustomRolesResponse, error) {
+	queries, err := ListCustomRolesRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.query.SearchCustomRoles(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListCustomRolesResponse{
+		Result:  CustomRolesToPb(roles.CustomRoles),
+		Details: object.ToListDetails(roles.Count, roles.Sequence, roles.Timestamp),
+	}, nil
+}
+
+func (s *Server) GetCustomRole(ctx context.Context, req *admin_pb.GetCustomRoleRequest) (*admin_pb.GetCustomRoleResponse, error) {
+	role, err := s.query.CustomRoleByKey(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomRoleResponse{
+		Role: CustomRoleToPb(role),
+	}, nil
+}
+
+func (s *Server) AddCustomRole(ctx context.Context, req *admin_pb.AddCustomRoleRequest) (*admin_pb.AddCustomRoleResponse, error) {
+	details, err := s.command.AddCustomRole(ctx, AddCustomRoleRequestToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddCustomRoleResponse{
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateCustomRole(ctx context.Context, req *admin_pb.UpdateCustomRoleRequest) (*admin_pb.UpdateCustomRoleResponse, error) {
+	details, err := s.command.ChangeCustomRole(ctx, UpdateCustomRoleRequestToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateCustomRoleResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveCustomRole(ctx context.Context, req *admin_pb.RemoveCustomRoleRequest) (*admin_pb.RemoveCustomRoleResponse, error) {
+	details, err := s.command.RemoveCustomRole(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveCustomRoleResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func AddCustomRoleRequestToDomain(req *admin_pb.AddCustomRoleRequest) *domain.CustomRole {
+	return &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	}
+}
+
+func UpdateCustomRoleRequestToDomain(req *admin_pb.UpdateCustomRoleRequest) *domain.CustomRole {
+	return &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	}
+}
+
+func ListCustomRolesRequestToQuery(req *admin_pb.ListCustomRolesRequest) (*query.CustomRoleSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := customRoleQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.CustomRoleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func customRoleQueriesToModel(queries []*admin_pb.CustomRoleQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = customRoleQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func customRoleQueryToModel(roleQuery *admin_pb.CustomRoleQuery) (query.SearchQuery, error) {
+	switch q := roleQuery.Query.(type) {
+	case *admin_pb.CustomRoleQuery_KeyQuery:
+		return query.NewCustomRoleKeySearchQuery(object.TextMethodToQuery(q.KeyQuery.Method), q.KeyQuery.Key)
+	case *admin_pb.CustomRoleQuery_DisplayNameQuery:
+		return query.NewCustomRoleDisplayNameSearchQuery(object.TextMethodToQuery(q.DisplayNameQuery.Method), q.DisplayNameQuery.DisplayName)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "ADMIN-Rk8vo", "List.Query.Invalid")
+	}
+}
+
+func CustomRolesToPb(roles []*query.CustomRole) []*admin_pb.CustomRole {
+	r := make([]*admin_pb.CustomRole, len(roles))
+	for i, role := range roles {
+		r[i] = CustomRoleToPb(role)
+	}
+	return r
+}
+
+func CustomRoleToPb(role *query.CustomRole) *admin_pb.CustomRole {
+	return &admin_pb.CustomRole{
+		Key:         role.Key,
+		DisplayName: role.DisplayName,
+		Permissions: role.Permissions,
+		Details:     object.ToViewDetailsPb(role.Sequence, role.CreationDate, role.ChangeDate, role.ResourceOwner),
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	authn_grpc "github.com/zitadel/zitadel/internal/api/grpc/authn"
+	project_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
 	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errors "github.com/zitadel/zitadel/internal/errors"
@@ -501,11 +502,12 @@ func (s *Server) getPasswordComplexityPolicy(ctx context.Context, orgID string)
 	}
 	if !queriedPasswordComplexity.IsDefault {
 		return &management_pb.AddCustomPasswordComplexityPolicyRequest{
-			MinLength:    queriedPasswordComplexity.MinLength,
-			HasUppercase: queriedPasswordComplexity.HasUppercase,
-			HasLowercase: queriedPasswordComplexity.HasLowercase,
-			HasNumber:    queriedPasswordComplexity.HasNumber,
-			HasSymbol:    queriedPasswordComplexity.HasSymbol,
+			MinLength:      queriedPasswordComplexity.MinLength,
+			HasUppercase:   queriedPasswordComplexity.HasUppercase,
+			HasLowercase:   queriedPasswordComplexity.HasLowercase,
+			HasNumber:      queriedPasswordComplexity.HasNumber,
+			HasSymbol:      queriedPasswordComplexity.HasSymbol,
+			RejectBreached: queriedPasswordComplexity.RejectBreached,
 		}, nil
 	}
 	return nil, nil
@@ -783,22 +785,27 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 				oidcApps = append(oidcApps, &v1_pb.DataOIDCApplication{
 					AppId: app.ID,
 					App: &management_pb.AddOIDCAppRequest{
-						ProjectId:                app.ProjectID,
-						Name:                     app.Name,
-						RedirectUris:             app.OIDCConfig.RedirectURIs,
-						ResponseTypes:            responseTypes,
-						GrantTypes:               grantTypes,
-						AppType:                  app_pb.OIDCAppType(app.OIDCConfig.AppType),
-						AuthMethodType:           app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
-						PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
-						Version:                  app_pb.OIDCVersion(app.OIDCConfig.Version),
-						DevMode:                  app.OIDCConfig.IsDevMode,
-						AccessTokenType:          app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
-						AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
-						IdTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
-						IdTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
-						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
-						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
+						ProjectId:                          app.ProjectID,
+						Name:                               app.Name,
+						RedirectUris:                       app.OIDCConfig.RedirectURIs,
+						ResponseTypes:                      responseTypes,
+						GrantTypes:                         grantTypes,
+						AppType:                            app_pb.OIDCAppType(app.OIDCConfig.AppType),
+						AuthMethodType:                     app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
+						PostLogoutRedirectUris:             app.OIDCConfig.PostLogoutRedirectURIs,
+						Version:                            app_pb.OIDCVersion(app.OIDCConfig.Version),
+						DevMode:                            app.OIDCConfig.IsDevMode,
+						AccessTokenType:                    app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
+						AccessTokenRoleAssertion:           app.OIDCConfig.AssertAccessTokenRole,
+						IdTokenRoleAssertion:               app.OIDCConfig.AssertIDTokenRole,
+						IdTokenUserinfoAssertion:           app.OIDCConfig.AssertIDTokenUserinfo,
+						ClockSkew:                          durationpb.New(app.OIDCConfig.ClockSkew),
+						AdditionalOrigins:                  app.OIDCConfig.AdditionalOrigins,
+						RequirePushedAuthorizationRequests: app.OIDCConfig.RequirePushedAuthorizationRequests,
+						Jwks:                               app.OIDCConfig.JWKS,
+						BackChannelLogoutUri:               app.OIDCConfig.BackChannelLogoutURI,
+						FrontChannelLogoutUri:              app.OIDCConfig.FrontChannelLogoutURI,
+						TokenSettings:                      project_grpc.TokenSettingsToPb(app.OIDCConfig.AppTokenSettings),
 					},
 				})
 			}
@@ -809,6 +816,7 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 						ProjectId:      app.ProjectID,
 						Name:           app.Name,
 						AuthMethodType: app_pb.APIAuthMethodType(app.APIConfig.AuthMethodType),
+						TokenSettings:  project_grpc.TokenSettingsToPb(app.APIConfig.AppTokenSettings),
 					},
 				})
 			}
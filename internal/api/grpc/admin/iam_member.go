@@ -10,7 +10,10 @@ import (
 )
 
 func (s *Server) ListIAMMemberRoles(ctx context.Context, req *admin_pb.ListIAMMemberRolesRequest) (*admin_pb.ListIAMMemberRolesResponse, error) {
-	roles := s.query.GetIAMMemberRoles()
+	roles, err := s.query.GetIAMMemberRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return &admin_pb.ListIAMMemberRolesResponse{
 		Roles:   roles,
 		Details: object.ToListDetails(uint64(len(roles)), 0, time.Now()),
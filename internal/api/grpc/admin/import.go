@@ -818,7 +818,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		if org.OrgMembers != nil {
 			for _, member := range org.GetOrgMembers() {
 				logging.Debugf("import orgmember: %s", member.GetUserId())
-				_, err := s.command.AddOrgMember(ctx, org.GetOrgId(), member.GetUserId(), member.GetRoles()...)
+				_, err := s.command.AddOrgMember(ctx, org.GetOrgId(), member.GetUserId(), domain.Validity{}, member.GetRoles()...)
 				if err != nil {
 					errors = append(errors, &admin_pb.ImportDataError{Type: "org_member", Id: org.GetOrgId() + "_" + member.GetUserId(), Message: err.Error()})
 					if isCtxTimeout(ctx) {
@@ -20,6 +20,7 @@ func updateLoginPolicyToCommand(p *admin_pb.UpdateLoginPolicyRequest) *command.C
 		AllowDomainDiscovery:       p.AllowDomainDiscovery,
 		DisableLoginWithEmail:      p.DisableLoginWithEmail,
 		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		AllowMagicLink:             p.AllowMagicLink,
 		DefaultRedirectURI:         p.DefaultRedirectUri,
 		PasswordCheckLifetime:      p.PasswordCheckLifetime.AsDuration(),
 		ExternalLoginCheckLifetime: p.ExternalLoginCheckLifetime.AsDuration(),
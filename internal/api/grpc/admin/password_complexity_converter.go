@@ -7,10 +7,11 @@ import (
 
 func UpdatePasswordComplexityPolicyToDomain(req *admin_pb.UpdatePasswordComplexityPolicyRequest) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
-		MinLength:    uint64(req.MinLength),
-		HasLowercase: req.HasLowercase,
-		HasUppercase: req.HasUppercase,
-		HasNumber:    req.HasNumber,
-		HasSymbol:    req.HasSymbol,
+		MinLength:      uint64(req.MinLength),
+		HasLowercase:   req.HasLowercase,
+		HasUppercase:   req.HasUppercase,
+		HasNumber:      req.HasNumber,
+		HasSymbol:      req.HasSymbol,
+		RejectBreached: req.RejectBreached,
 	}
 }
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetRiskPolicy(ctx context.Context, req *admin_pb.GetRiskPolicyRequest) (*admin_pb.GetRiskPolicyResponse, error) {
+	policy, err := s.query.DefaultRiskPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetRiskPolicyResponse{Policy: policy_grpc.ModelRiskPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddRiskPolicy(ctx context.Context, req *admin_pb.AddRiskPolicyRequest) (*admin_pb.AddRiskPolicyResponse, error) {
+	policy, err := s.command.AddDefaultRiskPolicy(ctx, AddRiskPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddRiskPolicyResponse{
+		Details: object.AddToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateRiskPolicy(ctx context.Context, req *admin_pb.UpdateRiskPolicyRequest) (*admin_pb.UpdateRiskPolicyResponse, error) {
+	policy, err := s.command.ChangeDefaultRiskPolicy(ctx, UpdateRiskPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateRiskPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
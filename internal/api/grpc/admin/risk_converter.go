@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func AddRiskPolicyToDomain(p *admin.AddRiskPolicyRequest) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		MFAOnNewDevice:          p.MfaOnNewDevice,
+		MFAOnNewIPRange:         p.MfaOnNewIpRange,
+		ImpossibleTravelWindow:  p.ImpossibleTravelWindow.AsDuration(),
+		FailedAttemptsThreshold: uint64(p.FailedAttemptsThreshold),
+	}
+}
+
+func UpdateRiskPolicyToDomain(p *admin.UpdateRiskPolicyRequest) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		MFAOnNewDevice:          p.MfaOnNewDevice,
+		MFAOnNewIPRange:         p.MfaOnNewIpRange,
+		ImpossibleTravelWindow:  p.ImpossibleTravelWindow.AsDuration(),
+		FailedAttemptsThreshold: uint64(p.FailedAttemptsThreshold),
+	}
+}
@@ -2,6 +2,8 @@ package admin
 
 import (
 	"context"
+	"time"
+
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/admin/repository"
@@ -13,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/throttle"
 	"github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -24,13 +27,15 @@ var _ admin.AdminServiceServer = (*Server)(nil)
 
 type Server struct {
 	admin.UnimplementedAdminServiceServer
-	database        string
-	command         *command.Commands
-	query           *query.Queries
-	administrator   repository.AdministratorRepository
-	assetsAPIDomain func(context.Context) string
-	userCodeAlg     crypto.EncryptionAlgorithm
-	passwordHashAlg crypto.HashAlgorithm
+	database          string
+	command           *command.Commands
+	query             *query.Queries
+	administrator     repository.AdministratorRepository
+	assetsAPIDomain   func(context.Context) string
+	userCodeAlg       crypto.EncryptionAlgorithm
+	passwordHashAlg   crypto.HashAlgorithm
+	auditLogRetention time.Duration
+	throttler         *throttle.Throttler
 }
 
 type Config struct {
@@ -45,15 +50,19 @@ func CreateServer(
 	repo repository.Repository,
 	externalSecure bool,
 	userCodeAlg crypto.EncryptionAlgorithm,
+	auditLogRetention time.Duration,
+	throttler *throttle.Throttler,
 ) *Server {
 	return &Server{
-		database:        database,
-		command:         command,
-		query:           query,
-		administrator:   repo,
-		assetsAPIDomain: assets.AssetAPI(externalSecure),
-		userCodeAlg:     userCodeAlg,
-		passwordHashAlg: crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
+		database:          database,
+		command:           command,
+		query:             query,
+		administrator:     repo,
+		assetsAPIDomain:   assets.AssetAPI(externalSecure),
+		userCodeAlg:       userCodeAlg,
+		passwordHashAlg:   crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
+		auditLogRetention: auditLogRetention,
+		throttler:         throttler,
 	}
 }
 
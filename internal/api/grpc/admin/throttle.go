@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"context"
+
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListThrottles(ctx context.Context, req *admin_pb.ListThrottlesRequest) (*admin_pb.ListThrottlesResponse, error) {
+	throttles, err := s.throttler.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListThrottlesResponse{Result: ThrottlesToPb(throttles)}, nil
+}
+
+func (s *Server) RemoveThrottle(ctx context.Context, req *admin_pb.RemoveThrottleRequest) (*admin_pb.RemoveThrottleResponse, error) {
+	if err := s.throttler.Remove(ctx, RemoveThrottleRequestToSubject(req)); err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveThrottleResponse{}, nil
+}
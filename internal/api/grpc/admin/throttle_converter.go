@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/throttle"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func ThrottlesToPb(throttles []*domain.Throttle) []*admin_pb.Throttle {
+	result := make([]*admin_pb.Throttle, len(throttles))
+	for i, t := range throttles {
+		result[i] = ThrottleToPb(t)
+	}
+	return result
+}
+
+func ThrottleToPb(t *domain.Throttle) *admin_pb.Throttle {
+	return &admin_pb.Throttle{
+		Kind:         ThrottleKindToPb(t.Kind),
+		Key:          t.Key,
+		Failures:     t.Failures,
+		LastFailure:  object.TimeToPb(t.LastFailure),
+		DelayedUntil: object.TimeToPb(t.DelayedUntil),
+		LockedUntil:  object.TimeToPb(t.LockedUntil),
+	}
+}
+
+func ThrottleKindToPb(kind domain.ThrottleKind) admin_pb.ThrottleKind {
+	switch kind {
+	case domain.ThrottleKindIP:
+		return admin_pb.ThrottleKind_THROTTLE_KIND_IP
+	case domain.ThrottleKindUserAgent:
+		return admin_pb.ThrottleKind_THROTTLE_KIND_USER_AGENT
+	case domain.ThrottleKindUser:
+		return admin_pb.ThrottleKind_THROTTLE_KIND_USER
+	case domain.ThrottleKindUserIP:
+		return admin_pb.ThrottleKind_THROTTLE_KIND_USER_IP
+	default:
+		return admin_pb.ThrottleKind_THROTTLE_KIND_UNSPECIFIED
+	}
+}
+
+func ThrottleKindToDomain(kind admin_pb.ThrottleKind) domain.ThrottleKind {
+	switch kind {
+	case admin_pb.ThrottleKind_THROTTLE_KIND_IP:
+		return domain.ThrottleKindIP
+	case admin_pb.ThrottleKind_THROTTLE_KIND_USER_AGENT:
+		return domain.ThrottleKindUserAgent
+	case admin_pb.ThrottleKind_THROTTLE_KIND_USER:
+		return domain.ThrottleKindUser
+	case admin_pb.ThrottleKind_THROTTLE_KIND_USER_IP:
+		return domain.ThrottleKindUserIP
+	default:
+		return domain.ThrottleKindUnspecified
+	}
+}
+
+func RemoveThrottleRequestToSubject(req *admin_pb.RemoveThrottleRequest) throttle.Subject {
+	return throttle.Subject{
+		Kind: ThrottleKindToDomain(req.Kind),
+		Key:  req.Key,
+	}
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
+)
+
+func (s *Server) RequestMyProjectAccess(ctx context.Context, req *auth_pb.RequestMyProjectAccessRequest) (*auth_pb.RequestMyProjectAccessResponse, error) {
+	request, err := s.command.RequestProjectAccess(ctx, &domain.AccessRequest{
+		UserID:    authz.GetCtxData(ctx).UserID,
+		ProjectID: req.ProjectId,
+		RoleKeys:  req.RoleKeys,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.RequestMyProjectAccessResponse{
+		Id: request.AggregateID,
+		Details: object.AddToDetailsPb(
+			request.Sequence,
+			request.ChangeDate,
+			request.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ListMyAccessRequests(ctx context.Context, req *auth_pb.ListMyAccessRequestsRequest) (*auth_pb.ListMyAccessRequestsResponse, error) {
+	queries, err := ListMyAccessRequestsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchAccessRequests(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.ListMyAccessRequestsResponse{
+		Result:  user_grpc.AccessRequestsToPb(res.AccessRequests),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func ListMyAccessRequestsRequestToQuery(ctx context.Context, req *auth_pb.ListMyAccessRequestsRequest) (*query.AccessRequestSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	userQuery, err := query.NewAccessRequestUserIDSearchQuery(authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.AccessRequestSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			userQuery,
+		},
+	}, nil
+}
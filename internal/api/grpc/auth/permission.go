@@ -2,10 +2,12 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
 )
@@ -34,15 +36,59 @@ func (s *Server) ListMyProjectPermissions(ctx context.Context, _ *auth_pb.ListMy
 	if err != nil {
 		return nil, err
 	}
-	userGrant, err := s.query.UserGrant(ctx, true, userGrantOrgID, userGrantProjectID, userGrantUserID)
+	activeQueries, err := query.NewUserGrantActiveQueries(time.Now())
 	if err != nil {
 		return nil, err
 	}
+	userGrant, err := s.query.UserGrant(ctx, true, append([]query.SearchQuery{userGrantOrgID, userGrantProjectID, userGrantUserID}, activeQueries...)...)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	groupGrantOrgID, err := query.NewGroupGrantResourceOwnerSearchQuery(ctxData.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	groupGrantProjectID, err := query.NewGroupGrantProjectIDSearchQuery(ctxData.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	groupGrants, err := s.query.UserGroupGrants(ctx, ctxData.UserID, groupGrantOrgID, groupGrantProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if userGrant == nil && len(groupGrants.UserGrants) == 0 {
+		return nil, errors.ThrowNotFound(nil, "AUTH-Gr3pm", "Errors.UserGrant.NotFound")
+	}
+	roles := make([]string, 0)
+	if userGrant != nil {
+		roles = append(roles, userGrant.Roles...)
+	}
+	for _, grant := range groupGrants.UserGrants {
+		roles = appendMissingRoles(roles, grant.Roles)
+	}
 	return &auth_pb.ListMyProjectPermissionsResponse{
-		Result: userGrant.Roles,
+		Result: roles,
 	}, nil
 }
 
+func appendMissingRoles(roles, additional []string) []string {
+	for _, role := range additional {
+		if !containsRole(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Server) ListMyMemberships(ctx context.Context, req *auth_pb.ListMyMembershipsRequest) (*auth_pb.ListMyMembershipsResponse, error) {
 	request, err := ListMyMembershipsRequestToModel(ctx, req)
 	if err != nil {
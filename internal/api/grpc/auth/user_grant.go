@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -9,21 +10,23 @@ import (
 	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
 )
 
-func ListMyUserGrantsRequestToQuery(ctx context.Context, req *auth_pb.ListMyUserGrantsRequest) (*query.UserGrantsQueries, error) {
+func ListMyUserGrantsRequestToQuery(ctx context.Context, req *auth_pb.ListMyUserGrantsRequest) (*query.UserAndGroupGrantsQueries, error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
-	userGrantUserID, err := query.NewUserGrantUserIDSearchQuery(authz.GetCtxData(ctx).UserID)
+	userGrantUserID, err := query.NewUserAndGroupGrantUserIDQuery(authz.GetCtxData(ctx).UserID)
 	if err != nil {
 		return nil, err
 	}
-	return &query.UserGrantsQueries{
+	activeQueries, err := query.NewUserAndGroupGrantActiveQueries(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserAndGroupGrantsQueries{
 		SearchRequest: query.SearchRequest{
 			Offset: offset,
 			Limit:  limit,
 			Asc:    asc,
 		},
-		Queries: []query.SearchQuery{
-			userGrantUserID,
-		},
+		Queries: append([]query.SearchQuery{userGrantUserID}, activeQueries...),
 	}, nil
 }
 
@@ -43,5 +46,6 @@ func UserGrantToPb(grant *query.UserGrant) *auth_pb.UserGrant {
 		ProjectId: grant.ProjectID,
 		UserId:    grant.UserID,
 		Roles:     grant.Roles,
+		GroupId:   grant.GroupID,
 	}
 }
@@ -1,6 +1,8 @@
 package change
 
 import (
+	"bytes"
+
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -37,3 +39,65 @@ func ChangeToPb(change *query.Change, assetAPIPrefix string) *change_pb.Change {
 		ResourceOwnerId:          change.ResourceOwner,
 	}
 }
+
+func AuditLogQueryToQuery(q *change_pb.AuditLogQuery) *query.AuditLogSearchQueries {
+	if q == nil {
+		return new(query.AuditLogSearchQueries)
+	}
+	queries := &query.AuditLogSearchQueries{
+		Sequence:       q.Sequence,
+		Limit:          uint64(q.Limit),
+		Asc:            q.Asc,
+		EditorUserIDs:  q.EditorIds,
+		EventTypes:     q.EventTypes,
+		AggregateTypes: q.AggregateTypes,
+		Text:           q.Text,
+	}
+	if q.From != nil {
+		queries.From = q.From.AsTime()
+	}
+	if q.To != nil {
+		queries.To = q.To.AsTime()
+	}
+	return queries
+}
+
+func AuditLogEventsToPb(events []*query.AuditLogEvent) []*change_pb.AuditLogEvent {
+	e := make([]*change_pb.AuditLogEvent, len(events))
+	for i, event := range events {
+		e[i] = AuditLogEventToPb(event)
+	}
+	return e
+}
+
+func AuditLogEventToPb(event *query.AuditLogEvent) *change_pb.AuditLogEvent {
+	return &change_pb.AuditLogEvent{
+		Sequence:                 event.Sequence,
+		CreationDate:             timestamppb.New(event.CreationDate),
+		EventType:                message.NewLocalizedEventType(event.EventType),
+		AggregateType:            event.AggregateType,
+		AggregateId:              event.AggregateID,
+		ResourceOwnerId:          event.ResourceOwner,
+		EditorId:                 event.EditorID,
+		EditorService:            event.EditorService,
+		EditorDisplayName:        event.EditorName,
+		EditorPreferredLoginName: event.EditorLogin,
+		Payload:                  event.Payload,
+	}
+}
+
+// ExportAuditLog returns the audit log in the requested format and its content type
+func ExportAuditLog(auditLog *query.AuditLog, format change_pb.AuditLogExportFormat) (data []byte, contentType string, err error) {
+	buf := new(bytes.Buffer)
+	if format == change_pb.AuditLogExportFormat_AUDIT_LOG_EXPORT_FORMAT_JSON {
+		contentType = "application/json"
+		err = auditLog.WriteJSON(buf)
+	} else {
+		contentType = "text/csv"
+		err = auditLog.WriteCSV(buf)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), contentType, nil
+}
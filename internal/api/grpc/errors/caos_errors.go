@@ -51,6 +51,8 @@ func ExtractCaosError(err error) (c codes.Code, msg, id string, ok bool) {
 		return codes.FailedPrecondition, caosErr.GetMessage(), caosErr.GetID(), true
 	case *caos_errs.UnauthenticatedError:
 		return codes.Unauthenticated, caosErr.GetMessage(), caosErr.GetID(), true
+	case *caos_errs.ResourceExhaustedError:
+		return codes.ResourceExhausted, caosErr.GetMessage(), caosErr.GetID(), true
 	case *caos_errs.UnavailableError:
 		return codes.Unavailable, caosErr.GetMessage(), caosErr.GetID(), true
 	case *caos_errs.UnimplementedError:
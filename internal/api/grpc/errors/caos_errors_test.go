@@ -120,6 +120,14 @@ func Test_Extract(t *testing.T) {
 			"id",
 			true,
 		},
+		{
+			"resource exhausted",
+			args{caos_errs.ThrowResourceExhausted(nil, "id", "resource exhausted")},
+			codes.ResourceExhausted,
+			"resource exhausted",
+			"id",
+			true,
+		},
 		{
 			"unavailable",
 			args{caos_errs.ThrowUnavailable(nil, "id", "unavailable")},
@@ -0,0 +1,69 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListAccessRequests(ctx context.Context, req *mgmt_pb.ListAccessRequestsRequest) (*mgmt_pb.ListAccessRequestsResponse, error) {
+	queries, err := ListAccessRequestsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchAccessRequests(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListAccessRequestsResponse{
+		Result:  user.AccessRequestsToPb(res.AccessRequests),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) GetAccessRequestByID(ctx context.Context, req *mgmt_pb.GetAccessRequestByIDRequest) (*mgmt_pb.GetAccessRequestByIDResponse, error) {
+	projectQuery, err := query.NewAccessRequestProjectIDSearchQuery(req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewAccessRequestResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.query.AccessRequestByID(ctx, true, req.Id, projectQuery, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAccessRequestByIDResponse{
+		AccessRequest: user.AccessRequestToPb(request),
+	}, nil
+}
+
+func (s *Server) ApproveAccessRequest(ctx context.Context, req *mgmt_pb.ApproveAccessRequestRequest) (*mgmt_pb.ApproveAccessRequestResponse, error) {
+	request, err := s.command.ApproveAccessRequest(ctx, req.ProjectId, req.Id, authz.GetCtxData(ctx).OrgID, obj_grpc.ValidityToDomain(req.ValidFrom, req.ValidUntil))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ApproveAccessRequestResponse{
+		UserGrantId: request.UserGrantID,
+		Details: obj_grpc.ChangeToDetailsPb(
+			request.Sequence,
+			request.ChangeDate,
+			request.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) DenyAccessRequest(ctx context.Context, req *mgmt_pb.DenyAccessRequestRequest) (*mgmt_pb.DenyAccessRequestResponse, error) {
+	details, err := s.command.DenyAccessRequest(ctx, req.ProjectId, req.Id, authz.GetCtxData(ctx).OrgID, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.DenyAccessRequestResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func ListAccessRequestsRequestToQuery(ctx context.Context, req *mgmt_pb.ListAccessRequestsRequest) (*query.AccessRequestSearchQueries, error) {
+	projectQuery, err := query.NewAccessRequestProjectIDSearchQuery(req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewAccessRequestResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{projectQuery, ownerQuery}
+	if state := user_grpc.AccessRequestStateToDomain(req.State); state != domain.AccessRequestStateUnspecified {
+		stateQuery, err := query.NewAccessRequestStateSearchQuery(state)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, stateQuery)
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.AccessRequestSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	change_grpc "github.com/zitadel/zitadel/internal/api/grpc/change"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListOrgAuditLog(ctx context.Context, req *mgmt_pb.ListOrgAuditLogRequest) (*mgmt_pb.ListOrgAuditLogResponse, error) {
+	queries := change_grpc.AuditLogQueryToQuery(req.Query)
+	queries.ResourceOwner = authz.GetCtxData(ctx).OrgID
+	auditLog, err := s.query.SearchAuditLog(ctx, queries, s.auditLogRetention)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgAuditLogResponse{
+		Result: change_grpc.AuditLogEventsToPb(auditLog.Events),
+	}, nil
+}
+
+func (s *Server) ExportOrgAuditLog(ctx context.Context, req *mgmt_pb.ExportOrgAuditLogRequest) (*mgmt_pb.ExportOrgAuditLogResponse, error) {
+	queries := change_grpc.AuditLogQueryToQuery(req.Query)
+	queries.ResourceOwner = authz.GetCtxData(ctx).OrgID
+	auditLog, err := s.query.SearchAuditLog(ctx, queries, s.auditLogRetention)
+	if err != nil {
+		return nil, err
+	}
+	data, contentType, err := change_grpc.ExportAuditLog(auditLog, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExportOrgAuditLogResponse{
+		Data:        data,
+		ContentType: contentType,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListGroups(ctx context.Context, req *mgmt_pb.ListGroupsRequest) (*mgmt_pb.ListGroupsResponse, error) {
+	queries, err := ListGroupsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchGroups(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupsResponse{
+		Result:  user.GroupsToPb(res.Groups),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) GetGroupByID(ctx context.Context, req *mgmt_pb.GetGroupByIDRequest) (*mgmt_pb.GetGroupByIDResponse, error) {
+	ownerQuery, err := query.NewGroupResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.query.GroupByID(ctx, true, req.Id, ownerQuery)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetGroupByIDResponse{
+		Group: user.GroupToPb(group),
+	}, nil
+}
+
+func (s *Server) AddGroup(ctx context.Context, req *mgmt_pb.AddGroupRequest) (*mgmt_pb.AddGroupResponse, error) {
+	group, err := s.command.AddGroup(ctx, AddGroupRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupResponse{
+		Id: group.AggregateID,
+		Details: obj_grpc.AddToDetailsPb(
+			group.Sequence,
+			group.ChangeDate,
+			group.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateGroup(ctx context.Context, req *mgmt_pb.UpdateGroupRequest) (*mgmt_pb.UpdateGroupResponse, error) {
+	group, err := s.command.ChangeGroup(ctx, UpdateGroupRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateGroupResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			group.Sequence,
+			group.ChangeDate,
+			group.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) RemoveGroup(ctx context.Context, req *mgmt_pb.RemoveGroupRequest) (*mgmt_pb.RemoveGroupResponse, error) {
+	details, err := s.command.RemoveGroup(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListGroupMembers(ctx context.Context, req *mgmt_pb.ListGroupMembersRequest) (*mgmt_pb.ListGroupMembersResponse, error) {
+	queries, err := ListGroupMembersRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchGroupMembers(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupMembersResponse{
+		Result:  user.GroupMembersToPb(res.Members),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) AddGroupMember(ctx context.Context, req *mgmt_pb.AddGroupMemberRequest) (*mgmt_pb.AddGroupMemberResponse, error) {
+	details, err := s.command.AddGroupMember(ctx, req.GroupId, req.UserId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupMemberResponse{
+		Details: obj_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveGroupMember(ctx context.Context, req *mgmt_pb.RemoveGroupMemberRequest) (*mgmt_pb.RemoveGroupMemberResponse, error) {
+	details, err := s.command.RemoveGroupMember(ctx, req.GroupId, req.UserId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupMemberResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListGroupGrants(ctx context.Context, req *mgmt_pb.ListGroupGrantsRequest) (*mgmt_pb.ListGroupGrantsResponse, error) {
+	queries, err := ListGroupGrantsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchGroupGrants(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupGrantsResponse{
+		Result:  user.GroupGrantsToPb(res.GroupGrants),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.Timestamp),
+	}, nil
+}
+
+func (s *Server) AddGroupGrant(ctx context.Context, req *mgmt_pb.AddGroupGrantRequest) (*mgmt_pb.AddGroupGrantResponse, error) {
+	grant, err := s.command.AddGroupGrant(ctx, AddGroupGrantRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupGrantResponse{
+		GrantId: grant.GrantID,
+		Details: obj_grpc.AddToDetailsPb(
+			grant.Sequence,
+			grant.ChangeDate,
+			grant.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateGroupGrant(ctx context.Context, req *mgmt_pb.UpdateGroupGrantRequest) (*mgmt_pb.UpdateGroupGrantResponse, error) {
+	grant, err := s.command.ChangeGroupGrant(ctx, UpdateGroupGrantRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateGroupGrantResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			grant.Sequence,
+			grant.ChangeDate,
+			grant.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) RemoveGroupGrant(ctx context.Context, req *mgmt_pb.RemoveGroupGrantRequest) (*mgmt_pb.RemoveGroupGrantResponse, error) {
+	details, err := s.command.RemoveGroupGrant(ctx, req.GroupId, req.GrantId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupGrantResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
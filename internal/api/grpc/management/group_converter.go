@@ -0,0 +1,112 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func ListGroupsRequestToQuery(ctx context.Context, req *mgmt_pb.ListGroupsRequest) (*query.GroupSearchQueries, error) {
+	queries, err := user_grpc.GroupQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries = append(queries, ownerQuery)
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func ListGroupMembersRequestToQuery(ctx context.Context, req *mgmt_pb.ListGroupMembersRequest) (*query.GroupMemberSearchQueries, error) {
+	groupQuery, err := query.NewGroupMemberGroupIDSearchQuery(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupMemberResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.GroupMemberSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{groupQuery, ownerQuery},
+	}, nil
+}
+
+func ListGroupGrantsRequestToQuery(ctx context.Context, req *mgmt_pb.ListGroupGrantsRequest) (*query.GroupGrantSearchQueries, error) {
+	groupQuery, err := query.NewGroupGrantGroupIDSearchQuery(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupGrantResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.GroupGrantSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{groupQuery, ownerQuery},
+	}, nil
+}
+
+func AddGroupRequestToDomain(req *mgmt_pb.AddGroupRequest) *domain.Group {
+	return &domain.Group{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func UpdateGroupRequestToDomain(req *mgmt_pb.UpdateGroupRequest) *domain.Group {
+	return &domain.Group{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func AddGroupGrantRequestToDomain(req *mgmt_pb.AddGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GroupId,
+		},
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: req.ProjectGrantId,
+		RoleKeys:       req.RoleKeys,
+	}
+}
+
+func UpdateGroupGrantRequestToDomain(req *mgmt_pb.UpdateGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GroupId,
+		},
+		GrantID:  req.GrantId,
+		RoleKeys: req.RoleKeys,
+	}
+}
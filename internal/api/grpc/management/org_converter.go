@@ -68,7 +68,9 @@ func SetPrimaryOrgDomainRequestToDomain(ctx context.Context, req *mgmt_pb.SetPri
 }
 
 func UpdateOrgMemberRequestToDomain(ctx context.Context, req *mgmt_pb.UpdateOrgMemberRequest) *domain.Member {
-	return domain.NewMember(authz.GetCtxData(ctx).OrgID, req.UserId, req.Roles...)
+	member := domain.NewMember(authz.GetCtxData(ctx).OrgID, req.UserId, req.Roles...)
+	member.Validity = object.ValidityToDomain(req.ValidFrom, req.ValidUntil)
+	return member
 }
 
 func ListOrgMembersRequestToModel(ctx context.Context, req *mgmt_pb.ListOrgMembersRequest) (*query.OrgMembersQuery, error) {
@@ -0,0 +1,64 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetCaptchaPolicy(ctx context.Context, req *mgmt_pb.GetCaptchaPolicyRequest) (*mgmt_pb.GetCaptchaPolicyResponse, error) {
+	policy, err := s.query.CaptchaPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCaptchaPolicyResponse{Policy: policy_grpc.ModelCaptchaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultCaptchaPolicy(ctx context.Context, req *mgmt_pb.GetDefaultCaptchaPolicyRequest) (*mgmt_pb.GetDefaultCaptchaPolicyResponse, error) {
+	policy, err := s.query.DefaultCaptchaPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultCaptchaPolicyResponse{Policy: policy_grpc.ModelCaptchaPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomCaptchaPolicy(ctx context.Context, req *mgmt_pb.AddCustomCaptchaPolicyRequest) (*mgmt_pb.AddCustomCaptchaPolicyResponse, error) {
+	policy, err := s.command.AddCaptchaPolicy(ctx, authz.GetCtxData(ctx).OrgID, AddCaptchaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomCaptchaPolicyResponse{
+		Details: object.AddToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomCaptchaPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomCaptchaPolicyRequest) (*mgmt_pb.UpdateCustomCaptchaPolicyResponse, error) {
+	policy, err := s.command.ChangeCaptchaPolicy(ctx, authz.GetCtxData(ctx).OrgID, UpdateCaptchaPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomCaptchaPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCaptchaPolicyToDefault(ctx context.Context, req *mgmt_pb.ResetCaptchaPolicyToDefaultRequest) (*mgmt_pb.ResetCaptchaPolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveCaptchaPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCaptchaPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package management
+
+import (
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddCaptchaPolicyToDomain(p *mgmt.AddCustomCaptchaPolicyRequest) *domain.CaptchaPolicy {
+	return &domain.CaptchaPolicy{
+		ProviderType:           policy_grpc.CaptchaProviderTypeToDomain(p.ProviderType),
+		VerifyURL:              p.VerifyUrl,
+		SiteKey:                p.SiteKey,
+		SecretString:           p.Secret,
+		RequireOnRegistration:  p.RequireOnRegistration,
+		RequireOnPasswordReset: p.RequireOnPasswordReset,
+		FailedLoginsThreshold:  uint64(p.FailedLoginsThreshold),
+	}
+}
+
+func UpdateCaptchaPolicyToDomain(p *mgmt.UpdateCustomCaptchaPolicyRequest) *domain.CaptchaPolicy {
+	return &domain.CaptchaPolicy{
+		ProviderType:           policy_grpc.CaptchaProviderTypeToDomain(p.ProviderType),
+		VerifyURL:              p.VerifyUrl,
+		SiteKey:                p.SiteKey,
+		SecretString:           p.Secret,
+		RequireOnRegistration:  p.RequireOnRegistration,
+		RequireOnPasswordReset: p.RequireOnPasswordReset,
+		FailedLoginsThreshold:  uint64(p.FailedLoginsThreshold),
+	}
+}
@@ -30,6 +30,7 @@ func AddLoginPolicyToCommand(p *mgmt_pb.AddCustomLoginPolicyRequest) *command.Ad
 		IDPProviders:               addLoginPolicyIDPsToCommand(p.Idps),
 		DisableLoginWithEmail:      p.DisableLoginWithEmail,
 		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		AllowMagicLink:             p.AllowMagicLink,
 	}
 }
 func addLoginPolicyIDPsToCommand(idps []*mgmt_pb.AddCustomLoginPolicyRequest_IDP) []*command.AddLoginPolicyIDP {
@@ -55,6 +56,7 @@ func updateLoginPolicyToCommand(p *mgmt_pb.UpdateCustomLoginPolicyRequest) *comm
 		AllowDomainDiscovery:       p.AllowDomainDiscovery,
 		DisableLoginWithEmail:      p.DisableLoginWithEmail,
 		DisableLoginWithPhone:      p.DisableLoginWithPhone,
+		AllowMagicLink:             p.AllowMagicLink,
 		DefaultRedirectURI:         p.DefaultRedirectUri,
 		PasswordCheckLifetime:      p.PasswordCheckLifetime.AsDuration(),
 		ExternalLoginCheckLifetime: p.ExternalLoginCheckLifetime.AsDuration(),
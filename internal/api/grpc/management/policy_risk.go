@@ -0,0 +1,64 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetRiskPolicy(ctx context.Context, req *mgmt_pb.GetRiskPolicyRequest) (*mgmt_pb.GetRiskPolicyResponse, error) {
+	policy, err := s.query.RiskPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetRiskPolicyResponse{Policy: policy_grpc.ModelRiskPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultRiskPolicy(ctx context.Context, req *mgmt_pb.GetDefaultRiskPolicyRequest) (*mgmt_pb.GetDefaultRiskPolicyResponse, error) {
+	policy, err := s.query.DefaultRiskPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultRiskPolicyResponse{Policy: policy_grpc.ModelRiskPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomRiskPolicy(ctx context.Context, req *mgmt_pb.AddCustomRiskPolicyRequest) (*mgmt_pb.AddCustomRiskPolicyResponse, error) {
+	policy, err := s.command.AddRiskPolicy(ctx, authz.GetCtxData(ctx).OrgID, AddRiskPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomRiskPolicyResponse{
+		Details: object.AddToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomRiskPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomRiskPolicyRequest) (*mgmt_pb.UpdateCustomRiskPolicyResponse, error) {
+	policy, err := s.command.ChangeRiskPolicy(ctx, authz.GetCtxData(ctx).OrgID, UpdateRiskPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomRiskPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetRiskPolicyToDefault(ctx context.Context, req *mgmt_pb.ResetRiskPolicyToDefaultRequest) (*mgmt_pb.ResetRiskPolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveRiskPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetRiskPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddRiskPolicyToDomain(p *mgmt.AddCustomRiskPolicyRequest) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		MFAOnNewDevice:          p.MfaOnNewDevice,
+		MFAOnNewIPRange:         p.MfaOnNewIpRange,
+		ImpossibleTravelWindow:  p.ImpossibleTravelWindow.AsDuration(),
+		FailedAttemptsThreshold: uint64(p.FailedAttemptsThreshold),
+	}
+}
+
+func UpdateRiskPolicyToDomain(p *mgmt.UpdateCustomRiskPolicyRequest) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		MFAOnNewDevice:          p.MfaOnNewDevice,
+		MFAOnNewIPRange:         p.MfaOnNewIpRange,
+		ImpossibleTravelWindow:  p.ImpossibleTravelWindow.AsDuration(),
+		FailedAttemptsThreshold: uint64(p.FailedAttemptsThreshold),
+	}
+}
@@ -215,6 +215,27 @@ func (s *Server) RegenerateAPIClientSecret(ctx context.Context, req *mgmt_pb.Reg
 	}, nil
 }
 
+func (s *Server) SetAppClaimMappings(ctx context.Context, req *mgmt_pb.SetAppClaimMappingsRequest) (*mgmt_pb.SetAppClaimMappingsResponse, error) {
+	details, err := s.command.SetApplicationClaimMappings(ctx, req.ProjectId, req.AppId, project_grpc.ClaimMappingsToDomain(req.ClaimMappings), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetAppClaimMappingsResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListAppClaimMappings(ctx context.Context, req *mgmt_pb.ListAppClaimMappingsRequest) (*mgmt_pb.ListAppClaimMappingsResponse, error) {
+	mappings, err := s.query.AppClaimMappings(ctx, req.ProjectId, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListAppClaimMappingsResponse{
+		Result:  project_grpc.ClaimMappingsToPb(mappings.ClaimMappings),
+		Details: object_grpc.ToListDetails(mappings.Count, mappings.Sequence, mappings.Timestamp),
+	}, nil
+}
+
 func (s *Server) GetAppKey(ctx context.Context, req *mgmt_pb.GetAppKeyRequest) (*mgmt_pb.GetAppKeyResponse, error) {
 	resourceOwner, err := query.NewAuthNKeyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -41,21 +41,26 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:                  req.Name,
-		OIDCVersion:              app_grpc.OIDCVersionToDomain(req.Version),
-		RedirectUris:             req.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(req.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
-		PostLogoutRedirectUris:   req.PostLogoutRedirectUris,
-		DevMode:                  req.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
-		AccessTokenRoleAssertion: req.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     req.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: req.IdTokenUserinfoAssertion,
-		ClockSkew:                req.ClockSkew.AsDuration(),
-		AdditionalOrigins:        req.AdditionalOrigins,
+		AppName:                            req.Name,
+		OIDCVersion:                        app_grpc.OIDCVersionToDomain(req.Version),
+		RedirectUris:                       req.RedirectUris,
+		ResponseTypes:                      app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
+		GrantTypes:                         app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
+		ApplicationType:                    app_grpc.OIDCApplicationTypeToDomain(req.AppType),
+		AuthMethodType:                     app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
+		PostLogoutRedirectUris:             req.PostLogoutRedirectUris,
+		DevMode:                            req.DevMode,
+		AccessTokenType:                    app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
+		AccessTokenRoleAssertion:           req.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:               req.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:           req.IdTokenUserinfoAssertion,
+		ClockSkew:                          req.ClockSkew.AsDuration(),
+		AdditionalOrigins:                  req.AdditionalOrigins,
+		RequirePushedAuthorizationRequests: req.RequirePushedAuthorizationRequests,
+		JWKS:                               req.Jwks,
+		BackChannelLogoutURI:               req.BackChannelLogoutUri,
+		FrontChannelLogoutURI:              req.FrontChannelLogoutUri,
+		AppTokenSettings:                   app_grpc.TokenSettingsToDomain(req.TokenSettings),
 	}
 }
 
@@ -75,8 +80,9 @@ func AddAPIAppRequestToDomain(app *mgmt_pb.AddAPIAppRequest) *domain.APIApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppName:        app.Name,
-		AuthMethodType: app_grpc.APIAuthMethodTypeToDomain(app.AuthMethodType),
+		AppName:          app.Name,
+		AuthMethodType:   app_grpc.APIAuthMethodTypeToDomain(app.AuthMethodType),
+		AppTokenSettings: app_grpc.TokenSettingsToDomain(app.TokenSettings),
 	}
 }
 
@@ -92,20 +98,25 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:                    app.AppId,
-		RedirectUris:             app.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(app.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
-		PostLogoutRedirectUris:   app.PostLogoutRedirectUris,
-		DevMode:                  app.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
-		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     app.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
-		ClockSkew:                app.ClockSkew.AsDuration(),
-		AdditionalOrigins:        app.AdditionalOrigins,
+		AppID:                              app.AppId,
+		RedirectUris:                       app.RedirectUris,
+		ResponseTypes:                      app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
+		GrantTypes:                         app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
+		ApplicationType:                    app_grpc.OIDCApplicationTypeToDomain(app.AppType),
+		AuthMethodType:                     app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
+		PostLogoutRedirectUris:             app.PostLogoutRedirectUris,
+		DevMode:                            app.DevMode,
+		AccessTokenType:                    app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
+		AccessTokenRoleAssertion:           app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:               app.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:           app.IdTokenUserinfoAssertion,
+		ClockSkew:                          app.ClockSkew.AsDuration(),
+		AdditionalOrigins:                  app.AdditionalOrigins,
+		RequirePushedAuthorizationRequests: app.RequirePushedAuthorizationRequests,
+		JWKS:                               app.Jwks,
+		BackChannelLogoutURI:               app.BackChannelLogoutUri,
+		FrontChannelLogoutURI:              app.FrontChannelLogoutUri,
+		AppTokenSettings:                   app_grpc.TokenSettingsToDomain(app.TokenSettings),
 	}
 }
 
@@ -125,8 +136,9 @@ func UpdateAPIAppConfigRequestToDomain(app *mgmt_pb.UpdateAPIAppConfigRequest) *
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:          app.AppId,
-		AuthMethodType: app_grpc.APIAuthMethodTypeToDomain(app.AuthMethodType),
+		AppID:            app.AppId,
+		AuthMethodType:   app_grpc.APIAuthMethodTypeToDomain(app.AuthMethodType),
+		AppTokenSettings: app_grpc.TokenSettingsToDomain(app.TokenSettings),
 	}
 }
 
@@ -94,11 +94,15 @@ func ProjectGrantsToIDs(projectGrants *query.ProjectGrants) []string {
 }
 
 func AddProjectMemberRequestToDomain(req *mgmt_pb.AddProjectMemberRequest) *domain.Member {
-	return domain.NewMember(req.ProjectId, req.UserId, req.Roles...)
+	member := domain.NewMember(req.ProjectId, req.UserId, req.Roles...)
+	member.Validity = object.ValidityToDomain(req.ValidFrom, req.ValidUntil)
+	return member
 }
 
 func UpdateProjectMemberRequestToDomain(req *mgmt_pb.UpdateProjectMemberRequest) *domain.Member {
-	return domain.NewMember(req.ProjectId, req.UserId, req.Roles...)
+	member := domain.NewMember(req.ProjectId, req.UserId, req.Roles...)
+	member.Validity = object.ValidityToDomain(req.ValidFrom, req.ValidUntil)
+	return member
 }
 
 func listProjectRequestToModel(req *mgmt_pb.ListProjectsRequest) (*query.ProjectSearchQueries, error) {
@@ -0,0 +1,92 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	project_grpc "github.com/zitadel/zitadel/internal/api/grpc/project"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) AddRelationTuples(ctx context.Context, req *mgmt_pb.AddRelationTuplesRequest) (*mgmt_pb.AddRelationTuplesResponse, error) {
+	details, err := s.command.AddRelationTuples(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, project_grpc.RelationTuplesToDomain(req.ProjectId, req.Tuples)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddRelationTuplesResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveRelationTuples(ctx context.Context, req *mgmt_pb.RemoveRelationTuplesRequest) (*mgmt_pb.RemoveRelationTuplesResponse, error) {
+	details, err := s.command.RemoveRelationTuples(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, project_grpc.RelationTuplesToDomain(req.ProjectId, req.Tuples)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveRelationTuplesResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListRelationTuples(ctx context.Context, req *mgmt_pb.ListRelationTuplesRequest) (*mgmt_pb.ListRelationTuplesResponse, error) {
+	queries, err := listRelationTuplesRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	tuples, err := s.query.SearchRelationTuples(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListRelationTuplesResponse{
+		Result:  project_grpc.RelationTuplesToPb(tuples.RelationTuples),
+		Details: object_grpc.ToListDetails(tuples.Count, tuples.Sequence, tuples.Timestamp),
+	}, nil
+}
+
+func (s *Server) CheckRelation(ctx context.Context, req *mgmt_pb.CheckRelationRequest) (*mgmt_pb.CheckRelationResponse, error) {
+	allowed, err := s.query.CheckRelation(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, req.Object.Type, req.Object.Id, req.Relation, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.CheckRelationResponse{
+		Allowed: allowed,
+	}, nil
+}
+
+func (s *Server) ListRelationObjects(ctx context.Context, req *mgmt_pb.ListRelationObjectsRequest) (*mgmt_pb.ListRelationObjectsResponse, error) {
+	objectIDs, err := s.query.ListRelationObjects(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, req.ObjectType, req.Relation, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListRelationObjectsResponse{
+		ObjectIds: objectIDs,
+	}, nil
+}
+
+func (s *Server) ExpandRelation(ctx context.Context, req *mgmt_pb.ExpandRelationRequest) (*mgmt_pb.ExpandRelationResponse, error) {
+	tree, err := s.query.ExpandRelation(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, req.Object.Type, req.Object.Id, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ExpandRelationResponse{
+		Tree: project_grpc.RelationTreeToPb(tree),
+	}, nil
+}
+
+func listRelationTuplesRequestToModel(req *mgmt_pb.ListRelationTuplesRequest) (*query.RelationTupleSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := project_grpc.RelationTupleQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.RelationTupleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
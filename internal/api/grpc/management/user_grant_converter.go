@@ -55,6 +55,7 @@ func AddUserGrantRequestToDomain(req *mgmt_pb.AddUserGrantRequest) *domain.UserG
 		ProjectID:      req.ProjectId,
 		ProjectGrantID: req.ProjectGrantId,
 		RoleKeys:       req.RoleKeys,
+		Validity:       object.ValidityToDomain(req.ValidFrom, req.ValidUntil),
 	}
 }
 
@@ -65,6 +66,7 @@ func UpdateUserGrantRequestToDomain(req *mgmt_pb.UpdateUserGrantRequest) *domain
 		},
 		UserID:   req.UserId,
 		RoleKeys: req.RoleKeys,
+		Validity: object.ValidityToDomain(req.ValidFrom, req.ValidUntil),
 	}
 
 }
@@ -149,3 +149,21 @@ func ListQueryToModel(query *object_pb.ListQuery) (offset, limit uint64, asc boo
 	}
 	return query.Offset, uint64(query.Limit), query.Asc
 }
+
+func ValidityToDomain(validFrom, validUntil *timestamppb.Timestamp) domain.Validity {
+	validity := domain.Validity{}
+	if validFrom != nil {
+		validity.ValidFrom = validFrom.AsTime()
+	}
+	if validUntil != nil {
+		validity.ValidUntil = validUntil.AsTime()
+	}
+	return validity
+}
+
+func TimeToPb(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelCaptchaPolicyToPb(policy *query.CaptchaPolicy) *policy_pb.CaptchaPolicy {
+	return &policy_pb.CaptchaPolicy{
+		IsDefault:              policy.IsDefault,
+		ProviderType:           ModelCaptchaProviderTypeToPb(policy.ProviderType),
+		VerifyUrl:              policy.VerifyURL,
+		SiteKey:                policy.SiteKey,
+		RequireOnRegistration:  policy.RequireOnRegistration,
+		RequireOnPasswordReset: policy.RequireOnPasswordReset,
+		FailedLoginsThreshold:  policy.FailedLoginsThreshold,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
+
+func CaptchaProviderTypeToDomain(providerType policy_pb.CaptchaProviderType) domain.CaptchaProviderType {
+	switch providerType {
+	case policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_HCAPTCHA:
+		return domain.CaptchaProviderTypeHCaptcha
+	case policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_RECAPTCHA:
+		return domain.CaptchaProviderTypeReCaptcha
+	case policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_TURNSTILE:
+		return domain.CaptchaProviderTypeTurnstile
+	default:
+		return domain.CaptchaProviderTypeUnspecified
+	}
+}
+
+func ModelCaptchaProviderTypeToPb(providerType domain.CaptchaProviderType) policy_pb.CaptchaProviderType {
+	switch providerType {
+	case domain.CaptchaProviderTypeHCaptcha:
+		return policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_HCAPTCHA
+	case domain.CaptchaProviderTypeReCaptcha:
+		return policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_RECAPTCHA
+	case domain.CaptchaProviderTypeTurnstile:
+		return policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_TURNSTILE
+	default:
+		return policy_pb.CaptchaProviderType_CAPTCHA_PROVIDER_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelRiskPolicyToPb(policy *query.RiskPolicy) *policy_pb.RiskPolicy {
+	return &policy_pb.RiskPolicy{
+		IsDefault:               policy.IsDefault,
+		MfaOnNewDevice:          policy.MFAOnNewDevice,
+		MfaOnNewIpRange:         policy.MFAOnNewIPRange,
+		ImpossibleTravelWindow:  durationpb.New(policy.ImpossibleTravelWindow),
+		FailedAttemptsThreshold: policy.FailedAttemptsThreshold,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
@@ -42,24 +42,29 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
+			RedirectUris:                       app.RedirectURIs,
+			ResponseTypes:                      OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                         OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                            OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                           app.ClientID,
+			AuthMethodType:                     OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:             app.PostLogoutRedirectURIs,
+			Version:                            OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:                      len(app.ComplianceProblems) != 0,
+			ComplianceProblems:                 ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                            app.IsDevMode,
+			AccessTokenType:                    oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:           app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:               app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:           app.AssertIDTokenUserinfo,
+			ClockSkew:                          durationpb.New(app.ClockSkew),
+			AdditionalOrigins:                  app.AdditionalOrigins,
+			AllowedOrigins:                     app.AllowedOrigins,
+			RequirePushedAuthorizationRequests: app.RequirePushedAuthorizationRequests,
+			Jwks:                               app.JWKS,
+			BackChannelLogoutUri:               app.BackChannelLogoutURI,
+			FrontChannelLogoutUri:              app.FrontChannelLogoutURI,
+			TokenSettings:                      TokenSettingsToPb(app.AppTokenSettings),
 		},
 	}
 }
@@ -77,6 +82,7 @@ func AppAPIConfigToPb(app *query.APIApp) app_pb.AppConfig {
 		ApiConfig: &app_pb.APIConfig{
 			ClientId:       app.ClientID,
 			AuthMethodType: APIAuthMethodeTypeToPb(app.AuthMethodType),
+			TokenSettings:  TokenSettingsToPb(app.AppTokenSettings),
 		},
 	}
 }
@@ -282,6 +288,48 @@ func APIAuthMethodTypeToDomain(authType app_pb.APIAuthMethodType) domain.APIAuth
 	}
 }
 
+func TokenSettingsToPb(settings domain.AppTokenSettings) *app_pb.TokenSettings {
+	return &app_pb.TokenSettings{
+		AccessTokenLifetime:        durationpb.New(settings.AccessTokenLifetime),
+		IdTokenLifetime:            durationpb.New(settings.IDTokenLifetime),
+		RefreshTokenIdleExpiration: durationpb.New(settings.RefreshTokenIdleExpiration),
+		RefreshTokenExpiration:     durationpb.New(settings.RefreshTokenExpiration),
+		RefreshTokenRotation:       refreshTokenRotationToPb(settings.RefreshTokenRotation),
+	}
+}
+
+func TokenSettingsToDomain(settings *app_pb.TokenSettings) domain.AppTokenSettings {
+	return domain.AppTokenSettings{
+		AccessTokenLifetime:        settings.GetAccessTokenLifetime().AsDuration(),
+		IDTokenLifetime:            settings.GetIdTokenLifetime().AsDuration(),
+		RefreshTokenIdleExpiration: settings.GetRefreshTokenIdleExpiration().AsDuration(),
+		RefreshTokenExpiration:     settings.GetRefreshTokenExpiration().AsDuration(),
+		RefreshTokenRotation:       refreshTokenRotationToDomain(settings.GetRefreshTokenRotation()),
+	}
+}
+
+func refreshTokenRotationToPb(rotation domain.RefreshTokenRotation) app_pb.RefreshTokenRotation {
+	switch rotation {
+	case domain.RefreshTokenRotationReuseDetection:
+		return app_pb.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_REUSE_DETECTION
+	case domain.RefreshTokenRotationNever:
+		return app_pb.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_NEVER
+	default:
+		return app_pb.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_ALWAYS
+	}
+}
+
+func refreshTokenRotationToDomain(rotation app_pb.RefreshTokenRotation) domain.RefreshTokenRotation {
+	switch rotation {
+	case app_pb.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_REUSE_DETECTION:
+		return domain.RefreshTokenRotationReuseDetection
+	case app_pb.RefreshTokenRotation_REFRESH_TOKEN_ROTATION_NEVER:
+		return domain.RefreshTokenRotationNever
+	default:
+		return domain.RefreshTokenRotationAlways
+	}
+}
+
 func AppQueriesToModel(queries []*app_pb.AppQuery) (q []query.SearchQuery, err error) {
 	q = make([]query.SearchQuery, len(queries))
 	for i, query := range queries {
@@ -301,3 +349,99 @@ func AppQueryToModel(appQuery *app_pb.AppQuery) (query.SearchQuery, error) {
 		return nil, errors.ThrowInvalidArgument(nil, "APP-Add46", "List.Query.Invalid")
 	}
 }
+
+func ClaimMappingsToPb(mappings []*query.ClaimMapping) []*app_pb.ClaimMapping {
+	m := make([]*app_pb.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		m[i] = &app_pb.ClaimMapping{
+			Source:      ClaimMappingSourceToPb(mapping.Source),
+			SourceKey:   mapping.SourceKey,
+			Claim:       mapping.Claim,
+			Type:        ClaimMappingTypeToPb(mapping.Type),
+			IdToken:     mapping.IDToken,
+			AccessToken: mapping.AccessToken,
+			Userinfo:    mapping.Userinfo,
+		}
+	}
+	return m
+}
+
+func ClaimMappingsToDomain(mappings []*app_pb.ClaimMapping) []*domain.ClaimMapping {
+	m := make([]*domain.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		m[i] = &domain.ClaimMapping{
+			Source:      ClaimMappingSourceToDomain(mapping.Source),
+			SourceKey:   mapping.SourceKey,
+			Claim:       mapping.Claim,
+			Type:        ClaimMappingTypeToDomain(mapping.Type),
+			IDToken:     mapping.IdToken,
+			AccessToken: mapping.AccessToken,
+			Userinfo:    mapping.Userinfo,
+		}
+	}
+	return m
+}
+
+func ClaimMappingSourceToPb(source domain.ClaimMappingSource) app_pb.ClaimMappingSource {
+	switch source {
+	case domain.ClaimMappingSourceProfile:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_PROFILE
+	case domain.ClaimMappingSourceMetadata:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_METADATA
+	case domain.ClaimMappingSourceOrg:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ORG
+	case domain.ClaimMappingSourceRoles:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ROLES
+	default:
+		return app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_UNSPECIFIED
+	}
+}
+
+func ClaimMappingSourceToDomain(source app_pb.ClaimMappingSource) domain.ClaimMappingSource {
+	switch source {
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_PROFILE:
+		return domain.ClaimMappingSourceProfile
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_METADATA:
+		return domain.ClaimMappingSourceMetadata
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ORG:
+		return domain.ClaimMappingSourceOrg
+	case app_pb.ClaimMappingSource_CLAIM_MAPPING_SOURCE_ROLES:
+		return domain.ClaimMappingSourceRoles
+	default:
+		return domain.ClaimMappingSourceUnspecified
+	}
+}
+
+func ClaimMappingTypeToPb(mappingType domain.ClaimMappingType) app_pb.ClaimMappingType {
+	switch mappingType {
+	case domain.ClaimMappingTypeString:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_STRING
+	case domain.ClaimMappingTypeNumber:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_NUMBER
+	case domain.ClaimMappingTypeBoolean:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_BOOLEAN
+	case domain.ClaimMappingTypeStringList:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_STRING_LIST
+	case domain.ClaimMappingTypeJSON:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_JSON
+	default:
+		return app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_UNSPECIFIED
+	}
+}
+
+func ClaimMappingTypeToDomain(mappingType app_pb.ClaimMappingType) domain.ClaimMappingType {
+	switch mappingType {
+	case app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_STRING:
+		return domain.ClaimMappingTypeString
+	case app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_NUMBER:
+		return domain.ClaimMappingTypeNumber
+	case app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_BOOLEAN:
+		return domain.ClaimMappingTypeBoolean
+	case app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_STRING_LIST:
+		return domain.ClaimMappingTypeStringList
+	case app_pb.ClaimMappingType_CLAIM_MAPPING_TYPE_JSON:
+		return domain.ClaimMappingTypeJSON
+	default:
+		return domain.ClaimMappingTypeUnspecified
+	}
+}
@@ -0,0 +1,134 @@
+package project
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	proj_pb "github.com/zitadel/zitadel/pkg/grpc/project"
+)
+
+func RelationTuplesToDomain(projectID string, tuples []*proj_pb.RelationTuple) []*domain.RelationTuple {
+	t := make([]*domain.RelationTuple, len(tuples))
+	for i, tuple := range tuples {
+		t[i] = RelationTupleToDomain(projectID, tuple)
+	}
+	return t
+}
+
+func RelationTupleToDomain(projectID string, tuple *proj_pb.RelationTuple) *domain.RelationTuple {
+	return &domain.RelationTuple{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: projectID,
+		},
+		ObjectType: tuple.GetObject().GetType(),
+		ObjectID:   tuple.GetObject().GetId(),
+		Relation:   tuple.Relation,
+		Subject:    RelationSubjectToDomain(tuple.Subject),
+	}
+}
+
+func RelationSubjectToDomain(subject *proj_pb.RelationSubject) domain.RelationSubject {
+	return domain.RelationSubject{
+		Type:     subject.GetType(),
+		ID:       subject.GetId(),
+		Relation: subject.GetRelation(),
+	}
+}
+
+func RelationTuplesToPb(tuples []*query.RelationTuple) []*proj_pb.RelationTuple {
+	t := make([]*proj_pb.RelationTuple, len(tuples))
+	for i, tuple := range tuples {
+		t[i] = RelationTupleToPb(tuple)
+	}
+	return t
+}
+
+func RelationTupleToPb(tuple *query.RelationTuple) *proj_pb.RelationTuple {
+	return &proj_pb.RelationTuple{
+		Object: &proj_pb.RelationObject{
+			Type: tuple.ObjectType,
+			Id:   tuple.ObjectID,
+		},
+		Relation: tuple.Relation,
+		Subject:  RelationSubjectToPb(tuple.Subject),
+	}
+}
+
+func RelationSubjectsToPb(subjects []domain.RelationSubject) []*proj_pb.RelationSubject {
+	s := make([]*proj_pb.RelationSubject, len(subjects))
+	for i, subject := range subjects {
+		s[i] = RelationSubjectToPb(subject)
+	}
+	return s
+}
+
+func RelationSubjectToPb(subject domain.RelationSubject) *proj_pb.RelationSubject {
+	return &proj_pb.RelationSubject{
+		Type:     subject.Type,
+		Id:       subject.ID,
+		Relation: subject.Relation,
+	}
+}
+
+func RelationTreeToPb(tree *query.RelationTree) *proj_pb.RelationTree {
+	children := make([]*proj_pb.RelationTree, len(tree.Children))
+	for i, child := range tree.Children {
+		children[i] = RelationTreeToPb(child)
+	}
+	return &proj_pb.RelationTree{
+		Object: &proj_pb.RelationObject{
+			Type: tree.ObjectType,
+			Id:   tree.ObjectID,
+		},
+		Relation: tree.Relation,
+		Subjects: RelationSubjectsToPb(tree.Subjects),
+		Children: children,
+	}
+}
+
+func RelationTupleQueriesToModel(queries []*proj_pb.RelationTupleQuery) ([]query.SearchQuery, error) {
+	q := make([]query.SearchQuery, 0, len(queries))
+	for _, query := range queries {
+		converted, err := RelationTupleQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, converted...)
+	}
+	return q, nil
+}
+
+func RelationTupleQueryToModel(apiQuery *proj_pb.RelationTupleQuery) ([]query.SearchQuery, error) {
+	switch q := apiQuery.Query.(type) {
+	case *proj_pb.RelationTupleQuery_ObjectTypeQuery:
+		return relationSearchQueries(query.NewRelationTupleObjectTypeSearchQuery(q.ObjectTypeQuery.ObjectType))
+	case *proj_pb.RelationTupleQuery_ObjectIdQuery:
+		return relationSearchQueries(query.NewRelationTupleObjectIDSearchQuery(q.ObjectIdQuery.ObjectId))
+	case *proj_pb.RelationTupleQuery_RelationQuery:
+		return relationSearchQueries(query.NewRelationTupleRelationSearchQuery(q.RelationQuery.Relation))
+	case *proj_pb.RelationTupleQuery_SubjectQuery:
+		subjectType, err := query.NewRelationTupleSubjectTypeSearchQuery(q.SubjectQuery.GetSubject().GetType())
+		if err != nil {
+			return nil, err
+		}
+		subjectID, err := query.NewRelationTupleSubjectIDSearchQuery(q.SubjectQuery.GetSubject().GetId())
+		if err != nil {
+			return nil, err
+		}
+		subjectRelation, err := query.NewRelationTupleSubjectRelationSearchQuery(q.SubjectQuery.GetSubject().GetRelation())
+		if err != nil {
+			return nil, err
+		}
+		return []query.SearchQuery{subjectType, subjectID, subjectRelation}, nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-Rk2nf", "List.Query.Invalid")
+	}
+}
+
+func relationSearchQueries(q query.SearchQuery, err error) ([]query.SearchQuery, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []query.SearchQuery{q}, nil
+}
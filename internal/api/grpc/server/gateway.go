@@ -23,6 +23,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		"dpop",
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
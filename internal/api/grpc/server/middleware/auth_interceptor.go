@@ -5,11 +5,14 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	grpc_util "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
@@ -35,6 +38,12 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 
 	orgID := grpc_util.GetHeader(authCtx, http.ZitadelOrgID)
 
+	proof, err := tokenProof(authCtx, authConfig.ClientCertificateHeader)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid client certificate")
+	}
+	authCtx = pop.WithProof(authCtx, proof)
+
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, verifier, authConfig, authOpt, info.FullMethod)
 	if err != nil {
 		return nil, err
@@ -42,3 +51,25 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	span.End()
 	return handler(ctxSetter(ctx), req)
 }
+
+// tokenProof returns the DPoP proof and the thumbprint of the client certificate presented along with the access token.
+// gRPC requests have no url, so the DPoP proof is not bound to the method and url of the request
+func tokenProof(ctx context.Context, clientCertificateHeader string) (_ *pop.Proof, err error) {
+	proof := &pop.Proof{
+		DPoP: grpc_util.GetHeader(ctx, http.DPoP),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			proof.CertificateThumbprint = pop.CertificateThumbprint(tlsInfo.State.PeerCertificates[0])
+			return proof, nil
+		}
+	}
+	if clientCertificateHeader == "" {
+		return proof, nil
+	}
+	proof.CertificateThumbprint, err = pop.ForwardedCertificateThumbprint(grpc_util.GetHeader(ctx, clientCertificateHeader))
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
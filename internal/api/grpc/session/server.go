@@ -0,0 +1,64 @@
+package session
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/server"
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/pkg/grpc/session"
+)
+
+var _ session.SessionServiceServer = (*Server)(nil)
+
+const (
+	sessionName = "Session-API"
+)
+
+type Server struct {
+	session.UnimplementedSessionServiceServer
+	repo                repository.SessionRepository
+	externalSecure      bool
+	oidcAuthCallbackURL func(context.Context, string) string
+	samlAuthCallbackURL func(context.Context, string) string
+}
+
+func CreateServer(
+	repo repository.SessionRepository,
+	externalSecure bool,
+	oidcAuthCallbackURL func(context.Context, string) string,
+	samlAuthCallbackURL func(context.Context, string) string,
+) *Server {
+	return &Server{
+		repo:                repo,
+		externalSecure:      externalSecure,
+		oidcAuthCallbackURL: oidcAuthCallbackURL,
+		samlAuthCallbackURL: samlAuthCallbackURL,
+	}
+}
+
+func (s *Server) RegisterServer(grpcServer *grpc.Server) {
+	session.RegisterSessionServiceServer(grpcServer, s)
+}
+
+func (s *Server) AppName() string {
+	return sessionName
+}
+
+func (s *Server) MethodPrefix() string {
+	return session.SessionService_MethodPrefix
+}
+
+func (s *Server) AuthMethods() authz.MethodMapping {
+	return session.SessionService_AuthMethods
+}
+
+func (s *Server) RegisterGateway() server.GatewayFunc {
+	return session.RegisterSessionServiceHandlerFromEndpoint
+}
+
+func (s *Server) GatewayPathPrefix() string {
+	return "/session/v1"
+}
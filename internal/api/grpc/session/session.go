@@ -0,0 +1,133 @@
+package session
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	session_pb "github.com/zitadel/zitadel/pkg/grpc/session"
+)
+
+func (s *Server) CreateSession(ctx context.Context, req *session_pb.CreateSessionRequest) (*session_pb.CreateSessionResponse, error) {
+	session, token, err := s.repo.CreateSession(ctx, req.AuthRequestId)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CreateSessionResponse{
+		Session:      SessionToPb(session),
+		SessionToken: token,
+	}, nil
+}
+
+func (s *Server) GetSession(ctx context.Context, req *session_pb.GetSessionRequest) (*session_pb.GetSessionResponse, error) {
+	session, err := s.repo.SessionByID(ctx, req.SessionId, req.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.GetSessionResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) CheckUser(ctx context.Context, req *session_pb.CheckUserRequest) (*session_pb.CheckUserResponse, error) {
+	session, err := s.repo.CheckSessionUser(ctx, req.SessionId, req.SessionToken, req.LoginName, BrowserInfoToDomain(req.BrowserInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CheckUserResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) CheckPassword(ctx context.Context, req *session_pb.CheckPasswordRequest) (*session_pb.CheckPasswordResponse, error) {
+	session, err := s.repo.CheckSessionPassword(ctx, req.SessionId, req.SessionToken, req.Password, req.CaptchaResponse, BrowserInfoToDomain(req.BrowserInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CheckPasswordResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) CheckOTP(ctx context.Context, req *session_pb.CheckOTPRequest) (*session_pb.CheckOTPResponse, error) {
+	session, err := s.repo.CheckSessionOTP(ctx, req.SessionId, req.SessionToken, req.Code, BrowserInfoToDomain(req.BrowserInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CheckOTPResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) StartWebAuthN(ctx context.Context, req *session_pb.StartWebAuthNRequest) (*session_pb.StartWebAuthNResponse, error) {
+	login, err := s.repo.BeginSessionWebAuthN(ctx, req.SessionId, req.SessionToken, req.Passwordless)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.StartWebAuthNResponse{
+		PublicKeyCredentialRequestOptions: login.CredentialAssertionData,
+	}, nil
+}
+
+func (s *Server) CheckWebAuthN(ctx context.Context, req *session_pb.CheckWebAuthNRequest) (*session_pb.CheckWebAuthNResponse, error) {
+	session, err := s.repo.CheckSessionWebAuthN(ctx, req.SessionId, req.SessionToken, req.PublicKeyCredential, req.Passwordless, BrowserInfoToDomain(req.BrowserInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CheckWebAuthNResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) CheckIDP(ctx context.Context, req *session_pb.CheckIDPRequest) (*session_pb.CheckIDPResponse, error) {
+	session, err := s.repo.CheckSessionExternalUser(ctx, req.SessionId, req.SessionToken, req.IdpId, req.ExternalUserId, BrowserInfoToDomain(req.BrowserInfo))
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.CheckIDPResponse{
+		Session: SessionToPb(session),
+	}, nil
+}
+
+func (s *Server) FinalizeAuthRequest(ctx context.Context, req *session_pb.FinalizeAuthRequestRequest) (*session_pb.FinalizeAuthRequestResponse, error) {
+	authRequest, err := s.repo.FinalizeAuthRequest(ctx, req.SessionId, req.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	callbackURL, err := s.authRequestCallbackURL(ctx, authRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.FinalizeAuthRequestResponse{
+		CallbackUrl: callbackURL,
+	}, nil
+}
+
+func (s *Server) TerminateSession(ctx context.Context, req *session_pb.TerminateSessionRequest) (*session_pb.TerminateSessionResponse, error) {
+	details, err := s.repo.TerminateSession(ctx, req.SessionId, req.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return &session_pb.TerminateSessionResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+// authRequestCallbackURL returns the absolute url of the callback of the OIDC or SAML provider.
+// The issuer of the providers is only set on their http handlers,
+// so the callback urls are relative on the api and are prefixed with the origin of the instance.
+func (s *Server) authRequestCallbackURL(ctx context.Context, authRequest *domain.AuthRequest) (string, error) {
+	origin := http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure)
+	switch authRequest.Request.(type) {
+	case *domain.AuthRequestOIDC:
+		return origin + s.oidcAuthCallbackURL(ctx, authRequest.ID), nil
+	case *domain.AuthRequestSAML:
+		return origin + saml.HandlerPrefix + s.samlAuthCallbackURL(ctx, authRequest.ID), nil
+	default:
+		return "", caos_errs.ThrowInternal(nil, "SESSION-Sk8rl", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+}
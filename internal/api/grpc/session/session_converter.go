@@ -0,0 +1,131 @@
+package session
+
+import (
+	"net"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	session_pb "github.com/zitadel/zitadel/pkg/grpc/session"
+)
+
+func SessionToPb(session *domain.Session) *session_pb.Session {
+	return &session_pb.Session{
+		Id:            session.AggregateID,
+		Details:       object.ToViewDetailsPb(session.Sequence, session.CreationDate, session.ChangeDate, session.ResourceOwner),
+		AuthRequestId: session.AuthRequestID,
+		UserId:        session.UserID,
+		UserOrgId:     session.UserResourceOwner,
+		NextSteps:     NextStepsToPb(session.NextSteps),
+	}
+}
+
+func NextStepsToPb(steps []domain.NextStep) []*session_pb.NextStep {
+	result := make([]*session_pb.NextStep, len(steps))
+	for i, step := range steps {
+		result[i] = NextStepToPb(step)
+	}
+	return result
+}
+
+func NextStepToPb(step domain.NextStep) *session_pb.NextStep {
+	pb := &session_pb.NextStep{
+		Type: NextStepTypeToPb(step.Type()),
+	}
+	switch s := step.(type) {
+	case *domain.MFAPromptStep:
+		pb.MfaProviders = MFATypesToPb(s.MFAProviders)
+		pb.MfaRequired = s.Required
+	case *domain.MFAVerificationStep:
+		pb.MfaProviders = MFATypesToPb(s.MFAProviders)
+	case *domain.ExternalLoginStep:
+		pb.IdpId = s.SelectedIDPConfigID
+	}
+	return pb
+}
+
+func NextStepTypeToPb(stepType domain.NextStepType) session_pb.NextStepType {
+	switch stepType {
+	case domain.NextStepLogin:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_LOGIN
+	case domain.NextStepUserSelection:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_USER_SELECTION
+	case domain.NextStepInitUser:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_INIT_USER
+	case domain.NextStepPassword:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_PASSWORD
+	case domain.NextStepChangePassword:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_CHANGE_PASSWORD
+	case domain.NextStepInitPassword:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_INIT_PASSWORD
+	case domain.NextStepVerifyEmail:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_VERIFY_EMAIL
+	case domain.NextStepMFAPrompt:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_MFA_PROMPT
+	case domain.NextStepMFAVerify:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_MFA_VERIFY
+	case domain.NextStepRedirectToCallback:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_REDIRECT_TO_CALLBACK
+	case domain.NextStepChangeUsername:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_CHANGE_USERNAME
+	case domain.NextStepLinkUsers:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_LINK_USERS
+	case domain.NextStepExternalNotFoundOption:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_EXTERNAL_NOT_FOUND_OPTION
+	case domain.NextStepExternalLogin:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_EXTERNAL_LOGIN
+	case domain.NextStepGrantRequired:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_GRANT_REQUIRED
+	case domain.NextStepPasswordless:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_PASSWORDLESS
+	case domain.NextStepPasswordlessRegistrationPrompt:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_PASSWORDLESS_REGISTRATION_PROMPT
+	case domain.NextStepRegistration:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_REGISTRATION
+	case domain.NextStepProjectRequired:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_PROJECT_REQUIRED
+	case domain.NextStepRedirectToExternalIDP:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_REDIRECT_TO_EXTERNAL_IDP
+	case domain.NextStepLoginSucceeded:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_LOGIN_SUCCEEDED
+	default:
+		return session_pb.NextStepType_NEXT_STEP_TYPE_UNSPECIFIED
+	}
+}
+
+func MFATypesToPb(mfaTypes []domain.MFAType) []session_pb.MFAType {
+	result := make([]session_pb.MFAType, len(mfaTypes))
+	for i, mfaType := range mfaTypes {
+		result[i] = MFATypeToPb(mfaType)
+	}
+	return result
+}
+
+func MFATypeToPb(mfaType domain.MFAType) session_pb.MFAType {
+	switch mfaType {
+	case domain.MFATypeOTP:
+		return session_pb.MFAType_MFA_TYPE_OTP
+	case domain.MFATypeU2F:
+		return session_pb.MFAType_MFA_TYPE_U2F
+	case domain.MFATypeU2FUserVerification:
+		return session_pb.MFAType_MFA_TYPE_U2F_USER_VERIFICATION
+	case domain.MFATypeOTPSMS:
+		return session_pb.MFAType_MFA_TYPE_OTP_SMS
+	case domain.MFATypeOTPEmail:
+		return session_pb.MFAType_MFA_TYPE_OTP_EMAIL
+	case domain.MFATypeRecoveryCode:
+		return session_pb.MFAType_MFA_TYPE_RECOVERY_CODE
+	default:
+		return session_pb.MFAType_MFA_TYPE_UNSPECIFIED
+	}
+}
+
+func BrowserInfoToDomain(info *session_pb.BrowserInfo) *domain.BrowserInfo {
+	if info == nil {
+		return nil
+	}
+	return &domain.BrowserInfo{
+		UserAgent:      info.UserAgent,
+		AcceptLanguage: info.AcceptLanguage,
+		RemoteIP:       net.ParseIP(info.RemoteIp),
+	}
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) ListProjections(ctx context.Context, req *system_pb.ListProjectionsRequest) (*system_pb.ListProjectionsResponse, error) {
+	queries, err := ListProjectionsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	states, err := s.query.SearchProjectionStates(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ListProjectionsResponse{
+		Details: object.ToListDetails(states.Count, states.Sequence, states.Timestamp),
+		Result:  ProjectionStatesToPb(states.ProjectionStates),
+	}, nil
+}
+
+func (s *Server) PauseProjection(ctx context.Context, req *system_pb.PauseProjectionRequest) (*system_pb.PauseProjectionResponse, error) {
+	if err := s.query.PauseProjection(ctx, req.ProjectionName); err != nil {
+		return nil, err
+	}
+	return &system_pb.PauseProjectionResponse{}, nil
+}
+
+func (s *Server) ResumeProjection(ctx context.Context, req *system_pb.ResumeProjectionRequest) (*system_pb.ResumeProjectionResponse, error) {
+	if err := s.query.ResumeProjection(ctx, req.ProjectionName); err != nil {
+		return nil, err
+	}
+	return &system_pb.ResumeProjectionResponse{}, nil
+}
+
+func (s *Server) RebuildProjection(ctx context.Context, req *system_pb.RebuildProjectionRequest) (*system_pb.RebuildProjectionResponse, error) {
+	if err := s.query.RebuildProjection(ctx, req.ProjectionName); err != nil {
+		return nil, err
+	}
+	return &system_pb.RebuildProjectionResponse{}, nil
+}
@@ -0,0 +1,63 @@
+package system
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/query"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func ListProjectionsRequestToQuery(req *system_pb.ListProjectionsRequest) (*query.ProjectionStateSearchQueries, error) {
+	queries := make([]query.SearchQuery, 0, 2)
+	if req.ProjectionName != "" {
+		q, err := query.NewProjectionStateProjectionNameSearchQuery(req.ProjectionName)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	if req.InstanceId != "" {
+		q, err := query.NewProjectionStateInstanceIDSearchQuery(req.InstanceId)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	return &query.ProjectionStateSearchQueries{
+		Queries: queries,
+	}, nil
+}
+
+func ProjectionStatesToPb(states []*query.ProjectionState) []*system_pb.Projection {
+	p := make([]*system_pb.Projection, len(states))
+	for i, state := range states {
+		p[i] = ProjectionStateToPb(state)
+	}
+	return p
+}
+
+func ProjectionStateToPb(state *query.ProjectionState) *system_pb.Projection {
+	return &system_pb.Projection{
+		ProjectionName:    state.ProjectionName,
+		InstanceId:        state.InstanceID,
+		ProcessedSequence: state.CurrentSequence,
+		LatestSequence:    state.LatestSequence,
+		Lag:               state.Lag,
+		EventTimestamp:    timestamppb.New(state.Timestamp),
+		Paused:            state.Paused,
+		RebuildState:      ProjectionRebuildStateToPb(state.RebuildState),
+	}
+}
+
+func ProjectionRebuildStateToPb(state query.ProjectionRebuildState) system_pb.ProjectionRebuildState {
+	switch state {
+	case query.ProjectionRebuildStateRunning:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_RUNNING
+	case query.ProjectionRebuildStateDone:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_DONE
+	case query.ProjectionRebuildStateFailed:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_FAILED
+	default:
+		return system_pb.ProjectionRebuildState_PROJECTION_REBUILD_STATE_UNSPECIFIED
+	}
+}
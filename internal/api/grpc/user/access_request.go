@@ -0,0 +1,61 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func AccessRequestsToPb(requests []*query.AccessRequest) []*user_pb.AccessRequest {
+	r := make([]*user_pb.AccessRequest, len(requests))
+	for i, request := range requests {
+		r[i] = AccessRequestToPb(request)
+	}
+	return r
+}
+
+func AccessRequestToPb(request *query.AccessRequest) *user_pb.AccessRequest {
+	return &user_pb.AccessRequest{
+		Id:             request.ID,
+		State:          AccessRequestStateToPb(request.State),
+		UserId:         request.UserID,
+		ProjectId:      request.ProjectID,
+		RoleKeys:       request.RoleKeys,
+		Reason:         request.Reason,
+		DecisionReason: request.DecisionReason,
+		UserGrantId:    request.UserGrantID,
+		Details: object.ToViewDetailsPb(
+			request.Sequence,
+			request.CreationDate,
+			request.ChangeDate,
+			request.ResourceOwner,
+		),
+	}
+}
+
+func AccessRequestStateToPb(state domain.AccessRequestState) user_pb.AccessRequestState {
+	switch state {
+	case domain.AccessRequestStatePending:
+		return user_pb.AccessRequestState_ACCESS_REQUEST_STATE_PENDING
+	case domain.AccessRequestStateApproved:
+		return user_pb.AccessRequestState_ACCESS_REQUEST_STATE_APPROVED
+	case domain.AccessRequestStateDenied:
+		return user_pb.AccessRequestState_ACCESS_REQUEST_STATE_DENIED
+	default:
+		return user_pb.AccessRequestState_ACCESS_REQUEST_STATE_UNSPECIFIED
+	}
+}
+
+func AccessRequestStateToDomain(state user_pb.AccessRequestState) domain.AccessRequestState {
+	switch state {
+	case user_pb.AccessRequestState_ACCESS_REQUEST_STATE_PENDING:
+		return domain.AccessRequestStatePending
+	case user_pb.AccessRequestState_ACCESS_REQUEST_STATE_APPROVED:
+		return domain.AccessRequestStateApproved
+	case user_pb.AccessRequestState_ACCESS_REQUEST_STATE_DENIED:
+		return domain.AccessRequestStateDenied
+	default:
+		return domain.AccessRequestStateUnspecified
+	}
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func GroupsToPb(groups []*query.Group) []*user_pb.Group {
+	g := make([]*user_pb.Group, len(groups))
+	for i, group := range groups {
+		g[i] = GroupToPb(group)
+	}
+	return g
+}
+
+func GroupToPb(group *query.Group) *user_pb.Group {
+	return &user_pb.Group{
+		Id:          group.ID,
+		State:       GroupStateToPb(group.State),
+		Name:        group.Name,
+		Description: group.Description,
+		Details: object.ToViewDetailsPb(
+			group.Sequence,
+			group.CreationDate,
+			group.ChangeDate,
+			group.ResourceOwner,
+		),
+	}
+}
+
+func GroupStateToPb(state domain.GroupState) user_pb.GroupState {
+	switch state {
+	case domain.GroupStateActive:
+		return user_pb.GroupState_GROUP_STATE_ACTIVE
+	default:
+		return user_pb.GroupState_GROUP_STATE_UNSPECIFIED
+	}
+}
+
+func GroupQueriesToQuery(queries []*user_pb.GroupQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = GroupQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func GroupQueryToQuery(q *user_pb.GroupQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *user_pb.GroupQuery_NameQuery:
+		return query.NewGroupNameSearchQuery(object.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "USER-Gr4qe", "Errors.List.Query.Invalid")
+	}
+}
+
+func GroupMembersToPb(members []*query.GroupMember) []*user_pb.GroupMember {
+	m := make([]*user_pb.GroupMember, len(members))
+	for i, member := range members {
+		m[i] = &user_pb.GroupMember{
+			UserId:             member.UserID,
+			PreferredLoginName: member.PreferredLoginName,
+			DisplayName:        member.DisplayName,
+			Details: object.ToViewDetailsPb(
+				member.Sequence,
+				member.CreationDate,
+				member.ChangeDate,
+				member.ResourceOwner,
+			),
+		}
+	}
+	return m
+}
+
+func GroupGrantsToPb(grants []*query.GroupGrant) []*user_pb.GroupGrant {
+	g := make([]*user_pb.GroupGrant, len(grants))
+	for i, grant := range grants {
+		g[i] = &user_pb.GroupGrant{
+			Id:             grant.ID,
+			GroupId:        grant.GroupID,
+			ProjectId:      grant.ProjectID,
+			ProjectName:    grant.ProjectName,
+			ProjectGrantId: grant.GrantID,
+			RoleKeys:       grant.RoleKeys,
+			Details: object.ToViewDetailsPb(
+				grant.Sequence,
+				grant.CreationDate,
+				grant.ChangeDate,
+				grant.ResourceOwner,
+			),
+		}
+	}
+	return g
+}
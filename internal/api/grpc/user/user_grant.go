@@ -38,6 +38,8 @@ func UserGrantToPb(assetPrefix string, grant *query.UserGrant) *user_pb.UserGran
 		ProjectName:        grant.ProjectName,
 		AvatarUrl:          domain.AvatarURL(assetPrefix, grant.UserResourceOwner, grant.AvatarURL),
 		PreferredLoginName: grant.PreferredLoginName,
+		ValidFrom:          object.TimeToPb(grant.ValidFrom),
+		ValidUntil:         object.TimeToPb(grant.ValidUntil),
 		Details: object.ToViewDetailsPb(
 			grant.Sequence,
 			grant.CreationDate,
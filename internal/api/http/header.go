@@ -2,9 +2,13 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+
+	"github.com/zitadel/logging"
 )
 
 const (
@@ -36,6 +40,9 @@ const (
 	PermissionsPolicy       = "permissions-policy"
 
 	ZitadelOrgID = "x-zitadel-orgid"
+
+	// DPoP is the header of the DPoP proof (RFC 9449) for sender-constrained access tokens
+	DPoP = "dpop"
 )
 
 type key int
@@ -67,16 +74,46 @@ func OriginFromCtx(ctx context.Context) string {
 	return headers.Get(Origin)
 }
 
-func RemoteIPFromCtx(ctx context.Context) string {
-	ctxHeaders, ok := HeadersFromCtx(ctx)
-	if !ok {
-		return RemoteAddrFromCtx(ctx)
+var (
+	// trustedProxies are the networks of the proxies whose x-forwarded-for header is trusted
+	trustedProxies []*net.IPNet
+	// untrustedForwardedFor warns only once about x-forwarded-for headers of untrusted peers,
+	// as every request of a proxy missing in the trusted proxies would log it
+	untrustedForwardedFor sync.Once
+)
+
+// SetTrustedProxies sets the ips or networks (CIDR) of the proxies in front of ZITADEL.
+// The x-forwarded-for header is ignored if the request isn't sent by one of them.
+func SetTrustedProxies(proxies []string) error {
+	networks := make([]*net.IPNet, len(proxies))
+	for i, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			networks[i] = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		networks[i] = network
 	}
-	forwarded, ok := GetForwardedFor(ctxHeaders)
-	if ok {
-		return forwarded
+	trustedProxies = networks
+	if len(networks) == 0 {
+		logging.Info("no trusted proxies configured, the x-forwarded-for header is ignored and the ip of the peer is used as client ip")
 	}
-	return RemoteAddrFromCtx(ctx)
+	return nil
+}
+
+func RemoteIPFromCtx(ctx context.Context) string {
+	ctxHeaders, _ := HeadersFromCtx(ctx)
+	return remoteIP(ctxHeaders, RemoteAddrFromCtx(ctx))
 }
 
 func RemoteIPFromRequest(r *http.Request) net.IP {
@@ -84,31 +121,64 @@ func RemoteIPFromRequest(r *http.Request) net.IP {
 }
 
 func RemoteIPStringFromRequest(r *http.Request) string {
-	ip, ok := GetForwardedFor(r.Header)
-	if ok {
-		return ip
+	return remoteIP(r.Header, r.RemoteAddr)
+}
+
+// remoteIP returns the ip of the client
+// the x-forwarded-for header is read from right to left as long as the sender is a trusted proxy,
+// so clients can't spoof their ip by sending the header themselves
+func remoteIP(headers http.Header, remoteAddr string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	forwarded := forwardedFor(headers)
+	if len(forwarded) > 0 && !isTrustedProxy(ip) {
+		untrustedForwardedFor.Do(func() {
+			logging.WithFields("peer", ip).Warn("x-forwarded-for header of an untrusted peer is ignored, add the ip of the proxy to TrustedProxies if ZITADEL runs behind it")
+		})
+	}
+	for i := len(forwarded) - 1; i >= 0 && isTrustedProxy(ip); i-- {
+		ip = forwarded[i]
 	}
-	host, _, _ := net.SplitHostPort(r.RemoteAddr)
-	return host
+	return ip
+}
+
+func forwardedFor(headers http.Header) []string {
+	ips := make([]string, 0)
+	for _, value := range headers.Values(ForwardedFor) {
+		for _, ip := range strings.Split(value, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return ips
+}
+
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
 }
 
 func GetAuthorization(r *http.Request) string {
 	return r.Header.Get(Authorization)
 }
 
-func GetOrgID(r *http.Request) string {
-	return r.Header.Get(ZitadelOrgID)
+func GetDPoP(r *http.Request) string {
+	return r.Header.Get(DPoP)
 }
 
-func GetForwardedFor(headers http.Header) (string, bool) {
-	forwarded, ok := headers[ForwardedFor]
-	if ok {
-		ip := strings.TrimSpace(strings.Split(forwarded[0], ",")[0])
-		if ip != "" {
-			return ip, true
-		}
-	}
-	return "", false
+func GetOrgID(r *http.Request) string {
+	return r.Header.Get(ZitadelOrgID)
 }
 
 func RemoteAddrFromCtx(ctx context.Context) string {
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteIPStringFromRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		want           string
+	}{
+		{
+			name:       "no proxy",
+			remoteAddr: "192.168.1.1:1234",
+			want:       "192.168.1.1",
+		},
+		{
+			name:         "forwarded for ignored without trusted proxies",
+			remoteAddr:   "192.168.1.1:1234",
+			forwardedFor: []string{"10.0.0.1"},
+			want:         "192.168.1.1",
+		},
+		{
+			name:           "forwarded for ignored from untrusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.168.1.1:1234",
+			forwardedFor:   []string{"172.16.0.1"},
+			want:           "192.168.1.1",
+		},
+		{
+			name:           "trusted proxy",
+			trustedProxies: []string{"10.0.0.1"},
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"192.168.1.1"},
+			want:           "192.168.1.1",
+		},
+		{
+			name:           "spoofed ip of client ignored",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"1.1.1.1, 192.168.1.1", "10.0.0.2"},
+			want:           "192.168.1.1",
+		},
+		{
+			name:           "only trusted proxies",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   []string{"10.0.0.3, 10.0.0.2"},
+			want:           "10.0.0.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetTrustedProxies(tt.trustedProxies))
+			t.Cleanup(func() { trustedProxies = nil })
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}}
+			for _, forwarded := range tt.forwardedFor {
+				r.Header.Add(ForwardedFor, forwarded)
+			}
+			assert.Equal(t, tt.want, RemoteIPStringFromRequest(r))
+		})
+	}
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	t.Cleanup(func() { trustedProxies = nil })
+	assert.NoError(t, SetTrustedProxies([]string{"10.0.0.1", "fd00::/8"}))
+	assert.Error(t, SetTrustedProxies([]string{"10.0.0"}))
+	assert.Error(t, SetTrustedProxies([]string{"10.0.0.0/33"}))
+}
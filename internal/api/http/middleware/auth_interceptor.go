@@ -7,24 +7,27 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
 type AuthInterceptor struct {
-	verifier   *authz.TokenVerifier
-	authConfig authz.Config
+	verifier       *authz.TokenVerifier
+	authConfig     authz.Config
+	externalSecure bool
 }
 
-func AuthorizationInterceptor(verifier *authz.TokenVerifier, authConfig authz.Config) *AuthInterceptor {
+func AuthorizationInterceptor(verifier *authz.TokenVerifier, authConfig authz.Config, externalSecure bool) *AuthInterceptor {
 	return &AuthInterceptor{
-		verifier:   verifier,
-		authConfig: authConfig,
+		verifier:       verifier,
+		authConfig:     authConfig,
+		externalSecure: externalSecure,
 	}
 }
 
 func (a *AuthInterceptor) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, err := authorize(r, a.verifier, a.authConfig)
+		ctx, err := authorize(r, a.verifier, a.authConfig, a.externalSecure)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -36,7 +39,7 @@ func (a *AuthInterceptor) Handler(next http.Handler) http.Handler {
 
 func (a *AuthInterceptor) HandlerFunc(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, err := authorize(r, a.verifier, a.authConfig)
+		ctx, err := authorize(r, a.verifier, a.authConfig, a.externalSecure)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -48,7 +51,7 @@ func (a *AuthInterceptor) HandlerFunc(next http.HandlerFunc) http.HandlerFunc {
 
 type httpReq struct{}
 
-func authorize(r *http.Request, verifier *authz.TokenVerifier, authConfig authz.Config) (_ context.Context, err error) {
+func authorize(r *http.Request, verifier *authz.TokenVerifier, authConfig authz.Config, externalSecure bool) (_ context.Context, err error) {
 	ctx := r.Context()
 	authOpt, needsToken := verifier.CheckAuthMethod(r.Method + ":" + r.RequestURI)
 	if !needsToken {
@@ -62,6 +65,17 @@ func authorize(r *http.Request, verifier *authz.TokenVerifier, authConfig authz.
 		return nil, errors.New("auth header missing")
 	}
 
+	thumbprint, err := pop.ClientCertificateThumbprint(r, authConfig.ClientCertificateHeader)
+	if err != nil {
+		return nil, err
+	}
+	authCtx = pop.WithProof(authCtx, &pop.Proof{
+		DPoP:                  http_util.GetDPoP(r),
+		Method:                r.Method,
+		URL:                   http_util.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + r.URL.Path,
+		CertificateThumbprint: thumbprint,
+	})
+
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, &httpReq{}, authToken, http_util.GetOrgID(r), verifier, authConfig, authOpt, r.RequestURI)
 	if err != nil {
 		return nil, err
@@ -18,6 +18,7 @@ var (
 			http_utils.AcceptLanguage,
 			http_utils.Authorization,
 			http_utils.ZitadelOrgID,
+			http_utils.DPoP,
 			http_utils.XUserAgent,
 			http_utils.XGrpcWeb,
 		},
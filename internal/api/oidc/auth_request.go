@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -89,12 +90,12 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		userOrgID = authReq.UserOrgID
 	}
 
-	accessTokenLifetime, _, _, _, err := o.getOIDCSettings(ctx)
+	tokenSettings, err := o.getTokenSettings(ctx, applicationID)
 	if err != nil {
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), tokenSettings.AccessTokenLifetime, confirmationFromContext(ctx))
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -113,14 +114,14 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 		request.SetCurrentScopes(scopes)
 	}
 
-	accessTokenLifetime, _, refreshTokenIdleExpiration, refreshTokenExpiration, err := o.getOIDCSettings(ctx)
+	tokenSettings, err := o.getTokenSettings(ctx, applicationID)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
-		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshToken, req.GetAudience(), scopes, authMethodsReferences, tokenSettings.AccessTokenLifetime,
+		tokenSettings.RefreshTokenIdleExpiration, tokenSettings.RefreshTokenExpiration, authTime, tokenSettings.RefreshTokenRotation, confirmationFromContext(ctx))
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -169,9 +170,34 @@ func (o *OPStorage) TerminateSession(ctx context.Context, userID, clientID strin
 	data := authz.CtxData{
 		UserID: userID,
 	}
-	err = o.command.HumansSignOut(authz.SetCtxData(ctx, data), userAgentID, userIDs)
-	logging.OnError(err).Error("error signing out")
-	return err
+	sessionClientIDs, err := o.query.SessionClientIDs(ctx, userAgentID, userIDs)
+	if err != nil {
+		logging.WithError(err).Error("error retrieving clients of user sessions")
+		return err
+	}
+	clientIDs, err := o.command.HumansSignOut(authz.SetCtxData(ctx, data), userAgentID, userIDs, sessionClientIDs)
+	if err != nil {
+		logging.WithError(err).Error("error signing out")
+		return err
+	}
+	setFrontChannelLogoutClients(ctx, o.frontChannelLogoutClients(ctx, clientIDs))
+	return nil
+}
+
+// frontChannelLogoutClients filters the signed out clients for the ones with a front-channel logout uri
+func (o *OPStorage) frontChannelLogoutClients(ctx context.Context, clientIDs []string) []string {
+	frontChannelClientIDs := make([]string, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		app, err := o.query.AppByOIDCClientID(ctx, clientID)
+		if err != nil {
+			logging.WithFields("client_id", clientID).WithError(err).Warn("unable to get client for front-channel logout")
+			continue
+		}
+		if app.OIDCConfig.FrontChannelLogoutURI != "" {
+			frontChannelClientIDs = append(frontChannelClientIDs, clientID)
+		}
+	}
+	return frontChannelClientIDs
 }
 
 func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID string) *oidc.Error {
@@ -272,3 +298,29 @@ func (o *OPStorage) getOIDCSettings(ctx context.Context) (accessTokenLifetime, i
 	}
 	return o.defaultAccessTokenLifetime, o.defaultIdTokenLifetime, o.defaultRefreshTokenIdleExpiration, o.defaultRefreshTokenExpiration, nil
 }
+
+// getTokenSettings returns the token lifetimes and refresh token rotation of the client,
+// lifetimes not overridden by the application are taken from the OIDC settings of the instance
+func (o *OPStorage) getTokenSettings(ctx context.Context, clientID string) (_ domain.AppTokenSettings, err error) {
+	var defaults domain.AppTokenSettings
+	defaults.AccessTokenLifetime, defaults.IDTokenLifetime, defaults.RefreshTokenIdleExpiration, defaults.RefreshTokenExpiration, err = o.getOIDCSettings(ctx)
+	if err != nil || clientID == "" {
+		return defaults, err
+	}
+	app, err := o.query.AppByClientID(ctx, clientID)
+	if err != nil {
+		return domain.AppTokenSettings{}, err
+	}
+	return appTokenSettings(app).WithDefaults(defaults), nil
+}
+
+func appTokenSettings(app *query.App) domain.AppTokenSettings {
+	switch {
+	case app.OIDCConfig != nil:
+		return app.OIDCConfig.AppTokenSettings
+	case app.APIConfig != nil:
+		return app.APIConfig.AppTokenSettings
+	default:
+		return domain.AppTokenSettings{}
+	}
+}
@@ -23,6 +23,7 @@ const (
 	amrPWD          = "pwd"
 	amrMFA          = "mfa"
 	amrOTP          = "otp"
+	amrSMS          = "sms"
 	amrUserPresence = "user"
 )
 
@@ -279,8 +280,11 @@ func CodeChallengeToOIDC(challenge *domain.OIDCCodeChallenge) *oidc.CodeChalleng
 
 func AMRFromMFAType(mfaType domain.MFAType) string {
 	switch mfaType {
-	case domain.MFATypeOTP:
+	case domain.MFATypeOTP,
+		domain.MFATypeOTPEmail:
 		return amrOTP
+	case domain.MFATypeOTPSMS:
+		return amrSMS
 	case domain.MFATypeU2F,
 		domain.MFATypeU2FUserVerification:
 		return amrUserPresence
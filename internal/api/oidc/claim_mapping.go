@@ -0,0 +1,261 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type claimMappingTarget int
+
+const (
+	claimMappingTargetIDToken claimMappingTarget = iota
+	claimMappingTargetAccessToken
+	claimMappingTargetUserinfo
+)
+
+func (t claimMappingTarget) includes(mapping *query.ClaimMapping) bool {
+	switch t {
+	case claimMappingTargetIDToken:
+		return mapping.IDToken
+	case claimMappingTargetAccessToken:
+		return mapping.AccessToken
+	case claimMappingTargetUserinfo:
+		return mapping.Userinfo
+	default:
+		return false
+	}
+}
+
+// claimMappingSources lazily loads the data the claim mappings of an application are resolved from
+type claimMappingSources struct {
+	o      *OPStorage
+	userID string
+
+	user     *query.User
+	metadata map[string]string
+	org      *query.Org
+	roles    map[string][]string
+}
+
+func (s *claimMappingSources) getUser(ctx context.Context) (*query.User, error) {
+	if s.user != nil {
+		return s.user, nil
+	}
+	user, err := s.o.query.GetUserByID(ctx, true, s.userID)
+	if err != nil {
+		return nil, err
+	}
+	s.user = user
+	return user, nil
+}
+
+func (s *claimMappingSources) getMetadata(ctx context.Context) (map[string]string, error) {
+	if s.metadata != nil {
+		return s.metadata, nil
+	}
+	metadata, err := s.o.query.SearchUserMetadata(ctx, true, s.userID, &query.UserMetadataSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	s.metadata = make(map[string]string, len(metadata.Metadata))
+	for _, md := range metadata.Metadata {
+		s.metadata[md.Key] = string(md.Value)
+	}
+	return s.metadata, nil
+}
+
+func (s *claimMappingSources) getOrg(ctx context.Context) (*query.Org, error) {
+	if s.org != nil {
+		return s.org, nil
+	}
+	user, err := s.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.o.query.OrgByID(ctx, true, user.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	s.org = org
+	return org, nil
+}
+
+// getRoles returns the unique role keys granted to the user on the project,
+// directly or through the membership of a group
+func (s *claimMappingSources) getRoles(ctx context.Context, projectID string) ([]string, error) {
+	if roles, ok := s.roles[projectID]; ok {
+		return roles, nil
+	}
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(s.userID)
+	if err != nil {
+		return nil, err
+	}
+	activeQueries, err := query.NewUserGrantActiveQueries(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.o.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: append([]query.SearchQuery{projectQuery, userIDQuery}, activeQueries...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupProjectQuery, err := query.NewGroupGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	groupGrants, err := s.o.query.UserGroupGrants(ctx, s.userID, groupProjectQuery)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0)
+	for _, grant := range append(grants.UserGrants, groupGrants.UserGrants...) {
+		for _, role := range grant.Roles {
+			if !containsRole(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if s.roles == nil {
+		s.roles = make(map[string][]string)
+	}
+	s.roles[projectID] = roles
+	return roles, nil
+}
+
+// mappedClaims resolves the declarative claim mappings of the application for the requested target.
+// They are evaluated before the actions of the customise token flow, so actions can still read and extend them.
+func (o *OPStorage) mappedClaims(ctx context.Context, userID, clientID string, target claimMappingTarget) (claims map[string]interface{}, err error) {
+	if clientID == "" {
+		return nil, nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	mappings, err := o.query.ClaimMappingsByOIDCClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	sources := &claimMappingSources{o: o, userID: userID}
+	for _, mapping := range mappings.ClaimMappings {
+		if !target.includes(mapping) {
+			continue
+		}
+		value, ok, err := sources.value(ctx, mapping)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		converted, err := mapping.Type.Convert(value)
+		if err != nil {
+			logging.WithFields("claim", mapping.Claim, "app", mapping.AppID).WithError(err).Info("unable to convert mapped claim")
+			continue
+		}
+		claims = appendClaim(claims, mapping.Claim, converted)
+	}
+	return claims, nil
+}
+
+// value returns the value of the source of the mapping,
+// ok is false if the source has no value for the user (e.g. a missing metadata key)
+func (s *claimMappingSources) value(ctx context.Context, mapping *query.ClaimMapping) (_ interface{}, ok bool, err error) {
+	switch mapping.Source {
+	case domain.ClaimMappingSourceProfile:
+		user, err := s.getUser(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		value, ok := profileClaimValue(user, mapping.SourceKey)
+		return value, ok, nil
+	case domain.ClaimMappingSourceMetadata:
+		metadata, err := s.getMetadata(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		value, ok := metadata[mapping.SourceKey]
+		return value, ok, nil
+	case domain.ClaimMappingSourceOrg:
+		org, err := s.getOrg(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		switch mapping.SourceKey {
+		case domain.ClaimMappingOrgID:
+			return org.ID, true, nil
+		case domain.ClaimMappingOrgName:
+			return org.Name, true, nil
+		case domain.ClaimMappingOrgPrimaryDomain:
+			return org.Domain, true, nil
+		}
+	case domain.ClaimMappingSourceRoles:
+		roles, err := s.getRoles(ctx, mapping.ProjectID)
+		if err != nil {
+			return nil, false, err
+		}
+		return roles, true, nil
+	}
+	return nil, false, nil
+}
+
+func profileClaimValue(user *query.User, field string) (interface{}, bool) {
+	switch field {
+	case domain.ClaimMappingProfileUserID:
+		return user.ID, true
+	case domain.ClaimMappingProfileUsername:
+		return user.Username, true
+	case domain.ClaimMappingProfilePreferredLoginName:
+		return user.PreferredLoginName, true
+	case domain.ClaimMappingProfileDisplayName:
+		if user.Machine != nil {
+			return user.Machine.Name, true
+		}
+	}
+	if user.Human == nil {
+		return nil, false
+	}
+	switch field {
+	case domain.ClaimMappingProfileFirstName:
+		return user.Human.FirstName, true
+	case domain.ClaimMappingProfileLastName:
+		return user.Human.LastName, true
+	case domain.ClaimMappingProfileNickName:
+		return user.Human.NickName, true
+	case domain.ClaimMappingProfileDisplayName:
+		return user.Human.DisplayName, true
+	case domain.ClaimMappingProfileEmail:
+		return user.Human.Email, true
+	case domain.ClaimMappingProfileEmailVerified:
+		return user.Human.IsEmailVerified, true
+	case domain.ClaimMappingProfilePhone:
+		return user.Human.Phone, user.Human.Phone != ""
+	case domain.ClaimMappingProfilePhoneVerified:
+		return user.Human.IsPhoneVerified, user.Human.Phone != ""
+	case domain.ClaimMappingProfilePreferredLanguage:
+		return user.Human.PreferredLanguage.String(), true
+	case domain.ClaimMappingProfileGender:
+		gender := getGender(user.Human.Gender)
+		return string(gender), gender != ""
+	}
+	return nil, false
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
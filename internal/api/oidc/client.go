@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dop251/goja"
 	"github.com/zitadel/logging"
@@ -17,6 +18,7 @@ import (
 	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -31,6 +33,8 @@ const (
 	ClaimUserMetaData      = ScopeUserMetaData
 	ScopeResourceOwner     = "urn:zitadel:iam:user:resourceowner"
 	ClaimResourceOwner     = ScopeResourceOwner + ":"
+	ScopeUserGroups        = "urn:zitadel:iam:user:groups"
+	ClaimUserGroups        = "groups"
 	ClaimActionLogFormat   = "urn:zitadel:iam:action:%s:log"
 
 	oidcCtx = "oidc"
@@ -75,6 +79,12 @@ func (o *OPStorage) GetKeyByIDAndIssuer(ctx context.Context, keyID, issuer strin
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	publicKeyData, err := o.query.GetAuthNKeyPublicKeyByIDAndIdentifier(ctx, keyID, issuer)
+	if errors.IsNotFound(err) {
+		// the issuer might be a client which registered its keys as JWKS
+		if key, jwksErr := o.getKeyFromClientJWKS(ctx, keyID, issuer); jwksErr == nil {
+			return key, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +99,33 @@ func (o *OPStorage) GetKeyByIDAndIssuer(ctx context.Context, keyID, issuer strin
 	}, nil
 }
 
+// getKeyFromClientJWKS returns the key of the JWKS registered on the oidc application of the client.
+// If no keyID is provided, the key is only returned if the set contains a single key.
+func (o *OPStorage) getKeyFromClientJWKS(ctx context.Context, keyID, clientID string) (*jose.JSONWebKey, error) {
+	app, err := o.query.AppByOIDCClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if app.State != domain.AppStateActive || app.OIDCConfig == nil || app.OIDCConfig.JWKS == "" {
+		return nil, errors.ThrowNotFound(nil, "OIDC-Ju2lq", "Errors.AuthNKey.NotFound")
+	}
+	keySet := new(jose.JSONWebKeySet)
+	if err = json.Unmarshal([]byte(app.OIDCConfig.JWKS), keySet); err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Wq3nf", "Errors.Internal")
+	}
+	if keyID == "" {
+		if len(keySet.Keys) == 1 {
+			return &keySet.Keys[0], nil
+		}
+		return nil, errors.ThrowNotFound(nil, "OIDC-Pf2mz", "Errors.AuthNKey.NotFound")
+	}
+	keys := keySet.Key(keyID)
+	if len(keys) == 0 {
+		return nil, errors.ThrowNotFound(nil, "OIDC-Hq8sk", "Errors.AuthNKey.NotFound")
+	}
+	return &keys[0], nil
+}
+
 func (o *OPStorage) ValidateJWTProfileScopes(ctx context.Context, subject string, scopes []string) ([]string, error) {
 	user, err := o.query.GetUserByID(ctx, true, subject)
 	if err != nil {
@@ -143,6 +180,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo oidc.User
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if err = (*pop.Confirmation)(&token.Confirmation).Verify(ctx, o.usedProofs, pop.ProofFromContext(ctx)); err != nil {
+		return errors.ThrowPermissionDenied(err, "OIDC-Rf3sq", "token is not valid for the presented proof")
+	}
 	if token.ApplicationID != "" {
 		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID)
 		if err != nil {
@@ -152,7 +192,7 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo oidc.User
 			return errors.ThrowPermissionDenied(nil, "OIDC-da1f3", "origin is not allowed")
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes)
+	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, claimMappingTargetUserinfo)
 }
 
 func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo oidc.UserInfoSetter, userID, applicationID string, scopes []string) (err error) {
@@ -170,7 +210,7 @@ func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo oidc.Use
 			}
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, userID, applicationID, scopes)
+	return o.setUserinfo(ctx, userInfo, userID, applicationID, scopes, claimMappingTargetIDToken)
 }
 
 func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection oidc.IntrospectionResponse, tokenID, subject, clientID string) error {
@@ -190,13 +230,20 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 	}
 	for _, aud := range token.Audience {
 		if aud == clientID || aud == projectID {
-			err := o.setUserinfo(ctx, introspection, token.UserID, clientID, token.Scopes)
+			err := o.setUserinfo(ctx, introspection, token.UserID, clientID, token.Scopes, claimMappingTargetAccessToken)
 			if err != nil {
 				return err
 			}
 			introspection.SetScopes(token.Scopes)
 			introspection.SetClientID(token.ApplicationID)
 			introspection.SetTokenType(oidc.BearerToken)
+			if token.Confirmation.JKT != "" {
+				introspection.SetTokenType(pop.TokenTypeDPoP)
+			}
+			// the resource server has to check the binding of sender-constrained tokens
+			if token.Confirmation.IsBound() {
+				introspection.AppendClaims(pop.ClaimConfirmation, &token.Confirmation)
+			}
 			introspection.SetExpiration(token.Expiration)
 			introspection.SetIssuedAt(token.CreationDate)
 			introspection.SetNotBefore(token.CreationDate)
@@ -209,7 +256,7 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 	return errors.ThrowPermissionDenied(nil, "OIDC-sdg3G", "token is not valid for this client")
 }
 
-func (o *OPStorage) setUserinfo(ctx context.Context, userInfo oidc.UserInfoSetter, userID, applicationID string, scopes []string) (err error) {
+func (o *OPStorage) setUserinfo(ctx context.Context, userInfo oidc.UserInfoSetter, userID, applicationID string, scopes []string, target claimMappingTarget) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	user, err := o.query.GetUserByID(ctx, true, userID)
@@ -263,6 +310,14 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo oidc.UserInfoSette
 			for claim, value := range resourceOwnerClaims {
 				userInfo.AppendClaims(claim, value)
 			}
+		case ScopeUserGroups:
+			groups, err := o.assertUserGroups(ctx, userID)
+			if err != nil {
+				return err
+			}
+			if len(groups) > 0 {
+				userInfo.AppendClaims(ClaimUserGroups, groups)
+			}
 
 		default:
 			if strings.HasPrefix(scope, ScopeProjectRolePrefix) {
@@ -284,15 +339,26 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo oidc.UserInfoSette
 		}
 	}
 
-	if len(roles) == 0 || applicationID == "" {
-		return o.userinfoFlows(ctx, user.ResourceOwner, userInfo)
+	if len(roles) > 0 && applicationID != "" {
+		projectRoles, err := o.assertRoles(ctx, userID, applicationID, roles)
+		if err != nil {
+			return err
+		}
+		if len(projectRoles) > 0 {
+			userInfo.AppendClaims(ClaimProjectRoles, projectRoles)
+		}
 	}
-	projectRoles, err := o.assertRoles(ctx, userID, applicationID, roles)
+
+	mappedClaims, err := o.mappedClaims(ctx, userID, applicationID, target)
 	if err != nil {
 		return err
 	}
-	if len(projectRoles) > 0 {
-		userInfo.AppendClaims(ClaimProjectRoles, projectRoles)
+	for claim, value := range mappedClaims {
+		// mappings never overwrite the claims asserted by ZITADEL
+		if userInfo.GetClaim(claim) != nil {
+			continue
+		}
+		userInfo.AppendClaims(claim, value)
 	}
 
 	return o.userinfoFlows(ctx, user.ResourceOwner, userInfo)
@@ -417,6 +483,14 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			for claim, value := range resourceOwnerClaims {
 				claims = appendClaim(claims, claim, value)
 			}
+		case ScopeUserGroups:
+			groups, err := o.assertUserGroups(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			if len(groups) > 0 {
+				claims = appendClaim(claims, ClaimUserGroups, groups)
+			}
 		}
 		if strings.HasPrefix(scope, ScopeProjectRolePrefix) {
 			roles = append(roles, strings.TrimPrefix(scope, ScopeProjectRolePrefix))
@@ -436,15 +510,30 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
-	if len(roles) == 0 || clientID == "" {
-		return o.privateClaimsFlows(ctx, userID, claims)
+	if len(roles) > 0 && clientID != "" {
+		projectRoles, err := o.assertRoles(ctx, userID, clientID, roles)
+		if err != nil {
+			return nil, err
+		}
+		if len(projectRoles) > 0 {
+			claims = appendClaim(claims, ClaimProjectRoles, projectRoles)
+		}
 	}
-	projectRoles, err := o.assertRoles(ctx, userID, clientID, roles)
+
+	mappedClaims, err := o.mappedClaims(ctx, userID, clientID, claimMappingTargetAccessToken)
 	if err != nil {
 		return nil, err
 	}
-	if len(projectRoles) > 0 {
-		claims = appendClaim(claims, ClaimProjectRoles, projectRoles)
+	for claim, value := range mappedClaims {
+		// mappings never overwrite the claims asserted by ZITADEL
+		if _, ok := claims[claim]; ok {
+			continue
+		}
+		claims = appendClaim(claims, claim, value)
+	}
+	// JWT access tokens carry the key they are bound to (RFC 9449 and RFC 8705)
+	if confirmation := pop.ConfirmationFromContext(ctx); confirmation != nil {
+		claims = appendClaim(claims, pop.ClaimConfirmation, confirmation)
 	}
 
 	return o.privateClaimsFlows(ctx, userID, claims)
@@ -566,12 +655,25 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 	if err != nil {
 		return nil, err
 	}
+	activeQueries, err := query.NewUserGrantActiveQueries(time.Now())
+	if err != nil {
+		return nil, err
+	}
 	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{
-		Queries: []query.SearchQuery{projectQuery, userIDQuery},
+		Queries: append([]query.SearchQuery{projectQuery, userIDQuery}, activeQueries...),
 	})
 	if err != nil {
 		return nil, err
 	}
+	groupProjectQuery, err := query.NewGroupGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	groupGrants, err := o.query.UserGroupGrants(ctx, userID, groupProjectQuery)
+	if err != nil {
+		return nil, err
+	}
+	grants.UserGrants = append(grants.UserGrants, groupGrants.UserGrants...)
 	projectRoles := make(map[string]map[string]string)
 	for _, requestedRole := range requestedRoles {
 		for _, grant := range grants.UserGrants {
@@ -594,6 +696,18 @@ func (o *OPStorage) assertUserMetaData(ctx context.Context, userID string) (map[
 	return userMetaData, nil
 }
 
+func (o *OPStorage) assertUserGroups(ctx context.Context, userID string) ([]string, error) {
+	groups, err := o.query.UserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(groups.Groups))
+	for i, group := range groups.Groups {
+		names[i] = group.Name
+	}
+	return names, nil
+}
+
 func (o *OPStorage) assertUserResourceOwner(ctx context.Context, userID string) (map[string]string, error) {
 	user, err := o.query.GetUserByID(ctx, true, userID)
 	if err != nil {
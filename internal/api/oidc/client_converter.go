@@ -88,11 +88,17 @@ func (c *Client) RestrictAdditionalAccessTokenScopes() func(scopes []string) []s
 }
 
 func (c *Client) AccessTokenLifetime() time.Duration {
-	return c.defaultAccessTokenLifetime //PLANNED: impl from real client
+	if lifetime := c.app.OIDCConfig.AccessTokenLifetime; lifetime > 0 {
+		return lifetime
+	}
+	return c.defaultAccessTokenLifetime
 }
 
 func (c *Client) IDTokenLifetime() time.Duration {
-	return c.defaultIdTokenLifetime //PLANNED: impl from real client
+	if lifetime := c.app.OIDCConfig.IDTokenLifetime; lifetime > 0 {
+		return lifetime
+	}
+	return c.defaultIdTokenLifetime
 }
 
 func (c *Client) AccessTokenType() op.AccessTokenType {
@@ -118,6 +124,9 @@ func (c *Client) IsScopeAllowed(scope string) bool {
 	if strings.HasPrefix(scope, ScopeResourceOwner) {
 		return true
 	}
+	if scope == ScopeUserGroups {
+		return true
+	}
 	for _, allowedScope := range c.allowedScopes {
 		if scope == allowedScope {
 			return true
@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+)
+
+type frontChannelLogoutKey struct{}
+
+// frontChannelLogoutClients are the clients of the terminated session which have a front-channel logout uri
+type frontChannelLogoutClients struct {
+	clientIDs []string
+}
+
+// frontChannelLogout redirects the user agent to the logout page of the login after the session was terminated on the end_session endpoint,
+// where the front-channel logout uris of the signed out clients are rendered in iframes (OpenID Connect Front-Channel Logout).
+// The logout page continues to the post_logout_redirect_uri the library would have redirected to.
+type frontChannelLogout struct {
+	endSessionEndpoint op.Endpoint
+	logoutPath         string
+}
+
+func newFrontChannelLogout(config Config, logoutPath string) *frontChannelLogout {
+	f := &frontChannelLogout{
+		endSessionEndpoint: op.DefaultEndpoints.EndSession,
+		logoutPath:         logoutPath,
+	}
+	if config.CustomEndpoints != nil && config.CustomEndpoints.EndSession != nil {
+		f.endSessionEndpoint = op.NewEndpointWithURL(config.CustomEndpoints.EndSession.Path, config.CustomEndpoints.EndSession.URL)
+	}
+	return f
+}
+
+func (f *frontChannelLogout) interceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != f.endSessionEndpoint.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		clients := new(frontChannelLogoutClients)
+		writer := &endSessionResponseWriter{
+			ResponseWriter: w,
+			logout:         f,
+			clients:        clients,
+		}
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), frontChannelLogoutKey{}, clients)))
+	})
+}
+
+// logoutURL returns the url of the logout page rendering the front-channel logout uris of the clients
+// and continuing to the redirect of the end_session endpoint
+func (f *frontChannelLogout) logoutURL(redirect string, clientIDs []string) string {
+	values := make(url.Values, 2)
+	values[login.QueryLogoutClientID] = clientIDs
+	if !strings.HasPrefix(redirect, f.logoutPath) {
+		values.Set(login.QueryPostLogoutRedirect, redirect)
+	}
+	return f.logoutPath + "?" + values.Encode()
+}
+
+// setFrontChannelLogoutClients passes the signed out clients with a front-channel logout uri
+// from the TerminateSession call to the interceptor of the end_session endpoint
+func setFrontChannelLogoutClients(ctx context.Context, clientIDs []string) {
+	clients, ok := ctx.Value(frontChannelLogoutKey{}).(*frontChannelLogoutClients)
+	if !ok {
+		return
+	}
+	clients.clientIDs = clientIDs
+}
+
+// endSessionResponseWriter replaces the redirect of the end_session endpoint
+// by the logout page if any signed out client has a front-channel logout uri
+type endSessionResponseWriter struct {
+	http.ResponseWriter
+	logout     *frontChannelLogout
+	clients    *frontChannelLogoutClients
+	redirected bool
+}
+
+func (w *endSessionResponseWriter) WriteHeader(status int) {
+	location := w.Header().Get("Location")
+	if status == http.StatusFound && location != "" && len(w.clients.clientIDs) > 0 {
+		w.Header().Set("Location", w.logout.logoutURL(location, w.clients.clientIDs))
+		w.redirected = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *endSessionResponseWriter) Write(b []byte) (int, error) {
+	// the body of the redirect would still link the original location
+	if w.redirected {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
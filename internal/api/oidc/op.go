@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/assets"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/pop"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -27,29 +28,32 @@ import (
 )
 
 type Config struct {
-	CodeMethodS256                    bool
-	AuthMethodPost                    bool
-	AuthMethodPrivateKeyJWT           bool
-	GrantTypeRefreshToken             bool
-	RequestObjectSupported            bool
-	SigningKeyAlgorithm               string
-	DefaultAccessTokenLifetime        time.Duration
-	DefaultIdTokenLifetime            time.Duration
-	DefaultRefreshTokenIdleExpiration time.Duration
-	DefaultRefreshTokenExpiration     time.Duration
-	UserAgentCookieConfig             *middleware.UserAgentCookieConfig
-	Cache                             *middleware.CacheConfig
-	CustomEndpoints                   *EndpointConfig
+	CodeMethodS256                     bool
+	AuthMethodPost                     bool
+	AuthMethodPrivateKeyJWT            bool
+	GrantTypeRefreshToken              bool
+	RequestObjectSupported             bool
+	PushedAuthorizationRequestLifetime time.Duration
+	ClientCertificateHeader            string
+	SigningKeyAlgorithm                string
+	DefaultAccessTokenLifetime         time.Duration
+	DefaultIdTokenLifetime             time.Duration
+	DefaultRefreshTokenIdleExpiration  time.Duration
+	DefaultRefreshTokenExpiration      time.Duration
+	UserAgentCookieConfig              *middleware.UserAgentCookieConfig
+	Cache                              *middleware.CacheConfig
+	CustomEndpoints                    *EndpointConfig
 }
 
 type EndpointConfig struct {
-	Auth          *Endpoint
-	Token         *Endpoint
-	Introspection *Endpoint
-	Userinfo      *Endpoint
-	Revocation    *Endpoint
-	EndSession    *Endpoint
-	Keys          *Endpoint
+	Auth                       *Endpoint
+	Token                      *Endpoint
+	Introspection              *Endpoint
+	Userinfo                   *Endpoint
+	Revocation                 *Endpoint
+	EndSession                 *Endpoint
+	Keys                       *Endpoint
+	PushedAuthorizationRequest *Endpoint
 }
 
 type Endpoint struct {
@@ -71,15 +75,19 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	usedProofs                        pop.UsedProofs
 }
 
-func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *sql.DB, userAgentCookie, instanceHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
+func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *sql.DB, usedProofs pop.UsedProofs, userAgentCookie, instanceHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler)
+	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, usedProofs, externalSecure)
+	par := newPushedAuthorizationRequests(config, storage)
+	senderConstraint := newSenderConstrainedTokens(config, usedProofs)
+	frontChannel := newFrontChannelLogout(config, defaultLogoutRedirectURI)
+	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, par.authorizeInterceptor, senderConstraint.interceptor, frontChannel.interceptor)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
@@ -93,7 +101,7 @@ func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI st
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-DAtg3", "cannot create provider")
 	}
-	return provider, nil
+	return newProvider(provider, par, senderConstraint, httpInterceptors(userAgentCookie, instanceHandler)...), nil
 }
 
 func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []byte) (*op.Config, error) {
@@ -117,17 +125,9 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler func(http.Handler) http.Handler) ([]op.Option, error) {
-	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler func(http.Handler) http.Handler, endpointInterceptors ...op.HttpInterceptor) ([]op.Option, error) {
 	options := []op.Option{
-		op.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			http_utils.CopyHeadersToContext,
-		),
+		op.WithHttpInterceptors(append(httpInterceptors(userAgentCookie, instanceHandler), endpointInterceptors...)...),
 	}
 	if !externalSecure {
 		options = append(options, op.WithAllowInsecure())
@@ -139,6 +139,18 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 	return options, nil
 }
 
+func httpInterceptors(userAgentCookie, instanceHandler func(http.Handler) http.Handler) []op.HttpInterceptor {
+	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+	return []op.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		http_utils.CopyHeadersToContext,
+	}
+}
+
 func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	if endpointConfig == nil {
 		return nil
@@ -168,7 +180,7 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	return options
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, projections *sql.DB, externalSecure bool) *OPStorage {
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, projections *sql.DB, usedProofs pop.UsedProofs, externalSecure bool) *OPStorage {
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -183,6 +195,7 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(projections, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		usedProofs:                        usedProofs,
 	}
 }
 
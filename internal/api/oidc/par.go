@@ -0,0 +1,381 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/pop"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// RequestURIPrefix is the prefix of the request_uri returned by the pushed authorization request endpoint (RFC 9126)
+	RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+	defaultPushedAuthorizationRequestEndpoint = "oauth/v2/par"
+	defaultPushedAuthorizationRequestLifetime = time.Minute
+
+	paramRequestURI          = "request_uri"
+	paramClientID            = "client_id"
+	paramClientSecret        = "client_secret"
+	paramClientAssertion     = "client_assertion"
+	paramClientAssertionType = "client_assertion_type"
+)
+
+// provider wraps the OpenID Provider of the library to add the endpoints and parameters it does not support (yet):
+// pushed authorization requests (RFC 9126), the `request_uri` parameter of the authorization endpoint
+// and the DPoP header (RFC 9449) on the token and userinfo endpoint
+type provider struct {
+	*op.Provider
+	par     *pushedAuthorizationRequests
+	handler http.Handler
+}
+
+func (p *provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
+func newProvider(inner *op.Provider, par *pushedAuthorizationRequests, senderConstraint *senderConstrainedTokens, interceptors ...op.HttpInterceptor) *provider {
+	par.provider = inner
+	p := &provider{
+		Provider: inner,
+		par:      par,
+	}
+	issuerInterceptor := op.NewIssuerInterceptor(inner.IssuerFromRequest)
+	intercept := func(handler http.Handler) http.Handler {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			handler = interceptors[i](handler)
+		}
+		return issuerInterceptor.Handler(handler)
+	}
+	pushedAuthorizationHandler := intercept(http.HandlerFunc(par.pushedAuthorizationRequestHandler))
+	discoveryHandler := middleware.CORSInterceptor(intercept(http.HandlerFunc(p.discoveryHandler)))
+	innerHandler := inner.HttpHandler()
+	// the CORS handler of the library does not allow the DPoP header, so preflight requests are answered here
+	preflightHandler := middleware.CORSInterceptor(innerHandler)
+	p.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && senderConstraint.isEndpoint(r.URL.Path) {
+			preflightHandler.ServeHTTP(w, r)
+			return
+		}
+		switch r.URL.Path {
+		case par.endpoint.Relative():
+			pushedAuthorizationHandler.ServeHTTP(w, r)
+		case oidc.DiscoveryEndpoint:
+			discoveryHandler.ServeHTTP(w, r)
+		default:
+			innerHandler.ServeHTTP(w, r)
+		}
+	})
+	return p
+}
+
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	PushedAuthorizationRequestEndpoint string `json:"pushed_authorization_request_endpoint,omitempty"`
+	RequirePushedAuthorizationRequests bool   `json:"require_pushed_authorization_requests"`
+
+	DPoPSigningAlgValuesSupported         []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	TLSClientCertificateBoundAccessTokens bool     `json:"tls_client_certificate_bound_access_tokens"`
+
+	BackChannelLogoutSupported  bool `json:"backchannel_logout_supported"`
+	FrontChannelLogoutSupported bool `json:"frontchannel_logout_supported"`
+}
+
+// discoveryHandler extends the discovery configuration of the library with the pushed authorization request endpoint,
+// the support of sender-constrained access tokens and of back- and front-channel logout
+func (p *provider) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	config := op.CreateDiscoveryConfig(r, p.Provider, p.Provider.Storage())
+	config.RequestURIParameterSupported = true
+	httphelper.MarshalJSON(w, &discoveryConfiguration{
+		DiscoveryConfiguration:             config,
+		PushedAuthorizationRequestEndpoint: p.par.endpoint.Absolute(config.Issuer),
+		// PAR is only required for applications which require it on their configuration
+		RequirePushedAuthorizationRequests:    false,
+		DPoPSigningAlgValuesSupported:         pop.DPoPSigningAlgorithms,
+		TLSClientCertificateBoundAccessTokens: true,
+		BackChannelLogoutSupported:            true,
+		FrontChannelLogoutSupported:           true,
+	})
+}
+
+type pushedAuthorizationRequests struct {
+	provider              *op.Provider
+	storage               *OPStorage
+	endpoint              op.Endpoint
+	authorizationEndpoint op.Endpoint
+	lifetime              time.Duration
+}
+
+func newPushedAuthorizationRequests(config Config, storage *OPStorage) *pushedAuthorizationRequests {
+	par := &pushedAuthorizationRequests{
+		storage:               storage,
+		endpoint:              op.NewEndpoint(defaultPushedAuthorizationRequestEndpoint),
+		authorizationEndpoint: op.DefaultEndpoints.Authorization,
+		lifetime:              config.PushedAuthorizationRequestLifetime,
+	}
+	if par.lifetime <= 0 {
+		par.lifetime = defaultPushedAuthorizationRequestLifetime
+	}
+	if config.CustomEndpoints == nil {
+		return par
+	}
+	if config.CustomEndpoints.PushedAuthorizationRequest != nil {
+		par.endpoint = op.NewEndpointWithURL(config.CustomEndpoints.PushedAuthorizationRequest.Path, config.CustomEndpoints.PushedAuthorizationRequest.URL)
+	}
+	if config.CustomEndpoints.Auth != nil {
+		par.authorizationEndpoint = op.NewEndpointWithURL(config.CustomEndpoints.Auth.Path, config.CustomEndpoints.Auth.URL)
+	}
+	return par
+}
+
+type pushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// pushedAuthorizationRequest is encrypted into the request_uri,
+// so the pushed request does not have to be stored
+type pushedAuthorizationRequest struct {
+	ClientID   string    `json:"clientId"`
+	Params     string    `json:"params"`
+	Expiration time.Time `json:"exp"`
+}
+
+// pushedAuthorizationRequestHandler implements the pushed authorization request endpoint (RFC 9126).
+// The client is authenticated the same way as on the token endpoint and the request is validated
+// as it would be on the authorization endpoint, including signed request objects (JAR, RFC 9101)
+func (p *pushedAuthorizationRequests) pushedAuthorizationRequestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("cannot parse form").WithParent(err))
+		return
+	}
+	client, err := p.authorizeClient(ctx, r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if r.PostForm.Get(paramRequestURI) != "" {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("request_uri is not allowed in a pushed authorization request"))
+		return
+	}
+	authReq, err := op.ParseAuthorizeRequest(r, p.provider.Decoder())
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if authReq.ClientID == "" {
+		authReq.ClientID = client.GetID()
+	}
+	if authReq.ClientID != client.GetID() {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client"))
+		return
+	}
+	if authReq.RequestParam != "" {
+		if !p.provider.RequestObjectSupported() {
+			op.RequestError(w, r, oidc.ErrRequestNotSupported())
+			return
+		}
+		authReq, err = op.ParseRequestObject(ctx, authReq, p.provider.Storage(), op.IssuerFromContext(ctx))
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+	}
+	if _, err = op.ValidateAuthRequest(ctx, authReq, p.provider.Storage(), p.provider.IDTokenHintVerifier(ctx)); err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	requestURI, err := p.newRequestURI(client.GetID(), pushedParams(r.PostForm, client.GetID()))
+	if err != nil {
+		op.RequestError(w, r, oidc.DefaultToServerError(err, "unable to create request_uri"))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, &pushedAuthorizationResponse{
+		RequestURI: requestURI,
+		ExpiresIn:  int64(p.lifetime.Seconds()),
+	}, http.StatusCreated)
+}
+
+// authorizeClient authenticates the client with a private key jwt assertion, client_id and client_secret (basic auth or post)
+// or only by its client_id if the client does not use any authentication
+func (p *pushedAuthorizationRequests) authorizeClient(ctx context.Context, r *http.Request) (_ op.Client, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if assertion := r.PostForm.Get(paramClientAssertion); assertion != "" {
+		if r.PostForm.Get(paramClientAssertionType) != oidc.ClientAssertionTypeJWTAssertion {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid client_assertion_type")
+		}
+		client, err := op.AuthorizePrivateJWTKey(ctx, assertion, p.provider)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		return client, nil
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		if clientSecret, err = url.QueryUnescape(clientSecret); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	} else {
+		clientID = r.PostForm.Get(paramClientID)
+		clientSecret = r.PostForm.Get(paramClientSecret)
+	}
+	if clientID == "" {
+		return nil, oidc.ErrInvalidClient().WithDescription("client_id missing")
+	}
+	client, err := p.provider.Storage().GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	switch client.AuthMethod() {
+	case oidc.AuthMethodNone:
+		return client, nil
+	case oidc.AuthMethodBasic, oidc.AuthMethodPost:
+		if clientSecret == "" {
+			return nil, oidc.ErrInvalidClient().WithDescription("client_secret missing")
+		}
+		if err = op.AuthorizeClientIDSecret(ctx, clientID, clientSecret, p.provider.Storage()); err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, oidc.ErrInvalidClient().WithDescription("client authentication missing")
+	}
+}
+
+// pushedParams returns the parameters of the authorization request without the ones used for the client authentication
+func pushedParams(form url.Values, clientID string) url.Values {
+	params := make(url.Values, len(form))
+	for key, values := range form {
+		switch key {
+		case paramClientSecret, paramClientAssertion, paramClientAssertionType:
+			continue
+		}
+		params[key] = values
+	}
+	params.Set(paramClientID, clientID)
+	return params
+}
+
+func (p *pushedAuthorizationRequests) newRequestURI(clientID string, params url.Values) (string, error) {
+	request, err := json.Marshal(&pushedAuthorizationRequest{
+		ClientID:   clientID,
+		Params:     params.Encode(),
+		Expiration: time.Now().Add(p.lifetime),
+	})
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := p.provider.Crypto().Encrypt(string(request))
+	if err != nil {
+		return "", err
+	}
+	return RequestURIPrefix + encrypted, nil
+}
+
+// resolveRequestURI returns the parameters of the pushed authorization request of the request_uri
+func (p *pushedAuthorizationRequests) resolveRequestURI(requestURI, clientID string) (url.Values, error) {
+	if !strings.HasPrefix(requestURI, RequestURIPrefix) {
+		// request_uri values pointing to the client are not fetched
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri not supported, use the pushed authorization request endpoint")
+	}
+	decrypted, err := p.provider.Crypto().Decrypt(strings.TrimPrefix(requestURI, RequestURIPrefix))
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri").WithParent(err)
+	}
+	request := new(pushedAuthorizationRequest)
+	if err = json.Unmarshal([]byte(decrypted), request); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri").WithParent(err)
+	}
+	if request.Expiration.Before(time.Now()) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri expired")
+	}
+	if request.ClientID != clientID {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the request_uri")
+	}
+	params, err := url.ParseQuery(request.Params)
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri").WithParent(err)
+	}
+	return params, nil
+}
+
+// authorizeInterceptor resolves the `request_uri` of a pushed authorization request into the parameters
+// of the authorization request and rejects requests of clients requiring them to be pushed.
+// The resulting request is then handled (and validated again) by the authorization endpoint of the library
+func (p *pushedAuthorizationRequests) authorizeInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != p.authorizationEndpoint.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("cannot parse form").WithParent(err), p.provider.Encoder())
+			return
+		}
+		clientID := r.Form.Get(paramClientID)
+		requestURI := r.Form.Get(paramRequestURI)
+		if requestURI == "" {
+			required, err := p.pushedAuthorizationRequired(r.Context(), clientID)
+			if err != nil {
+				op.AuthRequestError(w, r, nil, oidc.ErrServerError().WithParent(err), p.provider.Encoder())
+				return
+			}
+			if required {
+				op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("pushed authorization request required"), p.provider.Encoder())
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		params, err := p.resolveRequestURI(requestURI, clientID)
+		if err != nil {
+			op.AuthRequestError(w, r, nil, err, p.provider.Encoder())
+			return
+		}
+		r.URL.RawQuery = params.Encode()
+		r.Form = nil
+		r.PostForm = nil
+		next.ServeHTTP(w, r)
+	})
+}
+
+// pushedAuthorizationRequired returns an error if the client could not be checked,
+// so the requirement cannot be bypassed by a failing lookup
+func (p *pushedAuthorizationRequests) pushedAuthorizationRequired(ctx context.Context, clientID string) (bool, error) {
+	if clientID == "" {
+		return false, nil
+	}
+	app, err := p.storage.query.AppByOIDCClientID(ctx, clientID)
+	if caos_errs.IsNotFound(err) {
+		// unknown clients are rejected by the authorization endpoint
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if app.OIDCConfig == nil {
+		return false, nil
+	}
+	return app.OIDCConfig.RequirePushedAuthorizationRequests, nil
+}
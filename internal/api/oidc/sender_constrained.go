@@ -0,0 +1,167 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/pop"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	// errorInvalidDPoPProof is returned for invalid DPoP proofs (RFC 9449, section 5)
+	errorInvalidDPoPProof = "invalid_dpop_proof"
+)
+
+// senderConstrainedTokens binds the access tokens issued on the token endpoint to the key of a DPoP proof (RFC 9449)
+// or to the certificate the client authenticated with on the TLS connection (RFC 8705)
+// and checks the proof of possession on the userinfo endpoint
+type senderConstrainedTokens struct {
+	tokenEndpoint           op.Endpoint
+	userinfoEndpoint        op.Endpoint
+	clientCertificateHeader string
+	usedProofs              pop.UsedProofs
+}
+
+func newSenderConstrainedTokens(config Config, usedProofs pop.UsedProofs) *senderConstrainedTokens {
+	s := &senderConstrainedTokens{
+		tokenEndpoint:           op.DefaultEndpoints.Token,
+		userinfoEndpoint:        op.DefaultEndpoints.Userinfo,
+		clientCertificateHeader: config.ClientCertificateHeader,
+		usedProofs:              usedProofs,
+	}
+	if config.CustomEndpoints == nil {
+		return s
+	}
+	if config.CustomEndpoints.Token != nil {
+		s.tokenEndpoint = op.NewEndpointWithURL(config.CustomEndpoints.Token.Path, config.CustomEndpoints.Token.URL)
+	}
+	if config.CustomEndpoints.Userinfo != nil {
+		s.userinfoEndpoint = op.NewEndpointWithURL(config.CustomEndpoints.Userinfo.Path, config.CustomEndpoints.Userinfo.URL)
+	}
+	return s
+}
+
+func (s *senderConstrainedTokens) isEndpoint(path string) bool {
+	return path == s.tokenEndpoint.Relative() || path == s.userinfoEndpoint.Relative()
+}
+
+func (s *senderConstrainedTokens) interceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case s.tokenEndpoint.Relative():
+			s.tokenHandler(w, r, next)
+		case s.userinfoEndpoint.Relative():
+			s.userinfoHandler(w, r, next)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// tokenHandler sets the key the issued access tokens will be bound to.
+// The response of DPoP requests will have the token_type DPoP instead of Bearer
+func (s *senderConstrainedTokens) tokenHandler(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	thumbprint, err := pop.ClientCertificateThumbprint(r, s.clientCertificateHeader)
+	if err != nil {
+		op.RequestError(w, r, oidc.ErrInvalidClient().WithDescription("invalid client certificate").WithParent(err))
+		return
+	}
+	confirmation := &pop.Confirmation{
+		X5TS256: thumbprint,
+	}
+	proofs := r.Header.Values(http_utils.DPoP)
+	if len(proofs) > 1 {
+		op.RequestError(w, r, &oidc.Error{ErrorType: errorInvalidDPoPProof, Description: "multiple DPoP proofs"})
+		return
+	}
+	if len(proofs) == 1 {
+		confirmation.JKT, err = pop.VerifyDPoP(r.Context(), s.usedProofs, proofs[0], r.Method, s.tokenEndpoint.Absolute(op.IssuerFromContext(r.Context())), "")
+		if err != nil {
+			op.RequestError(w, r, &oidc.Error{ErrorType: errorInvalidDPoPProof, Description: err.Error(), Parent: err})
+			return
+		}
+		writer := &dpopTokenResponseWriter{ResponseWriter: w}
+		defer writer.flush()
+		w = writer
+	}
+	next.ServeHTTP(w, r.WithContext(pop.WithConfirmation(r.Context(), confirmation)))
+}
+
+// userinfoHandler sets the proof of possession presented along with the access token,
+// which is verified against the binding of the token on SetUserinfoFromToken.
+// As the library only accepts the Bearer scheme, the DPoP scheme is replaced after the proof is extracted
+func (s *senderConstrainedTokens) userinfoHandler(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	thumbprint, err := pop.ClientCertificateThumbprint(r, s.clientCertificateHeader)
+	if err != nil {
+		http.Error(w, "invalid client certificate", http.StatusUnauthorized)
+		return
+	}
+	proof := &pop.Proof{
+		Scheme:                pop.SchemeBearer,
+		DPoP:                  r.Header.Get(http_utils.DPoP),
+		Method:                r.Method,
+		URL:                   s.userinfoEndpoint.Absolute(op.IssuerFromContext(r.Context())),
+		CertificateThumbprint: thumbprint,
+	}
+	if scheme, accessToken, ok := pop.SplitAuthorization(r.Header.Get(http_utils.Authorization)); ok {
+		proof.Scheme = scheme
+		proof.AccessToken = accessToken
+		r.Header.Set(http_utils.Authorization, oidc.PrefixBearer+accessToken)
+	}
+	next.ServeHTTP(w, r.WithContext(pop.WithProof(r.Context(), proof)))
+}
+
+// dpopTokenResponseWriter buffers the token response to set its token_type to DPoP,
+// because the library always responds with the Bearer type
+type dpopTokenResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *dpopTokenResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *dpopTokenResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dpopTokenResponseWriter) flush() {
+	body := w.body.Bytes()
+	if w.status == 0 || w.status == http.StatusOK {
+		body = dpopTokenResponse(body)
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	w.ResponseWriter.Write(body)
+}
+
+// confirmationFromContext returns the key or certificate presented on the token request the tokens will be bound to
+func confirmationFromContext(ctx context.Context) *domain.TokenConfirmation {
+	return (*domain.TokenConfirmation)(pop.ConfirmationFromContext(ctx))
+}
+
+func dpopTokenResponse(body []byte) []byte {
+	response := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	if _, ok := response["token_type"]; !ok {
+		return body
+	}
+	response["token_type"], _ = json.Marshal(pop.TokenTypeDPoP)
+	rewritten, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
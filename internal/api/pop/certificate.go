@@ -0,0 +1,52 @@
+package pop
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+// CertificateThumbprint returns the base64url encoded SHA-256 thumbprint (x5t#S256) of the certificate
+func CertificateThumbprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// ClientCertificateThumbprint returns the thumbprint of the certificate the client authenticated with in the TLS handshake.
+// If ZITADEL runs behind a TLS terminating proxy, the proxy can forward the verified certificate (url escaped PEM)
+// in the header configured. The header must never be accepted from clients directly.
+// An empty thumbprint is returned if the client did not present a certificate.
+func ClientCertificateThumbprint(r *http.Request, header string) (string, error) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return CertificateThumbprint(r.TLS.PeerCertificates[0]), nil
+	}
+	if header == "" {
+		return "", nil
+	}
+	return ForwardedCertificateThumbprint(r.Header.Get(header))
+}
+
+// ForwardedCertificateThumbprint returns the thumbprint of the url escaped PEM certificate forwarded by a proxy
+func ForwardedCertificateThumbprint(escaped string) (string, error) {
+	if escaped == "" {
+		return "", nil
+	}
+	unescaped, err := url.QueryUnescape(escaped)
+	if err != nil {
+		return "", caos_errs.ThrowInvalidArgument(err, "POP-Ty6ve", "invalid client certificate")
+	}
+	block, _ := pem.Decode([]byte(unescaped))
+	if block == nil {
+		return "", caos_errs.ThrowInvalidArgument(nil, "POP-Bn7xo", "invalid client certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", caos_errs.ThrowInvalidArgument(err, "POP-Cu1ws", "invalid client certificate")
+	}
+	return CertificateThumbprint(cert), nil
+}
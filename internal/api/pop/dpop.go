@@ -0,0 +1,142 @@
+package pop
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// DPoPProofType is the `typ` header of DPoP proofs
+	DPoPProofType = "dpop+jwt"
+
+	// DPoPProofLifetime is the time window around the `iat` claim a proof is accepted in
+	DPoPProofLifetime = time.Minute
+)
+
+// DPoPSigningAlgorithms are the asymmetric algorithms accepted for DPoP proofs
+var DPoPSigningAlgorithms = []string{
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+type dpopClaims struct {
+	JTI      string `json:"jti"`
+	HTM      string `json:"htm"`
+	HTU      string `json:"htu"`
+	IssuedAt int64  `json:"iat"`
+	ATH      string `json:"ath,omitempty"`
+}
+
+// VerifyDPoP verifies the DPoP proof JWT (RFC 9449, section 4.3) and returns the thumbprint of its key.
+// The method and url are only checked if they are not empty,
+// the access token hash (`ath`) if the proof is presented along with an access token.
+// The proof is marked as used, so it cannot be replayed.
+func VerifyDPoP(ctx context.Context, usedProofs UsedProofs, proof, method, requestURL, accessToken string) (jkt string, err error) {
+	if proof == "" {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Vb3sd", "DPoP proof missing")
+	}
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "POP-Ui9kx", "invalid DPoP proof")
+	}
+	if len(jws.Signatures) != 1 {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Ncv2s", "invalid DPoP proof")
+	}
+	header := jws.Signatures[0].Header
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != DPoPProofType {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Iq7bw", "invalid DPoP proof type")
+	}
+	if !isDPoPSigningAlgorithm(header.Algorithm) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Ec8nh", "invalid DPoP proof algorithm")
+	}
+	if header.JSONWebKey == nil || !header.JSONWebKey.Valid() || !header.JSONWebKey.IsPublic() {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Rt5mz", "invalid DPoP proof key")
+	}
+	payload, err := jws.Verify(header.JSONWebKey)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "POP-Xk1aq", "invalid DPoP proof signature")
+	}
+	claims := new(dpopClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "POP-Zp4jw", "invalid DPoP proof")
+	}
+	if claims.JTI == "" {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Lw8fe", "DPoP proof jti missing")
+	}
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if now := time.Now(); issuedAt.Before(now.Add(-DPoPProofLifetime)) || issuedAt.After(now.Add(DPoPProofLifetime)) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Gh6ty", "DPoP proof expired")
+	}
+	if method != "" && !strings.EqualFold(claims.HTM, method) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Yd0pv", "DPoP proof method does not match")
+	}
+	if requestURL != "" && !equalURLs(claims.HTU, requestURL) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Fo2cb", "DPoP proof url does not match")
+	}
+	if accessToken != "" && claims.ATH != AccessTokenHash(accessToken) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Kj5rn", "DPoP proof access token hash does not match")
+	}
+	jkt, err = Thumbprint(header.JSONWebKey)
+	if err != nil {
+		return "", err
+	}
+	unused, err := usedProofs.Use(ctx, jkt+":"+claims.JTI, issuedAt.Add(DPoPProofLifetime))
+	if err != nil {
+		return "", err
+	}
+	if !unused {
+		return "", caos_errs.ThrowUnauthenticated(nil, "POP-Qe3ox", "DPoP proof already used")
+	}
+	return jkt, nil
+}
+
+// Thumbprint returns the base64url encoded SHA-256 JWK thumbprint (RFC 7638) of the key
+func Thumbprint(key *jose.JSONWebKey) (string, error) {
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", caos_errs.ThrowInvalidArgument(err, "POP-Oa3ud", "unable to compute key thumbprint")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// AccessTokenHash returns the value of the `ath` claim for the access token
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func isDPoPSigningAlgorithm(alg string) bool {
+	for _, supported := range DPoPSigningAlgorithms {
+		if alg == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// equalURLs compares the urls without their query and fragment (RFC 9449, section 4.3)
+func equalURLs(htu, requestURL string) bool {
+	a, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Host, b.Host) &&
+		a.Path == b.Path
+}
@@ -0,0 +1,289 @@
+package pop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	testMethod = "POST"
+	testURL    = "https://issuer.zitadel.ch/oauth/v2/token"
+)
+
+// testUsedProofs remembers the used proofs in memory instead of the database
+type testUsedProofs map[string]time.Time
+
+func (p testUsedProofs) Use(_ context.Context, id string, expiration time.Time) (bool, error) {
+	if _, ok := p[id]; ok {
+		return false, nil
+	}
+	p[id] = expiration
+	return true, nil
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *dpopClaims) string {
+	options := (&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ))
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, options)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func newTestJTI(t *testing.T) string {
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(jti)
+}
+
+func testJKT(t *testing.T, key *ecdsa.PrivateKey) string {
+	jkt, err := Thumbprint(&jose.JSONWebKey{Key: key.Public()})
+	require.NoError(t, err)
+	return jkt
+}
+
+func TestVerifyDPoP(t *testing.T) {
+	key := newTestKey(t)
+	type args struct {
+		proof       string
+		method      string
+		url         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr func(error) bool
+	}{
+		{
+			name: "missing proof",
+			args: args{
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "wrong type",
+			args: args{
+				proof:  newTestProof(t, key, "JWT", &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix()}),
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "missing jti",
+			args: args{
+				proof:  newTestProof(t, key, DPoPProofType, &dpopClaims{HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix()}),
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "expired",
+			args: args{
+				proof:  newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Add(-time.Hour).Unix()}),
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "wrong method",
+			args: args{
+				proof:  newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: "GET", HTU: testURL, IssuedAt: time.Now().Unix()}),
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "wrong url",
+			args: args{
+				proof:  newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: "https://issuer.zitadel.ch/oidc/v1/userinfo", IssuedAt: time.Now().Unix()}),
+				method: testMethod,
+				url:    testURL,
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "wrong access token hash",
+			args: args{
+				proof:       newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix(), ATH: AccessTokenHash("other")}),
+				method:      testMethod,
+				url:         testURL,
+				accessToken: "token",
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "valid, url with query",
+			args: args{
+				proof:  newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix()}),
+				method: testMethod,
+				url:    testURL + "?query=value",
+			},
+			want: testJKT(t, key),
+		},
+		{
+			name: "valid with access token hash",
+			args: args{
+				proof:       newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix(), ATH: AccessTokenHash("token")}),
+				method:      testMethod,
+				url:         testURL,
+				accessToken: "token",
+			},
+			want: testJKT(t, key),
+		},
+		{
+			name: "valid without method and url",
+			args: args{
+				proof: newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix()}),
+			},
+			want: testJKT(t, key),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyDPoP(context.Background(), testUsedProofs{}, tt.args.proof, tt.args.method, tt.args.url, tt.args.accessToken)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerifyDPoP_replay(t *testing.T) {
+	key := newTestKey(t)
+	proof := newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix()})
+	usedProofs := testUsedProofs{}
+	_, err := VerifyDPoP(context.Background(), usedProofs, proof, testMethod, testURL, "")
+	require.NoError(t, err)
+	_, err = VerifyDPoP(context.Background(), usedProofs, proof, testMethod, testURL, "")
+	assert.True(t, caos_errs.IsUnauthenticated(err), "unexpected error: %v", err)
+}
+
+func TestConfirmation_Verify(t *testing.T) {
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+	proof := func(key *ecdsa.PrivateKey) string {
+		return newTestProof(t, key, DPoPProofType, &dpopClaims{JTI: newTestJTI(t), HTM: testMethod, HTU: testURL, IssuedAt: time.Now().Unix(), ATH: AccessTokenHash("token")})
+	}
+	tests := []struct {
+		name         string
+		confirmation *Confirmation
+		proof        *Proof
+		wantErr      bool
+	}{
+		{
+			name:  "unbound bearer token",
+			proof: &Proof{Scheme: SchemeBearer, AccessToken: "token"},
+		},
+		{
+			name:  "unbound token without proof",
+			proof: nil,
+		},
+		{
+			name:    "unbound token with DPoP scheme",
+			proof:   &Proof{Scheme: SchemeDPoP, AccessToken: "token", DPoP: proof(key)},
+			wantErr: true,
+		},
+		{
+			name:         "DPoP bound token with bearer scheme",
+			confirmation: &Confirmation{JKT: testJKT(t, key)},
+			proof:        &Proof{Scheme: SchemeBearer, AccessToken: "token"},
+			wantErr:      true,
+		},
+		{
+			name:         "DPoP bound token with other key",
+			confirmation: &Confirmation{JKT: testJKT(t, key)},
+			proof:        &Proof{Scheme: SchemeDPoP, AccessToken: "token", DPoP: proof(otherKey), Method: testMethod, URL: testURL},
+			wantErr:      true,
+		},
+		{
+			name:         "DPoP bound token",
+			confirmation: &Confirmation{JKT: testJKT(t, key)},
+			proof:        &Proof{Scheme: SchemeDPoP, AccessToken: "token", DPoP: proof(key), Method: testMethod, URL: testURL},
+		},
+		{
+			name:         "certificate bound token without certificate",
+			confirmation: &Confirmation{X5TS256: "thumbprint"},
+			proof:        &Proof{Scheme: SchemeBearer, AccessToken: "token"},
+			wantErr:      true,
+		},
+		{
+			name:         "certificate bound token with other certificate",
+			confirmation: &Confirmation{X5TS256: "thumbprint"},
+			proof:        &Proof{Scheme: SchemeBearer, AccessToken: "token", CertificateThumbprint: "other"},
+			wantErr:      true,
+		},
+		{
+			name:         "certificate bound token",
+			confirmation: &Confirmation{X5TS256: "thumbprint"},
+			proof:        &Proof{Scheme: SchemeBearer, AccessToken: "token", CertificateThumbprint: "thumbprint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.confirmation.Verify(context.Background(), testUsedProofs{}, tt.proof)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsUnauthenticated(err), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSplitAuthorization(t *testing.T) {
+	tests := []struct {
+		authorization string
+		wantScheme    string
+		wantToken     string
+		wantOK        bool
+	}{
+		{authorization: "Bearer token", wantScheme: SchemeBearer, wantToken: "token", wantOK: true},
+		{authorization: "DPoP token", wantScheme: SchemeDPoP, wantToken: "token", wantOK: true},
+		{authorization: "dpop token", wantScheme: SchemeDPoP, wantToken: "token", wantOK: true},
+		{authorization: "Basic token"},
+		{authorization: "Bearer "},
+		{authorization: "token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.authorization, func(t *testing.T) {
+			scheme, token, ok := SplitAuthorization(tt.authorization)
+			assert.Equal(t, tt.wantScheme, scheme)
+			assert.Equal(t, tt.wantToken, token)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package pop implements sender-constrained (proof-of-possession) access tokens.
+// Tokens can be bound to a key of the client using DPoP proofs (RFC 9449)
+// or to the certificate of a mutual TLS connection (RFC 8705).
+package pop
+
+import (
+	"context"
+	"strings"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	SchemeBearer = "Bearer"
+	SchemeDPoP   = "DPoP"
+
+	// TokenTypeDPoP is the token_type of DPoP bound access tokens
+	TokenTypeDPoP = "DPoP"
+
+	// ClaimConfirmation is the claim of the introspection response containing the binding of the token (RFC 7800)
+	ClaimConfirmation = "cnf"
+)
+
+type key int
+
+const (
+	proofKey key = iota
+	confirmationKey
+)
+
+// Confirmation is the key the access token is bound to
+type Confirmation struct {
+	// JKT is the base64url encoded SHA-256 JWK thumbprint of the DPoP key
+	JKT string `json:"jkt,omitempty"`
+	// X5TS256 is the base64url encoded SHA-256 thumbprint of the client certificate
+	X5TS256 string `json:"x5t#S256,omitempty"`
+}
+
+// IsBound returns if the token is sender-constrained
+func (c *Confirmation) IsBound() bool {
+	return c != nil && (c.JKT != "" || c.X5TS256 != "")
+}
+
+// Proof is the proof of possession a client presents along with an access token
+type Proof struct {
+	// Scheme of the authorization header the access token was sent with
+	Scheme string
+	// AccessToken the proof is presented for
+	AccessToken string
+	// DPoP is the value of the DPoP header
+	DPoP string
+	// Method and URL of the request the DPoP proof must be issued for.
+	// They are not checked if empty (e.g. for gRPC requests, which have no URL)
+	Method string
+	URL    string
+	// CertificateThumbprint is the thumbprint of the client certificate of the mutual TLS connection
+	CertificateThumbprint string
+}
+
+// Verify checks the proof presented along with the access token against the confirmation of the token.
+// DPoP bound tokens have to be sent with the DPoP scheme and a proof of the bound key,
+// certificate bound tokens over a connection authenticated with the bound certificate.
+func (c *Confirmation) Verify(ctx context.Context, usedProofs UsedProofs, proof *Proof) error {
+	if proof == nil {
+		proof = new(Proof)
+	}
+	isDPoP := strings.EqualFold(proof.Scheme, SchemeDPoP)
+	if c == nil || c.JKT == "" {
+		if isDPoP {
+			return caos_errs.ThrowUnauthenticated(nil, "POP-Wq2rt", "token is not DPoP bound")
+		}
+	} else {
+		if !isDPoP {
+			return caos_errs.ThrowUnauthenticated(nil, "POP-Mxc4v", "DPoP bound token must be sent with the DPoP scheme")
+		}
+		jkt, err := VerifyDPoP(ctx, usedProofs, proof.DPoP, proof.Method, proof.URL, proof.AccessToken)
+		if err != nil {
+			return err
+		}
+		if jkt != c.JKT {
+			return caos_errs.ThrowUnauthenticated(nil, "POP-Hs9fk", "DPoP proof key does not match the token")
+		}
+	}
+	if c != nil && c.X5TS256 != "" && c.X5TS256 != proof.CertificateThumbprint {
+		return caos_errs.ThrowUnauthenticated(nil, "POP-Pl3xs", "client certificate does not match the token")
+	}
+	return nil
+}
+
+// SplitAuthorization returns the scheme (Bearer or DPoP) and the access token of an authorization header
+func SplitAuthorization(authorization string) (scheme, accessToken string, ok bool) {
+	scheme, accessToken, ok = strings.Cut(authorization, " ")
+	if !ok || accessToken == "" {
+		return "", "", false
+	}
+	switch {
+	case strings.EqualFold(scheme, SchemeBearer):
+		return SchemeBearer, accessToken, true
+	case strings.EqualFold(scheme, SchemeDPoP):
+		return SchemeDPoP, accessToken, true
+	default:
+		return "", "", false
+	}
+}
+
+// WithProof sets the proof the client presented on the request
+func WithProof(ctx context.Context, proof *Proof) context.Context {
+	return context.WithValue(ctx, proofKey, proof)
+}
+
+// ProofFromContext returns the proof the client presented on the request, if any
+func ProofFromContext(ctx context.Context) *Proof {
+	proof, _ := ctx.Value(proofKey).(*Proof)
+	return proof
+}
+
+// WithConfirmation sets the key the access tokens issued on the request will be bound to
+func WithConfirmation(ctx context.Context, confirmation *Confirmation) context.Context {
+	return context.WithValue(ctx, confirmationKey, confirmation)
+}
+
+// ConfirmationFromContext returns the key the access tokens issued on the request will be bound to.
+// It returns nil if the tokens are not sender-constrained
+func ConfirmationFromContext(ctx context.Context) *Confirmation {
+	confirmation, _ := ctx.Value(confirmationKey).(*Confirmation)
+	if !confirmation.IsBound() {
+		return nil
+	}
+	return confirmation
+}
@@ -0,0 +1,68 @@
+package pop
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// the proof is only stored if it wasn't used before or the previous use has already expired,
+	// so no row is affected on a replay, even if the proof is replayed against another instance of ZITADEL
+	useProofStmt = "INSERT INTO auth.dpop_proofs (id, expiration) VALUES ($1, $2)" +
+		" ON CONFLICT (id) DO UPDATE SET expiration = EXCLUDED.expiration" +
+		" WHERE auth.dpop_proofs.expiration < $3"
+	removeExpiredProofsStmt = "DELETE FROM auth.dpop_proofs WHERE expiration < $1"
+)
+
+// UsedProofs remembers the accepted DPoP proofs within their lifetime so they cannot be replayed (RFC 9449, section 11.1)
+type UsedProofs interface {
+	// Use marks the proof as used until the expiration and returns false if it has already been used before
+	Use(ctx context.Context, id string, expiration time.Time) (bool, error)
+}
+
+// ProofStorage stores the used proofs in the database, so they are shared by all instances of ZITADEL and survive restarts
+type ProofStorage struct {
+	client *sql.DB
+	now    func() time.Time
+}
+
+// StartProofStorage returns the storage of the used proofs and removes the expired ones until the context is done
+func StartProofStorage(ctx context.Context, client *sql.DB) *ProofStorage {
+	storage := &ProofStorage{
+		client: client,
+		now:    time.Now,
+	}
+	go storage.cleanup(ctx)
+	return storage
+}
+
+func (s *ProofStorage) Use(ctx context.Context, id string, expiration time.Time) (bool, error) {
+	result, err := s.client.ExecContext(ctx, useProofStmt, id, expiration, s.now())
+	if err != nil {
+		return false, caos_errs.ThrowInternal(err, "POP-Tn4dw", "Errors.Internal")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, caos_errs.ThrowInternal(err, "POP-Ae7mc", "Errors.Internal")
+	}
+	return rows > 0, nil
+}
+
+func (s *ProofStorage) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(DPoPProofLifetime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := s.client.ExecContext(ctx, removeExpiredProofsStmt, s.now())
+			logging.OnError(err).Warn("unable to remove expired DPoP proofs")
+		}
+	}
+}
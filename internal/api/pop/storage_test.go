@@ -0,0 +1,74 @@
+package pop
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestProofStorage_Use(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	expiration := now.Add(DPoPProofLifetime)
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		want    bool
+		wantErr func(error) bool
+	}{
+		{
+			name: "unused",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(useProofStmt)).
+					WithArgs("jkt:jti", expiration, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: true,
+		},
+		{
+			name: "already used",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(useProofStmt)).
+					WithArgs("jkt:jti", expiration, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: false,
+		},
+		{
+			name: "database error",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(useProofStmt)).
+					WithArgs("jkt:jti", expiration, now).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: caos_errs.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+			storage := &ProofStorage{
+				client: db,
+				now:    func() time.Time { return now },
+			}
+			got, err := storage.Use(context.Background(), "jkt:jti", expiration)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
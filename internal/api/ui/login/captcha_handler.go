@@ -0,0 +1,111 @@
+package login
+
+import (
+	"net/http"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+var (
+	// captchaScriptHosts serve the scripts and styles of the supported captcha widgets
+	captchaScriptHosts = []string{
+		"https://hcaptcha.com",
+		"https://*.hcaptcha.com",
+		"https://www.google.com/recaptcha/",
+		"https://www.gstatic.com/recaptcha/",
+		"https://challenges.cloudflare.com",
+	}
+	// captchaFrameHosts serve the challenges the supported captcha widgets render in an iframe
+	captchaFrameHosts = []string{
+		"https://hcaptcha.com",
+		"https://*.hcaptcha.com",
+		"https://www.google.com/recaptcha/",
+		"https://recaptcha.google.com/recaptcha/",
+		"https://challenges.cloudflare.com",
+	}
+)
+
+// captchaData renders the captcha widget if the site key is set
+type captchaData struct {
+	CaptchaScriptURL   string
+	CaptchaWidgetClass string
+	CaptchaSiteKey     string
+}
+
+func newCaptchaData(policy *query.CaptchaPolicy) captchaData {
+	if policy == nil {
+		return captchaData{}
+	}
+	return captchaData{
+		CaptchaScriptURL:   policy.ProviderType.ScriptURL(),
+		CaptchaWidgetClass: policy.ProviderType.WidgetClass(),
+		CaptchaSiteKey:     policy.SiteKey,
+	}
+}
+
+// getCaptchaPolicy returns the captcha policy of the org or the default of the instance,
+// nil if none is configured
+func (l *Login) getCaptchaPolicy(r *http.Request, orgID string) (*query.CaptchaPolicy, error) {
+	policy, err := l.query.CaptchaPolicyByOrg(r.Context(), false, orgID)
+	if caos_errs.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !policy.ProviderType.Valid() {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// verifyCaptcha verifies the response the captcha widget submitted with the form of the request
+func (l *Login) verifyCaptcha(r *http.Request, policy *query.CaptchaPolicy) error {
+	secret, err := crypto.DecryptString(policy.Secret, l.idpConfigAlg)
+	if err != nil {
+		return err
+	}
+	response := r.PostFormValue(policy.ProviderType.ResponseField())
+	return l.captchaVerifier.Verify(r.Context(), policy.VerifyEndpoint(), secret, response, http_utils.RemoteIPStringFromRequest(r))
+}
+
+// registrationCaptchaPolicy returns the captcha policy of the org if it requires a captcha on registration
+func (l *Login) registrationCaptchaPolicy(r *http.Request, orgID string) (*query.CaptchaPolicy, error) {
+	policy, err := l.getCaptchaPolicy(r, orgID)
+	if err != nil || policy == nil || !policy.RequireOnRegistration {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// passwordResetCaptchaPolicy returns the captcha policy of the org if it requires a captcha on password reset
+func (l *Login) passwordResetCaptchaPolicy(r *http.Request, orgID string) (*query.CaptchaPolicy, error) {
+	policy, err := l.getCaptchaPolicy(r, orgID)
+	if err != nil || policy == nil || !policy.RequireOnPasswordReset {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// loginCaptchaPolicy returns the captcha policy of the org if the user failed too many password checks
+func (l *Login) loginCaptchaPolicy(r *http.Request, authReq *domain.AuthRequest) (*query.CaptchaPolicy, error) {
+	if authReq == nil || authReq.UserID == "" {
+		return nil, nil
+	}
+	policy, err := l.getCaptchaPolicy(r, authReq.UserOrgID)
+	if err != nil || policy == nil || policy.FailedLoginsThreshold == 0 {
+		return nil, err
+	}
+	failedLogins, err := l.query.PasswordCheckFailedCount(setContext(r.Context(), authReq.UserOrgID), authReq.UserOrgID, authReq.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if failedLogins < policy.FailedLoginsThreshold {
+		return nil, nil
+	}
+	return policy, nil
+}
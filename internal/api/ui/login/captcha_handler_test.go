@@ -0,0 +1,160 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type stubVerifier struct {
+	endpoint string
+	secret   string
+	response string
+	remoteIP string
+	err      error
+}
+
+func (v *stubVerifier) Verify(_ context.Context, endpoint, secret, response, remoteIP string) error {
+	v.endpoint = endpoint
+	v.secret = secret
+	v.response = response
+	v.remoteIP = remoteIP
+	return v.err
+}
+
+func TestLogin_verifyCaptcha(t *testing.T) {
+	secret := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("secret"),
+	}
+	type args struct {
+		policy *query.CaptchaPolicy
+		form   url.Values
+	}
+	type want struct {
+		endpoint string
+		response string
+	}
+	tests := []struct {
+		name     string
+		verifier *stubVerifier
+		args     args
+		want     want
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "default endpoint of provider",
+			verifier: &stubVerifier{},
+			args: args{
+				policy: &query.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					Secret:       secret,
+				},
+				form: url.Values{"h-captcha-response": {"token"}},
+			},
+			want: want{
+				endpoint: "https://api.hcaptcha.com/siteverify",
+				response: "token",
+			},
+		},
+		{
+			name:     "custom verify url of default policy",
+			verifier: &stubVerifier{},
+			args: args{
+				policy: &query.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeTurnstile,
+					VerifyURL:    "https://captcha.example.com/siteverify",
+					Secret:       secret,
+					IsDefault:    true,
+				},
+				form: url.Values{"cf-turnstile-response": {"token"}},
+			},
+			want: want{
+				endpoint: "https://captcha.example.com/siteverify",
+				response: "token",
+			},
+		},
+		{
+			name:     "custom verify url of org policy ignored",
+			verifier: &stubVerifier{},
+			args: args{
+				policy: &query.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeTurnstile,
+					VerifyURL:    "https://captcha.example.com/siteverify",
+					Secret:       secret,
+				},
+				form: url.Values{"cf-turnstile-response": {"token"}},
+			},
+			want: want{
+				endpoint: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+				response: "token",
+			},
+		},
+		{
+			name:     "insecure verify url ignored",
+			verifier: &stubVerifier{},
+			args: args{
+				policy: &query.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					VerifyURL:    "http://169.254.169.254/latest",
+					Secret:       secret,
+					IsDefault:    true,
+				},
+				form: url.Values{"h-captcha-response": {"token"}},
+			},
+			want: want{
+				endpoint: "https://api.hcaptcha.com/siteverify",
+				response: "token",
+			},
+		},
+		{
+			name:     "verification failed",
+			verifier: &stubVerifier{err: caos_errs.ThrowInvalidArgument(nil, "id", "Errors.Captcha.Invalid")},
+			args: args{
+				policy: &query.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeReCaptcha,
+					Secret:       secret,
+				},
+				form: url.Values{"h-captcha-response": {"token"}},
+			},
+			want: want{
+				endpoint: "https://www.google.com/recaptcha/api/siteverify",
+			},
+			wantErr: caos_errs.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Login{
+				idpConfigAlg:    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				captchaVerifier: tt.verifier,
+			}
+			r := httptest.NewRequest(http.MethodPost, EndpointPassword, strings.NewReader(tt.args.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			err := l.verifyCaptcha(r, tt.args.policy)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.want.endpoint, tt.verifier.endpoint)
+			assert.Equal(t, "secret", tt.verifier.secret)
+			assert.Equal(t, tt.want.response, tt.verifier.response)
+			assert.Equal(t, "192.0.2.1", tt.verifier.remoteIP)
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	_ "github.com/zitadel/zitadel/internal/api/ui/login/statik"
 	auth_repository "github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
+	"github.com/zitadel/zitadel/internal/captcha"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -40,6 +41,7 @@ type Login struct {
 	samlAuthCallbackURL func(context.Context, string) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
+	captchaVerifier     captcha.Verifier
 }
 
 type Config struct {
@@ -85,6 +87,7 @@ func CreateLogin(config Config,
 		authRepo:            authRepo,
 		idpConfigAlg:        idpConfigAlg,
 		userCodeAlg:         userCodeAlg,
+		captchaVerifier:     captcha.NewHTTPVerifier(nil),
 	}
 	statikFS, err := fs.NewWithNamespace("login")
 	if err != nil {
@@ -104,8 +107,10 @@ func CreateLogin(config Config,
 func csp() *middleware.CSP {
 	csp := middleware.DefaultSCP
 	csp.ObjectSrc = middleware.CSPSourceOptsSelf()
-	csp.StyleSrc = csp.StyleSrc.AddNonce()
-	csp.ScriptSrc = csp.ScriptSrc.AddNonce()
+	csp.StyleSrc = csp.StyleSrc.AddNonce().AddHost(captchaScriptHosts...)
+	csp.ScriptSrc = csp.ScriptSrc.AddNonce().AddHost(captchaScriptHosts...)
+	csp.FrameSrc = middleware.CSPSourceOpts().AddHost(captchaFrameHosts...)
+	csp.ConnectSrc = csp.ConnectSrc.AddHost(captchaScriptHosts...)
 	return &csp
 }
 
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"encoding/base64"
 	"net/http"
 
 	"github.com/zitadel/logging"
@@ -82,7 +83,7 @@ func (l *Login) handleLoginNameCheck(w http.ResponseWriter, r *http.Request) {
 	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	loginName := data.LoginName
-	err = l.authRepo.CheckLoginName(r.Context(), authReq.ID, loginName, userAgentID)
+	err = l.authRepo.CheckLoginName(r.Context(), authReq.ID, loginName, userAgentID, domain.BrowserInfoFromRequest(r))
 	if err != nil {
 		l.renderLogin(w, r, authReq, err)
 		return
@@ -99,7 +100,10 @@ func (l *Login) renderLogin(w http.ResponseWriter, r *http.Request, authReq *dom
 		l.handleIDP(w, r, authReq, authReq.AllowedExternalIDPs[0].IDPConfigID)
 		return
 	}
-	data := l.getUserData(r, authReq, "Login.Title","Login.Description", errID, errMessage)
+	data := &webAuthNData{
+		userData:               l.getUserData(r, authReq, "Login.Title","Login.Description", errID, errMessage),
+		CredentialCreationData: l.beginDiscoverablePasswordlessLogin(r, authReq),
+	}
 	funcs := map[string]interface{}{
 		"hasUsernamePasswordLogin": func() bool {
 			return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowUsernamePassword
@@ -110,10 +114,28 @@ func (l *Login) renderLogin(w http.ResponseWriter, r *http.Request, authReq *dom
 		"hasRegistration": func() bool {
 			return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowRegister
 		},
+		"hasPasswordlessLogin": func() bool {
+			return data.CredentialCreationData != ""
+		},
 	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplLogin], data, funcs)
 }
 
+// beginDiscoverablePasswordlessLogin returns the assertion options for a login with a passkey,
+// which does not require the user to enter the login name first
+func (l *Login) beginDiscoverablePasswordlessLogin(r *http.Request, authReq *domain.AuthRequest) string {
+	if authReq == nil || authReq.LoginPolicy == nil || authReq.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return ""
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	webAuthNLogin, err := l.authRepo.BeginDiscoverablePasswordlessLogin(r.Context(), authReq.ID, userAgentID)
+	if err != nil {
+		logging.WithFields("authRequestID", authReq.ID).WithError(err).Warn("unable to begin discoverable passwordless login")
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(webAuthNLogin.CredentialAssertionData)
+}
+
 func singleIDPAllowed(authReq *domain.AuthRequest) bool {
 	return authReq != nil && authReq.LoginPolicy != nil && !authReq.LoginPolicy.AllowUsernamePassword && authReq.LoginPolicy.AllowExternalIDP && len(authReq.AllowedExternalIDPs) == 1
 }
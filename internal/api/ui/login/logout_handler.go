@@ -2,17 +2,105 @@ package login
 
 import (
 	"net/http"
+	"net/url"
+
+	"github.com/zitadel/logging"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
 )
 
 const (
 	tmplLogoutDone = "logoutdone"
+
+	// QueryLogoutClientID are the signed out clients, whose front-channel logout uris are rendered on the logout page
+	QueryLogoutClientID = "client_id"
+	// QueryPostLogoutRedirect is the post_logout_redirect_uri the logout page continues to after the front-channel logout
+	QueryPostLogoutRedirect = "post_logout_redirect"
+
+	paramState = "state"
 )
 
+type logoutDoneData struct {
+	userData
+	FrontChannelLogoutURIs []string
+	PostLogoutRedirectURI  string
+}
+
 func (l *Login) handleLogoutDone(w http.ResponseWriter, r *http.Request) {
-	l.renderLogoutDone(w, r)
+	frontChannelLogoutURIs, postLogoutRedirectURI := l.frontChannelLogout(r)
+	if len(frontChannelLogoutURIs) == 0 && postLogoutRedirectURI != "" {
+		http.Redirect(w, r, postLogoutRedirectURI, http.StatusFound)
+		return
+	}
+	l.renderLogoutDone(w, r, frontChannelLogoutURIs, postLogoutRedirectURI)
 }
 
-func (l *Login) renderLogoutDone(w http.ResponseWriter, r *http.Request) {
-	data := l.getUserData(r, nil, "LogoutDone.Title","LogoutDone.Description", "", "")
+func (l *Login) renderLogoutDone(w http.ResponseWriter, r *http.Request, frontChannelLogoutURIs []string, postLogoutRedirectURI string) {
+	data := logoutDoneData{
+		userData:               l.getUserData(r, nil, "LogoutDone.Title", "LogoutDone.Description", "", ""),
+		FrontChannelLogoutURIs: frontChannelLogoutURIs,
+		PostLogoutRedirectURI:  postLogoutRedirectURI,
+	}
+	if len(frontChannelLogoutURIs) > 0 {
+		w.Header().Set(http_utils.ContentSecurityPolicy, frontChannelLogoutCSP(frontChannelLogoutURIs).Value(middleware.GetNonce(r), r.Host))
+	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplLogoutDone], data, nil)
 }
+
+// frontChannelLogout returns the front-channel logout uris of the signed out clients (OpenID Connect Front-Channel Logout)
+// and the post_logout_redirect_uri to continue to, if it is registered for one of the clients
+func (l *Login) frontChannelLogout(r *http.Request) (frontChannelLogoutURIs []string, postLogoutRedirectURI string) {
+	redirect := r.URL.Query().Get(QueryPostLogoutRedirect)
+	for _, clientID := range r.URL.Query()[QueryLogoutClientID] {
+		app, err := l.query.AppByOIDCClientID(r.Context(), clientID)
+		if err != nil {
+			logging.WithFields("client_id", clientID).WithError(err).Warn("unable to get client for front-channel logout")
+			continue
+		}
+		if app.OIDCConfig.FrontChannelLogoutURI != "" {
+			frontChannelLogoutURIs = append(frontChannelLogoutURIs, app.OIDCConfig.FrontChannelLogoutURI)
+		}
+		if redirect != "" && isPostLogoutRedirectURI(redirect, app.OIDCConfig.PostLogoutRedirectURIs) {
+			postLogoutRedirectURI = redirect
+		}
+	}
+	return frontChannelLogoutURIs, postLogoutRedirectURI
+}
+
+// isPostLogoutRedirectURI checks if the redirect is one of the registered uris,
+// ignoring the state parameter which is added by the end_session endpoint
+func isPostLogoutRedirectURI(redirect string, registered []string) bool {
+	redirectURL, err := url.Parse(redirect)
+	if err != nil {
+		return false
+	}
+	query := redirectURL.Query()
+	query.Del(paramState)
+	redirectURL.RawQuery = query.Encode()
+	for _, uri := range registered {
+		registeredURL, err := url.Parse(uri)
+		if err != nil {
+			continue
+		}
+		registeredURL.RawQuery = registeredURL.Query().Encode()
+		if registeredURL.String() == redirectURL.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// frontChannelLogoutCSP allows the origins of the front-channel logout uris to be framed by the logout page
+func frontChannelLogoutCSP(frontChannelLogoutURIs []string) *middleware.CSP {
+	policy := csp()
+	policy.FrameSrc = middleware.CSPSourceOpts()
+	for _, uri := range frontChannelLogoutURIs {
+		logoutURL, err := url.Parse(uri)
+		if err != nil {
+			continue
+		}
+		policy.FrameSrc = policy.FrameSrc.AddHost(http_utils.BuildOrigin(logoutURL.Host, logoutURL.Scheme == "https"))
+	}
+	return policy
+}
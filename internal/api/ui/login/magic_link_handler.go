@@ -0,0 +1,119 @@
+package login
+
+import (
+	"fmt"
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplMagicLinkSent     = "magiclinksent"
+	tmplMagicLinkApprove  = "magiclinkapprove"
+	tmplMagicLinkApproved = "magiclinkapproved"
+)
+
+type magicLinkFormData struct {
+	UserID        string `schema:"userID"`
+	OrgID         string `schema:"orgID"`
+	AuthRequestID string `schema:"authRequestID"`
+	Code          string `schema:"code"`
+}
+
+type magicLinkApproveData struct {
+	userData
+	UserID           string
+	OrgID            string
+	AuthRequestID    string
+	Code             string
+	RequestUserAgent string
+}
+
+func MagicLinkLink(origin, userID, orgID, authRequestID, code string) string {
+	return fmt.Sprintf("%s%s?userID=%s&orgID=%s&authRequestID=%s&code=%s", externalLink(origin), EndpointMagicLink, userID, orgID, authRequestID, code)
+}
+
+func (l *Login) handleMagicLinkSend(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	err = l.authRepo.SendMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, authReq.AgentID)
+	if err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	data := l.getUserData(r, authReq, "MagicLinkSent.Title", "MagicLinkSent.Description", "", "")
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkSent], data, nil)
+}
+
+// handleMagicLink is called by the link of the email.
+// If the link is opened in the browser which started the login, the login is continued directly,
+// otherwise the user has to approve the login of the other browser.
+func (l *Login) handleMagicLink(w http.ResponseWriter, r *http.Request) {
+	data := new(magicLinkFormData)
+	if err := l.getParseData(r, data); err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReq, err := l.authRepo.MagicLinkAuthRequest(setContext(r.Context(), data.OrgID), data.AuthRequestID, data.UserID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if authReq.AgentID != userAgentID {
+		l.renderMagicLinkApprove(w, r, authReq, data, nil)
+		return
+	}
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, data.Code)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) handleMagicLinkApprove(w http.ResponseWriter, r *http.Request) {
+	formData := new(magicLinkFormData)
+	if err := l.getParseData(r, formData); err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReq, err := l.authRepo.MagicLinkAuthRequest(setContext(r.Context(), formData.OrgID), formData.AuthRequestID, formData.UserID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, formData.Code)
+	if err != nil {
+		l.renderMagicLinkApprove(w, r, authReq, formData, err)
+		return
+	}
+	data := l.getUserData(r, authReq, "MagicLinkApproved.Title", "MagicLinkApproved.Description", "", "")
+	// the auth request belongs to the other browser and must not be continued in this one
+	data.AuthReqID = ""
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkApproved], data, nil)
+}
+
+func (l *Login) renderMagicLinkApprove(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, formData *magicLinkFormData, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := magicLinkApproveData{
+		userData:      l.getUserData(r, authReq, "MagicLinkApprove.Title", "MagicLinkApprove.Description", errID, errMessage),
+		UserID:        formData.UserID,
+		OrgID:         formData.OrgID,
+		AuthRequestID: formData.AuthRequestID,
+		Code:          formData.Code,
+	}
+	// the auth request belongs to the other browser and must not be continued in this one
+	data.AuthReqID = ""
+	if authReq.BrowserInfo != nil {
+		data.RequestUserAgent = authReq.BrowserInfo.UserAgent
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkApprove], data, nil)
+}
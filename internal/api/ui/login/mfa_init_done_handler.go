@@ -15,6 +15,11 @@ type mfaInitDoneData struct {
 
 func (l *Login) renderMFAInitDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaDoneData) {
 	var errType, errMessage string
+	codes, err := l.command.InitHumanRecoveryCodes(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	if err != nil {
+		errType, errMessage = l.getErrorMessage(r, err)
+	}
+	data.RecoveryCodes = codes
 	translator := l.getTranslator(r.Context(), authReq)
 	data.baseData = l.getBaseData(r, authReq, "InitMFADone.Title","InitMFADone.Description", errType, errMessage)
 	data.profileData = l.getProfileData(authReq)
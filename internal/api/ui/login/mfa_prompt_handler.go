@@ -86,6 +86,10 @@ func (l *Login) handleMFACreation(w http.ResponseWriter, r *http.Request, authRe
 	case domain.MFATypeU2F:
 		l.renderRegisterU2F(w, r, authReq, nil)
 		return
+	case domain.MFATypeOTPSMS,
+		domain.MFATypeOTPEmail:
+		l.handleOTPCodeCreation(w, r, authReq, data.MFAType)
+		return
 	}
 	l.renderError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "APP-Or3HO", "Errors.User.MFA.NoProviders"))
 }
@@ -103,3 +107,23 @@ func (l *Login) handleOTPCreation(w http.ResponseWriter, r *http.Request, authRe
 	}
 	l.renderMFAInitVerify(w, r, authReq, data, nil)
 }
+
+// handleOTPCodeCreation adds the second factor directly,
+// as the user already verified the phone or email the one-time codes will be sent to
+func (l *Login) handleOTPCodeCreation(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, mfaType domain.MFAType) {
+	ctx := setContext(r.Context(), authReq.UserOrgID)
+	var err error
+	if mfaType == domain.MFATypeOTPSMS {
+		_, err = l.command.AddHumanOTPSMS(ctx, authReq.UserID, authReq.UserOrgID)
+	} else {
+		_, err = l.command.AddHumanOTPEmail(ctx, authReq.UserID, authReq.UserOrgID)
+	}
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	done := &mfaDoneData{
+		MFAType: mfaType,
+	}
+	l.renderMFAInitDone(w, r, authReq, done)
+}
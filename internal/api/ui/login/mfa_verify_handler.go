@@ -5,6 +5,7 @@ import (
 
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 )
 
 const (
@@ -30,26 +31,54 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if data.Code == "" {
-		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
+		err = l.sendMFAOTPCode(r, authReq, data.SelectedProvider)
+		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, err)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
-		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch data.MFAType {
+	case domain.MFATypeOTP:
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
-		if err != nil {
-			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
-			return
-		}
+	case domain.MFATypeOTPSMS:
+		err = l.authRepo.VerifyMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		err = l.authRepo.VerifyMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeRecoveryCode:
+		err = l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	}
+	if err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
 	}
 	l.renderNextStep(w, r, authReq)
 }
 
+// sendMFAOTPCode requests a new one-time code, if the selected provider delivers it by SMS or email
+// a resource exhausted error is returned if the last code was sent within the resend cooldown
+func (l *Login) sendMFAOTPCode(r *http.Request, authReq *domain.AuthRequest, provider domain.MFAType) error {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch provider {
+	case domain.MFATypeOTPSMS:
+		return l.authRepo.SendMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID)
+	case domain.MFATypeOTPEmail:
+		return l.authRepo.SendMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID)
+	}
+	return nil
+}
+
 func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, err error) {
 	if verificationStep == nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
 	provider := verificationStep.MFAProviders[len(verificationStep.MFAProviders)-1]
+	if err == nil {
+		err = l.sendMFAOTPCode(r, authReq, provider)
+		// the code sent on the last render is still valid
+		if caos_errs.IsResourceExhausted(err) {
+			err = nil
+		}
+	}
 	l.renderMFAVerifySelected(w, r, authReq, verificationStep, provider, err)
 }
 
@@ -69,13 +98,28 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	case domain.MFATypeU2F:
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAU2F.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAU2F.Description")
-		l.renderU2FVerification(w, r, authReq, removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeU2F), nil)
+		l.renderU2FVerification(w, r, authReq, withRecoveryCode(removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeU2F)), nil)
 		return
 	case domain.MFATypeOTP:
-		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTP)
+		data.MFAProviders = withRecoveryCode(removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTP))
 		data.SelectedMFAProvider = domain.MFATypeOTP
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTP.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTP.Description")
+	case domain.MFATypeOTPSMS:
+		data.MFAProviders = withRecoveryCode(removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPSMS))
+		data.SelectedMFAProvider = domain.MFATypeOTPSMS
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPSMS.Description")
+	case domain.MFATypeOTPEmail:
+		data.MFAProviders = withRecoveryCode(removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTPEmail))
+		data.SelectedMFAProvider = domain.MFATypeOTPEmail
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTPEmail.Description")
+	case domain.MFATypeRecoveryCode:
+		data.MFAProviders = verificationStep.MFAProviders
+		data.SelectedMFAProvider = domain.MFATypeRecoveryCode
+		data.Title = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Title")
+		data.Description = translator.LocalizeWithoutArgs("VerifyMFARecoveryCode.Description")
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -92,3 +136,8 @@ func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.
 	}
 	return providers
 }
+
+// withRecoveryCode offers a recovery code as alternative to the providers of the user
+func withRecoveryCode(providers []domain.MFAType) []domain.MFAType {
+	return append(providers[:len(providers):len(providers)], domain.MFATypeRecoveryCode)
+}
@@ -3,6 +3,8 @@ package login
 import (
 	"net/http"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
@@ -14,12 +16,22 @@ type passwordFormData struct {
 	Password string `schema:"password"`
 }
 
+type passwordCheckData struct {
+	userData
+	captchaData
+}
+
 func (l *Login) renderPassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
 	var errID, errMessage string
 	if err != nil {
 		errID, errMessage = l.getErrorMessage(r, err)
 	}
-	data := l.getUserData(r, authReq, "Password.Title","Password.Description", errID, errMessage)
+	captchaPolicy, captchaErr := l.loginCaptchaPolicy(r, authReq)
+	logging.OnError(captchaErr).Warn("unable to check if captcha is required")
+	data := passwordCheckData{
+		userData:    l.getUserData(r, authReq, "Password.Title","Password.Description", errID, errMessage),
+		captchaData: newCaptchaData(captchaPolicy),
+	}
 	funcs := map[string]interface{}{
 		"showPasswordReset": func() bool {
 			if authReq.LoginPolicy != nil {
@@ -27,6 +39,9 @@ func (l *Login) renderPassword(w http.ResponseWriter, r *http.Request, authReq *
 			}
 			return true
 		},
+		"showMagicLink": func() bool {
+			return authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowMagicLink
+		},
 	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPassword], data, funcs)
 }
@@ -38,6 +53,17 @@ func (l *Login) handlePasswordCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	captchaPolicy, err := l.loginCaptchaPolicy(r, authReq)
+	if err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	if captchaPolicy != nil {
+		if err = l.verifyCaptcha(r, captchaPolicy); err != nil {
+			l.renderPassword(w, r, authReq, err)
+			return
+		}
+	}
 	err = l.authRepo.VerifyPassword(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Password, authReq.AgentID, domain.BrowserInfoFromRequest(r))
 	if err != nil {
 		if authReq.LoginPolicy.IgnoreUnknownUsernames {
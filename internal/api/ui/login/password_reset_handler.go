@@ -9,15 +9,59 @@ import (
 )
 
 const (
+	tmplPasswordReset     = "passwordreset"
 	tmplPasswordResetDone = "passwordresetdone"
 )
 
+type passwordResetFormData struct {
+	AuthRequestID string `schema:"authRequestID"`
+}
+
+type passwordResetData struct {
+	userData
+	captchaData
+}
+
 func (l *Login) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 	authReq, err := l.getAuthRequest(r)
 	if err != nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	captchaPolicy, err := l.passwordResetCaptchaPolicy(r, authReq.UserOrgID)
+	if err != nil {
+		l.renderPasswordResetDone(w, r, authReq, err)
+		return
+	}
+	if captchaPolicy != nil {
+		l.renderPasswordReset(w, r, authReq, captchaPolicy, nil)
+		return
+	}
+	l.resetPassword(w, r, authReq)
+}
+
+func (l *Login) handlePasswordResetCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(passwordResetFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	captchaPolicy, err := l.passwordResetCaptchaPolicy(r, authReq.UserOrgID)
+	if err != nil {
+		l.renderPasswordResetDone(w, r, authReq, err)
+		return
+	}
+	if captchaPolicy != nil {
+		if err = l.verifyCaptcha(r, captchaPolicy); err != nil {
+			l.renderPasswordReset(w, r, authReq, captchaPolicy, err)
+			return
+		}
+	}
+	l.resetPassword(w, r, authReq)
+}
+
+func (l *Login) resetPassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
 	loginName, err := query.NewUserLoginNamesSearchQuery(authReq.LoginName)
 	if err != nil {
 		l.renderInitPassword(w, r, authReq, authReq.UserID, "", err)
@@ -40,6 +84,18 @@ func (l *Login) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 	l.renderPasswordResetDone(w, r, authReq, err)
 }
 
+func (l *Login) renderPasswordReset(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, captchaPolicy *query.CaptchaPolicy, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := passwordResetData{
+		userData:    l.getUserData(r, authReq, "PasswordReset.Title", "PasswordReset.Description", errID, errMessage),
+		captchaData: newCaptchaData(captchaPolicy),
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPasswordReset], data, nil)
+}
+
 func (l *Login) renderPasswordResetDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
 	var errID, errMessage string
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"net/http"
 
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
@@ -69,3 +70,26 @@ func (l *Login) handlePasswordlessVerification(w http.ResponseWriter, r *http.Re
 	}
 	l.renderNextStep(w, r, authReq)
 }
+
+// handleDiscoverablePasswordlessVerification checks a passkey used on the login name page,
+// where the user is resolved from the credential instead of the entered login name
+func (l *Login) handleDiscoverablePasswordlessVerification(w http.ResponseWriter, r *http.Request) {
+	formData := new(webAuthNFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	credData, err := base64.URLEncoding.DecodeString(formData.CredentialData)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.VerifyDiscoverablePasswordless(r.Context(), authReq.ID, userAgentID, credData, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
@@ -38,6 +38,7 @@ type registerData struct {
 	ShowUsername       bool
 	ShowUsernameSuffix bool
 	OrgRegister        bool
+	captchaData
 }
 
 func (l *Login) handleRegister(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +69,17 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 	if authRequest != nil && authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != resourceOwner {
 		resourceOwner = authRequest.RequestedOrgID
 	}
+	captchaPolicy, err := l.registrationCaptchaPolicy(r, resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
+	if captchaPolicy != nil {
+		if err = l.verifyCaptcha(r, captchaPolicy); err != nil {
+			l.renderRegister(w, r, authRequest, data, err)
+			return
+		}
+	}
 	initCodeGenerator, err := l.query.InitEncryptionGenerator(r.Context(), domain.SecretGeneratorTypeInitCode, l.userCodeAlg)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
@@ -160,6 +172,13 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 	}
 	data.ShowUsernameSuffix = !labelPolicy.HideLoginNameSuffix
 
+	captchaPolicy, err := l.registrationCaptchaPolicy(r, resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, formData, err)
+		return
+	}
+	data.captchaData = newCaptchaData(captchaPolicy)
+
 	funcs := map[string]interface{}{
 		"selectedLanguage": func(l string) bool {
 			if formData == nil {
@@ -35,6 +35,7 @@ type registerOrgData struct {
 	HasSymbol                 string
 	UserLoginMustBeDomain     bool
 	IamDomain                 string
+	captchaData
 }
 
 func (l *Login) handleRegisterOrg(w http.ResponseWriter, r *http.Request) {
@@ -59,6 +60,18 @@ func (l *Login) handleRegisterOrgCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderRegisterOrg(w, r, authRequest, data, err)
 		return
 	}
+	// the org doesn't exist yet, so the default policy of the instance applies
+	captchaPolicy, err := l.registrationCaptchaPolicy(r, "")
+	if err != nil {
+		l.renderRegisterOrg(w, r, authRequest, data, err)
+		return
+	}
+	if captchaPolicy != nil {
+		if err = l.verifyCaptcha(r, captchaPolicy); err != nil {
+			l.renderRegisterOrg(w, r, authRequest, data, err)
+			return
+		}
+	}
 
 	ctx := setContext(r.Context(), "")
 	userIDs, err := l.getClaimedUserIDsOfOrgDomain(ctx, data.RegisterOrgName)
@@ -112,6 +125,8 @@ func (l *Login) renderRegisterOrg(w http.ResponseWriter, r *http.Request, authRe
 		data.UserLoginMustBeDomain = orgPolicy.UserLoginMustBeDomain
 		data.IamDomain = authz.GetInstance(r.Context()).RequestedDomain()
 	}
+	captchaPolicy, _ := l.registrationCaptchaPolicy(r, "")
+	data.captchaData = newCaptchaData(captchaPolicy)
 
 	if authRequest == nil {
 		l.customTexts(r.Context(), translator, "")
@@ -52,6 +52,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordlessRegistration:     "passwordless_registration.html",
 		tmplPasswordlessRegistrationDone: "passwordless_registration_done.html",
 		tmplPasswordlessPrompt:           "passwordless_prompt.html",
+		tmplMagicLinkSent:                "magic_link_sent.html",
+		tmplMagicLinkApprove:             "magic_link_approve.html",
+		tmplMagicLinkApproved:            "magic_link_approved.html",
 		tmplMFAVerify:                    "mfa_verify_otp.html",
 		tmplMFAPrompt:                    "mfa_prompt.html",
 		tmplMFAInitVerify:                "mfa_init_otp.html",
@@ -64,6 +67,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplInitPasswordDone:             "init_password_done.html",
 		tmplInitUser:                     "init_user.html",
 		tmplInitUserDone:                 "init_user_done.html",
+		tmplPasswordReset:                "password_reset.html",
 		tmplPasswordResetDone:            "password_reset_done.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
@@ -148,12 +152,21 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"passwordlessPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPasswordlessPrompt)
 		},
+		"passwordlessDiscoverableUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordlessDiscoverable)
+		},
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
+		"magicLinkUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMagicLink)
+		},
+		"magicLinkSendUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMagicLinkSend)
+		},
 		"mfaVerifyUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAVerify)
 		},
@@ -214,6 +227,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"showPasswordReset": func() bool {
 			return true
 		},
+		"showMagicLink": func() bool {
+			return false
+		},
 		"hasExternalLogin": func() bool {
 			return false
 		},
@@ -665,7 +681,8 @@ type mfaVerifyData struct {
 type mfaDoneData struct {
 	baseData
 	profileData
-	MFAType domain.MFAType
+	MFAType       domain.MFAType
+	RecoveryCodes []string
 }
 
 type otpData struct {
@@ -0,0 +1,35 @@
+document.addEventListener('DOMContentLoaded', function () {
+    redirectAfterFrontChannelLogout();
+});
+
+const frontChannelLogoutTimeout = 5000;
+
+function redirectAfterFrontChannelLogout() {
+    let redirect = document.getElementById("post-logout-redirect");
+    if (!redirect) {
+        return;
+    }
+    let frames = document.getElementsByClassName("lgn-frontchannel-logout");
+    let pending = frames.length;
+    let redirected = false;
+    let doRedirect = function () {
+        if (redirected) {
+            return;
+        }
+        redirected = true;
+        window.location.href = redirect.href;
+    };
+    if (pending === 0) {
+        doRedirect();
+        return;
+    }
+    for (let i = 0; i < frames.length; i++) {
+        frames[i].addEventListener("load", function () {
+            pending--;
+            if (pending === 0) {
+                doRedirect();
+            }
+        });
+    }
+    setTimeout(doRedirect, frontChannelLogoutTimeout);
+}
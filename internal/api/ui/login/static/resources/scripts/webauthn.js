@@ -29,3 +29,24 @@ function bufferEncode(value) {
         .replace(/\//g, "_")
         .replace(/=/g, "");
 }
+
+function encodeAssertion(assertedCredential) {
+    let authData = new Uint8Array(assertedCredential.response.authenticatorData);
+    let clientDataJSON = new Uint8Array(assertedCredential.response.clientDataJSON);
+    let rawId = new Uint8Array(assertedCredential.rawId);
+    let sig = new Uint8Array(assertedCredential.response.signature);
+    let userHandle = new Uint8Array(assertedCredential.response.userHandle);
+
+    let data = JSON.stringify({
+        id: assertedCredential.id,
+        rawId: bufferEncode(rawId),
+        type: assertedCredential.type,
+        response: {
+            authenticatorData: bufferEncode(authData),
+            clientDataJSON: bufferEncode(clientDataJSON),
+            signature: bufferEncode(sig),
+            userHandle: bufferEncode(userHandle),
+        },
+    })
+    return btoa(data);
+}
@@ -0,0 +1,43 @@
+let conditionalLogin;
+
+document.addEventListener('DOMContentLoaded', function () {
+    checkWebauthnSupported('btn-passkey', function () {
+        discoverableLogin(false);
+    });
+    if (window.PublicKeyCredential && PublicKeyCredential.isConditionalMediationAvailable) {
+        PublicKeyCredential.isConditionalMediationAvailable().then(function (available) {
+            if (available) {
+                discoverableLogin(true);
+            }
+        });
+    }
+});
+
+function discoverableLogin(conditional) {
+    let form = document.getElementById('passwordless-form');
+    document.getElementById('wa-error').classList.add('hidden');
+    if (conditionalLogin) {
+        conditionalLogin.abort();
+        conditionalLogin = undefined;
+    }
+
+    let makeAssertionOptions = JSON.parse(atob(form.elements['credentialAssertionData'].value));
+    makeAssertionOptions.publicKey.challenge = bufferDecode(makeAssertionOptions.publicKey.challenge);
+    let request = {
+        publicKey: makeAssertionOptions.publicKey
+    };
+    if (conditional) {
+        // let the browser offer the passkeys in the autofill of the loginname input
+        conditionalLogin = new AbortController();
+        request.mediation = 'conditional';
+        request.signal = conditionalLogin.signal;
+    }
+    navigator.credentials.get(request).then(function (credential) {
+        form.elements['credentialData'].value = encodeAssertion(credential);
+        form.submit();
+    }).catch(function (err) {
+        if (err.name !== 'AbortError') {
+            webauthnError(err);
+        }
+    });
+}
@@ -18,24 +18,6 @@ function login() {
 }
 
 function verifyAssertion(assertedCredential) {
-    let authData = new Uint8Array(assertedCredential.response.authenticatorData);
-    let clientDataJSON = new Uint8Array(assertedCredential.response.clientDataJSON);
-    let rawId = new Uint8Array(assertedCredential.rawId);
-    let sig = new Uint8Array(assertedCredential.response.signature);
-    let userHandle = new Uint8Array(assertedCredential.response.userHandle);
-
-    let data = JSON.stringify({
-        id: assertedCredential.id,
-        rawId: bufferEncode(rawId),
-        type: assertedCredential.type,
-        response: {
-            authenticatorData: bufferEncode(authData),
-            clientDataJSON: bufferEncode(clientDataJSON),
-            signature: bufferEncode(sig),
-            userHandle: bufferEncode(userHandle),
-        },
-    })
-
-    document.getElementsByName('credentialData')[0].value = btoa(data);
+    document.getElementsByName('credentialData')[0].value = encodeAssertion(assertedCredential);
     document.getElementsByTagName('form')[0].submit();
-}
\ No newline at end of file
+}
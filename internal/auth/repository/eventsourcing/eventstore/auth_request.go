@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
 	cache "github.com/zitadel/zitadel/internal/auth_request/repository"
+	"github.com/zitadel/zitadel/internal/captcha"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -24,8 +25,10 @@ import (
 	"github.com/zitadel/zitadel/internal/query"
 	user_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/throttle"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
 	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 )
 
 const unknownUserID = "UNKNOWN"
@@ -46,12 +49,17 @@ type AuthRequestRepo struct {
 	OrgViewProvider           orgViewProvider
 	LoginPolicyViewProvider   loginPolicyViewProvider
 	LockoutPolicyViewProvider lockoutPolicyViewProvider
+	RiskPolicyViewProvider    riskPolicyViewProvider
 	PrivacyPolicyProvider     privacyPolicyProvider
 	IDPProviderViewProvider   idpProviderViewProvider
 	IDPUserLinksProvider      idpUserLinksProvider
 	UserGrantProvider         userGrantProvider
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
+	Throttler                 throttler
+
+	CaptchaVerifier  captcha.Verifier
+	CaptchaSecretAlg crypto.EncryptionAlgorithm
 
 	IdGenerator id.Generator
 }
@@ -80,6 +88,15 @@ type lockoutPolicyViewProvider interface {
 	LockoutPolicyByOrg(context.Context, bool, string) (*query.LockoutPolicy, error)
 }
 
+type riskPolicyViewProvider interface {
+	RiskPolicyByOrg(context.Context, bool, string) (*query.RiskPolicy, error)
+}
+
+type throttler interface {
+	Check(ctx context.Context, subjects ...throttle.Subject) error
+	Failed(ctx context.Context, subjects ...throttle.Subject)
+}
+
 type idpProviderViewProvider interface {
 	IDPProvidersByAggregateIDAndState(string, string, iam_model.IDPConfigState) ([]*iam_view_model.IDPProviderView, error)
 }
@@ -94,6 +111,7 @@ type userEventProvider interface {
 
 type userCommandProvider interface {
 	BulkAddedUserIDPLinks(ctx context.Context, userID, resourceOwner string, externalIDPs []*domain.UserIDPLink) error
+	HumanAssessLoginRisk(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest, policy *domain.RiskPolicy) (bool, error)
 }
 
 type orgViewProvider interface {
@@ -210,9 +228,14 @@ func (repo *AuthRequestRepo) DeleteAuthRequest(ctx context.Context, id string) (
 	return repo.AuthRequests.DeleteAuthRequest(ctx, id)
 }
 
-func (repo *AuthRequestRepo) CheckLoginName(ctx context.Context, id, loginName, userAgentID string) (err error) {
+func (repo *AuthRequestRepo) CheckLoginName(ctx context.Context, id, loginName, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects("", userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
 	request, err := repo.getAuthRequest(ctx, id, userAgentID)
 	if err != nil {
 		return err
@@ -290,6 +313,13 @@ func (repo *AuthRequestRepo) SelectUser(ctx context.Context, id, userID, userAge
 	if err != nil {
 		return err
 	}
+	if err = repo.selectUser(ctx, request, userID); err != nil {
+		return err
+	}
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
+func (repo *AuthRequestRepo) selectUser(ctx context.Context, request *domain.AuthRequest, userID string) error {
 	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
 	if err != nil {
 		return err
@@ -302,12 +332,17 @@ func (repo *AuthRequestRepo) SelectUser(ctx context.Context, id, userID, userAge
 		username = user.PreferredLoginName
 	}
 	request.SetUserInfo(user.ID, username, user.PreferredLoginName, user.DisplayName, user.AvatarKey, user.ResourceOwner)
-	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+	return nil
 }
 
 func (repo *AuthRequestRepo) VerifyPassword(ctx context.Context, authReqID, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects(userID, userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
 	request, err := repo.getAuthRequestEnsureUser(ctx, authReqID, userAgentID, userID)
 	if err != nil {
 		if isIgnoreUserNotFoundError(err, request) {
@@ -334,6 +369,40 @@ func isIgnoreUserInvalidPasswordError(err error, request *domain.AuthRequest) bo
 	return request != nil && request.LoginPolicy != nil && request.LoginPolicy.IgnoreUnknownUsernames && errors.IsErrorInvalidArgument(err) && errors.Contains(err, "Errors.User.Password.Invalid")
 }
 
+// throttleSubjects returns the ip, user agent, user and user from the ip a password, OTP or login name check is throttled by
+func throttleSubjects(userID, userAgentID string, info *domain.BrowserInfo) []throttle.Subject {
+	subjects := make([]throttle.Subject, 0, 4)
+	if info != nil && info.RemoteIP != nil {
+		subjects = append(subjects, throttle.Subject{Kind: domain.ThrottleKindIP, Key: info.RemoteIP.String()})
+	}
+	if userAgentID != "" {
+		subjects = append(subjects, throttle.Subject{Kind: domain.ThrottleKindUserAgent, Key: userAgentID})
+	}
+	if userID != "" && userID != unknownUserID {
+		subjects = append(subjects, throttle.Subject{Kind: domain.ThrottleKindUser, Key: userID})
+		if info != nil && info.RemoteIP != nil {
+			subjects = append(subjects, throttle.UserIPSubject(userID, info.RemoteIP))
+		}
+	}
+	return subjects
+}
+
+func (repo *AuthRequestRepo) checkThrottled(ctx context.Context, subjects []throttle.Subject) error {
+	if repo.Throttler == nil {
+		return nil
+	}
+	return repo.Throttler.Check(ctx, subjects...)
+}
+
+// throttleFailedCheck records wrong passwords and codes and unknown users,
+// other errors (e.g. locked users) are not counted as failure
+func (repo *AuthRequestRepo) throttleFailedCheck(ctx context.Context, subjects []throttle.Subject, err error) {
+	if repo.Throttler == nil || !(errors.IsErrorInvalidArgument(err) || errors.IsNotFound(err)) {
+		return
+	}
+	repo.Throttler.Failed(ctx, subjects...)
+}
+
 func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		ObjectRoot: es_models.ObjectRoot{
@@ -349,9 +418,47 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 	}
 }
 
+func (repo *AuthRequestRepo) SendMagicLink(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	if !request.LoginPolicy.AllowMagicLink {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Mk3l0", "Errors.User.MagicLink.NotAllowed")
+	}
+	return repo.Command.HumanSendMagicLink(ctx, userID, resourceOwner, request)
+}
+
+// MagicLinkAuthRequest returns the auth request a magic link was sent for.
+// The user agent is not checked, because the link might be opened in another browser.
+func (repo *AuthRequestRepo) MagicLinkAuthRequest(ctx context.Context, authRequestID, userID string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return repo.getMagicLinkAuthRequest(ctx, authRequestID, userID)
+}
+
+// VerifyMagicLink checks the code of the magic link.
+// The check is recorded for the user agent of the auth request, so it will be completed in the browser it was started in.
+func (repo *AuthRequestRepo) VerifyMagicLink(ctx context.Context, authRequestID, userID, code string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getMagicLinkAuthRequest(ctx, authRequestID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMagicLink(ctx, userID, code, request.UserOrgID, request)
+}
+
 func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects(userID, userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
 	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
 	if err != nil {
 		return err
@@ -359,6 +466,69 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
+func (repo *AuthRequestRepo) SendMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	if _, err = repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID); err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPSMS(ctx, userID, resourceOwner)
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects(userID, userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) SendMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	if _, err = repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID); err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPEmail(ctx, userID, resourceOwner)
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects(userID, userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	subjects := throttleSubjects(userID, userAgentID, info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -434,6 +604,74 @@ func (repo *AuthRequestRepo) VerifyPasswordless(ctx context.Context, userID, res
 	return repo.Command.HumanFinishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, request)
 }
 
+// BeginDiscoverablePasswordlessLogin starts a passwordless login without a selected user (passkey / conditional UI).
+// The challenge is kept on the auth request until the credential is verified.
+func (repo *AuthRequestRepo) BeginDiscoverablePasswordlessLogin(ctx context.Context, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	if request.LoginPolicy == nil || request.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Dm2ls", "Errors.Org.LoginPolicy.PasswordlessNotAllowed")
+	}
+	login, err = repo.Command.BeginDiscoverablePasswordlessLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	request.DiscoverableLogin = &domain.WebAuthNLogin{
+		Challenge:        login.Challenge,
+		UserVerification: login.UserVerification,
+	}
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+// VerifyDiscoverablePasswordless resolves the user from the credential of a login started by BeginDiscoverablePasswordlessLogin,
+// verifies the credential and selects the user on the auth request.
+func (repo *AuthRequestRepo) VerifyDiscoverablePasswordless(ctx context.Context, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authRequestID, userAgentID)
+	if err != nil {
+		return err
+	}
+	if request.LoginPolicy == nil || request.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Dm3mt", "Errors.Org.LoginPolicy.PasswordlessNotAllowed")
+	}
+	if request.DiscoverableLogin == nil {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Dm4nu", "Errors.User.WebAuthN.NotFound")
+	}
+	userID, err := webauthn_helper.UserIDFromCredentialData(credentialData)
+	if err != nil {
+		return err
+	}
+	login := request.DiscoverableLogin
+	// the challenge can only be used once
+	request.DiscoverableLogin = nil
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return err
+	}
+	if err = repo.selectUser(ctx, request, userID); err != nil {
+		return err
+	}
+	// the policy of the organisation of the user might not allow passwordless
+	if err = repo.fillPolicies(ctx, request); err != nil {
+		return err
+	}
+	if request.LoginPolicy == nil || request.LoginPolicy.PasswordlessType == domain.PasswordlessTypeNotAllowed {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Dm5ow", "Errors.Org.LoginPolicy.PasswordlessNotAllowed")
+	}
+	err = repo.Command.HumanFinishDiscoverablePasswordlessLogin(ctx, request.UserID, request.UserOrgID, credentialData, login, request.WithCurrentInfo(info))
+	if err != nil {
+		return err
+	}
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -531,6 +769,27 @@ func (repo *AuthRequestRepo) getAuthRequestEnsureUser(ctx context.Context, authR
 	return request, nil
 }
 
+func (repo *AuthRequestRepo) getMagicLinkAuthRequest(ctx context.Context, authRequestID, userID string) (*domain.AuthRequest, error) {
+	request, err := repo.AuthRequests.GetAuthRequestByID(ctx, authRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID != userID {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Mk4l1", "Errors.User.NotMatchingUserID")
+	}
+	if err = repo.fillPolicies(ctx, request); err != nil {
+		return nil, err
+	}
+	if !request.LoginPolicy.AllowMagicLink {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Mk5l2", "Errors.User.MagicLink.NotAllowed")
+	}
+	_, err = activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, request.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
 func (repo *AuthRequestRepo) getAuthRequest(ctx context.Context, id, userAgentID string) (*domain.AuthRequest, error) {
 	request, err := repo.AuthRequests.GetAuthRequestByID(ctx, id)
 	if err != nil {
@@ -585,6 +844,10 @@ func (repo *AuthRequestRepo) fillPolicies(ctx context.Context, request *domain.A
 		return err
 	}
 	request.LockoutPolicy = lockoutPolicyToDomain(lockoutPolicy)
+	request.RiskPolicy, err = repo.getRiskPolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
 	privacyPolicy, err := repo.GetPrivacyPolicy(ctx, orgID)
 	if err != nil {
 		return err
@@ -836,6 +1099,7 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowMagicLink:             policy.AllowMagicLink,
 	}
 }
 
@@ -912,7 +1176,11 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if user.PreferredLoginName != "" {
 		request.LoginName = user.PreferredLoginName
 	}
-	userSession, err := userSessionByIDs(ctx, repo.UserSessionViewProvider, repo.UserEventProvider, request.AgentID, user)
+	agentID := request.AgentID
+	if request.SessionID != "" {
+		agentID = request.SessionID
+	}
+	userSession, err := userSessionByIDs(ctx, repo.UserSessionViewProvider, repo.UserEventProvider, agentID, user)
 	if err != nil {
 		return nil, err
 	}
@@ -939,7 +1207,7 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		}
 	}
 
-	step, ok, err := repo.mfaChecked(userSession, request, user)
+	step, ok, err := repo.mfaChecked(ctx, userSession, request, user)
 	if err != nil {
 		return nil, err
 	}
@@ -964,6 +1232,10 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
+	// sessions of the session API without an auth request are done after the user steps
+	if request.Request == nil {
+		return steps, nil
+	}
 	//PLANNED: consent step
 
 	missing, err := projectRequired(ctx, request, repo.ProjectProvider)
@@ -1043,6 +1315,11 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 		return &domain.PasswordlessRegistrationPromptStep{}
 	}
 
+	if request.LoginPolicy.AllowMagicLink && checkVerificationTimeMaxAge(userSession.MagicLinkVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.AuthTime = userSession.MagicLinkVerification
+		return nil
+	}
+
 	if user.PasswordInitRequired {
 		return &domain.InitPasswordStep{}
 	}
@@ -1058,9 +1335,18 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 	return &domain.PasswordStep{}
 }
 
-func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
+func (repo *AuthRequestRepo) mfaChecked(ctx context.Context, userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
 	mfaLevel := request.MFALevel()
 	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy)
+	if request.RiskPolicy.IsActive() {
+		// adaptive authentication: the second factor is only forced if a risk signal fires,
+		// users who set up a second factor themselves are still asked for it
+		riskDetected, err := repo.UserCommandProvider.HumanAssessLoginRisk(ctx, user.ID, user.ResourceOwner, request, request.RiskPolicy)
+		if err != nil {
+			return nil, false, err
+		}
+		required = riskDetected
+	}
 	promptRequired := (user.MFAMaxSetUp < mfaLevel) || (len(allowedProviders) == 0 && required)
 	if promptRequired || !repo.mfaSkippedOrSetUp(user, request) {
 		types := user.MFATypesSetupPossible(mfaLevel, request.LoginPolicy)
@@ -1148,6 +1434,34 @@ func (repo *AuthRequestRepo) getLockoutPolicy(ctx context.Context, orgID string)
 	return policy, err
 }
 
+func (repo *AuthRequestRepo) getRiskPolicy(ctx context.Context, orgID string) (*domain.RiskPolicy, error) {
+	policy, err := repo.RiskPolicyViewProvider.RiskPolicyByOrg(ctx, false, orgID)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return riskPolicyToDomain(policy), nil
+}
+
+func riskPolicyToDomain(policy *query.RiskPolicy) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		ObjectRoot: es_models.ObjectRoot{
+			AggregateID:   policy.ID,
+			Sequence:      policy.Sequence,
+			ResourceOwner: policy.ResourceOwner,
+			CreationDate:  policy.CreationDate,
+			ChangeDate:    policy.ChangeDate,
+		},
+		Default:                 policy.IsDefault,
+		MFAOnNewDevice:          policy.MFAOnNewDevice,
+		MFAOnNewIPRange:         policy.MFAOnNewIPRange,
+		ImpossibleTravelWindow:  policy.ImpossibleTravelWindow,
+		FailedAttemptsThreshold: policy.FailedAttemptsThreshold,
+	}
+}
+
 func (repo *AuthRequestRepo) getLabelPolicy(ctx context.Context, orgID string) (*domain.LabelPolicy, error) {
 	policy, err := repo.LabelPolicyProvider.ActiveLabelPolicyByOrg(ctx, orgID)
 	if err != nil {
@@ -1298,9 +1612,17 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.UserIDPLoginCheckSucceededType,
 			user_repo.HumanMFAOTPCheckSucceededType,
 			user_repo.HumanMFAOTPCheckFailedType,
+			user_repo.HumanMFAOTPSMSCheckSucceededType,
+			user_repo.HumanMFAOTPSMSCheckFailedType,
+			user_repo.HumanMFAOTPEmailCheckSucceededType,
+			user_repo.HumanMFAOTPEmailCheckFailedType,
+			user_repo.HumanMFARecoveryCodeCheckSucceededType,
+			user_repo.HumanMFARecoveryCodeCheckFailedType,
 			user_repo.HumanSignedOutType,
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
+			user_repo.HumanMagicLinkCheckSucceededType,
+			user_repo.HumanMagicLinkCheckFailedType,
 			user_repo.HumanU2FTokenCheckSucceededType,
 			user_repo.HumanU2FTokenCheckFailedType:
 			eventData, err := user_view_model.UserSessionFromEvent(event)
@@ -3,12 +3,14 @@ package eventstore
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
+	auth_request_repo "github.com/zitadel/zitadel/internal/auth_request/repository"
 	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -17,6 +19,7 @@ import (
 	proj_view_model "github.com/zitadel/zitadel/internal/project/repository/view/model"
 	"github.com/zitadel/zitadel/internal/query"
 	user_repo "github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/throttle"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
 	user_es_model "github.com/zitadel/zitadel/internal/user/repository/eventsourcing/model"
 	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
@@ -50,6 +53,7 @@ type mockViewUserSession struct {
 	ExternalLoginVerification time.Time
 	PasswordlessVerification  time.Time
 	PasswordVerification      time.Time
+	MagicLinkVerification     time.Time
 	SecondFactorVerification  time.Time
 	MultiFactorVerification   time.Time
 	Users                     []mockUser
@@ -66,6 +70,7 @@ func (m *mockViewUserSession) UserSessionByIDs(string, string, string) (*user_vi
 		ExternalLoginVerification: m.ExternalLoginVerification,
 		PasswordlessVerification:  m.PasswordlessVerification,
 		PasswordVerification:      m.PasswordVerification,
+		MagicLinkVerification:     m.MagicLinkVerification,
 		SecondFactorVerification:  m.SecondFactorVerification,
 		MultiFactorVerification:   m.MultiFactorVerification,
 	}, nil
@@ -144,6 +149,18 @@ func (m *mockLockoutPolicy) LockoutPolicyByOrg(context.Context, bool, string) (*
 	return m.policy, nil
 }
 
+type mockUserCommands struct {
+	riskDetected bool
+}
+
+func (m *mockUserCommands) BulkAddedUserIDPLinks(context.Context, string, string, []*domain.UserIDPLink) error {
+	return nil
+}
+
+func (m *mockUserCommands) HumanAssessLoginRisk(context.Context, string, string, *domain.AuthRequest, *domain.RiskPolicy) (bool, error) {
+	return m.riskDetected, nil
+}
+
 func (m *mockViewUser) UserByID(string, string) (*user_view_model.UserView, error) {
 	return &user_view_model.UserView{
 		State:    int32(user_model.UserStateActive),
@@ -242,6 +259,30 @@ func (m *mockIDPUserLinks) IDPUserLinks(ctx context.Context, queries *query.IDPU
 	return &query.IDPUserLinks{Links: m.idps}, nil
 }
 
+// mockAuthRequests only implements GetAuthRequestByID, the other methods panic
+type mockAuthRequests struct {
+	auth_request_repo.AuthRequestCache
+	request *domain.AuthRequest
+	err     error
+}
+
+func (m *mockAuthRequests) GetAuthRequestByID(context.Context, string) (*domain.AuthRequest, error) {
+	return m.request, m.err
+}
+
+type mockThrottler struct {
+	checkErr error
+	failed   []throttle.Subject
+}
+
+func (m *mockThrottler) Check(context.Context, ...throttle.Subject) error {
+	return m.checkErr
+}
+
+func (m *mockThrottler) Failed(_ context.Context, subjects ...throttle.Subject) {
+	m.failed = append(m.failed, subjects...)
+}
+
 func TestAuthRequestRepo_nextSteps(t *testing.T) {
 	type fields struct {
 		AuthRequests            *cache.AuthRequestCache
@@ -885,6 +926,69 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			}},
 			nil,
 		},
+		{
+			"magic link verified, mfa not verified, mfa check step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					MagicLinkVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+					OTPState:    int32(user_model.MFAStateReady),
+					MFAMaxSetUp: int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						AllowMagicLink:            true,
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			}},
+			nil,
+		},
+		{
+			"magic link verified, magic link not allowed, password step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					MagicLinkVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						PasswordCheckLifetime: 10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.PasswordStep{}},
+			nil,
+		},
 		{
 			"mfa not verified, mfa check step",
 			fields{
@@ -1391,6 +1495,42 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.LinkUsersStep{}},
 			nil,
 		},
+		{
+			"session without auth request, user authenticated, no step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     testNow.Add(-5 * time.Minute),
+					SecondFactorVerification: testNow.Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+				idpUserLinksProvider: &mockIDPUserLinks{},
+			},
+			args{
+				&domain.AuthRequest{
+					ID:        "SessionID",
+					AgentID:   "SessionID",
+					SessionID: "SessionID",
+					UserID:    "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+					},
+				}, true},
+			[]domain.NextStep{},
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1420,9 +1560,10 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 
 func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 	type args struct {
-		userSession *user_model.UserSessionView
-		request     *domain.AuthRequest
-		user        *user_model.UserView
+		userSession  *user_model.UserSessionView
+		request      *domain.AuthRequest
+		user         *user_model.UserView
+		userCommands userCommandProvider
 	}
 	tests := []struct {
 		name        string
@@ -1591,11 +1732,112 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 			false,
 			nil,
 		},
+		{
+			"risk policy, no risk detected, set up, check and false",
+			args{
+				request: &domain.AuthRequest{
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+					RiskPolicy: &domain.RiskPolicy{MFAOnNewDevice: true},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+					},
+				},
+				userSession:  &user_model.UserSessionView{},
+				userCommands: &mockUserCommands{riskDetected: false},
+			},
+			&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			},
+			false,
+			nil,
+		},
+		{
+			"risk policy, force mfa, no risk detected, not set up, mfa skipped and true",
+			args{
+				request: &domain.AuthRequest{
+					LoginPolicy: &domain.LoginPolicy{
+						ForceMFA:      true,
+						SecondFactors: []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					},
+					RiskPolicy: &domain.RiskPolicy{MFAOnNewDevice: true},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelNotSetUp,
+					},
+				},
+				userSession:  &user_model.UserSessionView{},
+				userCommands: &mockUserCommands{riskDetected: false},
+			},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"risk policy, risk detected, check and false",
+			args{
+				request: &domain.AuthRequest{
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+					RiskPolicy: &domain.RiskPolicy{MFAOnNewDevice: true},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+					},
+				},
+				userSession:  &user_model.UserSessionView{},
+				userCommands: &mockUserCommands{riskDetected: true},
+			},
+			&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			},
+			false,
+			nil,
+		},
+		{
+			"risk policy, risk detected, not set up, required prompt and false",
+			args{
+				request: &domain.AuthRequest{
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:       []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						MFAInitSkipLifetime: 30 * 24 * time.Hour,
+					},
+					RiskPolicy: &domain.RiskPolicy{FailedAttemptsThreshold: 3},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp:    domain.MFALevelNotSetUp,
+						MFAInitSkipped: testNow,
+					},
+				},
+				userCommands: &mockUserCommands{riskDetected: true},
+			},
+			&domain.MFAPromptStep{
+				Required: true,
+				MFAProviders: []domain.MFAType{
+					domain.MFATypeOTP,
+				},
+			},
+			false,
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo := &AuthRequestRepo{}
-			got, ok, err := repo.mfaChecked(tt.args.userSession, tt.args.request, tt.args.user)
+			repo := &AuthRequestRepo{
+				UserCommandProvider: tt.args.userCommands,
+			}
+			got, ok, err := repo.mfaChecked(context.Background(), tt.args.userSession, tt.args.request, tt.args.user)
 			if (tt.errFunc != nil && !tt.errFunc(err)) || (err != nil && tt.errFunc == nil) {
 				t.Errorf("got wrong err: %v ", err)
 				return
@@ -1950,3 +2192,89 @@ func Test_userByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRequestRepo_VerifyMFAOTP_throttle(t *testing.T) {
+	type fields struct {
+		authRequests *mockAuthRequests
+		throttler    *mockThrottler
+	}
+	type args struct {
+		userAgentID string
+		info        *domain.BrowserInfo
+	}
+	type res struct {
+		errFunc func(error) bool
+		failed  []throttle.Subject
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"throttled, error",
+			fields{
+				authRequests: &mockAuthRequests{},
+				throttler: &mockThrottler{
+					checkErr: errors.ThrowResourceExhausted(nil, "THROT-Sw3lp", "Errors.Throttle.TooManyAttempts"),
+				},
+			},
+			args{
+				userAgentID: "agentID",
+				info:        &domain.BrowserInfo{RemoteIP: net.ParseIP("192.168.1.1")},
+			},
+			res{
+				errFunc: errors.IsResourceExhausted,
+			},
+		},
+		{
+			"failed check, recorded for ip, user agent, user and user from ip",
+			fields{
+				authRequests: &mockAuthRequests{
+					err: errors.ThrowNotFound(nil, "id", "Errors.AuthRequest.NotFound"),
+				},
+				throttler: &mockThrottler{},
+			},
+			args{
+				userAgentID: "agentID",
+				info:        &domain.BrowserInfo{RemoteIP: net.ParseIP("192.168.1.1")},
+			},
+			res{
+				errFunc: errors.IsNotFound,
+				failed: []throttle.Subject{
+					{Kind: domain.ThrottleKindIP, Key: "192.168.1.1"},
+					{Kind: domain.ThrottleKindUserAgent, Key: "agentID"},
+					{Kind: domain.ThrottleKindUser, Key: "userID"},
+					{Kind: domain.ThrottleKindUserIP, Key: "userID@192.168.1.1"},
+				},
+			},
+		},
+		{
+			"other error, not recorded",
+			fields{
+				authRequests: &mockAuthRequests{
+					request: &domain.AuthRequest{AgentID: "otherAgentID"},
+				},
+				throttler: &mockThrottler{},
+			},
+			args{
+				userAgentID: "agentID",
+			},
+			res{
+				errFunc: errors.IsPermissionDenied,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &AuthRequestRepo{
+				AuthRequests: tt.fields.authRequests,
+				Throttler:    tt.fields.throttler,
+			}
+			err := repo.VerifyMFAOTP(context.Background(), "authRequestID", "userID", "orgID", "code", tt.args.userAgentID, tt.args.info)
+			assert.True(t, tt.res.errFunc(err), "unexpected error: %v", err)
+			assert.Equal(t, tt.res.failed, tt.fields.throttler.failed)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	usr_model "github.com/zitadel/zitadel/internal/user/model"
 	usr_view "github.com/zitadel/zitadel/internal/user/repository/view"
@@ -68,10 +69,18 @@ func (r *RefreshTokenRepo) SearchMyRefreshTokens(ctx context.Context, userID str
 	if err != nil {
 		return nil, err
 	}
-	sequence, err := r.View.GetLatestRefreshTokenSequence(authz.GetInstance(ctx).InstanceID())
-	logging.Log("EVENT-GBdn4").OnError(err).WithField("traceID", tracing.TraceIDFromCtx(ctx)).Warn("could not read latest refresh token sequence")
-	request.Queries = append(request.Queries, &usr_model.RefreshTokenSearchQuery{Key: usr_model.RefreshTokenSearchKeyUserID, Method: domain.SearchMethodEquals, Value: userID})
-	tokens, count, err := r.View.SearchRefreshTokens(request)
+	userIDQuery, err := query.NewRefreshTokenUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, count, sequence, err := r.View.SearchRefreshTokens(ctx, &query.RefreshTokenSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: request.Offset,
+			Limit:  request.Limit,
+			Asc:    request.Asc,
+		},
+		Queries: []query.SearchQuery{userIDQuery},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -79,8 +88,8 @@ func (r *RefreshTokenRepo) SearchMyRefreshTokens(ctx context.Context, userID str
 		Offset:      request.Offset,
 		Limit:       request.Limit,
 		TotalResult: count,
-		Sequence:    sequence.CurrentSequence,
-		Timestamp:   sequence.LastSuccessfulSpoolerRun,
+		Sequence:    sequence.Sequence,
+		Timestamp:   sequence.Timestamp,
 		Result:      model.RefreshTokenViewsToModel(tokens),
 	}, nil
 }
@@ -0,0 +1,328 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (repo *AuthRequestRepo) CreateSession(ctx context.Context, authRequestID string) (_ *domain.Session, token string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	if authRequestID != "" {
+		if _, err = repo.AuthRequests.GetAuthRequestByID(ctx, authRequestID); err != nil {
+			return nil, "", err
+		}
+	}
+	session, token, err := repo.Command.AddSession(ctx, authRequestID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = repo.sessionNextSteps(ctx, session); err != nil {
+		return nil, "", err
+	}
+	return session, token, nil
+}
+
+func (repo *AuthRequestRepo) SessionByID(ctx context.Context, sessionID, token string) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, err := repo.Command.CheckSessionToken(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.sessionNextSteps(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (repo *AuthRequestRepo) CheckSessionUser(ctx context.Context, sessionID, token, loginName string, info *domain.BrowserInfo) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequest(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	subjects := throttleSubjects("", session.UserAgentID(), info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return nil, err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	if err = repo.checkLoginName(ctx, request, loginName); err != nil {
+		return nil, err
+	}
+	// the session api does not hide unknown usernames (or discover domains),
+	// the custom login ui is responsible for that
+	if request.UserID == "" || request.UserID == unknownUserID {
+		return nil, errors.ThrowNotFound(nil, "EVENT-Sd2kf", "Errors.User.NotFound")
+	}
+	if _, err = repo.Command.SessionUserChecked(ctx, session.AggregateID, request.UserID, request.UserOrgID); err != nil {
+		return nil, err
+	}
+	return repo.SessionByID(ctx, sessionID, token)
+}
+
+func (repo *AuthRequestRepo) CheckSessionPassword(ctx context.Context, sessionID, token, password, captchaResponse string, info *domain.BrowserInfo) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequestEnsureUser(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	subjects := throttleSubjects(session.UserID, session.UserAgentID(), info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return nil, err
+	}
+	if err = repo.checkSessionCaptcha(ctx, session, captchaResponse, info); err != nil {
+		return nil, err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	policy, err := repo.getLockoutPolicy(ctx, session.UserResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	err = repo.Command.HumanCheckPassword(ctx, session.UserResourceOwner, session.UserID, password, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+	if err != nil {
+		return nil, err
+	}
+	return repo.SessionByID(ctx, sessionID, token)
+}
+
+func (repo *AuthRequestRepo) CheckSessionOTP(ctx context.Context, sessionID, token, code string, info *domain.BrowserInfo) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequestEnsureUser(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	subjects := throttleSubjects(session.UserID, session.UserAgentID(), info)
+	if err = repo.checkThrottled(ctx, subjects); err != nil {
+		return nil, err
+	}
+	defer func() { repo.throttleFailedCheck(ctx, subjects, err) }()
+	err = repo.Command.HumanCheckMFAOTP(ctx, session.UserID, code, session.UserResourceOwner, request.WithCurrentInfo(info))
+	if err != nil {
+		return nil, err
+	}
+	return repo.SessionByID(ctx, sessionID, token)
+}
+
+func (repo *AuthRequestRepo) BeginSessionWebAuthN(ctx context.Context, sessionID, token string, passwordless bool) (_ *domain.WebAuthNLogin, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequestEnsureUser(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if passwordless {
+		return repo.Command.HumanBeginPasswordlessLogin(ctx, session.UserID, session.UserResourceOwner, request)
+	}
+	return repo.Command.HumanBeginU2FLogin(ctx, session.UserID, session.UserResourceOwner, request)
+}
+
+func (repo *AuthRequestRepo) CheckSessionWebAuthN(ctx context.Context, sessionID, token string, credentialData []byte, passwordless bool, info *domain.BrowserInfo) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequestEnsureUser(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if passwordless {
+		err = repo.Command.HumanFinishPasswordlessLogin(ctx, session.UserID, session.UserResourceOwner, credentialData, request.WithCurrentInfo(info))
+	} else {
+		err = repo.Command.HumanFinishU2FLogin(ctx, session.UserID, session.UserResourceOwner, credentialData, request.WithCurrentInfo(info))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repo.SessionByID(ctx, sessionID, token)
+}
+
+// CheckSessionExternalUser checks the user of the session by its link to the identity provider.
+// The authentication of the user on the identity provider is the responsibility of the caller.
+func (repo *AuthRequestRepo) CheckSessionExternalUser(ctx context.Context, sessionID, token, idpConfigID, externalUserID string, info *domain.BrowserInfo) (_ *domain.Session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, request, err := repo.getSessionAuthRequest(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if err = repo.checkSelectedExternalIDP(request, idpConfigID); err != nil {
+		return nil, err
+	}
+	sessionUserID := request.UserID
+	if err = repo.checkExternalUserLogin(ctx, request, idpConfigID, externalUserID); err != nil {
+		return nil, err
+	}
+	if sessionUserID != "" && sessionUserID != request.UserID {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Sf3mg", "Errors.Session.UserAlreadyChecked")
+	}
+	if _, err = repo.Command.SessionUserChecked(ctx, session.AggregateID, request.UserID, request.UserOrgID); err != nil {
+		return nil, err
+	}
+	if err = repo.Command.UserIDPLoginChecked(ctx, request.UserOrgID, request.UserID, request.WithCurrentInfo(info)); err != nil {
+		return nil, err
+	}
+	return repo.SessionByID(ctx, sessionID, token)
+}
+
+// FinalizeAuthRequest finishes the auth request of the session with the user of the session,
+// so the OIDC or SAML flow can be continued by calling the callback of the auth request.
+func (repo *AuthRequestRepo) FinalizeAuthRequest(ctx context.Context, sessionID, token string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, err := repo.Command.CheckSessionToken(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if session.AuthRequestID == "" {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Sg4nh", "Errors.Session.NoAuthRequest")
+	}
+	if session.UserID == "" {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Sh5oi", "Errors.Session.NotFinished")
+	}
+	request, err := repo.AuthRequests.GetAuthRequestByID(ctx, session.AuthRequestID)
+	if err != nil {
+		return nil, err
+	}
+	request.SessionID = session.AggregateID
+	request.SelectedIDPConfigID = ""
+	request.LinkingUsers = nil
+	if err = repo.selectUser(ctx, request, session.UserID); err != nil {
+		return nil, err
+	}
+	if err = repo.fillPolicies(ctx, request); err != nil {
+		return nil, err
+	}
+	steps, err := repo.nextSteps(ctx, request, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 || steps[len(steps)-1].Type() != domain.NextStepRedirectToCallback {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Si6pj", "Errors.Session.NotFinished")
+	}
+	request.PossibleSteps = steps
+	if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (repo *AuthRequestRepo) TerminateSession(ctx context.Context, sessionID, token string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	session, err := repo.Command.CheckSessionToken(ctx, sessionID, token)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != "" {
+		clientIDs, err := repo.Query.SessionClientIDs(ctx, session.UserAgentID(), []string{session.UserID})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = repo.Command.HumansSignOut(ctx, session.UserAgentID(), []string{session.UserID}, clientIDs); err != nil {
+			return nil, err
+		}
+	}
+	return repo.Command.TerminateSession(ctx, session.AggregateID)
+}
+
+// checkSessionCaptcha verifies the captcha response if the user failed more password checks
+// than the captcha policy of the organisation allows, the same as the login does
+func (repo *AuthRequestRepo) checkSessionCaptcha(ctx context.Context, session *domain.Session, captchaResponse string, info *domain.BrowserInfo) error {
+	policy, err := repo.Query.CaptchaPolicyByOrg(ctx, false, session.UserResourceOwner)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !policy.ProviderType.Valid() || policy.FailedLoginsThreshold == 0 {
+		return nil
+	}
+	failedLogins, err := repo.Query.PasswordCheckFailedCount(ctx, session.UserResourceOwner, session.UserID)
+	if err != nil {
+		return err
+	}
+	if failedLogins < policy.FailedLoginsThreshold {
+		return nil
+	}
+	secret, err := crypto.DecryptString(policy.Secret, repo.CaptchaSecretAlg)
+	if err != nil {
+		return err
+	}
+	var remoteIP string
+	if info != nil && info.RemoteIP != nil {
+		remoteIP = info.RemoteIP.String()
+	}
+	return repo.CaptchaVerifier.Verify(ctx, policy.VerifyEndpoint(), secret, captchaResponse, remoteIP)
+}
+
+func (repo *AuthRequestRepo) sessionNextSteps(ctx context.Context, session *domain.Session) error {
+	request, err := repo.sessionAuthRequest(ctx, session)
+	if err != nil {
+		return err
+	}
+	session.NextSteps, err = repo.nextSteps(ctx, request, true)
+	return err
+}
+
+func (repo *AuthRequestRepo) getSessionAuthRequest(ctx context.Context, sessionID, token string) (*domain.Session, *domain.AuthRequest, error) {
+	session, err := repo.Command.CheckSessionToken(ctx, sessionID, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := repo.sessionAuthRequest(ctx, session)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, request, nil
+}
+
+func (repo *AuthRequestRepo) getSessionAuthRequestEnsureUser(ctx context.Context, sessionID, token string) (*domain.Session, *domain.AuthRequest, error) {
+	session, request, err := repo.getSessionAuthRequest(ctx, sessionID, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.UserID == "" {
+		return nil, nil, errors.ThrowPreconditionFailed(nil, "EVENT-Sj7qk", "Errors.User.UserIDMissing")
+	}
+	return session, request, nil
+}
+
+// sessionAuthRequest creates an auth request for the checks of the session.
+// It's never stored, but is used to run the same checks and next steps as the login.
+// The session id is used as id and user agent id of the auth request,
+// settings like the requested organisation are taken from the auth request of the session if there is one.
+func (repo *AuthRequestRepo) sessionAuthRequest(ctx context.Context, session *domain.Session) (*domain.AuthRequest, error) {
+	request := &domain.AuthRequest{
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+	}
+	if session.AuthRequestID != "" {
+		authRequest, err := repo.AuthRequests.GetAuthRequestByID(ctx, session.AuthRequestID)
+		if err != nil {
+			return nil, err
+		}
+		request = authRequest
+		request.SetUserInfo("", "", "", "", "", "")
+		request.SelectedIDPConfigID = ""
+		request.LinkingUsers = nil
+	}
+	request.ID = session.AggregateID
+	request.AgentID = session.UserAgentID()
+	request.SessionID = session.AggregateID
+	if session.UserID != "" {
+		if err := repo.selectUser(ctx, request, session.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if err := repo.fillPolicies(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
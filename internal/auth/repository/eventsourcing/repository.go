@@ -8,13 +8,16 @@ import (
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/spooler"
 	auth_view "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/auth_request/repository/cache"
+	"github.com/zitadel/zitadel/internal/captcha"
 	"github.com/zitadel/zitadel/internal/command"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	es_spol "github.com/zitadel/zitadel/internal/eventstore/v1/spooler"
 	"github.com/zitadel/zitadel/internal/id"
+	project_view_model "github.com/zitadel/zitadel/internal/project/repository/view/model"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/throttle"
 )
 
 type Config struct {
@@ -33,7 +36,7 @@ type EsRepository struct {
 	eventstore.OrgRepository
 }
 
-func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, dbClient *sql.DB, oidcEncryption crypto.EncryptionAlgorithm, userEncryption crypto.EncryptionAlgorithm) (*EsRepository, error) {
+func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Commands, queries *query.Queries, dbClient *sql.DB, oidcEncryption crypto.EncryptionAlgorithm, userEncryption crypto.EncryptionAlgorithm, idpConfigEncryption crypto.EncryptionAlgorithm, throttler *throttle.Throttler) (*EsRepository, error) {
 	es, err := v1.Start(dbClient)
 	if err != nil {
 		return nil, err
@@ -82,10 +85,14 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Comma
 			IDPProviderViewProvider:   view,
 			IDPUserLinksProvider:      queries,
 			LockoutPolicyViewProvider: queries,
+			RiskPolicyViewProvider:    queries,
 			LoginPolicyViewProvider:   queries,
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
 			ApplicationProvider:       queries,
+			Throttler:                 throttler,
+			CaptchaVerifier:           captcha.NewHTTPVerifier(nil),
+			CaptchaSecretAlg:          idpConfigEncryption,
 			IdGenerator:               idGenerator,
 		},
 		eventstore.TokenRepo{
@@ -132,6 +139,11 @@ func (q queryViewWrapper) UserGrantsByProjectAndUserID(ctx context.Context, proj
 	}
 	return grants.UserGrants, nil
 }
+
+func (q queryViewWrapper) OrgProjectMappingByIDs(orgID, projectID, instanceID string) (*project_view_model.OrgProjectMapping, error) {
+	return q.View.OrgProjectMappingByIDs(orgID, projectID, instanceID)
+}
+
 func (repo *EsRepository) Health(ctx context.Context) error {
 	if err := repo.UserRepo.Health(ctx); err != nil {
 		return err
@@ -1,98 +1,53 @@
 package view
 
 import (
-	"github.com/zitadel/zitadel/internal/errors"
-	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
-	user_model "github.com/zitadel/zitadel/internal/user/model"
-	usr_view "github.com/zitadel/zitadel/internal/user/repository/view"
-	"github.com/zitadel/zitadel/internal/user/repository/view/model"
-	"github.com/zitadel/zitadel/internal/view/repository"
-)
+	"context"
 
-const (
-	refreshTokenTable = "auth.refresh_tokens"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/user/repository/view/model"
 )
 
 func (v *View) RefreshTokenByID(tokenID, instanceID string) (*model.RefreshTokenView, error) {
-	return usr_view.RefreshTokenByID(v.Db, refreshTokenTable, tokenID, instanceID)
-}
-
-func (v *View) RefreshTokensByUserID(userID, instanceID string) ([]*model.RefreshTokenView, error) {
-	return usr_view.RefreshTokensByUserID(v.Db, refreshTokenTable, userID, instanceID)
-}
-
-func (v *View) SearchRefreshTokens(request *user_model.RefreshTokenSearchRequest) ([]*model.RefreshTokenView, uint64, error) {
-	return usr_view.SearchRefreshTokens(v.Db, refreshTokenTable, request)
-}
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
 
-func (v *View) PutRefreshToken(token *model.RefreshTokenView, event *models.Event) error {
-	err := usr_view.PutRefreshToken(v.Db, refreshTokenTable, token)
+	token, err := v.query.RefreshTokenByID(ctx, tokenID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return v.ProcessedTokenSequence(event)
+	return refreshTokenViewFromQuery(token, instanceID), nil
 }
 
-func (v *View) PutRefreshTokens(token []*model.RefreshTokenView, event *models.Event) error {
-	err := usr_view.PutRefreshTokens(v.Db, refreshTokenTable, token...)
+func (v *View) SearchRefreshTokens(ctx context.Context, queries *query.RefreshTokenSearchQueries) ([]*model.RefreshTokenView, uint64, *query.LatestSequence, error) {
+	tokens, err := v.query.SearchRefreshTokens(ctx, queries)
 	if err != nil {
-		return err
+		return nil, 0, nil, err
 	}
-	return v.ProcessedRefreshTokenSequence(event)
-}
-
-func (v *View) DeleteRefreshToken(tokenID, instanceID string, event *models.Event) error {
-	err := usr_view.DeleteRefreshToken(v.Db, refreshTokenTable, tokenID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
-	}
-	return v.ProcessedRefreshTokenSequence(event)
-}
-
-func (v *View) DeleteUserRefreshTokens(userID, instanceID string, event *models.Event) error {
-	err := usr_view.DeleteUserRefreshTokens(v.Db, refreshTokenTable, userID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
-	}
-	return v.ProcessedRefreshTokenSequence(event)
-}
-
-func (v *View) DeleteApplicationRefreshTokens(event *models.Event, ids ...string) error {
-	err := usr_view.DeleteApplicationTokens(v.Db, refreshTokenTable, event.InstanceID, ids)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	result := make([]*model.RefreshTokenView, len(tokens.RefreshTokens))
+	for i, token := range tokens.RefreshTokens {
+		result[i] = refreshTokenViewFromQuery(token, instanceID)
 	}
-	return v.ProcessedRefreshTokenSequence(event)
-}
-
-func (v *View) DeleteInstanceRefreshTokens(event *models.Event) error {
-	err := usr_view.DeleteInstanceRefreshTokens(v.Db, refreshTokenTable, event.InstanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+	return result, tokens.Count, tokens.LatestSequence, nil
+}
+
+func refreshTokenViewFromQuery(token *query.RefreshToken, instanceID string) *model.RefreshTokenView {
+	return &model.RefreshTokenView{
+		ID:                    token.ID,
+		CreationDate:          token.CreationDate,
+		ChangeDate:            token.ChangeDate,
+		ResourceOwner:         token.ResourceOwner,
+		Token:                 token.Token,
+		UserID:                token.UserID,
+		ClientID:              token.ClientID,
+		UserAgentID:           token.UserAgentID,
+		Audience:              token.Audience,
+		Scopes:                token.Scopes,
+		AuthMethodsReferences: token.AuthMethodsReferences,
+		AuthTime:              token.AuthTime,
+		IdleExpiration:        token.IdleExpiration,
+		Expiration:            token.Expiration,
+		Sequence:              token.Sequence,
+		InstanceID:            instanceID,
 	}
-	return v.ProcessedRefreshTokenSequence(event)
-}
-
-func (v *View) GetLatestRefreshTokenSequence(instanceID string) (*repository.CurrentSequence, error) {
-	return v.latestSequence(refreshTokenTable, instanceID)
-}
-
-func (v *View) GetLatestRefreshTokenSequences(instanceIDs ...string) ([]*repository.CurrentSequence, error) {
-	return v.latestSequences(refreshTokenTable, instanceIDs...)
-}
-
-func (v *View) ProcessedRefreshTokenSequence(event *models.Event) error {
-	return v.saveCurrentSequence(refreshTokenTable, event)
-}
-
-func (v *View) UpdateRefreshTokenSpoolerRunTimestamp() error {
-	return v.updateSpoolerRunSequence(refreshTokenTable)
-}
-
-func (v *View) GetLatestRefreshTokenFailedEvent(sequence uint64, instanceID string) (*repository.FailedEvent, error) {
-	return v.latestFailedEvent(refreshTokenTable, instanceID, sequence)
-}
-
-func (v *View) ProcessedRefreshTokenFailedEvent(failedEvent *repository.FailedEvent) error {
-	return v.saveFailedEvent(failedEvent)
 }
@@ -1,89 +1,63 @@
 package view
 
 import (
-	"github.com/zitadel/zitadel/internal/errors"
-	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
-	"github.com/zitadel/zitadel/internal/user/repository/view"
-	"github.com/zitadel/zitadel/internal/user/repository/view/model"
-	"github.com/zitadel/zitadel/internal/view/repository"
-)
+	"context"
 
-const (
-	userSessionTable = "auth.user_sessions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/user/repository/view/model"
 )
 
 func (v *View) UserSessionByIDs(agentID, userID, instanceID string) (*model.UserSessionView, error) {
-	return view.UserSessionByIDs(v.Db, userSessionTable, agentID, userID, instanceID)
-}
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
 
-func (v *View) UserSessionsByUserID(userID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByUserID(v.Db, userSessionTable, userID, instanceID)
+	session, err := v.query.UserSessionByIDs(ctx, agentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return userSessionViewFromQuery(session, instanceID), nil
 }
 
 func (v *View) UserSessionsByAgentID(agentID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByAgentID(v.Db, userSessionTable, agentID, instanceID)
-}
-
-func (v *View) UserSessionsByOrgID(orgID, instanceID string) ([]*model.UserSessionView, error) {
-	return view.UserSessionsByOrgID(v.Db, userSessionTable, orgID, instanceID)
-}
-
-func (v *View) ActiveUserSessionsCount() (uint64, error) {
-	return view.ActiveUserSessions(v.Db, userSessionTable)
-}
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
 
-func (v *View) PutUserSession(userSession *model.UserSessionView, event *models.Event) error {
-	err := view.PutUserSession(v.Db, userSessionTable, userSession)
+	sessions, err := v.query.UserSessionsByAgentID(ctx, agentID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) PutUserSessions(userSession []*model.UserSessionView, event *models.Event) error {
-	err := view.PutUserSessions(v.Db, userSessionTable, userSession...)
-	if err != nil {
-		return err
+	result := make([]*model.UserSessionView, len(sessions.UserSessions))
+	for i, session := range sessions.UserSessions {
+		result[i] = userSessionViewFromQuery(session, instanceID)
 	}
-	return v.ProcessedUserSessionSequence(event)
+	return result, nil
 }
 
-func (v *View) DeleteUserSessions(userID, instanceID string, event *models.Event) error {
-	err := view.DeleteUserSessions(v.Db, userSessionTable, userID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
-	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) DeleteInstanceUserSessions(event *models.Event) error {
-	err := view.DeleteInstanceUserSessions(v.Db, userSessionTable, event.InstanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+func (v *View) ActiveUserSessionsCount() (uint64, error) {
+	return v.query.ActiveUserSessionsCount(context.Background())
+}
+
+func userSessionViewFromQuery(session *query.UserSession, instanceID string) *model.UserSessionView {
+	return &model.UserSessionView{
+		CreationDate:                 session.CreationDate,
+		ChangeDate:                   session.ChangeDate,
+		ResourceOwner:                session.ResourceOwner,
+		State:                        int32(session.State),
+		UserAgentID:                  session.UserAgentID,
+		UserID:                       session.UserID,
+		UserName:                     session.UserName,
+		LoginName:                    session.LoginName,
+		DisplayName:                  session.DisplayName,
+		AvatarKey:                    session.AvatarKey,
+		SelectedIDPConfigID:          session.SelectedIDPConfigID,
+		PasswordVerification:         session.PasswordVerification,
+		PasswordlessVerification:     session.PasswordlessVerification,
+		MagicLinkVerification:        session.MagicLinkVerification,
+		ExternalLoginVerification:    session.ExternalLoginVerification,
+		SecondFactorVerification:     session.SecondFactorVerification,
+		SecondFactorVerificationType: int32(session.SecondFactorVerificationType),
+		MultiFactorVerification:      session.MultiFactorVerification,
+		MultiFactorVerificationType:  int32(session.MultiFactorVerificationType),
+		Sequence:                     session.Sequence,
+		InstanceID:                   instanceID,
 	}
-	return v.ProcessedUserSessionSequence(event)
-}
-
-func (v *View) GetLatestUserSessionSequence(instanceID string) (*repository.CurrentSequence, error) {
-	return v.latestSequence(userSessionTable, instanceID)
-}
-
-func (v *View) GetLatestUserSessionSequences(instanceIDs ...string) ([]*repository.CurrentSequence, error) {
-	return v.latestSequences(userSessionTable, instanceIDs...)
-}
-
-func (v *View) ProcessedUserSessionSequence(event *models.Event) error {
-	return v.saveCurrentSequence(userSessionTable, event)
-}
-
-func (v *View) UpdateUserSessionSpoolerRunTimestamp() error {
-	return v.updateSpoolerRunSequence(userSessionTable)
-}
-
-func (v *View) GetLatestUserSessionFailedEvent(sequence uint64, instanceID string) (*repository.FailedEvent, error) {
-	return v.latestFailedEvent(userSessionTable, instanceID, sequence)
-}
-
-func (v *View) ProcessedUserSessionFailedEvent(failedEvent *repository.FailedEvent) error {
-	return v.saveFailedEvent(failedEvent)
 }
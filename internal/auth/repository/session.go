@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, authRequestID string) (_ *domain.Session, token string, err error)
+	SessionByID(ctx context.Context, sessionID, token string) (*domain.Session, error)
+	CheckSessionUser(ctx context.Context, sessionID, token, loginName string, info *domain.BrowserInfo) (*domain.Session, error)
+	CheckSessionPassword(ctx context.Context, sessionID, token, password, captchaResponse string, info *domain.BrowserInfo) (*domain.Session, error)
+	CheckSessionOTP(ctx context.Context, sessionID, token, code string, info *domain.BrowserInfo) (*domain.Session, error)
+	BeginSessionWebAuthN(ctx context.Context, sessionID, token string, passwordless bool) (*domain.WebAuthNLogin, error)
+	CheckSessionWebAuthN(ctx context.Context, sessionID, token string, credentialData []byte, passwordless bool, info *domain.BrowserInfo) (*domain.Session, error)
+	CheckSessionExternalUser(ctx context.Context, sessionID, token, idpConfigID, externalUserID string, info *domain.BrowserInfo) (*domain.Session, error)
+	FinalizeAuthRequest(ctx context.Context, sessionID, token string) (*domain.AuthRequest, error)
+	TerminateSession(ctx context.Context, sessionID, token string) (*domain.ObjectDetails, error)
+}
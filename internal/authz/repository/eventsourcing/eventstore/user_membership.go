@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/query"
@@ -22,6 +23,12 @@ func (repo *UserMembershipRepo) SearchMyMemberships(ctx context.Context) (_ []*a
 	return userMembershipsToMemberships(memberships), nil
 }
 
+func (repo *UserMembershipRepo) CustomRolePermissions(ctx context.Context, roles ...string) (_ map[string][]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return repo.Queries.CustomRolePermissions(ctx, roles...)
+}
+
 func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context) (_ []*query.Membership, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -38,8 +45,12 @@ func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context) (_ []
 	if err != nil {
 		return nil, err
 	}
+	effectiveQuery, err := query.NewMembershipEffectiveQuery(time.Now())
+	if err != nil {
+		return nil, err
+	}
 	memberships, err := repo.Queries.Memberships(ctx, &query.MembershipSearchQuery{
-		Queries: []query.SearchQuery{userIDQuery, query.Or(orgIDsQuery, grantedIDQuery)},
+		Queries: []query.SearchQuery{userIDQuery, query.Or(orgIDsQuery, grantedIDQuery), effectiveQuery},
 	})
 	if err != nil {
 		return nil, err
@@ -1,61 +1,41 @@
 package view
 
 import (
-	"github.com/zitadel/zitadel/internal/errors"
-	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
-	usr_view "github.com/zitadel/zitadel/internal/user/repository/view"
-	usr_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
-	"github.com/zitadel/zitadel/internal/view/repository"
-)
+	"context"
 
-const (
-	tokenTable = "auth.tokens"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+	usr_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
 )
 
 func (v *View) TokenByIDs(tokenID, userID, instanceID string) (*usr_view_model.TokenView, error) {
-	return usr_view.TokenByIDs(v.Db, tokenTable, tokenID, userID, instanceID)
-}
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
 
-func (v *View) PutToken(token *usr_view_model.TokenView, event *models.Event) error {
-	err := usr_view.PutToken(v.Db, tokenTable, token)
+	token, err := v.Query.TokenByIDs(ctx, tokenID, userID)
 	if err != nil {
-		return err
-	}
-	return v.ProcessedTokenSequence(event)
-}
-
-func (v *View) DeleteToken(tokenID, instanceID string, event *models.Event) error {
-	err := usr_view.DeleteToken(v.Db, tokenTable, tokenID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
-	}
-	return v.ProcessedTokenSequence(event)
-}
-
-func (v *View) DeleteSessionTokens(agentID, userID, instanceID string, event *models.Event) error {
-	err := usr_view.DeleteSessionTokens(v.Db, tokenTable, agentID, userID, instanceID)
-	if err != nil && !errors.IsNotFound(err) {
-		return err
+		return nil, err
 	}
-	return v.ProcessedTokenSequence(event)
-}
-
-func (v *View) GetLatestTokenSequence(instanceID string) (*repository.CurrentSequence, error) {
-	return v.latestSequence(tokenTable, instanceID)
-}
-
-func (v *View) ProcessedTokenSequence(event *models.Event) error {
-	return v.saveCurrentSequence(tokenTable, event)
-}
-
-func (v *View) UpdateTokenSpoolerRunTimestamp() error {
-	return v.updateSpoolerRunSequence(tokenTable)
-}
-
-func (v *View) GetLatestTokenFailedEvent(sequence uint64, instanceID string) (*repository.FailedEvent, error) {
-	return v.latestFailedEvent(tokenTable, instanceID, sequence)
-}
-
-func (v *View) ProcessedTokenFailedEvent(failedEvent *repository.FailedEvent) error {
-	return v.saveFailedEvent(failedEvent)
+	return &usr_view_model.TokenView{
+		ID:                token.ID,
+		CreationDate:      token.CreationDate,
+		ChangeDate:        token.ChangeDate,
+		ResourceOwner:     token.ResourceOwner,
+		UserID:            token.UserID,
+		ApplicationID:     token.ApplicationID,
+		UserAgentID:       token.UserAgentID,
+		Audience:          token.Audience,
+		Scopes:            token.Scopes,
+		Expiration:        token.Expiration,
+		Sequence:          token.Sequence,
+		PreferredLanguage: token.PreferredLanguage,
+		RefreshTokenID:    token.RefreshTokenID,
+		IsPAT:             token.IsPAT,
+		Confirmation:      token.Confirmation,
+		InstanceID:        instanceID,
+	}, nil
+}
+
+func (v *View) GetLatestTokenSequence(instanceID string) (*query.LatestSequence, error) {
+	ctx := authz.WithInstanceID(context.Background(), instanceID)
+	return v.Query.LatestTokenSequence(ctx)
 }
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+
+	"github.com/zitadel/zitadel/internal/api/pop"
 )
 
 type TokenVerifierRepository interface {
-	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, confirmation *pop.Confirmation, err error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	VerifierClientID(ctx context.Context, appName string) (clientID, projectID string, err error)
 }
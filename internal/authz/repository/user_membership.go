@@ -8,4 +8,5 @@ import (
 
 type UserMembershipRepository interface {
 	SearchMyMemberships(ctx context.Context) ([]*authz.Membership, error)
+	CustomRolePermissions(ctx context.Context, roles ...string) (map[string][]string, error)
 }
@@ -0,0 +1,140 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math"
+	"os"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// bloomFilterMagic identifies the file format:
+// the magic, the number of bits (uint64) and hash functions (uint32) in big endian followed by the bits
+var bloomFilterMagic = [4]byte{'Z', 'B', 'F', '1'}
+
+// BloomFilter is an offline set of breached SHA-1 password hashes.
+// It never misses a breached password but reports a password as breached
+// with the false positive rate it was created with.
+type BloomFilter struct {
+	bits   []byte
+	size   uint64
+	hashes uint32
+}
+
+// NewBloomFilter creates an empty filter sized for the expected amount of hashes and false positive rate
+func NewBloomFilter(expectedItems uint64, falsePositiveRate float64) *BloomFilter {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	size := uint64(math.Ceil(-float64(expectedItems) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if size == 0 {
+		size = 8
+	}
+	hashes := uint32(math.Round(float64(size) / float64(expectedItems) * math.Ln2))
+	if hashes == 0 {
+		hashes = 1
+	}
+	return &BloomFilter{
+		bits:   make([]byte, (size+7)/8),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// LoadBloomFilter reads the filter file at path
+func LoadBloomFilter(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Sd9wq", "Errors.Internal")
+	}
+	defer file.Close()
+	return ReadBloomFilter(bufio.NewReader(file))
+}
+
+// ReadBloomFilter reads a filter written by WriteTo
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	var header struct {
+		Magic  [4]byte
+		Size   uint64
+		Hashes uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Lo2vc", "Errors.Internal")
+	}
+	if header.Magic != bloomFilterMagic || header.Size == 0 || header.Hashes == 0 {
+		return nil, errors.ThrowInternal(nil, "BREAC-Xm7ra", "Errors.Internal")
+	}
+	filter := &BloomFilter{
+		bits:   make([]byte, (header.Size+7)/8),
+		size:   header.Size,
+		hashes: header.Hashes,
+	}
+	if _, err := io.ReadFull(r, filter.bits); err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Hu4ne", "Errors.Internal")
+	}
+	return filter, nil
+}
+
+// WriteTo writes the filter in the format read by ReadBloomFilter
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, 0, 16)
+	header = append(header, bloomFilterMagic[:]...)
+	header = binary.BigEndian.AppendUint64(header, f.size)
+	header = binary.BigEndian.AppendUint32(header, f.hashes)
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(f.bits)
+	return int64(n + m), err
+}
+
+// Add adds the SHA-1 hash of a breached password
+func (f *BloomFilter) Add(sum [sha1.Size]byte) {
+	f.each(sum, func(idx uint64) bool {
+		f.bits[idx/8] |= 1 << (idx % 8)
+		return true
+	})
+}
+
+// AddHex adds the hex encoded SHA-1 hash of a breached password, as distributed by breach corpora
+func (f *BloomFilter) AddHex(hexHash string) error {
+	var sum [sha1.Size]byte
+	if hex.DecodedLen(len(hexHash)) != sha1.Size {
+		return errors.ThrowInvalidArgument(nil, "BREAC-Ce6to", "Errors.Internal")
+	}
+	if _, err := hex.Decode(sum[:], []byte(hexHash)); err != nil {
+		return errors.ThrowInvalidArgument(err, "BREAC-Wy1kd", "Errors.Internal")
+	}
+	f.Add(sum)
+	return nil
+}
+
+// Contains reports if the SHA-1 hash was (probably) added
+func (f *BloomFilter) Contains(sum [sha1.Size]byte) bool {
+	return f.each(sum, func(idx uint64) bool {
+		return f.bits[idx/8]&(1<<(idx%8)) != 0
+	})
+}
+
+func (f *BloomFilter) IsBreached(_ context.Context, password string) (bool, error) {
+	return f.Contains(hash(password)), nil
+}
+
+// each calls fn with the bit index of every hash function until fn returns false,
+// the indexes are derived from the SHA-1 hash by double hashing
+func (f *BloomFilter) each(sum [sha1.Size]byte, fn func(idx uint64) bool) bool {
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16])
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		if !fn((h1 + i*h2) % f.size) {
+			return false
+		}
+	}
+	return true
+}
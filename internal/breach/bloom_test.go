@@ -0,0 +1,57 @@
+package breach
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	filter := NewBloomFilter(1000, 0.001)
+	for i := 0; i < 1000; i++ {
+		filter.Add(sha1.Sum([]byte("breached" + strconv.Itoa(i))))
+	}
+	// hashes of breach corpora are upper case hex
+	require.NoError(t, filter.AddHex("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"))
+	assert.Error(t, filter.AddHex("5BAA61"))
+
+	var buf bytes.Buffer
+	_, err := filter.WriteTo(&buf)
+	require.NoError(t, err)
+	loaded, err := ReadBloomFilter(&buf)
+	require.NoError(t, err)
+
+	for _, f := range []*BloomFilter{filter, loaded} {
+		for i := 0; i < 1000; i++ {
+			breached, err := f.IsBreached(context.Background(), "breached"+strconv.Itoa(i))
+			require.NoError(t, err)
+			assert.True(t, breached)
+		}
+		breached, err := f.IsBreached(context.Background(), "password")
+		require.NoError(t, err)
+		assert.True(t, breached)
+
+		falsePositives := 0
+		for i := 0; i < 1000; i++ {
+			if f.Contains(sha1.Sum([]byte("safe" + strconv.Itoa(i)))) {
+				falsePositives++
+			}
+		}
+		assert.Less(t, falsePositives, 10)
+	}
+}
+
+func TestReadBloomFilter_invalid(t *testing.T) {
+	_, err := ReadBloomFilter(bytes.NewReader([]byte("ZBF2")))
+	assert.Error(t, err)
+
+	header, _ := hex.DecodeString("5a424631" + "0000000000000010" + "00000001")
+	_, err = ReadBloomFilter(bytes.NewReader(header))
+	assert.Error(t, err, "bits missing")
+}
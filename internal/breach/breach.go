@@ -0,0 +1,95 @@
+package breach
+
+import (
+	"context"
+	"crypto/sha1"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	CheckFailedCounter            = "breached_passwords.check_failed_counter"
+	CheckFailedCounterDescription = "Counter of breached password checks which failed because a corpus was unavailable"
+)
+
+// Checker checks if a password was found in a corpus of breached passwords
+type Checker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+type Config struct {
+	RangeAPI RangeAPIConfig
+	// BloomFilterPath is the path to a bloom filter file of breached password hashes
+	// built with `zitadel breached-passwords build-filter`, empty disables the offline check
+	BloomFilterPath string
+	// FailOpen accepts the password with a warning if a corpus cannot be checked (e.g. the range API is unreachable),
+	// otherwise the password is rejected and registrations, password changes and resets fail until the corpus is available again
+	FailOpen bool
+}
+
+type RangeAPIConfig struct {
+	Enabled bool
+	// Endpoint is the base url of the k-anonymity range API (HaveIBeenPwned compatible),
+	// the first five characters of the SHA-1 hash are requested as {Endpoint}/range/{prefix}
+	Endpoint string
+}
+
+// NewChecker creates a checker of the configured sources,
+// a password is breached if any of them found it.
+// It returns nil if no source is configured.
+func NewChecker(config Config) (Checker, error) {
+	sources := make(checkers, 0, 2)
+	if config.BloomFilterPath != "" {
+		filter, err := LoadBloomFilter(config.BloomFilterPath)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, filter)
+	}
+	if config.RangeAPI.Enabled {
+		sources = append(sources, NewRangeChecker(config.RangeAPI.Endpoint, nil))
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	if config.FailOpen {
+		err := metrics.RegisterCounter(CheckFailedCounter, CheckFailedCounterDescription)
+		logging.OnError(err).Warn("unable to register breached password check counter")
+		return &failOpen{checker: sources}, nil
+	}
+	return sources, nil
+}
+
+// failOpen treats the password as not breached if the checker fails
+type failOpen struct {
+	checker Checker
+}
+
+func (f *failOpen) IsBreached(ctx context.Context, password string) (bool, error) {
+	breached, err := f.checker.IsBreached(ctx, password)
+	if err != nil {
+		logging.WithError(err).Warn("unable to check breached passwords, password is accepted")
+		err = metrics.AddCount(ctx, CheckFailedCounter, 1, nil)
+		logging.OnError(err).Warn("unable to count failed breached password check")
+		return false, nil
+	}
+	return breached, nil
+}
+
+type checkers []Checker
+
+func (c checkers) IsBreached(ctx context.Context, password string) (bool, error) {
+	for _, checker := range c {
+		breached, err := checker.IsBreached(ctx, password)
+		if err != nil || breached {
+			return breached, err
+		}
+	}
+	return false, nil
+}
+
+func hash(password string) [sha1.Size]byte {
+	return sha1.Sum([]byte(password))
+}
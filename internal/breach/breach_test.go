@@ -0,0 +1,50 @@
+package breach
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChecker struct {
+	breached bool
+	err      error
+}
+
+func (m *mockChecker) IsBreached(context.Context, string) (bool, error) {
+	return m.breached, m.err
+}
+
+func TestFailOpen_IsBreached(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker Checker
+		want    bool
+	}{
+		{
+			name:    "breached",
+			checker: &mockChecker{breached: true},
+			want:    true,
+		},
+		{
+			name:    "not breached",
+			checker: &mockChecker{breached: false},
+			want:    false,
+		},
+		{
+			name:    "check failed",
+			checker: &mockChecker{err: errors.New("unreachable")},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&failOpen{checker: tt.checker}).IsBreached(context.Background(), "password")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
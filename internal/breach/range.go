@@ -0,0 +1,75 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	prefixLength   = 5
+)
+
+// RangeChecker checks the password with a k-anonymity range API:
+// only the first five characters of the SHA-1 hash leave ZITADEL
+// and the API returns the suffixes of all breached hashes with this prefix
+type RangeChecker struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewRangeChecker creates a checker using the client,
+// if client is nil a client with a default timeout is used
+func NewRangeChecker(endpoint string, client *http.Client) *RangeChecker {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &RangeChecker{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+	}
+}
+
+func (c *RangeChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := hash(password)
+	hashed := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hashed[:prefixLength], hashed[prefixLength:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/range/"+prefix, nil)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "BREAC-Nf8sl", "Errors.Internal")
+	}
+	// padding hides the amount of suffixes of the prefix from observers of the response size
+	req.Header.Set("Add-Padding", "true")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "BREAC-Kw2bd", "Errors.Internal")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logging.WithFields("status", resp.StatusCode).Warn("breached passwords range api returned an error")
+		return false, errors.ThrowInternal(nil, "BREAC-Gq5xe", "Errors.Internal")
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		hashSuffix, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+		// padded entries have a count of 0
+		return strings.TrimLeft(count, "0") != "", nil
+	}
+	if err = scanner.Err(); err != nil {
+		return false, errors.ThrowInternal(err, "BREAC-Pa3mv", "Errors.Internal")
+	}
+	return false, nil
+}
@@ -0,0 +1,82 @@
+package breach
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestRangeChecker_IsBreached(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	tests := []struct {
+		name        string
+		password    string
+		handler     http.HandlerFunc
+		want        bool
+		wantErrFunc func(error) bool
+	}{
+		{
+			name:     "breached",
+			password: "password",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n"))
+			},
+			want: true,
+		},
+		{
+			name:     "lowercase suffix",
+			password: "password",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("1e4c9b93f3f0682250b6cf8331b7ee68fd8:3\n"))
+			},
+			want: true,
+		},
+		{
+			name:     "padded entry",
+			password: "password",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:0\r\n"))
+			},
+			want: false,
+		},
+		{
+			name:     "not breached",
+			password: "password",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n"))
+			},
+			want: false,
+		},
+		{
+			name:     "api error",
+			password: "password",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantErrFunc: errors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/range/5BAA6", r.URL.Path)
+				assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			got, err := NewRangeChecker(server.URL+"/", server.Client()).IsBreached(context.Background(), tt.password)
+			if tt.wantErrFunc == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErrFunc(err), "unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Verifier verifies the response token a captcha widget submitted with a form
+type Verifier interface {
+	Verify(ctx context.Context, endpoint, secret, response, remoteIP string) error
+}
+
+// HTTPVerifier verifies the response with the siteverify endpoint of the provider.
+// hCaptcha, reCAPTCHA and Turnstile share the same request and response format.
+type HTTPVerifier struct {
+	client *http.Client
+}
+
+// NewHTTPVerifier creates a verifier using the client,
+// if client is nil a client with a default timeout is used
+func NewHTTPVerifier(client *http.Client) *HTTPVerifier {
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	return &HTTPVerifier{client: client}
+}
+
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (v *HTTPVerifier) Verify(ctx context.Context, endpoint, secret, response, remoteIP string) error {
+	if response == "" {
+		return errors.ThrowInvalidArgument(nil, "CAPTC-Jd8sk", "Errors.Captcha.Missing")
+	}
+	form := url.Values{
+		"secret":   {secret},
+		"response": {response},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.ThrowInternal(err, "CAPTC-Mw2ls", "Errors.Captcha.VerificationFailed")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return errors.ThrowInternal(err, "CAPTC-Zu4nc", "Errors.Captcha.VerificationFailed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logging.WithFields("status", resp.StatusCode).Warn("captcha verify endpoint returned an error")
+		return errors.ThrowInternal(nil, "CAPTC-Ok3xp", "Errors.Captcha.VerificationFailed")
+	}
+	result := new(verifyResponse)
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.ThrowInternal(err, "CAPTC-Be5rt", "Errors.Captcha.VerificationFailed")
+	}
+	if !result.Success {
+		logging.WithFields("errorCodes", result.ErrorCodes).Debug("captcha verification failed")
+		return errors.ThrowInvalidArgument(nil, "CAPTC-Qa7vh", "Errors.Captcha.Invalid")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestHTTPVerifier_Verify(t *testing.T) {
+	type args struct {
+		response string
+		remoteIP string
+	}
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		args        args
+		wantErrFunc func(error) bool
+	}{
+		{
+			name: "response missing",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				t.Error("verify endpoint must not be called")
+			},
+			wantErrFunc: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "not successful",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+			},
+			args: args{
+				response: "response",
+			},
+			wantErrFunc: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "endpoint error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			args: args{
+				response: "response",
+			},
+			wantErrFunc: errors.IsInternal,
+		},
+		{
+			name: "invalid body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`success`))
+			},
+			args: args{
+				response: "response",
+			},
+			wantErrFunc: errors.IsInternal,
+		},
+		{
+			name: "successful",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "secret", r.PostForm.Get("secret"))
+				assert.Equal(t, "response", r.PostForm.Get("response"))
+				assert.Equal(t, "192.168.1.1", r.PostForm.Get("remoteip"))
+				w.Write([]byte(`{"success": true}`))
+			},
+			args: args{
+				response: "response",
+				remoteIP: "192.168.1.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			err := NewHTTPVerifier(server.Client()).Verify(context.Background(), server.URL, "secret", tt.args.response, tt.args.remoteIP)
+			if tt.wantErrFunc == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErrFunc(err), "unexpected error: %v", err)
+			}
+		})
+	}
+}
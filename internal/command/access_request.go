@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// RequestProjectAccess creates a pending request of the user for roles of the project
+// the request belongs to the organisation owning the project
+func (c *Commands) RequestProjectAccess(ctx context.Context, request *domain.AccessRequest) (_ *domain.AccessRequest, err error) {
+	if !request.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rq2ng", "Errors.AccessRequest.Invalid")
+	}
+	project, err := c.getProjectWriteModelByID(ctx, request.ProjectID, "")
+	if err != nil {
+		return nil, err
+	}
+	if project.State != domain.ProjectStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lw8dk", "Errors.Project.NotFound")
+	}
+	err = c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+		UserID:    request.UserID,
+		ProjectID: request.ProjectID,
+		RoleKeys:  request.RoleKeys,
+	}, project.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	request.AggregateID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	addedRequest := NewAccessRequestWriteModel(request.AggregateID, project.ResourceOwner)
+	requestAgg := AccessRequestAggregateFromWriteModel(&addedRequest.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, accessrequest.NewAddedEvent(
+		ctx,
+		requestAgg,
+		request.UserID,
+		request.ProjectID,
+		request.RoleKeys,
+		request.Reason,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedRequest, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return accessRequestWriteModelToAccessRequest(addedRequest), nil
+}
+
+// ApproveAccessRequest grants the requested roles to the user
+// the user grant and the approval are pushed together
+func (c *Commands) ApproveAccessRequest(ctx context.Context, projectID, requestID, resourceOwner string, validity domain.Validity) (_ *domain.AccessRequest, err error) {
+	existingRequest, err := c.pendingAccessRequestWriteModel(ctx, projectID, requestID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	userGrant := &domain.UserGrant{
+		UserID:    existingRequest.UserID,
+		ProjectID: existingRequest.ProjectID,
+		RoleKeys:  existingRequest.RoleKeys,
+		Validity:  validity,
+	}
+	events, _, err := c.addUserGrant(ctx, userGrant, existingRequest.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	requestAgg := AccessRequestAggregateFromWriteModel(&existingRequest.WriteModel)
+	events = append(events, accessrequest.NewApprovedEvent(
+		ctx,
+		requestAgg,
+		existingRequest.UserID,
+		existingRequest.ProjectID,
+		userGrant.AggregateID,
+		validity.ValidFrom,
+		validity.ValidUntil,
+	))
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingRequest, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return accessRequestWriteModelToAccessRequest(existingRequest), nil
+}
+
+// DenyAccessRequest closes the request without granting any roles
+func (c *Commands) DenyAccessRequest(ctx context.Context, projectID, requestID, resourceOwner, reason string) (_ *domain.ObjectDetails, err error) {
+	existingRequest, err := c.pendingAccessRequestWriteModel(ctx, projectID, requestID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	requestAgg := AccessRequestAggregateFromWriteModel(&existingRequest.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, accessrequest.NewDeniedEvent(
+		ctx,
+		requestAgg,
+		existingRequest.UserID,
+		existingRequest.ProjectID,
+		reason,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingRequest, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingRequest.WriteModel), nil
+}
+
+func (c *Commands) pendingAccessRequestWriteModel(ctx context.Context, projectID, requestID, resourceOwner string) (_ *AccessRequestWriteModel, err error) {
+	if projectID == "" || requestID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ue6ma", "Errors.IDMissing")
+	}
+	existingRequest, err := c.accessRequestWriteModelByID(ctx, requestID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingRequest.State == domain.AccessRequestStateUnspecified || existingRequest.ProjectID != projectID {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Hx3ob", "Errors.AccessRequest.NotFound")
+	}
+	if existingRequest.State != domain.AccessRequestStatePending {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Cj8wa", "Errors.AccessRequest.NotPending")
+	}
+	return existingRequest, nil
+}
+
+func (c *Commands) accessRequestWriteModelByID(ctx context.Context, requestID, resourceOwner string) (writeModel *AccessRequestWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewAccessRequestWriteModel(requestID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,15 @@
+package command
+
+import "github.com/zitadel/zitadel/internal/domain"
+
+func accessRequestWriteModelToAccessRequest(wm *AccessRequestWriteModel) *domain.AccessRequest {
+	return &domain.AccessRequest{
+		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
+		State:       wm.State,
+		UserID:      wm.UserID,
+		ProjectID:   wm.ProjectID,
+		RoleKeys:    wm.RoleKeys,
+		Reason:      wm.Reason,
+		UserGrantID: wm.UserGrantID,
+	}
+}
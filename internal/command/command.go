@@ -7,6 +7,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -14,11 +15,14 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/group"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/static"
@@ -46,6 +50,8 @@ type Commands struct {
 	domainVerificationAlg       crypto.EncryptionAlgorithm
 	domainVerificationGenerator crypto.Generator
 	domainVerificationValidator func(domain, token, verifier string, checkType api_http.CheckType) error
+	sessionTokenGenerator       crypto.Generator
+	defaultSecretGenerators     map[domain.SecretGeneratorType]*crypto.GeneratorConfig
 
 	multifactors         domain.MultifactorConfigs
 	webauthnConfig       *webauthn_helper.Config
@@ -56,6 +62,9 @@ type Commands struct {
 	privateKeyLifetime   time.Duration
 	publicKeyLifetime    time.Duration
 	certificateLifetime  time.Duration
+
+	// breachChecker is nil if no breached password corpus is configured
+	breachChecker breach.Checker
 }
 
 func StartCommands(es *eventstore.Eventstore,
@@ -107,6 +116,9 @@ func StartCommands(es *eventstore.Eventstore,
 	org.RegisterEventMappers(repo.eventstore)
 	usr_repo.RegisterEventMappers(repo.eventstore)
 	usr_grant_repo.RegisterEventMappers(repo.eventstore)
+	accessrequest.RegisterEventMappers(repo.eventstore)
+	group.RegisterEventMappers(repo.eventstore)
+	session.RegisterEventMappers(repo.eventstore)
 	proj_repo.RegisterEventMappers(repo.eventstore)
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
@@ -120,10 +132,25 @@ func StartCommands(es *eventstore.Eventstore,
 			CryptoMFA: otpEncryption,
 			Issuer:    defaults.Multifactors.OTP.Issuer,
 		},
+		RecoveryCodes: domain.RecoveryCodesConfig{
+			Count:     int(defaults.Multifactors.RecoveryCodes.Count),
+			Generator: crypto.NewHashGenerator(defaults.Multifactors.RecoveryCodes.Generator, repo.userPasswordAlg),
+		},
+	}
+
+	repo.defaultSecretGenerators = map[domain.SecretGeneratorType]*crypto.GeneratorConfig{
+		domain.SecretGeneratorTypeOTPSMS:        &defaults.SecretGenerators.OTPSMS,
+		domain.SecretGeneratorTypeOTPEmail:      &defaults.SecretGenerators.OTPEmail,
+		domain.SecretGeneratorTypeMagicLinkCode: &defaults.SecretGenerators.MagicLinkCode,
 	}
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = api_http.ValidateDomain
+	repo.sessionTokenGenerator = crypto.NewEncryptionGenerator(defaults.Sessions.TokenGenerator, repo.userEncryption)
+	repo.breachChecker, err = breach.NewChecker(defaults.BreachedPasswords)
+	if err != nil {
+		return nil, err
+	}
 	return repo, nil
 }
 
@@ -46,6 +46,20 @@ func newCryptoCodeWithPlain(ctx context.Context, filter preparation.FilterToQuer
 	return nil, "", errors.ThrowInvalidArgument(nil, "V2-NGESt", "Errors.Internal")
 }
 
+// newEncryptedCodeWithDefault generates an encrypted code with the config of the instance,
+// if the instance has no config of the type, the default config is used
+func newEncryptedCodeWithDefault(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.EncryptionAlgorithm, defaultConfig *crypto.GeneratorConfig) (value *crypto.CryptoValue, expiry time.Duration, err error) {
+	config, err := secretGeneratorConfigWithDefault(ctx, filter, typ, defaultConfig)
+	if err != nil {
+		return nil, -1, err
+	}
+	value, _, err = crypto.NewCode(crypto.NewEncryptionGenerator(*config, alg))
+	if err != nil {
+		return nil, -1, err
+	}
+	return value, config.Expiry, nil
+}
+
 func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType) (*crypto.GeneratorConfig, error) {
 	wm := NewInstanceSecretGeneratorConfigWriteModel(ctx, typ)
 	events, err := filter(ctx, wm.Query())
@@ -65,3 +79,29 @@ func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQuery
 		IncludeSymbols:      wm.IncludeSymbols,
 	}, nil
 }
+
+func secretGeneratorConfigWithDefault(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, defaultConfig *crypto.GeneratorConfig) (*crypto.GeneratorConfig, error) {
+	wm := NewInstanceSecretGeneratorConfigWriteModel(ctx, typ)
+	events, err := filter(ctx, wm.Query())
+	if err != nil {
+		return nil, err
+	}
+	wm.AppendEvents(events...)
+	if err := wm.Reduce(); err != nil {
+		return nil, err
+	}
+	if wm.State != domain.SecretGeneratorStateActive {
+		if defaultConfig == nil {
+			return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ds3fq", "Errors.SecretGenerator.NotFound")
+		}
+		return defaultConfig, nil
+	}
+	return &crypto.GeneratorConfig{
+		Length:              wm.Length,
+		Expiry:              wm.Expiry,
+		IncludeLowerLetters: wm.IncludeLowerLetters,
+		IncludeUpperLetters: wm.IncludeUpperLetters,
+		IncludeDigits:       wm.IncludeDigits,
+		IncludeSymbols:      wm.IncludeSymbols,
+	}, nil
+}
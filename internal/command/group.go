@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddGroup(ctx context.Context, addGroup *domain.Group, resourceOwner string) (_ *domain.Group, err error) {
+	if !addGroup.IsValid() || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gq2mc", "Errors.Group.Invalid")
+	}
+	addGroup.AggregateID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	addedGroup := NewGroupWriteModel(addGroup.AggregateID, resourceOwner)
+	groupAgg := GroupAggregateFromWriteModel(&addedGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewAddedEvent(
+		ctx,
+		groupAgg,
+		addGroup.Name,
+		addGroup.Description,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return groupWriteModelToGroup(addedGroup), nil
+}
+
+func (c *Commands) ChangeGroup(ctx context.Context, changeGroup *domain.Group, resourceOwner string) (*domain.Group, error) {
+	if !changeGroup.IsValid() || changeGroup.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb3sl", "Errors.Group.Invalid")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, changeGroup.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]group.GroupChanges, 0, 2)
+	oldName := ""
+	if existingGroup.Name != changeGroup.Name {
+		oldName = existingGroup.Name
+		changes = append(changes, group.ChangeName(changeGroup.Name))
+	}
+	if existingGroup.Description != changeGroup.Description {
+		changes = append(changes, group.ChangeDescription(changeGroup.Description))
+	}
+	if len(changes) == 0 {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lr8xn", "Errors.NoChangesFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	changedEvent, err := group.NewChangedEvent(ctx, groupAgg, oldName, changes)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return groupWriteModelToGroup(existingGroup), nil
+}
+
+// RemoveGroup removes the group together with its members and grants
+func (c *Commands) RemoveGroup(ctx context.Context, groupID, resourceOwner string) (*domain.ObjectDetails, error) {
+	existingGroup, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewRemovedEvent(ctx, groupAgg, existingGroup.Name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+func (c *Commands) AddGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zp4kd", "Errors.Group.Member.Invalid")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGroup.hasMember(userID) {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Ym9cw", "Errors.Group.Member.AlreadyExists")
+	}
+	if err = c.checkUserExists(ctx, userID, ""); err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMemberAddedEvent(ctx, groupAgg, userID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+func (c *Commands) RemoveGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xc5ab", "Errors.Group.Member.Invalid")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGroup.hasMember(userID) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sd2fe", "Errors.Group.Member.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewMemberRemovedEvent(ctx, groupAgg, userID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+func (c *Commands) existingGroupWriteModel(ctx context.Context, groupID, resourceOwner string) (*GroupWriteModel, error) {
+	if groupID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fe4wq", "Errors.IDMissing")
+	}
+	existingGroup, err := c.groupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGroup.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Qa7ve", "Errors.Group.NotFound")
+	}
+	return existingGroup, nil
+}
+
+func (c *Commands) groupWriteModelByID(ctx context.Context, groupID, resourceOwner string) (writeModel *GroupWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewGroupWriteModel(groupID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
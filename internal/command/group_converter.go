@@ -0,0 +1,22 @@
+package command
+
+import "github.com/zitadel/zitadel/internal/domain"
+
+func groupWriteModelToGroup(wm *GroupWriteModel) *domain.Group {
+	return &domain.Group{
+		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
+		State:       wm.State,
+		Name:        wm.Name,
+		Description: wm.Description,
+	}
+}
+
+func groupGrantWriteModelToGroupGrant(wm *GroupWriteModel, grant *GroupGrantWriteModel) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot:     writeModelToObjectRoot(wm.WriteModel),
+		GrantID:        grant.GrantID,
+		ProjectID:      grant.ProjectID,
+		ProjectGrantID: grant.ProjectGrantID,
+		RoleKeys:       grant.RoleKeys,
+	}
+}
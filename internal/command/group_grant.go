@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+// AddGroupGrant grants roles of a project to all members of the group
+// a group can be granted at most once per project (grant)
+func (c *Commands) AddGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ *domain.GroupGrant, err error) {
+	if !grant.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dk4ns", "Errors.Group.Grant.Invalid")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, grant.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGroup.grantOfProject(grant.ProjectID, grant.ProjectGrantID) != nil {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Pe6qa", "Errors.Group.Grant.AlreadyExists")
+	}
+	err = c.checkGroupGrantPreCondition(ctx, grant, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	grantID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantAddedEvent(
+		ctx,
+		groupAgg,
+		grantID,
+		grant.ProjectID,
+		grant.ProjectGrantID,
+		grant.RoleKeys,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return groupGrantWriteModelToGroupGrant(existingGroup, existingGroup.grant(grantID)), nil
+}
+
+func (c *Commands) ChangeGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ *domain.GroupGrant, err error) {
+	if grant.AggregateID == "" || grant.GrantID == "" || len(grant.RoleKeys) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wm3kc", "Errors.Group.Grant.Invalid")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, grant.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	existingGrant := existingGroup.grant(grant.GrantID)
+	if existingGrant == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ty2ol", "Errors.Group.Grant.NotFound")
+	}
+	if reflect.DeepEqual(existingGrant.RoleKeys, grant.RoleKeys) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fu9xe", "Errors.Group.Grant.NotChanged")
+	}
+	grant.ProjectID = existingGrant.ProjectID
+	grant.ProjectGrantID = existingGrant.ProjectGrantID
+	err = c.checkGroupGrantPreCondition(ctx, grant, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantChangedEvent(ctx, groupAgg, grant.GrantID, grant.RoleKeys))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return groupGrantWriteModelToGroupGrant(existingGroup, existingGroup.grant(grant.GrantID)), nil
+}
+
+func (c *Commands) RemoveGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if grantID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ob6fj", "Errors.IDMissing")
+	}
+	existingGroup, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGroup.grant(grantID) == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ea3lu", "Errors.Group.Grant.NotFound")
+	}
+	groupAgg := GroupAggregateFromWriteModel(&existingGroup.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, group.NewGrantRemovedEvent(ctx, groupAgg, grantID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGroup, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGroup.WriteModel), nil
+}
+
+// checkGroupGrantPreCondition reuses the preconditions of user grants,
+// the user part stays empty as the grant belongs to a group
+func (c *Commands) checkGroupGrantPreCondition(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) error {
+	preConditions := NewUserGrantPreConditionReadModel("", grant.ProjectID, grant.ProjectGrantID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, preConditions)
+	if err != nil {
+		return err
+	}
+	if grant.ProjectGrantID == "" && !preConditions.ProjectExists {
+		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Jf5nb", "Errors.Project.NotFound")
+	}
+	if grant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
+		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Ru8wd", "Errors.Project.Grant.NotFound")
+	}
+	if grant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
+		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Kv2pm", "Errors.Project.Role.NotFound")
+	}
+	return nil
+}
@@ -0,0 +1,493 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_AddGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		grant         *domain.GroupGrant
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.GroupGrant
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no roles, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project already granted, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewGrantAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"grant1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"rolekey1"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "role not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"rolekey1"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "grant added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewGrantAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"grant1",
+								"project1",
+								"",
+								[]string{"rolekey1"},
+							)),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "grant1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"rolekey1"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "group1",
+						ResourceOwner: "org1",
+					},
+					GrantID:   "grant1",
+					ProjectID: "project1",
+					RoleKeys:  []string{"rolekey1"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.AddGroupGrant(tt.args.ctx, tt.args.grant, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		grant         *domain.GroupGrant
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.GroupGrant
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "grant not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"rolekey1"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "roles not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewGrantAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"grant1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"rolekey1"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "grant changed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewGrantAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"grant1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey2",
+								"rolekey",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewGrantChangedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"grant1",
+								[]string{"rolekey1", "rolekey2"},
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"rolekey1", "rolekey2"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "group1",
+						ResourceOwner: "org1",
+					},
+					GrantID:   "grant1",
+					ProjectID: "project1",
+					RoleKeys:  []string{"rolekey1", "rolekey2"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeGroupGrant(tt.args.ctx, tt.args.grant, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		grantID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "grant not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				grantID:       "grant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "grant removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewGrantAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"grant1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewGrantRemovedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"grant1",
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				grantID:       "grant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveGroupGrant(tt.args.ctx, tt.args.groupID, tt.args.grantID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+type GroupWriteModel struct {
+	eventstore.WriteModel
+
+	Name        string
+	Description string
+	State       domain.GroupState
+	Members     []string
+	Grants      []*GroupGrantWriteModel
+}
+
+type GroupGrantWriteModel struct {
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func NewGroupWriteModel(groupID, resourceOwner string) *GroupWriteModel {
+	return &GroupWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   groupID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *GroupWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.Name = e.Name
+			wm.Description = e.Description
+			wm.State = domain.GroupStateActive
+		case *group.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+		case *group.RemovedEvent:
+			wm.State = domain.GroupStateRemoved
+			wm.Members = nil
+			wm.Grants = nil
+		case *group.MemberAddedEvent:
+			wm.Members = append(wm.Members, e.UserID)
+		case *group.MemberRemovedEvent:
+			for i, userID := range wm.Members {
+				if userID == e.UserID {
+					wm.Members = append(wm.Members[:i], wm.Members[i+1:]...)
+					break
+				}
+			}
+		case *group.GrantAddedEvent:
+			wm.Grants = append(wm.Grants, &GroupGrantWriteModel{
+				GrantID:        e.GrantID,
+				ProjectID:      e.ProjectID,
+				ProjectGrantID: e.ProjectGrantID,
+				RoleKeys:       e.RoleKeys,
+			})
+		case *group.GrantChangedEvent:
+			if grant := wm.grant(e.GrantID); grant != nil {
+				grant.RoleKeys = e.RoleKeys
+			}
+		case *group.GrantRemovedEvent:
+			for i, grant := range wm.Grants {
+				if grant.GrantID == e.GrantID {
+					wm.Grants = append(wm.Grants[:i], wm.Grants[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			group.AddedType,
+			group.ChangedType,
+			group.RemovedType,
+			group.MemberAddedType,
+			group.MemberRemovedType,
+			group.GrantAddedType,
+			group.GrantChangedType,
+			group.GrantRemovedType,
+		).
+		Builder()
+}
+
+func (wm *GroupWriteModel) hasMember(userID string) bool {
+	for _, member := range wm.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (wm *GroupWriteModel) grant(grantID string) *GroupGrantWriteModel {
+	for _, grant := range wm.Grants {
+		if grant.GrantID == grantID {
+			return grant
+		}
+	}
+	return nil
+}
+
+func (wm *GroupWriteModel) grantOfProject(projectID, projectGrantID string) *GroupGrantWriteModel {
+	for _, grant := range wm.Grants {
+		if grant.ProjectID == projectID && grant.ProjectGrantID == projectGrantID {
+			return grant
+		}
+	}
+	return nil
+}
+
+func GroupAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, group.AggregateType, group.AggregateVersion)
+}
@@ -0,0 +1,644 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_AddGroup(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		group         *domain.Group
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.Group
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid group, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				group:         &domain.Group{},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "name already taken, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPushFailed(caos_errs.ThrowAlreadyExists(nil, "ERROR", "internal"),
+						[]*repository.Event{
+							eventFromEventPusher(group.NewAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"name",
+								"",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				group: &domain.Group{
+					Name: "name",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "group added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"name",
+								"description",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				group: &domain.Group{
+					Name:        "name",
+					Description: "description",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.Group{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "group1",
+						ResourceOwner: "org1",
+					},
+					State:       domain.GroupStateActive,
+					Name:        "name",
+					Description: "description",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.AddGroup(tt.args.ctx, tt.args.group, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeGroup(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		group         *domain.Group
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.Group
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "group not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				group: &domain.Group{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					Name:       "name",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"description",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				group: &domain.Group{
+					ObjectRoot:  models.ObjectRoot{AggregateID: "group1"},
+					Name:        "name",
+					Description: "description",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "name changed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"description",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(newGroupChangedEvent(context.Background(),
+								"group1", "org1", "name", "newname",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewRemoveGroupNameUniqueConstraint("name", "org1")),
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("newname", "org1")),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				group: &domain.Group{
+					ObjectRoot:  models.ObjectRoot{AggregateID: "group1"},
+					Name:        "newname",
+					Description: "description",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.Group{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "group1",
+						ResourceOwner: "org1",
+					},
+					State:       domain.GroupStateActive,
+					Name:        "newname",
+					Description: "description",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeGroup(tt.args.ctx, tt.args.group, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveGroup(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "group already removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewRemovedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "group removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewRemovedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"name",
+							)),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewRemoveGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveGroup(tt.args.ctx, tt.args.groupID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddGroupMember(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		userID        string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "member already exists, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewMemberAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"user1",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "member added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org2").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewMemberAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"user1",
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AddGroupMember(tt.args.ctx, tt.args.groupID, tt.args.userID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveGroupMember(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		userID        string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "member not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "member removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(group.NewAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"name",
+							"",
+						)),
+						eventFromEventPusher(group.NewMemberAddedEvent(context.Background(),
+							&group.NewAggregate("group1", "org1").Aggregate,
+							"user1",
+						)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(group.NewMemberRemovedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"user1",
+							)),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveGroupMember(tt.args.ctx, tt.args.groupID, tt.args.userID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newGroupChangedEvent(ctx context.Context, groupID, resourceOwner, oldName, newName string) *group.ChangedEvent {
+	event, _ := group.NewChangedEvent(ctx,
+		&group.NewAggregate(groupID, resourceOwner).Aggregate,
+		oldName,
+		[]group.GroupChanges{
+			group.ChangeName(newName),
+		},
+	)
+	return event
+}
@@ -47,17 +47,22 @@ type InstanceSetup struct {
 		PasswordVerificationCode *crypto.GeneratorConfig
 		PasswordlessInitCode     *crypto.GeneratorConfig
 		DomainVerification       *crypto.GeneratorConfig
+		OTPSMS                   *crypto.GeneratorConfig
+		OTPEmail                 *crypto.GeneratorConfig
+		MagicLinkCode            *crypto.GeneratorConfig
 	}
 	PasswordComplexityPolicy struct {
-		MinLength    uint64
-		HasLowercase bool
-		HasUppercase bool
-		HasNumber    bool
-		HasSymbol    bool
+		MinLength      uint64
+		HasLowercase   bool
+		HasUppercase   bool
+		HasNumber      bool
+		HasSymbol      bool
+		RejectBreached bool
 	}
 	PasswordAgePolicy struct {
 		ExpireWarnDays uint64
 		MaxAgeDays     uint64
+		HistoryCount   uint64
 	}
 	DomainPolicy struct {
 		UserLoginMustBeDomain                  bool
@@ -74,6 +79,7 @@ type InstanceSetup struct {
 		AllowDomainDiscovery       bool
 		DisableLoginWithEmail      bool
 		DisableLoginWithPhone      bool
+		AllowMagicLink             bool
 		PasswordlessType           domain.PasswordlessType
 		DefaultRedirectURI         string
 		PasswordCheckLifetime      time.Duration
@@ -192,6 +198,9 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordResetCode, setup.SecretGenerators.PasswordVerificationCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordlessInitCode, setup.SecretGenerators.PasswordlessInitCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeVerifyDomain, setup.SecretGenerators.DomainVerification),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPSMS, setup.SecretGenerators.OTPSMS),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPEmail, setup.SecretGenerators.OTPEmail),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeMagicLinkCode, setup.SecretGenerators.MagicLinkCode),
 
 		prepareAddDefaultPasswordComplexityPolicy(
 			instanceAgg,
@@ -200,11 +209,13 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.PasswordComplexityPolicy.HasUppercase,
 			setup.PasswordComplexityPolicy.HasNumber,
 			setup.PasswordComplexityPolicy.HasSymbol,
+			setup.PasswordComplexityPolicy.RejectBreached,
 		),
 		prepareAddDefaultPasswordAgePolicy(
 			instanceAgg,
 			setup.PasswordAgePolicy.ExpireWarnDays,
 			setup.PasswordAgePolicy.MaxAgeDays,
+			setup.PasswordAgePolicy.HistoryCount,
 		),
 		prepareAddDefaultDomainPolicy(
 			instanceAgg,
@@ -223,6 +234,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.LoginPolicy.AllowDomainDiscovery,
 			setup.LoginPolicy.DisableLoginWithEmail,
 			setup.LoginPolicy.DisableLoginWithPhone,
+			setup.LoginPolicy.AllowMagicLink,
 			setup.LoginPolicy.PasswordlessType,
 			setup.LoginPolicy.DefaultRedirectURI,
 			setup.LoginPolicy.PasswordCheckLifetime,
@@ -285,7 +297,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 	validations = append(validations,
 		AddOrgCommand(ctx, orgAgg, setup.Org.Name),
 		c.prepareSetDefaultOrg(instanceAgg, orgAgg.ID),
-		AddHumanCommand(userAgg, &setup.Org.Human, c.userPasswordAlg, c.userEncryption),
+		AddHumanCommand(userAgg, &setup.Org.Human, c.userPasswordAlg, c.userEncryption, c.breachChecker),
 		c.AddOrgMemberCommand(orgAgg, userID, domain.RoleOrgOwner),
 		c.AddInstanceMemberCommand(instanceAgg, userID, domain.RoleIAMOwner),
 
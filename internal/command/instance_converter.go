@@ -21,6 +21,7 @@ func memberWriteModelToMember(writeModel *MemberWriteModel) *domain.Member {
 		ObjectRoot: writeModelToObjectRoot(writeModel.WriteModel),
 		Roles:      writeModel.Roles,
 		UserID:     writeModel.UserID,
+		Validity:   writeModel.Validity,
 	}
 }
 
@@ -99,17 +100,19 @@ func writeModelToPasswordAgePolicy(wm *PasswordAgePolicyWriteModel) *domain.Pass
 		ObjectRoot:     writeModelToObjectRoot(wm.WriteModel),
 		MaxAgeDays:     wm.MaxAgeDays,
 		ExpireWarnDays: wm.ExpireWarnDays,
+		HistoryCount:   wm.HistoryCount,
 	}
 }
 
 func writeModelToPasswordComplexityPolicy(wm *PasswordComplexityPolicyWriteModel) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
-		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
-		MinLength:    wm.MinLength,
-		HasLowercase: wm.HasLowercase,
-		HasUppercase: wm.HasUppercase,
-		HasNumber:    wm.HasNumber,
-		HasSymbol:    wm.HasSymbol,
+		ObjectRoot:     writeModelToObjectRoot(wm.WriteModel),
+		MinLength:      wm.MinLength,
+		HasLowercase:   wm.HasLowercase,
+		HasUppercase:   wm.HasUppercase,
+		HasNumber:      wm.HasNumber,
+		HasSymbol:      wm.HasSymbol,
+		RejectBreached: wm.RejectBreached,
 	}
 }
 
@@ -121,6 +124,29 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	}
 }
 
+func writeModelToRiskPolicy(wm *RiskPolicyWriteModel) *domain.RiskPolicy {
+	return &domain.RiskPolicy{
+		ObjectRoot:              writeModelToObjectRoot(wm.WriteModel),
+		MFAOnNewDevice:          wm.MFAOnNewDevice,
+		MFAOnNewIPRange:         wm.MFAOnNewIPRange,
+		ImpossibleTravelWindow:  wm.ImpossibleTravelWindow,
+		FailedAttemptsThreshold: wm.FailedAttemptsThreshold,
+	}
+}
+
+func writeModelToCaptchaPolicy(wm *CaptchaPolicyWriteModel) *domain.CaptchaPolicy {
+	return &domain.CaptchaPolicy{
+		ObjectRoot:             writeModelToObjectRoot(wm.WriteModel),
+		ProviderType:           wm.ProviderType,
+		VerifyURL:              wm.VerifyURL,
+		SiteKey:                wm.SiteKey,
+		Secret:                 wm.Secret,
+		RequireOnRegistration:  wm.RequireOnRegistration,
+		RequireOnPasswordReset: wm.RequireOnPasswordReset,
+		FailedLoginsThreshold:  wm.FailedLoginsThreshold,
+	}
+}
+
 func writeModelToPrivacyPolicy(wm *PrivacyPolicyWriteModel) *domain.PrivacyPolicy {
 	return &domain.PrivacyPolicy{
 		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
@@ -0,0 +1,180 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddCustomRole(ctx context.Context, role *domain.CustomRole) (*domain.ObjectDetails, error) {
+	if err := c.checkCustomRole(role); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.customRoleWriteModelByKey(ctx, role.Key)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Ee3qv", "Errors.CustomRole.AlreadyExists")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewCustomRoleAddedEvent(ctx, instanceAgg, role.Key, role.DisplayName, role.Permissions))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeCustomRole(ctx context.Context, role *domain.CustomRole) (*domain.ObjectDetails, error) {
+	if err := c.checkCustomRole(role); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.customRoleWriteModelByKey(ctx, role.Key)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Hq2zd", "Errors.CustomRole.NotFound")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	changedEvent, hasChanged, err := writeModel.NewChangedEvent(ctx, instanceAgg, role.DisplayName, role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Jc7ro", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveCustomRole removes the role of the instance and from all members which have it,
+// members without any other role are removed
+func (c *Commands) RemoveCustomRole(ctx context.Context, key string) (*domain.ObjectDetails, error) {
+	if key == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Vb4xk", "Errors.CustomRole.Invalid")
+	}
+	writeModel, err := c.customRoleWriteModelByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.CustomRoleStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Ry0fn", "Errors.CustomRole.NotFound")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	memberEvents, err := c.removeCustomRoleFromMembers(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, append([]eventstore.Command{instance.NewCustomRoleRemovedEvent(ctx, instanceAgg, key)}, memberEvents...)...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// removeCustomRoleFromMembers returns the events which remove the role from the members,
+// the members are removed if it was their only role
+func (c *Commands) removeCustomRoleFromMembers(ctx context.Context, key string) ([]eventstore.Command, error) {
+	members := NewCustomRoleMembersWriteModel(ctx, key)
+	if err := c.eventstore.FilterToQueryReducer(ctx, members); err != nil {
+		return nil, err
+	}
+	isOrgRole := (&domain.CustomRole{Key: key}).RolePrefix() == domain.OrgRolePrefix
+	events := make([]eventstore.Command, 0)
+	for _, member := range members.membersWithRole() {
+		if isOrgRole {
+			orgAgg := &org.NewAggregate(member.aggregateID).Aggregate
+			if len(member.remainingRoles) == 0 {
+				events = append(events, c.removeOrgMember(ctx, orgAgg, member.userID, true))
+				continue
+			}
+			events = append(events, org.NewMemberChangedEvent(ctx, orgAgg, member.userID, member.remainingRoles...))
+			continue
+		}
+		instanceAgg := &instance.NewAggregate(member.aggregateID).Aggregate
+		if len(member.remainingRoles) == 0 {
+			events = append(events, c.removeInstanceMember(ctx, instanceAgg, member.userID, true))
+			continue
+		}
+		events = append(events, instance.NewMemberChangedEvent(ctx, instanceAgg, member.userID, member.remainingRoles...))
+	}
+	return events, nil
+}
+
+func (c *Commands) checkCustomRole(role *domain.CustomRole) error {
+	if role == nil || !role.IsValid() {
+		return caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Wk9cs", "Errors.CustomRole.Invalid")
+	}
+	for _, zitadelRole := range c.zitadelRoles {
+		if zitadelRole.Role == role.Key {
+			return caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Sd6ul", "Errors.CustomRole.DefaultRole")
+		}
+	}
+	if len(domain.CheckForInvalidPermissions(role.Permissions, role.RolePrefix(), c.zitadelRoles)) > 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Tz1ak", "Errors.CustomRole.PermissionsInvalid")
+	}
+	return nil
+}
+
+func (c *Commands) customRoleWriteModelByKey(ctx context.Context, key string) (_ *InstanceCustomRoleWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstanceCustomRoleWriteModel(ctx, key)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// checkMemberRoles returns true if all roles are either default roles or custom roles of the instance with the prefix
+func (c *Commands) checkMemberRoles(ctx context.Context, filter preparation.FilterToQueryReducer, rolePrefix string, roles []string) (bool, error) {
+	invalidRoles := domain.CheckForInvalidRoles(roles, rolePrefix, c.zitadelRoles)
+	if len(invalidRoles) == 0 {
+		return true, nil
+	}
+	for _, role := range invalidRoles {
+		if !strings.HasPrefix(role, rolePrefix+"_") {
+			return false, nil
+		}
+	}
+	writeModel := NewInstanceCustomRolesWriteModel(ctx)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return false, err
+	}
+	writeModel.AppendEvents(events...)
+	if err = writeModel.Reduce(); err != nil {
+		return false, err
+	}
+	for _, role := range invalidRoles {
+		if !writeModel.Keys[role] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,267 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type InstanceCustomRoleWriteModel struct {
+	eventstore.WriteModel
+
+	Key         string
+	DisplayName string
+	Permissions []string
+	State       domain.CustomRoleState
+}
+
+func NewInstanceCustomRoleWriteModel(ctx context.Context, key string) *InstanceCustomRoleWriteModel {
+	return &InstanceCustomRoleWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+		Key: key,
+	}
+}
+
+func (wm *InstanceCustomRoleWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *instance.CustomRoleChangedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *instance.CustomRoleRemovedEvent:
+			if e.Key == wm.Key {
+				wm.WriteModel.AppendEvents(e)
+			}
+		}
+	}
+}
+
+func (wm *InstanceCustomRoleWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			wm.DisplayName = e.DisplayName
+			wm.Permissions = e.Permissions
+			wm.State = domain.CustomRoleStateActive
+		case *instance.CustomRoleChangedEvent:
+			if e.DisplayName != nil {
+				wm.DisplayName = *e.DisplayName
+			}
+			if e.Permissions != nil {
+				wm.Permissions = *e.Permissions
+			}
+		case *instance.CustomRoleRemovedEvent:
+			wm.DisplayName = ""
+			wm.Permissions = nil
+			wm.State = domain.CustomRoleStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceCustomRoleWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.CustomRoleAddedEventType,
+			instance.CustomRoleChangedEventType,
+			instance.CustomRoleRemovedEventType).
+		Builder()
+}
+
+func (wm *InstanceCustomRoleWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	displayName string,
+	permissions []string,
+) (*instance.CustomRoleChangedEvent, bool, error) {
+	changes := make([]instance.CustomRoleChanges, 0)
+	if wm.DisplayName != displayName {
+		changes = append(changes, instance.ChangeCustomRoleDisplayName(displayName))
+	}
+	if !reflect.DeepEqual(wm.Permissions, permissions) {
+		changes = append(changes, instance.ChangeCustomRolePermissions(permissions))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewCustomRoleChangedEvent(ctx, aggregate, wm.Key, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+// InstanceCustomRolesWriteModel holds the keys of all custom roles of the instance
+type InstanceCustomRolesWriteModel struct {
+	eventstore.WriteModel
+
+	Keys map[string]bool
+}
+
+func NewInstanceCustomRolesWriteModel(ctx context.Context) *InstanceCustomRolesWriteModel {
+	return &InstanceCustomRolesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+		Keys: make(map[string]bool),
+	}
+}
+
+func (wm *InstanceCustomRolesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			wm.Keys[e.Key] = true
+		case *instance.CustomRoleRemovedEvent:
+			delete(wm.Keys, e.Key)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceCustomRolesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.CustomRoleAddedEventType,
+			instance.CustomRoleRemovedEventType).
+		Builder()
+}
+
+// CustomRoleMembersWriteModel holds the roles of the members the custom role can be assigned to,
+// the members of the instance for IAM_ roles and the members of all organisations for ORG_ roles
+type CustomRoleMembersWriteModel struct {
+	eventstore.WriteModel
+
+	Key string
+	// Members maps the id of the instance or organisation to the roles of its members by user id
+	Members map[string]map[string][]string
+}
+
+func NewCustomRoleMembersWriteModel(ctx context.Context, key string) *CustomRoleMembersWriteModel {
+	return &CustomRoleMembersWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+		Key:     key,
+		Members: make(map[string]map[string][]string),
+	}
+}
+
+func (wm *CustomRoleMembersWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.MemberAddedEvent:
+			wm.setRoles(e.Aggregate().ID, e.UserID, e.Roles)
+		case *instance.MemberChangedEvent:
+			wm.setRoles(e.Aggregate().ID, e.UserID, e.Roles)
+		case *instance.MemberRemovedEvent:
+			delete(wm.Members[e.Aggregate().ID], e.UserID)
+		case *instance.MemberCascadeRemovedEvent:
+			delete(wm.Members[e.Aggregate().ID], e.UserID)
+		case *org.MemberAddedEvent:
+			wm.setRoles(e.Aggregate().ID, e.UserID, e.Roles)
+		case *org.MemberChangedEvent:
+			wm.setRoles(e.Aggregate().ID, e.UserID, e.Roles)
+		case *org.MemberRemovedEvent:
+			delete(wm.Members[e.Aggregate().ID], e.UserID)
+		case *org.MemberCascadeRemovedEvent:
+			delete(wm.Members[e.Aggregate().ID], e.UserID)
+		case *org.OrgRemovedEvent:
+			delete(wm.Members, e.Aggregate().ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *CustomRoleMembersWriteModel) setRoles(aggregateID, userID string, roles []string) {
+	if wm.Members[aggregateID] == nil {
+		wm.Members[aggregateID] = make(map[string][]string)
+	}
+	wm.Members[aggregateID][userID] = roles
+}
+
+type customRoleMember struct {
+	aggregateID    string
+	userID         string
+	remainingRoles []string
+}
+
+// membersWithRole returns the members which have the custom role with their other roles,
+// sorted by aggregate and user id so the events are always created in the same order
+func (wm *CustomRoleMembersWriteModel) membersWithRole() []*customRoleMember {
+	members := make([]*customRoleMember, 0)
+	for aggregateID, memberRoles := range wm.Members {
+		for userID, roles := range memberRoles {
+			if remainingRoles, hadRole := removeRole(roles, wm.Key); hadRole {
+				members = append(members, &customRoleMember{aggregateID: aggregateID, userID: userID, remainingRoles: remainingRoles})
+			}
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].aggregateID != members[j].aggregateID {
+			return members[i].aggregateID < members[j].aggregateID
+		}
+		return members[i].userID < members[j].userID
+	})
+	return members
+}
+
+func (wm *CustomRoleMembersWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).AddQuery()
+	if (&domain.CustomRole{Key: wm.Key}).RolePrefix() == domain.OrgRolePrefix {
+		return query.
+			AggregateTypes(org.AggregateType).
+			EventTypes(
+				org.MemberAddedEventType,
+				org.MemberChangedEventType,
+				org.MemberRemovedEventType,
+				org.MemberCascadeRemovedEventType,
+				org.OrgRemovedEventType).
+			Builder()
+	}
+	return query.
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.MemberAddedEventType,
+			instance.MemberChangedEventType,
+			instance.MemberRemovedEventType,
+			instance.MemberCascadeRemovedEventType).
+		Builder()
+}
+
+func removeRole(roles []string, role string) (_ []string, removed bool) {
+	remaining := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r == role {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, r)
+	}
+	return remaining, removed
+}
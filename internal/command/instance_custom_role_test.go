@@ -0,0 +1,593 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/member"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+var testZitadelRoles = []authz.RoleMapping{
+	{
+		Role:        "IAM_OWNER",
+		Permissions: []string{"iam.read", "iam.write", "org.read"},
+	},
+	{
+		Role:        "ORG_OWNER",
+		Permissions: []string{"org.read", "user.read", "user.write", "policy.write"},
+	},
+}
+
+func TestCommandSide_AddCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx  context.Context
+		role *domain.CustomRole
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid key, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Key:         "PROJECT_SUPPORT",
+					Permissions: []string{"user.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "default role, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Key:         "ORG_OWNER",
+					Permissions: []string{"user.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "permission not granted by org roles, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.CustomRole{
+					Key:         "ORG_SUPPORT",
+					Permissions: []string{"user.read", "iam.write"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already exists, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ORG_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				role: &domain.CustomRole{
+					Key:         "ORG_SUPPORT",
+					Permissions: []string{"user.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add custom role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewCustomRoleAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"ORG_SUPPORT",
+									"support",
+									[]string{"user.read", "user.write"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", instance.NewAddCustomRoleUniqueConstraint("ORG_SUPPORT")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				role: &domain.CustomRole{
+					Key:         "ORG_SUPPORT",
+					DisplayName: "support",
+					Permissions: []string{"user.read", "user.write"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.AddCustomRole(tt.args.ctx, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx  context.Context
+		role *domain.CustomRole
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "not found, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				role: &domain.CustomRole{
+					Key:         "IAM_SUPPORT",
+					Permissions: []string{"iam.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"IAM_SUPPORT",
+								"support",
+								[]string{"iam.read"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				role: &domain.CustomRole{
+					Key:         "IAM_SUPPORT",
+					DisplayName: "support",
+					Permissions: []string{"iam.read"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change permissions, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"IAM_SUPPORT",
+								"support",
+								[]string{"iam.read"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								newCustomRoleChangedEvent(context.Background(), "IAM_SUPPORT", instance.ChangeCustomRolePermissions([]string{"iam.read", "org.read"})),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				role: &domain.CustomRole{
+					Key:         "IAM_SUPPORT",
+					DisplayName: "support",
+					Permissions: []string{"iam.read", "org.read"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.ChangeCustomRole(tt.args.ctx, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveCustomRole(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		key string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty key, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already removed, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ORG_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						),
+						eventFromEventPusher(
+							instance.NewCustomRoleRemovedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ORG_SUPPORT",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				key: "ORG_SUPPORT",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove custom role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ORG_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewCustomRoleRemovedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"ORG_SUPPORT",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", instance.NewRemoveCustomRoleUniqueConstraint("ORG_SUPPORT")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				key: "ORG_SUPPORT",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "remove custom org role of members, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ORG_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMemberAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user1",
+								"ORG_SUPPORT", "ORG_OWNER",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMemberAddedEvent(context.Background(),
+								&org.NewAggregate("org2").Aggregate,
+								"user2",
+								"ORG_SUPPORT",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMemberAddedEvent(context.Background(),
+								&org.NewAggregate("org2").Aggregate,
+								"user3",
+								"ORG_OWNER",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewCustomRoleRemovedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"ORG_SUPPORT",
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								org.NewMemberChangedEvent(context.Background(),
+									&org.NewAggregate("org1").Aggregate,
+									"user1",
+									"ORG_OWNER",
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								org.NewMemberCascadeRemovedEvent(context.Background(),
+									&org.NewAggregate("org2").Aggregate,
+									"user2",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", instance.NewRemoveCustomRoleUniqueConstraint("ORG_SUPPORT")),
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", member.NewRemoveMemberUniqueConstraint("org2", "user2")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				key: "ORG_SUPPORT",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "remove custom instance role of members, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"IAM_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewMemberAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"user1",
+								"IAM_SUPPORT", "IAM_OWNER",
+							),
+						),
+						eventFromEventPusher(
+							instance.NewMemberAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"user2",
+								"IAM_SUPPORT",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewCustomRoleRemovedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"IAM_SUPPORT",
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewMemberChangedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"user1",
+									"IAM_OWNER",
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewMemberCascadeRemovedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"user2",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", instance.NewRemoveCustomRoleUniqueConstraint("IAM_SUPPORT")),
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", member.NewRemoveMemberUniqueConstraint("INSTANCE", "user2")),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				key: "IAM_SUPPORT",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				zitadelRoles: testZitadelRoles,
+			}
+			got, err := r.RemoveCustomRole(tt.args.ctx, tt.args.key)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newCustomRoleChangedEvent(ctx context.Context, key string, changes ...instance.CustomRoleChanges) *instance.CustomRoleChangedEvent {
+	event, _ := instance.NewCustomRoleChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		key,
+		changes,
+	)
+	return event
+}
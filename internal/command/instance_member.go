@@ -19,10 +19,10 @@ func (c *Commands) AddInstanceMemberCommand(a *instance.Aggregate, userID string
 		if userID == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "INSTA-SDSfs", "Errors.Invalid.Argument")
 		}
-		if len(domain.CheckForInvalidRoles(roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
-			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-4m0fS", "Errors.IAM.MemberInvalid")
-		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+				if valid, err := c.checkMemberRoles(ctx, filter, domain.IAMRolePrefix, roles); err != nil || !valid {
+					return nil, caos_errs.ThrowInvalidArgument(err, "INSTANCE-4m0fS", "Errors.IAM.MemberInvalid")
+				}
 				if exists, err := ExistsUser(ctx, filter, userID, ""); err != nil || !exists {
 					return nil, errors.ThrowPreconditionFailed(err, "INSTA-GSXOn", "Errors.User.NotFound")
 				}
@@ -93,8 +93,8 @@ func (c *Commands) ChangeInstanceMember(ctx context.Context, member *domain.Memb
 	if !member.IsIAMValid() {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-LiaZi", "Errors.IAM.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
-		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-3m9fs", "Errors.IAM.MemberInvalid")
+	if valid, err := c.checkMemberRoles(ctx, c.eventstore.Filter, domain.IAMRolePrefix, member.Roles); err != nil || !valid {
+		return nil, caos_errs.ThrowInvalidArgument(err, "INSTANCE-3m9fs", "Errors.IAM.MemberInvalid")
 	}
 
 	existingMember, err := c.instanceMemberWriteModelByID(ctx, member.UserID)
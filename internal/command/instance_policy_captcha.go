@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddDefaultCaptchaPolicy(ctx context.Context, policy *domain.CaptchaPolicy) (*domain.CaptchaPolicy, error) {
+	if !policy.IsValid() || policy.SecretString == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Cp7oS", "Errors.IAM.CaptchaPolicy.Invalid")
+	}
+	addedPolicy, err := c.defaultCaptchaPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Cp8pT", "Errors.IAM.CaptchaPolicy.AlreadyExists")
+	}
+	secret, err := crypto.Encrypt([]byte(policy.SecretString), c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewCaptchaPolicyAddedEvent(ctx, &instanceAgg.Aggregate, policy.ProviderType, policy.VerifyURL, policy.SiteKey, secret, policy.RequireOnRegistration, policy.RequireOnPasswordReset, policy.FailedLoginsThreshold))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToCaptchaPolicy(&addedPolicy.CaptchaPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeDefaultCaptchaPolicy(ctx context.Context, policy *domain.CaptchaPolicy) (*domain.CaptchaPolicy, error) {
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Cp9qU", "Errors.IAM.CaptchaPolicy.Invalid")
+	}
+	existingPolicy, err := c.defaultCaptchaPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Cp0rV", "Errors.IAM.CaptchaPolicy.NotFound")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.CaptchaPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged, err := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Cp1sW", "Errors.IAM.CaptchaPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToCaptchaPolicy(&existingPolicy.CaptchaPolicyWriteModel), nil
+}
+
+func (c *Commands) defaultCaptchaPolicyWriteModelByID(ctx context.Context) (policy *InstanceCaptchaPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstanceCaptchaPolicyWriteModel(ctx)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceCaptchaPolicyWriteModel struct {
+	CaptchaPolicyWriteModel
+}
+
+func NewInstanceCaptchaPolicyWriteModel(ctx context.Context) *InstanceCaptchaPolicyWriteModel {
+	return &InstanceCaptchaPolicyWriteModel{
+		CaptchaPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceCaptchaPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.CaptchaPolicyAddedEvent:
+			wm.CaptchaPolicyWriteModel.AppendEvents(&e.CaptchaPolicyAddedEvent)
+		case *instance.CaptchaPolicyChangedEvent:
+			wm.CaptchaPolicyWriteModel.AppendEvents(&e.CaptchaPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceCaptchaPolicyWriteModel) Reduce() error {
+	return wm.CaptchaPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceCaptchaPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.CaptchaPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.CaptchaPolicyAddedEventType,
+			instance.CaptchaPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceCaptchaPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	captchaPolicy *domain.CaptchaPolicy,
+	secretCrypto crypto.EncryptionAlgorithm,
+) (*instance.CaptchaPolicyChangedEvent, bool, error) {
+	changes, err := wm.changes(captchaPolicy, secretCrypto)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changedEvent, err := instance.NewCaptchaPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changedEvent, true, nil
+}
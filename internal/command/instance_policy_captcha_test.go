@@ -0,0 +1,357 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+func TestCommandSide_AddDefaultCaptchaPolicy(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		secretCrypto crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx    context.Context
+		policy *domain.CaptchaPolicy
+	}
+	type res struct {
+		want *domain.CaptchaPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "provider type missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.CaptchaPolicy{
+					SiteKey:      "sitekey",
+					SecretString: "secret",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "secret missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:      "sitekey",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "verify url not https, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					VerifyURL:    "http://169.254.169.254/latest",
+					SiteKey:      "sitekey",
+					SecretString: "secret",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy already existing, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID(
+							"INSTANCE",
+							newDefaultCaptchaPolicyAddedEvent(),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:      "sitekey",
+					SecretString: "secret",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add policy,ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								newDefaultCaptchaPolicyAddedEvent(),
+							),
+						},
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.CaptchaPolicy{
+					ProviderType:          domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:               "sitekey",
+					SecretString:          "secret",
+					RequireOnRegistration: true,
+					FailedLoginsThreshold: 3,
+				},
+			},
+			res: res{
+				want: &domain.CaptchaPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+						InstanceID:    "INSTANCE",
+					},
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:      "sitekey",
+					Secret: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("secret"),
+					},
+					RequireOnRegistration: true,
+					FailedLoginsThreshold: 3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:          tt.fields.eventstore,
+				idpConfigEncryption: tt.fields.secretCrypto,
+			}
+			got, err := r.AddDefaultCaptchaPolicy(tt.args.ctx, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeDefaultCaptchaPolicy(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		secretCrypto crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx    context.Context
+		policy *domain.CaptchaPolicy
+	}
+	type res struct {
+		want *domain.CaptchaPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "policy not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.CaptchaPolicy{
+					ProviderType: domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:      "sitekey",
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newDefaultCaptchaPolicyAddedEvent(),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.CaptchaPolicy{
+					ProviderType:          domain.CaptchaProviderTypeHCaptcha,
+					SiteKey:               "sitekey",
+					RequireOnRegistration: true,
+					FailedLoginsThreshold: 3,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							newDefaultCaptchaPolicyAddedEvent(),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newDefaultCaptchaPolicyChangedEvent(context.Background()),
+							),
+						},
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.CaptchaPolicy{
+					ProviderType:           domain.CaptchaProviderTypeTurnstile,
+					SiteKey:                "sitekey",
+					SecretString:           "secret2",
+					RequireOnRegistration:  true,
+					RequireOnPasswordReset: true,
+					FailedLoginsThreshold:  3,
+				},
+			},
+			res: res{
+				want: &domain.CaptchaPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+					},
+					ProviderType: domain.CaptchaProviderTypeTurnstile,
+					SiteKey:      "sitekey",
+					Secret: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("secret2"),
+					},
+					RequireOnRegistration:  true,
+					RequireOnPasswordReset: true,
+					FailedLoginsThreshold:  3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:          tt.fields.eventstore,
+				idpConfigEncryption: tt.fields.secretCrypto,
+			}
+			got, err := r.ChangeDefaultCaptchaPolicy(tt.args.ctx, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newDefaultCaptchaPolicyAddedEvent() *instance.CaptchaPolicyAddedEvent {
+	return instance.NewCaptchaPolicyAddedEvent(context.Background(),
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		domain.CaptchaProviderTypeHCaptcha,
+		"",
+		"sitekey",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("secret"),
+		},
+		true,
+		false,
+		3,
+	)
+}
+
+func newDefaultCaptchaPolicyChangedEvent(ctx context.Context) *instance.CaptchaPolicyChangedEvent {
+	event, _ := instance.NewCaptchaPolicyChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		[]policy.CaptchaPolicyChanges{
+			policy.ChangeCaptchaSecret(&crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("secret2"),
+			}),
+			policy.ChangeCaptchaProviderType(domain.CaptchaProviderTypeTurnstile),
+			policy.ChangeCaptchaRequireOnPasswordReset(true),
+		},
+	)
+	return event
+}
@@ -239,6 +239,7 @@ func prepareChangeDefaultLoginPolicy(a *instance.Aggregate, policy *ChangeLoginP
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -265,6 +266,7 @@ func prepareAddDefaultLoginPolicy(
 	allowDomainDiscovery bool,
 	disableLoginWithEmail bool,
 	disableLoginWithPhone bool,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime time.Duration,
@@ -298,6 +300,7 @@ func prepareAddDefaultLoginPolicy(
 					allowDomainDiscovery,
 					disableLoginWithEmail,
 					disableLoginWithPhone,
+					allowMagicLink,
 					passwordlessType,
 					defaultRedirectURI,
 					passwordCheckLifetime,
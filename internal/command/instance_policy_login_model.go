@@ -69,7 +69,8 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -128,6 +129,9 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
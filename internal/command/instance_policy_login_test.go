@@ -73,6 +73,7 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -129,6 +130,7 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -281,6 +283,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -322,6 +325,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -383,6 +387,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -526,6 +531,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -567,6 +573,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -621,6 +628,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -680,6 +688,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -747,6 +756,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -24,6 +24,7 @@ func TestCommandSide_AddDefaultPasswordAgePolicy(t *testing.T) {
 		ctx            context.Context
 		maxAgeDays     uint64
 		expireWarnDays uint64
+		historyCount   uint64
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -46,6 +47,7 @@ func TestCommandSide_AddDefaultPasswordAgePolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								365,
 								10,
+								0,
 							),
 						),
 					),
@@ -60,6 +62,23 @@ func TestCommandSide_AddDefaultPasswordAgePolicy(t *testing.T) {
 				err: caos_errs.IsErrorAlreadyExists,
 			},
 		},
+		{
+			name: "history count too high, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:            authz.WithInstanceID(context.Background(), "INSTANCE"),
+				expireWarnDays: 365,
+				maxAgeDays:     10,
+				historyCount:   25,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add policy,ok",
 			fields: fields{
@@ -74,6 +93,7 @@ func TestCommandSide_AddDefaultPasswordAgePolicy(t *testing.T) {
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									365,
 									10,
+									0,
 								),
 							),
 						},
@@ -97,7 +117,7 @@ func TestCommandSide_AddDefaultPasswordAgePolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultPasswordAgePolicy(tt.args.ctx, tt.args.expireWarnDays, tt.args.maxAgeDays)
+			got, err := r.AddDefaultPasswordAgePolicy(tt.args.ctx, tt.args.expireWarnDays, tt.args.maxAgeDays, tt.args.historyCount)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -159,6 +179,7 @@ func TestCommandSide_ChangeDefaultPasswordAgePolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								365,
 								10,
+								0,
 							),
 						),
 					),
@@ -186,13 +207,14 @@ func TestCommandSide_ChangeDefaultPasswordAgePolicy(t *testing.T) {
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								365,
 								10,
+								0,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newDefaultPasswordAgePolicyChangedEvent(context.Background(), 125, 5),
+								newDefaultPasswordAgePolicyChangedEvent(context.Background(), 125, 5, 3),
 							),
 						},
 					),
@@ -203,6 +225,7 @@ func TestCommandSide_ChangeDefaultPasswordAgePolicy(t *testing.T) {
 				policy: &domain.PasswordAgePolicy{
 					MaxAgeDays:     125,
 					ExpireWarnDays: 5,
+					HistoryCount:   3,
 				},
 			},
 			res: res{
@@ -213,6 +236,7 @@ func TestCommandSide_ChangeDefaultPasswordAgePolicy(t *testing.T) {
 					},
 					MaxAgeDays:     125,
 					ExpireWarnDays: 5,
+					HistoryCount:   3,
 				},
 			},
 		},
@@ -236,12 +260,13 @@ func TestCommandSide_ChangeDefaultPasswordAgePolicy(t *testing.T) {
 	}
 }
 
-func newDefaultPasswordAgePolicyChangedEvent(ctx context.Context, maxAgeDays, expiryWarnDays uint64) *instance.PasswordAgePolicyChangedEvent {
+func newDefaultPasswordAgePolicyChangedEvent(ctx context.Context, maxAgeDays, expiryWarnDays, historyCount uint64) *instance.PasswordAgePolicyChangedEvent {
 	event, _ := instance.NewPasswordAgePolicyChangedEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
 		[]policy.PasswordAgePolicyChanges{
 			policy.ChangeExpireWarnDays(expiryWarnDays),
 			policy.ChangeMaxAgeDays(maxAgeDays),
+			policy.ChangeHistoryCount(historyCount),
 		},
 	)
 	return event
@@ -59,7 +59,8 @@ func (wm *InstancePasswordComplexityPolicyWriteModel) NewChangedEvent(
 	hasLowercase,
 	hasUppercase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	rejectBreached bool,
 ) (*instance.PasswordComplexityPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PasswordComplexityPolicyChanges, 0)
@@ -78,6 +79,9 @@ func (wm *InstancePasswordComplexityPolicyWriteModel) NewChangedEvent(
 	if wm.HasSymbol != hasSymbol {
 		changes = append(changes, policy.ChangeHasSymbol(hasSymbol))
 	}
+	if wm.RejectBreached != rejectBreached {
+		changes = append(changes, policy.ChangeRejectBreached(rejectBreached))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddDefaultRiskPolicy(ctx context.Context, policy *domain.RiskPolicy) (*domain.RiskPolicy, error) {
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Rk7oS", "Errors.IAM.RiskPolicy.Invalid")
+	}
+	addedPolicy, err := c.defaultRiskPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Rk8pT", "Errors.IAM.RiskPolicy.AlreadyExists")
+	}
+
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewRiskPolicyAddedEvent(ctx, &instanceAgg.Aggregate, policy.MFAOnNewDevice, policy.MFAOnNewIPRange, policy.ImpossibleTravelWindow, policy.FailedAttemptsThreshold))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToRiskPolicy(&addedPolicy.RiskPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeDefaultRiskPolicy(ctx context.Context, policy *domain.RiskPolicy) (*domain.RiskPolicy, error) {
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Rk9qU", "Errors.IAM.RiskPolicy.Invalid")
+	}
+	existingPolicy, err := c.defaultRiskPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Rk0rV", "Errors.IAM.RiskPolicy.NotFound")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.RiskPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Rk1sW", "Errors.IAM.RiskPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToRiskPolicy(&existingPolicy.RiskPolicyWriteModel), nil
+}
+
+func (c *Commands) defaultRiskPolicyWriteModelByID(ctx context.Context) (policy *InstanceRiskPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstanceRiskPolicyWriteModel(ctx)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceRiskPolicyWriteModel struct {
+	RiskPolicyWriteModel
+}
+
+func NewInstanceRiskPolicyWriteModel(ctx context.Context) *InstanceRiskPolicyWriteModel {
+	return &InstanceRiskPolicyWriteModel{
+		RiskPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceRiskPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.RiskPolicyAddedEvent:
+			wm.RiskPolicyWriteModel.AppendEvents(&e.RiskPolicyAddedEvent)
+		case *instance.RiskPolicyChangedEvent:
+			wm.RiskPolicyWriteModel.AppendEvents(&e.RiskPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceRiskPolicyWriteModel) Reduce() error {
+	return wm.RiskPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceRiskPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.RiskPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.RiskPolicyAddedEventType,
+			instance.RiskPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceRiskPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	riskPolicy *domain.RiskPolicy,
+) (*instance.RiskPolicyChangedEvent, bool) {
+	changes := wm.changes(riskPolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewRiskPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
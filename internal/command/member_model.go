@@ -9,8 +9,9 @@ import (
 type MemberWriteModel struct {
 	eventstore.WriteModel
 
-	UserID string
-	Roles  []string
+	UserID   string
+	Roles    []string
+	Validity domain.Validity
 
 	State domain.MemberState
 }
@@ -27,11 +28,15 @@ func (wm *MemberWriteModel) Reduce() error {
 		case *member.MemberAddedEvent:
 			wm.UserID = e.UserID
 			wm.Roles = e.Roles
+			wm.Validity = domain.Validity{}
 			wm.State = domain.MemberStateActive
 		case *member.MemberChangedEvent:
 			wm.Roles = e.Roles
+		case *member.MemberValiditySetEvent:
+			wm.Validity = domain.Validity{ValidFrom: e.ValidFrom, ValidUntil: e.ValidUntil}
 		case *member.MemberRemovedEvent:
 			wm.Roles = nil
+			wm.Validity = domain.Validity{}
 			wm.State = domain.MemberStateRemoved
 		}
 	}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -20,11 +21,10 @@ func (c *Commands) AddOrgMemberCommand(a *org.Aggregate, userID string, roles ..
 		if len(roles) == 0 {
 			return nil, errors.ThrowInvalidArgument(nil, "V2-PfYhb", "Errors.Invalid.Argument")
 		}
-
-		if len(domain.CheckForInvalidRoles(roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 && len(domain.CheckForInvalidRoles(roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) > 0 {
-			return nil, errors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
-		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+				if valid, err := c.checkOrgMemberRoles(ctx, filter, roles); err != nil || !valid {
+					return nil, errors.ThrowInvalidArgument(err, "Org-4N8es", "Errors.Org.MemberInvalid")
+				}
 				if exists, err := ExistsUser(ctx, filter, userID, ""); err != nil || !exists {
 					return nil, errors.ThrowPreconditionFailed(err, "ORG-GoXOn", "Errors.User.NotFound")
 				}
@@ -37,6 +37,14 @@ func (c *Commands) AddOrgMemberCommand(a *org.Aggregate, userID string, roles ..
 	}
 }
 
+// checkOrgMemberRoles returns true if the roles are either all org roles or all self management roles
+func (c *Commands) checkOrgMemberRoles(ctx context.Context, filter preparation.FilterToQueryReducer, roles []string) (bool, error) {
+	if len(domain.CheckForInvalidRoles(roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) == 0 {
+		return true, nil
+	}
+	return c.checkMemberRoles(ctx, filter, domain.OrgRolePrefix, roles)
+}
+
 func IsOrgMember(ctx context.Context, filter preparation.FilterToQueryReducer, orgID, userID string) (isMember bool, err error) {
 	events, err := filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(orgID).
@@ -73,12 +81,18 @@ func IsOrgMember(ctx context.Context, filter preparation.FilterToQueryReducer, o
 	return isMember, nil
 }
 
-func (c *Commands) AddOrgMember(ctx context.Context, orgID, userID string, roles ...string) (*domain.Member, error) {
+func (c *Commands) AddOrgMember(ctx context.Context, orgID, userID string, validity domain.Validity, roles ...string) (*domain.Member, error) {
+	if !validity.IsValid() || validity.IsExpired(time.Now()) {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Vq3ld", "Errors.Org.MemberInvalid")
+	}
 	orgAgg := org.NewAggregate(orgID)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.AddOrgMemberCommand(orgAgg, userID, roles...))
 	if err != nil {
 		return nil, err
 	}
+	if !validity.IsZero() {
+		cmds = append(cmds, org.NewMemberValiditySetEvent(ctx, &orgAgg.Aggregate, userID, validity.ValidFrom, validity.ValidUntil))
+	}
 	events, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
 		return nil, err
@@ -95,8 +109,8 @@ func (c *Commands) addOrgMember(ctx context.Context, orgAgg *eventstore.Aggregat
 	if !member.IsValid() {
 		return nil, errors.ThrowInvalidArgument(nil, "Org-W8m4l", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 && len(domain.CheckForInvalidRoles(member.Roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) > 0 {
-		return nil, errors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
+	if valid, err := c.checkOrgMemberRoles(ctx, c.eventstore.Filter, member.Roles); err != nil || !valid {
+		return nil, errors.ThrowInvalidArgument(err, "Org-4N8es", "Errors.Org.MemberInvalid")
 	}
 	err := c.eventstore.FilterToQueryReducer(ctx, addedMember)
 	if err != nil {
@@ -111,11 +125,11 @@ func (c *Commands) addOrgMember(ctx context.Context, orgAgg *eventstore.Aggregat
 
 //ChangeOrgMember updates an existing member
 func (c *Commands) ChangeOrgMember(ctx context.Context, member *domain.Member) (*domain.Member, error) {
-	if !member.IsValid() {
+	if !member.IsValid() || member.Validity.IsExpired(time.Now()) {
 		return nil, errors.ThrowInvalidArgument(nil, "Org-LiaZi", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 {
-		return nil, errors.ThrowInvalidArgument(nil, "IAM-m9fG8", "Errors.Org.MemberInvalid")
+	if valid, err := c.checkMemberRoles(ctx, c.eventstore.Filter, domain.OrgRolePrefix, member.Roles); err != nil || !valid {
+		return nil, errors.ThrowInvalidArgument(err, "IAM-m9fG8", "Errors.Org.MemberInvalid")
 	}
 
 	existingMember, err := c.orgMemberWriteModelByID(ctx, member.AggregateID, member.UserID)
@@ -123,11 +137,23 @@ func (c *Commands) ChangeOrgMember(ctx context.Context, member *domain.Member) (
 		return nil, err
 	}
 
-	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
+	rolesChanged := !reflect.DeepEqual(existingMember.Roles, member.Roles)
+	validityChanged := !existingMember.Validity.Equal(member.Validity)
+	if !rolesChanged && !validityChanged {
 		return nil, errors.ThrowPreconditionFailed(nil, "Org-LiaZi", "Errors.Org.Member.RolesNotChanged")
 	}
 	orgAgg := OrgAggregateFromWriteModel(&existingMember.MemberWriteModel.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, org.NewMemberChangedEvent(ctx, orgAgg, member.UserID, member.Roles...))
+	events := make([]eventstore.Command, 0, 2)
+	if rolesChanged {
+		events = append(events, org.NewMemberChangedEvent(ctx, orgAgg, member.UserID, member.Roles...))
+	}
+	if validityChanged {
+		events = append(events, org.NewMemberValiditySetEvent(ctx, orgAgg, member.UserID, member.ValidFrom, member.ValidUntil))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
 	err = AppendAndReduce(existingMember, pushedEvents...)
 	if err != nil {
 		return nil, err
@@ -158,6 +184,28 @@ func (c *Commands) RemoveOrgMember(ctx context.Context, orgID, userID string) (*
 	return writeModelToObjectDetails(&m.WriteModel), nil
 }
 
+// RemoveExpiredOrgMember removes the member if its validity has expired
+func (c *Commands) RemoveExpiredOrgMember(ctx context.Context, orgID, userID string) (*domain.ObjectDetails, error) {
+	m, err := c.orgMemberWriteModelByID(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Validity.IsExpired(time.Now()) {
+		return writeModelToObjectDetails(&m.WriteModel), nil
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&m.MemberWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, c.removeOrgMember(ctx, orgAgg, userID, false))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(m, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&m.WriteModel), nil
+}
+
 func (c *Commands) removeOrgMember(ctx context.Context, orgAgg *eventstore.Aggregate, userID string, cascade bool) eventstore.Command {
 	if cascade {
 		return org.NewMemberCascadeRemovedEvent(
@@ -34,6 +34,11 @@ func (wm *OrgMemberWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.MemberWriteModel.AppendEvents(&e.MemberChangedEvent)
+		case *org.MemberValiditySetEvent:
+			if e.UserID != wm.MemberWriteModel.UserID {
+				continue
+			}
+			wm.MemberWriteModel.AppendEvents(&e.MemberValiditySetEvent)
 		case *org.MemberRemovedEvent:
 			if e.UserID != wm.MemberWriteModel.UserID {
 				continue
@@ -61,6 +66,7 @@ func (wm *OrgMemberWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			org.MemberAddedEventType,
 			org.MemberChangedEventType,
+			org.MemberValiditySetEventType,
 			org.MemberRemovedEventType,
 			org.MemberCascadeRemovedEventType).
 		Builder()
@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/member"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/project"
@@ -58,14 +59,59 @@ func TestAddMember(t *testing.T) {
 			},
 		},
 		{
-			name: "TODO: invalid roles",
+			name: "invalid roles",
 			args: args{
 				a:      agg,
 				userID: "123",
 				roles:  []string{"ORG_OWNER"},
+				filter: NewMultiFilter().Append(
+					func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).Filter(),
+			},
+			want: Want{
+				CreateErr: errors.ThrowInvalidArgument(nil, "Org-4N8es", ""),
+			},
+		},
+		{
+			name: "custom role",
+			args: args{
+				a:      agg,
+				userID: "userID",
+				roles:  []string{"ORG_SUPPORT"},
+				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							instance.NewCustomRoleAddedEvent(
+								ctx,
+								&instance.NewAggregate("instance").Aggregate,
+								"ORG_SUPPORT",
+								"support",
+								[]string{"user.read"},
+							),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							user.NewMachineAddedEvent(
+								ctx,
+								&user.NewAggregate("id", "ro").Aggregate,
+								"userName",
+								"name",
+								"description",
+								true,
+							),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).
+					Filter(),
 			},
 			want: Want{
-				ValidationErr: errors.ThrowInvalidArgument(nil, "Org-4N8es", ""),
+				Commands: []eventstore.Command{
+					org.NewMemberAddedEvent(ctx, &agg.Aggregate, "userID", "ORG_SUPPORT"),
+				},
 			},
 		},
 		{
@@ -326,6 +372,7 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -519,7 +566,7 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 				eventstore:   tt.fields.eventstore,
 				zitadelRoles: tt.fields.zitadelRoles,
 			}
-			got, err := r.AddOrgMember(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.roles...)
+			got, err := r.AddOrgMember(tt.args.ctx, tt.args.orgID, tt.args.userID, domain.Validity{}, tt.args.roles...)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func (c *Commands) AddCaptchaPolicy(ctx context.Context, resourceOwner string, policy *domain.CaptchaPolicy) (*domain.CaptchaPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp8fJ", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() || policy.SecretString == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp9gK", "Errors.Org.CaptchaPolicy.Invalid")
+	}
+	if policy.VerifyURL != "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp7uW", "Errors.Org.CaptchaPolicy.VerifyURLNotAllowed")
+	}
+	addedPolicy, err := c.orgCaptchaPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "ORG-Cp0hL", "Errors.Org.CaptchaPolicy.AlreadyExists")
+	}
+	secret, err := crypto.Encrypt([]byte(policy.SecretString), c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&addedPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewCaptchaPolicyAddedEvent(ctx, orgAgg, policy.ProviderType, policy.VerifyURL, policy.SiteKey, secret, policy.RequireOnRegistration, policy.RequireOnPasswordReset, policy.FailedLoginsThreshold))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToCaptchaPolicy(&addedPolicy.CaptchaPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeCaptchaPolicy(ctx context.Context, resourceOwner string, policy *domain.CaptchaPolicy) (*domain.CaptchaPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp1iM", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp2jN", "Errors.Org.CaptchaPolicy.Invalid")
+	}
+	if policy.VerifyURL != "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp8vX", "Errors.Org.CaptchaPolicy.VerifyURLNotAllowed")
+	}
+	existingPolicy, err := c.orgCaptchaPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Cp3kO", "Errors.Org.CaptchaPolicy.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.CaptchaPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged, err := existingPolicy.NewChangedEvent(ctx, orgAgg, policy, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Cp4lP", "Errors.Org.CaptchaPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToCaptchaPolicy(&existingPolicy.CaptchaPolicyWriteModel), nil
+}
+
+func (c *Commands) RemoveCaptchaPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Cp5mQ", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := c.orgCaptchaPolicyWriteModelByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Cp6nR", "Errors.Org.CaptchaPolicy.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewCaptchaPolicyRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingPolicy.CaptchaPolicyWriteModel.WriteModel), nil
+}
+
+func (c *Commands) orgCaptchaPolicyWriteModelByID(ctx context.Context, orgID string) (*OrgCaptchaPolicyWriteModel, error) {
+	policy := NewOrgCaptchaPolicyWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
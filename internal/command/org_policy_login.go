@@ -35,6 +35,7 @@ type AddLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
 }
 
 type AddLoginPolicyIDP struct {
@@ -59,6 +60,7 @@ type ChangeLoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
 }
 
 func (c *Commands) AddLoginPolicy(ctx context.Context, resourceOwner string, policy *AddLoginPolicy) (*domain.ObjectDetails, error) {
@@ -429,6 +431,7 @@ func prepareAddLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -474,6 +477,7 @@ func prepareChangeLoginPolicy(a *org.Aggregate, policy *ChangeLoginPolicy) prepa
 				policy.AllowDomainDiscovery,
 				policy.DisableLoginWithEmail,
 				policy.DisableLoginWithPhone,
+				policy.AllowMagicLink,
 				policy.PasswordlessType,
 				policy.DefaultRedirectURI,
 				policy.PasswordCheckLifetime,
@@ -71,7 +71,8 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -130,6 +131,9 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -63,6 +63,7 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 								true,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -118,6 +119,7 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -213,6 +215,7 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -341,6 +344,7 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -480,6 +484,7 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -536,6 +541,7 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -686,6 +692,7 @@ func TestCommandSide_RemoveLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -829,6 +836,7 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -873,6 +881,7 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -937,6 +946,7 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1104,6 +1114,7 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1148,6 +1159,7 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1204,6 +1216,7 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1267,6 +1280,7 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1338,6 +1352,7 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
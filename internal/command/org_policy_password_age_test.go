@@ -63,6 +63,7 @@ func TestCommandSide_AddPasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								365,
 								10,
+								0,
 							),
 						),
 					),
@@ -93,6 +94,7 @@ func TestCommandSide_AddPasswordAgePolicy(t *testing.T) {
 									&org.NewAggregate("org1").Aggregate,
 									10,
 									365,
+									0,
 								),
 							),
 						},
@@ -175,6 +177,26 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "history count too high, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.PasswordAgePolicy{
+					MaxAgeDays:     365,
+					ExpireWarnDays: 10,
+					HistoryCount:   25,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "policy not existing, not found error",
 			fields: fields{
@@ -206,6 +228,7 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
@@ -234,13 +257,14 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newPasswordAgePolicyChangedEvent(context.Background(), "org1", 150, 5),
+								newPasswordAgePolicyChangedEvent(context.Background(), "org1", 150, 5, 5),
 							),
 						},
 					),
@@ -252,6 +276,7 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 				policy: &domain.PasswordAgePolicy{
 					MaxAgeDays:     150,
 					ExpireWarnDays: 5,
+					HistoryCount:   5,
 				},
 			},
 			res: res{
@@ -262,6 +287,7 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 					},
 					MaxAgeDays:     150,
 					ExpireWarnDays: 5,
+					HistoryCount:   5,
 				},
 			},
 		},
@@ -344,6 +370,7 @@ func TestCommandSide_RemovePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
@@ -387,12 +414,13 @@ func TestCommandSide_RemovePasswordAgePolicy(t *testing.T) {
 	}
 }
 
-func newPasswordAgePolicyChangedEvent(ctx context.Context, orgID string, maxAgeDays, expireWarnDays uint64) *org.PasswordAgePolicyChangedEvent {
+func newPasswordAgePolicyChangedEvent(ctx context.Context, orgID string, maxAgeDays, expireWarnDays, historyCount uint64) *org.PasswordAgePolicyChangedEvent {
 	event, _ := org.NewPasswordAgePolicyChangedEvent(ctx,
 		&org.NewAggregate(orgID).Aggregate,
 		[]policy.PasswordAgePolicyChanges{
 			policy.ChangeMaxAgeDays(maxAgeDays),
 			policy.ChangeExpireWarnDays(expireWarnDays),
+			policy.ChangeHistoryCount(historyCount),
 		},
 	)
 	return event
@@ -60,7 +60,8 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) NewChangedEvent(
 	hasLowercase,
 	hasUppercase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	rejectBreached bool,
 ) (*org.PasswordComplexityPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PasswordComplexityPolicyChanges, 0)
@@ -79,6 +80,9 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) NewChangedEvent(
 	if wm.HasSymbol != hasSymbol {
 		changes = append(changes, policy.ChangeHasSymbol(hasSymbol))
 	}
+	if wm.RejectBreached != rejectBreached {
+		changes = append(changes, policy.ChangeRejectBreached(rejectBreached))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func (c *Commands) AddRiskPolicy(ctx context.Context, resourceOwner string, policy *domain.RiskPolicy) (*domain.RiskPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rk8fJ", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rk9gK", "Errors.Org.RiskPolicy.Invalid")
+	}
+	addedPolicy, err := c.orgRiskPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "ORG-Rk0hL", "Errors.Org.RiskPolicy.AlreadyExists")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&addedPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewRiskPolicyAddedEvent(ctx, orgAgg, policy.MFAOnNewDevice, policy.MFAOnNewIPRange, policy.ImpossibleTravelWindow, policy.FailedAttemptsThreshold))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToRiskPolicy(&addedPolicy.RiskPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeRiskPolicy(ctx context.Context, resourceOwner string, policy *domain.RiskPolicy) (*domain.RiskPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rk1iM", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rk2jN", "Errors.Org.RiskPolicy.Invalid")
+	}
+	existingPolicy, err := c.orgRiskPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Rk3kO", "Errors.Org.RiskPolicy.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.RiskPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, orgAgg, policy)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Rk4lP", "Errors.Org.RiskPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToRiskPolicy(&existingPolicy.RiskPolicyWriteModel), nil
+}
+
+func (c *Commands) RemoveRiskPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rk5mQ", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := c.orgRiskPolicyWriteModelByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Rk6nR", "Errors.Org.RiskPolicy.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewRiskPolicyRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingPolicy.RiskPolicyWriteModel.WriteModel), nil
+}
+
+func (c *Commands) orgRiskPolicyWriteModelByID(ctx context.Context, orgID string) (*OrgRiskPolicyWriteModel, error) {
+	policy := NewOrgRiskPolicyWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
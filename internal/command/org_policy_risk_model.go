@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgRiskPolicyWriteModel struct {
+	RiskPolicyWriteModel
+}
+
+func NewOrgRiskPolicyWriteModel(orgID string) *OrgRiskPolicyWriteModel {
+	return &OrgRiskPolicyWriteModel{
+		RiskPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgRiskPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.RiskPolicyAddedEvent:
+			wm.RiskPolicyWriteModel.AppendEvents(&e.RiskPolicyAddedEvent)
+		case *org.RiskPolicyChangedEvent:
+			wm.RiskPolicyWriteModel.AppendEvents(&e.RiskPolicyChangedEvent)
+		case *org.RiskPolicyRemovedEvent:
+			wm.RiskPolicyWriteModel.AppendEvents(&e.RiskPolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgRiskPolicyWriteModel) Reduce() error {
+	return wm.RiskPolicyWriteModel.Reduce()
+}
+
+func (wm *OrgRiskPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.RiskPolicyWriteModel.AggregateID).
+		EventTypes(org.RiskPolicyAddedEventType,
+			org.RiskPolicyChangedEventType,
+			org.RiskPolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgRiskPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	riskPolicy *domain.RiskPolicy,
+) (*org.RiskPolicyChangedEvent, bool) {
+	changes := wm.changes(riskPolicy)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewRiskPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type CaptchaPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	ProviderType           domain.CaptchaProviderType
+	VerifyURL              string
+	SiteKey                string
+	Secret                 *crypto.CryptoValue
+	RequireOnRegistration  bool
+	RequireOnPasswordReset bool
+	FailedLoginsThreshold  uint64
+	State                  domain.PolicyState
+}
+
+func (wm *CaptchaPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.CaptchaPolicyAddedEvent:
+			wm.ProviderType = e.ProviderType
+			wm.VerifyURL = e.VerifyURL
+			wm.SiteKey = e.SiteKey
+			wm.Secret = e.Secret
+			wm.RequireOnRegistration = e.RequireOnRegistration
+			wm.RequireOnPasswordReset = e.RequireOnPasswordReset
+			wm.FailedLoginsThreshold = e.FailedLoginsThreshold
+			wm.State = domain.PolicyStateActive
+		case *policy.CaptchaPolicyChangedEvent:
+			if e.ProviderType != nil {
+				wm.ProviderType = *e.ProviderType
+			}
+			if e.VerifyURL != nil {
+				wm.VerifyURL = *e.VerifyURL
+			}
+			if e.SiteKey != nil {
+				wm.SiteKey = *e.SiteKey
+			}
+			if e.Secret != nil {
+				wm.Secret = e.Secret
+			}
+			if e.RequireOnRegistration != nil {
+				wm.RequireOnRegistration = *e.RequireOnRegistration
+			}
+			if e.RequireOnPasswordReset != nil {
+				wm.RequireOnPasswordReset = *e.RequireOnPasswordReset
+			}
+			if e.FailedLoginsThreshold != nil {
+				wm.FailedLoginsThreshold = *e.FailedLoginsThreshold
+			}
+		case *policy.CaptchaPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// changes returns the changes of the policy,
+// the secret is only changed (and therefore encrypted) if a new one is provided
+func (wm *CaptchaPolicyWriteModel) changes(captchaPolicy *domain.CaptchaPolicy, secretCrypto crypto.EncryptionAlgorithm) ([]policy.CaptchaPolicyChanges, error) {
+	changes := make([]policy.CaptchaPolicyChanges, 0)
+	if captchaPolicy.SecretString != "" {
+		secret, err := crypto.Encrypt([]byte(captchaPolicy.SecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, policy.ChangeCaptchaSecret(secret))
+	}
+	if wm.ProviderType != captchaPolicy.ProviderType {
+		changes = append(changes, policy.ChangeCaptchaProviderType(captchaPolicy.ProviderType))
+	}
+	if wm.VerifyURL != captchaPolicy.VerifyURL {
+		changes = append(changes, policy.ChangeCaptchaVerifyURL(captchaPolicy.VerifyURL))
+	}
+	if wm.SiteKey != captchaPolicy.SiteKey {
+		changes = append(changes, policy.ChangeCaptchaSiteKey(captchaPolicy.SiteKey))
+	}
+	if wm.RequireOnRegistration != captchaPolicy.RequireOnRegistration {
+		changes = append(changes, policy.ChangeCaptchaRequireOnRegistration(captchaPolicy.RequireOnRegistration))
+	}
+	if wm.RequireOnPasswordReset != captchaPolicy.RequireOnPasswordReset {
+		changes = append(changes, policy.ChangeCaptchaRequireOnPasswordReset(captchaPolicy.RequireOnPasswordReset))
+	}
+	if wm.FailedLoginsThreshold != captchaPolicy.FailedLoginsThreshold {
+		changes = append(changes, policy.ChangeCaptchaFailedLoginsThreshold(captchaPolicy.FailedLoginsThreshold))
+	}
+	return changes, nil
+}
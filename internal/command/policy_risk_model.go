@@ -0,0 +1,65 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type RiskPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	MFAOnNewDevice          bool
+	MFAOnNewIPRange         bool
+	ImpossibleTravelWindow  time.Duration
+	FailedAttemptsThreshold uint64
+	State                   domain.PolicyState
+}
+
+func (wm *RiskPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.RiskPolicyAddedEvent:
+			wm.MFAOnNewDevice = e.MFAOnNewDevice
+			wm.MFAOnNewIPRange = e.MFAOnNewIPRange
+			wm.ImpossibleTravelWindow = e.ImpossibleTravelWindow
+			wm.FailedAttemptsThreshold = e.FailedAttemptsThreshold
+			wm.State = domain.PolicyStateActive
+		case *policy.RiskPolicyChangedEvent:
+			if e.MFAOnNewDevice != nil {
+				wm.MFAOnNewDevice = *e.MFAOnNewDevice
+			}
+			if e.MFAOnNewIPRange != nil {
+				wm.MFAOnNewIPRange = *e.MFAOnNewIPRange
+			}
+			if e.ImpossibleTravelWindow != nil {
+				wm.ImpossibleTravelWindow = *e.ImpossibleTravelWindow
+			}
+			if e.FailedAttemptsThreshold != nil {
+				wm.FailedAttemptsThreshold = *e.FailedAttemptsThreshold
+			}
+		case *policy.RiskPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *RiskPolicyWriteModel) changes(riskPolicy *domain.RiskPolicy) []policy.RiskPolicyChanges {
+	changes := make([]policy.RiskPolicyChanges, 0)
+	if wm.MFAOnNewDevice != riskPolicy.MFAOnNewDevice {
+		changes = append(changes, policy.ChangeMFAOnNewDevice(riskPolicy.MFAOnNewDevice))
+	}
+	if wm.MFAOnNewIPRange != riskPolicy.MFAOnNewIPRange {
+		changes = append(changes, policy.ChangeMFAOnNewIPRange(riskPolicy.MFAOnNewIPRange))
+	}
+	if wm.ImpossibleTravelWindow != riskPolicy.ImpossibleTravelWindow {
+		changes = append(changes, policy.ChangeImpossibleTravelWindow(riskPolicy.ImpossibleTravelWindow))
+	}
+	if wm.FailedAttemptsThreshold != riskPolicy.FailedAttemptsThreshold {
+		changes = append(changes, policy.ChangeFailedAttemptsThreshold(riskPolicy.FailedAttemptsThreshold))
+	}
+	return changes
+}
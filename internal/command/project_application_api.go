@@ -18,6 +18,7 @@ import (
 type addAPIApp struct {
 	AddApp
 	AuthMethodType domain.APIAuthMethodType
+	domain.AppTokenSettings
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -32,6 +33,9 @@ func (c *Commands) AddAPIAppCommand(app *addAPIApp, clientSecretAlg crypto.HashA
 		if app.Name = strings.TrimSpace(app.Name); app.Name == "" {
 			return nil, errors.ThrowInvalidArgument(nil, "PROJE-F7g21", "Errors.Invalid.Argument")
 		}
+		if !app.AppTokenSettings.IsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "PROJE-Tk9sq", "Errors.Project.App.TokenSettingsInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			project, err := projectWriteModel(ctx, filter, app.Aggregate.ID, app.Aggregate.ResourceOwner)
 			if err != nil || !project.State.Valid() {
@@ -64,6 +68,7 @@ func (c *Commands) AddAPIAppCommand(app *addAPIApp, clientSecretAlg crypto.HashA
 					app.ClientID,
 					app.ClientSecret,
 					app.AuthMethodType,
+					app.AppTokenSettings,
 				),
 			}, nil
 		}, nil
@@ -131,7 +136,8 @@ func (c *Commands) addAPIApplicationWithID(ctx context.Context, apiApp *domain.A
 		apiApp.AppID,
 		apiApp.ClientID,
 		apiApp.ClientSecret,
-		apiApp.AuthMethodType))
+		apiApp.AuthMethodType,
+		apiApp.AppTokenSettings))
 
 	addedApplication.AppID = apiApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -148,7 +154,7 @@ func (c *Commands) addAPIApplicationWithID(ctx context.Context, apiApp *domain.A
 }
 
 func (c *Commands) ChangeAPIApplication(ctx context.Context, apiApp *domain.APIApp, resourceOwner string) (*domain.APIApp, error) {
-	if apiApp.AppID == "" || apiApp.AggregateID == "" {
+	if apiApp.AppID == "" || apiApp.AggregateID == "" || !apiApp.AppTokenSettings.IsValid() {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-1m900", "Errors.Project.App.APIConfigInvalid")
 	}
 
@@ -167,7 +173,8 @@ func (c *Commands) ChangeAPIApplication(ctx context.Context, apiApp *domain.APIA
 		ctx,
 		projectAgg,
 		apiApp.AppID,
-		apiApp.AuthMethodType)
+		apiApp.AuthMethodType,
+		apiApp.AppTokenSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -19,8 +19,9 @@ type APIApplicationWriteModel struct {
 	ClientSecret       *crypto.CryptoValue
 	ClientSecretString string
 	AuthMethodType     domain.APIAuthMethodType
-	State              domain.AppState
-	api                bool
+	domain.AppTokenSettings
+	State domain.AppState
+	api   bool
 }
 
 func NewAPIApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *APIApplicationWriteModel {
@@ -128,12 +129,32 @@ func (wm *APIApplicationWriteModel) appendAddAPIEvent(e *project.APIConfigAddedE
 	wm.ClientID = e.ClientID
 	wm.ClientSecret = e.ClientSecret
 	wm.AuthMethodType = e.AuthMethodType
+	wm.AccessTokenLifetime = e.AccessTokenLifetime
+	wm.IDTokenLifetime = e.IDTokenLifetime
+	wm.RefreshTokenIdleExpiration = e.RefreshTokenIdleExpiration
+	wm.RefreshTokenExpiration = e.RefreshTokenExpiration
+	wm.RefreshTokenRotation = e.RefreshTokenRotation
 }
 
 func (wm *APIApplicationWriteModel) appendChangeAPIEvent(e *project.APIConfigChangedEvent) {
 	if e.AuthMethodType != nil {
 		wm.AuthMethodType = *e.AuthMethodType
 	}
+	if e.AccessTokenLifetime != nil {
+		wm.AccessTokenLifetime = *e.AccessTokenLifetime
+	}
+	if e.IDTokenLifetime != nil {
+		wm.IDTokenLifetime = *e.IDTokenLifetime
+	}
+	if e.RefreshTokenIdleExpiration != nil {
+		wm.RefreshTokenIdleExpiration = *e.RefreshTokenIdleExpiration
+	}
+	if e.RefreshTokenExpiration != nil {
+		wm.RefreshTokenExpiration = *e.RefreshTokenExpiration
+	}
+	if e.RefreshTokenRotation != nil {
+		wm.RefreshTokenRotation = *e.RefreshTokenRotation
+	}
 }
 
 func (wm *APIApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -160,6 +181,7 @@ func (wm *APIApplicationWriteModel) NewChangedEvent(
 	aggregate *eventstore.Aggregate,
 	appID string,
 	authMethodType domain.APIAuthMethodType,
+	tokenSettings domain.AppTokenSettings,
 ) (*project.APIConfigChangedEvent, bool, error) {
 	changes := make([]project.APIConfigChanges, 0)
 	var err error
@@ -167,6 +189,21 @@ func (wm *APIApplicationWriteModel) NewChangedEvent(
 	if wm.AuthMethodType != authMethodType {
 		changes = append(changes, project.ChangeAPIAuthMethodType(authMethodType))
 	}
+	if wm.AccessTokenLifetime != tokenSettings.AccessTokenLifetime {
+		changes = append(changes, project.ChangeAPIAccessTokenLifetime(tokenSettings.AccessTokenLifetime))
+	}
+	if wm.IDTokenLifetime != tokenSettings.IDTokenLifetime {
+		changes = append(changes, project.ChangeAPIIDTokenLifetime(tokenSettings.IDTokenLifetime))
+	}
+	if wm.RefreshTokenIdleExpiration != tokenSettings.RefreshTokenIdleExpiration {
+		changes = append(changes, project.ChangeAPIRefreshTokenIdleExpiration(tokenSettings.RefreshTokenIdleExpiration))
+	}
+	if wm.RefreshTokenExpiration != tokenSettings.RefreshTokenExpiration {
+		changes = append(changes, project.ChangeAPIRefreshTokenExpiration(tokenSettings.RefreshTokenExpiration))
+	}
+	if wm.RefreshTokenRotation != tokenSettings.RefreshTokenRotation {
+		changes = append(changes, project.ChangeAPIRefreshTokenRotation(tokenSettings.RefreshTokenRotation))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -117,6 +118,7 @@ func TestAddAPIConfig(t *testing.T) {
 						"clientID@project",
 						nil,
 						domain.APIAuthMethodTypePrivateKeyJWT,
+						domain.AppTokenSettings{},
 					),
 				},
 			},
@@ -267,7 +269,8 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 										KeyID:      "id",
 										Crypted:    []byte("a"),
 									},
-									domain.APIAuthMethodTypeBasic),
+									domain.APIAuthMethodTypeBasic,
+									domain.AppTokenSettings{}),
 							),
 						},
 						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
@@ -330,7 +333,8 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 									"app1",
 									"client1@project",
 									nil,
-									domain.APIAuthMethodTypePrivateKeyJWT),
+									domain.APIAuthMethodTypePrivateKeyJWT,
+									domain.AppTokenSettings{}),
 							),
 						},
 						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
@@ -426,6 +430,32 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "invalid token settings, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "app1",
+					AppName:        "app",
+					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+					AppTokenSettings: domain.AppTokenSettings{
+						RefreshTokenRotation: domain.RefreshTokenRotation(99),
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "missing aggregateid, invalid argument error",
 			fields: fields{
@@ -491,7 +521,8 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 								"app1",
 								"client1@project",
 								nil,
-								domain.APIAuthMethodTypePrivateKeyJWT),
+								domain.APIAuthMethodTypePrivateKeyJWT,
+								domain.AppTokenSettings{}),
 						),
 					),
 				),
@@ -536,7 +567,8 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("a"),
 								},
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								domain.AppTokenSettings{}),
 						),
 					),
 					expectPush(
@@ -578,6 +610,82 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change api app token settings, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewAPIConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"client1@project",
+								nil,
+								domain.APIAuthMethodTypePrivateKeyJWT,
+								domain.AppTokenSettings{}),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *project.APIConfigChangedEvent {
+									event, _ := project.NewAPIConfigChangedEvent(context.Background(),
+										&project.NewAggregate("project1", "org1").Aggregate,
+										"app1",
+										[]project.APIConfigChanges{
+											project.ChangeAPIAccessTokenLifetime(time.Minute * 5),
+											project.ChangeAPIRefreshTokenRotation(domain.RefreshTokenRotationReuseDetection),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "app1",
+					AppName:        "app",
+					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+					AppTokenSettings: domain.AppTokenSettings{
+						AccessTokenLifetime:  time.Minute * 5,
+						RefreshTokenRotation: domain.RefreshTokenRotationReuseDetection,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:          "app1",
+					AppName:        "app",
+					ClientID:       "client1@project",
+					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+					AppTokenSettings: domain.AppTokenSettings{
+						AccessTokenLifetime:  time.Minute * 5,
+						RefreshTokenRotation: domain.RefreshTokenRotationReuseDetection,
+					},
+					State: domain.AppStateActive,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -694,7 +802,8 @@ func TestCommandSide_ChangeAPIApplicationSecret(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("a"),
 								},
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								domain.AppTokenSettings{}),
 						),
 					),
 					expectPush(
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// SetApplicationClaimMappings replaces the declarative claim mappings of an OIDC application
+// SAML assertions don't support claim mappings, so they are rejected for SAML and API applications
+func (c *Commands) SetApplicationClaimMappings(ctx context.Context, projectID, appID string, mappings []*domain.ClaimMapping, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || appID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cm3lA", "Errors.IDMissing")
+	}
+	if !domain.ClaimMappingsAreValid(mappings) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Cm9sx", "Errors.Project.App.ClaimMappingInvalid")
+	}
+
+	existingApp, err := c.getApplicationClaimMappingsWriteModel(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingApp.State == domain.AppStateUnspecified || existingApp.State == domain.AppStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Cm0pq", "Errors.Project.App.NotExisting")
+	}
+	if !existingApp.IsOIDC() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Cm2kd", "Errors.Project.App.ClaimMappingNotSupported")
+	}
+	if !existingApp.hasChanged(mappings) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Cm8vB", "Errors.NoChangesFound")
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&existingApp.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewApplicationClaimMappingsSetEvent(ctx, projectAgg, appID, claimMappingsToEvent(mappings)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingApp, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingApp.WriteModel), nil
+}
+
+func (c *Commands) getApplicationClaimMappingsWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*ApplicationClaimMappingsWriteModel, error) {
+	writeModel := NewApplicationClaimMappingsWriteModel(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ApplicationClaimMappingsWriteModel struct {
+	eventstore.WriteModel
+
+	AppID         string
+	State         domain.AppState
+	isOIDC        bool
+	ClaimMappings []*domain.ClaimMapping
+}
+
+func NewApplicationClaimMappingsWriteModel(projectID, appID, resourceOwner string) *ApplicationClaimMappingsWriteModel {
+	return &ApplicationClaimMappingsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationClaimMappingsSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.State = domain.AppStateActive
+		case *project.OIDCConfigAddedEvent:
+			wm.isOIDC = true
+		case *project.ApplicationClaimMappingsSetEvent:
+			wm.ClaimMappings = claimMappingsToDomain(e.ClaimMappings)
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.ClaimMappings = nil
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+			wm.ClaimMappings = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.OIDCConfigAddedType,
+			project.ApplicationClaimMappingsSetType,
+			project.ApplicationRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) IsOIDC() bool {
+	return wm.isOIDC
+}
+
+func (wm *ApplicationClaimMappingsWriteModel) hasChanged(mappings []*domain.ClaimMapping) bool {
+	if len(wm.ClaimMappings) != len(mappings) {
+		return true
+	}
+	for i, mapping := range mappings {
+		if *wm.ClaimMappings[i] != *mapping {
+			return true
+		}
+	}
+	return false
+}
+
+func claimMappingsToDomain(mappings []*project.ClaimMapping) []*domain.ClaimMapping {
+	result := make([]*domain.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &domain.ClaimMapping{
+			Source:      mapping.Source,
+			SourceKey:   mapping.SourceKey,
+			Claim:       mapping.Claim,
+			Type:        mapping.Type,
+			IDToken:     mapping.IDToken,
+			AccessToken: mapping.AccessToken,
+			Userinfo:    mapping.Userinfo,
+		}
+	}
+	return result
+}
+
+func claimMappingsToEvent(mappings []*domain.ClaimMapping) []*project.ClaimMapping {
+	result := make([]*project.ClaimMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &project.ClaimMapping{
+			Source:      mapping.Source,
+			SourceKey:   mapping.SourceKey,
+			Claim:       mapping.Claim,
+			Type:        mapping.Type,
+			IDToken:     mapping.IDToken,
+			AccessToken: mapping.AccessToken,
+			Userinfo:    mapping.Userinfo,
+		}
+	}
+	return result
+}
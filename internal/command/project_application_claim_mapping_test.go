@@ -0,0 +1,332 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_SetApplicationClaimMappings(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		appID         string
+		mappings      []*domain.ClaimMapping
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing app id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "reserved claim, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:    domain.ClaimMappingSourceProfile,
+						SourceKey: domain.ClaimMappingProfileEmail,
+						Claim:     "sub",
+						IDToken:   true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "duplicate claim, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:    domain.ClaimMappingSourceMetadata,
+						SourceKey: "department",
+						Claim:     "department",
+						IDToken:   true,
+					},
+					{
+						Source:    domain.ClaimMappingSourceOrg,
+						SourceKey: domain.ClaimMappingOrgName,
+						Claim:     "department",
+						Userinfo:  true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "app not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:   domain.ClaimMappingSourceRoles,
+						Claim:    "roles",
+						Userinfo: true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "saml app, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\" entityID=\"https://test.com/saml/metadata\"></md:EntityDescriptor>"),
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:   domain.ClaimMappingSourceRoles,
+						Claim:    "roles",
+						Userinfo: true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							newClaimMappingOIDCConfigAddedEvent(),
+						),
+						eventFromEventPusher(
+							project.NewApplicationClaimMappingsSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]*project.ClaimMapping{
+									{
+										Source:   domain.ClaimMappingSourceRoles,
+										Claim:    "roles",
+										Userinfo: true,
+									},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:   domain.ClaimMappingSourceRoles,
+						Claim:    "roles",
+						Userinfo: true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set claim mappings, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							newClaimMappingOIDCConfigAddedEvent(),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationClaimMappingsSetEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									[]*project.ClaimMapping{
+										{
+											Source:      domain.ClaimMappingSourceMetadata,
+											SourceKey:   "department",
+											Claim:       "department",
+											Type:        domain.ClaimMappingTypeString,
+											IDToken:     true,
+											AccessToken: true,
+										},
+									},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				mappings: []*domain.ClaimMapping{
+					{
+						Source:      domain.ClaimMappingSourceMetadata,
+						SourceKey:   "department",
+						Claim:       "department",
+						Type:        domain.ClaimMappingTypeString,
+						IDToken:     true,
+						AccessToken: true,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetApplicationClaimMappings(tt.args.ctx, tt.args.projectID, tt.args.appID, tt.args.mappings, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newClaimMappingOIDCConfigAddedEvent() *project.OIDCConfigAddedEvent {
+	return project.NewOIDCConfigAddedEvent(context.Background(),
+		&project.NewAggregate("project1", "org1").Aggregate,
+		domain.OIDCVersionV1,
+		"app1",
+		"client1@project",
+		nil,
+		[]string{"https://test.ch"},
+		[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+		[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+		domain.OIDCApplicationTypeWeb,
+		domain.OIDCAuthMethodTypeNone,
+		nil,
+		false,
+		domain.OIDCTokenTypeBearer,
+		false,
+		false,
+		false,
+		0,
+		nil,
+		false,
+		"",
+		"",
+		"",
+		domain.AppTokenSettings{},
+	)
+}
@@ -122,7 +122,8 @@ func TestCommandSide_AddAPIApplicationKey(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("a"),
 								},
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								domain.AppTokenSettings{}),
 						),
 					),
 				),
@@ -168,7 +169,8 @@ func TestCommandSide_AddAPIApplicationKey(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("a"),
 								},
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								domain.AppTokenSettings{}),
 						),
 					),
 				),
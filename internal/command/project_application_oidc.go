@@ -20,20 +20,25 @@ import (
 
 type addOIDCApp struct {
 	AddApp
-	Version                  domain.OIDCVersion
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        []string
+	Version                            domain.OIDCVersion
+	RedirectUris                       []string
+	ResponseTypes                      []domain.OIDCResponseType
+	GrantTypes                         []domain.OIDCGrantType
+	ApplicationType                    domain.OIDCApplicationType
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectUris             []string
+	DevMode                            bool
+	AccessTokenType                    domain.OIDCTokenType
+	AccessTokenRoleAssertion           bool
+	IDTokenRoleAssertion               bool
+	IDTokenUserinfoAssertion           bool
+	ClockSkew                          time.Duration
+	AdditionalOrigins                  []string
+	RequirePushedAuthorizationRequests bool
+	JWKS                               string
+	BackChannelLogoutURI               string
+	FrontChannelLogoutURI              string
+	domain.AppTokenSettings
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -61,6 +66,18 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			}
 		}
 
+		if !domain.JWKSValid(app.JWKS) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Xk2lq", "Errors.Project.App.JWKSInvalid")
+		}
+
+		if !domain.LogoutURIValid(app.BackChannelLogoutURI) || !domain.LogoutURIValid(app.FrontChannelLogoutURI) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Pw4lv", "Errors.Project.App.LogoutURIInvalid")
+		}
+
+		if !app.AppTokenSettings.IsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Tk8rw", "Errors.Project.App.TokenSettingsInvalid")
+		}
+
 		if !domain.ContainsRequiredGrantTypes(app.ResponseTypes, app.GrantTypes) {
 			return nil, errors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
@@ -110,6 +127,11 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.IDTokenUserinfoAssertion,
 					app.ClockSkew,
 					app.AdditionalOrigins,
+					app.RequirePushedAuthorizationRequests,
+					app.JWKS,
+					app.BackChannelLogoutURI,
+					app.FrontChannelLogoutURI,
+					app.AppTokenSettings,
 				),
 			}, nil
 		}, nil
@@ -192,7 +214,12 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.IDTokenRoleAssertion,
 		oidcApp.IDTokenUserinfoAssertion,
 		oidcApp.ClockSkew,
-		oidcApp.AdditionalOrigins))
+		oidcApp.AdditionalOrigins,
+		oidcApp.RequirePushedAuthorizationRequests,
+		oidcApp.JWKS,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.FrontChannelLogoutURI,
+		oidcApp.AppTokenSettings))
 
 	addedApplication.AppID = oidcApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -242,7 +269,12 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.IDTokenRoleAssertion,
 		oidc.IDTokenUserinfoAssertion,
 		oidc.ClockSkew,
-		oidc.AdditionalOrigins)
+		oidc.AdditionalOrigins,
+		oidc.RequirePushedAuthorizationRequests,
+		oidc.JWKS,
+		oidc.BackChannelLogoutURI,
+		oidc.FrontChannelLogoutURI,
+		oidc.AppTokenSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -14,28 +14,33 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	oidc                     bool
+	AppID                              string
+	AppName                            string
+	ClientID                           string
+	ClientSecret                       *crypto.CryptoValue
+	ClientSecretString                 string
+	RedirectUris                       []string
+	ResponseTypes                      []domain.OIDCResponseType
+	GrantTypes                         []domain.OIDCGrantType
+	ApplicationType                    domain.OIDCApplicationType
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectUris             []string
+	OIDCVersion                        domain.OIDCVersion
+	Compliance                         *domain.Compliance
+	DevMode                            bool
+	AccessTokenType                    domain.OIDCTokenType
+	AccessTokenRoleAssertion           bool
+	IDTokenRoleAssertion               bool
+	IDTokenUserinfoAssertion           bool
+	ClockSkew                          time.Duration
+	State                              domain.AppState
+	AdditionalOrigins                  []string
+	RequirePushedAuthorizationRequests bool
+	JWKS                               string
+	BackChannelLogoutURI               string
+	FrontChannelLogoutURI              string
+	domain.AppTokenSettings
+	oidc bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -156,6 +161,15 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.IDTokenUserinfoAssertion = e.IDTokenUserinfoAssertion
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
+	wm.RequirePushedAuthorizationRequests = e.RequirePushedAuthorizationRequests
+	wm.JWKS = e.JWKS
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.FrontChannelLogoutURI = e.FrontChannelLogoutURI
+	wm.AccessTokenLifetime = e.AccessTokenLifetime
+	wm.IDTokenLifetime = e.IDTokenLifetime
+	wm.RefreshTokenIdleExpiration = e.RefreshTokenIdleExpiration
+	wm.RefreshTokenExpiration = e.RefreshTokenExpiration
+	wm.RefreshTokenRotation = e.RefreshTokenRotation
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -201,6 +215,33 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.AdditionalOrigins != nil {
 		wm.AdditionalOrigins = *e.AdditionalOrigins
 	}
+	if e.RequirePushedAuthorizationRequests != nil {
+		wm.RequirePushedAuthorizationRequests = *e.RequirePushedAuthorizationRequests
+	}
+	if e.JWKS != nil {
+		wm.JWKS = *e.JWKS
+	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		wm.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
+	if e.AccessTokenLifetime != nil {
+		wm.AccessTokenLifetime = *e.AccessTokenLifetime
+	}
+	if e.IDTokenLifetime != nil {
+		wm.IDTokenLifetime = *e.IDTokenLifetime
+	}
+	if e.RefreshTokenIdleExpiration != nil {
+		wm.RefreshTokenIdleExpiration = *e.RefreshTokenIdleExpiration
+	}
+	if e.RefreshTokenExpiration != nil {
+		wm.RefreshTokenExpiration = *e.RefreshTokenExpiration
+	}
+	if e.RefreshTokenRotation != nil {
+		wm.RefreshTokenRotation = *e.RefreshTokenRotation
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -240,6 +281,11 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
+	requirePushedAuthorizationRequests bool,
+	jwks,
+	backChannelLogoutURI,
+	frontChannelLogoutURI string,
+	tokenSettings domain.AppTokenSettings,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -286,6 +332,33 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if !reflect.DeepEqual(wm.AdditionalOrigins, additionalOrigins) {
 		changes = append(changes, project.ChangeAdditionalOrigins(additionalOrigins))
 	}
+	if wm.RequirePushedAuthorizationRequests != requirePushedAuthorizationRequests {
+		changes = append(changes, project.ChangeRequirePushedAuthorizationRequests(requirePushedAuthorizationRequests))
+	}
+	if wm.JWKS != jwks {
+		changes = append(changes, project.ChangeJWKS(jwks))
+	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.FrontChannelLogoutURI != frontChannelLogoutURI {
+		changes = append(changes, project.ChangeFrontChannelLogoutURI(frontChannelLogoutURI))
+	}
+	if wm.AccessTokenLifetime != tokenSettings.AccessTokenLifetime {
+		changes = append(changes, project.ChangeAccessTokenLifetime(tokenSettings.AccessTokenLifetime))
+	}
+	if wm.IDTokenLifetime != tokenSettings.IDTokenLifetime {
+		changes = append(changes, project.ChangeIDTokenLifetime(tokenSettings.IDTokenLifetime))
+	}
+	if wm.RefreshTokenIdleExpiration != tokenSettings.RefreshTokenIdleExpiration {
+		changes = append(changes, project.ChangeRefreshTokenIdleExpiration(tokenSettings.RefreshTokenIdleExpiration))
+	}
+	if wm.RefreshTokenExpiration != tokenSettings.RefreshTokenExpiration {
+		changes = append(changes, project.ChangeRefreshTokenExpiration(tokenSettings.RefreshTokenExpiration))
+	}
+	if wm.RefreshTokenRotation != tokenSettings.RefreshTokenRotation {
+		changes = append(changes, project.ChangeRefreshTokenRotation(tokenSettings.RefreshTokenRotation))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -169,6 +169,11 @@ func TestAddOIDCApp(t *testing.T) {
 						false,
 						0,
 						nil,
+						false,
+						"",
+						"",
+						"",
+						domain.AppTokenSettings{},
 					),
 				},
 			},
@@ -325,7 +330,12 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									true,
 									true,
 									time.Second*1,
-									[]string{"https://sub.test.ch"}),
+									[]string{"https://sub.test.ch"},
+									false,
+									"",
+									"",
+									"",
+									domain.AppTokenSettings{}),
 							),
 						},
 						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
@@ -496,6 +506,83 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "invalid jwks, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "appid",
+					AuthMethodType: domain.OIDCAuthMethodTypePost,
+					GrantTypes:     []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:  []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					JWKS:           `{"keys":[]}`,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid logout uri, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                "appid",
+					AuthMethodType:       domain.OIDCAuthMethodTypePost,
+					GrantTypes:           []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:        []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					BackChannelLogoutURI: "/logout",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid token settings, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "appid",
+					AuthMethodType: domain.OIDCAuthMethodTypePost,
+					GrantTypes:     []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:  []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					AppTokenSettings: domain.AppTokenSettings{
+						AccessTokenLifetime: -time.Hour,
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "app not existing, not found error",
 			fields: fields{
@@ -558,7 +645,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								"",
+								"",
+								"",
+								domain.AppTokenSettings{}),
 						),
 					),
 				),
@@ -629,7 +721,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								"",
+								"",
+								"",
+								domain.AppTokenSettings{}),
 						),
 					),
 					expectPush(
@@ -650,22 +747,26 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ObjectRoot: models.ObjectRoot{
 						AggregateID: "project1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{"https://test-change.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{"https://test-change.ch/logout"},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
+					AppID:                              "app1",
+					AppName:                            "app",
+					AuthMethodType:                     domain.OIDCAuthMethodTypePost,
+					OIDCVersion:                        domain.OIDCVersionV1,
+					RedirectUris:                       []string{"https://test-change.ch"},
+					ResponseTypes:                      []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                         []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:                    domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:             []string{"https://test-change.ch/logout"},
+					DevMode:                            true,
+					AccessTokenType:                    domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:           false,
+					IDTokenRoleAssertion:               false,
+					IDTokenUserinfoAssertion:           false,
+					ClockSkew:                          time.Second * 2,
+					AdditionalOrigins:                  []string{"https://sub.test.ch"},
+					RequirePushedAuthorizationRequests: true,
+					JWKS:                               testJWKS,
+					BackChannelLogoutURI:               "https://test-change.ch/backchannel-logout",
+					FrontChannelLogoutURI:              "https://test-change.ch/frontchannel-logout",
 				},
 				resourceOwner: "org1",
 			},
@@ -675,25 +776,29 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					ClientID:                 "client1@project",
-					AppName:                  "app",
-					AuthMethodType:           domain.OIDCAuthMethodTypePost,
-					OIDCVersion:              domain.OIDCVersionV1,
-					RedirectUris:             []string{"https://test-change.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          domain.OIDCApplicationTypeWeb,
-					PostLogoutRedirectUris:   []string{"https://test-change.ch/logout"},
-					DevMode:                  true,
-					AccessTokenType:          domain.OIDCTokenTypeJWT,
-					AccessTokenRoleAssertion: false,
-					IDTokenRoleAssertion:     false,
-					IDTokenUserinfoAssertion: false,
-					ClockSkew:                time.Second * 2,
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					Compliance:               &domain.Compliance{},
-					State:                    domain.AppStateActive,
+					AppID:                              "app1",
+					ClientID:                           "client1@project",
+					AppName:                            "app",
+					AuthMethodType:                     domain.OIDCAuthMethodTypePost,
+					OIDCVersion:                        domain.OIDCVersionV1,
+					RedirectUris:                       []string{"https://test-change.ch"},
+					ResponseTypes:                      []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                         []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:                    domain.OIDCApplicationTypeWeb,
+					PostLogoutRedirectUris:             []string{"https://test-change.ch/logout"},
+					DevMode:                            true,
+					AccessTokenType:                    domain.OIDCTokenTypeJWT,
+					AccessTokenRoleAssertion:           false,
+					IDTokenRoleAssertion:               false,
+					IDTokenUserinfoAssertion:           false,
+					ClockSkew:                          time.Second * 2,
+					AdditionalOrigins:                  []string{"https://sub.test.ch"},
+					RequirePushedAuthorizationRequests: true,
+					JWKS:                               testJWKS,
+					BackChannelLogoutURI:               "https://test-change.ch/backchannel-logout",
+					FrontChannelLogoutURI:              "https://test-change.ch/frontchannel-logout",
+					Compliance:                         &domain.Compliance{},
+					State:                              domain.AppStateActive,
 				},
 			},
 		},
@@ -826,7 +931,12 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								"",
+								"",
+								"",
+								domain.AppTokenSettings{}),
 						),
 					),
 					expectPush(
@@ -901,6 +1011,8 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 	}
 }
 
+const testJWKS = `{"keys":[{"use":"sig","kty":"EC","kid":"key1","crv":"P-256","alg":"ES256","x":"9LUGCEu8s7rO4fd-VcOwSi2r68_BR_5ga8GA5li7jWw","y":"GSbpFsebQUE9tFD_5iV76wMlPuXLSCJ0Hb97Y323MPs"}]}`
+
 func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner string) *project.OIDCConfigChangedEvent {
 	changes := []project.OIDCConfigChanges{
 		project.ChangeRedirectURIs([]string{"https://test-change.ch"}),
@@ -911,6 +1023,10 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeRequirePushedAuthorizationRequests(true),
+		project.ChangeJWKS(testJWKS),
+		project.ChangeBackChannelLogoutURI("https://test-change.ch/backchannel-logout"),
+		project.ChangeFrontChannelLogoutURI("https://test-change.ch/frontchannel-logout"),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -18,8 +19,12 @@ func (c *Commands) AddProjectMember(ctx context.Context, member *domain.Member,
 	if err != nil {
 		return nil, err
 	}
+	events := []eventstore.Command{event}
+	if !member.Validity.IsZero() {
+		events = append(events, project.NewProjectMemberValiditySetEvent(ctx, projectAgg, member.UserID, member.ValidFrom, member.ValidUntil))
+	}
 
-	pushedEvents, err := c.eventstore.Push(ctx, event)
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +37,7 @@ func (c *Commands) AddProjectMember(ctx context.Context, member *domain.Member,
 }
 
 func (c *Commands) addProjectMember(ctx context.Context, projectAgg *eventstore.Aggregate, addedMember *ProjectMemberWriteModel, member *domain.Member) (eventstore.Command, error) {
-	if !member.IsValid() {
+	if !member.IsValid() || member.Validity.IsExpired(time.Now()) {
 		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-W8m4l", "Errors.Project.Member.Invalid")
 	}
 	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectRolePrefix, c.zitadelRoles)) > 0 {
@@ -56,7 +61,7 @@ func (c *Commands) addProjectMember(ctx context.Context, projectAgg *eventstore.
 
 // ChangeProjectMember updates an existing member
 func (c *Commands) ChangeProjectMember(ctx context.Context, member *domain.Member, resourceOwner string) (*domain.Member, error) {
-	if !member.IsValid() {
+	if !member.IsValid() || member.Validity.IsExpired(time.Now()) {
 		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-LiaZi", "Errors.Project.Member.Invalid")
 	}
 	if len(domain.CheckForInvalidRoles(member.Roles, domain.ProjectRolePrefix, c.zitadelRoles)) > 0 {
@@ -68,11 +73,20 @@ func (c *Commands) ChangeProjectMember(ctx context.Context, member *domain.Membe
 		return nil, err
 	}
 
-	if reflect.DeepEqual(existingMember.Roles, member.Roles) {
+	rolesChanged := !reflect.DeepEqual(existingMember.Roles, member.Roles)
+	validityChanged := !existingMember.Validity.Equal(member.Validity)
+	if !rolesChanged && !validityChanged {
 		return nil, errors.ThrowPreconditionFailed(nil, "PROJECT-LiaZi", "Errors.Project.Member.RolesNotChanged")
 	}
 	projectAgg := ProjectAggregateFromWriteModel(&existingMember.MemberWriteModel.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, project.NewProjectMemberChangedEvent(ctx, projectAgg, member.UserID, member.Roles...))
+	events := make([]eventstore.Command, 0, 2)
+	if rolesChanged {
+		events = append(events, project.NewProjectMemberChangedEvent(ctx, projectAgg, member.UserID, member.Roles...))
+	}
+	if validityChanged {
+		events = append(events, project.NewProjectMemberValiditySetEvent(ctx, projectAgg, member.UserID, member.ValidFrom, member.ValidUntil))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +124,31 @@ func (c *Commands) RemoveProjectMember(ctx context.Context, projectID, userID, r
 	return writeModelToObjectDetails(&m.WriteModel), nil
 }
 
+// RemoveExpiredProjectMember removes the member if its validity has expired
+func (c *Commands) RemoveExpiredProjectMember(ctx context.Context, projectID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-Qe9xv", "Errors.Project.Member.Invalid")
+	}
+	m, err := c.projectMemberWriteModelByID(ctx, projectID, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Validity.IsExpired(time.Now()) {
+		return writeModelToObjectDetails(&m.WriteModel), nil
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&m.MemberWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, c.removeProjectMember(ctx, projectAgg, userID, false))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(m, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&m.WriteModel), nil
+}
+
 func (c *Commands) removeProjectMember(ctx context.Context, projectAgg *eventstore.Aggregate, userID string, cascade bool) eventstore.Command {
 	if cascade {
 		return project.NewProjectMemberCascadeRemovedEvent(
@@ -34,6 +34,11 @@ func (wm *ProjectMemberWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.MemberWriteModel.AppendEvents(&e.MemberChangedEvent)
+		case *project.MemberValiditySetEvent:
+			if e.UserID != wm.MemberWriteModel.UserID {
+				continue
+			}
+			wm.MemberWriteModel.AppendEvents(&e.MemberValiditySetEvent)
 		case *project.MemberRemovedEvent:
 			if e.UserID != wm.MemberWriteModel.UserID {
 				continue
@@ -60,6 +65,7 @@ func (wm *ProjectMemberWriteModel) Query() *eventstore.SearchQueryBuilder {
 		AggregateIDs(wm.MemberWriteModel.AggregateID).
 		EventTypes(project.MemberAddedType,
 			project.MemberChangedType,
+			project.MemberValiditySetType,
 			project.MemberRemovedType,
 			project.MemberCascadeRemovedType).
 		Builder()
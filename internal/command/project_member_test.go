@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -475,6 +476,65 @@ func TestCommandSide_ChangeProjectMember(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "member validity change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectMemberAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"user1",
+								[]string{"PROJECT_OWNER"}...,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(project.NewProjectMemberValiditySetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"user1",
+								time.Time{},
+								time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC),
+							)),
+						},
+					),
+				),
+				zitadelRoles: []authz.RoleMapping{
+					{
+						Role: domain.RoleProjectOwner,
+					},
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				member: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					UserID: "user1",
+					Roles:  []string{"PROJECT_OWNER"},
+					Validity: domain.Validity{
+						ValidUntil: time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						ResourceOwner: "org1",
+						AggregateID:   "project1",
+					},
+					UserID: "user1",
+					Roles:  []string{domain.RoleProjectOwner},
+					Validity: domain.Validity{
+						ValidUntil: time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddRelationTuples adds the relation tuples to the project
+// all tuples are added or none if one is invalid or already exists
+func (c *Commands) AddRelationTuples(ctx context.Context, projectID, resourceOwner string, tuples ...*domain.RelationTuple) (*domain.ObjectDetails, error) {
+	if projectID == "" || len(tuples) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gu3ka", "Errors.Project.RelationTuple.Invalid")
+	}
+	for _, tuple := range tuples {
+		if !tuple.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb7ds", "Errors.Project.RelationTuple.Invalid")
+		}
+	}
+	err := c.checkProjectExists(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.relationTuplesWriteModel(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&writeModel.WriteModel)
+	events := make([]eventstore.Command, 0, len(tuples))
+	added := make(map[string]bool, len(tuples))
+	for _, tuple := range tuples {
+		key := tuple.String()
+		if writeModel.Tuples[key] || added[key] {
+			return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Pe2lq", "Errors.Project.RelationTuple.AlreadyExists")
+		}
+		added[key] = true
+		events = append(events, project.NewRelationTupleAddedEvent(ctx, projectAgg, relationTupleToEvent(tuple)))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveRelationTuples removes the relation tuples of the project
+// all tuples are removed or none if one doesn't exist
+func (c *Commands) RemoveRelationTuples(ctx context.Context, projectID, resourceOwner string, tuples ...*domain.RelationTuple) (*domain.ObjectDetails, error) {
+	if projectID == "" || len(tuples) == 0 {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xs0ne", "Errors.Project.RelationTuple.Invalid")
+	}
+	writeModel, err := c.relationTuplesWriteModel(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&writeModel.WriteModel)
+	events := make([]eventstore.Command, 0, len(tuples))
+	removed := make(map[string]bool, len(tuples))
+	for _, tuple := range tuples {
+		key := tuple.String()
+		if !writeModel.Tuples[key] || removed[key] {
+			return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Jn4ws", "Errors.Project.RelationTuple.NotFound")
+		}
+		removed[key] = true
+		events = append(events, project.NewRelationTupleRemovedEvent(ctx, projectAgg, relationTupleToEvent(tuple)))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) relationTuplesWriteModel(ctx context.Context, projectID, resourceOwner string) (_ *ProjectRelationTuplesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewProjectRelationTuplesWriteModel(projectID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func relationTupleToEvent(tuple *domain.RelationTuple) project.RelationTuple {
+	return project.RelationTuple{
+		ObjectType:      tuple.ObjectType,
+		ObjectID:        tuple.ObjectID,
+		Relation:        tuple.Relation,
+		SubjectType:     tuple.Subject.Type,
+		SubjectID:       tuple.Subject.ID,
+		SubjectRelation: tuple.Subject.Relation,
+	}
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ProjectRelationTuplesWriteModel struct {
+	eventstore.WriteModel
+
+	Tuples map[string]bool
+}
+
+func NewProjectRelationTuplesWriteModel(projectID, resourceOwner string) *ProjectRelationTuplesWriteModel {
+	return &ProjectRelationTuplesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		Tuples: make(map[string]bool),
+	}
+}
+
+func (wm *ProjectRelationTuplesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.RelationTupleAddedEvent:
+			wm.Tuples[e.String()] = true
+		case *project.RelationTupleRemovedEvent:
+			delete(wm.Tuples, e.String())
+		case *project.ProjectRemovedEvent:
+			wm.Tuples = make(map[string]bool)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ProjectRelationTuplesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.RelationTupleAddedType,
+			project.RelationTupleRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
@@ -0,0 +1,340 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+var (
+	testViewerTuple = &domain.RelationTuple{
+		ObjectType: "document",
+		ObjectID:   "readme",
+		Relation:   "viewer",
+		Subject: domain.RelationSubject{
+			Type: domain.RelationNamespaceUser,
+			ID:   "user1",
+		},
+	}
+	testEditorTuple = &domain.RelationTuple{
+		ObjectType: "document",
+		ObjectID:   "readme",
+		Relation:   "editor",
+		Subject: domain.RelationSubject{
+			Type:     domain.RelationNamespaceProject,
+			ID:       "project1",
+			Relation: "writer",
+		},
+	}
+)
+
+func TestCommandSide_AddRelationTuples(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		resourceOwner string
+		tuples        []*domain.RelationTuple
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no tuples, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "built-in object namespace, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples: []*domain.RelationTuple{
+					{
+						ObjectType: domain.RelationNamespaceOrg,
+						ObjectID:   "org1",
+						Relation:   domain.RelationMember,
+						Subject:    domain.RelationSubject{Type: domain.RelationNamespaceUser, ID: "user1"},
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testViewerTuple},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "tuple already exists, already exists error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRelationTupleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								relationTupleToEvent(testViewerTuple),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testEditorTuple, testViewerTuple},
+			},
+			res: res{
+				err: caos_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add tuples, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewRelationTupleAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									relationTupleToEvent(testViewerTuple),
+								),
+							),
+							eventFromEventPusher(
+								project.NewRelationTupleAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									relationTupleToEvent(testEditorTuple),
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddRelationTupleUniqueConstraint("document:readme#viewer@user:user1", "project1")),
+						uniqueConstraintsFromEventConstraint(project.NewAddRelationTupleUniqueConstraint("document:readme#editor@project:project1#writer", "project1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testViewerTuple, testEditorTuple},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.AddRelationTuples(tt.args.ctx, tt.args.projectID, tt.args.resourceOwner, tt.args.tuples...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveRelationTuples(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		resourceOwner string
+		tuples        []*domain.RelationTuple
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "project missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testViewerTuple},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "tuple not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRelationTupleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								relationTupleToEvent(testViewerTuple),
+							),
+						),
+						eventFromEventPusher(
+							project.NewRelationTupleRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								relationTupleToEvent(testViewerTuple),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testViewerTuple},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "remove tuple, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRelationTupleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								relationTupleToEvent(testViewerTuple),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewRelationTupleRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									relationTupleToEvent(testViewerTuple),
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewRemoveRelationTupleUniqueConstraint("document:readme#viewer@user:user1", "project1")),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+				tuples:        []*domain.RelationTuple{testViewerTuple},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveRelationTuples(tt.args.ctx, tt.args.projectID, tt.args.resourceOwner, tt.args.tuples...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddSession creates a session of the session API, optionally for an OIDC or SAML auth request.
+// The returned token is needed for all further requests on the session and is only returned once.
+func (c *Commands) AddSession(ctx context.Context, authRequestID string) (_ *domain.Session, token string, err error) {
+	sessionID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	encryptedToken, token, err := crypto.NewCode(c.sessionTokenGenerator)
+	if err != nil {
+		return nil, "", err
+	}
+	addedSession := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewAddedEvent(
+		ctx,
+		SessionAggregateFromWriteModel(&addedSession.WriteModel),
+		authRequestID,
+		encryptedToken,
+	))
+	if err != nil {
+		return nil, "", err
+	}
+	err = AppendAndReduce(addedSession, pushedEvents...)
+	if err != nil {
+		return nil, "", err
+	}
+	return sessionWriteModelToSession(addedSession), token, nil
+}
+
+// CheckSessionToken returns the session if it's active and the token is valid
+func (c *Commands) CheckSessionToken(ctx context.Context, sessionID, token string) (*domain.Session, error) {
+	if sessionID == "" || token == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sx2kd", "Errors.Session.TokenInvalid")
+	}
+	existingSession, err := c.activeSessionWriteModelByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	err = crypto.VerifyCode(existingSession.TokenCreationDate, c.sessionTokenGenerator.Expiry(), existingSession.Token, token, c.sessionTokenGenerator)
+	if err != nil {
+		return nil, caos_errs.ThrowPermissionDenied(err, "COMMAND-Sx3le", "Errors.Session.TokenInvalid")
+	}
+	return sessionWriteModelToSession(existingSession), nil
+}
+
+// SessionUserChecked sets the user of the session.
+// Once set, the user of a session can't be changed anymore.
+func (c *Commands) SessionUserChecked(ctx context.Context, sessionID, userID, userResourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sx4mf", "Errors.User.UserIDMissing")
+	}
+	existingSession, err := c.activeSessionWriteModelByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if existingSession.UserID == userID {
+		return writeModelToObjectDetails(&existingSession.WriteModel), nil
+	}
+	if existingSession.UserID != "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sx5ng", "Errors.Session.UserAlreadyChecked")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewUserCheckedEvent(
+		ctx,
+		SessionAggregateFromWriteModel(&existingSession.WriteModel),
+		userID,
+		userResourceOwner,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingSession, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingSession.WriteModel), nil
+}
+
+func (c *Commands) TerminateSession(ctx context.Context, sessionID string) (*domain.ObjectDetails, error) {
+	existingSession, err := c.activeSessionWriteModelByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, session.NewTerminatedEvent(
+		ctx,
+		SessionAggregateFromWriteModel(&existingSession.WriteModel),
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingSession, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingSession.WriteModel), nil
+}
+
+func (c *Commands) activeSessionWriteModelByID(ctx context.Context, sessionID string) (writeModel *SessionWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if sessionID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sx6oh", "Errors.IDMissing")
+	}
+	writeModel = NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.SessionStateActive {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sx7pi", "Errors.Session.NotFound")
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,13 @@
+package command
+
+import "github.com/zitadel/zitadel/internal/domain"
+
+func sessionWriteModelToSession(wm *SessionWriteModel) *domain.Session {
+	return &domain.Session{
+		ObjectRoot:        writeModelToObjectRoot(wm.WriteModel),
+		State:             wm.State,
+		AuthRequestID:     wm.AuthRequestID,
+		UserID:            wm.UserID,
+		UserResourceOwner: wm.UserResourceOwner,
+	}
+}
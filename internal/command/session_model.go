@@ -0,0 +1,66 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+)
+
+type SessionWriteModel struct {
+	eventstore.WriteModel
+
+	State             domain.SessionState
+	AuthRequestID     string
+	Token             *crypto.CryptoValue
+	TokenCreationDate time.Time
+	UserID            string
+	UserResourceOwner string
+}
+
+func NewSessionWriteModel(sessionID, resourceOwner string) *SessionWriteModel {
+	return &SessionWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   sessionID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *SessionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *session.AddedEvent:
+			wm.AuthRequestID = e.AuthRequestID
+			wm.Token = e.Token
+			wm.TokenCreationDate = e.CreationDate()
+			wm.State = domain.SessionStateActive
+		case *session.UserCheckedEvent:
+			wm.UserID = e.UserID
+			wm.UserResourceOwner = e.UserResourceOwner
+		case *session.TerminatedEvent:
+			wm.State = domain.SessionStateTerminated
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(session.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			session.AddedType,
+			session.UserCheckedType,
+			session.TerminatedType,
+		).
+		Builder()
+}
+
+func SessionAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, session.AggregateType, session.AggregateVersion)
+}
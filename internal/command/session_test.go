@@ -0,0 +1,574 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/session"
+)
+
+func sessionToken(token string) *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(token),
+	}
+}
+
+func TestCommandSide_AddSession(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		authRequestID string
+	}
+	type res struct {
+		want  *domain.Session
+		token string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "session added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								session.NewAddedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"authRequest1",
+									sessionToken(""),
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "session1"),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				authRequestID: "authRequest1",
+			},
+			res: res{
+				want: &domain.Session{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "session1",
+						ResourceOwner: "instance1",
+						InstanceID:    "instance1",
+					},
+					State:         domain.SessionStateActive,
+					AuthRequestID: "authRequest1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:            tt.fields.eventstore,
+				idGenerator:           tt.fields.idGenerator,
+				sessionTokenGenerator: crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			}
+			got, token, err := r.AddSession(tt.args.ctx, tt.args.authRequestID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.token, token)
+			}
+		})
+	}
+}
+
+func TestCommandSide_CheckSessionToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		sessionID string
+		token     string
+	}
+	type res struct {
+		want *domain.Session
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing token, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "session not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+				token:     "token",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "session terminated, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+						eventFromEventPusher(
+							session.NewTerminatedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+				token:     "token",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "wrong token, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+				token:     "wrong",
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "valid token, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"authRequest1",
+								sessionToken("token"),
+							),
+						),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"user1",
+								"org1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+				token:     "token",
+			},
+			res: res{
+				want: &domain.Session{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "session1",
+						ResourceOwner: "instance1",
+					},
+					State:             domain.SessionStateActive,
+					AuthRequestID:     "authRequest1",
+					UserID:            "user1",
+					UserResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:            tt.fields.eventstore,
+				sessionTokenGenerator: crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, crypto.CreateMockEncryptionAlg(gomock.NewController(t))),
+			}
+			got, err := r.CheckSessionToken(tt.args.ctx, tt.args.sessionID, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SessionUserChecked(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx               context.Context
+		sessionID         string
+		userID            string
+		userResourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing user, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "session not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:               authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:         "session1",
+				userID:            "user1",
+				userResourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "other user already checked, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"user2",
+								"org1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:               authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:         "session1",
+				userID:            "user1",
+				userResourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "same user already checked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"user1",
+								"org1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:               authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:         "session1",
+				userID:            "user1",
+				userResourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "user checked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								session.NewUserCheckedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+									"user1",
+									"org1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:               authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID:         "session1",
+				userID:            "user1",
+				userResourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SessionUserChecked(tt.args.ctx, tt.args.sessionID, tt.args.userID, tt.args.userResourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_TerminateSession(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		sessionID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "session already terminated, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+						eventFromEventPusher(
+							session.NewTerminatedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "session terminated, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(),
+								&session.NewAggregate("session1", "instance1").Aggregate,
+								"",
+								sessionToken("token"),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								session.NewTerminatedEvent(context.Background(),
+									&session.NewAggregate("session1", "instance1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "instance1"),
+				sessionID: "session1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.TerminateSession(tt.args.ctx, tt.args.sessionID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
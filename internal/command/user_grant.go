@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -13,11 +14,11 @@ import (
 )
 
 func (c *Commands) AddUserGrant(ctx context.Context, usergrant *domain.UserGrant, resourceOwner string) (_ *domain.UserGrant, err error) {
-	event, addedUserGrant, err := c.addUserGrant(ctx, usergrant, resourceOwner)
+	events, addedUserGrant, err := c.addUserGrant(ctx, usergrant, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	pushedEvents, err := c.eventstore.Push(ctx, event)
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -29,10 +30,14 @@ func (c *Commands) AddUserGrant(ctx context.Context, usergrant *domain.UserGrant
 	return userGrantWriteModelToUserGrant(addedUserGrant), nil
 }
 
-func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (command eventstore.Command, _ *UserGrantWriteModel, err error) {
+func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (_ []eventstore.Command, _ *UserGrantWriteModel, err error) {
 	if !userGrant.IsValid() {
 		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-kVfMa", "Errors.UserGrant.Invalid")
 	}
+	now := time.Now()
+	if userGrant.Validity.IsExpired(now) {
+		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xp4mw", "Errors.UserGrant.ValidityInvalid")
+	}
 	err = c.checkUserGrantPreCondition(ctx, userGrant, resourceOwner)
 	if err != nil {
 		return nil, nil, err
@@ -44,23 +49,38 @@ func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant
 
 	addedUserGrant := NewUserGrantWriteModel(userGrant.AggregateID, resourceOwner)
 	userGrantAgg := UserGrantAggregateFromWriteModel(&addedUserGrant.WriteModel)
-	command = usergrant.NewUserGrantAddedEvent(
-		ctx,
-		userGrantAgg,
-		userGrant.UserID,
-		userGrant.ProjectID,
-		userGrant.ProjectGrantID,
-		userGrant.RoleKeys,
-	)
-	return command, addedUserGrant, nil
+	events := []eventstore.Command{
+		usergrant.NewUserGrantAddedEvent(
+			ctx,
+			userGrantAgg,
+			userGrant.UserID,
+			userGrant.ProjectID,
+			userGrant.ProjectGrantID,
+			userGrant.RoleKeys,
+		),
+	}
+	return append(events, userGrantValidityEvents(ctx, userGrantAgg, userGrant.Validity, domain.UserGrantStateActive, now)...), addedUserGrant, nil
+}
+
+// userGrantValidityEvents sets the validity of the grant
+// and deactivates it until the validity starts
+func userGrantValidityEvents(ctx context.Context, userGrantAgg *eventstore.Aggregate, validity domain.Validity, state domain.UserGrantState, now time.Time) []eventstore.Command {
+	if validity.IsZero() {
+		return nil
+	}
+	events := []eventstore.Command{usergrant.NewUserGrantValiditySetEvent(ctx, userGrantAgg, validity.ValidFrom, validity.ValidUntil)}
+	if state == domain.UserGrantStateActive && validity.NotYetValid(now) {
+		events = append(events, usergrant.NewUserGrantDeactivatedEvent(ctx, userGrantAgg))
+	}
+	return events
 }
 
 func (c *Commands) ChangeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (_ *domain.UserGrant, err error) {
-	event, changedUserGrant, err := c.changeUserGrant(ctx, userGrant, resourceOwner, false)
+	events, changedUserGrant, err := c.changeUserGrant(ctx, userGrant, resourceOwner, false)
 	if err != nil {
 		return nil, err
 	}
-	pushedEvents, err := c.eventstore.Push(ctx, event)
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +91,13 @@ func (c *Commands) ChangeUserGrant(ctx context.Context, userGrant *domain.UserGr
 	return userGrantWriteModelToUserGrant(changedUserGrant), nil
 }
 
-func (c *Commands) changeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string, cascade bool) (_ eventstore.Command, _ *UserGrantWriteModel, err error) {
-	if userGrant.AggregateID == "" {
+func (c *Commands) changeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string, cascade bool) (_ []eventstore.Command, _ *UserGrantWriteModel, err error) {
+	if userGrant.AggregateID == "" || !userGrant.Validity.IsValid() {
 		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-3M0sd", "Errors.UserGrant.Invalid")
 	}
+	if userGrant.Validity.IsExpired(time.Now()) {
+		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb2qe", "Errors.UserGrant.ValidityInvalid")
+	}
 	existingUserGrant, err := c.userGrantWriteModelByID(ctx, userGrant.AggregateID, userGrant.ResourceOwner)
 	if err != nil {
 		return nil, nil, err
@@ -86,7 +109,9 @@ func (c *Commands) changeUserGrant(ctx context.Context, userGrant *domain.UserGr
 	if existingUserGrant.State == domain.UserGrantStateUnspecified || existingUserGrant.State == domain.UserGrantStateRemoved {
 		return nil, nil, caos_errs.ThrowNotFound(nil, "COMMAND-3M9sd", "Errors.UserGrant.NotFound")
 	}
-	if reflect.DeepEqual(existingUserGrant.RoleKeys, userGrant.RoleKeys) {
+	rolesChanged := !reflect.DeepEqual(existingUserGrant.RoleKeys, userGrant.RoleKeys)
+	validityChanged := !existingUserGrant.Validity.Equal(userGrant.Validity)
+	if !rolesChanged && !validityChanged {
 		return nil, nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Rs8fy", "Errors.UserGrant.NotChanged")
 	}
 	userGrant.ProjectID = existingUserGrant.ProjectID
@@ -99,10 +124,25 @@ func (c *Commands) changeUserGrant(ctx context.Context, userGrant *domain.UserGr
 	changedUserGrant := NewUserGrantWriteModel(userGrant.AggregateID, resourceOwner)
 	userGrantAgg := UserGrantAggregateFromWriteModel(&changedUserGrant.WriteModel)
 
-	if cascade {
-		return usergrant.NewUserGrantCascadeChangedEvent(ctx, userGrantAgg, userGrant.RoleKeys), existingUserGrant, nil
+	events := make([]eventstore.Command, 0, 3)
+	if rolesChanged {
+		if cascade {
+			events = append(events, usergrant.NewUserGrantCascadeChangedEvent(ctx, userGrantAgg, userGrant.RoleKeys))
+		} else {
+			events = append(events, usergrant.NewUserGrantChangedEvent(ctx, userGrantAgg, userGrant.RoleKeys))
+		}
 	}
-	return usergrant.NewUserGrantChangedEvent(ctx, userGrantAgg, userGrant.RoleKeys), existingUserGrant, nil
+	if validityChanged {
+		events = append(events, usergrant.NewUserGrantValiditySetEvent(ctx, userGrantAgg, userGrant.ValidFrom, userGrant.ValidUntil))
+		now := time.Now()
+		switch {
+		case existingUserGrant.State == domain.UserGrantStateActive && userGrant.NotYetValid(now):
+			events = append(events, usergrant.NewUserGrantDeactivatedEvent(ctx, userGrantAgg))
+		case existingUserGrant.State == domain.UserGrantStateInactive && existingUserGrant.PendingActivation && userGrant.IsEffective(now):
+			events = append(events, usergrant.NewUserGrantReactivatedEvent(ctx, userGrantAgg))
+		}
+	}
+	return events, existingUserGrant, nil
 }
 
 func (c *Commands) removeRoleFromUserGrant(ctx context.Context, userGrantID string, roleKeys []string, cascade bool) (_ eventstore.Command, err error) {
@@ -203,6 +243,44 @@ func (c *Commands) ReactivateUserGrant(ctx context.Context, grantID, resourceOwn
 	return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
 }
 
+// ApplyUserGrantValidity deactivates the grant if its validity expired
+// and activates it if it was deactivated until its validity starts
+func (c *Commands) ApplyUserGrantValidity(ctx context.Context, grantID, resourceOwner string) (objectDetails *domain.ObjectDetails, err error) {
+	if grantID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jz3ns", "Errors.UserGrant.IDMissing")
+	}
+
+	existingUserGrant, err := c.userGrantWriteModelByID(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUserGrant.State == domain.UserGrantStateUnspecified || existingUserGrant.State == domain.UserGrantStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Wd0sj", "Errors.UserGrant.NotFound")
+	}
+
+	userGrantAgg := UserGrantAggregateFromWriteModel(&existingUserGrant.WriteModel)
+	var event eventstore.Command
+	now := time.Now()
+	switch {
+	case existingUserGrant.State == domain.UserGrantStateActive && existingUserGrant.Validity.IsExpired(now):
+		event = usergrant.NewUserGrantDeactivatedEvent(ctx, userGrantAgg)
+	case existingUserGrant.State == domain.UserGrantStateInactive && existingUserGrant.PendingActivation && existingUserGrant.Validity.IsEffective(now):
+		event = usergrant.NewUserGrantReactivatedEvent(ctx, userGrantAgg)
+	default:
+		return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUserGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+}
+
 func (c *Commands) RemoveUserGrant(ctx context.Context, grantID, resourceOwner string) (objectDetails *domain.ObjectDetails, err error) {
 	event, existingUserGrant, err := c.removeUserGrant(ctx, grantID, resourceOwner, false)
 	if err != nil {
@@ -16,6 +16,9 @@ type UserGrantWriteModel struct {
 	ProjectGrantID string
 	RoleKeys       []string
 	State          domain.UserGrantState
+	Validity       domain.Validity
+	// PendingActivation is true if the grant was deactivated because it wasn't valid yet
+	PendingActivation bool
 }
 
 func NewUserGrantWriteModel(userGrantID string, resourceOwner string) *UserGrantWriteModel {
@@ -40,16 +43,20 @@ func (wm *UserGrantWriteModel) Reduce() error {
 			wm.RoleKeys = e.RoleKeys
 		case *usergrant.UserGrantCascadeChangedEvent:
 			wm.RoleKeys = e.RoleKeys
+		case *usergrant.UserGrantValiditySetEvent:
+			wm.Validity = domain.Validity{ValidFrom: e.ValidFrom, ValidUntil: e.ValidUntil}
 		case *usergrant.UserGrantDeactivatedEvent:
 			if wm.State == domain.UserGrantStateRemoved {
 				continue
 			}
 			wm.State = domain.UserGrantStateInactive
+			wm.PendingActivation = wm.Validity.NotYetValid(e.CreationDate())
 		case *usergrant.UserGrantReactivatedEvent:
 			if wm.State == domain.UserGrantStateRemoved {
 				continue
 			}
 			wm.State = domain.UserGrantStateActive
+			wm.PendingActivation = false
 		case *usergrant.UserGrantRemovedEvent:
 			wm.State = domain.UserGrantStateRemoved
 		case *usergrant.UserGrantCascadeRemovedEvent:
@@ -69,6 +76,7 @@ func (wm *UserGrantWriteModel) Query() *eventstore.SearchQueryBuilder {
 			usergrant.UserGrantCascadeChangedType,
 			usergrant.UserGrantDeactivatedType,
 			usergrant.UserGrantReactivatedType,
+			usergrant.UserGrantValiditySetType,
 			usergrant.UserGrantRemovedType,
 			usergrant.UserGrantCascadeRemovedType).
 		Builder()
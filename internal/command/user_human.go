@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -70,7 +71,7 @@ func (c *Commands) AddHumanWithID(ctx context.Context, resourceOwner string, use
 
 func (c *Commands) addHumanWithID(ctx context.Context, resourceOwner string, userID string, human *AddHuman) (*domain.HumanDetails, error) {
 	agg := user.NewAggregate(userID, resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddHumanCommand(agg, human, c.userPasswordAlg, c.userEncryption))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddHumanCommand(agg, human, c.userPasswordAlg, c.userEncryption, c.breachChecker))
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +109,7 @@ type humanCreationCommand interface {
 	AddPasswordData(secret *crypto.CryptoValue, changeRequired bool)
 }
 
-func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.HashAlgorithm, codeAlg crypto.EncryptionAlgorithm) preparation.Validation {
+func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.HashAlgorithm, codeAlg crypto.EncryptionAlgorithm, breachChecker breach.Checker) preparation.Validation {
 	return func() (_ preparation.CreateCommands, err error) {
 		if !human.Email.Valid() {
 			return nil, errors.ThrowInvalidArgument(nil, "USER-Ec7dM", "Errors.Invalid.Argument")
@@ -175,7 +176,7 @@ func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.Hash
 			}
 
 			if human.Password != "" {
-				if err = humanValidatePassword(ctx, filter, human.Password); err != nil {
+				if err = humanValidatePassword(ctx, filter, human.Password, breachChecker); err != nil {
 					return nil, err
 				}
 
@@ -256,13 +257,16 @@ func userValidateDomain(ctx context.Context, a *user.Aggregate, username string,
 	return nil
 }
 
-func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
+func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string, breachChecker breach.Checker) error {
 	passwordComplexity, err := passwordComplexityPolicyWriteModel(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	return passwordComplexity.Validate(password)
+	if err = passwordComplexity.Validate(password); err != nil {
+		return err
+	}
+	return checkPasswordBreached(ctx, breachChecker, passwordComplexity.RejectBreached, password)
 }
 
 func (h *AddHuman) ensureDisplayName() {
@@ -468,6 +472,11 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 		if err := human.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg, human.Password.ChangeRequired); err != nil {
 			return nil, nil, err
 		}
+		if human.Password.SecretString != "" {
+			if err := checkPasswordBreached(ctx, c.breachChecker, pwPolicy.RejectBreached, human.Password.SecretString); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 
 	addedHuman = NewHumanWriteModel(human.AggregateID, orgID)
@@ -606,18 +615,23 @@ func createRegisterHumanEvent(ctx context.Context, aggregate *eventstore.Aggrega
 	return addEvent
 }
 
-func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []string) error {
+// HumansSignOut terminates the sessions of the users on the user agent.
+// The sessionClientIDs are the clients which were issued tokens in the session of each user (see query.SessionClientIDs),
+// the deduplicated ids of all of them are returned
+func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []string, sessionClientIDs map[string][]string) ([]string, error) {
 	if agentID == "" {
-		return errors.ThrowInvalidArgument(nil, "COMMAND-2M0ds", "Errors.User.UserIDMissing")
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-2M0ds", "Errors.User.UserIDMissing")
 	}
 	if len(userIDs) == 0 {
-		return errors.ThrowInvalidArgument(nil, "COMMAND-M0od3", "Errors.User.UserIDMissing")
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-M0od3", "Errors.User.UserIDMissing")
 	}
 	events := make([]eventstore.Command, 0)
+	var clientIDs []string
+	signedOutClients := make(map[string]struct{})
 	for _, userID := range userIDs {
 		existingUser, err := c.getHumanWriteModelByID(ctx, userID, "")
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !isUserStateExists(existingUser.UserState) {
 			continue
@@ -625,12 +639,39 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 		events = append(events, user.NewHumanSignedOutEvent(
 			ctx,
 			UserAggregateFromWriteModel(&existingUser.WriteModel),
-			agentID))
+			agentID,
+			sessionClientIDs[userID]))
+		for _, clientID := range sessionClientIDs[userID] {
+			if _, ok := signedOutClients[clientID]; ok {
+				continue
+			}
+			signedOutClients[clientID] = struct{}{}
+			clientIDs = append(clientIDs, clientID)
+		}
 	}
 	if len(events) == 0 {
-		return nil
+		return nil, nil
 	}
-	_, err := c.eventstore.Push(ctx, events...)
+	if _, err := c.eventstore.Push(ctx, events...); err != nil {
+		return nil, err
+	}
+	return clientIDs, nil
+}
+
+// BackChannelLogoutSent marks the logout token of the sign out on the user agent as delivered to the client
+func (c *Commands) BackChannelLogoutSent(ctx context.Context, orgID, userID, agentID, clientID string) error {
+	if userID == "" || agentID == "" || clientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Bq2ls", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Bq3lf", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanBackChannelLogoutSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), agentID, clientID))
 	return err
 }
 
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// HumanSendMagicLink generates a new login code for the auth request,
+// which will be sent as link to the verified email of the user by the notification handler
+func (c *Commands) HumanSendMagicLink(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mk2a9", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.ID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mk3b8", "Errors.User.MagicLink.AuthRequestMissing")
+	}
+	existing, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existing.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Mk4c7", "Errors.User.NotFound")
+	}
+	if !existing.EmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Mk5d6", "Errors.User.Email.NotVerified")
+	}
+	code, expiry, err := newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeMagicLinkCode, c.userEncryption, c.defaultSecretGenerators[domain.SecretGeneratorTypeMagicLinkCode])
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanMagicLinkCodeSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mk6e5", "Errors.User.UserIDMissing")
+	}
+	existing, err := c.magicLinkWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existing.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Mk7f4", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckMagicLink checks the code of the link sent for the auth request.
+// The code can only be used once, regardless of the result of the check.
+// The check is recorded for the user agent of the auth request (not the one of the clicked link),
+// so the login continues where it was started.
+func (c *Commands) HumanCheckMagicLink(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mk8g3", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mk9h2", "Errors.User.Code.Empty")
+	}
+	if authRequest == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ml0i1", "Errors.User.MagicLink.AuthRequestMissing")
+	}
+	existing, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existing.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Ml1j0", "Errors.User.NotFound")
+	}
+	if existing.Code == nil || existing.AuthRequestID != authRequest.ID {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Ml2k9", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	err = crypto.VerifyCode(existing.CodeCreationDate, existing.CodeExpiry, existing.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create magic link check failed event")
+	return err
+}
+
+func (c *Commands) magicLinkWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanMagicLinkWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanMagicLinkWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
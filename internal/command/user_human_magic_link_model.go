@@ -0,0 +1,84 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanMagicLinkWriteModel struct {
+	eventstore.WriteModel
+
+	UserState     domain.UserState
+	EmailVerified bool
+
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	AuthRequestID    string
+}
+
+func NewHumanMagicLinkWriteModel(userID, resourceOwner string) *HumanMagicLinkWriteModel {
+	return &HumanMagicLinkWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanMagicLinkWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanEmailVerifiedEvent:
+			wm.EmailVerified = true
+		case *user.HumanEmailChangedEvent:
+			wm.EmailVerified = false
+			wm.Code = nil
+		case *user.HumanMagicLinkCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.AuthRequestID = ""
+			if e.AuthRequestInfo != nil {
+				wm.AuthRequestID = e.AuthRequestInfo.ID
+			}
+		case *user.HumanMagicLinkCheckSucceededEvent,
+			*user.HumanMagicLinkCheckFailedEvent:
+			wm.Code = nil
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanMagicLinkWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1EmailVerifiedType,
+			user.HumanEmailVerifiedType,
+			user.HumanMagicLinkCodeAddedType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.HumanMagicLinkCheckFailedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
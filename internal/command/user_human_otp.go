@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 
@@ -13,6 +14,13 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
+const (
+	// otpCodeResendCooldown is the duration after sending an SMS or email one-time code, in which no new code can be requested
+	otpCodeResendCooldown = time.Minute
+	// otpCodeMaxAttempts is the amount of failed checks after which an SMS or email one-time code is invalidated
+	otpCodeMaxAttempts = 5
+)
+
 func (c *Commands) ImportHumanOTP(ctx context.Context, userID, userAgentID, resourceowner string, key string) error {
 	encryptedSecret, err := crypto.Encrypt([]byte(key), c.multifactors.OTP.CryptoMFA)
 	if err != nil {
@@ -177,3 +185,269 @@ func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner
 	}
 	return writeModel, nil
 }
+
+func (c *Commands) AddHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-QSF2s", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Ad3g2", "Errors.User.MFA.OTPSMS.AlreadyReady")
+	}
+	if !otpWriteModel.PhoneVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Q54j2", "Errors.User.Phone.NotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-S3br2", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sr3h3", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+// HumanSendOTPSMS generates a new one-time code, which will be sent to the verified phone of the user by the notification handler
+// A new code can't be requested during the resend cooldown of the last one
+func (c *Commands) HumanSendOTPSMS(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wf3h2", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gs3j2", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	if !existingOTP.PhoneVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ss3h4", "Errors.User.Phone.NotVerified")
+	}
+	if !existingOTP.codeResendAllowed(time.Now()) {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Sq4ka", "Errors.User.Code.ResendTooEarly")
+	}
+	code, expiry, err := newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, c.userEncryption, c.defaultSecretGenerators[domain.SecretGeneratorTypeOTPSMS])
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeAddedEvent(ctx, userAgg, code, expiry))
+	return err
+}
+
+func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-As3hj", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ds4h2", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fsj3h", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ds4hb", "Errors.User.Code.Empty")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jm3sg", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	if existingOTP.Code == nil {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Hn3sw", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	err = crypto.VerifyCode(existingOTP.CodeCreationDate, existingOTP.CodeExpiry, existingOTP.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create otp sms check failed event")
+	return err
+}
+
+func (c *Commands) otpSMSWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPSMSWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPSMSWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) AddHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sg1hz", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-MKL2s", "Errors.User.MFA.OTPEmail.AlreadyReady")
+	}
+	if !otpWriteModel.EmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-KLJ2d", "Errors.User.Email.NotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-S2h11", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-b312D", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+// HumanSendOTPEmail generates a new one-time code, which will be sent to the verified email of the user by the notification handler
+// A new code can't be requested during the resend cooldown of the last one
+func (c *Commands) HumanSendOTPEmail(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hj3ka", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nd3fa", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	if !existingOTP.EmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tq3fx", "Errors.User.Email.NotVerified")
+	}
+	if !existingOTP.codeResendAllowed(time.Now()) {
+		return caos_errs.ThrowResourceExhausted(nil, "COMMAND-Eq5lb", "Errors.User.Code.ResendTooEarly")
+	}
+	code, expiry, err := newEncryptedCodeWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, c.userEncryption, c.defaultSecretGenerators[domain.SecretGeneratorTypeOTPEmail])
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeAddedEvent(ctx, userAgg, code, expiry))
+	return err
+}
+
+func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ks3hd", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vr3sq", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Dh4k1", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rs2hq", "Errors.User.Code.Empty")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wp3vs", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	if existingOTP.Code == nil {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Zs3gd", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	err = crypto.VerifyCode(existingOTP.CodeCreationDate, existingOTP.CodeExpiry, existingOTP.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create otp email check failed event")
+	return err
+}
+
+func (c *Commands) otpEmailWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPEmailWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPEmailWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -59,3 +61,180 @@ func (wm *HumanOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+type HumanOTPSMSWriteModel struct {
+	eventstore.WriteModel
+
+	State         domain.MFAState
+	PhoneVerified bool
+
+	Code               *crypto.CryptoValue
+	CodeCreationDate   time.Time
+	CodeExpiry         time.Duration
+	CodeFailedAttempts int
+}
+
+func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteModel {
+	return &HumanOTPSMSWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPSMSWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPhoneVerifiedEvent:
+			wm.PhoneVerified = true
+		case *user.HumanPhoneChangedEvent,
+			*user.HumanPhoneRemovedEvent:
+			wm.PhoneVerified = false
+		case *user.HumanOTPSMSAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPSMSRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPSMSCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeFailedAttempts = 0
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.Code = nil
+		case *user.HumanOTPSMSCheckFailedEvent:
+			wm.codeCheckFailed()
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// codeCheckFailed invalidates the code after too many failed checks
+func (wm *HumanOTPSMSWriteModel) codeCheckFailed() {
+	if wm.Code == nil {
+		return
+	}
+	wm.CodeFailedAttempts++
+	if wm.CodeFailedAttempts >= otpCodeMaxAttempts {
+		wm.Code = nil
+	}
+}
+
+// codeResendAllowed returns false if a valid code was created within the cooldown
+func (wm *HumanOTPSMSWriteModel) codeResendAllowed(now time.Time) bool {
+	return wm.Code == nil || wm.CodeCreationDate.Add(otpCodeResendCooldown).Before(now)
+}
+
+func (wm *HumanOTPSMSWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanPhoneVerifiedType,
+			user.HumanPhoneChangedType,
+			user.HumanPhoneRemovedType,
+			user.HumanMFAOTPSMSAddedType,
+			user.HumanMFAOTPSMSRemovedType,
+			user.HumanMFAOTPSMSCodeAddedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPSMSCheckFailedType,
+			user.UserRemovedType,
+			user.UserV1PhoneVerifiedType,
+			user.UserV1PhoneChangedType,
+			user.UserV1PhoneRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+type HumanOTPEmailWriteModel struct {
+	eventstore.WriteModel
+
+	State         domain.MFAState
+	EmailVerified bool
+
+	Code               *crypto.CryptoValue
+	CodeCreationDate   time.Time
+	CodeExpiry         time.Duration
+	CodeFailedAttempts int
+}
+
+func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWriteModel {
+	return &HumanOTPEmailWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPEmailWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanEmailVerifiedEvent:
+			wm.EmailVerified = true
+		case *user.HumanEmailChangedEvent:
+			wm.EmailVerified = false
+		case *user.HumanOTPEmailAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPEmailRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPEmailCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeFailedAttempts = 0
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.Code = nil
+		case *user.HumanOTPEmailCheckFailedEvent:
+			wm.codeCheckFailed()
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// codeCheckFailed invalidates the code after too many failed checks
+func (wm *HumanOTPEmailWriteModel) codeCheckFailed() {
+	if wm.Code == nil {
+		return
+	}
+	wm.CodeFailedAttempts++
+	if wm.CodeFailedAttempts >= otpCodeMaxAttempts {
+		wm.Code = nil
+	}
+}
+
+// codeResendAllowed returns false if a valid code was created within the cooldown
+func (wm *HumanOTPEmailWriteModel) codeResendAllowed(now time.Time) bool {
+	return wm.Code == nil || wm.CodeCreationDate.Add(otpCodeResendCooldown).Before(now)
+}
+
+func (wm *HumanOTPEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanEmailVerifiedType,
+			user.HumanEmailChangedType,
+			user.HumanMFAOTPEmailAddedType,
+			user.HumanMFAOTPEmailRemovedType,
+			user.HumanMFAOTPEmailCodeAddedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanMFAOTPEmailCheckFailedType,
+			user.UserRemovedType,
+			user.UserV1EmailVerifiedType,
+			user.UserV1EmailChangedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
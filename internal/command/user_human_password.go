@@ -145,9 +145,38 @@ func (c *Commands) changePassword(ctx context.Context, userAgentID string, passw
 	if err := password.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg); err != nil {
 		return nil, err
 	}
+	if err := checkPasswordBreached(ctx, c.breachChecker, pwPolicy.RejectBreached, password.SecretString); err != nil {
+		return nil, err
+	}
+	if err := c.checkPasswordReused(ctx, userAgg.ResourceOwner, password.SecretString, existingPassword.PasswordHistory); err != nil {
+		return nil, err
+	}
 	return user.NewHumanPasswordChangedEvent(ctx, userAgg, password.SecretCrypto, password.ChangeRequired, userAgentID), nil
 }
 
+// checkPasswordReused rejects the password if it matches one of the previous passwords
+// the password age policy of the organisation prevents from reuse
+func (c *Commands) checkPasswordReused(ctx context.Context, orgID, password string, history []*crypto.CryptoValue) (err error) {
+	if password == "" || len(history) == 0 {
+		return nil
+	}
+	agePolicy, err := c.getOrgPasswordAgePolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if uint64(len(history)) > agePolicy.HistoryCount {
+		history = history[:agePolicy.HistoryCount]
+	}
+	_, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	defer func() { spanPasswordComparison.EndWithError(err) }()
+	for _, previous := range history {
+		if crypto.CompareHash(previous, []byte(password), c.userPasswordAlg) == nil {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ju8sb", "Errors.User.Password.Reused")
+		}
+	}
+	return nil
+}
+
 func (c *Commands) RequestSetPassword(ctx context.Context, userID, resourceOwner string, notifyType domain.NotificationType, passwordVerificationCode crypto.Generator) (objectDetails *domain.ObjectDetails, err error) {
 	if userID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-M00oL", "Errors.User.UserIDMissing")
@@ -15,6 +15,8 @@ type HumanPasswordWriteModel struct {
 
 	Secret               *crypto.CryptoValue
 	SecretChangeRequired bool
+	// PasswordHistory contains the hashes of the current and previous passwords, newest first
+	PasswordHistory []*crypto.CryptoValue
 
 	Code                     *crypto.CryptoValue
 	CodeCreationDate         time.Time
@@ -39,10 +41,12 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 		case *user.HumanAddedEvent:
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.addPasswordHistory(e.Secret)
 			wm.UserState = domain.UserStateActive
 		case *user.HumanRegisteredEvent:
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.addPasswordHistory(e.Secret)
 			wm.UserState = domain.UserStateActive
 		case *user.HumanInitialCodeAddedEvent:
 			wm.UserState = domain.UserStateInitial
@@ -51,6 +55,7 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 		case *user.HumanPasswordChangedEvent:
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
+			wm.addPasswordHistory(e.Secret)
 			wm.Code = nil
 			wm.PasswordCheckFailedCount = 0
 		case *user.HumanPasswordCodeAddedEvent:
@@ -74,6 +79,17 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// addPasswordHistory keeps at most the amount of hashes a password age policy can prevent from reuse
+func (wm *HumanPasswordWriteModel) addPasswordHistory(secret *crypto.CryptoValue) {
+	if secret == nil {
+		return
+	}
+	wm.PasswordHistory = append([]*crypto.CryptoValue{secret}, wm.PasswordHistory...)
+	if len(wm.PasswordHistory) > domain.MaxPasswordHistoryCount {
+		wm.PasswordHistory = wm.PasswordHistory[:domain.MaxPasswordHistoryCount]
+	}
+}
+
 func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
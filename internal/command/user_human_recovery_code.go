@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// recoveryCodeLookupSeparator separates the lookup from the secret part of a recovery code
+const recoveryCodeLookupSeparator = "-"
+
+// GenerateHumanRecoveryCodes replaces the recovery codes of the user with new ones.
+// Only the hashes are stored, so the returned plain codes have to be shown to the user once.
+func (c *Commands) GenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) ([]string, *domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc8sd", "Errors.User.UserIDMissing")
+	}
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, nil, err
+	}
+	recoveryCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	codes, err := c.pushRecoveryCodes(ctx, recoveryCodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codes, writeModelToObjectDetails(&recoveryCodes.WriteModel), nil
+}
+
+// InitHumanRecoveryCodes generates recovery codes on the setup of a second factor,
+// unless the user has unused codes left, which are kept and therefore no codes are returned.
+func (c *Commands) InitHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) ([]string, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc9sd", "Errors.User.UserIDMissing")
+	}
+	recoveryCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(recoveryCodes.Codes) > 0 {
+		return nil, nil
+	}
+	if err = c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	return c.pushRecoveryCodes(ctx, recoveryCodes)
+}
+
+func (c *Commands) pushRecoveryCodes(ctx context.Context, recoveryCodes *HumanRecoveryCodesWriteModel) ([]string, error) {
+	hashedCodes := make([]*user.RecoveryCode, c.multifactors.RecoveryCodes.Count)
+	codes := make([]string, c.multifactors.RecoveryCodes.Count)
+	for i := range codes {
+		id, err := c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+		hashedCode, code, err := crypto.NewCode(c.multifactors.RecoveryCodes.Generator)
+		if err != nil {
+			return nil, err
+		}
+		// the codes are always replaced together, so the position identifies the code without revealing any part of the secret
+		lookup := strconv.Itoa(i + 1)
+		hashedCodes[i] = &user.RecoveryCode{ID: id, Code: hashedCode, Lookup: lookup}
+		codes[i] = lookup + recoveryCodeLookupSeparator + code
+	}
+	userAgg := UserAggregateFromWriteModel(&recoveryCodes.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashedCodes))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(recoveryCodes, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// HumanCheckRecoveryCode checks the code against the unused recovery codes of the user.
+// A matching code is used up and can't be used again.
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rc9te", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rd0uf", "Errors.User.Code.Empty")
+	}
+	recoveryCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if len(recoveryCodes.Codes) == 0 {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Rd1vg", "Errors.User.MFA.RecoveryCode.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&recoveryCodes.WriteModel)
+	lookup, secret, hasLookup := strings.Cut(code, recoveryCodeLookupSeparator)
+	for _, recoveryCode := range recoveryCodes.Codes {
+		// codes without lookup were created before it was introduced and are compared with the whole input
+		compareCode := code
+		if recoveryCode.Lookup != "" && hasLookup {
+			if recoveryCode.Lookup != lookup {
+				continue
+			}
+			compareCode = secret
+		}
+		if crypto.CompareHash(recoveryCode.Code, []byte(compareCode), c.userPasswordAlg) == nil {
+			_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, recoveryCode.ID, authRequestDomainToAuthRequestInfo(authRequest)))
+			return err
+		}
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("error create recovery code check failed event")
+	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rd2wh", "Errors.User.MFA.RecoveryCode.Invalid")
+}
+
+func (c *Commands) HumanRecoveryCodeNotificationSent(ctx context.Context, orgID, userID, codeID string) error {
+	if userID == "" || codeID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rd3xi", "Errors.IDMissing")
+	}
+	recoveryCodes, err := c.recoveryCodesWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if recoveryCodes.UserRemoved {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Rd4yj", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanRecoveryCodeNotificationSentEvent(ctx, UserAggregateFromWriteModel(&recoveryCodes.WriteModel), codeID))
+	return err
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	// Codes are the recovery codes, which were not used yet
+	Codes       []*user.RecoveryCode
+	UserRemoved bool
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.Codes = e.Codes
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			wm.removeCode(e.CodeID)
+		case *user.UserRemovedEvent:
+			wm.Codes = nil
+			wm.UserRemoved = true
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) removeCode(codeID string) {
+	codes := make([]*user.RecoveryCode, 0, len(wm.Codes))
+	for _, code := range wm.Codes {
+		if code.ID != codeID {
+			codes = append(codes, code)
+		}
+	}
+	wm.Codes = codes
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanMFARecoveryCodesAddedType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,611 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_GenerateHumanRecoveryCodes(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type (
+		args struct {
+			ctx    context.Context
+			orgID  string
+			userID string
+		}
+	)
+	type res struct {
+		codes []string
+		want  *domain.ObjectDetails
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "generate codes, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]*user.RecoveryCode{
+										{
+											ID: "code1",
+											Code: &crypto.CryptoValue{
+												CryptoType: crypto.TypeHash,
+												Algorithm:  "hash",
+												Crypted:    []byte(""),
+											},
+											Lookup: "1",
+										},
+										{
+											ID: "code2",
+											Code: &crypto.CryptoValue{
+												CryptoType: crypto.TypeHash,
+												Algorithm:  "hash",
+												Crypted:    []byte(""),
+											},
+											Lookup: "2",
+										},
+									},
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "code1", "code2"),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				codes: []string{"1-", "2-"},
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				multifactors: domain.MultifactorConfigs{
+					RecoveryCodes: domain.RecoveryCodesConfig{
+						Count:     2,
+						Generator: crypto.NewHashGenerator(crypto.GeneratorConfig{}, crypto.CreateMockHashAlg(gomock.NewController(t))),
+					},
+				},
+			}
+			codes, got, err := r.GenerateHumanRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.codes, codes)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanCheckRecoveryCode(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx         context.Context
+			orgID       string
+			userID      string
+			code        string
+			authRequest *domain.AuthRequest
+		}
+	)
+	type res struct {
+		err func(error) bool
+	}
+	recoveryCodesAdded := func() *repository.Event {
+		return eventFromEventPusher(
+			user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				[]*user.RecoveryCode{
+					{
+						ID: "code1",
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeHash,
+							Algorithm:  "hash",
+							Crypted:    []byte("ABCDE12345"),
+						},
+						Lookup: "1",
+					},
+					{
+						ID: "code2",
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeHash,
+							Algorithm:  "hash",
+							Crypted:    []byte("FGHIJ67890"),
+						},
+						Lookup: "2",
+					},
+				},
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "code missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no recovery codes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				code:   "ABCDE12345",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "code already used, check failed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						recoveryCodesAdded(),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"code1",
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckFailedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				code:        "1-ABCDE12345",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "lookup not matching, hash not compared, check failed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*user.RecoveryCode{
+									{
+										ID: "code1",
+										Code: &crypto.CryptoValue{
+											CryptoType: crypto.TypeHash,
+											Algorithm:  "hash",
+											Crypted:    []byte("ABCDE12345"),
+										},
+										Lookup: "2",
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckFailedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				code:        "1-ABCDE12345",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "code without lookup, check succeeded",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*user.RecoveryCode{
+									{
+										ID: "code1",
+										Code: &crypto.CryptoValue{
+											CryptoType: crypto.TypeHash,
+											Algorithm:  "hash",
+											Crypted:    []byte("ABCDE12345"),
+										},
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"code1",
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				code:        "ABCDE12345",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+			},
+		},
+		{
+			name: "valid code, check succeeded",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						recoveryCodesAdded(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"code2",
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				code:        "2-FGHIJ67890",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+			},
+		},
+		{
+			name: "valid code without lookup, check succeeded",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						recoveryCodesAdded(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"code2",
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				code:        "FGHIJ67890",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore,
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			}
+			err := r.HumanCheckRecoveryCode(tt.args.ctx, tt.args.userID, tt.args.code, tt.args.orgID, tt.args.authRequest)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_InitHumanRecoveryCodes(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type (
+		args struct {
+			ctx    context.Context
+			orgID  string
+			userID string
+		}
+	)
+	type res struct {
+		codes []string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "unused codes existing, no new codes",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*user.RecoveryCode{
+									{
+										ID: "code1",
+										Code: &crypto.CryptoValue{
+											CryptoType: crypto.TypeHash,
+											Algorithm:  "hash",
+											Crypted:    []byte("ABCDE12345"),
+										},
+									},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{},
+		},
+		{
+			name: "all codes used, new codes",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]*user.RecoveryCode{
+									{
+										ID: "code1",
+										Code: &crypto.CryptoValue{
+											CryptoType: crypto.TypeHash,
+											Algorithm:  "hash",
+											Crypted:    []byte("ABCDE12345"),
+										},
+									},
+								},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRecoveryCodeCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"code1",
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRecoveryCodesAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]*user.RecoveryCode{
+										{
+											ID: "code2",
+											Code: &crypto.CryptoValue{
+												CryptoType: crypto.TypeHash,
+												Algorithm:  "hash",
+												Crypted:    []byte(""),
+											},
+											Lookup: "1",
+										},
+									},
+								),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "code2"),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+			},
+			res: res{
+				codes: []string{"1-"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				multifactors: domain.MultifactorConfigs{
+					RecoveryCodes: domain.RecoveryCodesConfig{
+						Count:     1,
+						Generator: crypto.NewHashGenerator(crypto.GeneratorConfig{}, crypto.CreateMockHashAlg(gomock.NewController(t))),
+					},
+				},
+			}
+			codes, err := r.InitHumanRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.codes, codes)
+			}
+		})
+	}
+}
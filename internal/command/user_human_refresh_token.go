@@ -24,11 +24,13 @@ func (c *Commands) AddAccessAndRefreshToken(
 	refreshIdleExpiration,
 	refreshExpiration time.Duration,
 	authTime time.Time,
+	rotation domain.RefreshTokenRotation,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, confirmation)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, rotation, confirmation)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,6 +46,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || agentID == "" || clientID == "" {
 		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
@@ -53,7 +56,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -79,13 +82,15 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
+	rotation domain.RefreshTokenRotation,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration, rotation, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -136,11 +141,15 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration, accessToken.Confirmation),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+// renewRefreshToken extends the idle expiration of the refresh token and replaces it depending on the rotation of the application.
+// If a superseded refresh token is used with reuse detection, the whole refresh token family (and the access tokens issued with it) is revoked
+// and a security event is emitted, as a rotated token might have been stolen and it's unknown which party is the legitimate client.
+// A refresh token bound to a DPoP key or client certificate can only be renewed with a proof of possession of the same key or certificate.
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration, rotation domain.RefreshTokenRotation, confirmation *domain.TokenConfirmation) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
 	if refreshToken == "" {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
@@ -160,21 +169,38 @@ func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refresh
 	if refreshTokenWriteModel.UserState != domain.UserStateActive {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-BHnhs", "Errors.User.RefreshToken.Invalid")
 	}
-	if refreshTokenWriteModel.RefreshToken != token ||
-		refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
+	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
+	if refreshTokenWriteModel.RefreshToken != token {
+		if rotation == domain.RefreshTokenRotationReuseDetection && refreshTokenWriteModel.IsSuperseded(token) {
+			_, err = c.eventstore.Push(ctx,
+				user.NewHumanRefreshTokenReusedEvent(ctx, userAgg, tokenID, refreshTokenWriteModel.ClientID, refreshTokenWriteModel.UserAgentID),
+				user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID),
+			)
+			if err != nil {
+				return nil, "", "", err
+			}
+		}
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
+	}
+	if refreshTokenWriteModel.IdleExpiration.Before(time.Now()) ||
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
 	}
+	if !refreshTokenWriteModel.Confirmation.Confirms(confirmation) {
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ks8ew", "Errors.User.RefreshToken.Invalid")
+	}
 
-	newToken, err := c.idGenerator.Next()
-	if err != nil {
-		return nil, "", "", err
+	newToken := token
+	if rotation != domain.RefreshTokenRotationNever {
+		newToken, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, "", "", err
+		}
 	}
 	newRefreshToken, err = domain.RefreshToken(userID, tokenID, newToken, c.keyAlgorithm)
 	if err != nil {
 		return nil, "", "", err
 	}
-	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenRenewedEvent(ctx, userAgg, tokenID, newToken, idleExpiration), tokenID, newRefreshToken, nil
 }
 
@@ -193,3 +219,19 @@ func (c *Commands) removeRefreshToken(ctx context.Context, userID, orgID, tokenI
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID), refreshTokenWriteModel, nil
 }
+
+func (c *Commands) RefreshTokenReuseNotificationSent(ctx context.Context, orgID, userID, tokenID string) error {
+	if userID == "" || tokenID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk5ow", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Rk6pr", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx,
+		user.NewHumanRefreshTokenReuseNotificationSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), tokenID))
+	return err
+}
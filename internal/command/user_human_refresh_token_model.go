@@ -12,8 +12,15 @@ import (
 type HumanRefreshTokenWriteModel struct {
 	eventstore.WriteModel
 
+	// TokenID identifies the refresh token family, which all rotated values of the refresh token belong to
 	TokenID      string
 	RefreshToken string
+	// supersededTokens are the previous values of the rotated refresh token
+	supersededTokens []string
+	ClientID         string
+	UserAgentID      string
+	// Confirmation is the key the refresh token is bound to
+	Confirmation *domain.TokenConfirmation
 
 	UserState      domain.UserState
 	IdleExpiration time.Time
@@ -58,12 +65,15 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 		case *user.HumanRefreshTokenAddedEvent:
 			wm.TokenID = e.TokenID
 			wm.RefreshToken = e.TokenID
+			wm.ClientID = e.ClientID
+			wm.UserAgentID = e.UserAgentID
+			wm.Confirmation = e.Confirmation
 			wm.IdleExpiration = e.CreationDate().Add(e.IdleExpiration)
 			wm.Expiration = e.CreationDate().Add(e.Expiration)
 			wm.UserState = domain.UserStateActive
 		case *user.HumanRefreshTokenRenewedEvent:
-			if wm.UserState == domain.UserStateActive {
-				wm.RefreshToken = e.RefreshToken
+			if wm.RefreshToken != e.RefreshToken {
+				wm.supersededTokens = append(wm.supersededTokens, wm.RefreshToken)
 			}
 			wm.RefreshToken = e.RefreshToken
 			wm.IdleExpiration = e.CreationDate().Add(e.IdleExpiration)
@@ -78,6 +88,16 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// IsSuperseded checks if the token was a previous value of the rotated refresh token
+func (wm *HumanRefreshTokenWriteModel) IsSuperseded(token string) bool {
+	for _, superseded := range wm.supersededTokens {
+		if superseded == token {
+			return true
+		}
+	}
+	return false
+}
+
 func (wm *HumanRefreshTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -41,6 +42,8 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		authTime              time.Time
 		refreshIdleExpiration time.Duration
 		refreshExpiration     time.Duration
+		rotation              domain.RefreshTokenRotation
+		confirmation          *domain.TokenConfirmation
 	}
 	type res struct {
 		token        *domain.Token
@@ -130,6 +133,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -168,6 +172,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							-1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -287,7 +292,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
 			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
-				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
+				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime, tt.args.rotation, tt.args.confirmation)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -367,6 +372,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -408,6 +414,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPush(
@@ -505,6 +512,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(),
@@ -538,6 +546,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -554,6 +563,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -600,6 +610,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectFilter(
@@ -616,6 +627,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							nil,
 						)),
 					),
 					expectPush(
@@ -748,6 +760,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					authTime,
 					1*time.Hour,
 					10*time.Hour,
+					nil,
 				),
 				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:refreshTokenID:refreshTokenID")),
 			},
@@ -786,6 +799,8 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		orgID          string
 		refreshToken   string
 		idleExpiration time.Duration
+		rotation       domain.RefreshTokenRotation
+		confirmation   *domain.TokenConfirmation
 	}
 	type res struct {
 		event           *user.HumanRefreshTokenRenewedEvent
@@ -859,6 +874,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -897,6 +913,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -930,6 +947,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							nil,
 						)),
 					),
 				),
@@ -955,6 +973,269 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
 			},
 		},
+		{
+			name: "superseded token, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "superseded token with reuse detection, revoked and error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenRenewedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"refreshToken1",
+							1*time.Hour,
+						)),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanRefreshTokenReusedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+								"applicationID",
+								"userAgentID",
+							),
+							user.NewHumanRefreshTokenRemovedEvent(
+								context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+							),
+						),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				rotation:       domain.RefreshTokenRotationReuseDetection,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token renewed without rotation, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							nil,
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				rotation:       domain.RefreshTokenRotationNever,
+			},
+			res: res{
+				event: user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"tokenID",
+					1*time.Hour,
+				),
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+			},
+		},
+		{
+			name: "bound token renewed without confirmation, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							&domain.TokenConfirmation{JKT: "jkt"},
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				rotation:       domain.RefreshTokenRotationNever,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "bound token renewed with other key, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							&domain.TokenConfirmation{JKT: "jkt"},
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				rotation:       domain.RefreshTokenRotationNever,
+				confirmation:   &domain.TokenConfirmation{JKT: "other"},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "bound token renewed with same key, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							&domain.TokenConfirmation{JKT: "jkt"},
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				rotation:       domain.RefreshTokenRotationNever,
+				confirmation:   &domain.TokenConfirmation{JKT: "jkt"},
+			},
+			res: res{
+				event: user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"tokenID",
+					1*time.Hour,
+				),
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -963,7 +1244,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration, tt.args.rotation, tt.args.confirmation)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -978,3 +1259,108 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_RefreshTokenReuseNotificationSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		orgID   string
+		userID  string
+		tokenID string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "tokenID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "orgID",
+				userID: "userID",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "orgID",
+				userID:  "userID",
+				tokenID: "tokenID",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "notification sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanRefreshTokenReuseNotificationSentEvent(context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"tokenID",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "orgID",
+				userID:  "userID",
+				tokenID: "tokenID",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.RefreshTokenReuseNotificationSent(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.tokenID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// HumanAssessLoginRisk evaluates the signals of the risk policy for the login of the auth request
+// and records the decision as event.
+// Every auth request is only assessed once, further calls return the recorded decision.
+func (c *Commands) HumanAssessLoginRisk(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest, policy *domain.RiskPolicy) (mfaRequired bool, err error) {
+	if userID == "" {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk2ta", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk3ub", "Errors.User.Risk.AuthRequestMissing")
+	}
+	if !policy.IsActive() {
+		return false, nil
+	}
+	existing, err := c.loginRiskWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !isUserStateExists(existing.UserState) {
+		return false, caos_errs.ThrowNotFound(nil, "COMMAND-Rk4vc", "Errors.User.NotFound")
+	}
+	if mfaRequired, ok := existing.Assessments[authRequest.ID]; ok {
+		return mfaRequired, nil
+	}
+	signals := existing.Signals(authRequest, policy, time.Now())
+	mfaRequired = len(signals) > 0
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanRiskAssessedEvent(ctx, userAgg, signals, mfaRequired, authRequestDomainToAuthRequestInfo(authRequest)))
+	if err != nil {
+		return false, err
+	}
+	return mfaRequired, nil
+}
+
+func (c *Commands) loginRiskWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLoginRiskWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLoginRiskWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,159 @@
+package command
+
+import (
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanLoginRiskWriteModel struct {
+	eventstore.WriteModel
+
+	UserState domain.UserState
+
+	KnownUserAgentIDs map[string]struct{}
+	KnownIPRanges     map[string]struct{}
+	LastLoginIPRange  string
+	LastLoginDate     time.Time
+	FailedAttempts    uint64
+	// Assessments maps the already assessed auth requests to the recorded decision
+	Assessments map[string]bool
+}
+
+func NewHumanLoginRiskWriteModel(userID, resourceOwner string) *HumanLoginRiskWriteModel {
+	return &HumanLoginRiskWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		KnownUserAgentIDs: make(map[string]struct{}),
+		KnownIPRanges:     make(map[string]struct{}),
+		Assessments:       make(map[string]bool),
+	}
+}
+
+func (wm *HumanLoginRiskWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanPasswordCheckFailedEvent:
+			wm.FailedAttempts++
+		case *user.HumanOTPCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanU2FCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanPasswordlessCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+		case *user.HumanRiskAssessedEvent:
+			if e.AuthRequestInfo != nil {
+				wm.Assessments[e.AuthRequestInfo.ID] = e.MFARequired
+			}
+			if !e.MFARequired {
+				wm.trustLogin(e.AuthRequestInfo, e.CreationDate())
+			}
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+// trustLogin remembers the device and network of a login which either passed a second factor
+// or was assessed without any risk
+func (wm *HumanLoginRiskWriteModel) trustLogin(info *user.AuthRequestInfo, loginDate time.Time) {
+	wm.FailedAttempts = 0
+	if info == nil {
+		return
+	}
+	if info.UserAgentID != "" {
+		wm.KnownUserAgentIDs[info.UserAgentID] = struct{}{}
+	}
+	if info.BrowserInfo == nil {
+		return
+	}
+	if ipRange := ipRangeOf(info.BrowserInfo.RemoteIP); ipRange != "" {
+		wm.KnownIPRanges[ipRange] = struct{}{}
+		wm.LastLoginIPRange = ipRange
+		wm.LastLoginDate = loginDate
+	}
+}
+
+// Signals returns the risk signals of the policy which fire for the auth request
+func (wm *HumanLoginRiskWriteModel) Signals(authRequest *domain.AuthRequest, policy *domain.RiskPolicy, now time.Time) []domain.RiskSignal {
+	signals := make([]domain.RiskSignal, 0)
+	if policy.MFAOnNewDevice {
+		if _, ok := wm.KnownUserAgentIDs[authRequest.AgentID]; !ok {
+			signals = append(signals, domain.RiskSignalNewDevice)
+		}
+	}
+	var ipRange string
+	if authRequest.BrowserInfo != nil {
+		ipRange = ipRangeOf(authRequest.BrowserInfo.RemoteIP)
+	}
+	// a login without a valid client ip can't be located, so its network is never known
+	if policy.MFAOnNewIPRange {
+		if _, ok := wm.KnownIPRanges[ipRange]; !ok {
+			signals = append(signals, domain.RiskSignalNewIPRange)
+		}
+	}
+	if policy.ImpossibleTravelWindow > 0 && ipRange != "" && wm.LastLoginIPRange != "" &&
+		ipRange != wm.LastLoginIPRange && now.Sub(wm.LastLoginDate) < policy.ImpossibleTravelWindow {
+		signals = append(signals, domain.RiskSignalImpossibleTravel)
+	}
+	if policy.FailedAttemptsThreshold > 0 && wm.FailedAttempts >= policy.FailedAttemptsThreshold {
+		signals = append(signals, domain.RiskSignalFailedAttempts)
+	}
+	return signals
+}
+
+func (wm *HumanLoginRiskWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1PasswordCheckFailedType,
+			user.HumanPasswordCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.HumanRiskAssessedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// ipRangeOf returns the network of the ip (/24 for IPv4 and /48 for IPv6),
+// which is used to approximate the location of a login.
+// The ip of the browser info is read with http.RemoteIPFromRequest or http.RemoteIPFromCtx,
+// which only trust the x-forwarded-for header of the configured trusted proxies.
+func ipRangeOf(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return (&net.IPNet{IP: ipv4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
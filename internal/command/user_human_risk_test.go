@@ -0,0 +1,562 @@
+package command
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_HumanAssessLoginRisk(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx         context.Context
+			orgID       string
+			userID      string
+			authRequest *domain.AuthRequest
+			policy      *domain.RiskPolicy
+		}
+	)
+	type res struct {
+		mfaRequired bool
+		err         func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewDevice: true},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy not active, no mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{},
+			},
+			res: res{
+				mfaRequired: false,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewDevice: true},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "new device, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{domain.RiskSignalNewDevice},
+									true,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewDevice: true},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "known device, no mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{},
+									false,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewDevice: true},
+			},
+			res: res{
+				mfaRequired: false,
+			},
+		},
+		{
+			name: "new ip range, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.1")}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{domain.RiskSignalNewIPRange},
+									true,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("192.168.0.1")}},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID", BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP("192.168.0.1")}},
+				policy:      &domain.RiskPolicy{MFAOnNewIPRange: true},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "unknown ip, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.1")}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{domain.RiskSignalNewIPRange},
+									true,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewIPRange: true},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "same ip range, no mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.1")}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{},
+									false,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.2")}},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID", BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.2")}},
+				policy:      &domain.RiskPolicy{MFAOnNewIPRange: true},
+			},
+			res: res{
+				mfaRequired: false,
+			},
+		},
+		{
+			name: "impossible travel, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.1")}},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{domain.RiskSignalImpossibleTravel},
+									true,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID", BrowserInfo: &user.BrowserInfo{RemoteIP: net.ParseIP("192.168.0.1")}},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID", BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP("192.168.0.1")}},
+				policy:      &domain.RiskPolicy{ImpossibleTravelWindow: time.Hour},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "failed attempts since last login, mfa required",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "oldAuthRequestID", UserAgentID: "agentID"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordCheckFailedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanRiskAssessedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									[]domain.RiskSignal{domain.RiskSignalFailedAttempts},
+									true,
+									&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{FailedAttemptsThreshold: 2},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+		{
+			name: "already assessed, recorded decision",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRiskAssessedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								[]domain.RiskSignal{domain.RiskSignalNewDevice},
+								true,
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&user.AuthRequestInfo{ID: "authRequestID", UserAgentID: "agentID"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				orgID:       "org1",
+				userID:      "user1",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "agentID"},
+				policy:      &domain.RiskPolicy{MFAOnNewDevice: true},
+			},
+			res: res{
+				mfaRequired: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.HumanAssessLoginRisk(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.authRequest, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.mfaRequired, got)
+			}
+		})
+	}
+}
+
+func TestIPRangeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   net.IP
+		want string
+	}{
+		{
+			name: "no ip",
+			ip:   nil,
+			want: "",
+		},
+		{
+			name: "ipv4",
+			ip:   net.ParseIP("192.168.10.42"),
+			want: "192.168.10.0/24",
+		},
+		{
+			name: "ipv6",
+			ip:   net.ParseIP("2001:db8:1234:5678::1"),
+			want: "2001:db8:1234::/48",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ipRangeOf(tt.ip))
+		})
+	}
+}
@@ -273,6 +273,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -364,6 +365,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -434,6 +436,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -504,6 +507,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -831,6 +835,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -874,6 +879,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -965,6 +971,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1050,6 +1057,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1157,6 +1165,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1268,6 +1277,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1375,6 +1385,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1477,6 +1488,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1748,6 +1760,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1796,6 +1809,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1812,6 +1826,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1865,6 +1880,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1881,6 +1897,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1934,6 +1951,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1950,6 +1968,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2020,6 +2039,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2036,6 +2056,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2164,6 +2185,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2180,6 +2202,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2276,6 +2299,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2292,6 +2316,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2382,6 +2407,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2398,6 +2424,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2510,6 +2537,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2526,6 +2554,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2633,6 +2662,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2649,6 +2679,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2903,13 +2934,14 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 	}
 	type (
 		args struct {
-			ctx     context.Context
-			agentID string
-			userIDs []string
+			ctx              context.Context
+			agentID          string
+			userIDs          []string
+			sessionClientIDs map[string][]string
 		}
 	)
 	type res struct {
-		want *domain.ObjectDetails
+		want []string
 		err  func(error) bool
 	}
 	tests := []struct {
@@ -2992,6 +3024,7 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 								user.NewHumanSignedOutEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
 									"agent1",
+									nil,
 								),
 							),
 						},
@@ -3003,11 +3036,7 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 				agentID: "agent1",
 				userIDs: []string{"user1"},
 			},
-			res: res{
-				want: &domain.ObjectDetails{
-					ResourceOwner: "org1",
-				},
-			},
+			res: res{},
 		},
 		{
 			name: "human sign out multiple users, ok",
@@ -3052,12 +3081,14 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 								user.NewHumanSignedOutEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
 									"agent1",
+									[]string{"client1"},
 								),
 							),
 							eventFromEventPusher(
 								user.NewHumanSignedOutEvent(context.Background(),
 									&user.NewAggregate("user2", "org1").Aggregate,
 									"agent1",
+									[]string{"client1", "client2"},
 								),
 							),
 						},
@@ -3068,11 +3099,13 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 				ctx:     context.Background(),
 				agentID: "agent1",
 				userIDs: []string{"user1", "user2"},
+				sessionClientIDs: map[string][]string{
+					"user1": {"client1"},
+					"user2": {"client1", "client2"},
+				},
 			},
 			res: res{
-				want: &domain.ObjectDetails{
-					ResourceOwner: "org1",
-				},
+				want: []string{"client1", "client2"},
 			},
 		},
 	}
@@ -3081,7 +3114,120 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			err := r.HumansSignOut(tt.args.ctx, tt.args.agentID, tt.args.userIDs)
+			got, err := r.HumansSignOut(tt.args.ctx, tt.args.agentID, tt.args.userIDs, tt.args.sessionClientIDs)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_BackChannelLogoutSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		orgID    string
+		userID   string
+		agentID  string
+		clientID string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "clientID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     context.Background(),
+				orgID:   "orgID",
+				userID:  "userID",
+				agentID: "agentID",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "orgID",
+				userID:   "userID",
+				agentID:  "agentID",
+				clientID: "clientID",
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "back-channel logout sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanBackChannelLogoutSentEvent(context.Background(),
+								&user.NewAggregate("userID", "orgID").Aggregate,
+								"agentID",
+								"clientID",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:      context.Background(),
+				orgID:    "orgID",
+				userID:   "userID",
+				agentID:  "agentID",
+				clientID: "clientID",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.BackChannelLogoutSent(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.agentID, tt.args.clientID)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -3242,6 +3388,7 @@ func TestAddHumanCommand(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 								),
 							}, nil
 						}).
@@ -3288,6 +3435,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
@@ -3324,7 +3472,7 @@ func TestAddHumanCommand(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			AssertValidation(t, context.Background(), AddHumanCommand(tt.args.a, tt.args.human, tt.args.passwordAlg, tt.args.codeAlg), tt.args.filter, tt.want)
+			AssertValidation(t, context.Background(), AddHumanCommand(tt.args.a, tt.args.human, tt.args.passwordAlg, tt.args.codeAlg, nil), tt.args.filter, tt.want)
 		})
 	}
 }
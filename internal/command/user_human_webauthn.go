@@ -82,7 +82,7 @@ func (c *Commands) HumanAddU2FSetup(ctx context.Context, userID, resourceowner s
 	if err != nil {
 		return nil, err
 	}
-	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, isLoginUI, u2fTokens, domain.AuthenticatorAttachmentUnspecified, domain.UserVerificationRequirementDiscouraged)
+	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, isLoginUI, u2fTokens, domain.AuthenticatorAttachmentUnspecified, domain.UserVerificationRequirementDiscouraged, domain.ResidentKeyRequirementDiscouraged)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +108,7 @@ func (c *Commands) HumanAddPasswordlessSetup(ctx context.Context, userID, resour
 	if err != nil {
 		return nil, err
 	}
-	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, isLoginUI, passwordlessTokens, authenticatorPlatform, domain.UserVerificationRequirementRequired)
+	addWebAuthN, userAgg, webAuthN, err := c.addHumanWebAuthN(ctx, userID, resourceowner, isLoginUI, passwordlessTokens, authenticatorPlatform, domain.UserVerificationRequirementRequired, domain.ResidentKeyRequirementPreferred)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +137,7 @@ func (c *Commands) HumanAddPasswordlessSetupInitCode(ctx context.Context, userID
 	return c.HumanAddPasswordlessSetup(ctx, userID, resourceowner, true, preferredPlatformType)
 }
 
-func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner string, isLoginUI bool, tokens []*domain.WebAuthNToken, authenticatorPlatform domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement) (*HumanWebAuthNWriteModel, *eventstore.Aggregate, *domain.WebAuthNToken, error) {
+func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner string, isLoginUI bool, tokens []*domain.WebAuthNToken, authenticatorPlatform domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, residentKey domain.ResidentKeyRequirement) (*HumanWebAuthNWriteModel, *eventstore.Aggregate, *domain.WebAuthNToken, error) {
 	if userID == "" {
 		return nil, nil, nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3M0od", "Errors.IDMissing")
 	}
@@ -157,7 +157,7 @@ func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner s
 	if accountName == "" {
 		accountName = user.EmailAddress
 	}
-	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, isLoginUI, tokens...)
+	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, residentKey, isLoginUI, tokens...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -401,12 +401,32 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	return err
 }
 
+// BeginDiscoverablePasswordlessLogin starts a passwordless login without a user.
+// The returned challenge has to be stored by the caller and passed to HumanFinishDiscoverablePasswordlessLogin.
+func (c *Commands) BeginDiscoverablePasswordlessLogin(ctx context.Context) (*domain.WebAuthNLogin, error) {
+	return c.webauthnConfig.BeginDiscoverableLogin(ctx, domain.UserVerificationRequirementRequired)
+}
+
 func (c *Commands) HumanFinishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
 	webAuthNLogin, err := c.getHumanPasswordlessLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
 	}
+	return c.finishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, authRequest)
+}
+
+// HumanFinishDiscoverablePasswordlessLogin checks the credential of a login started by BeginDiscoverablePasswordlessLogin.
+// The user has to be resolved from the user handle of the credential by the caller.
+func (c *Commands) HumanFinishDiscoverablePasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthNLogin *domain.WebAuthNLogin, authRequest *domain.AuthRequest) error {
+	if webAuthNLogin == nil || webAuthNLogin.Challenge == "" {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dl2ks", "Errors.User.WebAuthN.NotFound")
+	}
+	login := *webAuthNLogin
+	login.AggregateID = userID
+	return c.finishPasswordlessLogin(ctx, userID, resourceOwner, credentialData, &login, authRequest)
+}
 
+func (c *Commands) finishPasswordlessLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, webAuthNLogin *domain.WebAuthNLogin, authRequest *domain.AuthRequest) error {
 	passwordlessTokens, err := c.getHumanPasswordlessTokens(ctx, userID, resourceOwner)
 	if err != nil {
 		return err
@@ -3,7 +3,10 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/errors"
 )
@@ -47,3 +50,23 @@ func defaultPasswordComplexityPolicy(ctx context.Context, filter preparation.Fil
 	err = policy.Reduce()
 	return &policy.PasswordComplexityPolicyWriteModel, err
 }
+
+// checkPasswordBreached rejects the password if the policy requires it and it was found in the breached password corpora.
+// If the corpora cannot be checked, the password is rejected as well, unless the checker is configured to fail open
+func checkPasswordBreached(ctx context.Context, checker breach.Checker, rejectBreached bool, password string) error {
+	if !rejectBreached || password == "" {
+		return nil
+	}
+	if checker == nil {
+		logging.Warn("password complexity policy rejects breached passwords, but no breached password corpus is configured")
+		return nil
+	}
+	breached, err := checker.IsBreached(ctx, password)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "COMMAND-Wq4pz", "Errors.User.PasswordComplexityPolicy.BreachCheckFailed")
+	}
+	if breached {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Yd2ko", "Errors.User.PasswordComplexityPolicy.Breached")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -57,6 +58,7 @@ func Test_customPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -134,6 +136,7 @@ func Test_defaultPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -202,6 +205,7 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					}, nil
 				},
@@ -253,6 +257,7 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 							),
 						}, nil
 					}).
@@ -298,3 +303,73 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkPasswordBreached(t *testing.T) {
+	type args struct {
+		checker        breach.Checker
+		rejectBreached bool
+		password       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			name: "not rejected by policy",
+			args: args{
+				checker:        &mockBreachChecker{breached: map[string]bool{"password": true}},
+				rejectBreached: false,
+				password:       "password",
+			},
+		},
+		{
+			name: "no checker configured",
+			args: args{
+				checker:        nil,
+				rejectBreached: true,
+				password:       "password",
+			},
+		},
+		{
+			name: "breached",
+			args: args{
+				checker:        &mockBreachChecker{breached: map[string]bool{"password": true}},
+				rejectBreached: true,
+				password:       "password",
+			},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "not breached",
+			args: args{
+				checker:        &mockBreachChecker{breached: map[string]bool{"password": true}},
+				rejectBreached: true,
+				password:       "Tr0ub4dor&3-correct-horse",
+			},
+		},
+		{
+			name: "checker failed",
+			args: args{
+				checker:        &mockBreachChecker{err: errors.ThrowInternal(nil, "id", "Errors.Internal")},
+				rejectBreached: true,
+				password:       "password",
+			},
+			wantErr: errors.IsUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPasswordBreached(context.Background(), tt.args.checker, tt.args.rejectBreached, tt.args.password)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("checkPasswordBreached() unexpected error = %v", err)
+				}
+				return
+			}
+			if !tt.wantErr(err) {
+				t.Errorf("checkPasswordBreached() wrong error = %v", err)
+			}
+		})
+	}
+}
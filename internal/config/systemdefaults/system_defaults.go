@@ -3,6 +3,7 @@ package systemdefaults
 import (
 	"time"
 
+	"github.com/zitadel/zitadel/internal/breach"
 	"github.com/zitadel/zitadel/internal/crypto"
 )
 
@@ -10,28 +11,47 @@ type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
+	Sessions           Sessions
 	Notifications      Notifications
 	KeyConfig          KeyConfig
+	BreachedPasswords  breach.Config
 }
 
 type SecretGenerators struct {
 	PasswordSaltCost   int
 	MachineKeySize     uint32
 	ApplicationKeySize uint32
+	// OTPSMS and OTPEmail are used for the one-time codes of instances,
+	// which were set up before the second factors existed and therefore have no config of the type
+	OTPSMS   crypto.GeneratorConfig
+	OTPEmail crypto.GeneratorConfig
+	// MagicLinkCode is used for the login links of instances set up before magic links existed
+	MagicLinkCode crypto.GeneratorConfig
 }
 
 type MultifactorConfig struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer string
 }
 
+type RecoveryCodesConfig struct {
+	Count     uint
+	Generator crypto.GeneratorConfig
+}
+
 type DomainVerification struct {
 	VerificationGenerator crypto.GeneratorConfig
 }
 
+type Sessions struct {
+	// TokenGenerator creates the tokens of the session API, the expiry is the lifetime of the session
+	TokenGenerator crypto.GeneratorConfig
+}
+
 type Notifications struct {
 	FileSystemPath string
 }
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -11,6 +12,7 @@ import (
 
 type database struct {
 	client    *sql.DB
+	table     string
 	masterKey string
 	encrypt   func(key, masterKey string) (encryptedKey string, err error)
 	decrypt   func(encryptedKey, masterKey string) (key string, err error)
@@ -20,14 +22,28 @@ const (
 	EncryptionKeysTable  = "system.encryption_keys"
 	encryptionKeysIDCol  = "id"
 	encryptionKeysKeyCol = "key"
+
+	// DataEncryptionKeysTable holds the keys of the data (e.g. the personal data of a user),
+	// which are only read one at a time and are not loaded with the encryption keys
+	DataEncryptionKeysTable = "system.data_encryption_keys"
 )
 
 func NewKeyStorage(client *sql.DB, masterKey string) (*database, error) {
+	return newStorage(client, EncryptionKeysTable, masterKey)
+}
+
+// NewDataKeyStorage returns the storage of the data keys
+func NewDataKeyStorage(client *sql.DB, masterKey string) (crypto.DataKeyStorage, error) {
+	return newStorage(client, DataEncryptionKeysTable, masterKey)
+}
+
+func newStorage(client *sql.DB, table, masterKey string) (*database, error) {
 	if err := checkMasterKeyLength(masterKey); err != nil {
 		return nil, err
 	}
 	return &database{
 		client:    client,
+		table:     table,
 		masterKey: masterKey,
 		encrypt:   crypto.EncryptAESString,
 		decrypt:   crypto.DecryptAESString,
@@ -37,7 +53,7 @@ func NewKeyStorage(client *sql.DB, masterKey string) (*database, error) {
 func (d *database) ReadKeys() (crypto.Keys, error) {
 	keys := make(map[string]string)
 	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
-		From(EncryptionKeysTable).
+		From(d.table).
 		ToSql()
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "", "unable to read keys")
@@ -69,7 +85,7 @@ func (d *database) ReadKeys() (crypto.Keys, error) {
 
 func (d *database) ReadKey(id string) (*crypto.Key, error) {
 	stmt, args, err := sq.Select(encryptionKeysKeyCol).
-		From(EncryptionKeysTable).
+		From(d.table).
 		Where(sq.Eq{encryptionKeysIDCol: id}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -83,6 +99,9 @@ func (d *database) ReadKey(id string) (*crypto.Key, error) {
 	var encryptionKey string
 	err = row.Scan(&encryptionKey)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, caos_errs.ThrowNotFound(err, "", "key not found")
+		}
 		return nil, caos_errs.ThrowInternal(err, "", "unable to read key")
 	}
 	key, err := d.decrypt(encryptionKey, d.masterKey)
@@ -96,7 +115,7 @@ func (d *database) ReadKey(id string) (*crypto.Key, error) {
 }
 
 func (d *database) CreateKeys(keys ...*crypto.Key) error {
-	insert := sq.Insert(EncryptionKeysTable).
+	insert := sq.Insert(d.table).
 		Columns(encryptionKeysIDCol, encryptionKeysKeyCol).PlaceholderFormat(sq.Dollar)
 	for _, key := range keys {
 		encryptionKey, err := d.encrypt(key.Value, d.masterKey)
@@ -125,6 +144,21 @@ func (d *database) CreateKeys(keys ...*crypto.Key) error {
 	return nil
 }
 
+func (d *database) DeleteKeys(ids ...string) error {
+	stmt, args, err := sq.Delete(d.table).
+		Where(sq.Eq{encryptionKeysIDCol: ids}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to delete keys")
+	}
+	_, err = d.client.Exec(stmt, args...)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to delete keys")
+	}
+	return nil
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return caos_errs.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
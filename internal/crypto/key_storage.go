@@ -5,3 +5,10 @@ type KeyStorage interface {
 	ReadKey(id string) (*Key, error)
 	CreateKeys(...*Key) error
 }
+
+// DataKeyStorage holds the keys of the data (e.g. personal data), which are looked up one at a time
+type DataKeyStorage interface {
+	ReadKey(id string) (*Key, error)
+	CreateKeys(...*Key) error
+	DeleteKeys(ids ...string) error
+}
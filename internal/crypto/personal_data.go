@@ -0,0 +1,230 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// personalDataField holds the encrypted personal data fields in the payload of an event
+	personalDataField     = "$personalData"
+	personalDataKeyPrefix = "personal_data_"
+
+	// keys are cached, so erased keys might be used by other processes up to this duration
+	personalDataKeyCacheDuration = time.Minute
+	personalDataKeyCacheSize     = 10000
+)
+
+var personalDataMarker = []byte(`"` + personalDataField + `"`)
+
+// PersonalDataCrypto encrypts the personal data of event payloads
+// with a data key per aggregate (e.g. user) held in the data key storage.
+// As soon as the data key is erased the encrypted fields can no longer be read (crypto shredding)
+type PersonalDataCrypto struct {
+	keyStorage DataKeyStorage
+	// encrypt enables the encryption of new events
+	// events which are already encrypted are always decrypted
+	encrypt bool
+
+	keysMutex sync.Mutex
+	keys      map[string]*personalDataKey
+}
+
+type personalDataKey struct {
+	value   string
+	erased  bool
+	expires time.Time
+}
+
+func NewPersonalDataCrypto(keyStorage DataKeyStorage, encrypt bool) *PersonalDataCrypto {
+	return &PersonalDataCrypto{
+		keyStorage: keyStorage,
+		encrypt:    encrypt,
+		keys:       make(map[string]*personalDataKey),
+	}
+}
+
+// EncryptPersonalData moves the fields of the json payload into an encrypted field
+// the data key of the aggregate is created if it does not exist yet
+func (c *PersonalDataCrypto) EncryptPersonalData(instanceID, aggregateID string, fields []string, data []byte) ([]byte, error) {
+	if !c.encrypt || len(data) == 0 || len(fields) == 0 {
+		return data, nil
+	}
+	payload := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ph4ns", "unable to parse event data")
+	}
+	personalData := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		value, ok := payload[field]
+		if !ok {
+			continue
+		}
+		personalData[field] = value
+		delete(payload, field)
+	}
+	if len(personalData) == 0 {
+		return data, nil
+	}
+	key, err := c.encryptionKey(personalDataKeyID(instanceID, aggregateID))
+	if err != nil {
+		return nil, err
+	}
+	plain, err := json.Marshal(personalData)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Mw9gd", "unable to marshal personal data")
+	}
+	encrypted, err := EncryptAES(plain, key)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Sd2bq", "unable to encrypt personal data")
+	}
+	payload[personalDataField], err = json.Marshal(base64.RawStdEncoding.EncodeToString(encrypted))
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ov6tz", "unable to marshal personal data")
+	}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Xk3re", "unable to marshal event data")
+	}
+	return data, nil
+}
+
+// DecryptPersonalData restores the encrypted fields of the json payload
+// if the data key of the aggregate was erased, the fields are omitted
+func (c *PersonalDataCrypto) DecryptPersonalData(instanceID, aggregateID string, data []byte) ([]byte, error) {
+	if !bytes.Contains(data, personalDataMarker) {
+		return data, nil
+	}
+	payload := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Vb8ki", "unable to parse event data")
+	}
+	encoded, ok := payload[personalDataField]
+	if !ok {
+		return data, nil
+	}
+	delete(payload, personalDataField)
+
+	key, erased, err := c.decryptionKey(personalDataKeyID(instanceID, aggregateID))
+	if err != nil {
+		return nil, err
+	}
+	if !erased {
+		if err = decryptPersonalData(payload, encoded, key); err != nil {
+			return nil, err
+		}
+	}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ag5vw", "unable to marshal event data")
+	}
+	return data, nil
+}
+
+func decryptPersonalData(payload map[string]json.RawMessage, encoded json.RawMessage, key string) error {
+	var value string
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Bn7ce", "unable to parse personal data")
+	}
+	encrypted, err := base64.RawStdEncoding.DecodeString(value)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Kr2mh", "unable to parse personal data")
+	}
+	plain, err := DecryptAES(encrypted, key)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Ue1ow", "unable to decrypt personal data")
+	}
+	personalData := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(plain, &personalData); err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Hy6fp", "unable to parse personal data")
+	}
+	for field, value := range personalData {
+		payload[field] = value
+	}
+	return nil
+}
+
+// ErasePersonalData destroys the data key of the aggregate
+// all personal data encrypted with it becomes unreadable
+func (c *PersonalDataCrypto) ErasePersonalData(instanceID, aggregateID string) error {
+	id := personalDataKeyID(instanceID, aggregateID)
+	if err := c.keyStorage.DeleteKeys(id); err != nil {
+		return err
+	}
+	c.cacheKey(id, &personalDataKey{erased: true})
+	return nil
+}
+
+func (c *PersonalDataCrypto) encryptionKey(id string) (string, error) {
+	key, erased, err := c.decryptionKey(id)
+	if err != nil {
+		return "", err
+	}
+	if erased {
+		return c.createKey(id)
+	}
+	return key, nil
+}
+
+func (c *PersonalDataCrypto) createKey(id string) (string, error) {
+	key, err := NewKey(id)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "CRYPT-Rn4du", "unable to create data key")
+	}
+	if err = c.keyStorage.CreateKeys(key); err != nil {
+		// the key might have been created concurrently
+		existing, readErr := c.keyStorage.ReadKey(id)
+		if readErr != nil {
+			return "", err
+		}
+		key = existing
+	}
+	c.cacheKey(id, &personalDataKey{value: key.Value})
+	return key.Value, nil
+}
+
+// decryptionKey returns the key from the cache or the key storage
+// erased is true if the key does not exist (anymore)
+func (c *PersonalDataCrypto) decryptionKey(id string) (key string, erased bool, err error) {
+	c.keysMutex.Lock()
+	cached, ok := c.keys[id]
+	c.keysMutex.Unlock()
+	if ok && cached.expires.After(time.Now()) {
+		return cached.value, cached.erased, nil
+	}
+
+	stored, err := c.keyStorage.ReadKey(id)
+	if errors.IsNotFound(err) {
+		c.cacheKey(id, &personalDataKey{erased: true})
+		return "", true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	c.cacheKey(id, &personalDataKey{value: stored.Value})
+	return stored.Value, false, nil
+}
+
+func (c *PersonalDataCrypto) cacheKey(id string, key *personalDataKey) {
+	key.expires = time.Now().Add(personalDataKeyCacheDuration)
+	c.keysMutex.Lock()
+	defer c.keysMutex.Unlock()
+	if len(c.keys) >= personalDataKeyCacheSize {
+		now := time.Now()
+		for cachedID, cached := range c.keys {
+			if cached.expires.Before(now) {
+				delete(c.keys, cachedID)
+			}
+		}
+	}
+	c.keys[id] = key
+}
+
+func personalDataKeyID(instanceID, aggregateID string) string {
+	return personalDataKeyPrefix + instanceID + "_" + aggregateID
+}
@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type memoryKeyStorage struct {
+	keys Keys
+}
+
+func (s *memoryKeyStorage) ReadKey(id string) (*Key, error) {
+	value, ok := s.keys[id]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "", "key not found")
+	}
+	return &Key{ID: id, Value: value}, nil
+}
+
+func (s *memoryKeyStorage) CreateKeys(keys ...*Key) error {
+	for _, key := range keys {
+		if _, ok := s.keys[key.ID]; ok {
+			return errors.ThrowAlreadyExists(nil, "", "key exists")
+		}
+		s.keys[key.ID] = key.Value
+	}
+	return nil
+}
+
+func (s *memoryKeyStorage) DeleteKeys(ids ...string) error {
+	for _, id := range ids {
+		delete(s.keys, id)
+	}
+	return nil
+}
+
+func TestPersonalDataCrypto(t *testing.T) {
+	plain := []byte(`{"userName":"gigi","firstName":"Gigi","lastName":"Giraffe","email":"gigi@zitadel.ch"}`)
+	fields := []string{"firstName", "lastName", "email", "phone"}
+
+	t.Run("encryption disabled", func(t *testing.T) {
+		c := NewPersonalDataCrypto(&memoryKeyStorage{keys: Keys{}}, false)
+		data, err := c.EncryptPersonalData("instance", "user", fields, plain)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(data, plain) {
+			t.Errorf("expected unchanged data, got %s", data)
+		}
+	})
+	t.Run("no personal data", func(t *testing.T) {
+		storage := &memoryKeyStorage{keys: Keys{}}
+		c := NewPersonalDataCrypto(storage, true)
+		data, err := c.EncryptPersonalData("instance", "user", fields, []byte(`{"userName":"gigi"}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != `{"userName":"gigi"}` || len(storage.keys) != 0 {
+			t.Errorf("expected unchanged data without key, got %s", data)
+		}
+	})
+	t.Run("encrypt, decrypt and erase", func(t *testing.T) {
+		storage := &memoryKeyStorage{keys: Keys{}}
+		c := NewPersonalDataCrypto(storage, true)
+		encrypted, err := c.EncryptPersonalData("instance", "user", fields, plain)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bytes.Contains(encrypted, []byte("Gigi")) || bytes.Contains(encrypted, []byte("gigi@zitadel.ch")) {
+			t.Errorf("personal data not encrypted: %s", encrypted)
+		}
+		if _, ok := storage.keys["personal_data_instance_user"]; !ok {
+			t.Errorf("data key not created: %v", storage.keys)
+		}
+
+		decrypted, err := c.DecryptPersonalData("instance", "user", encrypted)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertJSONEqual(t, plain, decrypted)
+
+		if err = c.ErasePersonalData("instance", "user"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		erased, err := NewPersonalDataCrypto(storage, true).DecryptPersonalData("instance", "user", encrypted)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertJSONEqual(t, []byte(`{"userName":"gigi"}`), erased)
+	})
+	t.Run("plain data is not changed", func(t *testing.T) {
+		c := NewPersonalDataCrypto(&memoryKeyStorage{keys: Keys{}}, true)
+		data, err := c.DecryptPersonalData("instance", "user", plain)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(data, plain) {
+			t.Errorf("expected unchanged data, got %s", data)
+		}
+	})
+}
+
+func assertJSONEqual(t *testing.T, want, got []byte) {
+	t.Helper()
+	var wantMap, gotMap map[string]interface{}
+	if err := json.Unmarshal(want, &wantMap); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got, &gotMap); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wantMap, gotMap) {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// AccessRequest is the request of a user for roles of a project
+// the user grant is created as soon as the request is approved by a project owner
+type AccessRequest struct {
+	es_models.ObjectRoot
+
+	State       AccessRequestState
+	UserID      string
+	ProjectID   string
+	RoleKeys    []string
+	Reason      string
+	UserGrantID string
+}
+
+type AccessRequestState int32
+
+const (
+	AccessRequestStateUnspecified AccessRequestState = iota
+	AccessRequestStatePending
+	AccessRequestStateApproved
+	AccessRequestStateDenied
+
+	accessRequestStateCount
+)
+
+func (s AccessRequestState) Valid() bool {
+	return s > AccessRequestStateUnspecified && s < accessRequestStateCount
+}
+
+func (r *AccessRequest) IsValid() bool {
+	return r.UserID != "" && r.ProjectID != "" && len(r.RoleKeys) > 0
+}
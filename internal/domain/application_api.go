@@ -14,6 +14,7 @@ type APIApp struct {
 	ClientSecret       *crypto.CryptoValue
 	ClientSecretString string
 	AuthMethodType     APIAuthMethodType
+	AppTokenSettings
 
 	State AppState
 }
@@ -34,7 +35,7 @@ const (
 )
 
 func (a *APIApp) IsValid() bool {
-	return a.AppName != ""
+	return a.AppName != "" && a.AppTokenSettings.IsValid()
 }
 
 func (a *APIApp) setClientID(clientID string) {
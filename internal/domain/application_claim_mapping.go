@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ClaimMappingSource int32
+
+const (
+	ClaimMappingSourceUnspecified ClaimMappingSource = iota
+	ClaimMappingSourceProfile
+	ClaimMappingSourceMetadata
+	ClaimMappingSourceOrg
+	ClaimMappingSourceRoles
+	// count is for validation purposes
+	claimMappingSourceCount
+)
+
+func (s ClaimMappingSource) Valid() bool {
+	return s > ClaimMappingSourceUnspecified && s < claimMappingSourceCount
+}
+
+type ClaimMappingType int32
+
+const (
+	// ClaimMappingTypeUnspecified keeps the type of the source value
+	ClaimMappingTypeUnspecified ClaimMappingType = iota
+	ClaimMappingTypeString
+	ClaimMappingTypeNumber
+	ClaimMappingTypeBoolean
+	ClaimMappingTypeStringList
+	ClaimMappingTypeJSON
+	// count is for validation purposes
+	claimMappingTypeCount
+)
+
+func (t ClaimMappingType) Valid() bool {
+	return t >= ClaimMappingTypeUnspecified && t < claimMappingTypeCount
+}
+
+const (
+	ClaimMappingProfileUserID             = "user_id"
+	ClaimMappingProfileUsername           = "username"
+	ClaimMappingProfilePreferredLoginName = "preferred_login_name"
+	ClaimMappingProfileFirstName          = "first_name"
+	ClaimMappingProfileLastName           = "last_name"
+	ClaimMappingProfileNickName           = "nick_name"
+	ClaimMappingProfileDisplayName        = "display_name"
+	ClaimMappingProfileEmail              = "email"
+	ClaimMappingProfileEmailVerified      = "email_verified"
+	ClaimMappingProfilePhone              = "phone"
+	ClaimMappingProfilePhoneVerified      = "phone_verified"
+	ClaimMappingProfilePreferredLanguage  = "preferred_language"
+	ClaimMappingProfileGender             = "gender"
+
+	ClaimMappingOrgID            = "id"
+	ClaimMappingOrgName          = "name"
+	ClaimMappingOrgPrimaryDomain = "primary_domain"
+)
+
+var (
+	claimMappingProfileFields = []string{
+		ClaimMappingProfileUserID,
+		ClaimMappingProfileUsername,
+		ClaimMappingProfilePreferredLoginName,
+		ClaimMappingProfileFirstName,
+		ClaimMappingProfileLastName,
+		ClaimMappingProfileNickName,
+		ClaimMappingProfileDisplayName,
+		ClaimMappingProfileEmail,
+		ClaimMappingProfileEmailVerified,
+		ClaimMappingProfilePhone,
+		ClaimMappingProfilePhoneVerified,
+		ClaimMappingProfilePreferredLanguage,
+		ClaimMappingProfileGender,
+	}
+	claimMappingOrgFields = []string{
+		ClaimMappingOrgID,
+		ClaimMappingOrgName,
+		ClaimMappingOrgPrimaryDomain,
+	}
+	// reservedClaims are set by the protocol itself or the requested scopes and cannot be mapped
+	reservedClaims = []string{
+		"iss", "sub", "aud", "exp", "iat", "nbf", "jti", "azp", "nonce", "auth_time",
+		"acr", "amr", "at_hash", "c_hash", "sid", "client_id", "scope", "active", "token_type", "username", "cnf",
+		"name", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "profile", "picture",
+		"website", "gender", "birthdate", "zoneinfo", "locale", "updated_at",
+		"email", "email_verified", "phone_number", "phone_number_verified", "address",
+	}
+)
+
+// reservedClaimPrefix is used by the claims ZITADEL asserts itself (e.g. roles, metadata and organisation)
+const reservedClaimPrefix = "urn:zitadel:iam:"
+
+// ClaimMapping declares a claim which is asserted into the tokens and userinfo of an application
+// without the need of an action
+type ClaimMapping struct {
+	Source ClaimMappingSource
+	// SourceKey is the profile field, metadata key or org field the value is taken from,
+	// it's not used for roles
+	SourceKey string
+	Claim     string
+	Type      ClaimMappingType
+
+	IDToken     bool
+	AccessToken bool
+	Userinfo    bool
+}
+
+func (m *ClaimMapping) IsValid() bool {
+	if m == nil || !m.Source.Valid() || !m.Type.Valid() || m.Claim == "" || isReservedClaim(m.Claim) {
+		return false
+	}
+	if !m.IDToken && !m.AccessToken && !m.Userinfo {
+		return false
+	}
+	switch m.Source {
+	case ClaimMappingSourceProfile:
+		return containsString(claimMappingProfileFields, m.SourceKey)
+	case ClaimMappingSourceOrg:
+		return containsString(claimMappingOrgFields, m.SourceKey)
+	case ClaimMappingSourceMetadata:
+		return m.SourceKey != ""
+	default:
+		return true
+	}
+}
+
+// ClaimMappingsAreValid checks every mapping and ensures each claim is only mapped once
+func ClaimMappingsAreValid(mappings []*ClaimMapping) bool {
+	claims := make(map[string]struct{}, len(mappings))
+	for _, mapping := range mappings {
+		if !mapping.IsValid() {
+			return false
+		}
+		if _, ok := claims[mapping.Claim]; ok {
+			return false
+		}
+		claims[mapping.Claim] = struct{}{}
+	}
+	return true
+}
+
+// Convert converts the value of the source into the type of the mapping
+func (t ClaimMappingType) Convert(value interface{}) (interface{}, error) {
+	switch t {
+	case ClaimMappingTypeString:
+		if list, ok := value.([]string); ok {
+			return strings.Join(list, ","), nil
+		}
+		return fmt.Sprint(value), nil
+	case ClaimMappingTypeNumber:
+		switch v := value.(type) {
+		case bool:
+			if v {
+				return 1, nil
+			}
+			return 0, nil
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		case int, int32, int64, float32, float64:
+			return v, nil
+		}
+	case ClaimMappingTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(v))
+		}
+	case ClaimMappingTypeStringList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case string:
+			if v == "" {
+				return []string{}, nil
+			}
+			list := strings.Split(v, ",")
+			for i, entry := range list {
+				list[i] = strings.TrimSpace(entry)
+			}
+			return list, nil
+		default:
+			return []string{fmt.Sprint(v)}, nil
+		}
+	case ClaimMappingTypeJSON:
+		if v, ok := value.(string); ok {
+			var parsed interface{}
+			err := json.Unmarshal([]byte(v), &parsed)
+			return parsed, err
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+	return nil, fmt.Errorf("unable to convert %T to claim mapping type %d", value, t)
+}
+
+func isReservedClaim(claim string) bool {
+	return containsString(reservedClaims, claim) || strings.HasPrefix(claim, reservedClaimPrefix)
+}
+
+func containsString(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
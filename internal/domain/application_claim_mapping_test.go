@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimMappingIsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *ClaimMapping
+		result  bool
+	}{
+		{
+			name:    "empty mapping, invalid",
+			mapping: &ClaimMapping{},
+			result:  false,
+		},
+		{
+			name: "profile field, valid",
+			mapping: &ClaimMapping{
+				Source:    ClaimMappingSourceProfile,
+				SourceKey: ClaimMappingProfileEmail,
+				Claim:     "mail",
+				IDToken:   true,
+			},
+			result: true,
+		},
+		{
+			name: "unknown profile field, invalid",
+			mapping: &ClaimMapping{
+				Source:    ClaimMappingSourceProfile,
+				SourceKey: "password",
+				Claim:     "password",
+				IDToken:   true,
+			},
+			result: false,
+		},
+		{
+			name: "metadata without key, invalid",
+			mapping: &ClaimMapping{
+				Source:   ClaimMappingSourceMetadata,
+				Claim:    "department",
+				Userinfo: true,
+			},
+			result: false,
+		},
+		{
+			name: "reserved claim, invalid",
+			mapping: &ClaimMapping{
+				Source:      ClaimMappingSourceOrg,
+				SourceKey:   ClaimMappingOrgID,
+				Claim:       "aud",
+				AccessToken: true,
+			},
+			result: false,
+		},
+		{
+			name: "standard claim, invalid",
+			mapping: &ClaimMapping{
+				Source:    ClaimMappingSourceProfile,
+				SourceKey: ClaimMappingProfileEmail,
+				Claim:     "email",
+				IDToken:   true,
+			},
+			result: false,
+		},
+		{
+			name: "zitadel claim, invalid",
+			mapping: &ClaimMapping{
+				Source:   ClaimMappingSourceRoles,
+				Claim:    "urn:zitadel:iam:org:project:roles",
+				Userinfo: true,
+			},
+			result: false,
+		},
+		{
+			name: "no target, invalid",
+			mapping: &ClaimMapping{
+				Source: ClaimMappingSourceRoles,
+				Claim:  "roles",
+			},
+			result: false,
+		},
+		{
+			name: "roles, valid",
+			mapping: &ClaimMapping{
+				Source:      ClaimMappingSourceRoles,
+				Claim:       "roles",
+				Type:        ClaimMappingTypeStringList,
+				AccessToken: true,
+			},
+			result: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.result, tt.mapping.IsValid())
+		})
+	}
+}
+
+func TestClaimMappingTypeConvert(t *testing.T) {
+	tests := []struct {
+		name        string
+		mappingType ClaimMappingType
+		value       interface{}
+		result      interface{}
+		wantErr     bool
+	}{
+		{
+			name:        "unspecified keeps value",
+			mappingType: ClaimMappingTypeUnspecified,
+			value:       []string{"a", "b"},
+			result:      []string{"a", "b"},
+		},
+		{
+			name:        "list to string",
+			mappingType: ClaimMappingTypeString,
+			value:       []string{"a", "b"},
+			result:      "a,b",
+		},
+		{
+			name:        "string to number",
+			mappingType: ClaimMappingTypeNumber,
+			value:       " 42 ",
+			result:      float64(42),
+		},
+		{
+			name:        "invalid number",
+			mappingType: ClaimMappingTypeNumber,
+			value:       "forty-two",
+			wantErr:     true,
+		},
+		{
+			name:        "string to boolean",
+			mappingType: ClaimMappingTypeBoolean,
+			value:       "true",
+			result:      true,
+		},
+		{
+			name:        "list to boolean",
+			mappingType: ClaimMappingTypeBoolean,
+			value:       []string{"a"},
+			wantErr:     true,
+		},
+		{
+			name:        "string to list",
+			mappingType: ClaimMappingTypeStringList,
+			value:       "a, b",
+			result:      []string{"a", "b"},
+		},
+		{
+			name:        "string to json",
+			mappingType: ClaimMappingTypeJSON,
+			value:       `{"level": 1}`,
+			result:      map[string]interface{}{"level": float64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mappingType.Convert(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, got)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"encoding/json"
+	"net/url"
 	"strings"
 	"time"
 
+	"gopkg.in/square/go-jose.v2"
+
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -45,6 +49,15 @@ type OIDCApp struct {
 	IDTokenUserinfoAssertion bool
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
+	// RequirePushedAuthorizationRequests only allows authorization requests which were pushed (RFC 9126) before
+	RequirePushedAuthorizationRequests bool
+	// JWKS is the JSON web key set of the client, used to verify signed request objects and client assertions
+	JWKS string
+	// BackChannelLogoutURI receives the logout tokens of terminated sessions (OpenID Connect Back-Channel Logout)
+	BackChannelLogoutURI string
+	// FrontChannelLogoutURI is rendered in an iframe of the logout page (OpenID Connect Front-Channel Logout)
+	FrontChannelLogoutURI string
+	AppTokenSettings
 
 	State AppState
 }
@@ -121,7 +134,8 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !JWKSValid(a.JWKS) ||
+		!LogoutURIValid(a.BackChannelLogoutURI) || !LogoutURIValid(a.FrontChannelLogoutURI) || !a.AppTokenSettings.IsValid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -146,6 +160,37 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// JWKSValid checks if the provided JSON web key set only contains valid public keys.
+// An empty set is valid.
+func JWKSValid(jwks string) bool {
+	if jwks == "" {
+		return true
+	}
+	keySet := new(jose.JSONWebKeySet)
+	if err := json.Unmarshal([]byte(jwks), keySet); err != nil || len(keySet.Keys) == 0 {
+		return false
+	}
+	for _, key := range keySet.Keys {
+		if !key.Valid() || !key.IsPublic() {
+			return false
+		}
+	}
+	return true
+}
+
+// LogoutURIValid checks if the provided back- or front-channel logout uri is an absolute http(s) url without fragment.
+// An empty uri is valid.
+func LogoutURIValid(uri string) bool {
+	if uri == "" {
+		return true
+	}
+	logoutURI, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return (logoutURI.Scheme == "https" || logoutURI.Scheme == "http") && logoutURI.Host != "" && logoutURI.Fragment == ""
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
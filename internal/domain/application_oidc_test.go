@@ -1,11 +1,16 @@
 package domain
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"gopkg.in/square/go-jose.v2"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -673,3 +678,101 @@ func TestOIDCOriginAllowList(t *testing.T) {
 		})
 	}
 }
+
+func TestJWKSValid(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet := func(key interface{}) string {
+		jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key, KeyID: "key1", Algorithm: "RS256", Use: "sig"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(jwks)
+	}
+	tests := []struct {
+		name string
+		jwks string
+		want bool
+	}{
+		{
+			name: "empty",
+			jwks: "",
+			want: true,
+		},
+		{
+			name: "no json",
+			jwks: "key",
+			want: false,
+		},
+		{
+			name: "no keys",
+			jwks: `{"keys":[]}`,
+			want: false,
+		},
+		{
+			name: "private key",
+			jwks: keySet(privateKey),
+			want: false,
+		},
+		{
+			name: "public key",
+			jwks: keySet(&privateKey.PublicKey),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JWKSValid(tt.jwks); got != tt.want {
+				t.Errorf("JWKSValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogoutURIValid(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want bool
+	}{
+		{
+			name: "empty",
+			uri:  "",
+			want: true,
+		},
+		{
+			name: "relative",
+			uri:  "/logout",
+			want: false,
+		},
+		{
+			name: "custom scheme",
+			uri:  "app://logout",
+			want: false,
+		},
+		{
+			name: "fragment",
+			uri:  "https://rp.example.com/logout#fragment",
+			want: false,
+		},
+		{
+			name: "https with query",
+			uri:  "https://rp.example.com/logout?tenant=1",
+			want: true,
+		},
+		{
+			name: "http localhost",
+			uri:  "http://localhost:8080/logout",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LogoutURIValid(tt.uri); got != tt.want {
+				t.Errorf("LogoutURIValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
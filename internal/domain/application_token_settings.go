@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefreshTokenRotation defines if the refresh token of an application is replaced when it is used
+type RefreshTokenRotation int32
+
+const (
+	// RefreshTokenRotationAlways issues a new refresh token on every use
+	RefreshTokenRotationAlways RefreshTokenRotation = iota
+	// RefreshTokenRotationReuseDetection issues a new refresh token on every use
+	// and revokes the refresh token family if a superseded refresh token is used again
+	RefreshTokenRotationReuseDetection
+	// RefreshTokenRotationNever keeps the refresh token until it expires or is revoked
+	RefreshTokenRotationNever
+
+	refreshTokenRotationCount
+)
+
+func (r RefreshTokenRotation) Valid() bool {
+	return r >= 0 && r < refreshTokenRotationCount
+}
+
+// AppTokenSettings override the token lifetimes of the instance (OIDC settings) for an application.
+// A zero lifetime uses the lifetime of the instance.
+type AppTokenSettings struct {
+	AccessTokenLifetime        time.Duration
+	IDTokenLifetime            time.Duration
+	RefreshTokenIdleExpiration time.Duration
+	RefreshTokenExpiration     time.Duration
+	RefreshTokenRotation       RefreshTokenRotation
+}
+
+func (s *AppTokenSettings) IsValid() bool {
+	return s.AccessTokenLifetime >= 0 &&
+		s.IDTokenLifetime >= 0 &&
+		s.RefreshTokenIdleExpiration >= 0 &&
+		s.RefreshTokenExpiration >= 0 &&
+		s.RefreshTokenRotation.Valid()
+}
+
+// WithDefaults returns the settings where every lifetime not overridden by the application
+// is replaced by the lifetime of the defaults (e.g. the OIDC settings of the instance)
+func (s AppTokenSettings) WithDefaults(defaults AppTokenSettings) AppTokenSettings {
+	if s.AccessTokenLifetime == 0 {
+		s.AccessTokenLifetime = defaults.AccessTokenLifetime
+	}
+	if s.IDTokenLifetime == 0 {
+		s.IDTokenLifetime = defaults.IDTokenLifetime
+	}
+	if s.RefreshTokenIdleExpiration == 0 {
+		s.RefreshTokenIdleExpiration = defaults.RefreshTokenIdleExpiration
+	}
+	if s.RefreshTokenExpiration == 0 {
+		s.RefreshTokenExpiration = defaults.RefreshTokenExpiration
+	}
+	return s
+}
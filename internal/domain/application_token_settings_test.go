@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppTokenSettings_WithDefaults(t *testing.T) {
+	defaults := AppTokenSettings{
+		AccessTokenLifetime:        12 * time.Hour,
+		IDTokenLifetime:            12 * time.Hour,
+		RefreshTokenIdleExpiration: 720 * time.Hour,
+		RefreshTokenExpiration:     2160 * time.Hour,
+	}
+	tests := []struct {
+		name     string
+		settings AppTokenSettings
+		want     AppTokenSettings
+	}{
+		{
+			name:     "no overrides, defaults",
+			settings: AppTokenSettings{},
+			want:     defaults,
+		},
+		{
+			name: "overrides, app settings",
+			settings: AppTokenSettings{
+				AccessTokenLifetime:    5 * time.Minute,
+				RefreshTokenExpiration: 24 * time.Hour,
+				RefreshTokenRotation:   RefreshTokenRotationReuseDetection,
+			},
+			want: AppTokenSettings{
+				AccessTokenLifetime:        5 * time.Minute,
+				IDTokenLifetime:            12 * time.Hour,
+				RefreshTokenIdleExpiration: 720 * time.Hour,
+				RefreshTokenExpiration:     24 * time.Hour,
+				RefreshTokenRotation:       RefreshTokenRotationReuseDetection,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.settings.WithDefaults(defaults))
+		})
+	}
+}
+
+func TestAppTokenSettings_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings AppTokenSettings
+		want     bool
+	}{
+		{
+			name:     "empty, valid",
+			settings: AppTokenSettings{},
+			want:     true,
+		},
+		{
+			name:     "negative lifetime, invalid",
+			settings: AppTokenSettings{IDTokenLifetime: -time.Minute},
+			want:     false,
+		},
+		{
+			name:     "unknown rotation, invalid",
+			settings: AppTokenSettings{RefreshTokenRotation: refreshTokenRotationCount},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.settings.IsValid())
+		})
+	}
+}
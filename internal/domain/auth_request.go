@@ -41,6 +41,8 @@ type AuthRequest struct {
 	ApplicationResourceOwner string
 	PrivateLabelingSetting   PrivateLabelingSetting
 	SelectedIDPConfigID      string
+	SessionID                string
+	DiscoverableLogin        *WebAuthNLogin
 	LinkingUsers             []*ExternalUser
 	PossibleSteps            []NextStep
 	PasswordVerified         bool
@@ -53,6 +55,7 @@ type AuthRequest struct {
 	LabelPolicy              *LabelPolicy
 	PrivacyPolicy            *PrivacyPolicy
 	LockoutPolicy            *LockoutPolicy
+	RiskPolicy               *RiskPolicy
 	DefaultTranslations      []*CustomText
 	OrgTranslations          []*CustomText
 }
@@ -105,6 +108,10 @@ const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypeOTPSMS
+	MFATypeOTPEmail
+	// MFATypeRecoveryCode is no provider of its own, but can be used instead of any second factor the user has set up
+	MFATypeRecoveryCode
 )
 
 type MFALevel int
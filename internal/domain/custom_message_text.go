@@ -13,6 +13,11 @@ const (
 	VerifyPhoneMessageType              = "VerifyPhone"
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
+	RefreshTokenReusedMessageType       = "RefreshTokenReused"
+	VerifySMSOTPMessageType             = "VerifySMSOTP"
+	VerifyEmailOTPMessageType           = "VerifyEmailOTP"
+	RecoveryCodeUsedMessageType         = "RecoveryCodeUsed"
+	MagicLinkMessageType                = "MagicLink"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -29,6 +34,11 @@ type MessageTexts struct {
 	VerifyPhone              CustomMessageText
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
+	RefreshTokenReused       CustomMessageText
+	VerifySMSOTP             CustomMessageText
+	VerifyEmailOTP           CustomMessageText
+	RecoveryCodeUsed         CustomMessageText
+	MagicLink                CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -65,6 +75,16 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.DomainClaimed
 	case PasswordlessRegistrationMessageType:
 		return &m.PasswordlessRegistration
+	case RefreshTokenReusedMessageType:
+		return &m.RefreshTokenReused
+	case VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
+	case RecoveryCodeUsedMessageType:
+		return &m.RecoveryCodeUsed
+	case MagicLinkMessageType:
+		return &m.MagicLink
 	}
 	return nil
 }
@@ -75,5 +95,10 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailMessageType ||
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
-		textType == PasswordlessRegistrationMessageType
+		textType == PasswordlessRegistrationMessageType ||
+		textType == RefreshTokenReusedMessageType ||
+		textType == VerifySMSOTPMessageType ||
+		textType == VerifyEmailOTPMessageType ||
+		textType == RecoveryCodeUsedMessageType ||
+		textType == MagicLinkMessageType
 }
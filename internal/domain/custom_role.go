@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// custom role keys must start with the prefix of the members they can be assigned to (IAM_ or ORG_)
+var customRoleKeyRegex = regexp.MustCompile(`^(IAM|ORG)_[A-Z0-9_]{1,190}$`)
+
+type CustomRole struct {
+	models.ObjectRoot
+
+	Key         string
+	DisplayName string
+	Permissions []string
+}
+
+type CustomRoleState int32
+
+const (
+	CustomRoleStateUnspecified CustomRoleState = iota
+	CustomRoleStateActive
+	CustomRoleStateRemoved
+)
+
+func (r *CustomRole) IsValid() bool {
+	return customRoleKeyRegex.MatchString(r.Key) && len(r.Permissions) > 0
+}
+
+// RolePrefix returns the prefix of the member roles the custom role belongs to
+func (r *CustomRole) RolePrefix() string {
+	if strings.HasPrefix(r.Key, OrgRolePrefix) {
+		return OrgRolePrefix
+	}
+	return IAMRolePrefix
+}
+
+// CheckForInvalidPermissions returns the permissions which are not granted by any of the roles with the prefix
+// custom roles can therefore never grant more than the static roles of the same kind
+func CheckForInvalidPermissions(permissions []string, rolePrefix string, validRoles []authz.RoleMapping) []string {
+	invalidPermissions := make([]string, 0)
+	for _, permission := range permissions {
+		if !containsPermission(permission, rolePrefix, validRoles) {
+			invalidPermissions = append(invalidPermissions, permission)
+		}
+	}
+	return invalidPermissions
+}
+
+func containsPermission(permission, rolePrefix string, validRoles []authz.RoleMapping) bool {
+	for _, validRole := range validRoles {
+		if !strings.HasPrefix(validRole.Role, rolePrefix) {
+			continue
+		}
+		for _, validPermission := range validRole.Permissions {
+			if permission == validPermission {
+				return true
+			}
+		}
+	}
+	return false
+}
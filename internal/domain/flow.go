@@ -20,6 +20,7 @@ const (
 	FlowTypeUnspecified FlowType = iota
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
+	FlowTypeSecurityEvent
 	flowTypeCount
 )
 
@@ -49,6 +50,10 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePreUserinfoCreation,
 			TriggerTypePreAccessTokenCreation,
 		}
+	case FlowTypeSecurityEvent:
+		return []TriggerType{
+			TriggerTypeRefreshTokenReused,
+		}
 	default:
 		return nil
 	}
@@ -71,6 +76,8 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.ExternalAuthentication"
 	case FlowTypeCustomiseToken:
 		return "Action.Flow.Type.CustomiseToken"
+	case FlowTypeSecurityEvent:
+		return "Action.Flow.Type.SecurityEvent"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -85,6 +92,7 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypeRefreshTokenReused
 	triggerTypeCount
 )
 
@@ -115,6 +123,8 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypeRefreshTokenReused:
+		return "Action.TriggerType.RefreshTokenReused"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// Group bundles users of an organisation
+// roles granted to a group are inherited by all of its members
+type Group struct {
+	es_models.ObjectRoot
+
+	State       GroupState
+	Name        string
+	Description string
+}
+
+func (g *Group) IsValid() bool {
+	return g.Name != ""
+}
+
+type GroupState int32
+
+const (
+	GroupStateUnspecified GroupState = iota
+	GroupStateActive
+	GroupStateRemoved
+
+	groupStateCount
+)
+
+func (s GroupState) Valid() bool {
+	return s > GroupStateUnspecified && s < groupStateCount
+}
+
+func (s GroupState) Exists() bool {
+	return s != GroupStateUnspecified && s != GroupStateRemoved
+}
+
+// GroupGrant grants roles of a project to all members of the group
+// the AggregateID of the ObjectRoot is the id of the group
+type GroupGrant struct {
+	es_models.ObjectRoot
+
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func (g *GroupGrant) IsValid() bool {
+	return g.AggregateID != "" && g.ProjectID != "" && len(g.RoleKeys) > 0
+}
+
+func (g *GroupGrant) HasInvalidRoles(validRoles []string) bool {
+	for _, roleKey := range g.RoleKeys {
+		if !containsRoleKey(roleKey, validRoles) {
+			return true
+		}
+	}
+	return false
+}
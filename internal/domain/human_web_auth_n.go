@@ -43,6 +43,17 @@ const (
 	UserVerificationRequirementDiscouraged
 )
 
+// ResidentKeyRequirement is the requirement of a discoverable credential (passkey),
+// which is stored on the authenticator and can be used without entering the login name
+type ResidentKeyRequirement int32
+
+const (
+	ResidentKeyRequirementUnspecified ResidentKeyRequirement = iota
+	ResidentKeyRequirementDiscouraged
+	ResidentKeyRequirementPreferred
+	ResidentKeyRequirementRequired
+)
+
 type AuthenticatorAttachment int32
 
 const (
@@ -9,6 +9,7 @@ type Member struct {
 
 	UserID string
 	Roles  []string
+	Validity
 }
 
 func NewMember(aggregateID, userID string, roles ...string) *Member {
@@ -22,7 +23,7 @@ func NewMember(aggregateID, userID string, roles ...string) *Member {
 }
 
 func (i *Member) IsValid() bool {
-	return i.AggregateID != "" && i.UserID != "" && len(i.Roles) != 0
+	return i.AggregateID != "" && i.UserID != "" && len(i.Roles) != 0 && i.Validity.IsValid()
 }
 
 func (i *Member) IsIAMValid() bool {
@@ -18,10 +18,16 @@ func (f MFAState) Valid() bool {
 }
 
 type MultifactorConfigs struct {
-	OTP OTPConfig
+	OTP           OTPConfig
+	RecoveryCodes RecoveryCodesConfig
 }
 
 type OTPConfig struct {
 	Issuer    string
 	CryptoMFA crypto.EncryptionAlgorithm
 }
+
+type RecoveryCodesConfig struct {
+	Count     int
+	Generator crypto.Generator
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// CaptchaPolicy configures when the login requires the user to solve a captcha
+type CaptchaPolicy struct {
+	models.ObjectRoot
+
+	Default      bool
+	ProviderType CaptchaProviderType
+	// VerifyURL overrides the verify endpoint of the provider, e.g. for a self hosted compatible service
+	// it can only be set on the default policy of the instance
+	VerifyURL    string
+	SiteKey      string
+	Secret       *crypto.CryptoValue
+	SecretString string
+
+	RequireOnRegistration  bool
+	RequireOnPasswordReset bool
+	// FailedLoginsThreshold is the amount of failed password checks of a user
+	// after which the captcha is required on the password check, 0 disables it
+	FailedLoginsThreshold uint64
+}
+
+type CaptchaProviderType int32
+
+const (
+	CaptchaProviderTypeUnspecified CaptchaProviderType = iota
+	CaptchaProviderTypeHCaptcha
+	CaptchaProviderTypeReCaptcha
+	CaptchaProviderTypeTurnstile
+
+	captchaProviderTypeCount
+)
+
+func (t CaptchaProviderType) Valid() bool {
+	return t > CaptchaProviderTypeUnspecified && t < captchaProviderTypeCount
+}
+
+// VerifyEndpoint is the default siteverify endpoint of the provider
+func (t CaptchaProviderType) VerifyEndpoint() string {
+	switch t {
+	case CaptchaProviderTypeHCaptcha:
+		return "https://api.hcaptcha.com/siteverify"
+	case CaptchaProviderTypeReCaptcha:
+		return "https://www.google.com/recaptcha/api/siteverify"
+	case CaptchaProviderTypeTurnstile:
+		return "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	default:
+		return ""
+	}
+}
+
+// ScriptURL is the script rendering the widget of the provider
+func (t CaptchaProviderType) ScriptURL() string {
+	switch t {
+	case CaptchaProviderTypeHCaptcha:
+		return "https://js.hcaptcha.com/1/api.js"
+	case CaptchaProviderTypeReCaptcha:
+		return "https://www.google.com/recaptcha/api.js"
+	case CaptchaProviderTypeTurnstile:
+		return "https://challenges.cloudflare.com/turnstile/v0/api.js"
+	default:
+		return ""
+	}
+}
+
+// WidgetClass is the css class of the element the widget is rendered into
+func (t CaptchaProviderType) WidgetClass() string {
+	switch t {
+	case CaptchaProviderTypeHCaptcha:
+		return "h-captcha"
+	case CaptchaProviderTypeReCaptcha:
+		return "g-recaptcha"
+	case CaptchaProviderTypeTurnstile:
+		return "cf-turnstile"
+	default:
+		return ""
+	}
+}
+
+// ResponseField is the form field the widget submits the response token in
+func (t CaptchaProviderType) ResponseField() string {
+	switch t {
+	case CaptchaProviderTypeHCaptcha:
+		return "h-captcha-response"
+	case CaptchaProviderTypeReCaptcha:
+		return "g-recaptcha-response"
+	case CaptchaProviderTypeTurnstile:
+		return "cf-turnstile-response"
+	default:
+		return ""
+	}
+}
+
+func (p *CaptchaPolicy) IsValid() bool {
+	return p.ProviderType.Valid() && p.SiteKey != "" && CaptchaVerifyURLValid(p.VerifyURL)
+}
+
+// CaptchaVerifyURLValid checks if the verify url is empty or an absolute https url
+func CaptchaVerifyURLValid(verifyURL string) bool {
+	if verifyURL == "" {
+		return true
+	}
+	u, err := url.Parse(verifyURL)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
@@ -1,12 +1,25 @@
 package domain
 
 import (
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
+// MaxPasswordHistoryCount limits the previous passwords kept and compared on a password change
+const MaxPasswordHistoryCount = 24
+
 type PasswordAgePolicy struct {
 	models.ObjectRoot
 
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
+	// HistoryCount is the amount of previous passwords which cannot be reused, 0 allows any reuse
+	HistoryCount uint64
+}
+
+func (p *PasswordAgePolicy) IsValid() error {
+	if p.HistoryCount > MaxPasswordHistoryCount {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Hs8kq", "Errors.User.PasswordAgePolicy.HistoryCountNotAllowed")
+	}
+	return nil
 }
@@ -22,6 +22,8 @@ type PasswordComplexityPolicy struct {
 	HasUppercase bool
 	HasNumber    bool
 	HasSymbol    bool
+	// RejectBreached rejects passwords found in the configured breached password corpora
+	RejectBreached bool
 
 	Default bool
 }
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// RiskPolicy configures adaptive authentication:
+// a second factor is only forced (instead of LoginPolicy.ForceMFA) if at least one of the configured risk signals fires,
+// users who set up a second factor are always asked for it
+type RiskPolicy struct {
+	models.ObjectRoot
+
+	Default                 bool
+	MFAOnNewDevice          bool
+	MFAOnNewIPRange         bool
+	ImpossibleTravelWindow  time.Duration
+	FailedAttemptsThreshold uint64
+}
+
+// IsActive returns true if at least one risk signal is configured
+func (p *RiskPolicy) IsActive() bool {
+	return p != nil && (p.MFAOnNewDevice || p.MFAOnNewIPRange || p.ImpossibleTravelWindow > 0 || p.FailedAttemptsThreshold > 0)
+}
+
+type RiskSignal string
+
+const (
+	RiskSignalNewDevice        RiskSignal = "new_device"
+	RiskSignalNewIPRange       RiskSignal = "new_ip_range"
+	RiskSignalImpossibleTravel RiskSignal = "impossible_travel"
+	RiskSignalFailedAttempts   RiskSignal = "failed_attempts"
+)
+
+func (p *RiskPolicy) IsValid() bool {
+	return p.ImpossibleTravelWindow >= 0
+}
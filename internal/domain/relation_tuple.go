@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"regexp"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const (
+	// RelationNamespaceUser is the namespace of the users of ZITADEL
+	RelationNamespaceUser = "user"
+	// RelationNamespaceOrg is the namespace of the organisations of ZITADEL
+	// the relation `member` contains all users of the organisation
+	RelationNamespaceOrg = "org"
+	// RelationNamespaceProject is the namespace of the projects of ZITADEL
+	// the relations are the role keys of the project and contain all users granted with the role,
+	// the relation `member` contains all users granted on the project
+	RelationNamespaceProject = "project"
+	// RelationNamespaceGrant is the namespace of the project grants of ZITADEL
+	// the relations are the role keys of the grant and contain all users granted with the role,
+	// the relation `member` contains all users granted on the project grant
+	RelationNamespaceGrant = "grant"
+
+	RelationMember = "member"
+
+	// RelationMaxDepth limits the depth of nested usersets resolved on check and expand
+	RelationMaxDepth = 16
+)
+
+var (
+	relationNamespaceRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	relationIDRegex        = regexp.MustCompile(`^[^\s#@]{1,200}$`)
+)
+
+// RelationTuple defines the relation of a subject to an object in the form of `object#relation@subject`
+// e.g. `document:readme#viewer@user:123` or `document:readme#viewer@group:developers#member`
+type RelationTuple struct {
+	models.ObjectRoot
+
+	ObjectType string
+	ObjectID   string
+	Relation   string
+	Subject    RelationSubject
+}
+
+// RelationSubject is either a user (`user:123`)
+// or a userset (`group:developers#member`), which includes all subjects having the relation on the object
+type RelationSubject struct {
+	Type     string
+	ID       string
+	Relation string
+}
+
+type RelationTupleState int32
+
+const (
+	RelationTupleStateUnspecified RelationTupleState = iota
+	RelationTupleStateActive
+	RelationTupleStateRemoved
+)
+
+func (t *RelationTuple) IsValid() bool {
+	return relationNamespaceRegex.MatchString(t.ObjectType) &&
+		!IsBuiltInRelationNamespace(t.ObjectType) &&
+		relationIDRegex.MatchString(t.ObjectID) &&
+		relationNamespaceRegex.MatchString(t.Relation) &&
+		t.Subject.IsValid()
+}
+
+func (t *RelationTuple) String() string {
+	return t.ObjectType + ":" + t.ObjectID + "#" + t.Relation + "@" + t.Subject.String()
+}
+
+func (s *RelationSubject) IsValid() bool {
+	if !relationNamespaceRegex.MatchString(s.Type) || !relationIDRegex.MatchString(s.ID) {
+		return false
+	}
+	switch s.Type {
+	case RelationNamespaceUser:
+		return s.Relation == ""
+	case RelationNamespaceOrg:
+		return s.Relation == RelationMember
+	case RelationNamespaceProject, RelationNamespaceGrant:
+		return relationIDRegex.MatchString(s.Relation)
+	default:
+		return relationNamespaceRegex.MatchString(s.Relation)
+	}
+}
+
+// IsUserset returns true if the subject references the subjects of another relation
+func (s *RelationSubject) IsUserset() bool {
+	return s.Relation != ""
+}
+
+func (s *RelationSubject) String() string {
+	if s.Relation == "" {
+		return s.Type + ":" + s.ID
+	}
+	return s.Type + ":" + s.ID + "#" + s.Relation
+}
+
+// IsBuiltInRelationNamespace returns true if the relations of the namespace are derived from the objects of ZITADEL
+// and therefore can't be written
+func IsBuiltInRelationNamespace(namespace string) bool {
+	switch namespace {
+	case RelationNamespaceUser,
+		RelationNamespaceOrg,
+		RelationNamespaceProject,
+		RelationNamespaceGrant:
+		return true
+	}
+	return false
+}
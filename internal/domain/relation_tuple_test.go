@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRelationTupleValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		tuple  *RelationTuple
+		result bool
+	}{
+		{
+			name:   "empty tuple, invalid",
+			tuple:  &RelationTuple{},
+			result: false,
+		},
+		{
+			name: "user subject, valid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: RelationNamespaceUser, ID: "123"},
+			},
+			result: true,
+		},
+		{
+			name: "user subject with relation, invalid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: RelationNamespaceUser, ID: "123", Relation: "member"},
+			},
+			result: false,
+		},
+		{
+			name: "userset subject, valid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: "group", ID: "developers", Relation: "member"},
+			},
+			result: true,
+		},
+		{
+			name: "userset subject without relation, invalid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: "group", ID: "developers"},
+			},
+			result: false,
+		},
+		{
+			name: "org subject with other relation than member, invalid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: RelationNamespaceOrg, ID: "org1", Relation: "owner"},
+			},
+			result: false,
+		},
+		{
+			name: "project role subject, valid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme",
+				Relation:   "editor",
+				Subject:    RelationSubject{Type: RelationNamespaceProject, ID: "project1", Relation: "Document.Writer"},
+			},
+			result: true,
+		},
+		{
+			name: "built-in object namespace, invalid",
+			tuple: &RelationTuple{
+				ObjectType: RelationNamespaceProject,
+				ObjectID:   "project1",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: RelationNamespaceUser, ID: "123"},
+			},
+			result: false,
+		},
+		{
+			name: "object id with separator, invalid",
+			tuple: &RelationTuple{
+				ObjectType: "document",
+				ObjectID:   "readme#owner",
+				Relation:   "viewer",
+				Subject:    RelationSubject{Type: RelationNamespaceUser, ID: "123"},
+			},
+			result: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.tuple.IsValid(); result != tt.result {
+				t.Errorf("got wrong result: expected: %v, actual: %v ", tt.result, result)
+			}
+		})
+	}
+}
+
+func TestRelationTupleString(t *testing.T) {
+	tuple := &RelationTuple{
+		ObjectType: "document",
+		ObjectID:   "readme",
+		Relation:   "viewer",
+		Subject:    RelationSubject{Type: "group", ID: "developers", Relation: "member"},
+	}
+	if s := tuple.String(); s != "document:readme#viewer@group:developers#member" {
+		t.Errorf("unexpected tuple string %s", s)
+	}
+}
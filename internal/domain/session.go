@@ -0,0 +1,34 @@
+package domain
+
+import (
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// Session is a login of a user through the session API,
+// which is used by custom login UIs instead of the login of ZITADEL.
+// The id of the session is used as user agent id for the checks of the user,
+// so the verifications are stored in the user session of the user agent.
+type Session struct {
+	es_models.ObjectRoot
+
+	State             SessionState
+	AuthRequestID     string
+	UserID            string
+	UserResourceOwner string
+	// NextSteps are the steps needed to finish the login
+	// no steps (or a RedirectToCallbackStep for sessions of an auth request) mean the user is authenticated
+	NextSteps []NextStep
+}
+
+type SessionState int32
+
+const (
+	SessionStateUnspecified SessionState = iota
+	SessionStateActive
+	SessionStateTerminated
+)
+
+// UserAgentID is used for the checks of the session
+func (s *Session) UserAgentID() string {
+	return s.AggregateID
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+)
+
+// Throttle is the state of the failed password, OTP and login name checks of an ip, user agent, user or user from an ip.
+// Further checks are delayed progressively and locked temporarily if there are too many failures.
+type Throttle struct {
+	InstanceID   string
+	Kind         ThrottleKind
+	Key          string
+	Failures     uint64
+	LastFailure  time.Time
+	DelayedUntil time.Time
+	LockedUntil  time.Time
+}
+
+type ThrottleKind int32
+
+const (
+	ThrottleKindUnspecified ThrottleKind = iota
+	ThrottleKindIP
+	ThrottleKindUserAgent
+	ThrottleKindUser
+	ThrottleKindUserIP
+)
+
+func (k ThrottleKind) Valid() bool {
+	return k > ThrottleKindUnspecified && k <= ThrottleKindUserIP
+}
+
+// Lockable returns false for users, so nobody is able to lock out a user by failing checks,
+// the checks of a user from a specific ip are locked instead
+func (k ThrottleKind) Lockable() bool {
+	return k != ThrottleKindUser
+}
+
+// IsThrottled returns true if checks are not allowed at the given time
+func (t *Throttle) IsThrottled(now time.Time) bool {
+	return t != nil && (now.Before(t.DelayedUntil) || now.Before(t.LockedUntil))
+}
+
+// RetryAfter is the time when checks are allowed again
+func (t *Throttle) RetryAfter() time.Time {
+	if t.LockedUntil.After(t.DelayedUntil) {
+		return t.LockedUntil
+	}
+	return t.DelayedUntil
+}
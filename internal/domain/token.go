@@ -20,6 +20,34 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	Confirmation      *TokenConfirmation
+}
+
+// TokenConfirmation is the key a token is bound to (RFC 7800),
+// either the key of DPoP proofs (RFC 9449) or the client certificate of a mutual TLS connection (RFC 8705)
+type TokenConfirmation struct {
+	// JKT is the base64url encoded SHA-256 JWK thumbprint of the DPoP key
+	JKT string `json:"jkt,omitempty"`
+	// X5TS256 is the base64url encoded SHA-256 thumbprint of the client certificate
+	X5TS256 string `json:"x5t#S256,omitempty"`
+}
+
+// IsBound returns if the token is sender-constrained
+func (c *TokenConfirmation) IsBound() bool {
+	return c != nil && (c.JKT != "" || c.X5TS256 != "")
+}
+
+// Confirms checks if the presented confirmation proves possession of the key or certificate the token is bound to.
+// Unbound tokens are confirmed by any confirmation.
+func (c *TokenConfirmation) Confirms(presented *TokenConfirmation) bool {
+	if !c.IsBound() {
+		return true
+	}
+	if !presented.IsBound() {
+		return false
+	}
+	return (c.JKT == "" || c.JKT == presented.JKT) &&
+		(c.X5TS256 == "" || c.X5TS256 == presented.X5TS256)
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
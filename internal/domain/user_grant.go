@@ -10,6 +10,7 @@ type UserGrant struct {
 	ProjectID      string
 	ProjectGrantID string
 	RoleKeys       []string
+	Validity
 }
 
 type UserGrantState int32
@@ -22,7 +23,7 @@ const (
 )
 
 func (u *UserGrant) IsValid() bool {
-	return u.ProjectID != "" && u.UserID != ""
+	return u.ProjectID != "" && u.UserID != "" && u.Validity.IsValid()
 }
 
 func (g *UserGrant) HasInvalidRoles(validRoles []string) bool {
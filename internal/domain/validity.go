@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Validity restricts the period in which a user grant or a membership is effective
+// a zero ValidFrom or ValidUntil leaves the period open on that side
+type Validity struct {
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+func (v Validity) IsZero() bool {
+	return v.ValidFrom.IsZero() && v.ValidUntil.IsZero()
+}
+
+// IsValid returns false if the period ends before it starts
+func (v Validity) IsValid() bool {
+	return v.ValidUntil.IsZero() || v.ValidUntil.After(v.ValidFrom)
+}
+
+// NotYetValid returns true if the period starts after the given point in time
+func (v Validity) NotYetValid(now time.Time) bool {
+	return !v.ValidFrom.IsZero() && now.Before(v.ValidFrom)
+}
+
+// IsExpired returns true if the period ended before or at the given point in time
+func (v Validity) IsExpired(now time.Time) bool {
+	return !v.ValidUntil.IsZero() && !now.Before(v.ValidUntil)
+}
+
+// IsEffective returns true if the given point in time is inside the period
+func (v Validity) IsEffective(now time.Time) bool {
+	return !v.NotYetValid(now) && !v.IsExpired(now)
+}
+
+func (v Validity) Equal(other Validity) bool {
+	return v.ValidFrom.Equal(other.ValidFrom) && v.ValidUntil.Equal(other.ValidUntil)
+}
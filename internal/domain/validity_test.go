@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidity(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	type want struct {
+		valid       bool
+		notYetValid bool
+		expired     bool
+		effective   bool
+	}
+	tests := []struct {
+		name     string
+		validity Validity
+		want     want
+	}{
+		{
+			name:     "unbounded",
+			validity: Validity{},
+			want:     want{valid: true, effective: true},
+		},
+		{
+			name:     "started",
+			validity: Validity{ValidFrom: now.Add(-time.Hour)},
+			want:     want{valid: true, effective: true},
+		},
+		{
+			name:     "not yet started",
+			validity: Validity{ValidFrom: now.Add(time.Hour)},
+			want:     want{valid: true, notYetValid: true},
+		},
+		{
+			name:     "ends in future",
+			validity: Validity{ValidUntil: now.Add(time.Hour)},
+			want:     want{valid: true, effective: true},
+		},
+		{
+			name:     "ends now",
+			validity: Validity{ValidUntil: now},
+			want:     want{valid: true, expired: true},
+		},
+		{
+			name:     "ends before start",
+			validity: Validity{ValidFrom: now, ValidUntil: now.Add(-time.Hour)},
+			want:     want{valid: false, expired: true},
+		},
+		{
+			name:     "ends at start",
+			validity: Validity{ValidFrom: now.Add(time.Hour), ValidUntil: now.Add(time.Hour)},
+			want:     want{valid: false, notYetValid: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validity.IsValid(); got != tt.want.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want.valid)
+			}
+			if got := tt.validity.NotYetValid(now); got != tt.want.notYetValid {
+				t.Errorf("NotYetValid() = %v, want %v", got, tt.want.notYetValid)
+			}
+			if got := tt.validity.IsExpired(now); got != tt.want.expired {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want.expired)
+			}
+			if got := tt.validity.IsEffective(now); got != tt.want.effective {
+				t.Errorf("IsEffective() = %v, want %v", got, tt.want.effective)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	es_sql "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	// eventsToArchive selects all events of the aggregates of the types in $3 which were removed (by an event type in $4)
+	// and have no event newer than the cutoff ($2)
+	eventsToArchive = "SELECT e.id, e.event_sequence, e.previous_aggregate_sequence, e.previous_aggregate_type_sequence," +
+		" e.creation_date, e.event_type, e.event_data, e.editor_service, e.editor_user, e.aggregate_version," +
+		" e.aggregate_id, e.aggregate_type, e.resource_owner, e.instance_id" +
+		" FROM eventstore.events e" +
+		" WHERE e.instance_id = $1" +
+		" AND e.creation_date < $2" +
+		" AND e.aggregate_type = ANY($3)" +
+		" AND EXISTS (" + removedEventOfAggregate + ")" +
+		" AND NOT EXISTS (" + newerEventOfAggregate + ")" +
+		" ORDER BY e.event_sequence"
+
+	removedEventOfAggregate = "SELECT 1 FROM eventstore.events r" +
+		" WHERE r.instance_id = e.instance_id" +
+		" AND r.aggregate_type = e.aggregate_type" +
+		" AND r.aggregate_id = e.aggregate_id" +
+		" AND r.event_type = ANY($4)"
+
+	// deleteArchivedEvents removes the archived events ($3)
+	// it checks again that no event was added to the aggregates in the meantime
+	deleteArchivedEvents = "DELETE FROM eventstore.events e" +
+		" WHERE e.instance_id = $1" +
+		" AND e.event_sequence = ANY($3)" +
+		" AND NOT EXISTS (" + newerEventOfAggregate + ")"
+
+	newerEventOfAggregate = "SELECT 1 FROM eventstore.events n" +
+		" WHERE n.instance_id = e.instance_id" +
+		" AND n.aggregate_type = e.aggregate_type" +
+		" AND n.aggregate_id = e.aggregate_id" +
+		" AND n.creation_date >= $2"
+
+	// insertArchivedEvent inserts an event with its original sequences
+	// events which are already online are skipped, this makes imports idempotent
+	insertArchivedEvent = "INSERT INTO eventstore.events (" +
+		"id, event_sequence, previous_aggregate_sequence, previous_aggregate_type_sequence," +
+		" creation_date, event_type, event_data, editor_service, editor_user, aggregate_version," +
+		" aggregate_id, aggregate_type, resource_owner, instance_id" +
+		") VALUES ($1, $2, $3, $4, $5, $6, $7::JSONB, $8, $9, $10, $11, $12, $13, $14)" +
+		" ON CONFLICT DO NOTHING"
+
+	importBatchSize = 500
+)
+
+// archivableAggregateTypes are the aggregate types which can be archived and the event types removing them.
+// Only removed aggregates are archived, as their unique constraints were released on the removal
+// and no other aggregate depends on them. Aggregates like instances, orgs and projects are never archived.
+var archivableAggregateTypes = map[string][]eventstore.EventType{
+	user.AggregateType:      {user.UserRemovedType},
+	usergrant.AggregateType: {usergrant.UserGrantRemovedType, usergrant.UserGrantCascadeRemovedType},
+}
+
+// Archiver moves the events of removed aggregates which didn't change since a cutoff
+// from the eventstore into archive files and back
+//
+// only complete aggregates are archived, so projections and write models
+// of the aggregates which remain in the eventstore are not affected.
+// Archived aggregates are missing if a projection is rebuilt until they are imported again.
+type Archiver struct {
+	client  *sql.DB
+	storage Storage
+	now     func() time.Time
+}
+
+func NewArchiver(client *sql.DB, storage Storage) *Archiver {
+	return &Archiver{
+		client:  client,
+		storage: storage,
+		now:     time.Now,
+	}
+}
+
+// event is the representation of an archived event in the archive file
+type event struct {
+	ID                            string          `json:"id"`
+	Sequence                      uint64          `json:"sequence"`
+	PreviousAggregateSequence     uint64          `json:"previousAggregateSequence,omitempty"`
+	PreviousAggregateTypeSequence uint64          `json:"previousAggregateTypeSequence,omitempty"`
+	CreationDate                  time.Time       `json:"creationDate"`
+	Type                          string          `json:"type"`
+	Data                          json.RawMessage `json:"data,omitempty"`
+	EditorService                 string          `json:"editorService"`
+	EditorUser                    string          `json:"editorUser"`
+	Version                       string          `json:"version"`
+	AggregateID                   string          `json:"aggregateId"`
+	AggregateType                 string          `json:"aggregateType"`
+	ResourceOwner                 string          `json:"resourceOwner"`
+	InstanceID                    string          `json:"instanceId"`
+}
+
+// Export writes all events of the removed aggregates of the instance older than the cutoff into a gzip compressed JSONL file
+// and removes them from the eventstore after the archive and its manifest are stored.
+// If aggregate types are passed only aggregates of these types are archived, otherwise all archivableAggregateTypes.
+// The returned manifest has no name if there was nothing to archive.
+func (a *Archiver) Export(ctx context.Context, instanceID string, cutoff time.Time, aggregateTypes ...string) (_ *Manifest, err error) {
+	if instanceID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ARCHI-Nw5ql", "instance id is missing")
+	}
+	if !cutoff.Before(a.now()) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ARCHI-Kd2xu", "cutoff must be in the past")
+	}
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = defaultAggregateTypes()
+	}
+	removedEventTypes, err := removedEventTypes(aggregateTypes)
+	if err != nil {
+		return nil, err
+	}
+	manifest := newManifest(instanceID, aggregateTypes, cutoff, a.now())
+
+	file, err := os.CreateTemp("", "zitadel-archive-*")
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Oe7qm", "unable to create temporary file")
+	}
+	defer func() {
+		file.Close()
+		logging.OnError(os.Remove(file.Name())).Warn("unable to remove temporary archive file")
+	}()
+
+	checksum := sha256.New()
+	compressor := gzip.NewWriter(io.MultiWriter(file, checksum))
+	sequences, err := a.writeEvents(ctx, compressor, manifest, removedEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	if err = compressor.Close(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Ab4wr", "unable to write archive file")
+	}
+	if manifest.EventCount == 0 {
+		return manifest, nil
+	}
+	manifest.Checksum = hex.EncodeToString(checksum.Sum(nil))
+	manifest.setName()
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Sm6yt", "unable to read archive file")
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Ip1cn", "unable to read archive file")
+	}
+	manifest.Location, err = a.storage.Put(ctx, instanceID, manifest.File, file, size)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Rf3jz", "unable to marshal manifest")
+	}
+	if _, err = a.storage.Put(ctx, instanceID, manifest.fileName(), bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, err
+	}
+
+	return manifest, a.deleteEvents(ctx, instanceID, cutoff, sequences)
+}
+
+func defaultAggregateTypes() []string {
+	aggregateTypes := make([]string, 0, len(archivableAggregateTypes))
+	for aggregateType := range archivableAggregateTypes {
+		aggregateTypes = append(aggregateTypes, aggregateType)
+	}
+	sort.Strings(aggregateTypes)
+	return aggregateTypes
+}
+
+// removedEventTypes returns the event types removing the aggregates of the types
+func removedEventTypes(aggregateTypes []string) ([]string, error) {
+	eventTypes := make([]string, 0, len(archivableAggregateTypes))
+	for _, aggregateType := range aggregateTypes {
+		removedTypes, ok := archivableAggregateTypes[aggregateType]
+		if !ok {
+			return nil, caos_errs.ThrowInvalidArgumentf(nil, "ARCHI-Ap4se", "aggregates of type %s can't be archived", aggregateType)
+		}
+		for _, eventType := range removedTypes {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+	}
+	return eventTypes, nil
+}
+
+func (a *Archiver) writeEvents(ctx context.Context, w io.Writer, manifest *Manifest, removedEventTypes []string) ([]int64, error) {
+	rows, err := a.client.QueryContext(ctx, eventsToArchive,
+		manifest.InstanceID, manifest.Cutoff, pq.Array(manifest.AggregateTypes), pq.Array(removedEventTypes))
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Cz8ha", "unable to query events")
+	}
+	defer rows.Close()
+
+	encoder := json.NewEncoder(w)
+	sequences := make([]int64, 0)
+	for rows.Next() {
+		var (
+			e                             event
+			previousAggregateSequence     es_sql.Sequence
+			previousAggregateTypeSequence es_sql.Sequence
+			data                          es_sql.Data
+		)
+		err = rows.Scan(
+			&e.ID,
+			&e.Sequence,
+			&previousAggregateSequence,
+			&previousAggregateTypeSequence,
+			&e.CreationDate,
+			&e.Type,
+			&data,
+			&e.EditorService,
+			&e.EditorUser,
+			&e.Version,
+			&e.AggregateID,
+			&e.AggregateType,
+			&e.ResourceOwner,
+			&e.InstanceID,
+		)
+		if err != nil {
+			return nil, caos_errs.ThrowInternal(err, "ARCHI-Pu2mv", "unable to scan event")
+		}
+		e.PreviousAggregateSequence = uint64(previousAggregateSequence)
+		e.PreviousAggregateTypeSequence = uint64(previousAggregateTypeSequence)
+		e.Data = json.RawMessage(data)
+		if err = encoder.Encode(&e); err != nil {
+			return nil, caos_errs.ThrowInternal(err, "ARCHI-Ey9gb", "unable to write event")
+		}
+
+		if manifest.EventCount == 0 {
+			manifest.MinSequence = e.Sequence
+		}
+		manifest.MaxSequence = e.Sequence
+		manifest.EventCount++
+		sequences = append(sequences, int64(e.Sequence))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Dk5ow", "unable to query events")
+	}
+	return sequences, nil
+}
+
+func (a *Archiver) deleteEvents(ctx context.Context, instanceID string, cutoff time.Time, sequences []int64) error {
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "ARCHI-Gn1ct", "unable to begin transaction")
+	}
+	res, err := tx.ExecContext(ctx, deleteArchivedEvents, instanceID, cutoff, pq.Array(sequences))
+	if err != nil {
+		logging.OnError(tx.Rollback()).Debug("rollback failed")
+		return caos_errs.ThrowInternal(err, "ARCHI-Mb6sl", "unable to remove archived events")
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected != int64(len(sequences)) {
+		logging.OnError(tx.Rollback()).Debug("rollback failed")
+		return caos_errs.ThrowPreconditionFailed(err, "ARCHI-Yv4ha", "aggregates changed during the export, archived events are kept")
+	}
+	if err = tx.Commit(); err != nil {
+		return caos_errs.ThrowInternal(err, "ARCHI-Jx7pe", "unable to remove archived events")
+	}
+	return nil
+}
+
+// Import reads the archive of the manifest and inserts its events into the eventstore again.
+// The checksum of the archive is verified before any event is inserted.
+// Events which are already in the eventstore are skipped, it returns the amount of inserted events.
+func (a *Archiver) Import(ctx context.Context, instanceID, name string) (_ *Manifest, imported uint64, err error) {
+	manifest, err := a.readManifest(ctx, instanceID, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	object, err := a.storage.Get(ctx, instanceID, manifest.File)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer object.Close()
+
+	file, err := os.CreateTemp("", "zitadel-archive-*")
+	if err != nil {
+		return nil, 0, caos_errs.ThrowInternal(err, "ARCHI-Wc2ri", "unable to create temporary file")
+	}
+	defer func() {
+		file.Close()
+		logging.OnError(os.Remove(file.Name())).Warn("unable to remove temporary archive file")
+	}()
+
+	checksum := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(file, checksum), object); err != nil {
+		return nil, 0, caos_errs.ThrowInternal(err, "ARCHI-Qo3bx", "unable to read archive file")
+	}
+	if hex.EncodeToString(checksum.Sum(nil)) != manifest.Checksum {
+		return nil, 0, caos_errs.ThrowPreconditionFailed(nil, "ARCHI-Zb8nu", "checksum of archive file does not match the manifest")
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, caos_errs.ThrowInternal(err, "ARCHI-Tf6kw", "unable to read archive file")
+	}
+	imported, err = a.insertEvents(ctx, manifest, file)
+	return manifest, imported, err
+}
+
+func (a *Archiver) readManifest(ctx context.Context, instanceID, name string) (*Manifest, error) {
+	object, err := a.storage.Get(ctx, instanceID, ManifestFileName(name))
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Lr9vi", "unable to read manifest")
+	}
+	manifest, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, manifest.validate(instanceID)
+}
+
+func (a *Archiver) insertEvents(ctx context.Context, manifest *Manifest, r io.Reader) (imported uint64, err error) {
+	decompressor, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, caos_errs.ThrowInternal(err, "ARCHI-Uk4ns", "unable to read archive file")
+	}
+	defer decompressor.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(decompressor))
+	events := make([]*event, 0, importBatchSize)
+	var count uint64
+	for decoder.More() {
+		e := new(event)
+		if err = decoder.Decode(e); err != nil {
+			return imported, caos_errs.ThrowInternal(err, "ARCHI-Hq2dm", "unable to parse event")
+		}
+		if e.InstanceID != manifest.InstanceID {
+			return imported, caos_errs.ThrowPreconditionFailed(nil, "ARCHI-Bv7xo", "event belongs to another instance")
+		}
+		count++
+		events = append(events, e)
+		if len(events) < importBatchSize {
+			continue
+		}
+		inserted, err := a.insertBatch(ctx, events)
+		imported += inserted
+		if err != nil {
+			return imported, err
+		}
+		events = events[:0]
+	}
+	inserted, err := a.insertBatch(ctx, events)
+	imported += inserted
+	if err != nil {
+		return imported, err
+	}
+	if count != manifest.EventCount {
+		return imported, caos_errs.ThrowPreconditionFailedf(nil, "ARCHI-Fe3lu", "archive contains %d events but manifest expects %d", count, manifest.EventCount)
+	}
+	return imported, nil
+}
+
+func (a *Archiver) insertBatch(ctx context.Context, events []*event) (inserted uint64, err error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, caos_errs.ThrowInternal(err, "ARCHI-Cs5nw", "unable to begin transaction")
+	}
+	for _, e := range events {
+		res, err := tx.ExecContext(ctx, insertArchivedEvent,
+			e.ID,
+			e.Sequence,
+			es_sql.Sequence(e.PreviousAggregateSequence),
+			es_sql.Sequence(e.PreviousAggregateTypeSequence),
+			e.CreationDate,
+			e.Type,
+			es_sql.Data(e.Data),
+			e.EditorService,
+			e.EditorUser,
+			e.Version,
+			e.AggregateID,
+			e.AggregateType,
+			e.ResourceOwner,
+			e.InstanceID,
+		)
+		if err != nil {
+			logging.OnError(tx.Rollback()).Debug("rollback failed")
+			return 0, caos_errs.ThrowInternal(err, "ARCHI-Xi8ad", "unable to insert event")
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			logging.OnError(tx.Rollback()).Debug("rollback failed")
+			return 0, caos_errs.ThrowInternal(err, "ARCHI-Rj1yf", "unable to insert event")
+		}
+		inserted += uint64(affected)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, caos_errs.ThrowInternal(err, "ARCHI-Oa6gt", "unable to insert events")
+	}
+	return inserted, nil
+}
@@ -0,0 +1,190 @@
+package archive
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	testNow    = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCutoff = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	eventColumns = []string{
+		"id", "event_sequence", "previous_aggregate_sequence", "previous_aggregate_type_sequence",
+		"creation_date", "event_type", "event_data", "editor_service", "editor_user", "aggregate_version",
+		"aggregate_id", "aggregate_type", "resource_owner", "instance_id",
+	}
+)
+
+func newTestArchiver(t *testing.T) (*Archiver, sqlmock.Sqlmock, string) {
+	t.Helper()
+	client, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	dir := t.TempDir()
+	return &Archiver{
+		client:  client,
+		storage: &directoryStorage{directory: dir},
+		now:     func() time.Time { return testNow },
+	}, mock, dir
+}
+
+func expectEvents(mock sqlmock.Sqlmock, rows [][]driver.Value) {
+	result := sqlmock.NewRows(eventColumns)
+	for _, row := range rows {
+		result.AddRow(row...)
+	}
+	mock.ExpectQuery(eventsToArchive).
+		WithArgs("instance-id", testCutoff, `{"user"}`, `{"user.removed"}`).
+		WillReturnRows(result)
+}
+
+var testEvents = [][]driver.Value{
+	{"id-1", int64(5), nil, nil, testCutoff.Add(-2 * time.Hour), "user.human.added", []byte(`{"userName":"gigi"}`), "svc", "editor", "v2", "user-id", "user", "org-id", "instance-id"},
+	{"id-2", int64(7), int64(5), int64(5), testCutoff.Add(-time.Hour), "user.human.profile.changed", nil, "svc", "editor", "v2", "user-id", "user", "org-id", "instance-id"},
+}
+
+func TestArchiver_Export(t *testing.T) {
+	t.Run("cutoff in future", func(t *testing.T) {
+		a, _, _ := newTestArchiver(t)
+		_, err := a.Export(context.Background(), "instance-id", testNow.Add(time.Hour))
+		if !caos_errs.IsErrorInvalidArgument(err) {
+			t.Errorf("expected invalid argument, got %v", err)
+		}
+	})
+	t.Run("aggregate type not archivable", func(t *testing.T) {
+		a, _, _ := newTestArchiver(t)
+		_, err := a.Export(context.Background(), "instance-id", testCutoff, "user", "org")
+		if !caos_errs.IsErrorInvalidArgument(err) {
+			t.Errorf("expected invalid argument, got %v", err)
+		}
+	})
+	t.Run("nothing to archive", func(t *testing.T) {
+		a, mock, dir := newTestArchiver(t)
+		expectEvents(mock, nil)
+
+		manifest, err := a.Export(context.Background(), "instance-id", testCutoff, "user")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.EventCount != 0 || manifest.Name != "" {
+			t.Errorf("expected empty manifest, got %+v", manifest)
+		}
+		if _, err = os.Stat(filepath.Join(dir, "instance-id")); !os.IsNotExist(err) {
+			t.Errorf("expected no archive directory, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("aggregates changed", func(t *testing.T) {
+		a, mock, _ := newTestArchiver(t)
+		expectEvents(mock, testEvents)
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteArchivedEvents).
+			WithArgs("instance-id", testCutoff, "{5,7}").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		_, err := a.Export(context.Background(), "instance-id", testCutoff, "user")
+		if !caos_errs.IsPreconditionFailed(err) {
+			t.Errorf("expected precondition failed, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("archived", func(t *testing.T) {
+		a, mock, dir := newTestArchiver(t)
+		expectEvents(mock, testEvents)
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteArchivedEvents).
+			WithArgs("instance-id", testCutoff, "{5,7}").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		manifest, err := a.Export(context.Background(), "instance-id", testCutoff, "user")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.Name != "events_5-7" || manifest.EventCount != 2 || manifest.Checksum == "" {
+			t.Errorf("unexpected manifest %+v", manifest)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "instance-id", "events_5-7.manifest.json"))
+		if err != nil {
+			t.Fatalf("manifest not stored: %v", err)
+		}
+		stored := new(Manifest)
+		if err = json.Unmarshal(data, stored); err != nil || stored.Checksum != manifest.Checksum {
+			t.Errorf("unexpected stored manifest %s: %v", data, err)
+		}
+		if _, err = os.Stat(filepath.Join(dir, "instance-id", "events_5-7.jsonl.gz")); err != nil {
+			t.Errorf("archive not stored: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestArchiver_Import(t *testing.T) {
+	a, mock, dir := newTestArchiver(t)
+	expectEvents(mock, testEvents)
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteArchivedEvents).
+		WithArgs("instance-id", testCutoff, "{5,7}").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	if _, err := a.Export(context.Background(), "instance-id", testCutoff, "user"); err != nil {
+		t.Fatalf("unable to prepare archive: %v", err)
+	}
+
+	t.Run("wrong instance", func(t *testing.T) {
+		_, _, err := a.Import(context.Background(), "other-instance", "events_5-7")
+		if !caos_errs.IsNotFound(err) {
+			t.Errorf("expected not found, got %v", err)
+		}
+	})
+	t.Run("imported", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(insertArchivedEvent).
+			WithArgs("id-1", int64(5), nil, nil, testCutoff.Add(-2*time.Hour), "user.human.added", []byte(`{"userName":"gigi"}`), "svc", "editor", "v2", "user-id", "user", "org-id", "instance-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertArchivedEvent).
+			WithArgs("id-2", int64(7), int64(5), int64(5), testCutoff.Add(-time.Hour), "user.human.profile.changed", nil, "svc", "editor", "v2", "user-id", "user", "org-id", "instance-id").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		manifest, imported, err := a.Import(context.Background(), "instance-id", "events_5-7.jsonl.gz")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if imported != 1 || manifest.EventCount != 2 {
+			t.Errorf("expected 1 of 2 events imported, got %d of %d", imported, manifest.EventCount)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("checksum mismatch", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "instance-id", "events_5-7.jsonl.gz"), []byte("tampered"), 0o640)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = a.Import(context.Background(), "instance-id", "events_5-7")
+		if !caos_errs.IsPreconditionFailed(err) {
+			t.Errorf("expected precondition failed, got %v", err)
+		}
+	})
+}
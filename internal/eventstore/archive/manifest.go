@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	manifestVersion = 1
+
+	manifestSuffix = ".manifest.json"
+	eventsSuffix   = ".jsonl.gz"
+)
+
+// Manifest describes an archive file
+// it's stored next to the archive file and is needed to import the events again
+type Manifest struct {
+	Version        int       `json:"version"`
+	Name           string    `json:"name"`
+	File           string    `json:"file"`
+	Location       string    `json:"location"`
+	InstanceID     string    `json:"instanceId"`
+	AggregateTypes []string  `json:"aggregateTypes,omitempty"`
+	Cutoff         time.Time `json:"cutoff"`
+	CreationDate   time.Time `json:"creationDate"`
+	EventCount     uint64    `json:"eventCount"`
+	MinSequence    uint64    `json:"minSequence"`
+	MaxSequence    uint64    `json:"maxSequence"`
+	// Checksum is the hex encoded sha256 checksum of the compressed archive file
+	Checksum string `json:"checksum"`
+}
+
+func newManifest(instanceID string, aggregateTypes []string, cutoff, creationDate time.Time) *Manifest {
+	return &Manifest{
+		Version:        manifestVersion,
+		InstanceID:     instanceID,
+		AggregateTypes: aggregateTypes,
+		Cutoff:         cutoff,
+		CreationDate:   creationDate,
+	}
+}
+
+func (m *Manifest) setName() {
+	m.Name = fmt.Sprintf("events_%d-%d", m.MinSequence, m.MaxSequence)
+	m.File = m.Name + eventsSuffix
+}
+
+func (m *Manifest) fileName() string {
+	return m.Name + manifestSuffix
+}
+
+func (m *Manifest) validate(instanceID string) error {
+	if m.Version != manifestVersion {
+		return caos_errs.ThrowPreconditionFailedf(nil, "ARCHI-Vx3kd", "manifest version %d not supported", m.Version)
+	}
+	if m.InstanceID != instanceID {
+		return caos_errs.ThrowPreconditionFailed(nil, "ARCHI-Fq8ow", "manifest belongs to another instance")
+	}
+	if m.File == "" || m.Checksum == "" {
+		return caos_errs.ThrowPreconditionFailed(nil, "ARCHI-Hn2pe", "manifest is incomplete")
+	}
+	return nil
+}
+
+func parseManifest(data []byte) (*Manifest, error) {
+	m := new(Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Ue5ma", "unable to parse manifest")
+	}
+	return m, nil
+}
+
+// ManifestFileName returns the file name of the manifest of an archive
+// name can either be the name of the archive or the name of one of its files
+func ManifestFileName(name string) string {
+	name = strings.TrimSuffix(name, manifestSuffix)
+	name = strings.TrimSuffix(name, eventsSuffix)
+	return name + manifestSuffix
+}
@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/static/s3"
+)
+
+const (
+	// archiveResourceOwner is used as path prefix of the archive objects in the bucket of the instance
+	archiveResourceOwner = "archive"
+)
+
+type Config struct {
+	// Directory the archives are written to if no S3 storage is configured
+	Directory string
+	// S3 compatible storage the archives are uploaded to
+	// it's used as soon as an Endpoint is configured
+	S3 *s3.Config
+}
+
+// Storage persists the archive files of an instance
+type Storage interface {
+	Put(ctx context.Context, instanceID, name string, object io.Reader, size int64) (location string, err error)
+	Get(ctx context.Context, instanceID, name string) (io.ReadCloser, error)
+}
+
+func (c *Config) NewStorage() (Storage, error) {
+	if c.S3 != nil && c.S3.Endpoint != "" {
+		storage, err := c.S3.NewStorage()
+		if err != nil {
+			return nil, err
+		}
+		return &staticStorage{storage: storage}, nil
+	}
+	if c.Directory == "" {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ARCHI-Bm3ds", "neither a directory nor a s3 storage is configured")
+	}
+	return &directoryStorage{directory: c.Directory}, nil
+}
+
+type directoryStorage struct {
+	directory string
+}
+
+func (s *directoryStorage) Put(_ context.Context, instanceID, name string, object io.Reader, _ int64) (string, error) {
+	dir := filepath.Join(s.directory, instanceID)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", caos_errs.ThrowInternal(err, "ARCHI-Gk2wq", "unable to create archive directory")
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", caos_errs.ThrowInternal(err, "ARCHI-Wp8dv", "unable to create archive file")
+	}
+	if _, err = io.Copy(f, object); err != nil {
+		f.Close()
+		return "", caos_errs.ThrowInternal(err, "ARCHI-Lq4nf", "unable to write archive file")
+	}
+	if err = f.Close(); err != nil {
+		return "", caos_errs.ThrowInternal(err, "ARCHI-Zr6oe", "unable to write archive file")
+	}
+	return path, nil
+}
+
+func (s *directoryStorage) Get(_ context.Context, instanceID, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.directory, instanceID, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, caos_errs.ThrowNotFound(err, "ARCHI-Tx5va", "archive file not found")
+		}
+		return nil, caos_errs.ThrowInternal(err, "ARCHI-Jd9sk", "unable to open archive file")
+	}
+	return f, nil
+}
+
+// staticStorage stores the archives using the static storage of the assets
+type staticStorage struct {
+	storage static.Storage
+}
+
+func (s *staticStorage) Put(ctx context.Context, instanceID, name string, object io.Reader, size int64) (string, error) {
+	asset, err := s.storage.PutObject(ctx, instanceID, "", archiveResourceOwner, name, contentType(name), static.ObjectTypeArchive, object, size)
+	if err != nil {
+		return "", err
+	}
+	if asset.Location != "" {
+		return asset.Location, nil
+	}
+	return asset.InstanceID + "/" + asset.Name, nil
+}
+
+func (s *staticStorage) Get(ctx context.Context, instanceID, name string) (io.ReadCloser, error) {
+	object, _, err := s.storage.GetObject(ctx, instanceID, archiveResourceOwner, name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(object)), nil
+}
+
+func contentType(name string) string {
+	if filepath.Ext(name) == ".json" {
+		return "application/json"
+	}
+	return "application/gzip"
+}
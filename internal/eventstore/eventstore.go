@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
@@ -18,10 +20,21 @@ type Eventstore struct {
 	repo              repository.Repository
 	interceptorMutex  sync.Mutex
 	eventInterceptors map[EventType]eventTypeInterceptors
+	personalData      PersonalDataCrypto
 }
 
 type eventTypeInterceptors struct {
-	eventMapper func(*repository.Event) (Event, error)
+	eventMapper        func(*repository.Event) (Event, error)
+	personalDataFields []string
+	erasesPersonalData bool
+}
+
+// PersonalDataCrypto encrypts the personal data in the payload of events
+// with a key per aggregate and destroys the key if the personal data must be erased
+type PersonalDataCrypto interface {
+	EncryptPersonalData(instanceID, aggregateID string, fields []string, data []byte) ([]byte, error)
+	DecryptPersonalData(instanceID, aggregateID string, data []byte) ([]byte, error)
+	ErasePersonalData(instanceID, aggregateID string) error
 }
 
 func NewEventstore(repo repository.Repository) *Eventstore {
@@ -45,10 +58,20 @@ func (es *Eventstore) Push(ctx context.Context, cmds ...Command) ([]Event, error
 	if err != nil {
 		return nil, err
 	}
+	plainData, err := es.encryptPersonalData(events)
+	if err != nil {
+		return nil, err
+	}
 	err = es.repo.Push(ctx, events, constraints...)
 	if err != nil {
 		return nil, err
 	}
+	for i, data := range plainData {
+		events[i].Data = data
+	}
+	if err = es.erasePersonalData(events); err != nil {
+		return nil, err
+	}
 
 	eventReaders, err := es.mapEvents(events)
 	if err != nil {
@@ -134,7 +157,78 @@ func (es *Eventstore) Filter(ctx context.Context, queryFactory *SearchQueryBuild
 	return es.mapEvents(events)
 }
 
+// SetPersonalDataCrypto enables the encryption of the registered personal data fields
+// events stored before remain readable
+func (es *Eventstore) SetPersonalDataCrypto(personalData PersonalDataCrypto) {
+	es.personalData = personalData
+}
+
+// encryptPersonalData encrypts the personal data of the events
+// and returns the plain data of all events to restore it after the push
+func (es *Eventstore) encryptPersonalData(events []*repository.Event) (plainData [][]byte, err error) {
+	plainData = make([][]byte, len(events))
+	for i, event := range events {
+		plainData[i] = event.Data
+	}
+	if es.personalData == nil {
+		return plainData, nil
+	}
+
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	for _, event := range events {
+		fields := es.eventInterceptors[EventType(event.Type)].personalDataFields
+		if len(fields) == 0 {
+			continue
+		}
+		event.Data, err = es.personalData.EncryptPersonalData(event.InstanceID, event.AggregateID, fields, event.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plainData, nil
+}
+
+// erasePersonalData destroys the personal data keys of the aggregates
+// if one of the events requires the erasure.
+// The events are already stored, so the error is returned to let the caller know the data is still readable
+func (es *Eventstore) erasePersonalData(events []*repository.Event) error {
+	if es.personalData == nil {
+		return nil
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	for _, event := range events {
+		if !es.eventInterceptors[EventType(event.Type)].erasesPersonalData {
+			continue
+		}
+		if err := es.personalData.ErasePersonalData(event.InstanceID, event.AggregateID); err != nil {
+			logging.WithFields("instanceID", event.InstanceID, "aggregateID", event.AggregateID).WithError(err).Error("unable to erase personal data")
+			return errors.ThrowInternal(err, "V2-Ue3ks", "Errors.Internal")
+		}
+	}
+	return nil
+}
+
+func (es *Eventstore) decryptPersonalData(events []*repository.Event) (err error) {
+	if es.personalData == nil {
+		return nil
+	}
+	for _, event := range events {
+		event.Data, err = es.personalData.DecryptPersonalData(event.InstanceID, event.AggregateID, event.Data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (es *Eventstore) mapEvents(events []*repository.Event) (mappedEvents []Event, err error) {
+	if err = es.decryptPersonalData(events); err != nil {
+		return nil, err
+	}
 	mappedEvents = make([]Event, len(events))
 
 	es.interceptorMutex.Lock()
@@ -228,6 +322,37 @@ func (es *Eventstore) RegisterFilterEventMapper(eventType EventType, mapper func
 	return es
 }
 
+// RegisterPersonalData registers the fields of the payload of the event type which contain personal data
+// the fields are encrypted with the key of the aggregate as soon as a PersonalDataCrypto is set
+func (es *Eventstore) RegisterPersonalData(eventType EventType, fields ...string) *Eventstore {
+	if len(fields) == 0 || eventType == "" {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	interceptor := es.eventInterceptors[eventType]
+	interceptor.personalDataFields = fields
+	es.eventInterceptors[eventType] = interceptor
+
+	return es
+}
+
+// RegisterPersonalDataErasure registers the event type which erases the personal data of its aggregate
+func (es *Eventstore) RegisterPersonalDataErasure(eventType EventType) *Eventstore {
+	if eventType == "" {
+		return es
+	}
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+
+	interceptor := es.eventInterceptors[eventType]
+	interceptor.erasesPersonalData = true
+	es.eventInterceptors[eventType] = interceptor
+
+	return es
+}
+
 func EventData(event Command) ([]byte, error) {
 	switch data := event.Data().(type) {
 	case nil:
@@ -23,6 +23,7 @@ type StatementHandlerConfig struct {
 	SequenceTable     string
 	LockTable         string
 	FailedEventsTable string
+	StateTable        string
 	MaxFailureCount   uint
 	BulkLimit         uint64
 
@@ -41,6 +42,11 @@ type StatementHandler struct {
 	maxFailureCount         uint
 	failureCountStmt        string
 	setFailureCountStmt     string
+	pausedStmt              string
+	setPausedStmt           string
+	setRebuildStateStmt     string
+	claimRebuildStmt        string
+	renewRebuildClaimStmt   string
 
 	aggregates  []eventstore.AggregateType
 	reduces     map[eventstore.EventType]handler.Reduce
@@ -48,6 +54,8 @@ type StatementHandler struct {
 	initialized chan bool
 
 	bulkLimit uint64
+	// config is used to create the shadow projection on a rebuild
+	config StatementHandlerConfig
 }
 
 func NewStatementHandler(
@@ -71,15 +79,21 @@ func NewStatementHandler(
 		updateSequencesBaseStmt: fmt.Sprintf(updateCurrentSequencesStmtFormat, config.SequenceTable),
 		failureCountStmt:        fmt.Sprintf(failureCountStmtFormat, config.FailedEventsTable),
 		setFailureCountStmt:     fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
+		pausedStmt:              fmt.Sprintf(pausedStmtFormat, config.StateTable),
+		setPausedStmt:           fmt.Sprintf(setPausedStmtFormat, config.StateTable),
+		setRebuildStateStmt:     fmt.Sprintf(setRebuildStateStmtFormat, config.StateTable),
+		claimRebuildStmt:        fmt.Sprintf(claimRebuildStmtFormat, config.StateTable),
+		renewRebuildClaimStmt:   fmt.Sprintf(renewRebuildClaimStmtFormat, config.StateTable),
 		aggregates:              aggregateTypes,
 		reduces:                 reduces,
 		bulkLimit:               config.BulkLimit,
 		Locker:                  NewLocker(config.Client, config.LockTable, config.ProjectionName),
 		initCheck:               config.InitCheck,
 		initialized:             make(chan bool),
+		config:                  config,
 	}
 
-	h.ProjectionHandler = handler.NewProjectionHandler(ctx, config.ProjectionHandlerConfig, h.reduce, h.Update, h.SearchQuery, h.Lock, h.Unlock, h.initialized)
+	h.ProjectionHandler = handler.NewProjectionHandler(ctx, config.ProjectionHandlerConfig, h.reduce, h.Update, h.SearchQuery, h.Lock, h.Unlock, h.IsPaused, h.initialized)
 
 	return h
 }
@@ -299,17 +299,27 @@ func createTableStatement(table *Table, tableName string, suffix string) string
 		stmt += fmt.Sprintf(", CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE CASCADE", key.Name, strings.Join(key.Columns, ","), ref)
 	}
 	for _, constraint := range table.constraints {
-		stmt += fmt.Sprintf(", CONSTRAINT %s UNIQUE (%s)", constraint.Name, strings.Join(constraint.Columns, ","))
+		stmt += fmt.Sprintf(", CONSTRAINT %s UNIQUE (%s)", objectName(constraint.Name, tableName), strings.Join(constraint.Columns, ","))
 	}
 
 	stmt += ");"
 
 	for _, index := range table.indices {
-		stmt += fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s);", index.Name, tableName+suffix, strings.Join(index.Columns, ","))
+		stmt += fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s);", objectName(index.Name, tableName), tableName+suffix, strings.Join(index.Columns, ","))
 	}
 	return stmt
 }
 
+// objectName derives the name of an index or unique constraint from the name of the table
+// postgres requires them to be unique per schema, so the names of the shadow tables of a rebuild must differ from the ones of the projection
+// the swap of the rebuild renames them back
+func objectName(name, tableName string) string {
+	if strings.HasSuffix(tableName, shadowSuffix) {
+		return name + shadowSuffix
+	}
+	return name
+}
+
 func createViewStatement(viewName string, selectStmt string) string {
 	return fmt.Sprintf("CREATE VIEW %s AS %s",
 		viewName,
@@ -320,7 +330,7 @@ func createViewStatement(viewName string, selectStmt string) string {
 func createIndexStatement(index *Index) func(config execConfig) string {
 	return func(config execConfig) string {
 		stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
-			index.Name,
+			objectName(index.Name, config.tableName),
 			config.tableName,
 			strings.Join(index.Columns, ","),
 		)
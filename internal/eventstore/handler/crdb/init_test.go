@@ -0,0 +1,42 @@
+package crdb
+
+import (
+	"testing"
+)
+
+func Test_createTableStatement(t *testing.T) {
+	table := NewTable(
+		[]*Column{
+			NewColumn("id", ColumnTypeText),
+			NewColumn("name", ColumnTypeText),
+		},
+		NewPrimaryKey("id"),
+		WithIndex(NewIndex("name_idx", []string{"name"})),
+		WithConstraint(NewConstraint("name_unique", []string{"name"})),
+	)
+	tests := []struct {
+		name      string
+		tableName string
+		want      string
+	}{
+		{
+			name:      "projection",
+			tableName: "projections.test",
+			want: "CREATE TABLE IF NOT EXISTS projections.test (id TEXT NOT NULL,name TEXT NOT NULL, PRIMARY KEY (id), CONSTRAINT name_unique UNIQUE (name));" +
+				"CREATE INDEX IF NOT EXISTS name_idx ON projections.test (name);",
+		},
+		{
+			name:      "shadow of rebuild",
+			tableName: "projections.test" + shadowSuffix,
+			want: "CREATE TABLE IF NOT EXISTS projections.test_shadow (id TEXT NOT NULL,name TEXT NOT NULL, PRIMARY KEY (id), CONSTRAINT name_unique_shadow UNIQUE (name));" +
+				"CREATE INDEX IF NOT EXISTS name_idx_shadow ON projections.test_shadow (name);",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createTableStatement(table, tt.tableName, ""); got != tt.want {
+				t.Errorf("createTableStatement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
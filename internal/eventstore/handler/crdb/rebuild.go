@@ -0,0 +1,324 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	shadowSuffix        = "_shadow"
+	rebuildLockDuration = 10 * time.Second
+	// rebuildClaimDuration is the time after which the claim of a rebuild can be taken over if it's not renewed
+	rebuildClaimDuration = 1 * time.Minute
+
+	tablesStmt              = `SELECT table_name, table_type FROM information_schema.tables WHERE table_schema = $1`
+	lockSequencesStmtFormat = `SELECT current_sequence FROM %s WHERE projection_name = $1 FOR UPDATE`
+	deleteProjectionStmt    = `DELETE FROM %s WHERE projection_name = $1`
+	renameProjectionStmt    = `UPDATE %s SET projection_name = $1 WHERE projection_name = $2`
+	dropTableStmt           = `DROP TABLE IF EXISTS %s CASCADE`
+	renameTableStmt         = `ALTER TABLE %s RENAME TO %s`
+	indexesStmt             = `SELECT indexname FROM pg_indexes WHERE schemaname = $1 AND tablename = $2`
+	renameIndexStmt         = `ALTER INDEX %s RENAME TO %s`
+)
+
+// Rebuild reduces all events of the projection into shadow tables
+// as soon as the shadow tables caught up, they atomically replace the tables of the projection
+// the projection keeps processing events during the rebuild
+func (h *StatementHandler) Rebuild(ctx context.Context) error {
+	if err := h.claimRebuild(ctx); err != nil {
+		return err
+	}
+	return h.rebuild(ctx)
+}
+
+// StartRebuild claims the rebuild of the projection and runs it in the background
+// the state of the rebuild is stored in the state table
+func (h *StatementHandler) StartRebuild(ctx context.Context) error {
+	if err := h.claimRebuild(ctx); err != nil {
+		return err
+	}
+	go func() {
+		err := h.rebuild(context.Background())
+		logging.WithFields("projection", h.ProjectionName).OnError(err).Error("rebuild failed")
+	}()
+	return nil
+}
+
+// rebuild must only be called after the rebuild was claimed
+// the claim is renewed until the rebuild ended, the rebuild is canceled if the claim can't be renewed
+func (h *StatementHandler) rebuild(ctx context.Context) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go h.keepRebuildClaim(ctx, cancel)
+	defer func() {
+		state := RebuildStateDone
+		if err != nil {
+			state = RebuildStateFailed
+		}
+		stateErr := h.setRebuildState(context.Background(), state)
+		logging.WithFields("projection", h.ProjectionName).OnError(stateErr).Warn("unable to set rebuild state")
+	}()
+
+	schema, table := splitTableName(h.ProjectionName)
+	tables, err := h.tables(ctx, schema)
+	if err != nil {
+		return err
+	}
+	if tables[table] != "BASE TABLE" {
+		return errors.ThrowPreconditionFailed(nil, "CRDB-Ks9fL", "only projections based on tables can be rebuilt")
+	}
+
+	shadowCtx, cancelShadow := context.WithCancel(ctx)
+	defer cancelShadow()
+	shadowConfig := h.config
+	shadowConfig.ProjectionName = h.ProjectionName + shadowSuffix
+	shadow := NewStatementHandler(shadowCtx, shadowConfig)
+
+	// remove the leftovers of a previous rebuild
+	if err = shadow.drop(ctx, schema, tables); err != nil {
+		return err
+	}
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+
+	instanceIDs, err := h.Eventstore.InstanceIDs(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).AddQuery().ExcludedInstanceID("").Builder())
+	if err != nil {
+		return err
+	}
+	// the shadow catches up first without blocking the projection
+	if err = shadow.triggerInstances(ctx, instanceIDs); err != nil {
+		return err
+	}
+	if len(instanceIDs) == 0 {
+		return h.swap(ctx, &shadow, schema, tables)
+	}
+
+	lockCtx, cancelLock := context.WithCancel(ctx)
+	defer cancelLock()
+	locker := NewLocker(h.client, h.config.LockTable, h.ProjectionName)
+	errs := locker.Lock(lockCtx, rebuildLockDuration, instanceIDs...)
+	if err, ok := <-errs; err != nil || !ok {
+		if err == nil {
+			err = errors.ThrowInternal(nil, "CRDB-Ga9qe", "lock of projection canceled")
+		}
+		return err
+	}
+	go h.cancelOnErr(lockCtx, errs, cancelLock)
+	defer func() {
+		unlockErr := locker.Unlock(instanceIDs...)
+		logging.WithFields("projection", h.ProjectionName).OnError(unlockErr).Warn("unable to unlock after rebuild")
+	}()
+
+	// process the events which were pushed in the meantime
+	if err = shadow.triggerInstances(lockCtx, instanceIDs); err != nil {
+		return err
+	}
+	tables, err = h.tables(lockCtx, schema)
+	if err != nil {
+		return err
+	}
+	return h.swap(lockCtx, &shadow, schema, tables)
+}
+
+func (h *StatementHandler) keepRebuildClaim(ctx context.Context, cancel func()) {
+	renew := time.NewTicker(rebuildClaimDuration / 3)
+	defer renew.Stop()
+	for {
+		select {
+		case <-renew.C:
+			err := h.renewRebuildClaim(ctx)
+			if err != nil && ctx.Err() == nil {
+				logging.WithFields("projection", h.ProjectionName).WithError(err).Warn("rebuild canceled")
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *StatementHandler) triggerInstances(ctx context.Context, instanceIDs []string) error {
+	concurrentInstances := int(h.config.ConcurrentInstances)
+	if concurrentInstances < 1 {
+		concurrentInstances = 1
+	}
+	for i := 0; i < len(instanceIDs); i = i + concurrentInstances {
+		max := i + concurrentInstances
+		if max > len(instanceIDs) {
+			max = len(instanceIDs)
+		}
+		if err := h.Trigger(ctx, instanceIDs[i:max]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swap replaces the tables of the projection with the tables of the shadow
+// and takes over the sequences and failed events of the shadow
+func (h *StatementHandler) swap(ctx context.Context, shadow *StatementHandler, schema string, tables map[string]string) error {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Wq3ne", "begin failed")
+	}
+	// blocks all updates of the projection until the swap is committed
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(lockSequencesStmtFormat, h.config.SequenceTable), h.ProjectionName)
+	if err != nil {
+		tx.Rollback()
+		return errors.ThrowInternal(err, "CRDB-zR3va", "unable to lock current sequences")
+	}
+	if err = rows.Close(); err != nil {
+		tx.Rollback()
+		return errors.ThrowInternal(err, "CRDB-Pq7xo", "close rows failed")
+	}
+
+	_, shadowTable := splitTableName(shadow.ProjectionName)
+	_, table := splitTableName(h.ProjectionName)
+	for name, tableType := range tables {
+		if tableType != "BASE TABLE" || !strings.HasPrefix(name, shadowTable) {
+			continue
+		}
+		newName := table + strings.TrimPrefix(name, shadowTable)
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(dropTableStmt, schema+"."+newName)); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-Ud2ls", "unable to drop table")
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(renameTableStmt, schema+"."+name, newName)); err != nil {
+			tx.Rollback()
+			return errors.ThrowInternal(err, "CRDB-M9vgw", "unable to rename table")
+		}
+		if err = renameShadowIndexes(ctx, tx, schema, name, newName); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for _, stateTable := range []string{h.config.SequenceTable, h.config.FailedEventsTable} {
+		if err = swapProjectionName(ctx, tx, stateTable, h.ProjectionName, shadow.ProjectionName); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.ThrowInternal(err, "CRDB-o2Nrf", "commit failed")
+	}
+	return nil
+}
+
+// drop removes the tables, sequences and failed events of the projection
+func (h *StatementHandler) drop(ctx context.Context, schema string, tables map[string]string) error {
+	_, table := splitTableName(h.ProjectionName)
+	for name, tableType := range tables {
+		if tableType != "BASE TABLE" || !strings.HasPrefix(name, table) {
+			continue
+		}
+		if _, err := h.client.ExecContext(ctx, fmt.Sprintf(dropTableStmt, schema+"."+name)); err != nil {
+			return errors.ThrowInternal(err, "CRDB-eN4fs", "unable to drop table")
+		}
+	}
+	for _, stateTable := range []string{h.config.SequenceTable, h.config.FailedEventsTable} {
+		if _, err := h.client.ExecContext(ctx, fmt.Sprintf(deleteProjectionStmt, stateTable), h.ProjectionName); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Rb0ka", "unable to delete projection state")
+		}
+	}
+	return nil
+}
+
+// tables returns the type of all tables in the schema mapped by their name
+func (h *StatementHandler) tables(ctx context.Context, schema string) (map[string]string, error) {
+	rows, err := h.client.QueryContext(ctx, tablesStmt, schema)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-Ax3ke", "unable to query tables")
+	}
+	defer rows.Close()
+	tables := make(map[string]string)
+	for rows.Next() {
+		var name, tableType string
+		if err = rows.Scan(&name, &tableType); err != nil {
+			return nil, errors.ThrowInternal(err, "CRDB-Jz8sd", "scan failed")
+		}
+		tables[name] = tableType
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "CRDB-dm2Kp", "errors in scanning rows")
+	}
+	return tables, nil
+}
+
+// renameShadowIndexes renames the indexes and unique constraints of the renamed shadow table to the names of the projection
+// the old names are free as the tables of the projection were dropped before
+func renameShadowIndexes(ctx context.Context, tx *sql.Tx, schema, shadowTable, table string) error {
+	rows, err := tx.QueryContext(ctx, indexesStmt, schema, table)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Nq4sl", "unable to query indexes")
+	}
+	indexes := make([]string, 0)
+	for rows.Next() {
+		var index string
+		if err = rows.Scan(&index); err != nil {
+			rows.Close()
+			return errors.ThrowInternal(err, "CRDB-Yw8dk", "scan failed")
+		}
+		indexes = append(indexes, index)
+	}
+	if err = rows.Close(); err != nil {
+		return errors.ThrowInternal(err, "CRDB-Lx2vb", "close rows failed")
+	}
+	for _, index := range indexes {
+		newName := strings.TrimSuffix(index, shadowSuffix)
+		// e.g. primary keys are named by the database after the table
+		if strings.HasPrefix(newName, shadowTable) {
+			newName = table + strings.TrimPrefix(newName, shadowTable)
+		}
+		if newName == index {
+			continue
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(renameIndexStmt, schema+"."+index, newName)); err != nil {
+			return errors.ThrowInternal(err, "CRDB-Fp6ra", "unable to rename index")
+		}
+	}
+	return nil
+}
+
+func swapProjectionName(ctx context.Context, tx *sql.Tx, table, projectionName, shadowName string) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(deleteProjectionStmt, table), projectionName); err != nil {
+		return errors.ThrowInternal(err, "CRDB-Fk2dw", "unable to delete projection state")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(renameProjectionStmt, table), projectionName, shadowName); err != nil {
+		return errors.ThrowInternal(err, "CRDB-c8Yqo", "unable to rename projection state")
+	}
+	return nil
+}
+
+func (h *StatementHandler) cancelOnErr(ctx context.Context, errs <-chan error, cancel func()) {
+	for {
+		select {
+		case err := <-errs:
+			if err != nil {
+				logging.WithFields("projection", h.ProjectionName).WithError(err).Warn("rebuild canceled")
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			cancel()
+			return
+		}
+	}
+}
+
+func splitTableName(name string) (schema, table string) {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) == 1 {
+		return "public", parts[0]
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,100 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	pausedStmtFormat          = `SELECT paused FROM %s WHERE projection_name = $1`
+	setPausedStmtFormat       = `INSERT INTO %s (projection_name, paused, change_date) VALUES ($1, $2, now()) ON CONFLICT (projection_name) DO UPDATE SET paused = EXCLUDED.paused, change_date = EXCLUDED.change_date`
+	setRebuildStateStmtFormat = `INSERT INTO %s (projection_name, rebuild_state, change_date) VALUES ($1, $2, now()) ON CONFLICT (projection_name) DO UPDATE SET rebuild_state = EXCLUDED.rebuild_state, change_date = EXCLUDED.change_date`
+	// claimRebuildStmtFormat only sets the state if no other rebuild of the projection is running
+	// or the claim of the running rebuild expired, e.g. because the process crashed
+	claimRebuildStmtFormat = `INSERT INTO %[1]s (projection_name, rebuild_state, change_date, claimed_at) VALUES ($1, $2, now(), now())` +
+		` ON CONFLICT (projection_name) DO UPDATE SET rebuild_state = EXCLUDED.rebuild_state, change_date = EXCLUDED.change_date, claimed_at = EXCLUDED.claimed_at` +
+		` WHERE %[1]s.rebuild_state <> EXCLUDED.rebuild_state OR %[1]s.claimed_at IS NULL OR %[1]s.claimed_at < now()-$3::INTERVAL`
+	renewRebuildClaimStmtFormat = `UPDATE %s SET claimed_at = now() WHERE projection_name = $1 AND rebuild_state = $2`
+)
+
+type RebuildState int32
+
+const (
+	RebuildStateUnspecified RebuildState = iota
+	RebuildStateRunning
+	RebuildStateDone
+	RebuildStateFailed
+)
+
+// IsPaused implements handler.IsPaused
+func (h *StatementHandler) IsPaused(ctx context.Context) (bool, error) {
+	if h.config.StateTable == "" {
+		return false, nil
+	}
+	var paused bool
+	err := h.client.QueryRowContext(ctx, h.pausedStmt, h.ProjectionName).Scan(&paused)
+	if errs.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.ThrowInternal(err, "CRDB-Pw2rd", "unable to check if projection is paused")
+	}
+	return paused, nil
+}
+
+// Pause stops the processing of events on all instances until the projection is resumed
+// running handlers pick up the paused state with their next schedule
+func (h *StatementHandler) Pause(ctx context.Context) error {
+	return h.setPaused(ctx, true)
+}
+
+// Resume continues the processing of events of a paused projection
+func (h *StatementHandler) Resume(ctx context.Context) error {
+	return h.setPaused(ctx, false)
+}
+
+func (h *StatementHandler) setPaused(ctx context.Context, paused bool) error {
+	_, err := h.client.ExecContext(ctx, h.setPausedStmt, h.ProjectionName, paused)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-3nLw0", "unable to set paused state")
+	}
+	return nil
+}
+
+func (h *StatementHandler) setRebuildState(ctx context.Context, state RebuildState) error {
+	_, err := h.client.ExecContext(ctx, h.setRebuildStateStmt, h.ProjectionName, state)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Bx8fq", "unable to set rebuild state")
+	}
+	return nil
+}
+
+// claimRebuild sets the rebuild state to running
+// it fails if a rebuild of the projection is already running and its claim didn't expire
+func (h *StatementHandler) claimRebuild(ctx context.Context) error {
+	//the unit of crdb interval is seconds (https://www.cockroachlabs.com/docs/stable/interval.html).
+	res, err := h.client.ExecContext(ctx, h.claimRebuildStmt, h.ProjectionName, RebuildStateRunning, rebuildClaimDuration)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Ow3lq", "unable to claim rebuild")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return errors.ThrowAlreadyExists(nil, "CRDB-Vu4pe", "Errors.ProjectionName.RebuildRunning")
+	}
+	return nil
+}
+
+// renewRebuildClaim extends the claim of the running rebuild
+// it fails if the rebuild is no longer running
+func (h *StatementHandler) renewRebuildClaim(ctx context.Context) error {
+	res, err := h.client.ExecContext(ctx, h.renewRebuildClaimStmt, h.ProjectionName, RebuildStateRunning)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRDB-Hc5ta", "unable to renew rebuild claim")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return errors.ThrowPreconditionFailed(nil, "CRDB-Jd7zu", "rebuild is no longer running")
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+const (
+	stateTable = "my_state_table"
+)
+
+func expectPaused(stateTable, projectionName string, paused bool) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(`SELECT paused FROM ` + stateTable + ` WHERE projection_name = \$1`).
+			WithArgs(projectionName).
+			WillReturnRows(sqlmock.NewRows([]string{"paused"}).AddRow(paused))
+	}
+}
+
+func expectPausedErr(stateTable, projectionName string, err error) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(`SELECT paused FROM ` + stateTable + ` WHERE projection_name = \$1`).
+			WithArgs(projectionName).
+			WillReturnError(err)
+	}
+}
+
+func expectSetPaused(stateTable, projectionName string, paused bool) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`INSERT INTO `+stateTable+` \(projection_name, paused, change_date\) VALUES \(\$1, \$2, now\(\)\) ON CONFLICT \(projection_name\) DO UPDATE SET paused = EXCLUDED\.paused, change_date = EXCLUDED\.change_date`).
+			WithArgs(projectionName, paused).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+func expectSetPausedErr(stateTable, projectionName string, paused bool, err error) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`INSERT INTO `+stateTable+` \(projection_name, paused, change_date\) VALUES \(\$1, \$2, now\(\)\) ON CONFLICT \(projection_name\) DO UPDATE SET paused = EXCLUDED\.paused, change_date = EXCLUDED\.change_date`).
+			WithArgs(projectionName, paused).
+			WillReturnError(err)
+	}
+}
+
+func TestStatementHandler_IsPaused(t *testing.T) {
+	type want struct {
+		expectations []mockExpectation
+		paused       bool
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name       string
+		stateTable string
+		want       want
+	}{
+		{
+			name:       "no state table",
+			stateTable: "",
+			want: want{
+				paused: false,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name:       "no state",
+			stateTable: stateTable,
+			want: want{
+				expectations: []mockExpectation{
+					expectPausedErr(stateTable, projectionName, sql.ErrNoRows),
+				},
+				paused: false,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name:       "query fails",
+			stateTable: stateTable,
+			want: want{
+				expectations: []mockExpectation{
+					expectPausedErr(stateTable, projectionName, sql.ErrConnDone),
+				},
+				paused: false,
+				isErr: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone) && z_errs.IsInternal(err)
+				},
+			},
+		},
+		{
+			name:       "paused",
+			stateTable: stateTable,
+			want: want{
+				expectations: []mockExpectation{
+					expectPaused(stateTable, projectionName, true),
+				},
+				paused: true,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name:       "not paused",
+			stateTable: stateTable,
+			want: want{
+				expectations: []mockExpectation{
+					expectPaused(stateTable, projectionName, false),
+				},
+				paused: false,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: projectionName,
+				},
+				client: client,
+				config: StatementHandlerConfig{
+					StateTable: tt.stateTable,
+				},
+				pausedStmt: fmt.Sprintf(pausedStmtFormat, tt.stateTable),
+			}
+
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+
+			paused, err := h.IsPaused(context.Background())
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if paused != tt.want.paused {
+				t.Errorf("want paused %t, got %t", tt.want.paused, paused)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func TestStatementHandler_setPaused(t *testing.T) {
+	type want struct {
+		expectations []mockExpectation
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		paused bool
+		want   want
+	}{
+		{
+			name:   "pause",
+			paused: true,
+			want: want{
+				expectations: []mockExpectation{
+					expectSetPaused(stateTable, projectionName, true),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name:   "resume",
+			paused: false,
+			want: want{
+				expectations: []mockExpectation{
+					expectSetPaused(stateTable, projectionName, false),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name:   "exec fails",
+			paused: true,
+			want: want{
+				expectations: []mockExpectation{
+					expectSetPausedErr(stateTable, projectionName, true, sql.ErrConnDone),
+				},
+				isErr: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone) && z_errs.IsInternal(err)
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: projectionName,
+				},
+				client:        client,
+				setPausedStmt: fmt.Sprintf(setPausedStmtFormat, stateTable),
+			}
+
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+
+			if tt.paused {
+				err = h.Pause(context.Background())
+			} else {
+				err = h.Resume(context.Background())
+			}
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func expectClaimRebuild(stateTable, projectionName string, rowsAffected int64) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`INSERT INTO `+stateTable+` \(projection_name, rebuild_state, change_date, claimed_at\) VALUES \(\$1, \$2, now\(\), now\(\)\)`+
+			` ON CONFLICT \(projection_name\) DO UPDATE SET rebuild_state = EXCLUDED\.rebuild_state, change_date = EXCLUDED\.change_date, claimed_at = EXCLUDED\.claimed_at`+
+			` WHERE `+stateTable+`\.rebuild_state <> EXCLUDED\.rebuild_state OR `+stateTable+`\.claimed_at IS NULL OR `+stateTable+`\.claimed_at < now\(\)-\$3::INTERVAL`).
+			WithArgs(projectionName, RebuildStateRunning, rebuildClaimDuration).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+	}
+}
+
+func expectClaimRebuildErr(stateTable, projectionName string, err error) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`INSERT INTO `+stateTable+` \(projection_name, rebuild_state, change_date, claimed_at\) VALUES \(\$1, \$2, now\(\), now\(\)\)`+
+			` ON CONFLICT \(projection_name\) DO UPDATE SET rebuild_state = EXCLUDED\.rebuild_state, change_date = EXCLUDED\.change_date, claimed_at = EXCLUDED\.claimed_at`+
+			` WHERE `+stateTable+`\.rebuild_state <> EXCLUDED\.rebuild_state OR `+stateTable+`\.claimed_at IS NULL OR `+stateTable+`\.claimed_at < now\(\)-\$3::INTERVAL`).
+			WithArgs(projectionName, RebuildStateRunning, rebuildClaimDuration).
+			WillReturnError(err)
+	}
+}
+
+func TestStatementHandler_claimRebuild(t *testing.T) {
+	type want struct {
+		expectations []mockExpectation
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name string
+		want want
+	}{
+		{
+			name: "claimed",
+			want: want{
+				expectations: []mockExpectation{
+					expectClaimRebuild(stateTable, projectionName, 1),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name: "already running",
+			want: want{
+				expectations: []mockExpectation{
+					expectClaimRebuild(stateTable, projectionName, 0),
+				},
+				isErr: z_errs.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "exec fails",
+			want: want{
+				expectations: []mockExpectation{
+					expectClaimRebuildErr(stateTable, projectionName, sql.ErrConnDone),
+				},
+				isErr: func(err error) bool {
+					return errors.Is(err, sql.ErrConnDone) && z_errs.IsInternal(err)
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: projectionName,
+				},
+				client:           client,
+				claimRebuildStmt: fmt.Sprintf(claimRebuildStmtFormat, stateTable),
+			}
+
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+
+			err = h.claimRebuild(context.Background())
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func expectRenewRebuildClaim(stateTable, projectionName string, rowsAffected int64) func(sqlmock.Sqlmock) {
+	return func(m sqlmock.Sqlmock) {
+		m.ExpectExec(`UPDATE `+stateTable+` SET claimed_at = now\(\) WHERE projection_name = \$1 AND rebuild_state = \$2`).
+			WithArgs(projectionName, RebuildStateRunning).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+	}
+}
+
+func TestStatementHandler_renewRebuildClaim(t *testing.T) {
+	type want struct {
+		expectations []mockExpectation
+		isErr        func(error) bool
+	}
+	tests := []struct {
+		name string
+		want want
+	}{
+		{
+			name: "renewed",
+			want: want{
+				expectations: []mockExpectation{
+					expectRenewRebuildClaim(stateTable, projectionName, 1),
+				},
+				isErr: func(err error) bool {
+					return err == nil
+				},
+			},
+		},
+		{
+			name: "no longer running",
+			want: want{
+				expectations: []mockExpectation{
+					expectRenewRebuildClaim(stateTable, projectionName, 0),
+				},
+				isErr: z_errs.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := &StatementHandler{
+				ProjectionHandler: &handler.ProjectionHandler{
+					ProjectionName: projectionName,
+				},
+				client:                client,
+				renewRebuildClaimStmt: fmt.Sprintf(renewRebuildClaimStmtFormat, stateTable),
+			}
+
+			for _, expectation := range tt.want.expectations {
+				expectation(mock)
+			}
+
+			err = h.renewRebuildClaim(context.Background())
+			if !tt.want.isErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			mock.MatchExpectationsInOrder(true)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
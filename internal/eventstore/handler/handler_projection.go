@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"runtime/debug"
+	"sync/atomic"
 	"time"
 
 	"github.com/zitadel/logging"
@@ -43,15 +44,22 @@ type Lock func(context.Context, time.Duration, ...string) <-chan error
 // Unlock releases the mutex of the projection
 type Unlock func(...string) error
 
+// IsPaused checks if the processing of events is paused for the projection
+type IsPaused func(context.Context) (bool, error)
+
 type ProjectionHandler struct {
 	Handler
-	ProjectionName      string
-	reduce              Reduce
-	update              Update
-	searchQuery         SearchQuery
-	triggerProjection   *time.Timer
-	lock                Lock
-	unlock              Unlock
+	ProjectionName    string
+	reduce            Reduce
+	update            Update
+	searchQuery       SearchQuery
+	triggerProjection *time.Timer
+	lock              Lock
+	unlock            Unlock
+	isPaused          IsPaused
+	// pausedState is refreshed on every schedule
+	// so the paused state isn't queried for every batch of events
+	pausedState         atomic.Bool
 	requeueAfter        time.Duration
 	retryFailedAfter    time.Duration
 	retries             int
@@ -66,6 +74,7 @@ func NewProjectionHandler(
 	query SearchQuery,
 	lock Lock,
 	unlock Unlock,
+	isPaused IsPaused,
 	initialized <-chan bool,
 ) *ProjectionHandler {
 	concurrentInstances := int(config.ConcurrentInstances)
@@ -80,6 +89,7 @@ func NewProjectionHandler(
 		searchQuery:         query,
 		lock:                lock,
 		unlock:              unlock,
+		isPaused:            isPaused,
 		requeueAfter:        config.RequeueEvery,
 		triggerProjection:   time.NewTimer(0), // first trigger is instant on startup
 		retryFailedAfter:    config.RetryFailedAfter,
@@ -88,7 +98,12 @@ func NewProjectionHandler(
 	}
 
 	go func() {
-		<-initialized
+		select {
+		case <-initialized:
+		case <-ctx.Done():
+			return
+		}
+		h.refreshPaused(ctx)
 		go h.subscribe(ctx)
 
 		go h.schedule(ctx)
@@ -99,7 +114,11 @@ func NewProjectionHandler(
 
 // Trigger handles all events for the provided instances (or current instance from context if non specified)
 // by calling FetchEvents and Process until the amount of events is smaller than the BulkLimit
+// nothing is processed as long as the projection is paused
 func (h *ProjectionHandler) Trigger(ctx context.Context, instances ...string) error {
+	if h.pausedState.Load() {
+		return nil
+	}
 	ids := []string{authz.GetInstance(ctx).InstanceID()}
 	if len(instances) > 0 {
 		ids = instances
@@ -173,6 +192,10 @@ func (h *ProjectionHandler) subscribe(ctx context.Context) {
 	}()
 	for firstEvent := range h.EventQueue {
 		events := checkAdditionalEvents(h.EventQueue, firstEvent)
+		// the events of a paused projection will be handled by the scheduler after it's resumed
+		if h.pausedState.Load() {
+			continue
+		}
 
 		index, err := h.Process(ctx, events...)
 		if err != nil || index < len(events)-1 {
@@ -192,10 +215,17 @@ func (h *ProjectionHandler) schedule(ctx context.Context) {
 	}()
 	// flag if projection has been successfully executed at least once since start
 	var succeededOnce bool
+	// flag if projection has been paused since the last run
+	var wasPaused bool
 	var err error
 	// get every instance id except empty (system)
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsInstanceIDs).AddQuery().ExcludedInstanceID("")
 	for range h.triggerProjection.C {
+		if h.refreshPaused(ctx) {
+			wasPaused = true
+			h.triggerProjection.Reset(h.requeueAfter)
+			continue
+		}
 		if !succeededOnce {
 			// (re)check if it has succeeded in the meantime
 			succeededOnce, err = h.hasSucceededOnce(ctx)
@@ -226,6 +256,10 @@ func (h *ProjectionHandler) schedule(ctx context.Context) {
 			// twice the requeue time (just to be sure not to miss an event)
 			query = query.CreationDateAfter(time.Now().Add(-2 * h.requeueAfter))
 		}
+		if wasPaused {
+			// events could have been skipped during the pause, so every instance has to be checked
+			query = query.CreationDateAfter(time.Time{})
+		}
 		ids, err := h.Eventstore.InstanceIDs(ctx, query.Builder())
 		if err != nil {
 			logging.WithFields("projection", h.ProjectionName).WithError(err).Error("instance ids")
@@ -271,10 +305,31 @@ func (h *ProjectionHandler) schedule(ctx context.Context) {
 		}
 		// it succeeded at least once if it has succeeded before or if it has succeeded now - not failed ;-)
 		succeededOnce = succeededOnce || !failed
+		wasPaused = wasPaused && failed
 		h.triggerProjection.Reset(h.requeueAfter)
 	}
 }
 
+// Name returns the name of the projection
+func (h *ProjectionHandler) Name() string {
+	return h.ProjectionName
+}
+
+// refreshPaused reads the paused state of the projection
+// the previous state is kept if it can't be read
+func (h *ProjectionHandler) refreshPaused(ctx context.Context) bool {
+	if h.isPaused == nil {
+		return false
+	}
+	paused, err := h.isPaused(ctx)
+	if err != nil {
+		logging.WithFields("projection", h.ProjectionName).WithError(err).Warn("unable to check if projection is paused")
+		return h.pausedState.Load()
+	}
+	h.pausedState.Store(paused)
+	return paused
+}
+
 func (h *ProjectionHandler) hasSucceededOnce(ctx context.Context) (bool, error) {
 	events, err := h.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -59,6 +59,8 @@ const (
 	OperationJSONContains
 	//OperationNotIn checks if a stored value does not match one of the passed value list
 	OperationNotIn
+	//OperationTextContains checks if the text representation of a stored value contains the given text (case insensitive)
+	OperationTextContains
 
 	operationCount
 )
@@ -319,6 +319,8 @@ func (db *CRDB) conditionFormat(operation repository.Operation) string {
 		return "%s %s ANY(?)"
 	case repository.OperationNotIn:
 		return "%s %s ALL(?)"
+	case repository.OperationTextContains:
+		return "%s::TEXT %s ?"
 	}
 	return "%s %s ?"
 }
@@ -335,6 +337,8 @@ func (db *CRDB) operation(operation repository.Operation) string {
 		return "@>"
 	case repository.OperationNotIn:
 		return "<>"
+	case repository.OperationTextContains:
+		return "ILIKE"
 	}
 	return ""
 }
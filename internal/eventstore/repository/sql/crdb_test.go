@@ -117,6 +117,15 @@ func TestCRDB_operation(t *testing.T) {
 				op: "=",
 			},
 		},
+		{
+			name: "text contains",
+			args: args{
+				operation: repository.OperationTextContains,
+			},
+			res: res{
+				op: "ILIKE",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -158,6 +167,15 @@ func TestCRDB_conditionFormat(t *testing.T) {
 				format: "%s %s ANY(?)",
 			},
 		},
+		{
+			name: "text contains",
+			args: args{
+				operation: repository.OperationTextContains,
+			},
+			res: res{
+				format: "%s::TEXT %s ?",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
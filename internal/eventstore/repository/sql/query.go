@@ -28,6 +28,9 @@ type querier interface {
 
 type scan func(dest ...interface{}) error
 
+// likeEscaper escapes the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func query(ctx context.Context, criteria querier, searchQuery *repository.SearchQuery, dest interface{}) error {
 	query, rowScanner := prepareColumns(criteria, searchQuery.Columns)
 	where, values := prepareCondition(criteria, searchQuery.Filters)
@@ -168,7 +171,7 @@ func prepareCondition(criteria querier, filters [][]*repository.Filter) (clause
 		subClauses := make([]string, 0, len(filter))
 		for _, f := range filter {
 			value := f.Value
-			switch value.(type) {
+			switch v := value.(type) {
 			case map[string]interface{}:
 				var err error
 				value, err = json.Marshal(value)
@@ -176,6 +179,10 @@ func prepareCondition(criteria querier, filters [][]*repository.Filter) (clause
 					logging.WithError(err).Warn("unable to marshal search value")
 					continue
 				}
+			case string:
+				if f.Operation == repository.OperationTextContains {
+					value = "%" + likeEscaper.Replace(v) + "%"
+				}
 			}
 
 			subClauses = append(subClauses, getCondition(criteria, f))
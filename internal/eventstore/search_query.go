@@ -31,7 +31,10 @@ type SearchQuery struct {
 	eventSequenceLess    uint64
 	eventTypes           []EventType
 	eventData            map[string]interface{}
+	eventDataText        string
 	creationDateAfter    time.Time
+	creationDateBefore   time.Time
+	editorUsers          []string
 }
 
 // Columns defines which fields of the event are needed for the query
@@ -183,6 +186,18 @@ func (query *SearchQuery) CreationDateAfter(time time.Time) *SearchQuery {
 	return query
 }
 
+// CreationDateBefore filters for events which happened before the specified time
+func (query *SearchQuery) CreationDateBefore(time time.Time) *SearchQuery {
+	query.creationDateBefore = time
+	return query
+}
+
+// EditorUsers filters for events created by the given users
+func (query *SearchQuery) EditorUsers(ids ...string) *SearchQuery {
+	query.editorUsers = ids
+	return query
+}
+
 // EventTypes filters for events with the given event types
 func (query *SearchQuery) EventTypes(types ...EventType) *SearchQuery {
 	query.eventTypes = types
@@ -196,6 +211,14 @@ func (query *SearchQuery) EventData(data map[string]interface{}) *SearchQuery {
 	return query
 }
 
+// EventDataText filters for events containing the text in their event data (case insensitive).
+// Use this call with care as it will be slower than the other filters
+// and the text is also matched against secrets stored in the event data.
+func (query *SearchQuery) EventDataText(text string) *SearchQuery {
+	query.eventDataText = text
+	return query
+}
+
 // Builder returns the SearchQueryBuilder of the sub query
 func (query *SearchQuery) Builder() *SearchQueryBuilder {
 	return query.builder
@@ -238,11 +261,14 @@ func (builder *SearchQueryBuilder) build(instanceID string) (*repository.SearchQ
 			query.aggregateIDFilter,
 			query.eventTypeFilter,
 			query.eventDataFilter,
+			query.eventDataTextFilter,
 			query.eventSequenceGreaterFilter,
 			query.eventSequenceLessFilter,
 			query.instanceIDFilter,
 			query.excludedInstanceIDFilter,
 			query.creationDateAfterFilter,
+			query.creationDateBeforeFilter,
+			query.editorUserFilter,
 			query.builder.resourceOwnerFilter,
 			query.builder.instanceIDFilter,
 		} {
@@ -366,3 +392,27 @@ func (query *SearchQuery) eventDataFilter() *repository.Filter {
 	}
 	return repository.NewFilter(repository.FieldEventData, query.eventData, repository.OperationJSONContains)
 }
+
+func (query *SearchQuery) eventDataTextFilter() *repository.Filter {
+	if query.eventDataText == "" {
+		return nil
+	}
+	return repository.NewFilter(repository.FieldEventData, query.eventDataText, repository.OperationTextContains)
+}
+
+func (query *SearchQuery) creationDateBeforeFilter() *repository.Filter {
+	if query.creationDateBefore.IsZero() {
+		return nil
+	}
+	return repository.NewFilter(repository.FieldCreationDate, query.creationDateBefore, repository.OperationLess)
+}
+
+func (query *SearchQuery) editorUserFilter() *repository.Filter {
+	if len(query.editorUsers) < 1 {
+		return nil
+	}
+	if len(query.editorUsers) == 1 {
+		return repository.NewFilter(repository.FieldEditorUser, query.editorUsers[0], repository.OperationEquals)
+	}
+	return repository.NewFilter(repository.FieldEditorUser, database.StringArray(query.editorUsers), repository.OperationIn)
+}
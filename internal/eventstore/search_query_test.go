@@ -94,6 +94,27 @@ func testSetCreationDateAfter(date time.Time) func(*SearchQuery) *SearchQuery {
 	}
 }
 
+func testSetCreationDateBefore(date time.Time) func(*SearchQuery) *SearchQuery {
+	return func(query *SearchQuery) *SearchQuery {
+		query = query.CreationDateBefore(date)
+		return query
+	}
+}
+
+func testSetEditorUsers(ids ...string) func(*SearchQuery) *SearchQuery {
+	return func(query *SearchQuery) *SearchQuery {
+		query = query.EditorUsers(ids...)
+		return query
+	}
+}
+
+func testSetEventDataText(text string) func(*SearchQuery) *SearchQuery {
+	return func(query *SearchQuery) *SearchQuery {
+		query = query.EventDataText(text)
+		return query
+	}
+}
+
 func testSetSortOrder(asc bool) func(*SearchQueryBuilder) *SearchQueryBuilder {
 	return func(query *SearchQueryBuilder) *SearchQueryBuilder {
 		if asc {
@@ -685,6 +706,38 @@ func TestSearchQuerybuilderBuild(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "filter editor users, event data text and creation date range",
+			args: args{
+				columns: ColumnsEvent,
+				setters: []func(*SearchQueryBuilder) *SearchQueryBuilder{
+					testAddQuery(
+						testSetEditorUsers("user1", "user2"),
+						testSetEventDataText("gigi"),
+						testSetCreationDateAfter(testNow),
+						testSetCreationDateBefore(testNow.Add(time.Hour)),
+					),
+				},
+				instanceID: "instanceID",
+			},
+			res: res{
+				isErr: nil,
+				query: &repository.SearchQuery{
+					Columns: repository.ColumnsEvent,
+					Desc:    false,
+					Limit:   0,
+					Filters: [][]*repository.Filter{
+						{
+							repository.NewFilter(repository.FieldEventData, "gigi", repository.OperationTextContains),
+							repository.NewFilter(repository.FieldCreationDate, testNow, repository.OperationGreater),
+							repository.NewFilter(repository.FieldCreationDate, testNow.Add(time.Hour), repository.OperationLess),
+							repository.NewFilter(repository.FieldEditorUser, database.StringArray{"user1", "user2"}, repository.OperationIn),
+							repository.NewFilter(repository.FieldInstanceID, "instanceID", repository.OperationEquals),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "column invalid",
 			args: args{
@@ -18,6 +18,19 @@ type Eventstore interface {
 
 var _ Eventstore = (*eventstore)(nil)
 
+// PersonalDataDecrypter restores the encrypted personal data in the payload of events
+type PersonalDataDecrypter interface {
+	DecryptPersonalData(instanceID, aggregateID string, data []byte) ([]byte, error)
+}
+
+var personalData PersonalDataDecrypter
+
+// SetPersonalDataDecrypter enables the decryption of personal data for the filtered events
+// it must be called before the eventstores are started
+func SetPersonalDataDecrypter(decrypter PersonalDataDecrypter) {
+	personalData = decrypter
+}
+
 type eventstore struct {
 	repo repository.Repository
 }
@@ -32,7 +45,17 @@ func (es *eventstore) FilterEvents(ctx context.Context, searchQuery *models.Sear
 	if err := searchQuery.Validate(); err != nil {
 		return nil, err
 	}
-	return es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	events, err := es.repo.Filter(ctx, models.FactoryFromSearchQuery(searchQuery))
+	if err != nil || personalData == nil {
+		return events, err
+	}
+	for _, event := range events {
+		event.Data, err = personalData.DecryptPersonalData(event.InstanceID, event.AggregateID, event.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
 }
 
 func (es *eventstore) Health(ctx context.Context) error {
@@ -0,0 +1,102 @@
+// Package expiry applies the validity of time-bound user grants and members.
+// Expired user grants are deactivated, user grants which were deactivated until their validity starts are activated
+// and expired org and project members are removed.
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// SystemUserID is set as editor of the events pushed by the worker
+	SystemUserID = "EXPIRY"
+)
+
+type Config struct {
+	// Interval in which the validities are checked, the worker is disabled if it's 0
+	Interval time.Duration
+	// BulkLimit is the maximum of user grants and members handled per type and run
+	BulkLimit uint64
+}
+
+type commands interface {
+	ApplyUserGrantValidity(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+	RemoveExpiredOrgMember(ctx context.Context, orgID, userID string) (*domain.ObjectDetails, error)
+	RemoveExpiredProjectMember(ctx context.Context, projectID, userID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+type queries interface {
+	DueUserGrants(ctx context.Context, now time.Time, limit uint64) ([]*query.DueValidity, error)
+	ExpiredOrgMembers(ctx context.Context, now time.Time, limit uint64) ([]*query.DueValidity, error)
+	ExpiredProjectMembers(ctx context.Context, now time.Time, limit uint64) ([]*query.DueValidity, error)
+}
+
+type worker struct {
+	config   Config
+	commands commands
+	queries  queries
+}
+
+func Start(ctx context.Context, config Config, commands *command.Commands, queries *query.Queries) {
+	if config.Interval <= 0 {
+		logging.Info("validity worker disabled")
+		return
+	}
+	w := &worker{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+	}
+	go w.schedule(ctx)
+}
+
+func (w *worker) schedule(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx, time.Now())
+		}
+	}
+}
+
+// run handles all user grants and members due at the given point in time
+// failures are logged and retried in the next run
+func (w *worker) run(ctx context.Context, now time.Time) {
+	grants, err := w.queries.DueUserGrants(ctx, now, w.config.BulkLimit)
+	logging.OnError(err).Warn("unable to query due user grants")
+	for _, grant := range grants {
+		_, err = w.commands.ApplyUserGrantValidity(systemContext(ctx, grant), grant.AggregateID, grant.ResourceOwner)
+		logging.WithFields("instance", grant.InstanceID, "grant", grant.AggregateID).OnError(err).Warn("unable to apply validity of user grant")
+	}
+
+	orgMembers, err := w.queries.ExpiredOrgMembers(ctx, now, w.config.BulkLimit)
+	logging.OnError(err).Warn("unable to query expired org members")
+	for _, member := range orgMembers {
+		_, err = w.commands.RemoveExpiredOrgMember(systemContext(ctx, member), member.AggregateID, member.UserID)
+		logging.WithFields("instance", member.InstanceID, "org", member.AggregateID, "user", member.UserID).OnError(err).Warn("unable to remove expired org member")
+	}
+
+	projectMembers, err := w.queries.ExpiredProjectMembers(ctx, now, w.config.BulkLimit)
+	logging.OnError(err).Warn("unable to query expired project members")
+	for _, member := range projectMembers {
+		_, err = w.commands.RemoveExpiredProjectMember(systemContext(ctx, member), member.AggregateID, member.UserID, member.ResourceOwner)
+		logging.WithFields("instance", member.InstanceID, "project", member.AggregateID, "user", member.UserID).OnError(err).Warn("unable to remove expired project member")
+	}
+}
+
+func systemContext(ctx context.Context, due *query.DueValidity) context.Context {
+	ctx = authz.WithInstanceID(ctx, due.InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: SystemUserID, OrgID: due.ResourceOwner})
+}
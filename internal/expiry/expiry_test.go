@@ -0,0 +1,100 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockCommands struct {
+	calls []string
+}
+
+func (m *mockCommands) record(ctx context.Context, call string) {
+	m.calls = append(m.calls, authz.GetInstance(ctx).InstanceID()+":"+authz.GetCtxData(ctx).UserID+":"+call)
+}
+
+func (m *mockCommands) ApplyUserGrantValidity(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error) {
+	m.record(ctx, "grant:"+grantID+":"+resourceOwner)
+	return nil, nil
+}
+
+func (m *mockCommands) RemoveExpiredOrgMember(ctx context.Context, orgID, userID string) (*domain.ObjectDetails, error) {
+	m.record(ctx, "org:"+orgID+":"+userID)
+	return nil, nil
+}
+
+func (m *mockCommands) RemoveExpiredProjectMember(ctx context.Context, projectID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	m.record(ctx, "project:"+projectID+":"+userID+":"+resourceOwner)
+	return nil, errors.New("failed")
+}
+
+type mockQueries struct {
+	grants, orgMembers, projectMembers []*query.DueValidity
+	err                                error
+}
+
+func (m *mockQueries) DueUserGrants(context.Context, time.Time, uint64) ([]*query.DueValidity, error) {
+	return m.grants, m.err
+}
+
+func (m *mockQueries) ExpiredOrgMembers(context.Context, time.Time, uint64) ([]*query.DueValidity, error) {
+	return m.orgMembers, m.err
+}
+
+func (m *mockQueries) ExpiredProjectMembers(context.Context, time.Time, uint64) ([]*query.DueValidity, error) {
+	return m.projectMembers, m.err
+}
+
+func Test_worker_run(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries *mockQueries
+		want    []string
+	}{
+		{
+			name:    "query error, nothing applied",
+			queries: &mockQueries{err: errors.New("unavailable")},
+		},
+		{
+			name: "due grants and members, applied in their instance",
+			queries: &mockQueries{
+				grants: []*query.DueValidity{
+					{InstanceID: "instance1", ResourceOwner: "org1", AggregateID: "grant1", UserID: "user1"},
+				},
+				orgMembers: []*query.DueValidity{
+					{InstanceID: "instance2", ResourceOwner: "org2", AggregateID: "org2", UserID: "user2"},
+				},
+				projectMembers: []*query.DueValidity{
+					{InstanceID: "instance1", ResourceOwner: "org1", AggregateID: "project1", UserID: "user3"},
+					{InstanceID: "instance1", ResourceOwner: "org1", AggregateID: "project2", UserID: "user3"},
+				},
+			},
+			want: []string{
+				"instance1:EXPIRY:grant:grant1:org1",
+				"instance2:EXPIRY:org:org2:user2",
+				"instance1:EXPIRY:project:project1:user3:org1",
+				"instance1:EXPIRY:project:project2:user3:org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(mockCommands)
+			w := &worker{
+				config:   Config{BulkLimit: 10},
+				commands: commands,
+				queries:  tt.queries,
+			}
+			w.run(context.Background(), time.Now())
+			assert.Equal(t, tt.want, commands.calls)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	BackChannelLogoutProjectionTable = "projections.notifications_back_channel_logout"
+
+	// backChannelLogoutRetry is the time the logout tokens of a sign out are sent to the clients,
+	// older sign outs are skipped if the projection processes them again (e.g. after a failure of the handler)
+	backChannelLogoutRetry = 10 * time.Minute
+	// backChannelLogoutTokenLifetime is the lifetime of the logout tokens
+	backChannelLogoutTokenLifetime = 2 * time.Minute
+	backChannelLogoutTimeout       = 10 * time.Second
+
+	logoutTokenType  = "logout+jwt"
+	backChannelEvent = "http://schemas.openid.net/event/backchannel-logout"
+)
+
+var backChannelLogoutClient = &http.Client{Timeout: backChannelLogoutTimeout}
+
+// logoutTokenClaims are the claims of the logout token (OpenID Connect Back-Channel Logout, section 2.4)
+type logoutTokenClaims struct {
+	Issuer     string                 `json:"iss"`
+	Subject    string                 `json:"sub"`
+	Audience   []string               `json:"aud"`
+	IssuedAt   int64                  `json:"iat"`
+	Expiration int64                  `json:"exp"`
+	JWTID      string                 `json:"jti"`
+	Events     map[string]interface{} `json:"events"`
+}
+
+// backChannelLogoutProjection sends the logout tokens of the sign outs to the clients (OpenID Connect Back-Channel Logout).
+// It's separated from the notificationsProjection, so slow or unreachable clients don't delay the other notifications.
+type backChannelLogoutProjection struct {
+	crdb.StatementHandler
+	commands       *command.Commands
+	queries        *query.Queries
+	es             *eventstore.Eventstore
+	oidcKeyCrypto  crypto.EncryptionAlgorithm
+	externalPort   uint16
+	externalSecure bool
+}
+
+func newBackChannelLogoutProjection(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *query.Queries,
+	es *eventstore.Eventstore,
+	oidcKeyCrypto crypto.EncryptionAlgorithm,
+	externalSecure bool,
+	externalPort uint16,
+) *backChannelLogoutProjection {
+	p := new(backChannelLogoutProjection)
+	config.ProjectionName = BackChannelLogoutProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.commands = commands
+	p.queries = queries
+	p.es = es
+	p.oidcKeyCrypto = oidcKeyCrypto
+	p.externalPort = externalPort
+	p.externalSecure = externalSecure
+
+	// needs to be started here as it is not part of the projection.projections / projection.newProjectionsList()
+	p.Start()
+	return p
+}
+
+func (p *backChannelLogoutProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+			},
+		},
+	}
+}
+
+// logoutTarget is a client with a back-channel logout uri and the logout token to send
+type logoutTarget struct {
+	clientID  string
+	logoutURI string
+	token     string
+}
+
+// reduceSignedOut sends a logout token to the back-channel logout uri of every client
+// which had tokens in the terminated user agent session (OpenID Connect Back-Channel Logout).
+// The tokens are sent in parallel and every acknowledged delivery is stored as event.
+// Failed deliveries are logged per client and not retried,
+// so an unreachable client doesn't block the sign outs of the other users.
+func (p *backChannelLogoutProjection) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wq3pm", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	if len(e.ClientIDs) == 0 || e.CreationDate().Add(backChannelLogoutRetry).Before(time.Now().UTC()) {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	targets, err := p.logoutTargets(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target *logoutTarget) {
+			defer wg.Done()
+			p.sendBackChannelLogout(ctx, e, target)
+		}(target)
+	}
+	wg.Wait()
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// sendBackChannelLogout sends the logout token to a single client and stores the delivery
+func (p *backChannelLogoutProjection) sendBackChannelLogout(ctx context.Context, e *user.HumanSignedOutEvent, target *logoutTarget) {
+	if err := sendLogoutToken(ctx, target.logoutURI, target.token); err != nil {
+		logging.WithFields("instance", e.Aggregate().InstanceID, "user", e.Aggregate().ID, "client_id", target.clientID).WithError(err).Warn("back-channel logout failed")
+		return
+	}
+	err := p.commands.BackChannelLogoutSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.UserAgentID, target.clientID)
+	logging.WithFields("instance", e.Aggregate().InstanceID, "user", e.Aggregate().ID, "client_id", target.clientID).OnError(err).Warn("unable to store back-channel logout")
+}
+
+// logoutTargets returns the clients of the sign out with a back-channel logout uri, which did not yet receive the logout token
+func (p *backChannelLogoutProjection) logoutTargets(ctx context.Context, e *user.HumanSignedOutEvent) ([]*logoutTarget, error) {
+	sent, err := p.sentClientIDs(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	ctx, origin, err := instanceOrigin(ctx, p.queries, p.externalPort, p.externalSecure)
+	if err != nil {
+		return nil, err
+	}
+	var signer jose.Signer
+	targets := make([]*logoutTarget, 0, len(e.ClientIDs))
+	for _, clientID := range e.ClientIDs {
+		if _, ok := sent[clientID]; ok {
+			continue
+		}
+		app, err := p.queries.AppByOIDCClientID(ctx, clientID)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if app.OIDCConfig == nil || app.OIDCConfig.BackChannelLogoutURI == "" {
+			continue
+		}
+		if signer == nil {
+			signer, err = p.logoutTokenSigner(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		token, err := logoutToken(signer, origin, e.Aggregate().ID, clientID)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, &logoutTarget{clientID: clientID, logoutURI: app.OIDCConfig.BackChannelLogoutURI, token: token})
+	}
+	return targets, nil
+}
+
+// sentClientIDs returns the clients which already acknowledged the logout token of the sign out
+func (p *backChannelLogoutProjection) sentClientIDs(ctx context.Context, e *user.HumanSignedOutEvent) (map[string]struct{}, error) {
+	events, err := p.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(e.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(e.Aggregate().ID).
+			SequenceGreater(e.Sequence()).
+			EventTypes(user.HumanBackChannelLogoutSentType).
+			EventData(map[string]interface{}{"userAgentID": e.UserAgentID}).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sent := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		if sentEvent, ok := event.(*user.HumanBackChannelLogoutSentEvent); ok {
+			sent[sentEvent.ClientID] = struct{}{}
+		}
+	}
+	return sent, nil
+}
+
+// logoutTokenSigner returns a signer using the active signing key of the instance, which also signs the id_tokens
+func (p *backChannelLogoutProjection) logoutTokenSigner(ctx context.Context) (jose.Signer, error) {
+	keys, err := p.queries.ActivePrivateSigningKey(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "NOTIF-Kq8fn", "Errors.Notification.NoSigningKey")
+	}
+	key := keys.Keys[len(keys.Keys)-1]
+	keyData, err := crypto.Decrypt(key.Key(), p.oidcKeyCrypto)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	if err != nil {
+		return nil, err
+	}
+	return jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.SignatureAlgorithm(key.Algorithm()),
+			Key:       &jose.JSONWebKey{Key: privateKey, KeyID: key.ID()},
+		},
+		(&jose.SignerOptions{}).WithType(logoutTokenType),
+	)
+}
+
+func logoutToken(signer jose.Signer, issuer, userID, clientID string) (string, error) {
+	jti, err := id.SonyFlakeGenerator().Next()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	payload, err := json.Marshal(&logoutTokenClaims{
+		Issuer:     issuer,
+		Subject:    userID,
+		Audience:   []string{clientID},
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(backChannelLogoutTokenLifetime).Unix(),
+		JWTID:      jti,
+		Events:     map[string]interface{}{backChannelEvent: struct{}{}},
+	})
+	if err != nil {
+		return "", err
+	}
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return jws.CompactSerialize()
+}
+
+func sendLogoutToken(ctx context.Context, logoutURI, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, logoutURI, strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := backChannelLogoutClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnavailablef(nil, "NOTIF-Lb4xs", "back-channel logout responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// reduceMagicLinkCodeAdded sends the single-use login link to the verified email of the user
+func (p *notificationsProjection) reduceMagicLinkCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanMagicLinkCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mk8q2", "reduce.wrong.event.type %s", user.HumanMagicLinkCodeAddedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMagicLinkCodeAddedType, user.HumanMagicLinkCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, p.userDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := p.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var authRequestID string
+	if e.AuthRequestInfo != nil {
+		authRequestID = e.AuthRequestInfo.ID
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		p.getSMTPConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendMagicLink(notifyUser, origin, code, authRequestID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.commands.HumanMagicLinkCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// reduceOTPSMSCodeAdded sends the one-time code of the second factor to the verified phone of the user
+func (p *notificationsProjection) reduceOTPSMSCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPSMSCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Asf3g", "reduce.wrong.event.type %s", user.HumanMFAOTPSMSCodeAddedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPSMSCodeAddedType, user.HumanMFAOTPSMSCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, p.userDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendSMSTwilio(
+		ctx,
+		translator,
+		notifyUser,
+		p.getTwilioConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendOTPSMSCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = p.commands.HumanOTPSMSCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// reduceOTPEmailCodeAdded sends the one-time code of the second factor to the verified email of the user
+func (p *notificationsProjection) reduceOTPEmailCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPEmailCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-JL3hw", "reduce.wrong.event.type %s", user.HumanMFAOTPEmailCodeAddedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPEmailCodeAddedType, user.HumanMFAOTPEmailCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, p.userDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := p.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		p.getSMTPConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendOTPEmailCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = p.commands.HumanOTPEmailCodeSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
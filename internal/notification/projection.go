@@ -34,11 +34,12 @@ const (
 	NotifyUserID                 = "NOTIFICATION" //TODO: system?
 )
 
-func Start(ctx context.Context, customConfig projection.CustomConfig, externalPort uint16, externalSecure bool, commands *command.Commands, queries *query.Queries, es *eventstore.Eventstore, assetsPrefix func(context.Context) string, fileSystemPath string, userEncryption, smtpEncryption, smsEncryption crypto.EncryptionAlgorithm) {
+func Start(ctx context.Context, customConfig projection.CustomConfig, externalPort uint16, externalSecure bool, commands *command.Commands, queries *query.Queries, es *eventstore.Eventstore, assetsPrefix func(context.Context) string, fileSystemPath string, userEncryption, smtpEncryption, smsEncryption, oidcKeyEncryption crypto.EncryptionAlgorithm) {
 	statikFS, err := statik_fs.NewWithNamespace("notification")
 	logging.OnError(err).Panic("unable to start listener")
 
 	projection.NotificationsProjection = newNotificationsProjection(ctx, projection.ApplyCustomConfig(customConfig), commands, queries, es, userEncryption, smtpEncryption, smsEncryption, externalSecure, externalPort, fileSystemPath, assetsPrefix, statikFS)
+	newBackChannelLogoutProjection(ctx, projection.ApplyCustomConfig(customConfig), commands, queries, es, oidcKeyEncryption, externalSecure, externalPort)
 }
 
 type notificationsProjection struct {
@@ -137,6 +138,26 @@ func (p *notificationsProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPhoneCodeAddedType,
 					Reduce: p.reducePhoneCodeAdded,
 				},
+				{
+					Event:  user.HumanRefreshTokenReusedType,
+					Reduce: p.reduceRefreshTokenReused,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSCodeAddedType,
+					Reduce: p.reduceOTPSMSCodeAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCodeAddedType,
+					Reduce: p.reduceOTPEmailCodeAdded,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckSucceededType,
+					Reduce: p.reduceRecoveryCodeUsed,
+				},
+				{
+					Event:  user.HumanMagicLinkCodeAddedType,
+					Reduce: p.reduceMagicLinkCodeAdded,
+				},
 			},
 		},
 	}
@@ -643,11 +664,16 @@ func (p *notificationsProjection) getTranslatorWithOrgTexts(ctx context.Context,
 }
 
 func (p *notificationsProjection) origin(ctx context.Context) (context.Context, string, error) {
+	return instanceOrigin(ctx, p.queries, p.externalPort, p.externalSecure)
+}
+
+// instanceOrigin sets the primary domain of the instance as requested domain and returns the origin of it
+func instanceOrigin(ctx context.Context, queries *query.Queries, externalPort uint16, externalSecure bool) (context.Context, string, error) {
 	primary, err := query.NewInstanceDomainPrimarySearchQuery(true)
 	if err != nil {
 		return ctx, "", err
 	}
-	domains, err := p.queries.SearchInstanceDomains(ctx, &query.InstanceDomainSearchQueries{
+	domains, err := queries.SearchInstanceDomains(ctx, &query.InstanceDomainSearchQueries{
 		Queries: []query.SearchQuery{primary},
 	})
 	if err != nil {
@@ -657,7 +683,7 @@ func (p *notificationsProjection) origin(ctx context.Context) (context.Context,
 		return ctx, "", errors.ThrowInternal(nil, "NOTIF-Ef3r1", "Errors.Notification.NoDomain")
 	}
 	ctx = authz.WithRequestedDomain(ctx, domains.Domains[0].Domain)
-	return ctx, http_utils.BuildHTTP(domains.Domains[0].Domain, p.externalPort, p.externalSecure), nil
+	return ctx, http_utils.BuildHTTP(domains.Domains[0].Domain, externalPort, externalSecure), nil
 }
 
 func setNotificationContext(event eventstore.Aggregate) context.Context {
@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// reduceRecoveryCodeUsed informs the user about the used recovery code,
+// so a login with a stolen code doesn't go unnoticed.
+// The user is only notified if the email is verified, as the mail might otherwise reach a stranger.
+func (p *notificationsProjection) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rc6oe", "reduce.wrong.event.type %s", user.HumanMFARecoveryCodeCheckSucceededType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfAlreadyHandled(ctx, event, map[string]interface{}{"codeId": e.CodeID},
+		user.HumanMFARecoveryCodeNotificationSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	if notifyUser.VerifiedEmail != "" {
+		colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		template, err := p.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RecoveryCodeUsedMessageType)
+		if err != nil {
+			return nil, err
+		}
+		ctx, origin, err := p.origin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		err = types.SendEmail(
+			ctx,
+			string(template.Template),
+			translator,
+			notifyUser,
+			p.getSMTPConfig,
+			p.getFileSystemProvider,
+			p.getLogProvider,
+			colors,
+			p.assetsPrefix(ctx),
+		).SendRecoveryCodeUsed(notifyUser, origin)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.commands.HumanRecoveryCodeNotificationSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.CodeID)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
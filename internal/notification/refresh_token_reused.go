@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// reduceRefreshTokenReused informs the user about the revoked refresh token family
+// and runs the actions of the security event flow, which can e.g. call a webhook.
+// The user is only notified if the email is verified, as the mail might otherwise reach a stranger.
+func (p *notificationsProjection) reduceRefreshTokenReused(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenReusedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rk7ls", "reduce.wrong.event.type %s", user.HumanRefreshTokenReusedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfAlreadyHandled(ctx, event, map[string]interface{}{"tokenId": e.TokenID},
+		user.HumanRefreshTokenReuseNotificationSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	if notifyUser.VerifiedEmail != "" {
+		if err = p.sendRefreshTokenReused(ctx, e, notifyUser); err != nil {
+			return nil, err
+		}
+	}
+	err = p.commands.RefreshTokenReuseNotificationSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID, e.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	p.runSecurityEventActions(ctx, e, domain.TriggerTypeRefreshTokenReused)
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (p *notificationsProjection) sendRefreshTokenReused(ctx context.Context, e *user.HumanRefreshTokenReusedEvent, notifyUser *query.NotifyUser) error {
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return err
+	}
+	template, err := p.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.RefreshTokenReusedMessageType)
+	if err != nil {
+		return err
+	}
+	applicationName := e.ClientID
+	if app, err := p.queries.AppByClientID(ctx, e.ClientID); err == nil {
+		applicationName = app.Name
+	}
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return err
+	}
+	return types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		p.getSMTPConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendRefreshTokenReused(notifyUser, origin, applicationName)
+}
+
+// runSecurityEventActions runs the actions of the security event flow of the organisation of the user.
+// Failing actions are only logged, as the refresh token family is already revoked and the user notified.
+func (p *notificationsProjection) runSecurityEventActions(ctx context.Context, e *user.HumanRefreshTokenReusedEvent, triggerType domain.TriggerType) {
+	queriedActions, err := p.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeSecurityEvent, triggerType, e.Aggregate().ResourceOwner)
+	if err != nil {
+		logging.WithFields("userID", e.Aggregate().ID).WithError(err).Warn("unable to get security event actions")
+		return
+	}
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("event",
+				actions.SetFields("type", string(e.Type())),
+				actions.SetFields("creationDate", e.CreationDate()),
+			),
+			actions.SetFields("user",
+				actions.SetFields("id", e.Aggregate().ID),
+				actions.SetFields("resourceOwner", e.Aggregate().ResourceOwner),
+			),
+			actions.SetFields("refreshToken",
+				actions.SetFields("id", e.TokenID),
+				actions.SetFields("clientId", e.ClientID),
+				actions.SetFields("userAgentId", e.UserAgentID),
+			),
+		),
+	)
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			nil,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithLogger(actions.ServerLog))...,
+		)
+		cancel()
+		logging.WithFields("userID", e.Aggregate().ID, "action", action.Name).OnError(err).Warn("security event action failed")
+	}
+}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendMagicLink(user *query.NotifyUser, origin, code, authRequestID string) error {
+	url := login.MagicLinkLink(origin, user.ID, user.ResourceOwner, authRequestID, code)
+	return notify(url, nil, domain.MagicLinkMessageType, false)
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendOTPSMSCode(user *query.NotifyUser, origin, code string) error {
+	args := make(map[string]interface{})
+	args["Code"] = code
+	return notify("", args, domain.VerifySMSOTPMessageType, false)
+}
+
+func (notify Notify) SendOTPEmailCode(user *query.NotifyUser, origin, code string) error {
+	url := login.LoginLink(origin, user.ResourceOwner)
+	args := make(map[string]interface{})
+	args["Code"] = code
+	return notify(url, args, domain.VerifyEmailOTPMessageType, false)
+}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendRecoveryCodeUsed(user *query.NotifyUser, origin string) error {
+	url := login.LoginLink(origin, user.ResourceOwner)
+	return notify(url, nil, domain.RecoveryCodeUsedMessageType, false)
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendRefreshTokenReused(user *query.NotifyUser, origin, applicationName string) error {
+	url := login.LoginLink(origin, user.ResourceOwner)
+	args := make(map[string]interface{})
+	args["ApplicationName"] = applicationName
+	return notify(url, args, domain.RefreshTokenReusedMessageType, false)
+}
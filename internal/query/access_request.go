@@ -0,0 +1,274 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type AccessRequest struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	State         domain.AccessRequestState
+
+	UserID         string
+	ProjectID      string
+	RoleKeys       database.StringArray
+	Reason         string
+	DecisionReason string
+	UserGrantID    string
+}
+
+type AccessRequests struct {
+	SearchResponse
+	AccessRequests []*AccessRequest
+}
+
+type AccessRequestSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *AccessRequestSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewAccessRequestProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnProjectID, value, TextEquals)
+}
+
+func NewAccessRequestUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnUserID, value, TextEquals)
+}
+
+func NewAccessRequestResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnResourceOwner, value, TextEquals)
+}
+
+func NewAccessRequestStateSearchQuery(value domain.AccessRequestState) (SearchQuery, error) {
+	return NewNumberQuery(AccessRequestColumnState, int(value), NumberEquals)
+}
+
+var (
+	accessRequestsTable = table{
+		name:          projection.AccessRequestProjectionTable,
+		instanceIDCol: projection.AccessRequestInstanceID,
+	}
+	AccessRequestColumnID = Column{
+		name:  projection.AccessRequestID,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnCreationDate = Column{
+		name:  projection.AccessRequestCreationDate,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnChangeDate = Column{
+		name:  projection.AccessRequestChangeDate,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnSequence = Column{
+		name:  projection.AccessRequestSequence,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnState = Column{
+		name:  projection.AccessRequestState,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnResourceOwner = Column{
+		name:  projection.AccessRequestResourceOwner,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnInstanceID = Column{
+		name:  projection.AccessRequestInstanceID,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnUserID = Column{
+		name:  projection.AccessRequestUserID,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnProjectID = Column{
+		name:  projection.AccessRequestProjectID,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnRoleKeys = Column{
+		name:  projection.AccessRequestRoleKeys,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnReason = Column{
+		name:  projection.AccessRequestReason,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnDecisionReason = Column{
+		name:  projection.AccessRequestDecisionReason,
+		table: accessRequestsTable,
+	}
+	AccessRequestColumnUserGrantID = Column{
+		name:  projection.AccessRequestUserGrantID,
+		table: accessRequestsTable,
+	}
+)
+
+func (q *Queries) AccessRequestByID(ctx context.Context, shouldTriggerBulk bool, id string, queries ...SearchQuery) (_ *AccessRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.AccessRequestProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareAccessRequestQuery()
+	for _, q := range queries {
+		query = q.toQuery(query)
+	}
+	stmt, args, err := query.
+		Where(sq.Eq{
+			AccessRequestColumnID.identifier():         id,
+			AccessRequestColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Jd8sw", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchAccessRequests(ctx context.Context, queries *AccessRequestSearchQueries) (requests *AccessRequests, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareAccessRequestsQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			AccessRequestColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Tq3bn", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cm2vk", "Errors.Internal")
+	}
+	requests, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	requests.LatestSequence, err = q.latestSequence(ctx, accessRequestsTable)
+	return requests, err
+}
+
+func prepareAccessRequestQuery() (sq.SelectBuilder, func(*sql.Row) (*AccessRequest, error)) {
+	return sq.Select(
+			AccessRequestColumnID.identifier(),
+			AccessRequestColumnCreationDate.identifier(),
+			AccessRequestColumnChangeDate.identifier(),
+			AccessRequestColumnSequence.identifier(),
+			AccessRequestColumnResourceOwner.identifier(),
+			AccessRequestColumnState.identifier(),
+			AccessRequestColumnUserID.identifier(),
+			AccessRequestColumnProjectID.identifier(),
+			AccessRequestColumnRoleKeys.identifier(),
+			AccessRequestColumnReason.identifier(),
+			AccessRequestColumnDecisionReason.identifier(),
+			AccessRequestColumnUserGrantID.identifier(),
+		).
+			From(accessRequestsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*AccessRequest, error) {
+			r := new(AccessRequest)
+			err := row.Scan(
+				&r.ID,
+				&r.CreationDate,
+				&r.ChangeDate,
+				&r.Sequence,
+				&r.ResourceOwner,
+				&r.State,
+				&r.UserID,
+				&r.ProjectID,
+				&r.RoleKeys,
+				&r.Reason,
+				&r.DecisionReason,
+				&r.UserGrantID,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Xs2mf", "Errors.AccessRequest.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Pw8la", "Errors.Internal")
+			}
+			return r, nil
+		}
+}
+
+func prepareAccessRequestsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AccessRequests, error)) {
+	return sq.Select(
+			AccessRequestColumnID.identifier(),
+			AccessRequestColumnCreationDate.identifier(),
+			AccessRequestColumnChangeDate.identifier(),
+			AccessRequestColumnSequence.identifier(),
+			AccessRequestColumnResourceOwner.identifier(),
+			AccessRequestColumnState.identifier(),
+			AccessRequestColumnUserID.identifier(),
+			AccessRequestColumnProjectID.identifier(),
+			AccessRequestColumnRoleKeys.identifier(),
+			AccessRequestColumnReason.identifier(),
+			AccessRequestColumnDecisionReason.identifier(),
+			AccessRequestColumnUserGrantID.identifier(),
+			countColumn.identifier(),
+		).
+			From(accessRequestsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*AccessRequests, error) {
+			requests := make([]*AccessRequest, 0)
+			var count uint64
+			for rows.Next() {
+				r := new(AccessRequest)
+				err := rows.Scan(
+					&r.ID,
+					&r.CreationDate,
+					&r.ChangeDate,
+					&r.Sequence,
+					&r.ResourceOwner,
+					&r.State,
+					&r.UserID,
+					&r.ProjectID,
+					&r.RoleKeys,
+					&r.Reason,
+					&r.DecisionReason,
+					&r.UserGrantID,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				requests = append(requests, r)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Vr4ne", "Errors.Query.CloseRows")
+			}
+
+			return &AccessRequests{
+				AccessRequests: requests,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
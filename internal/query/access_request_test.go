@@ -0,0 +1,207 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	accessRequestStmt = regexp.QuoteMeta(`SELECT projections.access_requests.id,` +
+		` projections.access_requests.creation_date,` +
+		` projections.access_requests.change_date,` +
+		` projections.access_requests.sequence,` +
+		` projections.access_requests.resource_owner,` +
+		` projections.access_requests.state,` +
+		` projections.access_requests.user_id,` +
+		` projections.access_requests.project_id,` +
+		` projections.access_requests.role_keys,` +
+		` projections.access_requests.reason,` +
+		` projections.access_requests.decision_reason,` +
+		` projections.access_requests.user_grant_id` +
+		` FROM projections.access_requests`)
+	accessRequestCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"state",
+		"user_id",
+		"project_id",
+		"role_keys",
+		"reason",
+		"decision_reason",
+		"user_grant_id",
+	}
+	accessRequestsStmt = regexp.QuoteMeta(`SELECT projections.access_requests.id,` +
+		` projections.access_requests.creation_date,` +
+		` projections.access_requests.change_date,` +
+		` projections.access_requests.sequence,` +
+		` projections.access_requests.resource_owner,` +
+		` projections.access_requests.state,` +
+		` projections.access_requests.user_id,` +
+		` projections.access_requests.project_id,` +
+		` projections.access_requests.role_keys,` +
+		` projections.access_requests.reason,` +
+		` projections.access_requests.decision_reason,` +
+		` projections.access_requests.user_grant_id,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.access_requests`)
+	accessRequestsCols = append(accessRequestCols, "count")
+)
+
+func Test_AccessRequestPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAccessRequestQuery no result",
+			prepare: prepareAccessRequestQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					accessRequestStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AccessRequest)(nil),
+		},
+		{
+			name:    "prepareAccessRequestQuery found",
+			prepare: prepareAccessRequestQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					accessRequestStmt,
+					accessRequestCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						domain.AccessRequestStateApproved,
+						"user-id",
+						"project-id",
+						database.StringArray{"role-key"},
+						"reason",
+						"decision",
+						"grant-id",
+					},
+				),
+			},
+			object: &AccessRequest{
+				ID:             "id",
+				CreationDate:   testNow,
+				ChangeDate:     testNow,
+				Sequence:       20211108,
+				ResourceOwner:  "ro",
+				State:          domain.AccessRequestStateApproved,
+				UserID:         "user-id",
+				ProjectID:      "project-id",
+				RoleKeys:       database.StringArray{"role-key"},
+				Reason:         "reason",
+				DecisionReason: "decision",
+				UserGrantID:    "grant-id",
+			},
+		},
+		{
+			name:    "prepareAccessRequestsQuery no result",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					accessRequestsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &AccessRequests{AccessRequests: []*AccessRequest{}},
+		},
+		{
+			name:    "prepareAccessRequestsQuery one result",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					accessRequestsStmt,
+					accessRequestsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							domain.AccessRequestStatePending,
+							"user-id",
+							"project-id",
+							database.StringArray{"role-key"},
+							"",
+							"",
+							"",
+						},
+					},
+				),
+			},
+			object: &AccessRequests{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				AccessRequests: []*AccessRequest{
+					{
+						ID:            "id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+						ResourceOwner: "ro",
+						State:         domain.AccessRequestStatePending,
+						UserID:        "user-id",
+						ProjectID:     "project-id",
+						RoleKeys:      database.StringArray{"role-key"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAccessRequestsQuery sql err",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					accessRequestsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// DueValidity is a user grant or a membership whose validity requires a state change
+// the queries are executed over all instances
+type DueValidity struct {
+	InstanceID    string
+	ResourceOwner string
+	AggregateID   string
+	UserID        string
+}
+
+// DueUserGrants returns the active user grants which expired
+// and the inactive user grants which were deactivated until their validity starts and became valid
+func (q *Queries) DueUserGrants(ctx context.Context, now time.Time, limit uint64) (_ []*DueValidity, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.dueValidities(ctx, prepareDueUserGrantsQuery(now), limit)
+}
+
+// ExpiredOrgMembers returns the org members whose validity ended
+func (q *Queries) ExpiredOrgMembers(ctx context.Context, now time.Time, limit uint64) (_ []*DueValidity, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.dueValidities(ctx, prepareExpiredOrgMembersQuery(now), limit)
+}
+
+// ExpiredProjectMembers returns the project members whose validity ended
+func (q *Queries) ExpiredProjectMembers(ctx context.Context, now time.Time, limit uint64) (_ []*DueValidity, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.dueValidities(ctx, prepareExpiredProjectMembersQuery(now), limit)
+}
+
+func (q *Queries) dueValidities(ctx context.Context, query sq.SelectBuilder, limit uint64) ([]*DueValidity, error) {
+	stmt, args, err := query.Limit(limit).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Bm8sd", "Errors.Query.SQLStatement")
+	}
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Qo4xa", "Errors.Internal")
+	}
+	return scanDueValidities(rows)
+}
+
+func prepareDueUserGrantsQuery(now time.Time) sq.SelectBuilder {
+	return sq.Select(
+		UserGrantInstanceID.identifier(),
+		UserGrantResourceOwner.identifier(),
+		UserGrantID.identifier(),
+		UserGrantUserID.identifier(),
+	).
+		From(userGrantTable.identifier()).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{UserGrantState.identifier(): domain.UserGrantStateActive},
+				sq.LtOrEq{UserGrantValidUntil.identifier(): now},
+			},
+			// the grant was deactivated before its validity started
+			sq.And{
+				sq.Eq{UserGrantState.identifier(): domain.UserGrantStateInactive},
+				sq.LtOrEq{UserGrantValidFrom.identifier(): now},
+				sq.Expr(UserGrantValidFrom.identifier() + " > " + UserGrantChangeDate.identifier()),
+				sq.Or{
+					sq.Eq{UserGrantValidUntil.identifier(): nil},
+					sq.Gt{UserGrantValidUntil.identifier(): now},
+				},
+			},
+		}).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func prepareExpiredOrgMembersQuery(now time.Time) sq.SelectBuilder {
+	return sq.Select(
+		OrgMemberInstanceID.identifier(),
+		OrgMemberResourceOwner.identifier(),
+		OrgMemberOrgID.identifier(),
+		OrgMemberUserID.identifier(),
+	).
+		From(orgMemberTable.identifier()).
+		Where(sq.LtOrEq{OrgMemberValidUntil.identifier(): now}).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func prepareExpiredProjectMembersQuery(now time.Time) sq.SelectBuilder {
+	return sq.Select(
+		ProjectMemberInstanceID.identifier(),
+		ProjectMemberResourceOwner.identifier(),
+		ProjectMemberProjectID.identifier(),
+		ProjectMemberUserID.identifier(),
+	).
+		From(projectMemberTable.identifier()).
+		Where(sq.LtOrEq{ProjectMemberValidUntil.identifier(): now}).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func scanDueValidities(rows *sql.Rows) ([]*DueValidity, error) {
+	due := make([]*DueValidity, 0)
+	for rows.Next() {
+		d := new(DueValidity)
+		err := rows.Scan(
+			&d.InstanceID,
+			&d.ResourceOwner,
+			&d.AggregateID,
+			&d.UserID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ht5gn", "Errors.Query.CloseRows")
+	}
+	return due, nil
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_dueValidityQueries(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    sq.SelectBuilder
+		wantStmt string
+		wantArgs []interface{}
+	}{
+		{
+			name:  "due user grants",
+			query: prepareDueUserGrantsQuery(now),
+			wantStmt: "SELECT projections.user_grants3.instance_id, projections.user_grants3.resource_owner, projections.user_grants3.id, projections.user_grants3.user_id" +
+				" FROM projections.user_grants3" +
+				" WHERE ((projections.user_grants3.state = $1 AND projections.user_grants3.valid_until <= $2)" +
+				" OR (projections.user_grants3.state = $3 AND projections.user_grants3.valid_from <= $4 AND projections.user_grants3.valid_from > projections.user_grants3.change_date" +
+				" AND (projections.user_grants3.valid_until IS NULL OR projections.user_grants3.valid_until > $5)))",
+			wantArgs: []interface{}{domain.UserGrantStateActive, now, domain.UserGrantStateInactive, now, now},
+		},
+		{
+			name:  "expired org members",
+			query: prepareExpiredOrgMembersQuery(now),
+			wantStmt: "SELECT members.instance_id, members.resource_owner, members.org_id, members.user_id" +
+				" FROM projections.org_members3 AS members" +
+				" WHERE members.valid_until <= $1",
+			wantArgs: []interface{}{now},
+		},
+		{
+			name:  "expired project members",
+			query: prepareExpiredProjectMembersQuery(now),
+			wantStmt: "SELECT members.instance_id, members.resource_owner, members.project_id, members.user_id" +
+				" FROM projections.project_members3 AS members" +
+				" WHERE members.valid_until <= $1",
+			wantArgs: []interface{}{now},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, args, err := tt.query.ToSql()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stmt != tt.wantStmt {
+				t.Errorf("wrong stmt:\nwant %q\ngot  %q", tt.wantStmt, stmt)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("wrong args: want %v got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
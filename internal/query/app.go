@@ -38,23 +38,28 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs           database.StringArray
-	ResponseTypes          database.EnumArray[domain.OIDCResponseType]
-	GrantTypes             database.EnumArray[domain.OIDCGrantType]
-	AppType                domain.OIDCApplicationType
-	ClientID               string
-	AuthMethodType         domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs database.StringArray
-	Version                domain.OIDCVersion
-	ComplianceProblems     database.StringArray
-	IsDevMode              bool
-	AccessTokenType        domain.OIDCTokenType
-	AssertAccessTokenRole  bool
-	AssertIDTokenRole      bool
-	AssertIDTokenUserinfo  bool
-	ClockSkew              time.Duration
-	AdditionalOrigins      database.StringArray
-	AllowedOrigins         database.StringArray
+	RedirectURIs                       database.StringArray
+	ResponseTypes                      database.EnumArray[domain.OIDCResponseType]
+	GrantTypes                         database.EnumArray[domain.OIDCGrantType]
+	AppType                            domain.OIDCApplicationType
+	ClientID                           string
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs             database.StringArray
+	Version                            domain.OIDCVersion
+	ComplianceProblems                 database.StringArray
+	IsDevMode                          bool
+	AccessTokenType                    domain.OIDCTokenType
+	AssertAccessTokenRole              bool
+	AssertIDTokenRole                  bool
+	AssertIDTokenUserinfo              bool
+	ClockSkew                          time.Duration
+	AdditionalOrigins                  database.StringArray
+	AllowedOrigins                     database.StringArray
+	RequirePushedAuthorizationRequests bool
+	JWKS                               string
+	BackChannelLogoutURI               string
+	FrontChannelLogoutURI              string
+	domain.AppTokenSettings
 }
 
 type SAMLApp struct {
@@ -66,6 +71,7 @@ type SAMLApp struct {
 type APIApp struct {
 	ClientID       string
 	AuthMethodType domain.APIAuthMethodType
+	domain.AppTokenSettings
 }
 
 type AppSearchQueries struct {
@@ -164,6 +170,26 @@ var (
 		name:  projection.AppAPIConfigColumnAuthMethod,
 		table: appAPIConfigsTable,
 	}
+	AppAPIConfigColumnAccessTokenLifetime = Column{
+		name:  projection.AppAPIConfigColumnAccessTokenLifetime,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnIDTokenLifetime = Column{
+		name:  projection.AppAPIConfigColumnIDTokenLifetime,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnRefreshTokenIdleExpiration = Column{
+		name:  projection.AppAPIConfigColumnRefreshTokenIdleExpiration,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnRefreshTokenExpiration = Column{
+		name:  projection.AppAPIConfigColumnRefreshTokenExpiration,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnRefreshTokenRotation = Column{
+		name:  projection.AppAPIConfigColumnRefreshTokenRotation,
+		table: appAPIConfigsTable,
+	}
 )
 
 var (
@@ -235,6 +261,42 @@ var (
 		name:  projection.AppOIDCConfigColumnAdditionalOrigins,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRequirePushedAuthorizationRequests = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthorizationRequests,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnJWKS = Column{
+		name:  projection.AppOIDCConfigColumnJWKS,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnFrontChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnFrontChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnAccessTokenLifetime = Column{
+		name:  projection.AppOIDCConfigColumnAccessTokenLifetime,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnIDTokenLifetime = Column{
+		name:  projection.AppOIDCConfigColumnIDTokenLifetime,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenIdleExpiration = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenIdleExpiration,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenExpiration = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenExpiration,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenRotation = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenRotation,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (*App, error) {
@@ -458,6 +520,11 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppAPIConfigColumnAppID.identifier(),
 			AppAPIConfigColumnClientID.identifier(),
 			AppAPIConfigColumnAuthMethod.identifier(),
+			AppAPIConfigColumnAccessTokenLifetime.identifier(),
+			AppAPIConfigColumnIDTokenLifetime.identifier(),
+			AppAPIConfigColumnRefreshTokenIdleExpiration.identifier(),
+			AppAPIConfigColumnRefreshTokenExpiration.identifier(),
+			AppAPIConfigColumnRefreshTokenRotation.identifier(),
 
 			AppOIDCConfigColumnAppID.identifier(),
 			AppOIDCConfigColumnVersion.identifier(),
@@ -475,6 +542,15 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnIDTokenUserinfoAssertion.identifier(),
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthorizationRequests.identifier(),
+			AppOIDCConfigColumnJWKS.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnAccessTokenLifetime.identifier(),
+			AppOIDCConfigColumnIDTokenLifetime.identifier(),
+			AppOIDCConfigColumnRefreshTokenIdleExpiration.identifier(),
+			AppOIDCConfigColumnRefreshTokenExpiration.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -506,6 +582,11 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&apiConfig.appID,
 				&apiConfig.clientID,
 				&apiConfig.authMethod,
+				&apiConfig.tokenSettings.accessTokenLifetime,
+				&apiConfig.tokenSettings.iDTokenLifetime,
+				&apiConfig.tokenSettings.refreshTokenIdleExpiration,
+				&apiConfig.tokenSettings.refreshTokenExpiration,
+				&apiConfig.tokenSettings.refreshTokenRotation,
 
 				&oidcConfig.appID,
 				&oidcConfig.version,
@@ -523,6 +604,15 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.iDTokenUserinfoAssertion,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
+				&oidcConfig.requirePushedAuthorizationRequests,
+				&oidcConfig.jwks,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.frontChannelLogoutURI,
+				&oidcConfig.tokenSettings.accessTokenLifetime,
+				&oidcConfig.tokenSettings.iDTokenLifetime,
+				&oidcConfig.tokenSettings.refreshTokenIdleExpiration,
+				&oidcConfig.tokenSettings.refreshTokenExpiration,
+				&oidcConfig.tokenSettings.refreshTokenRotation,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
@@ -626,6 +716,11 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppAPIConfigColumnAppID.identifier(),
 			AppAPIConfigColumnClientID.identifier(),
 			AppAPIConfigColumnAuthMethod.identifier(),
+			AppAPIConfigColumnAccessTokenLifetime.identifier(),
+			AppAPIConfigColumnIDTokenLifetime.identifier(),
+			AppAPIConfigColumnRefreshTokenIdleExpiration.identifier(),
+			AppAPIConfigColumnRefreshTokenExpiration.identifier(),
+			AppAPIConfigColumnRefreshTokenRotation.identifier(),
 
 			AppOIDCConfigColumnAppID.identifier(),
 			AppOIDCConfigColumnVersion.identifier(),
@@ -643,6 +738,15 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppOIDCConfigColumnIDTokenUserinfoAssertion.identifier(),
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthorizationRequests.identifier(),
+			AppOIDCConfigColumnJWKS.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnAccessTokenLifetime.identifier(),
+			AppOIDCConfigColumnIDTokenLifetime.identifier(),
+			AppOIDCConfigColumnRefreshTokenIdleExpiration.identifier(),
+			AppOIDCConfigColumnRefreshTokenExpiration.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -677,6 +781,11 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&apiConfig.appID,
 					&apiConfig.clientID,
 					&apiConfig.authMethod,
+					&apiConfig.tokenSettings.accessTokenLifetime,
+					&apiConfig.tokenSettings.iDTokenLifetime,
+					&apiConfig.tokenSettings.refreshTokenIdleExpiration,
+					&apiConfig.tokenSettings.refreshTokenExpiration,
+					&apiConfig.tokenSettings.refreshTokenRotation,
 
 					&oidcConfig.appID,
 					&oidcConfig.version,
@@ -694,6 +803,15 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&oidcConfig.iDTokenUserinfoAssertion,
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
+					&oidcConfig.requirePushedAuthorizationRequests,
+					&oidcConfig.jwks,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.frontChannelLogoutURI,
+					&oidcConfig.tokenSettings.accessTokenLifetime,
+					&oidcConfig.tokenSettings.iDTokenLifetime,
+					&oidcConfig.tokenSettings.refreshTokenIdleExpiration,
+					&oidcConfig.tokenSettings.refreshTokenExpiration,
+					&oidcConfig.tokenSettings.refreshTokenRotation,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -749,22 +867,27 @@ func prepareClientIDsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]string, error
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.StringArray
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.StringArray
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.StringArray
-	responseTypes            database.EnumArray[domain.OIDCResponseType]
-	grantTypes               database.EnumArray[domain.OIDCGrantType]
+	appID                              sql.NullString
+	version                            sql.NullInt32
+	clientID                           sql.NullString
+	redirectUris                       database.StringArray
+	applicationType                    sql.NullInt16
+	authMethodType                     sql.NullInt16
+	postLogoutRedirectUris             database.StringArray
+	devMode                            sql.NullBool
+	accessTokenType                    sql.NullInt16
+	accessTokenRoleAssertion           sql.NullBool
+	iDTokenRoleAssertion               sql.NullBool
+	iDTokenUserinfoAssertion           sql.NullBool
+	clockSkew                          sql.NullInt64
+	additionalOrigins                  database.StringArray
+	requirePushedAuthorizationRequests sql.NullBool
+	jwks                               sql.NullString
+	backChannelLogoutURI               sql.NullString
+	frontChannelLogoutURI              sql.NullString
+	tokenSettings                      sqlTokenSettings
+	responseTypes                      database.EnumArray[domain.OIDCResponseType]
+	grantTypes                         database.EnumArray[domain.OIDCGrantType]
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -772,21 +895,26 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                domain.OIDCVersion(c.version.Int32),
-		ClientID:               c.clientID.String,
-		RedirectURIs:           c.redirectUris,
-		AppType:                domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:         domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs: c.postLogoutRedirectUris,
-		IsDevMode:              c.devMode.Bool,
-		AccessTokenType:        domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:  c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:      c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:  c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:              time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:      c.additionalOrigins,
-		ResponseTypes:          c.responseTypes,
-		GrantTypes:             c.grantTypes,
+		Version:                            domain.OIDCVersion(c.version.Int32),
+		ClientID:                           c.clientID.String,
+		RedirectURIs:                       c.redirectUris,
+		AppType:                            domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:                     domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:             c.postLogoutRedirectUris,
+		IsDevMode:                          c.devMode.Bool,
+		AccessTokenType:                    domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:              c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:                  c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:              c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                          time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:                  c.additionalOrigins,
+		RequirePushedAuthorizationRequests: c.requirePushedAuthorizationRequests.Bool,
+		JWKS:                               c.jwks.String,
+		BackChannelLogoutURI:               c.backChannelLogoutURI.String,
+		FrontChannelLogoutURI:              c.frontChannelLogoutURI.String,
+		AppTokenSettings:                   c.tokenSettings.set(),
+		ResponseTypes:                      c.responseTypes,
+		GrantTypes:                         c.grantTypes,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -815,9 +943,10 @@ func (c sqlSAMLConfig) set(app *App) {
 }
 
 type sqlAPIConfig struct {
-	appID      sql.NullString
-	clientID   sql.NullString
-	authMethod sql.NullInt16
+	appID         sql.NullString
+	clientID      sql.NullString
+	authMethod    sql.NullInt16
+	tokenSettings sqlTokenSettings
 }
 
 func (c sqlAPIConfig) set(app *App) {
@@ -825,7 +954,26 @@ func (c sqlAPIConfig) set(app *App) {
 		return
 	}
 	app.APIConfig = &APIApp{
-		ClientID:       c.clientID.String,
-		AuthMethodType: domain.APIAuthMethodType(c.authMethod.Int16),
+		ClientID:         c.clientID.String,
+		AuthMethodType:   domain.APIAuthMethodType(c.authMethod.Int16),
+		AppTokenSettings: c.tokenSettings.set(),
+	}
+}
+
+type sqlTokenSettings struct {
+	accessTokenLifetime        sql.NullInt64
+	iDTokenLifetime            sql.NullInt64
+	refreshTokenIdleExpiration sql.NullInt64
+	refreshTokenExpiration     sql.NullInt64
+	refreshTokenRotation       sql.NullInt16
+}
+
+func (c sqlTokenSettings) set() domain.AppTokenSettings {
+	return domain.AppTokenSettings{
+		AccessTokenLifetime:        time.Duration(c.accessTokenLifetime.Int64),
+		IDTokenLifetime:            time.Duration(c.iDTokenLifetime.Int64),
+		RefreshTokenIdleExpiration: time.Duration(c.refreshTokenIdleExpiration.Int64),
+		RefreshTokenExpiration:     time.Duration(c.refreshTokenExpiration.Int64),
+		RefreshTokenRotation:       domain.RefreshTokenRotation(c.refreshTokenRotation.Int16),
 	}
 }
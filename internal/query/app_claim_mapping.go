@@ -0,0 +1,213 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type ClaimMapping struct {
+	AppID         string
+	ProjectID     string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	Claim       string
+	Source      domain.ClaimMappingSource
+	SourceKey   string
+	Type        domain.ClaimMappingType
+	IDToken     bool
+	AccessToken bool
+	Userinfo    bool
+}
+
+type ClaimMappings struct {
+	SearchResponse
+	ClaimMappings []*ClaimMapping
+}
+
+var (
+	appClaimMappingsTable = table{
+		name:          projection.AppClaimMappingTable,
+		instanceIDCol: projection.AppClaimMappingColumnInstanceID,
+	}
+	AppClaimMappingColumnAppID = Column{
+		name:  projection.AppClaimMappingColumnAppID,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnInstanceID = Column{
+		name:  projection.AppClaimMappingColumnInstanceID,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnProjectID = Column{
+		name:  projection.AppClaimMappingColumnProjectID,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnResourceOwner = Column{
+		name:  projection.AppClaimMappingColumnResourceOwner,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnCreationDate = Column{
+		name:  projection.AppClaimMappingColumnCreationDate,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnChangeDate = Column{
+		name:  projection.AppClaimMappingColumnChangeDate,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnSequence = Column{
+		name:  projection.AppClaimMappingColumnSequence,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnClaim = Column{
+		name:  projection.AppClaimMappingColumnClaim,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnSource = Column{
+		name:  projection.AppClaimMappingColumnSource,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnSourceKey = Column{
+		name:  projection.AppClaimMappingColumnSourceKey,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnType = Column{
+		name:  projection.AppClaimMappingColumnType,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnIDToken = Column{
+		name:  projection.AppClaimMappingColumnIDToken,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnAccessToken = Column{
+		name:  projection.AppClaimMappingColumnAccessToken,
+		table: appClaimMappingsTable,
+	}
+	AppClaimMappingColumnUserinfo = Column{
+		name:  projection.AppClaimMappingColumnUserinfo,
+		table: appClaimMappingsTable,
+	}
+)
+
+func (q *Queries) AppClaimMappings(ctx context.Context, projectID, appID string) (mappings *ClaimMappings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareClaimMappingsQuery()
+	stmt, args, err := query.Where(
+		sq.Eq{
+			AppClaimMappingColumnProjectID.identifier():  projectID,
+			AppClaimMappingColumnAppID.identifier():      appID,
+			AppClaimMappingColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Cm3gs", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cm9qe", "Errors.Internal")
+	}
+	mappings, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	mappings.LatestSequence, err = q.latestSequence(ctx, appClaimMappingsTable)
+	return mappings, err
+}
+
+// ClaimMappingsByOIDCClientID returns the claim mappings of the OIDC application with the given client id
+func (q *Queries) ClaimMappingsByOIDCClientID(ctx context.Context, clientID string) (mappings *ClaimMappings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareClaimMappingsQuery()
+	stmt, args, err := query.
+		Join(join(AppOIDCConfigColumnAppID, AppClaimMappingColumnAppID)).
+		Where(
+			sq.Eq{
+				AppOIDCConfigColumnClientID.identifier():     clientID,
+				AppClaimMappingColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			},
+		).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Cm7wn", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cm1ha", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareClaimMappingsQuery() (sq.SelectBuilder, func(*sql.Rows) (*ClaimMappings, error)) {
+	return sq.Select(
+			AppClaimMappingColumnAppID.identifier(),
+			AppClaimMappingColumnProjectID.identifier(),
+			AppClaimMappingColumnCreationDate.identifier(),
+			AppClaimMappingColumnChangeDate.identifier(),
+			AppClaimMappingColumnSequence.identifier(),
+			AppClaimMappingColumnResourceOwner.identifier(),
+			AppClaimMappingColumnClaim.identifier(),
+			AppClaimMappingColumnSource.identifier(),
+			AppClaimMappingColumnSourceKey.identifier(),
+			AppClaimMappingColumnType.identifier(),
+			AppClaimMappingColumnIDToken.identifier(),
+			AppClaimMappingColumnAccessToken.identifier(),
+			AppClaimMappingColumnUserinfo.identifier(),
+			countColumn.identifier(),
+		).
+			From(appClaimMappingsTable.identifier()).
+			OrderBy(AppClaimMappingColumnClaim.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ClaimMappings, error) {
+			mappings := make([]*ClaimMapping, 0)
+			var count uint64
+			for rows.Next() {
+				m := new(ClaimMapping)
+				err := rows.Scan(
+					&m.AppID,
+					&m.ProjectID,
+					&m.CreationDate,
+					&m.ChangeDate,
+					&m.Sequence,
+					&m.ResourceOwner,
+					&m.Claim,
+					&m.Source,
+					&m.SourceKey,
+					&m.Type,
+					&m.IDToken,
+					&m.AccessToken,
+					&m.Userinfo,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				mappings = append(mappings, m)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Cm5zu", "Errors.Query.CloseRows")
+			}
+
+			return &ClaimMappings{
+				ClaimMappings: mappings,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
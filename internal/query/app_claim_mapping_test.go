@@ -0,0 +1,170 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	claimMappingsStmt = regexp.QuoteMeta(`SELECT projections.app_claim_mappings.app_id,` +
+		` projections.app_claim_mappings.project_id,` +
+		` projections.app_claim_mappings.creation_date,` +
+		` projections.app_claim_mappings.change_date,` +
+		` projections.app_claim_mappings.sequence,` +
+		` projections.app_claim_mappings.resource_owner,` +
+		` projections.app_claim_mappings.claim,` +
+		` projections.app_claim_mappings.source,` +
+		` projections.app_claim_mappings.source_key,` +
+		` projections.app_claim_mappings.type,` +
+		` projections.app_claim_mappings.id_token,` +
+		` projections.app_claim_mappings.access_token,` +
+		` projections.app_claim_mappings.userinfo,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.app_claim_mappings` +
+		` ORDER BY projections.app_claim_mappings.claim`)
+	claimMappingsCols = []string{
+		"app_id",
+		"project_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"claim",
+		"source",
+		"source_key",
+		"type",
+		"id_token",
+		"access_token",
+		"userinfo",
+		"count",
+	}
+)
+
+func Test_ClaimMappingPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareClaimMappingsQuery no result",
+			prepare: prepareClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					claimMappingsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &ClaimMappings{ClaimMappings: []*ClaimMapping{}},
+		},
+		{
+			name:    "prepareClaimMappingsQuery multiple results",
+			prepare: prepareClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					claimMappingsStmt,
+					claimMappingsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"project-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"department",
+							domain.ClaimMappingSourceMetadata,
+							"department",
+							domain.ClaimMappingTypeString,
+							true,
+							false,
+							true,
+						},
+						{
+							"app-id",
+							"project-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"roles",
+							domain.ClaimMappingSourceRoles,
+							"",
+							domain.ClaimMappingTypeUnspecified,
+							false,
+							true,
+							false,
+						},
+					},
+				),
+			},
+			object: &ClaimMappings{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				ClaimMappings: []*ClaimMapping{
+					{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+						ResourceOwner: "ro",
+						Claim:         "department",
+						Source:        domain.ClaimMappingSourceMetadata,
+						SourceKey:     "department",
+						Type:          domain.ClaimMappingTypeString,
+						IDToken:       true,
+						Userinfo:      true,
+					},
+					{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+						ResourceOwner: "ro",
+						Claim:         "roles",
+						Source:        domain.ClaimMappingSourceRoles,
+						Type:          domain.ClaimMappingTypeUnspecified,
+						AccessToken:   true,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareClaimMappingsQuery sql err",
+			prepare: prepareClaimMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					claimMappingsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -15,93 +15,121 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps3.id,` +
-		` projections.apps3.name,` +
-		` projections.apps3.project_id,` +
-		` projections.apps3.creation_date,` +
-		` projections.apps3.change_date,` +
-		` projections.apps3.resource_owner,` +
-		` projections.apps3.state,` +
-		` projections.apps3.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id,` +
+		` projections.apps6.creation_date,` +
+		` projections.apps6.change_date,` +
+		` projections.apps6.resource_owner,` +
+		` projections.apps6.state,` +
+		` projections.apps6.sequence,` +
 		// api config
-		` projections.apps3_api_configs.app_id,` +
-		` projections.apps3_api_configs.client_id,` +
-		` projections.apps3_api_configs.auth_method,` +
+		` projections.apps6_api_configs.app_id,` +
+		` projections.apps6_api_configs.client_id,` +
+		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps6_api_configs.access_token_lifetime,` +
+		` projections.apps6_api_configs.id_token_lifetime,` +
+		` projections.apps6_api_configs.refresh_token_idle_expiration,` +
+		` projections.apps6_api_configs.refresh_token_expiration,` +
+		` projections.apps6_api_configs.refresh_token_rotation,` +
 		// oidc config
-		` projections.apps3_oidc_configs.app_id,` +
-		` projections.apps3_oidc_configs.version,` +
-		` projections.apps3_oidc_configs.client_id,` +
-		` projections.apps3_oidc_configs.redirect_uris,` +
-		` projections.apps3_oidc_configs.response_types,` +
-		` projections.apps3_oidc_configs.grant_types,` +
-		` projections.apps3_oidc_configs.application_type,` +
-		` projections.apps3_oidc_configs.auth_method_type,` +
-		` projections.apps3_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps3_oidc_configs.is_dev_mode,` +
-		` projections.apps3_oidc_configs.access_token_type,` +
-		` projections.apps3_oidc_configs.access_token_role_assertion,` +
-		` projections.apps3_oidc_configs.id_token_role_assertion,` +
-		` projections.apps3_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps3_oidc_configs.clock_skew,` +
-		` projections.apps3_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.app_id,` +
+		` projections.apps6_oidc_configs.version,` +
+		` projections.apps6_oidc_configs.client_id,` +
+		` projections.apps6_oidc_configs.redirect_uris,` +
+		` projections.apps6_oidc_configs.response_types,` +
+		` projections.apps6_oidc_configs.grant_types,` +
+		` projections.apps6_oidc_configs.application_type,` +
+		` projections.apps6_oidc_configs.auth_method_type,` +
+		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps6_oidc_configs.is_dev_mode,` +
+		` projections.apps6_oidc_configs.access_token_type,` +
+		` projections.apps6_oidc_configs.access_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps6_oidc_configs.clock_skew,` +
+		` projections.apps6_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.require_pushed_authorization_requests,` +
+		` projections.apps6_oidc_configs.jwks,` +
+		` projections.apps6_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps6_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps6_oidc_configs.access_token_lifetime,` +
+		` projections.apps6_oidc_configs.id_token_lifetime,` +
+		` projections.apps6_oidc_configs.refresh_token_idle_expiration,` +
+		` projections.apps6_oidc_configs.refresh_token_expiration,` +
+		` projections.apps6_oidc_configs.refresh_token_rotation,` +
 		//saml config
-		` projections.apps3_saml_configs.app_id,` +
-		` projections.apps3_saml_configs.entity_id,` +
-		` projections.apps3_saml_configs.metadata,` +
-		` projections.apps3_saml_configs.metadata_url` +
-		` FROM projections.apps3` +
-		` LEFT JOIN projections.apps3_api_configs ON projections.apps3.id = projections.apps3_api_configs.app_id AND projections.apps3.instance_id = projections.apps3_api_configs.instance_id` +
-		` LEFT JOIN projections.apps3_oidc_configs ON projections.apps3.id = projections.apps3_oidc_configs.app_id AND projections.apps3.instance_id = projections.apps3_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps3_saml_configs ON projections.apps3.id = projections.apps3_saml_configs.app_id AND projections.apps3.instance_id = projections.apps3_saml_configs.instance_id`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps3.id,` +
-		` projections.apps3.name,` +
-		` projections.apps3.project_id,` +
-		` projections.apps3.creation_date,` +
-		` projections.apps3.change_date,` +
-		` projections.apps3.resource_owner,` +
-		` projections.apps3.state,` +
-		` projections.apps3.sequence,` +
+		` projections.apps6_saml_configs.app_id,` +
+		` projections.apps6_saml_configs.entity_id,` +
+		` projections.apps6_saml_configs.metadata,` +
+		` projections.apps6_saml_configs.metadata_url` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id`)
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id,` +
+		` projections.apps6.creation_date,` +
+		` projections.apps6.change_date,` +
+		` projections.apps6.resource_owner,` +
+		` projections.apps6.state,` +
+		` projections.apps6.sequence,` +
 		// api config
-		` projections.apps3_api_configs.app_id,` +
-		` projections.apps3_api_configs.client_id,` +
-		` projections.apps3_api_configs.auth_method,` +
+		` projections.apps6_api_configs.app_id,` +
+		` projections.apps6_api_configs.client_id,` +
+		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps6_api_configs.access_token_lifetime,` +
+		` projections.apps6_api_configs.id_token_lifetime,` +
+		` projections.apps6_api_configs.refresh_token_idle_expiration,` +
+		` projections.apps6_api_configs.refresh_token_expiration,` +
+		` projections.apps6_api_configs.refresh_token_rotation,` +
 		// oidc config
-		` projections.apps3_oidc_configs.app_id,` +
-		` projections.apps3_oidc_configs.version,` +
-		` projections.apps3_oidc_configs.client_id,` +
-		` projections.apps3_oidc_configs.redirect_uris,` +
-		` projections.apps3_oidc_configs.response_types,` +
-		` projections.apps3_oidc_configs.grant_types,` +
-		` projections.apps3_oidc_configs.application_type,` +
-		` projections.apps3_oidc_configs.auth_method_type,` +
-		` projections.apps3_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps3_oidc_configs.is_dev_mode,` +
-		` projections.apps3_oidc_configs.access_token_type,` +
-		` projections.apps3_oidc_configs.access_token_role_assertion,` +
-		` projections.apps3_oidc_configs.id_token_role_assertion,` +
-		` projections.apps3_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps3_oidc_configs.clock_skew,` +
-		` projections.apps3_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.app_id,` +
+		` projections.apps6_oidc_configs.version,` +
+		` projections.apps6_oidc_configs.client_id,` +
+		` projections.apps6_oidc_configs.redirect_uris,` +
+		` projections.apps6_oidc_configs.response_types,` +
+		` projections.apps6_oidc_configs.grant_types,` +
+		` projections.apps6_oidc_configs.application_type,` +
+		` projections.apps6_oidc_configs.auth_method_type,` +
+		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps6_oidc_configs.is_dev_mode,` +
+		` projections.apps6_oidc_configs.access_token_type,` +
+		` projections.apps6_oidc_configs.access_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_role_assertion,` +
+		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps6_oidc_configs.clock_skew,` +
+		` projections.apps6_oidc_configs.additional_origins,` +
+		` projections.apps6_oidc_configs.require_pushed_authorization_requests,` +
+		` projections.apps6_oidc_configs.jwks,` +
+		` projections.apps6_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps6_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps6_oidc_configs.access_token_lifetime,` +
+		` projections.apps6_oidc_configs.id_token_lifetime,` +
+		` projections.apps6_oidc_configs.refresh_token_idle_expiration,` +
+		` projections.apps6_oidc_configs.refresh_token_expiration,` +
+		` projections.apps6_oidc_configs.refresh_token_rotation,` +
 		//saml config
-		` projections.apps3_saml_configs.app_id,` +
-		` projections.apps3_saml_configs.entity_id,` +
-		` projections.apps3_saml_configs.metadata,` +
-		` projections.apps3_saml_configs.metadata_url,` +
+		` projections.apps6_saml_configs.app_id,` +
+		` projections.apps6_saml_configs.entity_id,` +
+		` projections.apps6_saml_configs.metadata,` +
+		` projections.apps6_saml_configs.metadata_url,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps3` +
-		` LEFT JOIN projections.apps3_api_configs ON projections.apps3.id = projections.apps3_api_configs.app_id AND projections.apps3.instance_id = projections.apps3_api_configs.instance_id` +
-		` LEFT JOIN projections.apps3_oidc_configs ON projections.apps3.id = projections.apps3_oidc_configs.app_id AND projections.apps3.instance_id = projections.apps3_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps3_saml_configs ON projections.apps3.id = projections.apps3_saml_configs.app_id AND projections.apps3.instance_id = projections.apps3_saml_configs.instance_id`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps3_api_configs.client_id,` +
-		` projections.apps3_oidc_configs.client_id` +
-		` FROM projections.apps3` +
-		` LEFT JOIN projections.apps3_api_configs ON projections.apps3.id = projections.apps3_api_configs.app_id AND projections.apps3.instance_id = projections.apps3_api_configs.instance_id` +
-		` LEFT JOIN projections.apps3_oidc_configs ON projections.apps3.id = projections.apps3_oidc_configs.app_id AND projections.apps3.instance_id = projections.apps3_oidc_configs.instance_id`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps3.project_id` +
-		` FROM projections.apps3` +
-		` LEFT JOIN projections.apps3_api_configs ON projections.apps3.id = projections.apps3_api_configs.app_id AND projections.apps3.instance_id = projections.apps3_api_configs.instance_id` +
-		` LEFT JOIN projections.apps3_oidc_configs ON projections.apps3.id = projections.apps3_oidc_configs.app_id AND projections.apps3.instance_id = projections.apps3_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps3_saml_configs ON projections.apps3.id = projections.apps3_saml_configs.app_id AND projections.apps3.instance_id = projections.apps3_saml_configs.instance_id`)
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id`)
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps6_api_configs.client_id,` +
+		` projections.apps6_oidc_configs.client_id` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id`)
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.project_id` +
+		` FROM projections.apps6` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects2.id,` +
 		` projections.projects2.creation_date,` +
 		` projections.projects2.change_date,` +
@@ -114,10 +142,10 @@ var (
 		` projections.projects2.has_project_check,` +
 		` projections.projects2.private_labeling_setting` +
 		` FROM projections.projects2` +
-		` JOIN projections.apps3 ON projections.projects2.id = projections.apps3.project_id AND projections.projects2.instance_id = projections.apps3.instance_id` +
-		` LEFT JOIN projections.apps3_api_configs ON projections.apps3.id = projections.apps3_api_configs.app_id AND projections.apps3.instance_id = projections.apps3_api_configs.instance_id` +
-		` LEFT JOIN projections.apps3_oidc_configs ON projections.apps3.id = projections.apps3_oidc_configs.app_id AND projections.apps3.instance_id = projections.apps3_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps3_saml_configs ON projections.apps3.id = projections.apps3_saml_configs.app_id AND projections.apps3.instance_id = projections.apps3_saml_configs.instance_id`)
+		` JOIN projections.apps6 ON projections.projects2.id = projections.apps6.project_id AND projections.projects2.instance_id = projections.apps6.instance_id` +
+		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id`)
 
 	appCols = database.StringArray{
 		"id",
@@ -132,6 +160,11 @@ var (
 		"app_id",
 		"client_id",
 		"auth_method",
+		"access_token_lifetime",
+		"id_token_lifetime",
+		"refresh_token_idle_expiration",
+		"refresh_token_expiration",
+		"refresh_token_rotation",
 		// oidc config
 		"app_id",
 		"version",
@@ -149,6 +182,15 @@ var (
 		"id_token_userinfo_assertion",
 		"clock_skew",
 		"additional_origins",
+		"require_pushed_authorization_requests",
+		"jwks",
+		"back_channel_logout_uri",
+		"front_channel_logout_uri",
+		"access_token_lifetime",
+		"id_token_lifetime",
+		"refresh_token_idle_expiration",
+		"refresh_token_expiration",
+		"refresh_token_rotation",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -202,6 +244,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -219,6 +266,15 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -267,6 +323,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							5 * time.Minute,
+							nil,
+							nil,
+							nil,
+							domain.RefreshTokenRotationReuseDetection,
 							// oidc config
 							nil,
 							nil,
@@ -284,6 +345,15 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -310,6 +380,10 @@ func Test_AppsPrepare(t *testing.T) {
 						APIConfig: &APIApp{
 							ClientID:       "api-client-id",
 							AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+							AppTokenSettings: domain.AppTokenSettings{
+								AccessTokenLifetime:  5 * time.Minute,
+								RefreshTokenRotation: domain.RefreshTokenRotationReuseDetection,
+							},
 						},
 					},
 				},
@@ -335,6 +409,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -352,6 +431,15 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -405,6 +493,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -422,6 +515,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							true,
+							`{"keys":[]}`,
+							"https://redirect.to/backchannel-logout",
+							"https://redirect.to/frontchannel-logout",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -446,23 +548,27 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						OIDCConfig: &OIDCApp{
-							Version:                domain.OIDCVersionV1,
-							ClientID:               "oidc-client-id",
-							RedirectURIs:           database.StringArray{"https://redirect.to/me"},
-							ResponseTypes:          database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
-							GrantTypes:             database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
-							AppType:                domain.OIDCApplicationTypeUserAgent,
-							AuthMethodType:         domain.OIDCAuthMethodTypeNone,
-							PostLogoutRedirectURIs: database.StringArray{"post.logout.ch"},
-							IsDevMode:              true,
-							AccessTokenType:        domain.OIDCTokenTypeJWT,
-							AssertAccessTokenRole:  true,
-							AssertIDTokenRole:      true,
-							AssertIDTokenUserinfo:  true,
-							ClockSkew:              1 * time.Second,
-							AdditionalOrigins:      database.StringArray{"additional.origin"},
-							ComplianceProblems:     nil,
-							AllowedOrigins:         database.StringArray{"https://redirect.to", "additional.origin"},
+							Version:                            domain.OIDCVersionV1,
+							ClientID:                           "oidc-client-id",
+							RedirectURIs:                       database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:                      database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:                         database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                            domain.OIDCApplicationTypeUserAgent,
+							AuthMethodType:                     domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:             database.StringArray{"post.logout.ch"},
+							IsDevMode:                          true,
+							AccessTokenType:                    domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:              true,
+							AssertIDTokenRole:                  true,
+							AssertIDTokenUserinfo:              true,
+							ClockSkew:                          1 * time.Second,
+							AdditionalOrigins:                  database.StringArray{"additional.origin"},
+							ComplianceProblems:                 nil,
+							AllowedOrigins:                     database.StringArray{"https://redirect.to", "additional.origin"},
+							RequirePushedAuthorizationRequests: true,
+							JWKS:                               `{"keys":[]}`,
+							BackChannelLogoutURI:               "https://redirect.to/backchannel-logout",
+							FrontChannelLogoutURI:              "https://redirect.to/frontchannel-logout",
 						},
 					},
 				},
@@ -489,6 +595,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -506,6 +617,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -573,6 +693,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -590,6 +715,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -657,6 +791,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -674,6 +813,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -741,6 +889,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -758,6 +911,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -825,6 +987,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"oidc-app-id",
 							domain.OIDCVersionV1,
@@ -842,6 +1009,15 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -861,6 +1037,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"api-app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -878,6 +1059,15 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -897,6 +1087,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -914,6 +1109,15 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1063,6 +1267,11 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// oidc config
 						nil,
 						nil,
@@ -1080,6 +1289,15 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						"",
+						"",
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1120,6 +1338,11 @@ func Test_AppPrepare(t *testing.T) {
 							"app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -1137,6 +1360,15 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1182,6 +1414,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1199,6 +1436,15 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1258,6 +1504,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -1275,6 +1526,15 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1321,6 +1581,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1338,6 +1603,15 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1398,6 +1672,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1415,6 +1694,15 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1475,6 +1763,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1492,6 +1785,15 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1552,6 +1854,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1569,6 +1876,15 @@ func Test_AppPrepare(t *testing.T) {
 							false,
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
+							false,
+							"",
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -0,0 +1,296 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	// AuditLogMaxLimit is the maximum amount of events returned by a single audit log search
+	AuditLogMaxLimit = 1000
+)
+
+// auditLogRedactedFields are removed from the payloads if the field name contains one of them (case insensitive)
+var auditLogRedactedFields = []string{"secret", "hash", "password", "privatekey"}
+
+var auditLogCSVHeader = []string{
+	"sequence",
+	"creation_date",
+	"event_type",
+	"aggregate_type",
+	"aggregate_id",
+	"resource_owner",
+	"editor_id",
+	"editor_service",
+	"editor_display_name",
+	"editor_preferred_login_name",
+	"payload",
+}
+
+type AuditLogSearchQueries struct {
+	// Sequence is the sequence of the last event of the previous page
+	Sequence uint64
+	Limit    uint64
+	Asc      bool
+
+	ResourceOwner  string
+	EditorUserIDs  []string
+	EventTypes     []string
+	AggregateTypes []string
+	From           time.Time
+	To             time.Time
+	// Text searches case insensitive in the redacted payload of the events
+	// encrypted personal data and redacted secrets are not searchable
+	Text string
+}
+
+type AuditLog struct {
+	Events []*AuditLogEvent
+}
+
+type AuditLogEvent struct {
+	Sequence      uint64          `json:"sequence"`
+	CreationDate  time.Time       `json:"creationDate"`
+	EventType     string          `json:"eventType"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	EditorID      string          `json:"editorId"`
+	EditorService string          `json:"editorService"`
+	EditorName    string          `json:"editorDisplayName,omitempty"`
+	EditorLogin   string          `json:"editorPreferredLoginName,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+// SearchAuditLog searches all events of the instance (or the organisation if ResourceOwner is set)
+// events older than the audit log retention are never returned
+func (q *Queries) SearchAuditLog(ctx context.Context, queries *AuditLogSearchQueries, auditLogRetention time.Duration) (*AuditLog, error) {
+	if queries == nil {
+		queries = new(AuditLogSearchQueries)
+	}
+	if !queries.From.IsZero() && !queries.To.IsZero() && !queries.From.Before(queries.To) {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Hs8ag", "Errors.AuditLog.InvalidTimeRange")
+	}
+	now := time.Now()
+	limit := queries.limit()
+	editors := make(map[string]*User)
+	auditLog := &AuditLog{Events: make([]*AuditLogEvent, 0)}
+	sequence := queries.Sequence
+	for {
+		events, err := q.eventstore.Filter(ctx, queries.toSearchQuery(now, auditLogRetention, sequence, limit))
+		if err != nil {
+			logging.WithError(err).Warn("eventstore unavailable")
+			return nil, errors.ThrowInternal(err, "QUERY-Wq2lf", "Errors.Internal")
+		}
+		for _, event := range events {
+			payload := redactPayload(event.DataAsBytes())
+			// the eventstore also matches the text against the secrets, so it's checked again on the redacted payload
+			if !payloadContainsText(payload, queries.Text) {
+				continue
+			}
+			auditLogEvent := &AuditLogEvent{
+				Sequence:      event.Sequence(),
+				CreationDate:  event.CreationDate(),
+				EventType:     string(event.Type()),
+				AggregateType: string(event.Aggregate().Type),
+				AggregateID:   event.Aggregate().ID,
+				ResourceOwner: event.Aggregate().ResourceOwner,
+				EditorID:      event.EditorUser(),
+				EditorService: event.EditorService(),
+				EditorName:    event.EditorUser(),
+				EditorLogin:   event.EditorUser(),
+				Payload:       payload,
+			}
+			editor, ok := editors[event.EditorUser()]
+			if !ok {
+				editor, _ = q.GetUserByID(ctx, false, event.EditorUser())
+				editors[event.EditorUser()] = editor
+			}
+			auditLogEvent.setEditor(editor)
+			auditLog.Events = append(auditLog.Events, auditLogEvent)
+			if uint64(len(auditLog.Events)) == limit {
+				return auditLog, nil
+			}
+		}
+		// events only matching a redacted secret were skipped, so the next events are searched to fill the page
+		if uint64(len(events)) < limit {
+			return auditLog, nil
+		}
+		sequence = events[len(events)-1].Sequence()
+	}
+}
+
+func (queries *AuditLogSearchQueries) limit() uint64 {
+	if queries.Limit == 0 || queries.Limit > AuditLogMaxLimit {
+		return AuditLogMaxLimit
+	}
+	return queries.Limit
+}
+
+func (queries *AuditLogSearchQueries) toSearchQuery(now time.Time, auditLogRetention time.Duration, sequence, limit uint64) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		Limit(limit).
+		ResourceOwner(queries.ResourceOwner)
+	if !queries.Asc {
+		builder.OrderDesc()
+	}
+
+	from := queries.From
+	if retention := now.Add(-auditLogRetention); auditLogRetention != 0 && from.Before(retention) {
+		from = retention
+	}
+	eventTypes := make([]eventstore.EventType, len(queries.EventTypes))
+	for i, eventType := range queries.EventTypes {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(queries.AggregateTypes))
+	for i, aggregateType := range queries.AggregateTypes {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+
+	builder.AddQuery().
+		SequenceGreater(sequence). //always use greater (less is done automatically by sorting desc)
+		AggregateTypes(aggregateTypes...).
+		EventTypes(eventTypes...).
+		EditorUsers(queries.EditorUserIDs...).
+		CreationDateAfter(from).
+		CreationDateBefore(queries.To).
+		EventDataText(queries.Text)
+	return builder
+}
+
+func payloadContainsText(payload json.RawMessage, text string) bool {
+	if text == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(string(payload)), strings.ToLower(text))
+}
+
+// redactPayload removes secrets, hashes and crypto values (e.g. encrypted codes) from the payload
+func redactPayload(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		logging.WithError(err).Warn("unable to redact audit log payload")
+		return nil
+	}
+	redacted, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		logging.WithError(err).Warn("unable to redact audit log payload")
+		return nil
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isRedactedField(key) || isCryptoValue(field) {
+				delete(v, key)
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			if isCryptoValue(item) {
+				v[i] = nil
+				continue
+			}
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isRedactedField(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range auditLogRedactedFields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCryptoValue checks for the json representation of a crypto.CryptoValue
+func isCryptoValue(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = object["Crypted"]
+	return ok
+}
+
+func (e *AuditLogEvent) setEditor(editor *User) {
+	if editor == nil {
+		return
+	}
+	e.EditorLogin = editor.PreferredLoginName
+	if editor.Human != nil {
+		e.EditorName = editor.Human.DisplayName
+	}
+	if editor.Machine != nil {
+		e.EditorName = editor.Machine.Name
+	}
+}
+
+// WriteCSV writes the events including a header row as comma separated values
+func (l *AuditLog) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditLogCSVHeader); err != nil {
+		return errors.ThrowInternal(err, "QUERY-Zm1xa", "Errors.Internal")
+	}
+	for _, event := range l.Events {
+		err := writer.Write([]string{
+			strconv.FormatUint(event.Sequence, 10),
+			event.CreationDate.UTC().Format(time.RFC3339Nano),
+			event.EventType,
+			event.AggregateType,
+			event.AggregateID,
+			event.ResourceOwner,
+			event.EditorID,
+			event.EditorService,
+			event.EditorName,
+			event.EditorLogin,
+			string(event.Payload),
+		})
+		if err != nil {
+			return errors.ThrowInternal(err, "QUERY-Pd3ts", "Errors.Internal")
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errors.ThrowInternal(err, "QUERY-Ju7hq", "Errors.Internal")
+	}
+	return nil
+}
+
+// WriteJSON writes the events as json array
+func (l *AuditLog) WriteJSON(w io.Writer) error {
+	events := l.Events
+	if events == nil {
+		events = []*AuditLogEvent{}
+	}
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		return errors.ThrowInternal(err, "QUERY-Cx5nb", "Errors.Internal")
+	}
+	return nil
+}
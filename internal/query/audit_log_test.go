@@ -0,0 +1,167 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var testAuditLog = &AuditLog{
+	Events: []*AuditLogEvent{
+		{
+			Sequence:      20211108,
+			CreationDate:  time.Date(2021, 11, 8, 10, 0, 0, 0, time.UTC),
+			EventType:     "user.human.added",
+			AggregateType: "user",
+			AggregateID:   "user-id",
+			ResourceOwner: "org-id",
+			EditorID:      "editor-id",
+			EditorService: "MANAGEMENT-API",
+			EditorName:    "Gigi Giraffe",
+			EditorLogin:   "gigi@zitadel.ch",
+			Payload:       []byte(`{"userName":"gigi","roles":["a","b"]}`),
+		},
+		{
+			Sequence:      20211109,
+			CreationDate:  time.Date(2021, 11, 9, 10, 0, 0, 0, time.UTC),
+			EventType:     "user.locked",
+			AggregateType: "user",
+			AggregateID:   "user-id",
+			ResourceOwner: "org-id",
+			EditorID:      "LOGIN",
+			EditorService: "LOGIN",
+			EditorName:    "LOGIN",
+			EditorLogin:   "LOGIN",
+		},
+	},
+}
+
+func TestAuditLog_WriteCSV(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := testAuditLog.WriteCSV(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `sequence,creation_date,event_type,aggregate_type,aggregate_id,resource_owner,editor_id,editor_service,editor_display_name,editor_preferred_login_name,payload
+20211108,2021-11-08T10:00:00Z,user.human.added,user,user-id,org-id,editor-id,MANAGEMENT-API,Gigi Giraffe,gigi@zitadel.ch,"{""userName"":""gigi"",""roles"":[""a"",""b""]}"
+20211109,2021-11-09T10:00:00Z,user.locked,user,user-id,org-id,LOGIN,LOGIN,LOGIN,LOGIN,
+`
+	if buf.String() != want {
+		t.Errorf("unexpected csv:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestAuditLog_WriteJSON(t *testing.T) {
+	t.Run("no events", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := new(AuditLog).WriteJSON(buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != "[]\n" {
+			t.Errorf("expected empty array, got %s", buf.String())
+		}
+	})
+	t.Run("events", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := testAuditLog.WriteJSON(buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var events []map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &events); err != nil {
+			t.Fatalf("invalid json %s: %v", buf.String(), err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+		payload, ok := events[0]["payload"].(map[string]interface{})
+		if !ok || payload["userName"] != "gigi" {
+			t.Errorf("expected payload as json object, got %v", events[0]["payload"])
+		}
+		if _, ok = events[1]["payload"]; ok {
+			t.Errorf("expected no payload, got %v", events[1]["payload"])
+		}
+	})
+}
+
+func Test_redactPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name: "no payload",
+		},
+		{
+			name:    "password hash",
+			payload: `{"secret":{"CryptoType":1,"Algorithm":"bcrypt","KeyID":"","Crypted":"aGFzaA=="},"changeRequired":false}`,
+			want:    `{"changeRequired":false}`,
+		},
+		{
+			name:    "client secret and crypto values",
+			payload: `{"appId":"app","clientSecret":{"Crypted":"c2VjcmV0"},"codes":[{"Crypted":"Y29kZQ=="}],"config":{"passwordHash":"hash","name":"n"}}`,
+			want:    `{"appId":"app","codes":[null],"config":{"name":"n"}}`,
+		},
+		{
+			name:    "numbers are kept",
+			payload: `{"userName":"gigi","expiry":3600000000000}`,
+			want:    `{"expiry":3600000000000,"userName":"gigi"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactPayload([]byte(tt.payload))
+			if string(got) != tt.want {
+				t.Errorf("redactPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueries_SearchAuditLog_InvalidTimeRange(t *testing.T) {
+	now := time.Now()
+	_, err := new(Queries).SearchAuditLog(context.Background(), &AuditLogSearchQueries{From: now, To: now.Add(-time.Hour)}, 0)
+	if !errors.IsErrorInvalidArgument(err) {
+		t.Errorf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_payloadContainsText(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		text    string
+		want    bool
+	}{
+		{
+			name: "no text",
+			want: true,
+		},
+		{
+			name:    "case insensitive",
+			payload: `{"userName":"Gigi"}`,
+			text:    "gIGI",
+			want:    true,
+		},
+		{
+			name:    "not contained",
+			payload: `{"userName":"gigi"}`,
+			text:    "giraffe",
+		},
+		{
+			name:    "redacted secret not searchable",
+			payload: `{"appId":"app","clientSecret":"supersecret"}`,
+			text:    "supersecret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := payloadContainsText(redactPayload([]byte(tt.payload)), tt.text); got != tt.want {
+				t.Errorf("payloadContainsText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type CaptchaPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	ProviderType           domain.CaptchaProviderType
+	VerifyURL              string
+	SiteKey                string
+	Secret                 *crypto.CryptoValue
+	RequireOnRegistration  bool
+	RequireOnPasswordReset bool
+	FailedLoginsThreshold  uint64
+
+	IsDefault bool
+}
+
+// VerifyEndpoint returns the endpoint the response of the widget is verified with,
+// only the default policy of the instance can override the endpoint of the provider
+func (p *CaptchaPolicy) VerifyEndpoint() string {
+	if p.IsDefault && p.VerifyURL != "" && domain.CaptchaVerifyURLValid(p.VerifyURL) {
+		return p.VerifyURL
+	}
+	return p.ProviderType.VerifyEndpoint()
+}
+
+var (
+	captchaTable = table{
+		name:          projection.CaptchaPolicyTable,
+		instanceIDCol: projection.CaptchaPolicyInstanceIDCol,
+	}
+	CaptchaColID = Column{
+		name:  projection.CaptchaPolicyIDCol,
+		table: captchaTable,
+	}
+	CaptchaColInstanceID = Column{
+		name:  projection.CaptchaPolicyInstanceIDCol,
+		table: captchaTable,
+	}
+	CaptchaColSequence = Column{
+		name:  projection.CaptchaPolicySequenceCol,
+		table: captchaTable,
+	}
+	CaptchaColCreationDate = Column{
+		name:  projection.CaptchaPolicyCreationDateCol,
+		table: captchaTable,
+	}
+	CaptchaColChangeDate = Column{
+		name:  projection.CaptchaPolicyChangeDateCol,
+		table: captchaTable,
+	}
+	CaptchaColResourceOwner = Column{
+		name:  projection.CaptchaPolicyResourceOwnerCol,
+		table: captchaTable,
+	}
+	CaptchaColProviderType = Column{
+		name:  projection.CaptchaPolicyProviderTypeCol,
+		table: captchaTable,
+	}
+	CaptchaColVerifyURL = Column{
+		name:  projection.CaptchaPolicyVerifyURLCol,
+		table: captchaTable,
+	}
+	CaptchaColSiteKey = Column{
+		name:  projection.CaptchaPolicySiteKeyCol,
+		table: captchaTable,
+	}
+	CaptchaColSecret = Column{
+		name:  projection.CaptchaPolicySecretCol,
+		table: captchaTable,
+	}
+	CaptchaColRequireOnRegistration = Column{
+		name:  projection.CaptchaPolicyRequireOnRegistrationCol,
+		table: captchaTable,
+	}
+	CaptchaColRequireOnPasswordReset = Column{
+		name:  projection.CaptchaPolicyRequireOnPasswordResetCol,
+		table: captchaTable,
+	}
+	CaptchaColFailedLoginsThreshold = Column{
+		name:  projection.CaptchaPolicyFailedLoginsThresholdCol,
+		table: captchaTable,
+	}
+	CaptchaColIsDefault = Column{
+		name:  projection.CaptchaPolicyIsDefaultCol,
+		table: captchaTable,
+	}
+	CaptchaColState = Column{
+		name:  projection.CaptchaPolicyStateCol,
+		table: captchaTable,
+	}
+)
+
+func (q *Queries) CaptchaPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (*CaptchaPolicy, error) {
+	if shouldTriggerBulk {
+		projection.CaptchaPolicyProjection.Trigger(ctx)
+	}
+
+	stmt, scan := prepareCaptchaPolicyQuery()
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				CaptchaColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			},
+			sq.Or{
+				sq.Eq{
+					CaptchaColID.identifier(): orgID,
+				},
+				sq.Eq{
+					CaptchaColID.identifier(): authz.GetInstance(ctx).InstanceID(),
+				},
+			},
+		}).
+		OrderBy(CaptchaColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cp5oD", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultCaptchaPolicy(ctx context.Context) (*CaptchaPolicy, error) {
+	stmt, scan := prepareCaptchaPolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		CaptchaColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		CaptchaColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(CaptchaColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Cp6pE", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareCaptchaPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*CaptchaPolicy, error)) {
+	return sq.Select(
+			CaptchaColID.identifier(),
+			CaptchaColSequence.identifier(),
+			CaptchaColCreationDate.identifier(),
+			CaptchaColChangeDate.identifier(),
+			CaptchaColResourceOwner.identifier(),
+			CaptchaColProviderType.identifier(),
+			CaptchaColVerifyURL.identifier(),
+			CaptchaColSiteKey.identifier(),
+			CaptchaColSecret.identifier(),
+			CaptchaColRequireOnRegistration.identifier(),
+			CaptchaColRequireOnPasswordReset.identifier(),
+			CaptchaColFailedLoginsThreshold.identifier(),
+			CaptchaColIsDefault.identifier(),
+			CaptchaColState.identifier(),
+		).
+			From(captchaTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*CaptchaPolicy, error) {
+			policy := new(CaptchaPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.ProviderType,
+				&policy.VerifyURL,
+				&policy.SiteKey,
+				&policy.Secret,
+				&policy.RequireOnRegistration,
+				&policy.RequireOnPasswordReset,
+				&policy.FailedLoginsThreshold,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Cp7qF", "Errors.Org.CaptchaPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Cp8rG", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_CaptchaPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareCaptchaPolicyQuery no result",
+			prepare: prepareCaptchaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.captcha_policies.id,`+
+						` projections.captcha_policies.sequence,`+
+						` projections.captcha_policies.creation_date,`+
+						` projections.captcha_policies.change_date,`+
+						` projections.captcha_policies.resource_owner,`+
+						` projections.captcha_policies.provider_type,`+
+						` projections.captcha_policies.verify_url,`+
+						` projections.captcha_policies.site_key,`+
+						` projections.captcha_policies.secret,`+
+						` projections.captcha_policies.require_on_registration,`+
+						` projections.captcha_policies.require_on_password_reset,`+
+						` projections.captcha_policies.failed_logins_threshold,`+
+						` projections.captcha_policies.is_default,`+
+						` projections.captcha_policies.state`+
+						` FROM projections.captcha_policies`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*CaptchaPolicy)(nil),
+		},
+		{
+			name:    "prepareCaptchaPolicyQuery found",
+			prepare: prepareCaptchaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.captcha_policies.id,`+
+						` projections.captcha_policies.sequence,`+
+						` projections.captcha_policies.creation_date,`+
+						` projections.captcha_policies.change_date,`+
+						` projections.captcha_policies.resource_owner,`+
+						` projections.captcha_policies.provider_type,`+
+						` projections.captcha_policies.verify_url,`+
+						` projections.captcha_policies.site_key,`+
+						` projections.captcha_policies.secret,`+
+						` projections.captcha_policies.require_on_registration,`+
+						` projections.captcha_policies.require_on_password_reset,`+
+						` projections.captcha_policies.failed_logins_threshold,`+
+						` projections.captcha_policies.is_default,`+
+						` projections.captcha_policies.state`+
+						` FROM projections.captcha_policies`),
+					[]string{
+						"id",
+						"sequence",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"provider_type",
+						"verify_url",
+						"site_key",
+						"secret",
+						"require_on_registration",
+						"require_on_password_reset",
+						"failed_logins_threshold",
+						"is_default",
+						"state",
+					},
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						domain.CaptchaProviderTypeHCaptcha,
+						"",
+						"sitekey",
+						[]byte(`{"CryptoType":0,"Algorithm":"enc","KeyID":"id","Crypted":"c2VjcmV0"}`),
+						true,
+						false,
+						3,
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &CaptchaPolicy{
+				ID:            "pol-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro",
+				State:         domain.PolicyStateActive,
+				ProviderType:  domain.CaptchaProviderTypeHCaptcha,
+				SiteKey:       "sitekey",
+				Secret: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("secret"),
+				},
+				RequireOnRegistration: true,
+				FailedLoginsThreshold: 3,
+				IsDefault:             true,
+			},
+		},
+		{
+			name:    "prepareCaptchaPolicyQuery sql err",
+			prepare: prepareCaptchaPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT projections.captcha_policies.id,`+
+						` projections.captcha_policies.sequence,`+
+						` projections.captcha_policies.creation_date,`+
+						` projections.captcha_policies.change_date,`+
+						` projections.captcha_policies.resource_owner,`+
+						` projections.captcha_policies.provider_type,`+
+						` projections.captcha_policies.verify_url,`+
+						` projections.captcha_policies.site_key,`+
+						` projections.captcha_policies.secret,`+
+						` projections.captcha_policies.require_on_registration,`+
+						` projections.captcha_policies.require_on_password_reset,`+
+						` projections.captcha_policies.failed_logins_threshold,`+
+						` projections.captcha_policies.is_default,`+
+						` projections.captcha_policies.state`+
+						` FROM projections.captcha_policies`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
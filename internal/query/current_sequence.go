@@ -109,7 +109,7 @@ func (q *Queries) checkAndLock(ctx context.Context, projectionName string) error
 		From("[show tables from projections]").
 		Where(
 			sq.And{
-				sq.NotEq{"table_name": []string{"locks", "current_sequences", "failed_events"}},
+				sq.NotEq{"table_name": []string{"locks", "current_sequences", "failed_events", "projection_states"}},
 				sq.Eq{"concat('projections.', table_name)": projectionName},
 			}).
 		PlaceholderFormat(sq.Dollar).
@@ -145,7 +145,7 @@ func tablesForReset(ctx context.Context, tx *sql.Tx, projectionName string) ([]s
 		Where(
 			sq.And{
 				sq.Eq{"type": "table"},
-				sq.NotEq{"table_name": []string{"locks", "current_sequences", "failed_events"}},
+				sq.NotEq{"table_name": []string{"locks", "current_sequences", "failed_events", "projection_states"}},
 				sq.Like{"concat('projections.', table_name)": projectionName + "%"},
 			}).
 		PlaceholderFormat(sq.Dollar).
@@ -0,0 +1,227 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	customRolesTable = table{
+		name:          projection.CustomRoleProjectionTable,
+		instanceIDCol: projection.CustomRoleColumnInstanceID,
+	}
+	CustomRoleColumnKey = Column{
+		name:  projection.CustomRoleColumnKey,
+		table: customRolesTable,
+	}
+	CustomRoleColumnCreationDate = Column{
+		name:  projection.CustomRoleColumnCreationDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnChangeDate = Column{
+		name:  projection.CustomRoleColumnChangeDate,
+		table: customRolesTable,
+	}
+	CustomRoleColumnSequence = Column{
+		name:  projection.CustomRoleColumnSequence,
+		table: customRolesTable,
+	}
+	CustomRoleColumnResourceOwner = Column{
+		name:  projection.CustomRoleColumnResourceOwner,
+		table: customRolesTable,
+	}
+	CustomRoleColumnInstanceID = Column{
+		name:  projection.CustomRoleColumnInstanceID,
+		table: customRolesTable,
+	}
+	CustomRoleColumnDisplayName = Column{
+		name:  projection.CustomRoleColumnDisplayName,
+		table: customRolesTable,
+	}
+	CustomRoleColumnPermissions = Column{
+		name:  projection.CustomRoleColumnPermissions,
+		table: customRolesTable,
+	}
+)
+
+type CustomRoles struct {
+	SearchResponse
+	CustomRoles []*CustomRole
+}
+
+type CustomRole struct {
+	Key           string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+	DisplayName   string
+	Permissions   database.StringArray
+}
+
+type CustomRoleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *CustomRoleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewCustomRoleKeySearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnKey, value, method)
+}
+
+func NewCustomRoleDisplayNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnDisplayName, value, method)
+}
+
+func (q *Queries) CustomRoleByKey(ctx context.Context, key string) (*CustomRole, error) {
+	stmt, scan := prepareCustomRoleQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		CustomRoleColumnKey.identifier():        key,
+		CustomRoleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Fh4kx", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchCustomRoles(ctx context.Context, queries *CustomRoleSearchQueries) (customRoles *CustomRoles, err error) {
+	query, scan := prepareCustomRolesQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			CustomRoleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Lp3wd", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Zc8nb", "Errors.Internal")
+	}
+	customRoles, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	customRoles.LatestSequence, err = q.latestSequence(ctx, customRolesTable)
+	return customRoles, err
+}
+
+// CustomRolePermissions returns the permissions of the custom roles of the instance by their key
+// keys which are not a custom role are ignored
+func (q *Queries) CustomRolePermissions(ctx context.Context, keys ...string) (_ map[string][]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	permissions := make(map[string][]string)
+	if len(keys) == 0 {
+		return permissions, nil
+	}
+	keyQuery, err := NewListQuery(CustomRoleColumnKey, keys, ListIn)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := q.SearchCustomRoles(ctx, &CustomRoleSearchQueries{Queries: []SearchQuery{keyQuery}})
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles.CustomRoles {
+		permissions[role.Key] = role.Permissions
+	}
+	return permissions, nil
+}
+
+func prepareCustomRoleQuery() (sq.SelectBuilder, func(*sql.Row) (*CustomRole, error)) {
+	return sq.Select(
+			CustomRoleColumnKey.identifier(),
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnDisplayName.identifier(),
+			CustomRoleColumnPermissions.identifier()).
+			From(customRolesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*CustomRole, error) {
+			role := new(CustomRole)
+			err := row.Scan(
+				&role.Key,
+				&role.CreationDate,
+				&role.ChangeDate,
+				&role.Sequence,
+				&role.ResourceOwner,
+				&role.DisplayName,
+				&role.Permissions,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Xn2ro", "Errors.CustomRole.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Bv5sw", "Errors.Internal")
+			}
+			return role, nil
+		}
+}
+
+func prepareCustomRolesQuery() (sq.SelectBuilder, func(*sql.Rows) (*CustomRoles, error)) {
+	return sq.Select(
+			CustomRoleColumnKey.identifier(),
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnDisplayName.identifier(),
+			CustomRoleColumnPermissions.identifier(),
+			countColumn.identifier()).
+			From(customRolesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*CustomRoles, error) {
+			roles := make([]*CustomRole, 0)
+			var count uint64
+			for rows.Next() {
+				role := new(CustomRole)
+				err := rows.Scan(
+					&role.Key,
+					&role.CreationDate,
+					&role.ChangeDate,
+					&role.Sequence,
+					&role.ResourceOwner,
+					&role.DisplayName,
+					&role.Permissions,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				roles = append(roles, role)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ow4ke", "Errors.Query.CloseRows")
+			}
+
+			return &CustomRoles{
+				CustomRoles: roles,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,702 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type Group struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	State         domain.GroupState
+	ResourceOwner string
+
+	Name        string
+	Description string
+}
+
+type Groups struct {
+	SearchResponse
+	Groups []*Group
+}
+
+type GroupSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type GroupMember struct {
+	GroupID       string
+	UserID        string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	PreferredLoginName string
+	DisplayName        string
+}
+
+type GroupMembers struct {
+	SearchResponse
+	Members []*GroupMember
+}
+
+type GroupMemberSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupMemberSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type GroupGrant struct {
+	ID            string
+	GroupID       string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	ProjectID   string
+	ProjectName string
+	GrantID     string
+	RoleKeys    database.StringArray
+}
+
+type GroupGrants struct {
+	SearchResponse
+	GroupGrants []*GroupGrant
+}
+
+type GroupGrantSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupGrantSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewGroupNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnName, value, method)
+}
+
+func NewGroupResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnResourceOwner, value, TextEquals)
+}
+
+func NewGroupMemberGroupIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnGroupID, value, TextEquals)
+}
+
+func NewGroupMemberResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnResourceOwner, value, TextEquals)
+}
+
+func NewGroupMemberUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnUserID, value, TextEquals)
+}
+
+func NewGroupGrantGroupIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnGroupID, value, TextEquals)
+}
+
+func NewGroupGrantProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnProjectID, value, TextEquals)
+}
+
+func NewGroupGrantResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnResourceOwner, value, TextEquals)
+}
+
+var (
+	groupsTable = table{
+		name:          projection.GroupProjectionTable,
+		instanceIDCol: projection.GroupColumnInstanceID,
+	}
+	GroupColumnID = Column{
+		name:  projection.GroupColumnID,
+		table: groupsTable,
+	}
+	GroupColumnCreationDate = Column{
+		name:  projection.GroupColumnCreationDate,
+		table: groupsTable,
+	}
+	GroupColumnChangeDate = Column{
+		name:  projection.GroupColumnChangeDate,
+		table: groupsTable,
+	}
+	GroupColumnSequence = Column{
+		name:  projection.GroupColumnSequence,
+		table: groupsTable,
+	}
+	GroupColumnState = Column{
+		name:  projection.GroupColumnState,
+		table: groupsTable,
+	}
+	GroupColumnResourceOwner = Column{
+		name:  projection.GroupColumnResourceOwner,
+		table: groupsTable,
+	}
+	GroupColumnInstanceID = Column{
+		name:  projection.GroupColumnInstanceID,
+		table: groupsTable,
+	}
+	GroupColumnName = Column{
+		name:  projection.GroupColumnName,
+		table: groupsTable,
+	}
+	GroupColumnDescription = Column{
+		name:  projection.GroupColumnDescription,
+		table: groupsTable,
+	}
+)
+
+var (
+	groupMembersTable = table{
+		name:          projection.GroupMemberTable,
+		instanceIDCol: projection.GroupMemberColumnInstanceID,
+	}
+	GroupMemberColumnGroupID = Column{
+		name:  projection.GroupMemberColumnGroupID,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnUserID = Column{
+		name:  projection.GroupMemberColumnUserID,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnInstanceID = Column{
+		name:  projection.GroupMemberColumnInstanceID,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnResourceOwner = Column{
+		name:  projection.GroupMemberColumnResourceOwner,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnCreationDate = Column{
+		name:  projection.GroupMemberColumnCreationDate,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnChangeDate = Column{
+		name:  projection.GroupMemberColumnChangeDate,
+		table: groupMembersTable,
+	}
+	GroupMemberColumnSequence = Column{
+		name:  projection.GroupMemberColumnSequence,
+		table: groupMembersTable,
+	}
+)
+
+var (
+	groupGrantsTable = table{
+		name:          projection.GroupGrantTable,
+		instanceIDCol: projection.GroupGrantColumnInstanceID,
+	}
+	GroupGrantColumnID = Column{
+		name:  projection.GroupGrantColumnID,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnGroupID = Column{
+		name:  projection.GroupGrantColumnGroupID,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnInstanceID = Column{
+		name:  projection.GroupGrantColumnInstanceID,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnResourceOwner = Column{
+		name:  projection.GroupGrantColumnResourceOwner,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnCreationDate = Column{
+		name:  projection.GroupGrantColumnCreationDate,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnChangeDate = Column{
+		name:  projection.GroupGrantColumnChangeDate,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnSequence = Column{
+		name:  projection.GroupGrantColumnSequence,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnProjectID = Column{
+		name:  projection.GroupGrantColumnProjectID,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnGrantID = Column{
+		name:  projection.GroupGrantColumnGrantID,
+		table: groupGrantsTable,
+	}
+	GroupGrantColumnRoleKeys = Column{
+		name:  projection.GroupGrantColumnRoleKeys,
+		table: groupGrantsTable,
+	}
+)
+
+func (q *Queries) GroupByID(ctx context.Context, shouldTriggerBulk bool, id string, queries ...SearchQuery) (_ *Group, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		projection.GroupProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareGroupQuery()
+	for _, q := range queries {
+		query = q.toQuery(query)
+	}
+	stmt, args, err := query.
+		Where(sq.Eq{
+			GroupColumnID.identifier():         id,
+			GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gr2bq", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchGroups(ctx context.Context, queries *GroupSearchQueries) (groups *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupsQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Gr3kd", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gr4lx", "Errors.Internal")
+	}
+	groups, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	groups.LatestSequence, err = q.latestSequence(ctx, groupsTable)
+	return groups, err
+}
+
+// UserGroups returns the groups the user is a member of
+func (q *Queries) UserGroups(ctx context.Context, userID string) (groups *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupsQuery()
+	stmt, args, err := query.
+		Join(join(GroupMemberColumnGroupID, GroupColumnID)).
+		Where(sq.Eq{
+			GroupMemberColumnUserID.identifier(): userID,
+			GroupColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gr5mv", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gr6pw", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func (q *Queries) SearchGroupMembers(ctx context.Context, queries *GroupMemberSearchQueries) (members *GroupMembers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupMembersQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Gr7ne", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gr8qa", "Errors.Internal")
+	}
+	members, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	members.LatestSequence, err = q.latestSequence(ctx, groupsTable)
+	return members, err
+}
+
+func (q *Queries) SearchGroupGrants(ctx context.Context, queries *GroupGrantSearchQueries) (grants *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupGrantsQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Gr9rb", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gs0tc", "Errors.Internal")
+	}
+	grants, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	grants.LatestSequence, err = q.latestSequence(ctx, groupsTable)
+	return grants, err
+}
+
+// UserGroupGrants returns the grants the user inherits through the membership of groups.
+// The grants are returned as user grants of the user, referencing the group they were inherited from.
+func (q *Queries) UserGroupGrants(ctx context.Context, userID string, queries ...SearchQuery) (grants *UserGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserGroupGrantsQuery()
+	for _, q := range queries {
+		query = q.toQuery(query)
+	}
+	stmt, args, err := query.
+		Where(sq.Eq{
+			GroupMemberColumnUserID.identifier():    userID,
+			GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gs1ud", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gs2ve", "Errors.Internal")
+	}
+	grants, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants.UserGrants {
+		grant.UserID = userID
+	}
+	return grants, nil
+}
+
+func prepareGroupQuery() (sq.SelectBuilder, func(*sql.Row) (*Group, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+		).
+			From(groupsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Group, error) {
+			g := new(Group)
+			description := sql.NullString{}
+			err := row.Scan(
+				&g.ID,
+				&g.CreationDate,
+				&g.ChangeDate,
+				&g.Sequence,
+				&g.State,
+				&g.ResourceOwner,
+				&g.Name,
+				&description,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Gs3wf", "Errors.Group.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Gs4xg", "Errors.Internal")
+			}
+			g.Description = description.String
+			return g, nil
+		}
+}
+
+func prepareGroupsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Groups, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+			countColumn.identifier(),
+		).
+			From(groupsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Groups, error) {
+			groups := make([]*Group, 0)
+			var count uint64
+			for rows.Next() {
+				g := new(Group)
+				description := sql.NullString{}
+				err := rows.Scan(
+					&g.ID,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.State,
+					&g.ResourceOwner,
+					&g.Name,
+					&description,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				g.Description = description.String
+				groups = append(groups, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gs5yh", "Errors.Query.CloseRows")
+			}
+
+			return &Groups{
+				Groups: groups,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupMembersQuery() (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberColumnGroupID.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			GroupMemberColumnCreationDate.identifier(),
+			GroupMemberColumnChangeDate.identifier(),
+			GroupMemberColumnSequence.identifier(),
+			GroupMemberColumnResourceOwner.identifier(),
+			LoginNameNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			MachineNameCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(groupMembersTable.identifier()).
+			LeftJoin(join(HumanUserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(MachineUserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberColumnUserID)).
+			Where(
+				sq.Eq{LoginNameIsPrimaryCol.identifier(): true},
+			).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			members := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				m := new(GroupMember)
+				var (
+					preferredLoginName = sql.NullString{}
+					displayName        = sql.NullString{}
+					machineName        = sql.NullString{}
+				)
+				err := rows.Scan(
+					&m.GroupID,
+					&m.UserID,
+					&m.CreationDate,
+					&m.ChangeDate,
+					&m.Sequence,
+					&m.ResourceOwner,
+					&preferredLoginName,
+					&displayName,
+					&machineName,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				m.PreferredLoginName = preferredLoginName.String
+				m.DisplayName = displayName.String
+				if !displayName.Valid {
+					m.DisplayName = machineName.String
+				}
+				members = append(members, m)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gs6zi", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				Members: members,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*GroupGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnResourceOwner.identifier(),
+			GroupGrantColumnProjectID.identifier(),
+			ProjectColumnName.identifier(),
+			GroupGrantColumnGrantID.identifier(),
+			GroupGrantColumnRoleKeys.identifier(),
+			countColumn.identifier(),
+		).
+			From(groupGrantsTable.identifier()).
+			LeftJoin(join(ProjectColumnID, GroupGrantColumnProjectID)).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupGrants, error) {
+			grants := make([]*GroupGrant, 0)
+			var count uint64
+			for rows.Next() {
+				g := new(GroupGrant)
+				projectName := sql.NullString{}
+				err := rows.Scan(
+					&g.ID,
+					&g.GroupID,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.ResourceOwner,
+					&g.ProjectID,
+					&projectName,
+					&g.GrantID,
+					&g.RoleKeys,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				g.ProjectName = projectName.String
+				grants = append(grants, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gs7aj", "Errors.Query.CloseRows")
+			}
+
+			return &GroupGrants{
+				GroupGrants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareUserGroupGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnGrantID.identifier(),
+			GroupGrantColumnRoleKeys.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupColumnName.identifier(),
+
+			GroupGrantColumnResourceOwner.identifier(),
+			OrgColumnName.identifier(),
+			OrgColumnDomain.identifier(),
+
+			GroupGrantColumnProjectID.identifier(),
+			ProjectColumnName.identifier(),
+		).
+			From(groupGrantsTable.identifier()).
+			Join(join(GroupMemberColumnGroupID, GroupGrantColumnGroupID)).
+			Join(join(GroupColumnID, GroupGrantColumnGroupID)).
+			LeftJoin(join(OrgColumnID, GroupGrantColumnResourceOwner)).
+			LeftJoin(join(ProjectColumnID, GroupGrantColumnProjectID)).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserGrants, error) {
+			grants := make([]*UserGrant, 0)
+			for rows.Next() {
+				g := &UserGrant{State: domain.UserGrantStateActive}
+				var (
+					orgName     sql.NullString
+					orgDomain   sql.NullString
+					projectName sql.NullString
+				)
+				err := rows.Scan(
+					&g.ID,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.GrantID,
+					&g.Roles,
+					&g.GroupID,
+					&g.GroupName,
+
+					&g.ResourceOwner,
+					&orgName,
+					&orgDomain,
+
+					&g.ProjectID,
+					&projectName,
+				)
+				if err != nil {
+					return nil, err
+				}
+				g.OrgName = orgName.String
+				g.OrgPrimaryDomain = orgDomain.String
+				g.ProjectName = projectName.String
+				grants = append(grants, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gs8bk", "Errors.Query.CloseRows")
+			}
+
+			return &UserGrants{
+				UserGrants: grants,
+				SearchResponse: SearchResponse{
+					Count: uint64(len(grants)),
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,427 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	groupStmt = regexp.QuoteMeta(`SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.state,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description` +
+		` FROM projections.groups`)
+	groupCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"resource_owner",
+		"name",
+		"description",
+	}
+	groupsStmt = regexp.QuoteMeta(`SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.state,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups`)
+	groupsCols = append(groupCols, "count")
+
+	groupMembersStmt = regexp.QuoteMeta(`SELECT projections.groups_members.group_id,` +
+		` projections.groups_members.user_id,` +
+		` projections.groups_members.creation_date,` +
+		` projections.groups_members.change_date,` +
+		` projections.groups_members.sequence,` +
+		` projections.groups_members.resource_owner,` +
+		` projections.login_names.login_name,` +
+		` projections.users5_humans.display_name,` +
+		` projections.users5_machines.name,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_members` +
+		` LEFT JOIN projections.users5_humans ON projections.groups_members.user_id = projections.users5_humans.user_id AND projections.groups_members.instance_id = projections.users5_humans.instance_id` +
+		` LEFT JOIN projections.users5_machines ON projections.groups_members.user_id = projections.users5_machines.user_id AND projections.groups_members.instance_id = projections.users5_machines.instance_id` +
+		` LEFT JOIN projections.login_names ON projections.groups_members.user_id = projections.login_names.user_id AND projections.groups_members.instance_id = projections.login_names.instance_id` +
+		` WHERE projections.login_names.is_primary = $1`)
+	groupMembersCols = []string{
+		"group_id",
+		"user_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"login_name",
+		"display_name",
+		"name",
+		"count",
+	}
+
+	groupGrantsStmt = regexp.QuoteMeta(`SELECT projections.groups_grants.id,` +
+		` projections.groups_grants.group_id,` +
+		` projections.groups_grants.creation_date,` +
+		` projections.groups_grants.change_date,` +
+		` projections.groups_grants.sequence,` +
+		` projections.groups_grants.resource_owner,` +
+		` projections.groups_grants.project_id,` +
+		` projections.projects2.name,` +
+		` projections.groups_grants.grant_id,` +
+		` projections.groups_grants.role_keys,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_grants` +
+		` LEFT JOIN projections.projects2 ON projections.groups_grants.project_id = projections.projects2.id AND projections.groups_grants.instance_id = projections.projects2.instance_id`)
+	groupGrantsCols = []string{
+		"id",
+		"group_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"project_id",
+		"name",
+		"grant_id",
+		"role_keys",
+		"count",
+	}
+
+	userGroupGrantsStmt = regexp.QuoteMeta(`SELECT projections.groups_grants.id,` +
+		` projections.groups_grants.creation_date,` +
+		` projections.groups_grants.change_date,` +
+		` projections.groups_grants.sequence,` +
+		` projections.groups_grants.grant_id,` +
+		` projections.groups_grants.role_keys,` +
+		` projections.groups_grants.group_id,` +
+		` projections.groups.name,` +
+		` projections.groups_grants.resource_owner,` +
+		` projections.orgs.name,` +
+		` projections.orgs.primary_domain,` +
+		` projections.groups_grants.project_id,` +
+		` projections.projects2.name` +
+		` FROM projections.groups_grants` +
+		` JOIN projections.groups_members ON projections.groups_grants.group_id = projections.groups_members.group_id AND projections.groups_grants.instance_id = projections.groups_members.instance_id` +
+		` JOIN projections.groups ON projections.groups_grants.group_id = projections.groups.id AND projections.groups_grants.instance_id = projections.groups.instance_id` +
+		` LEFT JOIN projections.orgs ON projections.groups_grants.resource_owner = projections.orgs.id AND projections.groups_grants.instance_id = projections.orgs.instance_id` +
+		` LEFT JOIN projections.projects2 ON projections.groups_grants.project_id = projections.projects2.id AND projections.groups_grants.instance_id = projections.projects2.instance_id`)
+	userGroupGrantsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"grant_id",
+		"role_keys",
+		"group_id",
+		"name",
+		"resource_owner",
+		"name",
+		"primary_domain",
+		"project_id",
+		"name",
+	}
+)
+
+func Test_GroupPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareGroupQuery no result",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Group)(nil),
+		},
+		{
+			name:    "prepareGroupQuery found",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					groupStmt,
+					groupCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						domain.GroupStateActive,
+						"ro",
+						"name",
+						"description",
+					},
+				),
+			},
+			object: &Group{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211108,
+				State:         domain.GroupStateActive,
+				ResourceOwner: "ro",
+				Name:          "name",
+				Description:   "description",
+			},
+		},
+		{
+			name:    "prepareGroupsQuery one result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupsStmt,
+					groupsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							domain.GroupStateActive,
+							"ro",
+							"name",
+							nil,
+						},
+					},
+				),
+			},
+			object: &Groups{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Groups: []*Group{
+					{
+						ID:            "id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+						State:         domain.GroupStateActive,
+						ResourceOwner: "ro",
+						Name:          "name",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupsQuery sql err",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					groupsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareGroupMembersQuery human and machine",
+			prepare: prepareGroupMembersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupMembersStmt,
+					groupMembersCols,
+					[][]driver.Value{
+						{
+							"group-id",
+							"user-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"login@name",
+							"display name",
+							nil,
+						},
+						{
+							"group-id",
+							"machine-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"machine@name",
+							nil,
+							"machine",
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Members: []*GroupMember{
+					{
+						GroupID:            "group-id",
+						UserID:             "user-id",
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211108,
+						ResourceOwner:      "ro",
+						PreferredLoginName: "login@name",
+						DisplayName:        "display name",
+					},
+					{
+						GroupID:            "group-id",
+						UserID:             "machine-id",
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211108,
+						ResourceOwner:      "ro",
+						PreferredLoginName: "machine@name",
+						DisplayName:        "machine",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupGrantsQuery one result",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					groupGrantsStmt,
+					groupGrantsCols,
+					[][]driver.Value{
+						{
+							"grant-id",
+							"group-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"project-id",
+							"project",
+							"",
+							database.StringArray{"role-key"},
+						},
+					},
+				),
+			},
+			object: &GroupGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupGrants: []*GroupGrant{
+					{
+						ID:            "grant-id",
+						GroupID:       "group-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+						ResourceOwner: "ro",
+						ProjectID:     "project-id",
+						ProjectName:   "project",
+						RoleKeys:      database.StringArray{"role-key"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserGroupGrantsQuery one result",
+			prepare: prepareUserGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userGroupGrantsStmt,
+					userGroupGrantsCols,
+					[][]driver.Value{
+						{
+							"grant-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"",
+							database.StringArray{"role-key"},
+							"group-id",
+							"group",
+							"ro",
+							"org",
+							"primary.domain",
+							"project-id",
+							"project",
+						},
+					},
+				),
+			},
+			object: &UserGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				UserGrants: []*UserGrant{
+					{
+						ID:               "grant-id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211108,
+						Roles:            database.StringArray{"role-key"},
+						State:            domain.UserGrantStateActive,
+						GroupID:          "group-id",
+						GroupName:        "group",
+						ResourceOwner:    "ro",
+						OrgName:          "org",
+						OrgPrimaryDomain: "primary.domain",
+						ProjectID:        "project-id",
+						ProjectName:      "project",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserGroupGrantsQuery sql err",
+			prepare: prepareUserGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userGroupGrantsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -33,6 +33,7 @@ type LoginPolicy struct {
 	AllowDomainDiscovery       bool
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
 	DefaultRedirectURI         string
 	PasswordCheckLifetime      time.Duration
 	ExternalLoginCheckLifetime time.Duration
@@ -129,6 +130,10 @@ var (
 		name:  projection.DisableLoginWithPhone,
 		table: loginPolicyTable,
 	}
+	LoginPolicyColumnAllowMagicLink = Column{
+		name:  projection.AllowMagicLink,
+		table: loginPolicyTable,
+	}
 	LoginPolicyColumnDefaultRedirectURI = Column{
 		name:  projection.DefaultRedirectURI,
 		table: loginPolicyTable,
@@ -338,6 +343,7 @@ func prepareLoginPolicyQuery() (sq.SelectBuilder, func(*sql.Rows) (*LoginPolicy,
 			LoginPolicyColumnAllowDomainDiscovery.identifier(),
 			LoginPolicyColumnDisableLoginWithEmail.identifier(),
 			LoginPolicyColumnDisableLoginWithPhone.identifier(),
+			LoginPolicyColumnAllowMagicLink.identifier(),
 			LoginPolicyColumnDefaultRedirectURI.identifier(),
 			LoginPolicyColumnPasswordCheckLifetime.identifier(),
 			LoginPolicyColumnExternalLoginCheckLifetime.identifier(),
@@ -368,6 +374,7 @@ func prepareLoginPolicyQuery() (sq.SelectBuilder, func(*sql.Rows) (*LoginPolicy,
 					&p.AllowDomainDiscovery,
 					&p.DisableLoginWithEmail,
 					&p.DisableLoginWithPhone,
+					&p.AllowMagicLink,
 					&defaultRedirectURI,
 					&p.PasswordCheckLifetime,
 					&p.ExternalLoginCheckLifetime,
@@ -15,30 +15,31 @@ import (
 )
 
 var (
-	loginPolicyQuery = `SELECT projections.login_policies3.aggregate_id,` +
-		` projections.login_policies3.creation_date,` +
-		` projections.login_policies3.change_date,` +
-		` projections.login_policies3.sequence,` +
-		` projections.login_policies3.allow_register,` +
-		` projections.login_policies3.allow_username_password,` +
-		` projections.login_policies3.allow_external_idps,` +
-		` projections.login_policies3.force_mfa,` +
-		` projections.login_policies3.second_factors,` +
-		` projections.login_policies3.multi_factors,` +
-		` projections.login_policies3.passwordless_type,` +
-		` projections.login_policies3.is_default,` +
-		` projections.login_policies3.hide_password_reset,` +
-		` projections.login_policies3.ignore_unknown_usernames,` +
-		` projections.login_policies3.allow_domain_discovery,` +
-		` projections.login_policies3.disable_login_with_email,` +
-		` projections.login_policies3.disable_login_with_phone,` +
-		` projections.login_policies3.default_redirect_uri,` +
-		` projections.login_policies3.password_check_lifetime,` +
-		` projections.login_policies3.external_login_check_lifetime,` +
-		` projections.login_policies3.mfa_init_skip_lifetime,` +
-		` projections.login_policies3.second_factor_check_lifetime,` +
-		` projections.login_policies3.multi_factor_check_lifetime` +
-		` FROM projections.login_policies3`
+	loginPolicyQuery = `SELECT projections.login_policies4.aggregate_id,` +
+		` projections.login_policies4.creation_date,` +
+		` projections.login_policies4.change_date,` +
+		` projections.login_policies4.sequence,` +
+		` projections.login_policies4.allow_register,` +
+		` projections.login_policies4.allow_username_password,` +
+		` projections.login_policies4.allow_external_idps,` +
+		` projections.login_policies4.force_mfa,` +
+		` projections.login_policies4.second_factors,` +
+		` projections.login_policies4.multi_factors,` +
+		` projections.login_policies4.passwordless_type,` +
+		` projections.login_policies4.is_default,` +
+		` projections.login_policies4.hide_password_reset,` +
+		` projections.login_policies4.ignore_unknown_usernames,` +
+		` projections.login_policies4.allow_domain_discovery,` +
+		` projections.login_policies4.disable_login_with_email,` +
+		` projections.login_policies4.disable_login_with_phone,` +
+		` projections.login_policies4.allow_magic_link,` +
+		` projections.login_policies4.default_redirect_uri,` +
+		` projections.login_policies4.password_check_lifetime,` +
+		` projections.login_policies4.external_login_check_lifetime,` +
+		` projections.login_policies4.mfa_init_skip_lifetime,` +
+		` projections.login_policies4.second_factor_check_lifetime,` +
+		` projections.login_policies4.multi_factor_check_lifetime` +
+		` FROM projections.login_policies4`
 	loginPolicyCols = []string{
 		"aggregate_id",
 		"creation_date",
@@ -57,6 +58,7 @@ var (
 		"allow_domain_discovery",
 		"disable_login_with_email",
 		"disable_login_with_phone",
+		"allow_magic_link",
 		"default_redirect_uri",
 		"password_check_lifetime",
 		"external_login_check_lifetime",
@@ -120,6 +122,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
 						"https://example.com/redirect",
 						time.Hour * 2,
 						time.Hour * 2,
@@ -147,6 +150,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 				AllowDomainDiscovery:       true,
 				DisableLoginWithEmail:      true,
 				DisableLoginWithPhone:      true,
+				AllowMagicLink:             true,
 				DefaultRedirectURI:         "https://example.com/redirect",
 				PasswordCheckLifetime:      time.Hour * 2,
 				ExternalLoginCheckLifetime: time.Hour * 2,
@@ -177,8 +181,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.second_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"second_factors",
 					},
@@ -198,8 +202,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.second_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"second_factors",
 					},
@@ -220,8 +224,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.second_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"second_factors",
 					},
@@ -237,8 +241,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicy2FAsQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.second_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.second_factors`+
+						` FROM projections.login_policies4`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -255,8 +259,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.multi_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"multi_factors",
 					},
@@ -276,8 +280,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.multi_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"multi_factors",
 					},
@@ -298,8 +302,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.multi_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
+						` FROM projections.login_policies4`),
 					[]string{
 						"multi_factors",
 					},
@@ -315,8 +319,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 			prepare: prepareLoginPolicyMFAsQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.login_policies3.multi_factors`+
-						` FROM projections.login_policies3`),
+					regexp.QuoteMeta(`SELECT projections.login_policies4.multi_factors`+
+						` FROM projections.login_policies4`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -8,17 +8,17 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
-func (q *Queries) GetIAMMemberRoles() []string {
+func (q *Queries) GetIAMMemberRoles(ctx context.Context) ([]string, error) {
 	roles := make([]string, 0)
 	for _, roleMap := range q.zitadelRoles {
 		if strings.HasPrefix(roleMap.Role, "IAM") {
 			roles = append(roles, roleMap.Role)
 		}
 	}
-	return roles
+	return q.appendCustomRoles(ctx, roles, domain.IAMRolePrefix)
 }
 
-func (q *Queries) GetOrgMemberRoles(isGlobal bool) []string {
+func (q *Queries) GetOrgMemberRoles(ctx context.Context, isGlobal bool) ([]string, error) {
 	roles := make([]string, 0)
 	for _, roleMap := range q.zitadelRoles {
 		if strings.HasPrefix(roleMap.Role, "ORG") {
@@ -28,7 +28,22 @@ func (q *Queries) GetOrgMemberRoles(isGlobal bool) []string {
 	if isGlobal {
 		roles = append(roles, domain.RoleSelfManagementGlobal)
 	}
-	return roles
+	return q.appendCustomRoles(ctx, roles, domain.OrgRolePrefix)
+}
+
+func (q *Queries) appendCustomRoles(ctx context.Context, roles []string, rolePrefix string) ([]string, error) {
+	keyQuery, err := NewCustomRoleKeySearchQuery(TextStartsWith, rolePrefix+"_")
+	if err != nil {
+		return nil, err
+	}
+	customRoles, err := q.SearchCustomRoles(ctx, &CustomRoleSearchQueries{Queries: []SearchQuery{keyQuery}})
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range customRoles.CustomRoles {
+		roles = append(roles, role.Key)
+	}
+	return roles, nil
 }
 
 func (q *Queries) GetProjectMemberRoles(ctx context.Context) ([]string, error) {
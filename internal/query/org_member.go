@@ -45,6 +45,14 @@ var (
 		name:  projection.MemberInstanceID,
 		table: orgMemberTable,
 	}
+	OrgMemberValidFrom = Column{
+		name:  projection.MemberValidFrom,
+		table: orgMemberTable,
+	}
+	OrgMemberValidUntil = Column{
+		name:  projection.MemberValidUntil,
+		table: orgMemberTable,
+	}
 	OrgMemberOrgID = Column{
 		name:  projection.OrgMemberOrgIDCol,
 		table: orgMemberTable,
@@ -27,7 +27,7 @@ var (
 		", projections.users5_machines.name" +
 		", projections.users5_humans.avatar_key" +
 		", COUNT(*) OVER () " +
-		"FROM projections.org_members2 AS members " +
+		"FROM projections.org_members3 AS members " +
 		"LEFT JOIN projections.users5_humans " +
 		"ON members.user_id = projections.users5_humans.user_id " +
 		"AND members.instance_id = projections.users5_humans.instance_id " +
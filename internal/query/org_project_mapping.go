@@ -0,0 +1,96 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	orgProjectMappingsTable = table{
+		name:          projection.OrgProjectMappingProjectionTable,
+		instanceIDCol: projection.OrgProjectMappingColumnInstanceID,
+	}
+	OrgProjectMappingColumnOrgID = Column{
+		name:  projection.OrgProjectMappingColumnOrgID,
+		table: orgProjectMappingsTable,
+	}
+	OrgProjectMappingColumnProjectID = Column{
+		name:  projection.OrgProjectMappingColumnProjectID,
+		table: orgProjectMappingsTable,
+	}
+	OrgProjectMappingColumnProjectGrantID = Column{
+		name:  projection.OrgProjectMappingColumnProjectGrantID,
+		table: orgProjectMappingsTable,
+	}
+	OrgProjectMappingColumnInstanceID = Column{
+		name:  projection.OrgProjectMappingColumnInstanceID,
+		table: orgProjectMappingsTable,
+	}
+	OrgProjectMappingColumnCreationDate = Column{
+		name:  projection.OrgProjectMappingColumnCreationDate,
+		table: orgProjectMappingsTable,
+	}
+	OrgProjectMappingColumnSequence = Column{
+		name:  projection.OrgProjectMappingColumnSequence,
+		table: orgProjectMappingsTable,
+	}
+)
+
+type OrgProjectMapping struct {
+	OrgID          string
+	ProjectID      string
+	ProjectGrantID string
+	CreationDate   time.Time
+	Sequence       uint64
+}
+
+// OrgProjectMappingByIDs returns the mapping if the organisation owns or was granted the project
+func (q *Queries) OrgProjectMappingByIDs(ctx context.Context, orgID, projectID string) (*OrgProjectMapping, error) {
+	query, scan := prepareOrgProjectMappingQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		OrgProjectMappingColumnOrgID.identifier():      orgID,
+		OrgProjectMappingColumnProjectID.identifier():  projectID,
+		OrgProjectMappingColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Qz6ug", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareOrgProjectMappingQuery() (sq.SelectBuilder, func(*sql.Row) (*OrgProjectMapping, error)) {
+	return sq.Select(
+			OrgProjectMappingColumnOrgID.identifier(),
+			OrgProjectMappingColumnProjectID.identifier(),
+			OrgProjectMappingColumnProjectGrantID.identifier(),
+			OrgProjectMappingColumnCreationDate.identifier(),
+			OrgProjectMappingColumnSequence.identifier()).
+			From(orgProjectMappingsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*OrgProjectMapping, error) {
+			m := new(OrgProjectMapping)
+			err := row.Scan(
+				&m.OrgID,
+				&m.ProjectID,
+				&m.ProjectGrantID,
+				&m.CreationDate,
+				&m.Sequence,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ce9xh", "Errors.OrgProjectMapping.NotExisting")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Sr2nw", "Errors.Internal")
+			}
+			return m, nil
+		}
+}
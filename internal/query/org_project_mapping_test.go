@@ -0,0 +1,107 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	orgProjectMappingStmt = regexp.QuoteMeta(
+		"SELECT projections.org_project_mappings.org_id," +
+			" projections.org_project_mappings.project_id," +
+			" projections.org_project_mappings.project_grant_id," +
+			" projections.org_project_mappings.creation_date," +
+			" projections.org_project_mappings.sequence" +
+			" FROM projections.org_project_mappings")
+	orgProjectMappingCols = []string{
+		"org_id",
+		"project_id",
+		"project_grant_id",
+		"creation_date",
+		"sequence",
+	}
+)
+
+func Test_OrgProjectMappingPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOrgProjectMappingQuery no result",
+			prepare: prepareOrgProjectMappingQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					orgProjectMappingStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*OrgProjectMapping)(nil),
+		},
+		{
+			name:    "prepareOrgProjectMappingQuery found",
+			prepare: prepareOrgProjectMappingQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					orgProjectMappingStmt,
+					orgProjectMappingCols,
+					[]driver.Value{
+						"org-id",
+						"project-id",
+						"grant-id",
+						testNow,
+						uint64(20211202),
+					},
+				),
+			},
+			object: &OrgProjectMapping{
+				OrgID:          "org-id",
+				ProjectID:      "project-id",
+				ProjectGrantID: "grant-id",
+				CreationDate:   testNow,
+				Sequence:       20211202,
+			},
+		},
+		{
+			name:    "prepareOrgProjectMappingQuery sql err",
+			prepare: prepareOrgProjectMappingQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					orgProjectMappingStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -24,6 +24,7 @@ type PasswordAgePolicy struct {
 
 	ExpireWarnDays uint64
 	MaxAgeDays     uint64
+	HistoryCount   uint64
 
 	IsDefault bool
 }
@@ -65,6 +66,10 @@ var (
 		name:  projection.AgePolicyMaxAgeDaysCol,
 		table: passwordAgeTable,
 	}
+	PasswordAgeColHistoryCount = Column{
+		name:  projection.AgePolicyHistoryCountCol,
+		table: passwordAgeTable,
+	}
 	PasswordAgeColIsDefault = Column{
 		name:  projection.AgePolicyIsDefaultCol,
 		table: passwordAgeTable,
@@ -133,6 +138,7 @@ func preparePasswordAgePolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*Passwor
 			PasswordAgeColResourceOwner.identifier(),
 			PasswordAgeColWarnDays.identifier(),
 			PasswordAgeColMaxAge.identifier(),
+			PasswordAgeColHistoryCount.identifier(),
 			PasswordAgeColIsDefault.identifier(),
 			PasswordAgeColState.identifier(),
 		).
@@ -147,6 +153,7 @@ func preparePasswordAgePolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*Passwor
 				&policy.ResourceOwner,
 				&policy.ExpireWarnDays,
 				&policy.MaxAgeDays,
+				&policy.HistoryCount,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -28,16 +28,17 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 			prepare: preparePasswordAgePolicyQuery,
 			want: want{
 				sqlExpectations: mockQueries(
-					regexp.QuoteMeta(`SELECT projections.password_age_policies.id,`+
-						` projections.password_age_policies.sequence,`+
-						` projections.password_age_policies.creation_date,`+
-						` projections.password_age_policies.change_date,`+
-						` projections.password_age_policies.resource_owner,`+
-						` projections.password_age_policies.expire_warn_days,`+
-						` projections.password_age_policies.max_age_days,`+
-						` projections.password_age_policies.is_default,`+
-						` projections.password_age_policies.state`+
-						` FROM projections.password_age_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_age_policies2.id,`+
+						` projections.password_age_policies2.sequence,`+
+						` projections.password_age_policies2.creation_date,`+
+						` projections.password_age_policies2.change_date,`+
+						` projections.password_age_policies2.resource_owner,`+
+						` projections.password_age_policies2.expire_warn_days,`+
+						` projections.password_age_policies2.max_age_days,`+
+						` projections.password_age_policies2.history_count,`+
+						` projections.password_age_policies2.is_default,`+
+						` projections.password_age_policies2.state`+
+						` FROM projections.password_age_policies2`),
 					nil,
 					nil,
 				),
@@ -55,16 +56,17 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 			prepare: preparePasswordAgePolicyQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.password_age_policies.id,`+
-						` projections.password_age_policies.sequence,`+
-						` projections.password_age_policies.creation_date,`+
-						` projections.password_age_policies.change_date,`+
-						` projections.password_age_policies.resource_owner,`+
-						` projections.password_age_policies.expire_warn_days,`+
-						` projections.password_age_policies.max_age_days,`+
-						` projections.password_age_policies.is_default,`+
-						` projections.password_age_policies.state`+
-						` FROM projections.password_age_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_age_policies2.id,`+
+						` projections.password_age_policies2.sequence,`+
+						` projections.password_age_policies2.creation_date,`+
+						` projections.password_age_policies2.change_date,`+
+						` projections.password_age_policies2.resource_owner,`+
+						` projections.password_age_policies2.expire_warn_days,`+
+						` projections.password_age_policies2.max_age_days,`+
+						` projections.password_age_policies2.history_count,`+
+						` projections.password_age_policies2.is_default,`+
+						` projections.password_age_policies2.state`+
+						` FROM projections.password_age_policies2`),
 					[]string{
 						"id",
 						"sequence",
@@ -73,6 +75,7 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 						"resource_owner",
 						"expire_warn_days",
 						"max_age_days",
+						"history_count",
 						"is_default",
 						"state",
 					},
@@ -84,6 +87,7 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 						"ro",
 						10,
 						20,
+						5,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -98,6 +102,7 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 				State:          domain.PolicyStateActive,
 				ExpireWarnDays: 10,
 				MaxAgeDays:     20,
+				HistoryCount:   5,
 				IsDefault:      true,
 			},
 		},
@@ -106,16 +111,17 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 			prepare: preparePasswordAgePolicyQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.password_age_policies.id,`+
-						` projections.password_age_policies.sequence,`+
-						` projections.password_age_policies.creation_date,`+
-						` projections.password_age_policies.change_date,`+
-						` projections.password_age_policies.resource_owner,`+
-						` projections.password_age_policies.expire_warn_days,`+
-						` projections.password_age_policies.max_age_days,`+
-						` projections.password_age_policies.is_default,`+
-						` projections.password_age_policies.state`+
-						` FROM projections.password_age_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_age_policies2.id,`+
+						` projections.password_age_policies2.sequence,`+
+						` projections.password_age_policies2.creation_date,`+
+						` projections.password_age_policies2.change_date,`+
+						` projections.password_age_policies2.resource_owner,`+
+						` projections.password_age_policies2.expire_warn_days,`+
+						` projections.password_age_policies2.max_age_days,`+
+						` projections.password_age_policies2.history_count,`+
+						` projections.password_age_policies2.is_default,`+
+						` projections.password_age_policies2.state`+
+						` FROM projections.password_age_policies2`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -28,19 +28,20 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 			prepare: preparePasswordComplexityPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueries(
-					regexp.QuoteMeta(`SELECT projections.password_complexity_policies.id,`+
-						` projections.password_complexity_policies.sequence,`+
-						` projections.password_complexity_policies.creation_date,`+
-						` projections.password_complexity_policies.change_date,`+
-						` projections.password_complexity_policies.resource_owner,`+
-						` projections.password_complexity_policies.min_length,`+
-						` projections.password_complexity_policies.has_lowercase,`+
-						` projections.password_complexity_policies.has_uppercase,`+
-						` projections.password_complexity_policies.has_number,`+
-						` projections.password_complexity_policies.has_symbol,`+
-						` projections.password_complexity_policies.is_default,`+
-						` projections.password_complexity_policies.state`+
-						` FROM projections.password_complexity_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_complexity_policies2.id,`+
+						` projections.password_complexity_policies2.sequence,`+
+						` projections.password_complexity_policies2.creation_date,`+
+						` projections.password_complexity_policies2.change_date,`+
+						` projections.password_complexity_policies2.resource_owner,`+
+						` projections.password_complexity_policies2.min_length,`+
+						` projections.password_complexity_policies2.has_lowercase,`+
+						` projections.password_complexity_policies2.has_uppercase,`+
+						` projections.password_complexity_policies2.has_number,`+
+						` projections.password_complexity_policies2.has_symbol,`+
+						` projections.password_complexity_policies2.reject_breached,`+
+						` projections.password_complexity_policies2.is_default,`+
+						` projections.password_complexity_policies2.state`+
+						` FROM projections.password_complexity_policies2`),
 					nil,
 					nil,
 				),
@@ -58,19 +59,20 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 			prepare: preparePasswordComplexityPolicyQuery,
 			want: want{
 				sqlExpectations: mockQuery(
-					regexp.QuoteMeta(`SELECT projections.password_complexity_policies.id,`+
-						` projections.password_complexity_policies.sequence,`+
-						` projections.password_complexity_policies.creation_date,`+
-						` projections.password_complexity_policies.change_date,`+
-						` projections.password_complexity_policies.resource_owner,`+
-						` projections.password_complexity_policies.min_length,`+
-						` projections.password_complexity_policies.has_lowercase,`+
-						` projections.password_complexity_policies.has_uppercase,`+
-						` projections.password_complexity_policies.has_number,`+
-						` projections.password_complexity_policies.has_symbol,`+
-						` projections.password_complexity_policies.is_default,`+
-						` projections.password_complexity_policies.state`+
-						` FROM projections.password_complexity_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_complexity_policies2.id,`+
+						` projections.password_complexity_policies2.sequence,`+
+						` projections.password_complexity_policies2.creation_date,`+
+						` projections.password_complexity_policies2.change_date,`+
+						` projections.password_complexity_policies2.resource_owner,`+
+						` projections.password_complexity_policies2.min_length,`+
+						` projections.password_complexity_policies2.has_lowercase,`+
+						` projections.password_complexity_policies2.has_uppercase,`+
+						` projections.password_complexity_policies2.has_number,`+
+						` projections.password_complexity_policies2.has_symbol,`+
+						` projections.password_complexity_policies2.reject_breached,`+
+						` projections.password_complexity_policies2.is_default,`+
+						` projections.password_complexity_policies2.state`+
+						` FROM projections.password_complexity_policies2`),
 					[]string{
 						"id",
 						"sequence",
@@ -82,6 +84,7 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						"has_uppercase",
 						"has_number",
 						"has_symbol",
+						"reject_breached",
 						"is_default",
 						"state",
 					},
@@ -97,23 +100,25 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
 			},
 			object: &PasswordComplexityPolicy{
-				ID:            "pol-id",
-				CreationDate:  testNow,
-				ChangeDate:    testNow,
-				Sequence:      20211109,
-				ResourceOwner: "ro",
-				State:         domain.PolicyStateActive,
-				MinLength:     8,
-				HasLowercase:  true,
-				HasUppercase:  true,
-				HasNumber:     true,
-				HasSymbol:     true,
-				IsDefault:     true,
+				ID:             "pol-id",
+				CreationDate:   testNow,
+				ChangeDate:     testNow,
+				Sequence:       20211109,
+				ResourceOwner:  "ro",
+				State:          domain.PolicyStateActive,
+				MinLength:      8,
+				HasLowercase:   true,
+				HasUppercase:   true,
+				HasNumber:      true,
+				HasSymbol:      true,
+				RejectBreached: true,
+				IsDefault:      true,
 			},
 		},
 		{
@@ -121,19 +126,20 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 			prepare: preparePasswordComplexityPolicyQuery,
 			want: want{
 				sqlExpectations: mockQueryErr(
-					regexp.QuoteMeta(`SELECT projections.password_complexity_policies.id,`+
-						` projections.password_complexity_policies.sequence,`+
-						` projections.password_complexity_policies.creation_date,`+
-						` projections.password_complexity_policies.change_date,`+
-						` projections.password_complexity_policies.resource_owner,`+
-						` projections.password_complexity_policies.min_length,`+
-						` projections.password_complexity_policies.has_lowercase,`+
-						` projections.password_complexity_policies.has_uppercase,`+
-						` projections.password_complexity_policies.has_number,`+
-						` projections.password_complexity_policies.has_symbol,`+
-						` projections.password_complexity_policies.is_default,`+
-						` projections.password_complexity_policies.state`+
-						` FROM projections.password_complexity_policies`),
+					regexp.QuoteMeta(`SELECT projections.password_complexity_policies2.id,`+
+						` projections.password_complexity_policies2.sequence,`+
+						` projections.password_complexity_policies2.creation_date,`+
+						` projections.password_complexity_policies2.change_date,`+
+						` projections.password_complexity_policies2.resource_owner,`+
+						` projections.password_complexity_policies2.min_length,`+
+						` projections.password_complexity_policies2.has_lowercase,`+
+						` projections.password_complexity_policies2.has_uppercase,`+
+						` projections.password_complexity_policies2.has_number,`+
+						` projections.password_complexity_policies2.has_symbol,`+
+						` projections.password_complexity_policies2.reject_breached,`+
+						` projections.password_complexity_policies2.is_default,`+
+						` projections.password_complexity_policies2.state`+
+						` FROM projections.password_complexity_policies2`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
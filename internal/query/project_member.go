@@ -46,6 +46,14 @@ var (
 		name:  projection.MemberInstanceID,
 		table: projectMemberTable,
 	}
+	ProjectMemberValidFrom = Column{
+		name:  projection.MemberValidFrom,
+		table: projectMemberTable,
+	}
+	ProjectMemberValidUntil = Column{
+		name:  projection.MemberValidUntil,
+		table: projectMemberTable,
+	}
 	ProjectMemberProjectID = Column{
 		name:  projection.ProjectMemberProjectIDCol,
 		table: projectMemberTable,
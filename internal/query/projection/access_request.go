@@ -0,0 +1,208 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	AccessRequestProjectionTable = "projections.access_requests"
+
+	AccessRequestID             = "id"
+	AccessRequestCreationDate   = "creation_date"
+	AccessRequestChangeDate     = "change_date"
+	AccessRequestSequence       = "sequence"
+	AccessRequestState          = "state"
+	AccessRequestResourceOwner  = "resource_owner"
+	AccessRequestInstanceID     = "instance_id"
+	AccessRequestUserID         = "user_id"
+	AccessRequestProjectID      = "project_id"
+	AccessRequestRoleKeys       = "role_keys"
+	AccessRequestReason         = "reason"
+	AccessRequestDecisionReason = "decision_reason"
+	AccessRequestUserGrantID    = "user_grant_id"
+)
+
+type accessRequestProjection struct {
+	crdb.StatementHandler
+}
+
+func newAccessRequestProjection(ctx context.Context, config crdb.StatementHandlerConfig) *accessRequestProjection {
+	p := new(accessRequestProjection)
+	config.ProjectionName = AccessRequestProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(AccessRequestID, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessRequestCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AccessRequestChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AccessRequestSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(AccessRequestState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(AccessRequestResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessRequestInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessRequestUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessRequestProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessRequestRoleKeys, crdb.ColumnTypeTextArray),
+			crdb.NewColumn(AccessRequestReason, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AccessRequestDecisionReason, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AccessRequestUserGrantID, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(AccessRequestInstanceID, AccessRequestID),
+			crdb.WithIndex(crdb.NewIndex("access_request_user_idx", []string{AccessRequestUserID})),
+			crdb.WithIndex(crdb.NewIndex("access_request_project_idx", []string{AccessRequestProjectID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *accessRequestProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: accessrequest.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  accessrequest.AddedType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  accessrequest.ApprovedType,
+					Reduce: p.reduceApproved,
+				},
+				{
+					Event:  accessrequest.DeniedType,
+					Reduce: p.reduceDenied,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(AccessRequestInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *accessRequestProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*accessrequest.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ak2vd", "reduce.wrong.event.type %s", accessrequest.AddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AccessRequestID, e.Aggregate().ID),
+			handler.NewCol(AccessRequestResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(AccessRequestInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(AccessRequestCreationDate, e.CreationDate()),
+			handler.NewCol(AccessRequestChangeDate, e.CreationDate()),
+			handler.NewCol(AccessRequestSequence, e.Sequence()),
+			handler.NewCol(AccessRequestUserID, e.UserID),
+			handler.NewCol(AccessRequestProjectID, e.ProjectID),
+			handler.NewCol(AccessRequestRoleKeys, database.StringArray(e.RoleKeys)),
+			handler.NewCol(AccessRequestReason, e.Reason),
+			handler.NewCol(AccessRequestState, domain.AccessRequestStatePending),
+		},
+	), nil
+}
+
+func (p *accessRequestProjection) reduceApproved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*accessrequest.ApprovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Oz8bw", "reduce.wrong.event.type %s", accessrequest.ApprovedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AccessRequestChangeDate, e.CreationDate()),
+			handler.NewCol(AccessRequestSequence, e.Sequence()),
+			handler.NewCol(AccessRequestState, domain.AccessRequestStateApproved),
+			handler.NewCol(AccessRequestUserGrantID, e.UserGrantID),
+		},
+		[]handler.Condition{
+			handler.NewCond(AccessRequestID, e.Aggregate().ID),
+			handler.NewCond(AccessRequestInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *accessRequestProjection) reduceDenied(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*accessrequest.DeniedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Nd6sx", "reduce.wrong.event.type %s", accessrequest.DeniedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AccessRequestChangeDate, e.CreationDate()),
+			handler.NewCol(AccessRequestSequence, e.Sequence()),
+			handler.NewCol(AccessRequestState, domain.AccessRequestStateDenied),
+			handler.NewCol(AccessRequestDecisionReason, e.Reason),
+		},
+		[]handler.Condition{
+			handler.NewCond(AccessRequestID, e.Aggregate().ID),
+			handler.NewCond(AccessRequestInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *accessRequestProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*user.UserRemovedEvent); !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ux5ma", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(AccessRequestUserID, event.Aggregate().ID),
+			handler.NewCond(AccessRequestInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *accessRequestProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*project.ProjectRemovedEvent); !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Pr3kz", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(AccessRequestProjectID, event.Aggregate().ID),
+			handler.NewCond(AccessRequestInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps3"
+	AppProjectionTable = "projections.apps6"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -29,32 +29,46 @@ const (
 	AppColumnState         = "state"
 	AppColumnSequence      = "sequence"
 
-	appAPITableSuffix              = "api_configs"
-	AppAPIConfigColumnAppID        = "app_id"
-	AppAPIConfigColumnInstanceID   = "instance_id"
-	AppAPIConfigColumnClientID     = "client_id"
-	AppAPIConfigColumnClientSecret = "client_secret"
-	AppAPIConfigColumnAuthMethod   = "auth_method"
+	appAPITableSuffix                            = "api_configs"
+	AppAPIConfigColumnAppID                      = "app_id"
+	AppAPIConfigColumnInstanceID                 = "instance_id"
+	AppAPIConfigColumnClientID                   = "client_id"
+	AppAPIConfigColumnClientSecret               = "client_secret"
+	AppAPIConfigColumnAuthMethod                 = "auth_method"
+	AppAPIConfigColumnAccessTokenLifetime        = "access_token_lifetime"
+	AppAPIConfigColumnIDTokenLifetime            = "id_token_lifetime"
+	AppAPIConfigColumnRefreshTokenIdleExpiration = "refresh_token_idle_expiration"
+	AppAPIConfigColumnRefreshTokenExpiration     = "refresh_token_expiration"
+	AppAPIConfigColumnRefreshTokenRotation       = "refresh_token_rotation"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
+	appOIDCTableSuffix                                    = "oidc_configs"
+	AppOIDCConfigColumnAppID                              = "app_id"
+	AppOIDCConfigColumnInstanceID                         = "instance_id"
+	AppOIDCConfigColumnVersion                            = "version"
+	AppOIDCConfigColumnClientID                           = "client_id"
+	AppOIDCConfigColumnClientSecret                       = "client_secret"
+	AppOIDCConfigColumnRedirectUris                       = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes                      = "response_types"
+	AppOIDCConfigColumnGrantTypes                         = "grant_types"
+	AppOIDCConfigColumnApplicationType                    = "application_type"
+	AppOIDCConfigColumnAuthMethodType                     = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris             = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                            = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType                    = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion           = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion               = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion           = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                          = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins                  = "additional_origins"
+	AppOIDCConfigColumnRequirePushedAuthorizationRequests = "require_pushed_authorization_requests"
+	AppOIDCConfigColumnJWKS                               = "jwks"
+	AppOIDCConfigColumnBackChannelLogoutURI               = "back_channel_logout_uri"
+	AppOIDCConfigColumnFrontChannelLogoutURI              = "front_channel_logout_uri"
+	AppOIDCConfigColumnAccessTokenLifetime                = "access_token_lifetime"
+	AppOIDCConfigColumnIDTokenLifetime                    = "id_token_lifetime"
+	AppOIDCConfigColumnRefreshTokenIdleExpiration         = "refresh_token_idle_expiration"
+	AppOIDCConfigColumnRefreshTokenExpiration             = "refresh_token_expiration"
+	AppOIDCConfigColumnRefreshTokenRotation               = "refresh_token_rotation"
 
 	appSAMLTableSuffix             = "saml_configs"
 	AppSAMLConfigColumnAppID       = "app_id"
@@ -94,6 +108,11 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppAPIConfigColumnClientID, crdb.ColumnTypeText),
 			crdb.NewColumn(AppAPIConfigColumnClientSecret, crdb.ColumnTypeJSONB, crdb.Nullable()),
 			crdb.NewColumn(AppAPIConfigColumnAuthMethod, crdb.ColumnTypeEnum),
+			crdb.NewColumn(AppAPIConfigColumnAccessTokenLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppAPIConfigColumnIDTokenLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppAPIConfigColumnRefreshTokenIdleExpiration, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppAPIConfigColumnRefreshTokenExpiration, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppAPIConfigColumnRefreshTokenRotation, crdb.ColumnTypeEnum, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(AppAPIConfigColumnInstanceID, AppAPIConfigColumnAppID),
 			appAPITableSuffix,
@@ -119,6 +138,15 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppOIDCConfigColumnIDTokenUserinfoAssertion, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(AppOIDCConfigColumnClockSkew, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(AppOIDCConfigColumnAdditionalOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(AppOIDCConfigColumnRequirePushedAuthorizationRequests, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnJWKS, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnFrontChannelLogoutURI, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppOIDCConfigColumnAccessTokenLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppOIDCConfigColumnIDTokenLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenIdleExpiration, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenExpiration, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(AppOIDCConfigColumnRefreshTokenRotation, crdb.ColumnTypeEnum, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -340,6 +368,11 @@ func (p *appProjection) reduceAPIConfigAdded(event eventstore.Event) (*handler.S
 				handler.NewCol(AppAPIConfigColumnClientID, e.ClientID),
 				handler.NewCol(AppAPIConfigColumnClientSecret, e.ClientSecret),
 				handler.NewCol(AppAPIConfigColumnAuthMethod, e.AuthMethodType),
+				handler.NewCol(AppAPIConfigColumnAccessTokenLifetime, e.AccessTokenLifetime),
+				handler.NewCol(AppAPIConfigColumnIDTokenLifetime, e.IDTokenLifetime),
+				handler.NewCol(AppAPIConfigColumnRefreshTokenIdleExpiration, e.RefreshTokenIdleExpiration),
+				handler.NewCol(AppAPIConfigColumnRefreshTokenExpiration, e.RefreshTokenExpiration),
+				handler.NewCol(AppAPIConfigColumnRefreshTokenRotation, e.RefreshTokenRotation),
 			},
 			crdb.WithTableSuffix(appAPITableSuffix),
 		),
@@ -361,13 +394,28 @@ func (p *appProjection) reduceAPIConfigChanged(event eventstore.Event) (*handler
 	if !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-vnZKi", "reduce.wrong.event.type %s", project.APIConfigChangedType)
 	}
-	cols := make([]handler.Column, 0, 2)
+	cols := make([]handler.Column, 0, 7)
 	if e.ClientSecret != nil {
 		cols = append(cols, handler.NewCol(AppAPIConfigColumnClientSecret, e.ClientSecret))
 	}
 	if e.AuthMethodType != nil {
 		cols = append(cols, handler.NewCol(AppAPIConfigColumnAuthMethod, *e.AuthMethodType))
 	}
+	if e.AccessTokenLifetime != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnAccessTokenLifetime, *e.AccessTokenLifetime))
+	}
+	if e.IDTokenLifetime != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnIDTokenLifetime, *e.IDTokenLifetime))
+	}
+	if e.RefreshTokenIdleExpiration != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnRefreshTokenIdleExpiration, *e.RefreshTokenIdleExpiration))
+	}
+	if e.RefreshTokenExpiration != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnRefreshTokenExpiration, *e.RefreshTokenExpiration))
+	}
+	if e.RefreshTokenRotation != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnRefreshTokenRotation, *e.RefreshTokenRotation))
+	}
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
 	}
@@ -451,6 +499,15 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnIDTokenUserinfoAssertion, e.IDTokenUserinfoAssertion),
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(e.AdditionalOrigins)),
+				handler.NewCol(AppOIDCConfigColumnRequirePushedAuthorizationRequests, e.RequirePushedAuthorizationRequests),
+				handler.NewCol(AppOIDCConfigColumnJWKS, e.JWKS),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, e.FrontChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnAccessTokenLifetime, e.AccessTokenLifetime),
+				handler.NewCol(AppOIDCConfigColumnIDTokenLifetime, e.IDTokenLifetime),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenIdleExpiration, e.RefreshTokenIdleExpiration),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenExpiration, e.RefreshTokenExpiration),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, e.RefreshTokenRotation),
 			},
 			crdb.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -473,7 +530,7 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-GNHU1", "reduce.wrong.event.type %s", project.OIDCConfigChangedType)
 	}
 
-	cols := make([]handler.Column, 0, 15)
+	cols := make([]handler.Column, 0, 24)
 	if e.Version != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnVersion, *e.Version))
 	}
@@ -516,6 +573,33 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.AdditionalOrigins != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(*e.AdditionalOrigins)))
 	}
+	if e.RequirePushedAuthorizationRequests != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePushedAuthorizationRequests, *e.RequirePushedAuthorizationRequests))
+	}
+	if e.JWKS != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnJWKS, *e.JWKS))
+	}
+	if e.BackChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, *e.BackChannelLogoutURI))
+	}
+	if e.FrontChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, *e.FrontChannelLogoutURI))
+	}
+	if e.AccessTokenLifetime != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnAccessTokenLifetime, *e.AccessTokenLifetime))
+	}
+	if e.IDTokenLifetime != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnIDTokenLifetime, *e.IDTokenLifetime))
+	}
+	if e.RefreshTokenIdleExpiration != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenIdleExpiration, *e.RefreshTokenIdleExpiration))
+	}
+	if e.RefreshTokenExpiration != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenExpiration, *e.RefreshTokenExpiration))
+	}
+	if e.RefreshTokenRotation != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, *e.RefreshTokenRotation))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
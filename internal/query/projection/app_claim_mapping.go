@@ -0,0 +1,158 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	AppClaimMappingTable = "projections.app_claim_mappings"
+
+	AppClaimMappingColumnAppID         = "app_id"
+	AppClaimMappingColumnInstanceID    = "instance_id"
+	AppClaimMappingColumnProjectID     = "project_id"
+	AppClaimMappingColumnResourceOwner = "resource_owner"
+	AppClaimMappingColumnCreationDate  = "creation_date"
+	AppClaimMappingColumnChangeDate    = "change_date"
+	AppClaimMappingColumnSequence      = "sequence"
+	AppClaimMappingColumnClaim         = "claim"
+	AppClaimMappingColumnSource        = "source"
+	AppClaimMappingColumnSourceKey     = "source_key"
+	AppClaimMappingColumnType          = "type"
+	AppClaimMappingColumnIDToken       = "id_token"
+	AppClaimMappingColumnAccessToken   = "access_token"
+	AppClaimMappingColumnUserinfo      = "userinfo"
+)
+
+type appClaimMappingProjection struct {
+	crdb.StatementHandler
+}
+
+func newAppClaimMappingProjection(ctx context.Context, config crdb.StatementHandlerConfig) *appClaimMappingProjection {
+	p := new(appClaimMappingProjection)
+	config.ProjectionName = AppClaimMappingTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(AppClaimMappingColumnAppID, crdb.ColumnTypeText),
+			crdb.NewColumn(AppClaimMappingColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(AppClaimMappingColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(AppClaimMappingColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(AppClaimMappingColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AppClaimMappingColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AppClaimMappingColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(AppClaimMappingColumnClaim, crdb.ColumnTypeText),
+			crdb.NewColumn(AppClaimMappingColumnSource, crdb.ColumnTypeEnum),
+			crdb.NewColumn(AppClaimMappingColumnSourceKey, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(AppClaimMappingColumnType, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(AppClaimMappingColumnIDToken, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppClaimMappingColumnAccessToken, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppClaimMappingColumnUserinfo, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(AppClaimMappingColumnInstanceID, AppClaimMappingColumnAppID, AppClaimMappingColumnClaim),
+			crdb.WithIndex(crdb.NewIndex("app_claim_mappings_project_id_idx", []string{AppClaimMappingColumnProjectID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *appClaimMappingProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ApplicationClaimMappingsSetType,
+					Reduce: p.reduceClaimMappingsSet,
+				},
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceAppRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(AppClaimMappingColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *appClaimMappingProjection) reduceClaimMappingsSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationClaimMappingsSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cm4lq", "reduce.wrong.event.type %s", project.ApplicationClaimMappingsSetType)
+	}
+	stmts := make([]func(reader eventstore.Event) crdb.Exec, len(e.ClaimMappings)+1)
+	stmts[0] = crdb.AddDeleteStatement(
+		[]handler.Condition{
+			handler.NewCond(AppClaimMappingColumnAppID, e.AppID),
+			handler.NewCond(AppClaimMappingColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	)
+	for i, mapping := range e.ClaimMappings {
+		stmts[i+1] = crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(AppClaimMappingColumnAppID, e.AppID),
+				handler.NewCol(AppClaimMappingColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(AppClaimMappingColumnProjectID, e.Aggregate().ID),
+				handler.NewCol(AppClaimMappingColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(AppClaimMappingColumnCreationDate, e.CreationDate()),
+				handler.NewCol(AppClaimMappingColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppClaimMappingColumnSequence, e.Sequence()),
+				handler.NewCol(AppClaimMappingColumnClaim, mapping.Claim),
+				handler.NewCol(AppClaimMappingColumnSource, mapping.Source),
+				handler.NewCol(AppClaimMappingColumnSourceKey, mapping.SourceKey),
+				handler.NewCol(AppClaimMappingColumnType, mapping.Type),
+				handler.NewCol(AppClaimMappingColumnIDToken, mapping.IDToken),
+				handler.NewCol(AppClaimMappingColumnAccessToken, mapping.AccessToken),
+				handler.NewCol(AppClaimMappingColumnUserinfo, mapping.Userinfo),
+			},
+		)
+	}
+	return crdb.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *appClaimMappingProjection) reduceAppRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cm8sn", "reduce.wrong.event.type %s", project.ApplicationRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppClaimMappingColumnAppID, e.AppID),
+			handler.NewCond(AppClaimMappingColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *appClaimMappingProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cm2ox", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppClaimMappingColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(AppClaimMappingColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
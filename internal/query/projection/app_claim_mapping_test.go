@@ -0,0 +1,184 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestAppClaimMappingProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "project reduceClaimMappingsSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationClaimMappingsSetType),
+					project.AggregateType,
+					[]byte(`{"appId": "app-id", "claimMappings": [{"source": 2, "sourceKey": "department", "claim": "department", "type": 1, "idToken": true}, {"source": 4, "claim": "roles", "accessToken": true, "userinfo": true}]}`),
+				), project.ApplicationClaimMappingsSetEventMapper),
+			},
+			reduce: (&appClaimMappingProjection{}).reduceClaimMappingsSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_claim_mappings WHERE (app_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.app_claim_mappings (app_id, instance_id, project_id, resource_owner, creation_date, change_date, sequence, claim, source, source_key, type, id_token, access_token, userinfo) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"department",
+								domain.ClaimMappingSourceMetadata,
+								"department",
+								domain.ClaimMappingTypeString,
+								true,
+								false,
+								false,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.app_claim_mappings (app_id, instance_id, project_id, resource_owner, creation_date, change_date, sequence, claim, source, source_key, type, id_token, access_token, userinfo) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"roles",
+								domain.ClaimMappingSourceRoles,
+								"",
+								domain.ClaimMappingTypeUnspecified,
+								false,
+								true,
+								true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceAppRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationRemovedType),
+					project.AggregateType,
+					[]byte(`{"appId": "app-id"}`),
+				), project.ApplicationRemovedEventMapper),
+			},
+			reduce: (&appClaimMappingProjection{}).reduceAppRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_claim_mappings WHERE (app_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&appClaimMappingProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_claim_mappings WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(AppClaimMappingColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_claim_mappings WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, AppClaimMappingTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	CaptchaPolicyTable = "projections.captcha_policies"
+
+	CaptchaPolicyIDCol                     = "id"
+	CaptchaPolicyCreationDateCol           = "creation_date"
+	CaptchaPolicyChangeDateCol             = "change_date"
+	CaptchaPolicySequenceCol               = "sequence"
+	CaptchaPolicyStateCol                  = "state"
+	CaptchaPolicyIsDefaultCol              = "is_default"
+	CaptchaPolicyResourceOwnerCol          = "resource_owner"
+	CaptchaPolicyInstanceIDCol             = "instance_id"
+	CaptchaPolicyProviderTypeCol           = "provider_type"
+	CaptchaPolicyVerifyURLCol              = "verify_url"
+	CaptchaPolicySiteKeyCol                = "site_key"
+	CaptchaPolicySecretCol                 = "secret"
+	CaptchaPolicyRequireOnRegistrationCol  = "require_on_registration"
+	CaptchaPolicyRequireOnPasswordResetCol = "require_on_password_reset"
+	CaptchaPolicyFailedLoginsThresholdCol  = "failed_logins_threshold"
+)
+
+type captchaPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newCaptchaPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *captchaPolicyProjection {
+	p := new(captchaPolicyProjection)
+	config.ProjectionName = CaptchaPolicyTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(CaptchaPolicyIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CaptchaPolicyCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CaptchaPolicyChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(CaptchaPolicySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(CaptchaPolicyStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(CaptchaPolicyIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(CaptchaPolicyResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CaptchaPolicyInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CaptchaPolicyProviderTypeCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(CaptchaPolicyVerifyURLCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CaptchaPolicySiteKeyCol, crdb.ColumnTypeText),
+			crdb.NewColumn(CaptchaPolicySecretCol, crdb.ColumnTypeJSONB),
+			crdb.NewColumn(CaptchaPolicyRequireOnRegistrationCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(CaptchaPolicyRequireOnPasswordResetCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(CaptchaPolicyFailedLoginsThresholdCol, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(CaptchaPolicyInstanceIDCol, CaptchaPolicyIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *captchaPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.CaptchaPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.CaptchaPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.CaptchaPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.CaptchaPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.CaptchaPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(CaptchaPolicyInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *captchaPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.CaptchaPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.CaptchaPolicyAddedEvent:
+		policyEvent = e.CaptchaPolicyAddedEvent
+		isDefault = false
+	case *instance.CaptchaPolicyAddedEvent:
+		policyEvent = e.CaptchaPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Cp2lA", "reduce.wrong.event.type, %v", []eventstore.EventType{org.CaptchaPolicyAddedEventType, instance.CaptchaPolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(CaptchaPolicyCreationDateCol, policyEvent.CreationDate()),
+			handler.NewCol(CaptchaPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(CaptchaPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(CaptchaPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(CaptchaPolicyStateCol, domain.PolicyStateActive),
+			handler.NewCol(CaptchaPolicyProviderTypeCol, policyEvent.ProviderType),
+			handler.NewCol(CaptchaPolicyVerifyURLCol, policyEvent.VerifyURL),
+			handler.NewCol(CaptchaPolicySiteKeyCol, policyEvent.SiteKey),
+			handler.NewCol(CaptchaPolicySecretCol, policyEvent.Secret),
+			handler.NewCol(CaptchaPolicyRequireOnRegistrationCol, policyEvent.RequireOnRegistration),
+			handler.NewCol(CaptchaPolicyRequireOnPasswordResetCol, policyEvent.RequireOnPasswordReset),
+			handler.NewCol(CaptchaPolicyFailedLoginsThresholdCol, policyEvent.FailedLoginsThreshold),
+			handler.NewCol(CaptchaPolicyIsDefaultCol, isDefault),
+			handler.NewCol(CaptchaPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(CaptchaPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *captchaPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.CaptchaPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.CaptchaPolicyChangedEvent:
+		policyEvent = e.CaptchaPolicyChangedEvent
+	case *instance.CaptchaPolicyChangedEvent:
+		policyEvent = e.CaptchaPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Cp3mB", "reduce.wrong.event.type, %v", []eventstore.EventType{org.CaptchaPolicyChangedEventType, instance.CaptchaPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(CaptchaPolicyChangeDateCol, policyEvent.CreationDate()),
+		handler.NewCol(CaptchaPolicySequenceCol, policyEvent.Sequence()),
+	}
+	if policyEvent.ProviderType != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicyProviderTypeCol, *policyEvent.ProviderType))
+	}
+	if policyEvent.VerifyURL != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicyVerifyURLCol, *policyEvent.VerifyURL))
+	}
+	if policyEvent.SiteKey != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicySiteKeyCol, *policyEvent.SiteKey))
+	}
+	if policyEvent.Secret != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicySecretCol, policyEvent.Secret))
+	}
+	if policyEvent.RequireOnRegistration != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicyRequireOnRegistrationCol, *policyEvent.RequireOnRegistration))
+	}
+	if policyEvent.RequireOnPasswordReset != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicyRequireOnPasswordResetCol, *policyEvent.RequireOnPasswordReset))
+	}
+	if policyEvent.FailedLoginsThreshold != nil {
+		cols = append(cols, handler.NewCol(CaptchaPolicyFailedLoginsThresholdCol, *policyEvent.FailedLoginsThreshold))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(CaptchaPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(CaptchaPolicyInstanceIDCol, event.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *captchaPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.CaptchaPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Cp4nC", "reduce.wrong.event.type %s", org.CaptchaPolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(CaptchaPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(CaptchaPolicyInstanceIDCol, event.Aggregate().InstanceID),
+		}), nil
+}
@@ -0,0 +1,432 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	GroupProjectionTable = "projections.groups"
+	GroupMemberTable     = GroupProjectionTable + "_" + GroupMemberSuffix
+	GroupGrantTable      = GroupProjectionTable + "_" + GroupGrantSuffix
+
+	GroupColumnID            = "id"
+	GroupColumnCreationDate  = "creation_date"
+	GroupColumnChangeDate    = "change_date"
+	GroupColumnSequence      = "sequence"
+	GroupColumnState         = "state"
+	GroupColumnResourceOwner = "resource_owner"
+	GroupColumnInstanceID    = "instance_id"
+	GroupColumnName          = "name"
+	GroupColumnDescription   = "description"
+
+	GroupMemberSuffix              = "members"
+	GroupMemberColumnGroupID       = "group_id"
+	GroupMemberColumnUserID        = "user_id"
+	GroupMemberColumnInstanceID    = "instance_id"
+	GroupMemberColumnResourceOwner = "resource_owner"
+	GroupMemberColumnCreationDate  = "creation_date"
+	GroupMemberColumnChangeDate    = "change_date"
+	GroupMemberColumnSequence      = "sequence"
+
+	GroupGrantSuffix              = "grants"
+	GroupGrantColumnID            = "id"
+	GroupGrantColumnGroupID       = "group_id"
+	GroupGrantColumnInstanceID    = "instance_id"
+	GroupGrantColumnResourceOwner = "resource_owner"
+	GroupGrantColumnCreationDate  = "creation_date"
+	GroupGrantColumnChangeDate    = "change_date"
+	GroupGrantColumnSequence      = "sequence"
+	GroupGrantColumnProjectID     = "project_id"
+	GroupGrantColumnGrantID       = "grant_id"
+	GroupGrantColumnRoleKeys      = "role_keys"
+)
+
+type groupProjection struct {
+	crdb.StatementHandler
+}
+
+func newGroupProjection(ctx context.Context, config crdb.StatementHandlerConfig) *groupProjection {
+	p := new(groupProjection)
+	config.ProjectionName = GroupProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(GroupColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(GroupColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnName, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnDescription, crdb.ColumnTypeText, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(GroupColumnInstanceID, GroupColumnID),
+			crdb.WithIndex(crdb.NewIndex("group_ro_idx", []string{GroupColumnResourceOwner})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupMemberColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMemberColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMemberColumnSequence, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(GroupMemberColumnInstanceID, GroupMemberColumnGroupID, GroupMemberColumnUserID),
+			GroupMemberSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("fk_member_ref_group", []string{GroupMemberColumnInstanceID, GroupMemberColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("group_member_user_idx", []string{GroupMemberColumnUserID})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupGrantColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupGrantColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnGrantID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnRoleKeys, crdb.ColumnTypeTextArray, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(GroupGrantColumnInstanceID, GroupGrantColumnID),
+			GroupGrantSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("fk_grant_ref_group", []string{GroupGrantColumnInstanceID, GroupGrantColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("group_grant_group_idx", []string{GroupGrantColumnGroupID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *groupProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  group.AddedType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  group.ChangedType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  group.RemovedType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  group.MemberAddedType,
+					Reduce: p.reduceMemberAdded,
+				},
+				{
+					Event:  group.MemberRemovedType,
+					Reduce: p.reduceMemberRemoved,
+				},
+				{
+					Event:  group.GrantAddedType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  group.GrantChangedType,
+					Reduce: p.reduceGrantChanged,
+				},
+				{
+					Event:  group.GrantRemovedType,
+					Reduce: p.reduceGrantRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+				{
+					Event:  project.GrantRemovedType,
+					Reduce: p.reduceProjectGrantRemoved,
+				},
+				{
+					Event:  project.RoleRemovedType,
+					Reduce: p.reduceRoleRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOrgRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(GroupColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *groupProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0a1", "reduce.wrong.event.type %s", group.AddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupColumnID, e.Aggregate().ID),
+			handler.NewCol(GroupColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupColumnSequence, e.Sequence()),
+			handler.NewCol(GroupColumnState, domain.GroupStateActive),
+			handler.NewCol(GroupColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupColumnName, e.Name),
+			handler.NewCol(GroupColumnDescription, e.Description),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0c2", "reduce.wrong.event.type %s", group.ChangedType)
+	}
+	if e.Name == nil && e.Description == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	cols := []handler.Column{
+		handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+		handler.NewCol(GroupColumnSequence, e.Sequence()),
+	}
+	if e.Name != nil {
+		cols = append(cols, handler.NewCol(GroupColumnName, *e.Name))
+	}
+	if e.Description != nil {
+		cols = append(cols, handler.NewCol(GroupColumnDescription, *e.Description))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0r3", "reduce.wrong.event.type %s", group.RemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceMemberAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0m4", "reduce.wrong.event.type %s", group.MemberAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupMemberColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupMemberColumnUserID, e.UserID),
+			handler.NewCol(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupMemberColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupMemberColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupMemberColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupMemberColumnSequence, e.Sequence()),
+		},
+		crdb.WithTableSuffix(GroupMemberSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceMemberRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0m5", "reduce.wrong.event.type %s", group.MemberRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberColumnGroupID, e.Aggregate().ID),
+			handler.NewCond(GroupMemberColumnUserID, e.UserID),
+			handler.NewCond(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupMemberSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0g6", "reduce.wrong.event.type %s", group.GrantAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnID, e.GrantID),
+			handler.NewCol(GroupGrantColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupGrantColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupGrantColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnProjectID, e.ProjectID),
+			handler.NewCol(GroupGrantColumnGrantID, e.ProjectGrantID),
+			handler.NewCol(GroupGrantColumnRoleKeys, database.StringArray(e.RoleKeys)),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0g7", "reduce.wrong.event.type %s", group.GrantChangedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnRoleKeys, database.StringArray(e.RoleKeys)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0g8", "reduce.wrong.event.type %s", group.GrantRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*user.UserRemovedEvent); !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr0u9", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberColumnUserID, event.Aggregate().ID),
+			handler.NewCond(GroupMemberColumnInstanceID, event.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupMemberSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*project.ProjectRemovedEvent); !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr1p0", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectID, event.Aggregate().ID),
+			handler.NewCond(GroupGrantColumnInstanceID, event.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr1p1", "reduce.wrong.event.type %s", project.GrantRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnGrantID, e.GrantID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceRoleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.RoleRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr1p2", "reduce.wrong.event.type %s", project.RoleRemovedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			crdb.NewArrayRemoveCol(GroupGrantColumnRoleKeys, e.Key),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gr1o3", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnResourceOwner, e.Aggregate().ID),
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,448 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestGroupProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.AddedType),
+					group.AggregateType,
+					[]byte(`{"name": "name", "description": "description"}`),
+				), group.AddedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups (id, creation_date, change_date, sequence, state, resource_owner, instance_id, name, description) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.GroupStateActive,
+								"ro-id",
+								"instance-id",
+								"name",
+								"description",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.ChangedType),
+					group.AggregateType,
+					[]byte(`{"name": "new name"}`),
+				), group.ChangedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceChanged,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence, name) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"new name",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.RemovedType),
+					group.AggregateType,
+					nil,
+				), group.RemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.MemberAddedType),
+					group.AggregateType,
+					[]byte(`{"userId": "user-id"}`),
+				), group.MemberAddedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceMemberAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_members (group_id, user_id, instance_id, resource_owner, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"user-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.MemberRemovedType),
+					group.AggregateType,
+					[]byte(`{"userId": "user-id"}`),
+				), group.MemberRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceMemberRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (group_id = $1) AND (user_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"user-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantAddedType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id", "projectId": "project-id", "roleKeys": ["role"]}`),
+				), group.GrantAddedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceGrantAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_grants (id, group_id, instance_id, resource_owner, creation_date, change_date, sequence, project_id, grant_id, role_keys) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"project-id",
+								"",
+								database.StringArray{"role"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantChangedType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id", "roleKeys": ["role", "role2"]}`),
+				), group.GrantChangedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceGrantChanged,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET (change_date, sequence, role_keys) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								database.StringArray{"role", "role2"},
+								"grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantRemovedType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id"}`),
+				), group.GrantRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceGrantRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectGrantRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.GrantRemovedType),
+					project.AggregateType,
+					[]byte(`{"grantId": "grant-id"}`),
+				), project.GrantRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceProjectGrantRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (grant_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceRoleRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.RoleRemovedType),
+					project.AggregateType,
+					[]byte(`{"key": "role"}`),
+				), project.RoleRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceRoleRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET role_keys = array_remove(role_keys, $1) WHERE (project_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"role",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOrgRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceOrgRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (resource_owner = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(GroupColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    instance.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, GroupProjectionTable, tt.want)
+		})
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	LoginPolicyTable = "projections.login_policies3"
+	LoginPolicyTable = "projections.login_policies4"
 
 	LoginPolicyIDCol                    = "aggregate_id"
 	LoginPolicyInstanceIDCol            = "instance_id"
@@ -33,6 +33,7 @@ const (
 	AllowDomainDiscovery                = "allow_domain_discovery"
 	DisableLoginWithEmail               = "disable_login_with_email"
 	DisableLoginWithPhone               = "disable_login_with_phone"
+	AllowMagicLink                      = "allow_magic_link"
 	DefaultRedirectURI                  = "default_redirect_uri"
 	PasswordCheckLifetimeCol            = "password_check_lifetime"
 	ExternalLoginCheckLifetimeCol       = "external_login_check_lifetime"
@@ -69,6 +70,7 @@ func newLoginPolicyProjection(ctx context.Context, config crdb.StatementHandlerC
 			crdb.NewColumn(AllowDomainDiscovery, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithEmail, crdb.ColumnTypeBool),
 			crdb.NewColumn(DisableLoginWithPhone, crdb.ColumnTypeBool),
+			crdb.NewColumn(AllowMagicLink, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(DefaultRedirectURI, crdb.ColumnTypeText, crdb.Nullable()),
 			crdb.NewColumn(PasswordCheckLifetimeCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(ExternalLoginCheckLifetimeCol, crdb.ColumnTypeInt64),
@@ -185,6 +187,7 @@ func (p *loginPolicyProjection) reduceLoginPolicyAdded(event eventstore.Event) (
 		handler.NewCol(AllowDomainDiscovery, policyEvent.AllowDomainDiscovery),
 		handler.NewCol(DisableLoginWithEmail, policyEvent.DisableLoginWithEmail),
 		handler.NewCol(DisableLoginWithPhone, policyEvent.DisableLoginWithPhone),
+		handler.NewCol(AllowMagicLink, policyEvent.AllowMagicLink),
 		handler.NewCol(DefaultRedirectURI, policyEvent.DefaultRedirectURI),
 		handler.NewCol(PasswordCheckLifetimeCol, policyEvent.PasswordCheckLifetime),
 		handler.NewCol(ExternalLoginCheckLifetimeCol, policyEvent.ExternalLoginCheckLifetime),
@@ -239,6 +242,9 @@ func (p *loginPolicyProjection) reduceLoginPolicyChanged(event eventstore.Event)
 	if policyEvent.DisableLoginWithPhone != nil {
 		cols = append(cols, handler.NewCol(DisableLoginWithPhone, *policyEvent.DisableLoginWithPhone))
 	}
+	if policyEvent.AllowMagicLink != nil {
+		cols = append(cols, handler.NewCol(AllowMagicLink, *policyEvent.AllowMagicLink))
+	}
 	if policyEvent.DefaultRedirectURI != nil {
 		cols = append(cols, handler.NewCol(DefaultRedirectURI, *policyEvent.DefaultRedirectURI))
 	}
@@ -17,6 +17,9 @@ const (
 	MemberSequence      = "sequence"
 	MemberResourceOwner = "resource_owner"
 	MemberInstanceID    = "instance_id"
+
+	MemberValidFrom  = "valid_from"
+	MemberValidUntil = "valid_until"
 )
 
 var (
@@ -29,6 +32,11 @@ var (
 		crdb.NewColumn(MemberResourceOwner, crdb.ColumnTypeText),
 		crdb.NewColumn(MemberInstanceID, crdb.ColumnTypeText),
 	}
+	// memberValidityColumns are only added to the members which can be time-bound (org and project)
+	memberValidityColumns = []*crdb.Column{
+		crdb.NewColumn(MemberValidFrom, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+		crdb.NewColumn(MemberValidUntil, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+	}
 )
 
 type reduceMemberConfig struct {
@@ -90,6 +98,26 @@ func reduceMemberChanged(e member.MemberChangedEvent, opts ...reduceMemberOpt) (
 	return crdb.NewUpdateStatement(&e, config.cols, config.conds), nil
 }
 
+func reduceMemberValiditySet(e member.MemberValiditySetEvent, opts ...reduceMemberOpt) (*handler.Statement, error) {
+	config := reduceMemberConfig{
+		cols: []handler.Column{
+			handler.NewCol(MemberValidFrom, nullTime(e.ValidFrom)),
+			handler.NewCol(MemberValidUntil, nullTime(e.ValidUntil)),
+			handler.NewCol(MemberChangeDate, e.CreationDate()),
+			handler.NewCol(MemberSequence, e.Sequence()),
+		},
+		conds: []handler.Condition{
+			handler.NewCond(MemberInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(MemberUserIDCol, e.UserID),
+		}}
+
+	for _, opt := range opts {
+		config = opt(config)
+	}
+
+	return crdb.NewUpdateStatement(&e, config.cols, config.conds), nil
+}
+
 func reduceMemberCascadeRemoved(e member.MemberCascadeRemovedEvent, opts ...reduceMemberOpt) (*handler.Statement, error) {
 	config := reduceMemberConfig{
 		conds: []handler.Condition{
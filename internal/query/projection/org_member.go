@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	OrgMemberProjectionTable = "projections.org_members2"
+	OrgMemberProjectionTable = "projections.org_members3"
 	OrgMemberOrgIDCol        = "org_id"
 )
 
@@ -27,7 +27,7 @@ func newOrgMemberProjection(ctx context.Context, config crdb.StatementHandlerCon
 	config.Reducers = p.reducers()
 	config.InitCheck = crdb.NewTableCheck(
 		crdb.NewTable(
-			append(memberColumns, crdb.NewColumn(OrgMemberOrgIDCol, crdb.ColumnTypeText)),
+			append(append(memberColumns, crdb.NewColumn(OrgMemberOrgIDCol, crdb.ColumnTypeText)), memberValidityColumns...),
 			crdb.NewPrimaryKey(MemberInstanceID, OrgMemberOrgIDCol, MemberUserIDCol),
 			crdb.WithIndex(crdb.NewIndex("org_memb_user_idx", []string{MemberUserIDCol})),
 		),
@@ -49,6 +49,10 @@ func (p *orgMemberProjection) reducers() []handler.AggregateReducer {
 					Event:  org.MemberChangedEventType,
 					Reduce: p.reduceChanged,
 				},
+				{
+					Event:  org.MemberValiditySetEventType,
+					Reduce: p.reduceValiditySet,
+				},
 				{
 					Event:  org.MemberCascadeRemovedEventType,
 					Reduce: p.reduceCascadeRemoved,
@@ -100,6 +104,14 @@ func (p *orgMemberProjection) reduceChanged(event eventstore.Event) (*handler.St
 	return reduceMemberChanged(e.MemberChangedEvent, withMemberCond(OrgMemberOrgIDCol, e.Aggregate().ID))
 }
 
+func (p *orgMemberProjection) reduceValiditySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberValiditySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vm2qo", "reduce.wrong.event.type %s", org.MemberValiditySetEventType)
+	}
+	return reduceMemberValiditySet(e.MemberValiditySetEvent, withMemberCond(OrgMemberOrgIDCol, e.Aggregate().ID))
+}
+
 func (p *orgMemberProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*org.MemberCascadeRemovedEvent)
 	if !ok {
@@ -2,6 +2,7 @@ package projection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -43,7 +44,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.org_members2 (user_id, roles, creation_date, change_date, sequence, resource_owner, instance_id, org_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedStmt: "INSERT INTO projections.org_members3 (user_id, roles, creation_date, change_date, sequence, resource_owner, instance_id, org_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"user-id",
 								database.StringArray{"role"},
@@ -79,7 +80,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.org_members2 SET (roles, change_date, sequence) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5) AND (org_id = $6)",
+							expectedStmt: "UPDATE projections.org_members3 SET (roles, change_date, sequence) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5) AND (org_id = $6)",
 							expectedArgs: []interface{}{
 								database.StringArray{"role", "changed"},
 								anyArg{},
@@ -93,6 +94,41 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "org MemberValiditySetType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MemberValiditySetEventType),
+					org.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"validUntil": "2022-06-01T12:00:00Z"
+				}`),
+				), org.MemberValiditySetEventMapper),
+			},
+			reduce: (&orgMemberProjection{}).reduceValiditySet,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.org_members3 SET (valid_from, valid_until, change_date, sequence) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (user_id = $6) AND (org_id = $7)",
+							expectedArgs: []interface{}{
+								nil,
+								time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC),
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								"user-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "org MemberCascadeRemovedType",
 			args: args{
@@ -112,7 +148,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.org_members2 WHERE (instance_id = $1) AND (user_id = $2) AND (org_id = $3)",
+							expectedStmt: "DELETE FROM projections.org_members3 WHERE (instance_id = $1) AND (user_id = $2) AND (org_id = $3)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"user-id",
@@ -142,7 +178,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.org_members2 WHERE (instance_id = $1) AND (user_id = $2) AND (org_id = $3)",
+							expectedStmt: "DELETE FROM projections.org_members3 WHERE (instance_id = $1) AND (user_id = $2) AND (org_id = $3)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"user-id",
@@ -170,7 +206,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.org_members2 WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedStmt: "DELETE FROM projections.org_members3 WHERE (instance_id = $1) AND (user_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -197,7 +233,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.org_members2 WHERE (instance_id = $1) AND (org_id = $2)",
+							expectedStmt: "DELETE FROM projections.org_members3 WHERE (instance_id = $1) AND (org_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -224,7 +260,7 @@ func TestOrgMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.org_members2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.org_members3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
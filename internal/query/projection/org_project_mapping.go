@@ -0,0 +1,147 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	OrgProjectMappingProjectionTable = "projections.org_project_mappings"
+
+	OrgProjectMappingColumnOrgID          = "org_id"
+	OrgProjectMappingColumnProjectID      = "project_id"
+	OrgProjectMappingColumnProjectGrantID = "project_grant_id"
+	OrgProjectMappingColumnInstanceID     = "instance_id"
+	OrgProjectMappingColumnCreationDate   = "creation_date"
+	OrgProjectMappingColumnSequence       = "sequence"
+)
+
+type orgProjectMappingProjection struct {
+	crdb.StatementHandler
+}
+
+func newOrgProjectMappingProjection(ctx context.Context, config crdb.StatementHandlerConfig) *orgProjectMappingProjection {
+	p := new(orgProjectMappingProjection)
+	config.ProjectionName = OrgProjectMappingProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(OrgProjectMappingColumnOrgID, crdb.ColumnTypeText),
+			crdb.NewColumn(OrgProjectMappingColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(OrgProjectMappingColumnProjectGrantID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(OrgProjectMappingColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(OrgProjectMappingColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OrgProjectMappingColumnSequence, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(OrgProjectMappingColumnInstanceID, OrgProjectMappingColumnOrgID, OrgProjectMappingColumnProjectID),
+			crdb.WithIndex(crdb.NewIndex("org_project_mapping_project_idx", []string{OrgProjectMappingColumnProjectID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *orgProjectMappingProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ProjectAddedType,
+					Reduce: p.reduceProjectAdded,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+				{
+					Event:  project.GrantAddedType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  project.GrantRemovedType,
+					Reduce: p.reduceGrantRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OrgProjectMappingColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *orgProjectMappingProjection) reduceProjectAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ak3wz", "reduce.wrong.event.type %s", project.ProjectAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgProjectMappingColumnOrgID, e.Aggregate().ResourceOwner),
+			handler.NewCol(OrgProjectMappingColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(OrgProjectMappingColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(OrgProjectMappingColumnCreationDate, e.CreationDate()),
+			handler.NewCol(OrgProjectMappingColumnSequence, e.Sequence()),
+		},
+	), nil
+}
+
+func (p *orgProjectMappingProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Xb8vn", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgProjectMappingColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(OrgProjectMappingColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *orgProjectMappingProjection) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rw5gm", "reduce.wrong.event.type %s", project.GrantAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgProjectMappingColumnOrgID, e.GrantedOrgID),
+			handler.NewCol(OrgProjectMappingColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(OrgProjectMappingColumnProjectGrantID, e.GrantID),
+			handler.NewCol(OrgProjectMappingColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(OrgProjectMappingColumnCreationDate, e.CreationDate()),
+			handler.NewCol(OrgProjectMappingColumnSequence, e.Sequence()),
+		},
+	), nil
+}
+
+func (p *orgProjectMappingProjection) reduceGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Jq1hd", "reduce.wrong.event.type %s", project.GrantRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgProjectMappingColumnProjectGrantID, e.GrantID),
+			handler.NewCond(OrgProjectMappingColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,179 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestOrgProjectMappingProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceProjectAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectAddedType),
+					project.AggregateType,
+					[]byte(`{"name": "project"}`),
+				), project.ProjectAddedEventMapper),
+			},
+			reduce: (&orgProjectMappingProjection{}).reduceProjectAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_project_mappings (org_id, project_id, instance_id, creation_date, sequence) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"ro-id",
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					[]byte(`{}`),
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&orgProjectMappingProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_project_mappings WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.GrantAddedType),
+					project.AggregateType,
+					[]byte(`{"grantId": "grant-id", "grantedOrgId": "org-id"}`),
+				), project.GrantAddedEventMapper),
+			},
+			reduce: (&orgProjectMappingProjection{}).reduceGrantAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_project_mappings (org_id, project_id, project_grant_id, instance_id, creation_date, sequence) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								"org-id",
+								"agg-id",
+								"grant-id",
+								"instance-id",
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.GrantRemovedType),
+					project.AggregateType,
+					[]byte(`{"grantId": "grant-id"}`),
+				), project.GrantRemovedEventMapper),
+			},
+			reduce: (&orgProjectMappingProjection{}).reduceGrantRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_project_mappings WHERE (project_grant_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(OrgProjectMappingColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_project_mappings WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, OrgProjectMappingProjectionTable, tt.want)
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	PasswordAgeTable = "projections.password_age_policies"
+	PasswordAgeTable = "projections.password_age_policies2"
 
 	AgePolicyIDCol             = "id"
 	AgePolicyCreationDateCol   = "creation_date"
@@ -26,6 +26,7 @@ const (
 	AgePolicyInstanceIDCol     = "instance_id"
 	AgePolicyExpireWarnDaysCol = "expire_warn_days"
 	AgePolicyMaxAgeDaysCol     = "max_age_days"
+	AgePolicyHistoryCountCol   = "history_count"
 )
 
 type passwordAgeProjection struct {
@@ -48,6 +49,7 @@ func newPasswordAgeProjection(ctx context.Context, config crdb.StatementHandlerC
 			crdb.NewColumn(AgePolicyInstanceIDCol, crdb.ColumnTypeText),
 			crdb.NewColumn(AgePolicyExpireWarnDaysCol, crdb.ColumnTypeInt64),
 			crdb.NewColumn(AgePolicyMaxAgeDaysCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(AgePolicyHistoryCountCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(AgePolicyInstanceIDCol, AgePolicyIDCol),
 		),
@@ -118,6 +120,7 @@ func (p *passwordAgeProjection) reduceAdded(event eventstore.Event) (*handler.St
 			handler.NewCol(AgePolicyStateCol, domain.PolicyStateActive),
 			handler.NewCol(AgePolicyExpireWarnDaysCol, policyEvent.ExpireWarnDays),
 			handler.NewCol(AgePolicyMaxAgeDaysCol, policyEvent.MaxAgeDays),
+			handler.NewCol(AgePolicyHistoryCountCol, policyEvent.HistoryCount),
 			handler.NewCol(AgePolicyIsDefaultCol, isDefault),
 			handler.NewCol(AgePolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(AgePolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
@@ -144,6 +147,9 @@ func (p *passwordAgeProjection) reduceChanged(event eventstore.Event) (*handler.
 	if policyEvent.MaxAgeDays != nil {
 		cols = append(cols, handler.NewCol(AgePolicyMaxAgeDaysCol, *policyEvent.MaxAgeDays))
 	}
+	if policyEvent.HistoryCount != nil {
+		cols = append(cols, handler.NewCol(AgePolicyHistoryCountCol, *policyEvent.HistoryCount))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
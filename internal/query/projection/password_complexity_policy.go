@@ -14,21 +14,22 @@ import (
 )
 
 const (
-	PasswordComplexityTable = "projections.password_complexity_policies"
+	PasswordComplexityTable = "projections.password_complexity_policies2"
 
-	ComplexityPolicyIDCol            = "id"
-	ComplexityPolicyCreationDateCol  = "creation_date"
-	ComplexityPolicyChangeDateCol    = "change_date"
-	ComplexityPolicySequenceCol      = "sequence"
-	ComplexityPolicyStateCol         = "state"
-	ComplexityPolicyIsDefaultCol     = "is_default"
-	ComplexityPolicyResourceOwnerCol = "resource_owner"
-	ComplexityPolicyInstanceIDCol    = "instance_id"
-	ComplexityPolicyMinLengthCol     = "min_length"
-	ComplexityPolicyHasLowercaseCol  = "has_lowercase"
-	ComplexityPolicyHasUppercaseCol  = "has_uppercase"
-	ComplexityPolicyHasSymbolCol     = "has_symbol"
-	ComplexityPolicyHasNumberCol     = "has_number"
+	ComplexityPolicyIDCol             = "id"
+	ComplexityPolicyCreationDateCol   = "creation_date"
+	ComplexityPolicyChangeDateCol     = "change_date"
+	ComplexityPolicySequenceCol       = "sequence"
+	ComplexityPolicyStateCol          = "state"
+	ComplexityPolicyIsDefaultCol      = "is_default"
+	ComplexityPolicyResourceOwnerCol  = "resource_owner"
+	ComplexityPolicyInstanceIDCol     = "instance_id"
+	ComplexityPolicyMinLengthCol      = "min_length"
+	ComplexityPolicyHasLowercaseCol   = "has_lowercase"
+	ComplexityPolicyHasUppercaseCol   = "has_uppercase"
+	ComplexityPolicyHasSymbolCol      = "has_symbol"
+	ComplexityPolicyHasNumberCol      = "has_number"
+	ComplexityPolicyRejectBreachedCol = "reject_breached"
 )
 
 type passwordComplexityProjection struct {
@@ -54,6 +55,7 @@ func newPasswordComplexityProjection(ctx context.Context, config crdb.StatementH
 			crdb.NewColumn(ComplexityPolicyHasUppercaseCol, crdb.ColumnTypeBool),
 			crdb.NewColumn(ComplexityPolicyHasSymbolCol, crdb.ColumnTypeBool),
 			crdb.NewColumn(ComplexityPolicyHasNumberCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(ComplexityPolicyRejectBreachedCol, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(ComplexityPolicyInstanceIDCol, ComplexityPolicyIDCol),
 		),
@@ -127,6 +129,7 @@ func (p *passwordComplexityProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(ComplexityPolicyHasUppercaseCol, policyEvent.HasUppercase),
 			handler.NewCol(ComplexityPolicyHasSymbolCol, policyEvent.HasSymbol),
 			handler.NewCol(ComplexityPolicyHasNumberCol, policyEvent.HasNumber),
+			handler.NewCol(ComplexityPolicyRejectBreachedCol, policyEvent.RejectBreached),
 			handler.NewCol(ComplexityPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(ComplexityPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
 			handler.NewCol(ComplexityPolicyIsDefaultCol, isDefault),
@@ -162,6 +165,9 @@ func (p *passwordComplexityProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.HasNumber != nil {
 		cols = append(cols, handler.NewCol(ComplexityPolicyHasNumberCol, *policyEvent.HasNumber))
 	}
+	if policyEvent.RejectBreached != nil {
+		cols = append(cols, handler.NewCol(ComplexityPolicyRejectBreachedCol, *policyEvent.RejectBreached))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	ProjectMemberProjectionTable = "projections.project_members2"
+	ProjectMemberProjectionTable = "projections.project_members3"
 	ProjectMemberProjectIDCol    = "project_id"
 )
 
@@ -29,9 +29,9 @@ func newProjectMemberProjection(ctx context.Context, config crdb.StatementHandle
 	config.Reducers = p.reducers()
 	config.InitCheck = crdb.NewTableCheck(
 		crdb.NewTable(
-			append(memberColumns,
+			append(append(memberColumns,
 				crdb.NewColumn(ProjectMemberProjectIDCol, crdb.ColumnTypeText),
-			),
+			), memberValidityColumns...),
 			crdb.NewPrimaryKey(MemberInstanceID, ProjectMemberProjectIDCol, MemberUserIDCol),
 			crdb.WithIndex(crdb.NewIndex("proj_memb_user_idx", []string{MemberUserIDCol})),
 		),
@@ -54,6 +54,10 @@ func (p *projectMemberProjection) reducers() []handler.AggregateReducer {
 					Event:  project.MemberChangedType,
 					Reduce: p.reduceChanged,
 				},
+				{
+					Event:  project.MemberValiditySetType,
+					Reduce: p.reduceValiditySet,
+				},
 				{
 					Event:  project.MemberCascadeRemovedType,
 					Reduce: p.reduceCascadeRemoved,
@@ -120,6 +124,14 @@ func (p *projectMemberProjection) reduceChanged(event eventstore.Event) (*handle
 	)
 }
 
+func (p *projectMemberProjection) reduceValiditySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.MemberValiditySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tq8ws", "reduce.wrong.event.type %s", project.MemberValiditySetType)
+	}
+	return reduceMemberValiditySet(e.MemberValiditySetEvent, withMemberCond(ProjectMemberProjectIDCol, e.Aggregate().ID))
+}
+
 func (p *projectMemberProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*project.MemberCascadeRemovedEvent)
 	if !ok {
@@ -2,6 +2,7 @@ package projection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -44,7 +45,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.project_members2 (user_id, roles, creation_date, change_date, sequence, resource_owner, instance_id, project_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedStmt: "INSERT INTO projections.project_members3 (user_id, roles, creation_date, change_date, sequence, resource_owner, instance_id, project_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"user-id",
 								database.StringArray{"role"},
@@ -80,7 +81,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_members2 SET (roles, change_date, sequence) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5) AND (project_id = $6)",
+							expectedStmt: "UPDATE projections.project_members3 SET (roles, change_date, sequence) = ($1, $2, $3) WHERE (instance_id = $4) AND (user_id = $5) AND (project_id = $6)",
 							expectedArgs: []interface{}{
 								database.StringArray{"role", "changed"},
 								anyArg{},
@@ -94,6 +95,42 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "project MemberValiditySetType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.MemberValiditySetType),
+					project.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"validFrom": "2022-06-01T12:00:00Z",
+					"validUntil": "2022-07-01T12:00:00Z"
+				}`),
+				), project.MemberValiditySetEventMapper),
+			},
+			reduce: (&projectMemberProjection{}).reduceValiditySet,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_members3 SET (valid_from, valid_until, change_date, sequence) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (user_id = $6) AND (project_id = $7)",
+							expectedArgs: []interface{}{
+								time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC),
+								time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC),
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								"user-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "project MemberCascadeRemovedType",
 			args: args{
@@ -113,7 +150,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1) AND (user_id = $2) AND (project_id = $3)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1) AND (user_id = $2) AND (project_id = $3)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"user-id",
@@ -143,7 +180,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1) AND (user_id = $2) AND (project_id = $3)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1) AND (user_id = $2) AND (project_id = $3)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"user-id",
@@ -171,7 +208,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1) AND (user_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -198,7 +235,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -225,7 +262,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -251,7 +288,7 @@ func TestProjectMemberProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_members2 WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedStmt: "DELETE FROM projections.project_members3 WHERE (instance_id = $1) AND (project_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -14,6 +15,7 @@ const (
 	CurrentSeqTable   = "projections.current_sequences"
 	LocksTable        = "projections.locks"
 	FailedEventsTable = "projections.failed_events"
+	StatesTable       = "projections.projection_states"
 )
 
 var (
@@ -26,6 +28,8 @@ var (
 	PasswordComplexityProjection        *passwordComplexityProjection
 	PasswordAgeProjection               *passwordAgeProjection
 	LockoutPolicyProjection             *lockoutPolicyProjection
+	RiskPolicyProjection                *riskPolicyProjection
+	CaptchaPolicyProjection             *captchaPolicyProjection
 	PrivacyPolicyProjection             *privacyPolicyProjection
 	DomainPolicyProjection              *domainPolicyProjection
 	LabelPolicyProjection               *labelPolicyProjection
@@ -59,12 +63,26 @@ var (
 	OIDCSettingsProjection              *oidcSettingsProjection
 	DebugNotificationProviderProjection *debugNotificationProviderProjection
 	KeyProjection                       *keyProjection
+	TokenProjection                     *tokenProjection
+	RefreshTokenProjection              *refreshTokenProjection
+	UserSessionProjection               *userSessionProjection
+	OrgProjectMappingProjection         *orgProjectMappingProjection
+	CustomRoleProjection                *customRoleProjection
+	RelationTupleProjection             *relationTupleProjection
+	AccessRequestProjection             *accessRequestProjection
+	GroupProjection                     *groupProjection
+	AppClaimMappingProjection           *appClaimMappingProjection
 	NotificationsProjection             interface{}
 )
 
 type projection interface {
 	Start()
 	Init(ctx context.Context) error
+	Name() string
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+	Rebuild(ctx context.Context) error
+	StartRebuild(ctx context.Context) error
 }
 
 var (
@@ -86,6 +104,7 @@ func Create(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, c
 		SequenceTable:     CurrentSeqTable,
 		LockTable:         LocksTable,
 		FailedEventsTable: FailedEventsTable,
+		StateTable:        StatesTable,
 		MaxFailureCount:   config.MaxFailureCount,
 		BulkLimit:         config.BulkLimit,
 	}
@@ -98,6 +117,8 @@ func Create(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, c
 	PasswordComplexityProjection = newPasswordComplexityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_complexities"]))
 	PasswordAgeProjection = newPasswordAgeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_age_policy"]))
 	LockoutPolicyProjection = newLockoutPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["lockout_policy"]))
+	RiskPolicyProjection = newRiskPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["risk_policy"]))
+	CaptchaPolicyProjection = newCaptchaPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["captcha_policy"]))
 	PrivacyPolicyProjection = newPrivacyPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["privacy_policy"]))
 	DomainPolicyProjection = newDomainPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_iam_policy"]))
 	LabelPolicyProjection = newLabelPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["label_policy"]))
@@ -131,6 +152,15 @@ func Create(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, c
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
+	TokenProjection = newTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["tokens"]))
+	RefreshTokenProjection = newRefreshTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["refresh_tokens"]))
+	UserSessionProjection = newUserSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_sessions"]))
+	OrgProjectMappingProjection = newOrgProjectMappingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_project_mappings"]))
+	CustomRoleProjection = newCustomRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_roles"]))
+	RelationTupleProjection = newRelationTupleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["relation_tuples"]))
+	AccessRequestProjection = newAccessRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["access_requests"]))
+	GroupProjection = newGroupProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["groups"]))
+	AppClaimMappingProjection = newAppClaimMappingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["app_claim_mappings"]))
 	newProjectionsList()
 	return nil
 }
@@ -188,6 +218,8 @@ func newProjectionsList() {
 		PasswordComplexityProjection,
 		PasswordAgeProjection,
 		LockoutPolicyProjection,
+		RiskPolicyProjection,
+		CaptchaPolicyProjection,
 		PrivacyPolicyProjection,
 		DomainPolicyProjection,
 		LabelPolicyProjection,
@@ -221,5 +253,22 @@ func newProjectionsList() {
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
 		KeyProjection,
+		TokenProjection,
+		RefreshTokenProjection,
+		UserSessionProjection,
+		OrgProjectMappingProjection,
+		CustomRoleProjection,
+		RelationTupleProjection,
+		AccessRequestProjection,
+		GroupProjection,
+		AppClaimMappingProjection,
 	}
 }
+
+// nullTime stores zero timestamps as NULL
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,190 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	RefreshTokenProjectionTable = "projections.refresh_tokens"
+
+	RefreshTokenColumnID                    = "id"
+	RefreshTokenColumnCreationDate          = "creation_date"
+	RefreshTokenColumnChangeDate            = "change_date"
+	RefreshTokenColumnSequence              = "sequence"
+	RefreshTokenColumnResourceOwner         = "resource_owner"
+	RefreshTokenColumnInstanceID            = "instance_id"
+	RefreshTokenColumnUserID                = "user_id"
+	RefreshTokenColumnClientID              = "client_id"
+	RefreshTokenColumnUserAgentID           = "user_agent_id"
+	RefreshTokenColumnAudience              = "audience"
+	RefreshTokenColumnScopes                = "scopes"
+	RefreshTokenColumnAuthMethodsReferences = "amr"
+	RefreshTokenColumnAuthTime              = "auth_time"
+	RefreshTokenColumnIdleExpiration        = "idle_expiration"
+	RefreshTokenColumnExpiration            = "expiration"
+	RefreshTokenColumnToken                 = "token"
+)
+
+type refreshTokenProjection struct {
+	crdb.StatementHandler
+}
+
+func newRefreshTokenProjection(ctx context.Context, config crdb.StatementHandlerConfig) *refreshTokenProjection {
+	p := new(refreshTokenProjection)
+	config.ProjectionName = RefreshTokenProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RefreshTokenColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RefreshTokenColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(RefreshTokenColumnAudience, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnAuthMethodsReferences, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(RefreshTokenColumnAuthTime, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnIdleExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RefreshTokenColumnToken, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(RefreshTokenColumnInstanceID, RefreshTokenColumnID),
+			crdb.WithIndex(crdb.NewIndex("refresh_token_user_idx", []string{RefreshTokenColumnUserID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *refreshTokenProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanRefreshTokenAddedType,
+					Reduce: p.reduceRefreshTokenAdded,
+				},
+				{
+					Event:  user.HumanRefreshTokenRenewedType,
+					Reduce: p.reduceRefreshTokenRenewed,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserRefreshTokensRemoved,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserRefreshTokensRemoved,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRefreshTokensRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RefreshTokenColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ke7bs", "reduce.wrong.event.type %s", user.HumanRefreshTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenColumnID, e.TokenID),
+			handler.NewCol(RefreshTokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(RefreshTokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(RefreshTokenColumnClientID, e.ClientID),
+			handler.NewCol(RefreshTokenColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(RefreshTokenColumnAudience, database.StringArray(e.Audience)),
+			handler.NewCol(RefreshTokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(RefreshTokenColumnAuthMethodsReferences, database.StringArray(e.AuthMethodsReferences)),
+			handler.NewCol(RefreshTokenColumnAuthTime, e.AuthTime),
+			handler.NewCol(RefreshTokenColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+			handler.NewCol(RefreshTokenColumnExpiration, e.CreationDate().Add(e.Expiration)),
+			handler.NewCol(RefreshTokenColumnToken, e.TokenID),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenRenewed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRenewedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Fn2wq", "reduce.wrong.event.type %s", user.HumanRefreshTokenRenewedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RefreshTokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(RefreshTokenColumnSequence, e.Sequence()),
+			handler.NewCol(RefreshTokenColumnIdleExpiration, e.CreationDate().Add(e.IdleExpiration)),
+			handler.NewCol(RefreshTokenColumnToken, e.RefreshToken),
+		},
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnID, e.TokenID),
+			handler.NewCond(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mx4tu", "reduce.wrong.event.type %s", user.HumanRefreshTokenRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnID, e.TokenID),
+			handler.NewCond(RefreshTokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *refreshTokenProjection) reduceUserRefreshTokensRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent, *user.UserDeactivatedEvent, *user.UserRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wj3ba", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType, user.UserRemovedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(RefreshTokenColumnUserID, event.Aggregate().ID),
+			handler.NewCond(RefreshTokenColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
@@ -0,0 +1,164 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestRefreshTokenProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRefreshTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenAddedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id", "clientId": "client-id", "userAgentId": "agent-id", "audience": ["aud"], "scopes": ["openid"], "authMethodReferences": ["pwd"], "authTime": "2022-01-01T00:00:00Z", "idleExpiration": 3600000000000, "expiration": 7200000000000}`),
+				), user.HumanRefreshTokenAddedEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.refresh_tokens (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, client_id, user_agent_id, audience, scopes, amr, auth_time, idle_expiration, expiration, token) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								"client-id",
+								"agent-id",
+								database.StringArray{"aud"},
+								database.StringArray{"openid"},
+								database.StringArray{"pwd"},
+								anyArg{},
+								anyArg{},
+								anyArg{},
+								"token-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRenewed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRenewedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id", "refreshToken": "new-token", "idleExpiration": 3600000000000}`),
+				), user.HumanRefreshTokenRenewedEventEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenRenewed,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.refresh_tokens SET (change_date, sequence, idle_expiration, token) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"new-token",
+								"token-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRefreshTokenRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanRefreshTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id"}`),
+				), user.HumanRefreshTokenRemovedEventEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceRefreshTokenRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"token-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRefreshTokensRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&refreshTokenProjection{}).reduceUserRefreshTokensRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.refresh_tokens WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, RefreshTokenProjectionTable, tt.want)
+		})
+	}
+}
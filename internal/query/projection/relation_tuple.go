@@ -0,0 +1,155 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	RelationTupleProjectionTable = "projections.relation_tuples"
+
+	RelationTupleColumnProjectID       = "project_id"
+	RelationTupleColumnObjectType      = "object_type"
+	RelationTupleColumnObjectID        = "object_id"
+	RelationTupleColumnRelation        = "relation"
+	RelationTupleColumnSubjectType     = "subject_type"
+	RelationTupleColumnSubjectID       = "subject_id"
+	RelationTupleColumnSubjectRelation = "subject_relation"
+	RelationTupleColumnCreationDate    = "creation_date"
+	RelationTupleColumnSequence        = "sequence"
+	RelationTupleColumnResourceOwner   = "resource_owner"
+	RelationTupleColumnInstanceID      = "instance_id"
+)
+
+type relationTupleProjection struct {
+	crdb.StatementHandler
+}
+
+func newRelationTupleProjection(ctx context.Context, config crdb.StatementHandlerConfig) *relationTupleProjection {
+	p := new(relationTupleProjection)
+	config.ProjectionName = RelationTupleProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RelationTupleColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnObjectType, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnObjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnRelation, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnSubjectType, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnSubjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnSubjectRelation, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(RelationTupleColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RelationTupleColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RelationTupleColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(RelationTupleColumnInstanceID, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(
+				RelationTupleColumnInstanceID,
+				RelationTupleColumnProjectID,
+				RelationTupleColumnObjectType,
+				RelationTupleColumnObjectID,
+				RelationTupleColumnRelation,
+				RelationTupleColumnSubjectType,
+				RelationTupleColumnSubjectID,
+				RelationTupleColumnSubjectRelation,
+			),
+			crdb.WithIndex(crdb.NewIndex("relation_tuples_subject_idx", []string{RelationTupleColumnSubjectType, RelationTupleColumnSubjectID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *relationTupleProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.RelationTupleAddedType,
+					Reduce: p.reduceRelationTupleAdded,
+				},
+				{
+					Event:  project.RelationTupleRemovedType,
+					Reduce: p.reduceRelationTupleRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RelationTupleColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *relationTupleProjection) reduceRelationTupleAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.RelationTupleAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tz5rw", "reduce.wrong.event.type %s", project.RelationTupleAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RelationTupleColumnProjectID, e.Aggregate().ID),
+			handler.NewCol(RelationTupleColumnObjectType, e.ObjectType),
+			handler.NewCol(RelationTupleColumnObjectID, e.ObjectID),
+			handler.NewCol(RelationTupleColumnRelation, e.Relation),
+			handler.NewCol(RelationTupleColumnSubjectType, e.SubjectType),
+			handler.NewCol(RelationTupleColumnSubjectID, e.SubjectID),
+			handler.NewCol(RelationTupleColumnSubjectRelation, e.SubjectRelation),
+			handler.NewCol(RelationTupleColumnCreationDate, e.CreationDate()),
+			handler.NewCol(RelationTupleColumnSequence, e.Sequence()),
+			handler.NewCol(RelationTupleColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(RelationTupleColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *relationTupleProjection) reduceRelationTupleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.RelationTupleRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Mb0ge", "reduce.wrong.event.type %s", project.RelationTupleRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RelationTupleColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(RelationTupleColumnObjectType, e.ObjectType),
+			handler.NewCond(RelationTupleColumnObjectID, e.ObjectID),
+			handler.NewCond(RelationTupleColumnRelation, e.Relation),
+			handler.NewCond(RelationTupleColumnSubjectType, e.SubjectType),
+			handler.NewCond(RelationTupleColumnSubjectID, e.SubjectID),
+			handler.NewCond(RelationTupleColumnSubjectRelation, e.SubjectRelation),
+			handler.NewCond(RelationTupleColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *relationTupleProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Yc3xa", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RelationTupleColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(RelationTupleColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
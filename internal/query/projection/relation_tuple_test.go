@@ -0,0 +1,160 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestRelationTupleProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRelationTupleAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.RelationTupleAddedType),
+					project.AggregateType,
+					[]byte(`{"objectType": "document", "objectId": "readme", "relation": "viewer", "subjectType": "group", "subjectId": "developers", "subjectRelation": "member"}`),
+				), project.RelationTupleAddedEventMapper),
+			},
+			reduce: (&relationTupleProjection{}).reduceRelationTupleAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.relation_tuples (project_id, object_type, object_id, relation, subject_type, subject_id, subject_relation, creation_date, sequence, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"document",
+								"readme",
+								"viewer",
+								"group",
+								"developers",
+								"member",
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRelationTupleRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.RelationTupleRemovedType),
+					project.AggregateType,
+					[]byte(`{"objectType": "document", "objectId": "readme", "relation": "viewer", "subjectType": "user", "subjectId": "user1"}`),
+				), project.RelationTupleRemovedEventMapper),
+			},
+			reduce: (&relationTupleProjection{}).reduceRelationTupleRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.relation_tuples WHERE (project_id = $1) AND (object_type = $2) AND (object_id = $3) AND (relation = $4) AND (subject_type = $5) AND (subject_id = $6) AND (subject_relation = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"document",
+								"readme",
+								"viewer",
+								"user",
+								"user1",
+								"",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&relationTupleProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.relation_tuples WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(RelationTupleColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.relation_tuples WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, RelationTupleProjectionTable, tt.want)
+		})
+	}
+}
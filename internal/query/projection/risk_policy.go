@@ -0,0 +1,179 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	RiskPolicyTable = "projections.risk_policies"
+
+	RiskPolicyIDCol                      = "id"
+	RiskPolicyCreationDateCol            = "creation_date"
+	RiskPolicyChangeDateCol              = "change_date"
+	RiskPolicySequenceCol                = "sequence"
+	RiskPolicyStateCol                   = "state"
+	RiskPolicyIsDefaultCol               = "is_default"
+	RiskPolicyResourceOwnerCol           = "resource_owner"
+	RiskPolicyInstanceIDCol              = "instance_id"
+	RiskPolicyMFAOnNewDeviceCol          = "mfa_on_new_device"
+	RiskPolicyMFAOnNewIPRangeCol         = "mfa_on_new_ip_range"
+	RiskPolicyImpossibleTravelWindowCol  = "impossible_travel_window"
+	RiskPolicyFailedAttemptsThresholdCol = "failed_attempts_threshold"
+)
+
+type riskPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newRiskPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *riskPolicyProjection {
+	p := new(riskPolicyProjection)
+	config.ProjectionName = RiskPolicyTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RiskPolicyIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RiskPolicyCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RiskPolicyChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RiskPolicySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RiskPolicyStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(RiskPolicyIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(RiskPolicyResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RiskPolicyInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RiskPolicyMFAOnNewDeviceCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(RiskPolicyMFAOnNewIPRangeCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(RiskPolicyImpossibleTravelWindowCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RiskPolicyFailedAttemptsThresholdCol, crdb.ColumnTypeInt64),
+		},
+			crdb.NewPrimaryKey(RiskPolicyInstanceIDCol, RiskPolicyIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *riskPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.RiskPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.RiskPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.RiskPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.RiskPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.RiskPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RiskPolicyInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *riskPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.RiskPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.RiskPolicyAddedEvent:
+		policyEvent = e.RiskPolicyAddedEvent
+		isDefault = false
+	case *instance.RiskPolicyAddedEvent:
+		policyEvent = e.RiskPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rk2lA", "reduce.wrong.event.type, %v", []eventstore.EventType{org.RiskPolicyAddedEventType, instance.RiskPolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(RiskPolicyCreationDateCol, policyEvent.CreationDate()),
+			handler.NewCol(RiskPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(RiskPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(RiskPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(RiskPolicyStateCol, domain.PolicyStateActive),
+			handler.NewCol(RiskPolicyMFAOnNewDeviceCol, policyEvent.MFAOnNewDevice),
+			handler.NewCol(RiskPolicyMFAOnNewIPRangeCol, policyEvent.MFAOnNewIPRange),
+			handler.NewCol(RiskPolicyImpossibleTravelWindowCol, policyEvent.ImpossibleTravelWindow),
+			handler.NewCol(RiskPolicyFailedAttemptsThresholdCol, policyEvent.FailedAttemptsThreshold),
+			handler.NewCol(RiskPolicyIsDefaultCol, isDefault),
+			handler.NewCol(RiskPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(RiskPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *riskPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.RiskPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.RiskPolicyChangedEvent:
+		policyEvent = e.RiskPolicyChangedEvent
+	case *instance.RiskPolicyChangedEvent:
+		policyEvent = e.RiskPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rk3mB", "reduce.wrong.event.type, %v", []eventstore.EventType{org.RiskPolicyChangedEventType, instance.RiskPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(RiskPolicyChangeDateCol, policyEvent.CreationDate()),
+		handler.NewCol(RiskPolicySequenceCol, policyEvent.Sequence()),
+	}
+	if policyEvent.MFAOnNewDevice != nil {
+		cols = append(cols, handler.NewCol(RiskPolicyMFAOnNewDeviceCol, *policyEvent.MFAOnNewDevice))
+	}
+	if policyEvent.MFAOnNewIPRange != nil {
+		cols = append(cols, handler.NewCol(RiskPolicyMFAOnNewIPRangeCol, *policyEvent.MFAOnNewIPRange))
+	}
+	if policyEvent.ImpossibleTravelWindow != nil {
+		cols = append(cols, handler.NewCol(RiskPolicyImpossibleTravelWindowCol, *policyEvent.ImpossibleTravelWindow))
+	}
+	if policyEvent.FailedAttemptsThreshold != nil {
+		cols = append(cols, handler.NewCol(RiskPolicyFailedAttemptsThresholdCol, *policyEvent.FailedAttemptsThreshold))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(RiskPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(RiskPolicyInstanceIDCol, event.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *riskPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.RiskPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Rk4nC", "reduce.wrong.event.type %s", org.RiskPolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(RiskPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(RiskPolicyInstanceIDCol, event.Aggregate().InstanceID),
+		}), nil
+}
@@ -0,0 +1,60 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Pause stops the projection from processing events until it's resumed
+func Pause(ctx context.Context, projectionName string) error {
+	p, err := projectionByName(projectionName)
+	if err != nil {
+		return err
+	}
+	return p.Pause(ctx)
+}
+
+// Resume continues the processing of events of a paused projection
+func Resume(ctx context.Context, projectionName string) error {
+	p, err := projectionByName(projectionName)
+	if err != nil {
+		return err
+	}
+	return p.Resume(ctx)
+}
+
+// Rebuild reduces all events into new tables which replace the current tables of the projection
+// as soon as they caught up
+func Rebuild(ctx context.Context, projectionName string) error {
+	p, err := projectionByName(projectionName)
+	if err != nil {
+		return err
+	}
+	return p.Rebuild(ctx)
+}
+
+// StartRebuild claims the rebuild of the projection and runs it in the background
+// it fails if a rebuild of the projection is already running
+func StartRebuild(ctx context.Context, projectionName string) error {
+	p, err := projectionByName(projectionName)
+	if err != nil {
+		return err
+	}
+	return p.StartRebuild(ctx)
+}
+
+// Exists checks if the projection is registered
+func Exists(projectionName string) bool {
+	_, err := projectionByName(projectionName)
+	return err == nil
+}
+
+func projectionByName(projectionName string) (projection, error) {
+	for _, p := range projections {
+		if p.Name() == projectionName {
+			return p, nil
+		}
+	}
+	return nil, errors.ThrowNotFound(nil, "PROJE-Jw9nf", "Errors.ProjectionName.Invalid")
+}
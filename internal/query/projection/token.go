@@ -0,0 +1,453 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	TokenProjectionTable = "projections.tokens2"
+	TokenAppTable        = TokenProjectionTable + "_" + tokenAppTableSuffix
+
+	TokenColumnID                  = "id"
+	TokenColumnCreationDate        = "creation_date"
+	TokenColumnChangeDate          = "change_date"
+	TokenColumnSequence            = "sequence"
+	TokenColumnResourceOwner       = "resource_owner"
+	TokenColumnInstanceID          = "instance_id"
+	TokenColumnUserID              = "user_id"
+	TokenColumnApplicationID       = "application_id"
+	TokenColumnUserAgentID         = "user_agent_id"
+	TokenColumnAudience            = "audience"
+	TokenColumnScopes              = "scopes"
+	TokenColumnExpiration          = "expiration"
+	TokenColumnPreferredLanguage   = "preferred_language"
+	TokenColumnRefreshTokenID      = "refresh_token_id"
+	TokenColumnIsPAT               = "is_pat"
+	TokenColumnConfirmationJKT     = "cnf_jkt"
+	TokenColumnConfirmationX5TS256 = "cnf_x5t_s256"
+
+	tokenAppTableSuffix      = "apps"
+	TokenAppColumnAppID      = "app_id"
+	TokenAppColumnInstanceID = "instance_id"
+	TokenAppColumnProjectID  = "project_id"
+	TokenAppColumnClientID   = "client_id"
+
+	deleteAppTokensStmtFormat = "DELETE FROM %[1]s WHERE " + TokenColumnInstanceID + " = $1" +
+		" AND " + TokenColumnApplicationID + " IN (SELECT " + TokenAppColumnClientID + " FROM %[1]s_" + tokenAppTableSuffix +
+		" WHERE " + TokenAppColumnInstanceID + " = $1 AND %[2]s = $2)"
+)
+
+type tokenProjection struct {
+	crdb.StatementHandler
+}
+
+func newTokenProjection(ctx context.Context, config crdb.StatementHandlerConfig) *tokenProjection {
+	p := new(tokenProjection)
+	config.ProjectionName = TokenProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(TokenColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TokenColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenColumnApplicationID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnUserAgentID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnAudience, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenColumnScopes, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(TokenColumnExpiration, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenColumnPreferredLanguage, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnRefreshTokenID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnIsPAT, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(TokenColumnConfirmationJKT, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(TokenColumnConfirmationX5TS256, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(TokenColumnInstanceID, TokenColumnID),
+			crdb.WithIndex(crdb.NewIndex("token_user_idx", []string{TokenColumnUserID})),
+			crdb.WithIndex(crdb.NewIndex("token_refresh_token_idx", []string{TokenColumnRefreshTokenID})),
+			crdb.WithIndex(crdb.NewIndex("token_app_idx", []string{TokenColumnApplicationID})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(TokenAppColumnAppID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenAppColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenAppColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenAppColumnClientID, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(TokenAppColumnInstanceID, TokenAppColumnAppID),
+			tokenAppTableSuffix,
+			crdb.WithIndex(crdb.NewIndex("token_app_project_idx", []string{TokenAppColumnProjectID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *tokenProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserTokenAddedType,
+					Reduce: p.reduceTokenAdded,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: p.reducePersonalAccessTokenAdded,
+				},
+				{
+					Event:  user.UserTokenRemovedType,
+					Reduce: p.reduceTokenRemoved,
+				},
+				{
+					Event:  user.PersonalAccessTokenRemovedType,
+					Reduce: p.reduceTokenRemoved,
+				},
+				{
+					Event:  user.HumanRefreshTokenRemovedType,
+					Reduce: p.reduceRefreshTokenRemoved,
+				},
+				{
+					Event:  user.UserV1ProfileChangedType,
+					Reduce: p.reduceProfileChanged,
+				},
+				{
+					Event:  user.HumanProfileChangedType,
+					Reduce: p.reduceProfileChanged,
+				},
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserTokensRemoved,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserTokensRemoved,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserTokensRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.OIDCConfigAddedType,
+					Reduce: p.reduceAppConfigAdded,
+				},
+				{
+					Event:  project.APIConfigAddedType,
+					Reduce: p.reduceAppConfigAdded,
+				},
+				{
+					Event:  project.ApplicationDeactivatedType,
+					Reduce: p.reduceAppDeactivated,
+				},
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceAppRemoved,
+				},
+				{
+					Event:  project.ProjectDeactivatedType,
+					Reduce: p.reduceProjectDeactivated,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *tokenProjection) reduceTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gq2dv", "reduce.wrong.event.type %s", user.UserTokenAddedType)
+	}
+	var confirmation domain.TokenConfirmation
+	if e.Confirmation != nil {
+		confirmation = *e.Confirmation
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnID, e.TokenID),
+			handler.NewCol(TokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(TokenColumnApplicationID, e.ApplicationID),
+			handler.NewCol(TokenColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(TokenColumnAudience, database.StringArray(e.Audience)),
+			handler.NewCol(TokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(TokenColumnExpiration, e.Expiration),
+			handler.NewCol(TokenColumnPreferredLanguage, e.PreferredLanguage),
+			handler.NewCol(TokenColumnRefreshTokenID, e.RefreshTokenID),
+			handler.NewCol(TokenColumnIsPAT, false),
+			handler.NewCol(TokenColumnConfirmationJKT, confirmation.JKT),
+			handler.NewCol(TokenColumnConfirmationX5TS256, confirmation.X5TS256),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reducePersonalAccessTokenAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.PersonalAccessTokenAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rk2nw", "reduce.wrong.event.type %s", user.PersonalAccessTokenAddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnID, e.TokenID),
+			handler.NewCol(TokenColumnCreationDate, e.CreationDate()),
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(TokenColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCol(TokenColumnScopes, database.StringArray(e.Scopes)),
+			handler.NewCol(TokenColumnExpiration, e.Expiration),
+			handler.NewCol(TokenColumnIsPAT, true),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var tokenID string
+	switch e := event.(type) {
+	case *user.UserTokenRemovedEvent:
+		tokenID = e.TokenID
+	case *user.PersonalAccessTokenRemovedEvent:
+		tokenID = e.TokenID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lp9sx", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserTokenRemovedType, user.PersonalAccessTokenRemovedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnID, tokenID),
+			handler.NewCond(TokenColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceRefreshTokenRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRefreshTokenRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vw3ja", "reduce.wrong.event.type %s", user.HumanRefreshTokenRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnRefreshTokenID, e.TokenID),
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceProfileChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanProfileChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bn4Xe", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1ProfileChangedType, user.HumanProfileChangedType})
+	}
+	if e.PreferredLanguage == nil {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenColumnChangeDate, e.CreationDate()),
+			handler.NewCol(TokenColumnSequence, e.Sequence()),
+			handler.NewCol(TokenColumnPreferredLanguage, e.PreferredLanguage.String()),
+		},
+		[]handler.Condition{
+			handler.NewCond(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Zp2wd", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1SignedOutType, user.HumanSignedOutType})
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnUserAgentID, e.UserAgentID),
+			handler.NewCond(TokenColumnUserID, e.Aggregate().ID),
+			handler.NewCond(TokenColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *tokenProjection) reduceUserTokensRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent, *user.UserDeactivatedEvent, *user.UserRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ku8nm", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType, user.UserRemovedType})
+	}
+	return crdb.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(TokenColumnUserID, event.Aggregate().ID),
+			handler.NewCond(TokenColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+// reduceAppConfigAdded keeps the client id of the app
+// so the tokens can be removed if the app or its project is deactivated or removed
+func (p *tokenProjection) reduceAppConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	var appID, clientID string
+	switch e := event.(type) {
+	case *project.OIDCConfigAddedEvent:
+		appID, clientID = e.AppID, e.ClientID
+	case *project.APIConfigAddedEvent:
+		appID, clientID = e.AppID, e.ClientID
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Qe5tz", "reduce.wrong.event.type %v", []eventstore.EventType{project.OIDCConfigAddedType, project.APIConfigAddedType})
+	}
+	return crdb.NewCreateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(TokenAppColumnAppID, appID),
+			handler.NewCol(TokenAppColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCol(TokenAppColumnProjectID, event.Aggregate().ID),
+			handler.NewCol(TokenAppColumnClientID, clientID),
+		},
+		crdb.WithTableSuffix(tokenAppTableSuffix),
+	), nil
+}
+
+func (p *tokenProjection) reduceAppDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Yc8mf", "reduce.wrong.event.type %s", project.ApplicationDeactivatedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteAppTokens(TokenAppColumnAppID, e.AppID),
+	), nil
+}
+
+func (p *tokenProjection) reduceAppRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hw6vb", "reduce.wrong.event.type %s", project.ApplicationRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteAppTokens(TokenAppColumnAppID, e.AppID),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenAppColumnAppID, e.AppID),
+				handler.NewCond(TokenAppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(tokenAppTableSuffix),
+		),
+	), nil
+}
+
+func (p *tokenProjection) reduceProjectDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectDeactivatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ew1kz", "reduce.wrong.event.type %s", project.ProjectDeactivatedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteAppTokens(TokenAppColumnProjectID, e.Aggregate().ID),
+	), nil
+}
+
+func (p *tokenProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tm7uq", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		deleteAppTokens(TokenAppColumnProjectID, e.Aggregate().ID),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenAppColumnProjectID, e.Aggregate().ID),
+				handler.NewCond(TokenAppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(tokenAppTableSuffix),
+		),
+	), nil
+}
+
+func (p *tokenProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.InstanceRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ud3pa", "reduce.wrong.event.type %s", instance.InstanceRemovedEventType)
+	}
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		crdb.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(TokenAppColumnInstanceID, e.Aggregate().ID),
+			},
+			crdb.WithTableSuffix(tokenAppTableSuffix),
+		),
+	), nil
+}
+
+// deleteAppTokens removes the tokens issued to the clients of the apps matching the column of the app table
+func deleteAppTokens(appColumn, value string) func(eventstore.Event) crdb.Exec {
+	return func(event eventstore.Event) crdb.Exec {
+		return func(ex handler.Executer, projectionName string) error {
+			if projectionName == "" {
+				return handler.ErrNoProjection
+			}
+			_, err := ex.Exec(fmt.Sprintf(deleteAppTokensStmtFormat, projectionName, appColumn), event.Aggregate().InstanceID, value)
+			if err != nil {
+				return errors.ThrowInternal(err, "HANDL-Ob3fs", "unable to delete tokens of apps")
+			}
+			return nil
+		}
+	}
+}
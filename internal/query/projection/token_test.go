@@ -0,0 +1,329 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestTokenProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceTokenAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserTokenAddedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id", "applicationId": "client-id", "userAgentId": "agent-id", "refreshTokenID": "refresh-id", "audience": ["aud"], "scopes": ["openid"], "expiration": "9999-12-31T23:59:59Z", "preferredLanguage": "de"}`),
+				), user.UserTokenAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceTokenAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens2 (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, application_id, user_agent_id, audience, scopes, expiration, preferred_language, refresh_token_id, is_pat, cnf_jkt, cnf_x5t_s256) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								"client-id",
+								"agent-id",
+								database.StringArray{"aud"},
+								database.StringArray{"openid"},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+								"de",
+								"refresh-id",
+								false,
+								"",
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTokenAdded sender-constrained",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserTokenAddedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id", "applicationId": "client-id", "userAgentId": "agent-id", "refreshTokenID": "refresh-id", "audience": ["aud"], "scopes": ["openid"], "expiration": "9999-12-31T23:59:59Z", "preferredLanguage": "de", "cnf": {"jkt": "jkt", "x5t#S256": "x5t"}}`),
+				), user.UserTokenAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceTokenAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens2 (id, creation_date, change_date, sequence, resource_owner, instance_id, user_id, application_id, user_agent_id, audience, scopes, expiration, preferred_language, refresh_token_id, is_pat, cnf_jkt, cnf_x5t_s256) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"token-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								"client-id",
+								"agent-id",
+								database.StringArray{"aud"},
+								database.StringArray{"openid"},
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+								"de",
+								"refresh-id",
+								false,
+								"jkt",
+								"x5t",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTokenRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserTokenRemovedType),
+					user.AggregateType,
+					[]byte(`{"tokenId": "token-id"}`),
+				), user.UserTokenRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceTokenRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"token-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSignedOut",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanSignedOutType),
+					user.AggregateType,
+					[]byte(`{"userAgentID": "agent-id"}`),
+				), user.HumanSignedOutEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceSignedOut,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE (user_agent_id = $1) AND (user_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agent-id",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserTokensRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserLockedType),
+					user.AggregateType,
+					nil,
+				), user.UserLockedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceUserTokensRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceAppConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.OIDCConfigAddedType),
+					project.AggregateType,
+					[]byte(`{"appId": "app-id", "clientId": "client-id"}`),
+				), project.OIDCConfigAddedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceAppConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.tokens2_apps (app_id, instance_id, project_id, client_id) VALUES ($1, $2, $3, $4)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								"agg-id",
+								"client-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceAppRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationRemovedType),
+					project.AggregateType,
+					[]byte(`{"appId": "app-id"}`),
+				), project.ApplicationRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceAppRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE instance_id = $1 AND application_id IN (SELECT client_id FROM projections.tokens2_apps WHERE instance_id = $1 AND app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens2_apps WHERE (app_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectDeactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectDeactivatedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectDeactivatedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceProjectDeactivated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE instance_id = $1 AND application_id IN (SELECT client_id FROM projections.tokens2_apps WHERE instance_id = $1 AND project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&tokenProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.tokens2 WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.tokens2_apps WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, TokenProjectionTable, tt.want)
+		})
+	}
+}
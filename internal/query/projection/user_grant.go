@@ -16,7 +16,7 @@ import (
 )
 
 const (
-	UserGrantProjectionTable = "projections.user_grants2"
+	UserGrantProjectionTable = "projections.user_grants3"
 
 	UserGrantID            = "id"
 	UserGrantCreationDate  = "creation_date"
@@ -29,6 +29,8 @@ const (
 	UserGrantProjectID     = "project_id"
 	UserGrantGrantID       = "grant_id"
 	UserGrantRoles         = "roles"
+	UserGrantValidFrom     = "valid_from"
+	UserGrantValidUntil    = "valid_until"
 )
 
 type userGrantProjection struct {
@@ -52,6 +54,8 @@ func newUserGrantProjection(ctx context.Context, config crdb.StatementHandlerCon
 			crdb.NewColumn(UserGrantProjectID, crdb.ColumnTypeText),
 			crdb.NewColumn(UserGrantGrantID, crdb.ColumnTypeText),
 			crdb.NewColumn(UserGrantRoles, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(UserGrantValidFrom, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserGrantValidUntil, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 		},
 			crdb.NewPrimaryKey(UserGrantInstanceID, UserGrantID),
 			crdb.WithIndex(crdb.NewIndex("user_grant_user_idx", []string{UserGrantUserID})),
@@ -96,6 +100,10 @@ func (p *userGrantProjection) reducers() []handler.AggregateReducer {
 					Event:  usergrant.UserGrantReactivatedType,
 					Reduce: p.reduceReactivated,
 				},
+				{
+					Event:  usergrant.UserGrantValiditySetType,
+					Reduce: p.reduceValiditySet,
+				},
 			},
 		},
 		{
@@ -230,7 +238,7 @@ func (p *userGrantProjection) reduceDeactivated(event eventstore.Event) (*handle
 }
 
 func (p *userGrantProjection) reduceReactivated(event eventstore.Event) (*handler.Statement, error) {
-	if _, ok := event.(*usergrant.UserGrantDeactivatedEvent); !ok {
+	if _, ok := event.(*usergrant.UserGrantReactivatedEvent); !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-DGsKh", "reduce.wrong.event.type %s", usergrant.UserGrantReactivatedType)
 	}
 
@@ -248,6 +256,27 @@ func (p *userGrantProjection) reduceReactivated(event eventstore.Event) (*handle
 	), nil
 }
 
+func (p *userGrantProjection) reduceValiditySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*usergrant.UserGrantValiditySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Wv4nq", "reduce.wrong.event.type %s", usergrant.UserGrantValiditySetType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserGrantChangeDate, e.CreationDate()),
+			handler.NewCol(UserGrantValidFrom, nullTime(e.ValidFrom)),
+			handler.NewCol(UserGrantValidUntil, nullTime(e.ValidUntil)),
+			handler.NewCol(UserGrantSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserGrantID, e.Aggregate().ID),
+			handler.NewCond(UserGrantInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *userGrantProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
 	if _, ok := event.(*user.UserRemovedEvent); !ok {
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Bner2a", "reduce.wrong.event.type %s", user.UserRemovedType)
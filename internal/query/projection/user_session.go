@@ -0,0 +1,501 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	UserSessionProjectionTable = "projections.user_sessions2"
+
+	UserSessionColumnUserAgentID                  = "user_agent_id"
+	UserSessionColumnUserID                       = "user_id"
+	UserSessionColumnInstanceID                   = "instance_id"
+	UserSessionColumnCreationDate                 = "creation_date"
+	UserSessionColumnChangeDate                   = "change_date"
+	UserSessionColumnSequence                     = "sequence"
+	UserSessionColumnResourceOwner                = "resource_owner"
+	UserSessionColumnState                        = "state"
+	UserSessionColumnSelectedIDPConfigID          = "selected_idp_config_id"
+	UserSessionColumnPasswordVerification         = "password_verification"
+	UserSessionColumnPasswordlessVerification     = "passwordless_verification"
+	UserSessionColumnMagicLinkVerification        = "magic_link_verification"
+	UserSessionColumnExternalLoginVerification    = "external_login_verification"
+	UserSessionColumnSecondFactorVerification     = "second_factor_verification"
+	UserSessionColumnSecondFactorVerificationType = "second_factor_verification_type"
+	UserSessionColumnMultiFactorVerification      = "multi_factor_verification"
+	UserSessionColumnMultiFactorVerificationType  = "multi_factor_verification_type"
+)
+
+type userSessionProjection struct {
+	crdb.StatementHandler
+}
+
+func newUserSessionProjection(ctx context.Context, config crdb.StatementHandlerConfig) *userSessionProjection {
+	p := new(userSessionProjection)
+	config.ProjectionName = UserSessionProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(UserSessionColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(UserSessionColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(UserSessionColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(UserSessionColumnState, crdb.ColumnTypeEnum, crdb.Default(int32(domain.UserSessionStateActive))),
+			crdb.NewColumn(UserSessionColumnSelectedIDPConfigID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(UserSessionColumnPasswordVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnPasswordlessVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnMagicLinkVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnExternalLoginVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnSecondFactorVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnSecondFactorVerificationType, crdb.ColumnTypeEnum, crdb.Default(0)),
+			crdb.NewColumn(UserSessionColumnMultiFactorVerification, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+			crdb.NewColumn(UserSessionColumnMultiFactorVerificationType, crdb.ColumnTypeEnum, crdb.Default(0)),
+		},
+			crdb.NewPrimaryKey(UserSessionColumnInstanceID, UserSessionColumnUserAgentID, UserSessionColumnUserID),
+			crdb.WithIndex(crdb.NewIndex("user_session_user_idx", []string{UserSessionColumnUserID})),
+		),
+	)
+
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *userSessionProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.UserV1PasswordCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanPasswordCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.UserV1MFAOTPCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.UserV1MFAOTPCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPSMSCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanU2FTokenCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanU2FTokenCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMagicLinkCheckSucceededType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.HumanMagicLinkCheckFailedType,
+					Reduce: p.reduceCheck,
+				},
+				{
+					Event:  user.UserV1SignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: p.reduceSignedOut,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reducePasswordChanged,
+				},
+				{
+					Event:  user.UserV1MFAOTPRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: p.reduceSecondFactorRemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: p.reducePasswordlessRemoved,
+				},
+				{
+					Event:  user.UserIDPLinkRemovedType,
+					Reduce: p.reduceIDPLinkRemoved,
+				},
+				{
+					Event:  user.UserIDPLinkCascadeRemovedType,
+					Reduce: p.reduceIDPLinkRemoved,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserTerminated,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserSessionColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+// reduceCheck creates the session of the user agent on the first check
+// and sets the verification of the checked factor
+func (p *userSessionProjection) reduceCheck(event eventstore.Event) (*handler.Statement, error) {
+	var (
+		info *user.AuthRequestInfo
+		cols []handler.Column
+	)
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanPasswordCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordVerification, nil),
+		}
+	case *user.UserIDPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		var selectedIDPConfigID string
+		if info != nil {
+			selectedIDPConfigID = info.SelectedIDPConfigID
+		}
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnExternalLoginVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSelectedIDPConfigID, selectedIDPConfigID),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanOTPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFATypeOTP),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanOTPCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		}
+	case *user.HumanOTPSMSCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFATypeOTPSMS),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanOTPSMSCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		}
+	case *user.HumanOTPEmailCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFATypeOTPEmail),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanOTPEmailCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		}
+	case *user.HumanRecoveryCodeCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFATypeRecoveryCode),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanRecoveryCodeCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		}
+	case *user.HumanU2FCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFATypeU2F),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanU2FCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		}
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordlessVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnMultiFactorVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnMultiFactorVerificationType, domain.MFATypeU2FUserVerification),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanPasswordlessCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnPasswordlessVerification, nil),
+			handler.NewCol(UserSessionColumnMultiFactorVerification, nil),
+		}
+	case *user.HumanMagicLinkCheckSucceededEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnMagicLinkVerification, e.CreationDate()),
+			handler.NewCol(UserSessionColumnState, domain.UserSessionStateActive),
+		}
+	case *user.HumanMagicLinkCheckFailedEvent:
+		info = e.AuthRequestInfo
+		cols = []handler.Column{
+			handler.NewCol(UserSessionColumnMagicLinkVerification, nil),
+		}
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tb4kw", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanPasswordCheckSucceededType,
+			user.HumanPasswordCheckFailedType,
+			user.UserIDPLoginCheckSucceededType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPSMSCheckFailedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanMFAOTPEmailCheckFailedType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.HumanMFARecoveryCodeCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.HumanMagicLinkCheckFailedType,
+		})
+	}
+	var userAgentID string
+	if info != nil {
+		userAgentID = info.UserAgentID
+	}
+	return p.upsertSession(event, userAgentID, cols...), nil
+}
+
+func (p *userSessionProjection) reduceSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gd2fb", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1SignedOutType, user.HumanSignedOutType})
+	}
+	return p.upsertSession(e, e.UserAgentID, terminatedSessionCols()...), nil
+}
+
+func (p *userSessionProjection) upsertSession(event eventstore.Event, userAgentID string, cols ...handler.Column) *handler.Statement {
+	return crdb.NewUpsertStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserSessionColumnInstanceID, nil),
+			handler.NewCol(UserSessionColumnUserAgentID, nil),
+			handler.NewCol(UserSessionColumnUserID, nil),
+		},
+		append([]handler.Column{
+			handler.NewCol(UserSessionColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCol(UserSessionColumnUserAgentID, userAgentID),
+			handler.NewCol(UserSessionColumnUserID, event.Aggregate().ID),
+			handler.NewCol(UserSessionColumnCreationDate, event.CreationDate()),
+			handler.NewCol(UserSessionColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserSessionColumnSequence, event.Sequence()),
+			handler.NewCol(UserSessionColumnResourceOwner, event.Aggregate().ResourceOwner),
+		}, cols...),
+	)
+}
+
+// reducePasswordChanged resets the password verification of all sessions of the user
+// except the session of the user agent which changed the password
+func (p *userSessionProjection) reducePasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vc1nw", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1PasswordChangedType, user.HumanPasswordChangedType})
+	}
+	return p.updateUserSessions(e,
+		handler.Column{
+			Name:  UserSessionColumnPasswordVerification,
+			Value: e.UserAgentID,
+			ParameterOpt: func(placeholder string) string {
+				return "CASE WHEN " + UserSessionColumnUserAgentID + " = " + placeholder + " THEN " + UserSessionColumnPasswordVerification + " END"
+			},
+		},
+	), nil
+}
+
+func (p *userSessionProjection) reduceSecondFactorRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanOTPRemovedEvent, *user.HumanU2FRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pw8xc", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserV1MFAOTPRemovedType, user.HumanMFAOTPRemovedType, user.HumanU2FTokenRemovedType})
+	}
+	return p.updateUserSessions(event,
+		handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+	), nil
+}
+
+func (p *userSessionProjection) reducePasswordlessRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordlessRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nf5ui", "reduce.wrong.event.type %s", user.HumanPasswordlessTokenRemovedType)
+	}
+	return p.updateUserSessions(e,
+		handler.NewCol(UserSessionColumnPasswordlessVerification, nil),
+		handler.NewCol(UserSessionColumnMultiFactorVerification, nil),
+	), nil
+}
+
+func (p *userSessionProjection) reduceIDPLinkRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserIDPLinkRemovedEvent, *user.UserIDPLinkCascadeRemovedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Cy3gk", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserIDPLinkRemovedType, user.UserIDPLinkCascadeRemovedType})
+	}
+	return p.updateUserSessions(event,
+		handler.NewCol(UserSessionColumnExternalLoginVerification, nil),
+		handler.NewCol(UserSessionColumnSelectedIDPConfigID, ""),
+	), nil
+}
+
+func (p *userSessionProjection) reduceUserTerminated(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserLockedEvent, *user.UserDeactivatedEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ix6dm", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserLockedType, user.UserDeactivatedType})
+	}
+	return p.updateUserSessions(event, terminatedSessionCols()...), nil
+}
+
+func (p *userSessionProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lh9ot", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserSessionColumnUserID, e.Aggregate().ID),
+			handler.NewCond(UserSessionColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userSessionProjection) updateUserSessions(event eventstore.Event, cols ...handler.Column) *handler.Statement {
+	return crdb.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(UserSessionColumnChangeDate, event.CreationDate()),
+			handler.NewCol(UserSessionColumnSequence, event.Sequence()),
+		}, cols...),
+		[]handler.Condition{
+			handler.NewCond(UserSessionColumnUserID, event.Aggregate().ID),
+			handler.NewCond(UserSessionColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	)
+}
+
+func terminatedSessionCols() []handler.Column {
+	return []handler.Column{
+		handler.NewCol(UserSessionColumnPasswordVerification, nil),
+		handler.NewCol(UserSessionColumnPasswordlessVerification, nil),
+		handler.NewCol(UserSessionColumnMagicLinkVerification, nil),
+		handler.NewCol(UserSessionColumnExternalLoginVerification, nil),
+		handler.NewCol(UserSessionColumnSecondFactorVerification, nil),
+		handler.NewCol(UserSessionColumnSecondFactorVerificationType, domain.MFALevelNotSetUp),
+		handler.NewCol(UserSessionColumnMultiFactorVerification, nil),
+		handler.NewCol(UserSessionColumnMultiFactorVerificationType, domain.MFALevelNotSetUp),
+		handler.NewCol(UserSessionColumnState, domain.UserSessionStateTerminated),
+	}
+}
@@ -0,0 +1,235 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestUserSessionProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceCheck password succeeded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{"userAgentID": "agent-id"}`),
+				), user.HumanPasswordCheckSucceededEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceCheck,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_sessions2 (instance_id, user_agent_id, user_id, creation_date, change_date, sequence, resource_owner, password_verification, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, user_agent_id, user_id) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, password_verification, state) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.password_verification, EXCLUDED.state)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agent-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								anyArg{},
+								domain.UserSessionStateActive,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSignedOut",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanSignedOutType),
+					user.AggregateType,
+					[]byte(`{"userAgentID": "agent-id"}`),
+				), user.HumanSignedOutEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceSignedOut,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_sessions2 (instance_id, user_agent_id, user_id, creation_date, change_date, sequence, resource_owner, password_verification, passwordless_verification, magic_link_verification, external_login_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) ON CONFLICT (instance_id, user_agent_id, user_id) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, password_verification, passwordless_verification, magic_link_verification, external_login_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type, state) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.password_verification, EXCLUDED.passwordless_verification, EXCLUDED.magic_link_verification, EXCLUDED.external_login_verification, EXCLUDED.second_factor_verification, EXCLUDED.second_factor_verification_type, EXCLUDED.multi_factor_verification, EXCLUDED.multi_factor_verification_type, EXCLUDED.state)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agent-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								nil,
+								nil,
+								nil,
+								nil,
+								nil,
+								domain.MFALevelNotSetUp,
+								nil,
+								domain.MFALevelNotSetUp,
+								domain.UserSessionStateTerminated,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reducePasswordChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordChangedType),
+					user.AggregateType,
+					[]byte(`{"userAgentID": "agent-id"}`),
+				), user.HumanPasswordChangedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reducePasswordChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_sessions2 SET (change_date, sequence, password_verification) = ($1, $2, CASE WHEN user_agent_id = $3 THEN password_verification END) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agent-id",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserTerminated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserDeactivatedType),
+					user.AggregateType,
+					nil,
+				), user.UserDeactivatedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceUserTerminated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_sessions2 SET (change_date, sequence, password_verification, passwordless_verification, magic_link_verification, external_login_verification, second_factor_verification, second_factor_verification_type, multi_factor_verification, multi_factor_verification_type, state) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) WHERE (user_id = $12) AND (instance_id = $13)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								nil,
+								nil,
+								nil,
+								nil,
+								nil,
+								domain.MFALevelNotSetUp,
+								nil,
+								domain.MFALevelNotSetUp,
+								domain.UserSessionStateTerminated,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&userSessionProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("user"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_sessions2 WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(UserSessionColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_sessions2 WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserSessionProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	// latestSequenceJoin selects the latest event sequence of the aggregate type of the current sequence
+	latestSequenceJoin = "LEFT JOIN LATERAL (SELECT max(eventstore.events.event_sequence) AS latest_sequence FROM eventstore.events" +
+		" WHERE eventstore.events.instance_id = projections.current_sequences.instance_id" +
+		" AND eventstore.events.aggregate_type = projections.current_sequences.aggregate_type) AS latest ON true"
+)
+
+type ProjectionRebuildState int32
+
+const (
+	ProjectionRebuildStateUnspecified = ProjectionRebuildState(crdb.RebuildStateUnspecified)
+	ProjectionRebuildStateRunning     = ProjectionRebuildState(crdb.RebuildStateRunning)
+	ProjectionRebuildStateDone        = ProjectionRebuildState(crdb.RebuildStateDone)
+	ProjectionRebuildStateFailed      = ProjectionRebuildState(crdb.RebuildStateFailed)
+)
+
+type ProjectionStates struct {
+	SearchResponse
+	ProjectionStates []*ProjectionState
+}
+
+type ProjectionState struct {
+	ProjectionName  string
+	InstanceID      string
+	CurrentSequence uint64
+	LatestSequence  uint64
+	// Lag is the amount of sequences the projection is behind the latest event of its aggregate types
+	Lag          uint64
+	Timestamp    time.Time
+	Paused       bool
+	RebuildState ProjectionRebuildState
+}
+
+type ProjectionStateSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ProjectionStateSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewProjectionStateProjectionNameSearchQuery(projectionName string) (SearchQuery, error) {
+	return NewTextQuery(CurrentSequenceColProjectionName, projectionName, TextEquals)
+}
+
+func NewProjectionStateInstanceIDSearchQuery(instanceID string) (SearchQuery, error) {
+	return NewTextQuery(CurrentSequenceColInstanceID, instanceID, TextEquals)
+}
+
+func (q *Queries) SearchProjectionStates(ctx context.Context, queries *ProjectionStateSearchQueries) (*ProjectionStates, error) {
+	return SearchProjectionStates(ctx, q.client, queries)
+}
+
+// SearchProjectionStates is used by the cli which doesn't start the queries
+func SearchProjectionStates(ctx context.Context, client *sql.DB, queries *ProjectionStateSearchQueries) (*ProjectionStates, error) {
+	query, scan := prepareProjectionStatesQuery()
+	stmt, args, err := queries.toQuery(query).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wb2nr", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-M2nfs", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// PauseProjection stops the processing of events of the projection on all instances
+func (q *Queries) PauseProjection(ctx context.Context, projectionName string) error {
+	return projection.Pause(ctx, projectionName)
+}
+
+// ResumeProjection continues the processing of events of a paused projection
+func (q *Queries) ResumeProjection(ctx context.Context, projectionName string) error {
+	return projection.Resume(ctx, projectionName)
+}
+
+// RebuildProjection starts the rebuild of the projection into shadow tables in the background
+// the state of the rebuild can be checked using SearchProjectionStates
+// it fails if a rebuild of the projection is already running
+func (q *Queries) RebuildProjection(ctx context.Context, projectionName string) error {
+	if !projection.Exists(projectionName) {
+		return errors.ThrowNotFound(nil, "QUERY-Zu3kn", "Errors.ProjectionName.Invalid")
+	}
+	return projection.StartRebuild(ctx, projectionName)
+}
+
+func prepareProjectionStatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*ProjectionStates, error)) {
+	return sq.Select(
+			CurrentSequenceColProjectionName.identifier(),
+			CurrentSequenceColInstanceID.identifier(),
+			"max("+CurrentSequenceColCurrentSequence.identifier()+") as "+CurrentSequenceColCurrentSequence.name,
+			"COALESCE(max(latest.latest_sequence), 0) as latest_sequence",
+			"COALESCE(max(GREATEST(latest.latest_sequence - "+CurrentSequenceColCurrentSequence.identifier()+", 0)), 0) as lag",
+			"max("+CurrentSequenceColTimestamp.identifier()+") as "+CurrentSequenceColTimestamp.name,
+			ProjectionStateColPaused.identifier(),
+			ProjectionStateColRebuildState.identifier(),
+			countColumn.identifier()).
+			From(currentSequencesTable.identifier()).
+			JoinClause(latestSequenceJoin).
+			LeftJoin(projectionStatesTable.identifier()+" ON "+CurrentSequenceColProjectionName.identifier()+" = "+ProjectionStateColProjectionName.identifier()).
+			GroupBy(
+				CurrentSequenceColProjectionName.identifier(),
+				CurrentSequenceColInstanceID.identifier(),
+				ProjectionStateColPaused.identifier(),
+				ProjectionStateColRebuildState.identifier(),
+			).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ProjectionStates, error) {
+			states := make([]*ProjectionState, 0)
+			var count uint64
+			for rows.Next() {
+				state := new(ProjectionState)
+				var (
+					paused       sql.NullBool
+					rebuildState sql.NullInt32
+				)
+				err := rows.Scan(
+					&state.ProjectionName,
+					&state.InstanceID,
+					&state.CurrentSequence,
+					&state.LatestSequence,
+					&state.Lag,
+					&state.Timestamp,
+					&paused,
+					&rebuildState,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				state.Paused = paused.Bool
+				state.RebuildState = ProjectionRebuildState(rebuildState.Int32)
+				states = append(states, state)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Sl4m2", "Errors.Query.CloseRows")
+			}
+
+			return &ProjectionStates{
+				ProjectionStates: states,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+var (
+	projectionStatesTable = table{
+		name: projection.StatesTable,
+	}
+	ProjectionStateColProjectionName = Column{
+		name:  "projection_name",
+		table: projectionStatesTable,
+	}
+	ProjectionStateColPaused = Column{
+		name:  "paused",
+		table: projectionStatesTable,
+	}
+	ProjectionStateColRebuildState = Column{
+		name:  "rebuild_state",
+		table: projectionStatesTable,
+	}
+)
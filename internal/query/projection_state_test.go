@@ -0,0 +1,149 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	projectionStatesQuery = regexp.QuoteMeta(`SELECT projections.current_sequences.projection_name,` +
+		` projections.current_sequences.instance_id,` +
+		` max(projections.current_sequences.current_sequence) as current_sequence,` +
+		` COALESCE(max(latest.latest_sequence), 0) as latest_sequence,` +
+		` COALESCE(max(GREATEST(latest.latest_sequence - projections.current_sequences.current_sequence, 0)), 0) as lag,` +
+		` max(projections.current_sequences.timestamp) as timestamp,` +
+		` projections.projection_states.paused,` +
+		` projections.projection_states.rebuild_state,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.current_sequences` +
+		` LEFT JOIN LATERAL (SELECT max(eventstore.events.event_sequence) AS latest_sequence FROM eventstore.events` +
+		` WHERE eventstore.events.instance_id = projections.current_sequences.instance_id` +
+		` AND eventstore.events.aggregate_type = projections.current_sequences.aggregate_type) AS latest ON true` +
+		` LEFT JOIN projections.projection_states ON projections.current_sequences.projection_name = projections.projection_states.projection_name` +
+		` GROUP BY projections.current_sequences.projection_name,` +
+		` projections.current_sequences.instance_id,` +
+		` projections.projection_states.paused,` +
+		` projections.projection_states.rebuild_state`)
+	projectionStatesCols = []string{
+		"projection_name",
+		"instance_id",
+		"current_sequence",
+		"latest_sequence",
+		"lag",
+		"timestamp",
+		"paused",
+		"rebuild_state",
+		"count",
+	}
+)
+
+func Test_ProjectionStatesPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareProjectionStatesQuery no result",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					projectionStatesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: &ProjectionStates{ProjectionStates: []*ProjectionState{}},
+		},
+		{
+			name:    "prepareProjectionStatesQuery multiple result",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					projectionStatesQuery,
+					projectionStatesCols,
+					[][]driver.Value{
+						{
+							"projection-name",
+							"instance-id",
+							uint64(20211108),
+							uint64(20211110),
+							uint64(2),
+							testNow,
+							true,
+							int32(ProjectionRebuildStateRunning),
+						},
+						{
+							"projection-name-2",
+							"instance-id",
+							uint64(20211108),
+							uint64(20211108),
+							uint64(0),
+							testNow,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &ProjectionStates{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				ProjectionStates: []*ProjectionState{
+					{
+						ProjectionName:  "projection-name",
+						InstanceID:      "instance-id",
+						CurrentSequence: 20211108,
+						LatestSequence:  20211110,
+						Lag:             2,
+						Timestamp:       testNow,
+						Paused:          true,
+						RebuildState:    ProjectionRebuildStateRunning,
+					},
+					{
+						ProjectionName:  "projection-name-2",
+						InstanceID:      "instance-id",
+						CurrentSequence: 20211108,
+						LatestSequence:  20211108,
+						Lag:             0,
+						Timestamp:       testNow,
+						Paused:          false,
+						RebuildState:    ProjectionRebuildStateUnspecified,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareProjectionStatesQuery sql err",
+			prepare: prepareProjectionStatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					projectionStatesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	refreshTokensTable = table{
+		name:          projection.RefreshTokenProjectionTable,
+		instanceIDCol: projection.RefreshTokenColumnInstanceID,
+	}
+	RefreshTokenColumnID = Column{
+		name:  projection.RefreshTokenColumnID,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnCreationDate = Column{
+		name:  projection.RefreshTokenColumnCreationDate,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnChangeDate = Column{
+		name:  projection.RefreshTokenColumnChangeDate,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnSequence = Column{
+		name:  projection.RefreshTokenColumnSequence,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnResourceOwner = Column{
+		name:  projection.RefreshTokenColumnResourceOwner,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnInstanceID = Column{
+		name:  projection.RefreshTokenColumnInstanceID,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnUserID = Column{
+		name:  projection.RefreshTokenColumnUserID,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnClientID = Column{
+		name:  projection.RefreshTokenColumnClientID,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnUserAgentID = Column{
+		name:  projection.RefreshTokenColumnUserAgentID,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnAudience = Column{
+		name:  projection.RefreshTokenColumnAudience,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnScopes = Column{
+		name:  projection.RefreshTokenColumnScopes,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnAuthMethodsReferences = Column{
+		name:  projection.RefreshTokenColumnAuthMethodsReferences,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnAuthTime = Column{
+		name:  projection.RefreshTokenColumnAuthTime,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnIdleExpiration = Column{
+		name:  projection.RefreshTokenColumnIdleExpiration,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnExpiration = Column{
+		name:  projection.RefreshTokenColumnExpiration,
+		table: refreshTokensTable,
+	}
+	RefreshTokenColumnToken = Column{
+		name:  projection.RefreshTokenColumnToken,
+		table: refreshTokensTable,
+	}
+)
+
+type RefreshTokens struct {
+	SearchResponse
+	RefreshTokens []*RefreshToken
+}
+
+type RefreshToken struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	UserID                string
+	ClientID              string
+	UserAgentID           string
+	Audience              database.StringArray
+	Scopes                database.StringArray
+	AuthMethodsReferences database.StringArray
+	AuthTime              time.Time
+	IdleExpiration        time.Time
+	Expiration            time.Time
+	Token                 string
+}
+
+type RefreshTokenSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *RefreshTokenSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewRefreshTokenUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RefreshTokenColumnUserID, value, TextEquals)
+}
+
+func (q *Queries) RefreshTokenByID(ctx context.Context, id string) (*RefreshToken, error) {
+	query, scan := prepareRefreshTokenQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		RefreshTokenColumnID.identifier():         id,
+		RefreshTokenColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Xk2dp", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchRefreshTokens(ctx context.Context, queries *RefreshTokenSearchQueries) (refreshTokens *RefreshTokens, err error) {
+	query, scan := prepareRefreshTokensQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			RefreshTokenColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ol4fv", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tb6pw", "Errors.Internal")
+	}
+	refreshTokens, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokens.LatestSequence, err = q.latestSequence(ctx, refreshTokensTable)
+	return refreshTokens, err
+}
+
+func prepareRefreshTokenQuery() (sq.SelectBuilder, func(*sql.Row) (*RefreshToken, error)) {
+	return sq.Select(
+			RefreshTokenColumnID.identifier(),
+			RefreshTokenColumnCreationDate.identifier(),
+			RefreshTokenColumnChangeDate.identifier(),
+			RefreshTokenColumnResourceOwner.identifier(),
+			RefreshTokenColumnSequence.identifier(),
+			RefreshTokenColumnUserID.identifier(),
+			RefreshTokenColumnClientID.identifier(),
+			RefreshTokenColumnUserAgentID.identifier(),
+			RefreshTokenColumnAudience.identifier(),
+			RefreshTokenColumnScopes.identifier(),
+			RefreshTokenColumnAuthMethodsReferences.identifier(),
+			RefreshTokenColumnAuthTime.identifier(),
+			RefreshTokenColumnIdleExpiration.identifier(),
+			RefreshTokenColumnExpiration.identifier(),
+			RefreshTokenColumnToken.identifier()).
+			From(refreshTokensTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*RefreshToken, error) {
+			t := new(RefreshToken)
+			err := row.Scan(
+				&t.ID,
+				&t.CreationDate,
+				&t.ChangeDate,
+				&t.ResourceOwner,
+				&t.Sequence,
+				&t.UserID,
+				&t.ClientID,
+				&t.UserAgentID,
+				&t.Audience,
+				&t.Scopes,
+				&t.AuthMethodsReferences,
+				&t.AuthTime,
+				&t.IdleExpiration,
+				&t.Expiration,
+				&t.Token,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ek3jb", "Errors.User.RefreshToken.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Rb7kx", "Errors.Internal")
+			}
+			return t, nil
+		}
+}
+
+func prepareRefreshTokensQuery() (sq.SelectBuilder, func(*sql.Rows) (*RefreshTokens, error)) {
+	return sq.Select(
+			RefreshTokenColumnID.identifier(),
+			RefreshTokenColumnCreationDate.identifier(),
+			RefreshTokenColumnChangeDate.identifier(),
+			RefreshTokenColumnResourceOwner.identifier(),
+			RefreshTokenColumnSequence.identifier(),
+			RefreshTokenColumnUserID.identifier(),
+			RefreshTokenColumnClientID.identifier(),
+			RefreshTokenColumnUserAgentID.identifier(),
+			RefreshTokenColumnAudience.identifier(),
+			RefreshTokenColumnScopes.identifier(),
+			RefreshTokenColumnAuthMethodsReferences.identifier(),
+			RefreshTokenColumnAuthTime.identifier(),
+			RefreshTokenColumnIdleExpiration.identifier(),
+			RefreshTokenColumnExpiration.identifier(),
+			RefreshTokenColumnToken.identifier(),
+			countColumn.identifier()).
+			From(refreshTokensTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*RefreshTokens, error) {
+			refreshTokens := make([]*RefreshToken, 0)
+			var count uint64
+			for rows.Next() {
+				t := new(RefreshToken)
+				err := rows.Scan(
+					&t.ID,
+					&t.CreationDate,
+					&t.ChangeDate,
+					&t.ResourceOwner,
+					&t.Sequence,
+					&t.UserID,
+					&t.ClientID,
+					&t.UserAgentID,
+					&t.Audience,
+					&t.Scopes,
+					&t.AuthMethodsReferences,
+					&t.AuthTime,
+					&t.IdleExpiration,
+					&t.Expiration,
+					&t.Token,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				refreshTokens = append(refreshTokens, t)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Wd5mn", "Errors.Query.CloseRows")
+			}
+
+			return &RefreshTokens{
+				RefreshTokens: refreshTokens,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
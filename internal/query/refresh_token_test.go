@@ -0,0 +1,266 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	refreshTokenStmt = regexp.QuoteMeta(
+		"SELECT projections.refresh_tokens.id," +
+			" projections.refresh_tokens.creation_date," +
+			" projections.refresh_tokens.change_date," +
+			" projections.refresh_tokens.resource_owner," +
+			" projections.refresh_tokens.sequence," +
+			" projections.refresh_tokens.user_id," +
+			" projections.refresh_tokens.client_id," +
+			" projections.refresh_tokens.user_agent_id," +
+			" projections.refresh_tokens.audience," +
+			" projections.refresh_tokens.scopes," +
+			" projections.refresh_tokens.amr," +
+			" projections.refresh_tokens.auth_time," +
+			" projections.refresh_tokens.idle_expiration," +
+			" projections.refresh_tokens.expiration," +
+			" projections.refresh_tokens.token" +
+			" FROM projections.refresh_tokens")
+	refreshTokenCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"client_id",
+		"user_agent_id",
+		"audience",
+		"scopes",
+		"amr",
+		"auth_time",
+		"idle_expiration",
+		"expiration",
+		"token",
+	}
+	refreshTokensStmt = regexp.QuoteMeta(
+		"SELECT projections.refresh_tokens.id," +
+			" projections.refresh_tokens.creation_date," +
+			" projections.refresh_tokens.change_date," +
+			" projections.refresh_tokens.resource_owner," +
+			" projections.refresh_tokens.sequence," +
+			" projections.refresh_tokens.user_id," +
+			" projections.refresh_tokens.client_id," +
+			" projections.refresh_tokens.user_agent_id," +
+			" projections.refresh_tokens.audience," +
+			" projections.refresh_tokens.scopes," +
+			" projections.refresh_tokens.amr," +
+			" projections.refresh_tokens.auth_time," +
+			" projections.refresh_tokens.idle_expiration," +
+			" projections.refresh_tokens.expiration," +
+			" projections.refresh_tokens.token," +
+			" COUNT(*) OVER ()" +
+			" FROM projections.refresh_tokens")
+	refreshTokensCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"client_id",
+		"user_agent_id",
+		"audience",
+		"scopes",
+		"amr",
+		"auth_time",
+		"idle_expiration",
+		"expiration",
+		"token",
+		"count",
+	}
+)
+
+func Test_RefreshTokenPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareRefreshTokenQuery no result",
+			prepare: prepareRefreshTokenQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					refreshTokenStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*RefreshToken)(nil),
+		},
+		{
+			name:    "prepareRefreshTokenQuery found",
+			prepare: prepareRefreshTokenQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					refreshTokenStmt,
+					refreshTokenCols,
+					[]driver.Value{
+						"token-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211202),
+						"user-id",
+						"client-id",
+						"agent-id",
+						database.StringArray{"aud"},
+						database.StringArray{"openid"},
+						database.StringArray{"pwd"},
+						testNow,
+						testNow,
+						testNow,
+						"token",
+					},
+				),
+			},
+			object: &RefreshToken{
+				ID:                    "token-id",
+				CreationDate:          testNow,
+				ChangeDate:            testNow,
+				ResourceOwner:         "ro",
+				Sequence:              20211202,
+				UserID:                "user-id",
+				ClientID:              "client-id",
+				UserAgentID:           "agent-id",
+				Audience:              database.StringArray{"aud"},
+				Scopes:                database.StringArray{"openid"},
+				AuthMethodsReferences: database.StringArray{"pwd"},
+				AuthTime:              testNow,
+				IdleExpiration:        testNow,
+				Expiration:            testNow,
+				Token:                 "token",
+			},
+		},
+		{
+			name:    "prepareRefreshTokenQuery sql err",
+			prepare: prepareRefreshTokenQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					refreshTokenStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareRefreshTokensQuery no result",
+			prepare: prepareRefreshTokensQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					refreshTokensStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &RefreshTokens{RefreshTokens: []*RefreshToken{}},
+		},
+		{
+			name:    "prepareRefreshTokensQuery one token",
+			prepare: prepareRefreshTokensQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					refreshTokensStmt,
+					refreshTokensCols,
+					[][]driver.Value{
+						{
+							"token-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211202),
+							"user-id",
+							"client-id",
+							"agent-id",
+							database.StringArray{"aud"},
+							database.StringArray{"openid"},
+							database.StringArray{"pwd"},
+							testNow,
+							testNow,
+							testNow,
+							"token",
+						},
+					},
+				),
+			},
+			object: &RefreshTokens{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				RefreshTokens: []*RefreshToken{
+					{
+						ID:                    "token-id",
+						CreationDate:          testNow,
+						ChangeDate:            testNow,
+						ResourceOwner:         "ro",
+						Sequence:              20211202,
+						UserID:                "user-id",
+						ClientID:              "client-id",
+						UserAgentID:           "agent-id",
+						Audience:              database.StringArray{"aud"},
+						Scopes:                database.StringArray{"openid"},
+						AuthMethodsReferences: database.StringArray{"pwd"},
+						AuthTime:              testNow,
+						IdleExpiration:        testNow,
+						Expiration:            testNow,
+						Token:                 "token",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareRefreshTokensQuery sql err",
+			prepare: prepareRefreshTokensQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					refreshTokensStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	relationTuplesTable = table{
+		name:          projection.RelationTupleProjectionTable,
+		instanceIDCol: projection.RelationTupleColumnInstanceID,
+	}
+	RelationTupleColumnProjectID = Column{
+		name:  projection.RelationTupleColumnProjectID,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnObjectType = Column{
+		name:  projection.RelationTupleColumnObjectType,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnObjectID = Column{
+		name:  projection.RelationTupleColumnObjectID,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnRelation = Column{
+		name:  projection.RelationTupleColumnRelation,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnSubjectType = Column{
+		name:  projection.RelationTupleColumnSubjectType,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnSubjectID = Column{
+		name:  projection.RelationTupleColumnSubjectID,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnSubjectRelation = Column{
+		name:  projection.RelationTupleColumnSubjectRelation,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnCreationDate = Column{
+		name:  projection.RelationTupleColumnCreationDate,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnSequence = Column{
+		name:  projection.RelationTupleColumnSequence,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnResourceOwner = Column{
+		name:  projection.RelationTupleColumnResourceOwner,
+		table: relationTuplesTable,
+	}
+	RelationTupleColumnInstanceID = Column{
+		name:  projection.RelationTupleColumnInstanceID,
+		table: relationTuplesTable,
+	}
+)
+
+type RelationTuples struct {
+	SearchResponse
+	RelationTuples []*RelationTuple
+}
+
+type RelationTuple struct {
+	ProjectID     string
+	CreationDate  time.Time
+	Sequence      uint64
+	ResourceOwner string
+
+	ObjectType string
+	ObjectID   string
+	Relation   string
+	Subject    domain.RelationSubject
+}
+
+// RelationTree is the result of the expansion of a relation of an object
+// Subjects contains the users and the usersets of ZITADEL objects (org, project and grant)
+// Children contains the expanded usersets of custom objects
+type RelationTree struct {
+	ObjectType string
+	ObjectID   string
+	Relation   string
+	Subjects   []domain.RelationSubject
+	Children   []*RelationTree
+}
+
+type RelationTupleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *RelationTupleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewRelationTupleObjectTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnObjectType, value, TextEquals)
+}
+
+func NewRelationTupleObjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnObjectID, value, TextEquals)
+}
+
+func NewRelationTupleRelationSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnRelation, value, TextEquals)
+}
+
+func NewRelationTupleSubjectTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnSubjectType, value, TextEquals)
+}
+
+func NewRelationTupleSubjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnSubjectID, value, TextEquals)
+}
+
+func NewRelationTupleSubjectRelationSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnSubjectRelation, value, TextEquals)
+}
+
+func (q *Queries) SearchRelationTuples(ctx context.Context, projectID, resourceOwner string, queries *RelationTupleSearchQueries) (tuples *RelationTuples, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareRelationTuplesQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			RelationTupleColumnProjectID.identifier():     projectID,
+			RelationTupleColumnResourceOwner.identifier(): resourceOwner,
+			RelationTupleColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Nd5ok", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ga7dm", "Errors.Internal")
+	}
+	tuples, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	tuples.LatestSequence, err = q.latestSequence(ctx, relationTuplesTable)
+	return tuples, err
+}
+
+// CheckRelation returns true if the user has the relation on the object
+// either directly, through a userset or through the orgs, projects and grants of ZITADEL
+func (q *Queries) CheckRelation(ctx context.Context, projectID, resourceOwner, objectType, objectID, relation, userID string) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.relationResolver(projectID, resourceOwner).check(ctx, objectType, objectID, relation, userID)
+}
+
+// ListRelationObjects returns the ids of all objects of the type the user has the relation on
+func (q *Queries) ListRelationObjects(ctx context.Context, projectID, resourceOwner, objectType, relation, userID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.relationResolver(projectID, resourceOwner).listObjects(ctx, objectType, relation, userID)
+}
+
+// ExpandRelation returns the tree of all subjects having the relation on the object
+func (q *Queries) ExpandRelation(ctx context.Context, projectID, resourceOwner, objectType, objectID, relation string) (_ *RelationTree, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.relationResolver(projectID, resourceOwner).expand(ctx, objectType, objectID, relation, make(map[string]bool), 0)
+}
+
+func (q *Queries) relationResolver(projectID, resourceOwner string) *relationResolver {
+	return &relationResolver{
+		tuplesByObject: func(ctx context.Context, objectType, objectID, relation string) ([]*RelationTuple, error) {
+			return q.relationTuples(ctx, projectID, resourceOwner, sq.Eq{
+				RelationTupleColumnObjectType.identifier(): objectType,
+				RelationTupleColumnObjectID.identifier():   objectID,
+				RelationTupleColumnRelation.identifier():   relation,
+			})
+		},
+		tuplesBySubjects: func(ctx context.Context, subjects []domain.RelationSubject) ([]*RelationTuple, error) {
+			subjectsQuery := make(sq.Or, len(subjects))
+			for i, subject := range subjects {
+				subjectsQuery[i] = sq.Eq{
+					RelationTupleColumnSubjectType.identifier():     subject.Type,
+					RelationTupleColumnSubjectID.identifier():       subject.ID,
+					RelationTupleColumnSubjectRelation.identifier(): subject.Relation,
+				}
+			}
+			return q.relationTuples(ctx, projectID, resourceOwner, subjectsQuery)
+		},
+		userSubjects: func(ctx context.Context, userID string) ([]domain.RelationSubject, error) {
+			return q.relationUserSubjects(ctx, projectID, userID)
+		},
+	}
+}
+
+func (q *Queries) relationTuples(ctx context.Context, projectID, resourceOwner string, condition sq.Sqlizer) ([]*RelationTuple, error) {
+	query, scan := prepareRelationTuplesQuery()
+	stmt, args, err := query.
+		Where(sq.Eq{
+			RelationTupleColumnProjectID.identifier():     projectID,
+			RelationTupleColumnResourceOwner.identifier(): resourceOwner,
+			RelationTupleColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		}).
+		Where(condition).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pk3vd", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ur6qe", "Errors.Internal")
+	}
+	tuples, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	return tuples.RelationTuples, nil
+}
+
+// relationUserSubjects returns the user itself and all usersets of ZITADEL objects the user is part of:
+// the organisation of the user and the roles of the active user grants on the project (and its grants)
+func (q *Queries) relationUserSubjects(ctx context.Context, projectID, userID string) ([]domain.RelationSubject, error) {
+	user, err := q.GetUserByID(ctx, false, userID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if user.State == domain.UserStateInactive || user.State == domain.UserStateLocked {
+		return nil, nil
+	}
+	subjects := []domain.RelationSubject{
+		{Type: domain.RelationNamespaceUser, ID: user.ID},
+		{Type: domain.RelationNamespaceOrg, ID: user.ResourceOwner, Relation: domain.RelationMember},
+	}
+
+	userIDQuery, err := NewUserGrantUserIDSearchQuery(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDQuery, err := NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	effectiveQuery, err := NewUserGrantEffectiveQuery(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{userIDQuery, projectIDQuery, effectiveQuery}})
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants.UserGrants {
+		if grant.State != domain.UserGrantStateActive {
+			continue
+		}
+		subjects = appendRelationRoleSubjects(subjects, domain.RelationNamespaceProject, grant.ProjectID, grant.Roles)
+		if grant.GrantID != "" {
+			subjects = appendRelationRoleSubjects(subjects, domain.RelationNamespaceGrant, grant.GrantID, grant.Roles)
+		}
+	}
+	return subjects, nil
+}
+
+func appendRelationRoleSubjects(subjects []domain.RelationSubject, namespace, id string, roles []string) []domain.RelationSubject {
+	subjects = append(subjects, domain.RelationSubject{Type: namespace, ID: id, Relation: domain.RelationMember})
+	for _, role := range roles {
+		subjects = append(subjects, domain.RelationSubject{Type: namespace, ID: id, Relation: role})
+	}
+	return subjects
+}
+
+type relationResolver struct {
+	tuplesByObject   func(ctx context.Context, objectType, objectID, relation string) ([]*RelationTuple, error)
+	tuplesBySubjects func(ctx context.Context, subjects []domain.RelationSubject) ([]*RelationTuple, error)
+	userSubjects     func(ctx context.Context, userID string) ([]domain.RelationSubject, error)
+}
+
+func (r *relationResolver) check(ctx context.Context, objectType, objectID, relation, userID string) (bool, error) {
+	subjects, err := r.userSubjects(ctx, userID)
+	if err != nil || len(subjects) == 0 {
+		return false, err
+	}
+	userSubjects := make(map[string]bool, len(subjects))
+	for _, subject := range subjects {
+		userSubjects[subject.String()] = true
+	}
+	return r.checkUserset(ctx, objectType, objectID, relation, userSubjects, make(map[string]bool), 0)
+}
+
+func (r *relationResolver) checkUserset(ctx context.Context, objectType, objectID, relation string, userSubjects, visited map[string]bool, depth int) (bool, error) {
+	key := relationUsersetKey(objectType, objectID, relation)
+	if depth >= domain.RelationMaxDepth || visited[key] {
+		return false, nil
+	}
+	visited[key] = true
+
+	tuples, err := r.tuplesByObject(ctx, objectType, objectID, relation)
+	if err != nil {
+		return false, err
+	}
+	usersets := make([]domain.RelationSubject, 0, len(tuples))
+	for _, tuple := range tuples {
+		if userSubjects[tuple.Subject.String()] {
+			return true, nil
+		}
+		if tuple.Subject.IsUserset() && !domain.IsBuiltInRelationNamespace(tuple.Subject.Type) {
+			usersets = append(usersets, tuple.Subject)
+		}
+	}
+	for _, userset := range usersets {
+		allowed, err := r.checkUserset(ctx, userset.Type, userset.ID, userset.Relation, userSubjects, visited, depth+1)
+		if allowed || err != nil {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+func (r *relationResolver) listObjects(ctx context.Context, objectType, relation, userID string) ([]string, error) {
+	frontier, err := r.userSubjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	visited := make(map[string]bool, len(frontier))
+	for _, subject := range frontier {
+		visited[subject.String()] = true
+	}
+	found := make(map[string]bool)
+	objectIDs := make([]string, 0)
+	for depth := 0; len(frontier) > 0 && depth < domain.RelationMaxDepth; depth++ {
+		tuples, err := r.tuplesBySubjects(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = make([]domain.RelationSubject, 0, len(tuples))
+		for _, tuple := range tuples {
+			if tuple.ObjectType == objectType && tuple.Relation == relation && !found[tuple.ObjectID] {
+				found[tuple.ObjectID] = true
+				objectIDs = append(objectIDs, tuple.ObjectID)
+			}
+			userset := domain.RelationSubject{Type: tuple.ObjectType, ID: tuple.ObjectID, Relation: tuple.Relation}
+			if !visited[userset.String()] {
+				visited[userset.String()] = true
+				frontier = append(frontier, userset)
+			}
+		}
+	}
+	sort.Strings(objectIDs)
+	return objectIDs, nil
+}
+
+func (r *relationResolver) expand(ctx context.Context, objectType, objectID, relation string, visited map[string]bool, depth int) (*RelationTree, error) {
+	visited[relationUsersetKey(objectType, objectID, relation)] = true
+	tree := &RelationTree{
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Relation:   relation,
+		Subjects:   make([]domain.RelationSubject, 0),
+		Children:   make([]*RelationTree, 0),
+	}
+	tuples, err := r.tuplesByObject(ctx, objectType, objectID, relation)
+	if err != nil {
+		return nil, err
+	}
+	for _, tuple := range tuples {
+		if !tuple.Subject.IsUserset() ||
+			domain.IsBuiltInRelationNamespace(tuple.Subject.Type) ||
+			visited[tuple.Subject.String()] ||
+			depth+1 >= domain.RelationMaxDepth {
+			tree.Subjects = append(tree.Subjects, tuple.Subject)
+			continue
+		}
+		child, err := r.expand(ctx, tuple.Subject.Type, tuple.Subject.ID, tuple.Subject.Relation, visited, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+	return tree, nil
+}
+
+func relationUsersetKey(objectType, objectID, relation string) string {
+	return objectType + ":" + objectID + "#" + relation
+}
+
+func prepareRelationTuplesQuery() (sq.SelectBuilder, func(*sql.Rows) (*RelationTuples, error)) {
+	return sq.Select(
+			RelationTupleColumnProjectID.identifier(),
+			RelationTupleColumnCreationDate.identifier(),
+			RelationTupleColumnSequence.identifier(),
+			RelationTupleColumnResourceOwner.identifier(),
+			RelationTupleColumnObjectType.identifier(),
+			RelationTupleColumnObjectID.identifier(),
+			RelationTupleColumnRelation.identifier(),
+			RelationTupleColumnSubjectType.identifier(),
+			RelationTupleColumnSubjectID.identifier(),
+			RelationTupleColumnSubjectRelation.identifier(),
+			countColumn.identifier()).
+			From(relationTuplesTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*RelationTuples, error) {
+			tuples := make([]*RelationTuple, 0)
+			var count uint64
+			for rows.Next() {
+				tuple := new(RelationTuple)
+				err := rows.Scan(
+					&tuple.ProjectID,
+					&tuple.CreationDate,
+					&tuple.Sequence,
+					&tuple.ResourceOwner,
+					&tuple.ObjectType,
+					&tuple.ObjectID,
+					&tuple.Relation,
+					&tuple.Subject.Type,
+					&tuple.Subject.ID,
+					&tuple.Subject.Relation,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				tuples = append(tuples, tuple)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Hs9wl", "Errors.Query.CloseRows")
+			}
+
+			return &RelationTuples{
+				RelationTuples: tuples,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
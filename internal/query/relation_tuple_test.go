@@ -0,0 +1,327 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	relationTuplesStmt = regexp.QuoteMeta(`SELECT projections.relation_tuples.project_id,` +
+		` projections.relation_tuples.creation_date,` +
+		` projections.relation_tuples.sequence,` +
+		` projections.relation_tuples.resource_owner,` +
+		` projections.relation_tuples.object_type,` +
+		` projections.relation_tuples.object_id,` +
+		` projections.relation_tuples.relation,` +
+		` projections.relation_tuples.subject_type,` +
+		` projections.relation_tuples.subject_id,` +
+		` projections.relation_tuples.subject_relation,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.relation_tuples`)
+	relationTuplesCols = []string{
+		"project_id",
+		"creation_date",
+		"sequence",
+		"resource_owner",
+		"object_type",
+		"object_id",
+		"relation",
+		"subject_type",
+		"subject_id",
+		"subject_relation",
+		"count",
+	}
+)
+
+func Test_RelationTuplePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareRelationTuplesQuery no result",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					relationTuplesStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &RelationTuples{RelationTuples: []*RelationTuple{}},
+		},
+		{
+			name:    "prepareRelationTuplesQuery multiple result",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					relationTuplesStmt,
+					relationTuplesCols,
+					[][]driver.Value{
+						{
+							"project-id",
+							testNow,
+							uint64(20211108),
+							"ro",
+							"document",
+							"readme",
+							"viewer",
+							"user",
+							"user-id",
+							"",
+						},
+						{
+							"project-id",
+							testNow,
+							uint64(20211109),
+							"ro",
+							"document",
+							"readme",
+							"editor",
+							"group",
+							"developers",
+							"member",
+						},
+					},
+				),
+			},
+			object: &RelationTuples{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				RelationTuples: []*RelationTuple{
+					{
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						Sequence:      20211108,
+						ResourceOwner: "ro",
+						ObjectType:    "document",
+						ObjectID:      "readme",
+						Relation:      "viewer",
+						Subject:       domain.RelationSubject{Type: "user", ID: "user-id"},
+					},
+					{
+						ProjectID:     "project-id",
+						CreationDate:  testNow,
+						Sequence:      20211109,
+						ResourceOwner: "ro",
+						ObjectType:    "document",
+						ObjectID:      "readme",
+						Relation:      "editor",
+						Subject:       domain.RelationSubject{Type: "group", ID: "developers", Relation: "member"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareRelationTuplesQuery sql err",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					relationTuplesStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
+
+func testRelationTuple(object, relation string, subject domain.RelationSubject) *RelationTuple {
+	parts := strings.SplitN(object, ":", 2)
+	return &RelationTuple{ObjectType: parts[0], ObjectID: parts[1], Relation: relation, Subject: subject}
+}
+
+// testRelationResolver resolves the relations of the given tuples in memory
+// the user `user1` is part of `org1` and has the role `writer` on `project1`
+func testRelationResolver(tuples ...*RelationTuple) *relationResolver {
+	return &relationResolver{
+		tuplesByObject: func(_ context.Context, objectType, objectID, relation string) ([]*RelationTuple, error) {
+			result := make([]*RelationTuple, 0)
+			for _, tuple := range tuples {
+				if tuple.ObjectType == objectType && tuple.ObjectID == objectID && tuple.Relation == relation {
+					result = append(result, tuple)
+				}
+			}
+			return result, nil
+		},
+		tuplesBySubjects: func(_ context.Context, subjects []domain.RelationSubject) ([]*RelationTuple, error) {
+			result := make([]*RelationTuple, 0)
+			for _, tuple := range tuples {
+				for _, subject := range subjects {
+					if tuple.Subject == subject {
+						result = append(result, tuple)
+						break
+					}
+				}
+			}
+			return result, nil
+		},
+		userSubjects: func(_ context.Context, userID string) ([]domain.RelationSubject, error) {
+			if userID != "user1" {
+				return nil, nil
+			}
+			subjects := []domain.RelationSubject{
+				{Type: domain.RelationNamespaceUser, ID: "user1"},
+				{Type: domain.RelationNamespaceOrg, ID: "org1", Relation: domain.RelationMember},
+			}
+			return appendRelationRoleSubjects(subjects, domain.RelationNamespaceProject, "project1", []string{"writer"}), nil
+		},
+	}
+}
+
+var testRelationTuples = []*RelationTuple{
+	testRelationTuple("document:readme", "viewer", domain.RelationSubject{Type: "group", ID: "developers", Relation: "member"}),
+	testRelationTuple("document:readme", "viewer", domain.RelationSubject{Type: "user", ID: "user2"}),
+	testRelationTuple("group:developers", "member", domain.RelationSubject{Type: "group", ID: "backend", Relation: "member"}),
+	testRelationTuple("group:backend", "member", domain.RelationSubject{Type: "user", ID: "user1"}),
+	testRelationTuple("document:changelog", "editor", domain.RelationSubject{Type: "project", ID: "project1", Relation: "writer"}),
+	testRelationTuple("document:roadmap", "viewer", domain.RelationSubject{Type: "org", ID: "org2", Relation: "member"}),
+	// cycle
+	testRelationTuple("group:a", "member", domain.RelationSubject{Type: "group", ID: "b", Relation: "member"}),
+	testRelationTuple("group:b", "member", domain.RelationSubject{Type: "group", ID: "a", Relation: "member"}),
+}
+
+func Test_relationResolver_check(t *testing.T) {
+	tests := []struct {
+		name     string
+		object   string
+		relation string
+		userID   string
+		want     bool
+	}{
+		{
+			name:     "nested usersets",
+			object:   "document:readme",
+			relation: "viewer",
+			userID:   "user1",
+			want:     true,
+		},
+		{
+			name:     "project role",
+			object:   "document:changelog",
+			relation: "editor",
+			userID:   "user1",
+			want:     true,
+		},
+		{
+			name:     "other org",
+			object:   "document:roadmap",
+			relation: "viewer",
+			userID:   "user1",
+			want:     false,
+		},
+		{
+			name:     "other relation",
+			object:   "document:readme",
+			relation: "editor",
+			userID:   "user1",
+			want:     false,
+		},
+		{
+			name:     "unknown user",
+			object:   "document:readme",
+			relation: "viewer",
+			userID:   "user2",
+			want:     false,
+		},
+		{
+			name:     "cycle",
+			object:   "group:a",
+			relation: "member",
+			userID:   "user1",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tuple := testRelationTuple(tt.object, tt.relation, domain.RelationSubject{})
+			got, err := testRelationResolver(testRelationTuples...).check(context.Background(), tuple.ObjectType, tuple.ObjectID, tuple.Relation, tt.userID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_relationResolver_listObjects(t *testing.T) {
+	resolver := testRelationResolver(append(testRelationTuples,
+		testRelationTuple("document:license", "viewer", domain.RelationSubject{Type: "org", ID: "org1", Relation: "member"}),
+	)...)
+	got, err := resolver.listObjects(context.Background(), "document", "viewer", "user1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"license", "readme"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	got, err = resolver.listObjects(context.Background(), "group", "member", "user1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"backend", "developers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func Test_relationResolver_expand(t *testing.T) {
+	got, err := testRelationResolver(testRelationTuples...).expand(context.Background(), "document", "readme", "viewer", make(map[string]bool), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &RelationTree{
+		ObjectType: "document",
+		ObjectID:   "readme",
+		Relation:   "viewer",
+		Subjects:   []domain.RelationSubject{{Type: "user", ID: "user2"}},
+		Children: []*RelationTree{
+			{
+				ObjectType: "group",
+				ObjectID:   "developers",
+				Relation:   "member",
+				Subjects:   []domain.RelationSubject{},
+				Children: []*RelationTree{
+					{
+						ObjectType: "group",
+						ObjectID:   "backend",
+						Relation:   "member",
+						Subjects:   []domain.RelationSubject{{Type: "user", ID: "user1"}},
+						Children:   []*RelationTree{},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tree %+v", got)
+	}
+}
@@ -0,0 +1,173 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type RiskPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	MFAOnNewDevice          bool
+	MFAOnNewIPRange         bool
+	ImpossibleTravelWindow  time.Duration
+	FailedAttemptsThreshold uint64
+
+	IsDefault bool
+}
+
+var (
+	riskTable = table{
+		name:          projection.RiskPolicyTable,
+		instanceIDCol: projection.RiskPolicyInstanceIDCol,
+	}
+	RiskColID = Column{
+		name:  projection.RiskPolicyIDCol,
+		table: riskTable,
+	}
+	RiskColInstanceID = Column{
+		name:  projection.RiskPolicyInstanceIDCol,
+		table: riskTable,
+	}
+	RiskColSequence = Column{
+		name:  projection.RiskPolicySequenceCol,
+		table: riskTable,
+	}
+	RiskColCreationDate = Column{
+		name:  projection.RiskPolicyCreationDateCol,
+		table: riskTable,
+	}
+	RiskColChangeDate = Column{
+		name:  projection.RiskPolicyChangeDateCol,
+		table: riskTable,
+	}
+	RiskColResourceOwner = Column{
+		name:  projection.RiskPolicyResourceOwnerCol,
+		table: riskTable,
+	}
+	RiskColMFAOnNewDevice = Column{
+		name:  projection.RiskPolicyMFAOnNewDeviceCol,
+		table: riskTable,
+	}
+	RiskColMFAOnNewIPRange = Column{
+		name:  projection.RiskPolicyMFAOnNewIPRangeCol,
+		table: riskTable,
+	}
+	RiskColImpossibleTravelWindow = Column{
+		name:  projection.RiskPolicyImpossibleTravelWindowCol,
+		table: riskTable,
+	}
+	RiskColFailedAttemptsThreshold = Column{
+		name:  projection.RiskPolicyFailedAttemptsThresholdCol,
+		table: riskTable,
+	}
+	RiskColIsDefault = Column{
+		name:  projection.RiskPolicyIsDefaultCol,
+		table: riskTable,
+	}
+	RiskColState = Column{
+		name:  projection.RiskPolicyStateCol,
+		table: riskTable,
+	}
+)
+
+func (q *Queries) RiskPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (*RiskPolicy, error) {
+	if shouldTriggerBulk {
+		projection.RiskPolicyProjection.Trigger(ctx)
+	}
+
+	stmt, scan := prepareRiskPolicyQuery()
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				RiskColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			},
+			sq.Or{
+				sq.Eq{
+					RiskColID.identifier(): orgID,
+				},
+				sq.Eq{
+					RiskColID.identifier(): authz.GetInstance(ctx).InstanceID(),
+				},
+			},
+		}).
+		OrderBy(RiskColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rk5oD", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultRiskPolicy(ctx context.Context) (*RiskPolicy, error) {
+	stmt, scan := prepareRiskPolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		RiskColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		RiskColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(RiskColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rk6pE", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareRiskPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*RiskPolicy, error)) {
+	return sq.Select(
+			RiskColID.identifier(),
+			RiskColSequence.identifier(),
+			RiskColCreationDate.identifier(),
+			RiskColChangeDate.identifier(),
+			RiskColResourceOwner.identifier(),
+			RiskColMFAOnNewDevice.identifier(),
+			RiskColMFAOnNewIPRange.identifier(),
+			RiskColImpossibleTravelWindow.identifier(),
+			RiskColFailedAttemptsThreshold.identifier(),
+			RiskColIsDefault.identifier(),
+			RiskColState.identifier(),
+		).
+			From(riskTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*RiskPolicy, error) {
+			policy := new(RiskPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.MFAOnNewDevice,
+				&policy.MFAOnNewIPRange,
+				&policy.ImpossibleTravelWindow,
+				&policy.FailedAttemptsThreshold,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Rk7qF", "Errors.Org.RiskPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Rk8rG", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,149 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_RiskPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareRiskPolicyQuery no result",
+			prepare: prepareRiskPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.risk_policies.id,`+
+						` projections.risk_policies.sequence,`+
+						` projections.risk_policies.creation_date,`+
+						` projections.risk_policies.change_date,`+
+						` projections.risk_policies.resource_owner,`+
+						` projections.risk_policies.mfa_on_new_device,`+
+						` projections.risk_policies.mfa_on_new_ip_range,`+
+						` projections.risk_policies.impossible_travel_window,`+
+						` projections.risk_policies.failed_attempts_threshold,`+
+						` projections.risk_policies.is_default,`+
+						` projections.risk_policies.state`+
+						` FROM projections.risk_policies`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*RiskPolicy)(nil),
+		},
+		{
+			name:    "prepareRiskPolicyQuery found",
+			prepare: prepareRiskPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.risk_policies.id,`+
+						` projections.risk_policies.sequence,`+
+						` projections.risk_policies.creation_date,`+
+						` projections.risk_policies.change_date,`+
+						` projections.risk_policies.resource_owner,`+
+						` projections.risk_policies.mfa_on_new_device,`+
+						` projections.risk_policies.mfa_on_new_ip_range,`+
+						` projections.risk_policies.impossible_travel_window,`+
+						` projections.risk_policies.failed_attempts_threshold,`+
+						` projections.risk_policies.is_default,`+
+						` projections.risk_policies.state`+
+						` FROM projections.risk_policies`),
+					[]string{
+						"id",
+						"sequence",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"mfa_on_new_device",
+						"mfa_on_new_ip_range",
+						"impossible_travel_window",
+						"failed_attempts_threshold",
+						"is_default",
+						"state",
+					},
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						true,
+						false,
+						time.Hour,
+						5,
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &RiskPolicy{
+				ID:                      "pol-id",
+				CreationDate:            testNow,
+				ChangeDate:              testNow,
+				Sequence:                20211109,
+				ResourceOwner:           "ro",
+				State:                   domain.PolicyStateActive,
+				MFAOnNewDevice:          true,
+				MFAOnNewIPRange:         false,
+				ImpossibleTravelWindow:  time.Hour,
+				FailedAttemptsThreshold: 5,
+				IsDefault:               true,
+			},
+		},
+		{
+			name:    "prepareRiskPolicyQuery sql err",
+			prepare: prepareRiskPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT projections.risk_policies.id,`+
+						` projections.risk_policies.sequence,`+
+						` projections.risk_policies.creation_date,`+
+						` projections.risk_policies.change_date,`+
+						` projections.risk_policies.resource_owner,`+
+						` projections.risk_policies.mfa_on_new_device,`+
+						` projections.risk_policies.mfa_on_new_ip_range,`+
+						` projections.risk_policies.impossible_travel_window,`+
+						` projections.risk_policies.failed_attempts_threshold,`+
+						` projections.risk_policies.is_default,`+
+						` projections.risk_policies.state`+
+						` FROM projections.risk_policies`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
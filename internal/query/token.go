@@ -0,0 +1,230 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	tokensTable = table{
+		name:          projection.TokenProjectionTable,
+		instanceIDCol: projection.TokenColumnInstanceID,
+	}
+	TokenColumnID = Column{
+		name:  projection.TokenColumnID,
+		table: tokensTable,
+	}
+	TokenColumnCreationDate = Column{
+		name:  projection.TokenColumnCreationDate,
+		table: tokensTable,
+	}
+	TokenColumnChangeDate = Column{
+		name:  projection.TokenColumnChangeDate,
+		table: tokensTable,
+	}
+	TokenColumnSequence = Column{
+		name:  projection.TokenColumnSequence,
+		table: tokensTable,
+	}
+	TokenColumnResourceOwner = Column{
+		name:  projection.TokenColumnResourceOwner,
+		table: tokensTable,
+	}
+	TokenColumnInstanceID = Column{
+		name:  projection.TokenColumnInstanceID,
+		table: tokensTable,
+	}
+	TokenColumnUserID = Column{
+		name:  projection.TokenColumnUserID,
+		table: tokensTable,
+	}
+	TokenColumnApplicationID = Column{
+		name:  projection.TokenColumnApplicationID,
+		table: tokensTable,
+	}
+	TokenColumnUserAgentID = Column{
+		name:  projection.TokenColumnUserAgentID,
+		table: tokensTable,
+	}
+	TokenColumnAudience = Column{
+		name:  projection.TokenColumnAudience,
+		table: tokensTable,
+	}
+	TokenColumnScopes = Column{
+		name:  projection.TokenColumnScopes,
+		table: tokensTable,
+	}
+	TokenColumnExpiration = Column{
+		name:  projection.TokenColumnExpiration,
+		table: tokensTable,
+	}
+	TokenColumnPreferredLanguage = Column{
+		name:  projection.TokenColumnPreferredLanguage,
+		table: tokensTable,
+	}
+	TokenColumnRefreshTokenID = Column{
+		name:  projection.TokenColumnRefreshTokenID,
+		table: tokensTable,
+	}
+	TokenColumnIsPAT = Column{
+		name:  projection.TokenColumnIsPAT,
+		table: tokensTable,
+	}
+	TokenColumnConfirmationJKT = Column{
+		name:  projection.TokenColumnConfirmationJKT,
+		table: tokensTable,
+	}
+	TokenColumnConfirmationX5TS256 = Column{
+		name:  projection.TokenColumnConfirmationX5TS256,
+		table: tokensTable,
+	}
+)
+
+type Token struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	UserID            string
+	ApplicationID     string
+	UserAgentID       string
+	Audience          database.StringArray
+	Scopes            database.StringArray
+	Expiration        time.Time
+	PreferredLanguage string
+	RefreshTokenID    string
+	IsPAT             bool
+	Confirmation      domain.TokenConfirmation
+}
+
+// TokenByIDs returns the access token or personal access token of the user
+func (q *Queries) TokenByIDs(ctx context.Context, tokenID, userID string) (*Token, error) {
+	query, scan := prepareTokenQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		TokenColumnID.identifier():         tokenID,
+		TokenColumnUserID.identifier():     userID,
+		TokenColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mz3lq", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+// SessionClientIDs returns the ids of the clients which were issued tokens in the session of each of the users on the user agent
+func (q *Queries) SessionClientIDs(ctx context.Context, userAgentID string, userIDs []string) (map[string][]string, error) {
+	projection.TokenProjection.Trigger(ctx)
+
+	query, scan := prepareSessionClientIDsQuery()
+	stmt, args, err := query.Where(sq.And{
+		sq.Eq{
+			TokenColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+			TokenColumnUserAgentID.identifier(): userAgentID,
+			TokenColumnUserID.identifier():      userIDs,
+		},
+		sq.NotEq{
+			TokenColumnApplicationID.identifier(): "",
+		},
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Sc3lw", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Sc4kd", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// LatestTokenSequence returns the sequence up to which the tokens are projected
+func (q *Queries) LatestTokenSequence(ctx context.Context) (*LatestSequence, error) {
+	return q.latestSequence(ctx, tokensTable)
+}
+
+func prepareTokenQuery() (sq.SelectBuilder, func(*sql.Row) (*Token, error)) {
+	return sq.Select(
+			TokenColumnID.identifier(),
+			TokenColumnCreationDate.identifier(),
+			TokenColumnChangeDate.identifier(),
+			TokenColumnResourceOwner.identifier(),
+			TokenColumnSequence.identifier(),
+			TokenColumnUserID.identifier(),
+			TokenColumnApplicationID.identifier(),
+			TokenColumnUserAgentID.identifier(),
+			TokenColumnAudience.identifier(),
+			TokenColumnScopes.identifier(),
+			TokenColumnExpiration.identifier(),
+			TokenColumnPreferredLanguage.identifier(),
+			TokenColumnRefreshTokenID.identifier(),
+			TokenColumnIsPAT.identifier(),
+			TokenColumnConfirmationJKT.identifier(),
+			TokenColumnConfirmationX5TS256.identifier()).
+			From(tokensTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Token, error) {
+			t := new(Token)
+			err := row.Scan(
+				&t.ID,
+				&t.CreationDate,
+				&t.ChangeDate,
+				&t.ResourceOwner,
+				&t.Sequence,
+				&t.UserID,
+				&t.ApplicationID,
+				&t.UserAgentID,
+				&t.Audience,
+				&t.Scopes,
+				&t.Expiration,
+				&t.PreferredLanguage,
+				&t.RefreshTokenID,
+				&t.IsPAT,
+				&t.Confirmation.JKT,
+				&t.Confirmation.X5TS256,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Va8rk", "Errors.Token.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Vn1oq", "Errors.Internal")
+			}
+			return t, nil
+		}
+}
+
+func prepareSessionClientIDsQuery() (sq.SelectBuilder, func(*sql.Rows) (map[string][]string, error)) {
+	return sq.Select(
+			TokenColumnUserID.identifier(),
+			TokenColumnApplicationID.identifier()).
+			Distinct().
+			From(tokensTable.identifier()).
+			OrderBy(TokenColumnApplicationID.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (map[string][]string, error) {
+			clientIDs := make(map[string][]string)
+			for rows.Next() {
+				var userID, clientID string
+				if err := rows.Scan(&userID, &clientID); err != nil {
+					return nil, errors.ThrowInternal(err, "QUERY-Sc5ja", "Errors.Internal")
+				}
+				clientIDs[userID] = append(clientIDs[userID], clientID)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Sc6nf", "Errors.Query.CloseRows")
+			}
+			return clientIDs, nil
+		}
+}
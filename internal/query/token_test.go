@@ -0,0 +1,212 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	tokenStmt = regexp.QuoteMeta(
+		"SELECT projections.tokens2.id," +
+			" projections.tokens2.creation_date," +
+			" projections.tokens2.change_date," +
+			" projections.tokens2.resource_owner," +
+			" projections.tokens2.sequence," +
+			" projections.tokens2.user_id," +
+			" projections.tokens2.application_id," +
+			" projections.tokens2.user_agent_id," +
+			" projections.tokens2.audience," +
+			" projections.tokens2.scopes," +
+			" projections.tokens2.expiration," +
+			" projections.tokens2.preferred_language," +
+			" projections.tokens2.refresh_token_id," +
+			" projections.tokens2.is_pat," +
+			" projections.tokens2.cnf_jkt," +
+			" projections.tokens2.cnf_x5t_s256" +
+			" FROM projections.tokens2")
+	tokenCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"application_id",
+		"user_agent_id",
+		"audience",
+		"scopes",
+		"expiration",
+		"preferred_language",
+		"refresh_token_id",
+		"is_pat",
+		"cnf_jkt",
+		"cnf_x5t_s256",
+	}
+	sessionClientIDsStmt = regexp.QuoteMeta(
+		"SELECT DISTINCT projections.tokens2.user_id," +
+			" projections.tokens2.application_id" +
+			" FROM projections.tokens2" +
+			" ORDER BY projections.tokens2.application_id")
+	sessionClientIDsCols = []string{
+		"user_id",
+		"application_id",
+	}
+)
+
+func Test_TokenPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTokenQuery no result",
+			prepare: prepareTokenQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					tokenStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Token)(nil),
+		},
+		{
+			name:    "prepareTokenQuery found",
+			prepare: prepareTokenQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					tokenStmt,
+					tokenCols,
+					[]driver.Value{
+						"token-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211202),
+						"user-id",
+						"client-id",
+						"agent-id",
+						database.StringArray{"aud"},
+						database.StringArray{"openid"},
+						time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+						"de",
+						"refresh-id",
+						false,
+						"jkt",
+						"",
+					},
+				),
+			},
+			object: &Token{
+				ID:                "token-id",
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				ResourceOwner:     "ro",
+				Sequence:          20211202,
+				UserID:            "user-id",
+				ApplicationID:     "client-id",
+				UserAgentID:       "agent-id",
+				Audience:          database.StringArray{"aud"},
+				Scopes:            database.StringArray{"openid"},
+				Expiration:        time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+				PreferredLanguage: "de",
+				RefreshTokenID:    "refresh-id",
+				IsPAT:             false,
+				Confirmation: domain.TokenConfirmation{
+					JKT: "jkt",
+				},
+			},
+		},
+		{
+			name:    "prepareTokenQuery sql err",
+			prepare: prepareTokenQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					tokenStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareSessionClientIDsQuery no result",
+			prepare: prepareSessionClientIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					sessionClientIDsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: map[string][]string{},
+		},
+		{
+			name:    "prepareSessionClientIDsQuery found",
+			prepare: prepareSessionClientIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					sessionClientIDsStmt,
+					sessionClientIDsCols,
+					[][]driver.Value{
+						{"user-1", "client-1"},
+						{"user-2", "client-1"},
+						{"user-1", "client-2"},
+					},
+				),
+			},
+			object: map[string][]string{
+				"user-1": {"client-1", "client-2"},
+				"user-2": {"client-1"},
+			},
+		},
+		{
+			name:    "prepareSessionClientIDsQuery sql err",
+			prepare: prepareSessionClientIDsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					sessionClientIDsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// UserAndGroupGrantsQueries searches the grants of a user together with the grants the user inherits through groups.
+// The queries must be created by the NewUserAndGroupGrant* functions.
+type UserAndGroupGrantsQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *UserAndGroupGrantsQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewUserAndGroupGrantUserIDQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(userAndGroupGrantUserID, id, TextEquals)
+}
+
+// NewUserAndGroupGrantActiveQueries returns the queries which restrict the grants to the active ones valid at the given point in time,
+// grants inherited through groups are always active
+func NewUserAndGroupGrantActiveQueries(now time.Time) ([]SearchQuery, error) {
+	if now.IsZero() {
+		return nil, ErrMissingColumn
+	}
+	stateQuery, err := NewNumberQuery(userAndGroupGrantState, domain.UserGrantStateActive, NumberEquals)
+	if err != nil {
+		return nil, err
+	}
+	effectiveQuery := &userGrantEffectiveQuery{
+		validFrom:  userAndGroupGrantValidFrom,
+		validUntil: userAndGroupGrantValidUntil,
+		now:        now,
+	}
+	return []SearchQuery{stateQuery, effectiveQuery}, nil
+}
+
+// UserAndGroupGrants returns the user grants and the grants inherited through groups in one list,
+// so paging, sorting and the count span both of them.
+// Without a sorting column the grants are sorted by their creation date.
+func (q *Queries) UserAndGroupGrants(ctx context.Context, queries *UserAndGroupGrantsQueries) (grants *UserGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	search := *queries
+	if search.SortingColumn.isZero() {
+		search.SortingColumn = userAndGroupGrantCreationDate
+	}
+	query, scan := prepareUserAndGroupGrantsQuery()
+	stmt, args, err := search.toQuery(query).
+		Where(sq.Eq{
+			userAndGroupGrantInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ug7rt", "Errors.Query.SQLStatement")
+	}
+
+	latestSequence, err := q.latestSequence(ctx, userGrantTable, groupGrantsTable)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ug8su", "Errors.Internal")
+	}
+	grants, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	grants.LatestSequence = latestSequence
+	return grants, nil
+}
+
+var (
+	//userAndGroupGrantsAlias is a hack to satisfy checks in the queries
+	userAndGroupGrantsAlias = table{
+		name:          "user_and_group_grants",
+		instanceIDCol: projection.UserGrantInstanceID,
+	}
+	userAndGroupGrantID = Column{
+		name:  projection.UserGrantID,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantCreationDate = Column{
+		name:  projection.UserGrantCreationDate,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantChangeDate = Column{
+		name:  projection.UserGrantChangeDate,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantSequence = Column{
+		name:  projection.UserGrantSequence,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantGrantID = Column{
+		name:  projection.UserGrantGrantID,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantRoles = Column{
+		name:  projection.UserGrantRoles,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantState = Column{
+		name:  projection.UserGrantState,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantValidFrom = Column{
+		name:  projection.UserGrantValidFrom,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantValidUntil = Column{
+		name:  projection.UserGrantValidUntil,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantUserID = Column{
+		name:  projection.UserGrantUserID,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantResourceOwner = Column{
+		name:  projection.UserGrantResourceOwner,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantInstanceID = Column{
+		name:  projection.UserGrantInstanceID,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantProjectID = Column{
+		name:  projection.UserGrantProjectID,
+		table: userAndGroupGrantsAlias,
+	}
+	userAndGroupGrantGroupID = Column{
+		name:  projection.GroupGrantColumnGroupID,
+		table: userAndGroupGrantsAlias,
+	}
+
+	userAndGroupGrantsFrom = "(" +
+		prepareUserGrantOfUserAndGroupGrants() +
+		" UNION ALL " +
+		prepareGroupGrantOfUserAndGroupGrants() +
+		") AS " + userAndGroupGrantsAlias.identifier()
+)
+
+func prepareUserAndGroupGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, error)) {
+	return sq.Select(
+			userAndGroupGrantID.identifier(),
+			userAndGroupGrantCreationDate.identifier(),
+			userAndGroupGrantChangeDate.identifier(),
+			userAndGroupGrantSequence.identifier(),
+			userAndGroupGrantGrantID.identifier(),
+			userAndGroupGrantRoles.identifier(),
+			userAndGroupGrantState.identifier(),
+			userAndGroupGrantValidFrom.identifier(),
+			userAndGroupGrantValidUntil.identifier(),
+			userAndGroupGrantUserID.identifier(),
+
+			userAndGroupGrantResourceOwner.identifier(),
+			OrgColumnName.identifier(),
+			OrgColumnDomain.identifier(),
+
+			userAndGroupGrantProjectID.identifier(),
+			ProjectColumnName.identifier(),
+
+			userAndGroupGrantGroupID.identifier(),
+			GroupColumnName.identifier(),
+
+			countColumn.identifier(),
+		).
+			From(userAndGroupGrantsFrom).
+			LeftJoin(join(OrgColumnID, userAndGroupGrantResourceOwner)).
+			LeftJoin(join(ProjectColumnID, userAndGroupGrantProjectID)).
+			LeftJoin(join(GroupColumnID, userAndGroupGrantGroupID)).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserGrants, error) {
+			grants := make([]*UserGrant, 0)
+			var count uint64
+			for rows.Next() {
+				g := new(UserGrant)
+				var (
+					validFrom   sql.NullTime
+					validUntil  sql.NullTime
+					orgName     sql.NullString
+					orgDomain   sql.NullString
+					projectName sql.NullString
+					groupID     sql.NullString
+					groupName   sql.NullString
+				)
+				err := rows.Scan(
+					&g.ID,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.GrantID,
+					&g.Roles,
+					&g.State,
+					&validFrom,
+					&validUntil,
+					&g.UserID,
+
+					&g.ResourceOwner,
+					&orgName,
+					&orgDomain,
+
+					&g.ProjectID,
+					&projectName,
+
+					&groupID,
+					&groupName,
+
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				g.ValidFrom = validFrom.Time
+				g.ValidUntil = validUntil.Time
+				g.OrgName = orgName.String
+				g.OrgPrimaryDomain = orgDomain.String
+				g.ProjectName = projectName.String
+				g.GroupID = groupID.String
+				g.GroupName = groupName.String
+				grants = append(grants, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ug9tv", "Errors.Query.CloseRows")
+			}
+
+			return &UserGrants{
+				UserGrants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareUserGrantOfUserAndGroupGrants() string {
+	stmt, _ := sq.Select(
+		UserGrantID.identifier(),
+		UserGrantCreationDate.identifier(),
+		UserGrantChangeDate.identifier(),
+		UserGrantSequence.identifier(),
+		UserGrantGrantID.identifier(),
+		UserGrantRoles.identifier(),
+		UserGrantState.identifier(),
+		UserGrantValidFrom.identifier(),
+		UserGrantValidUntil.identifier(),
+		UserGrantUserID.identifier(),
+		UserGrantResourceOwner.identifier(),
+		UserGrantInstanceID.identifier(),
+		UserGrantProjectID.identifier(),
+		"NULL::TEXT AS "+userAndGroupGrantGroupID.name,
+	).From(userGrantTable.identifier()).MustSql()
+	return stmt
+}
+
+func prepareGroupGrantOfUserAndGroupGrants() string {
+	stmt, _ := sq.Select(
+		GroupGrantColumnID.identifier(),
+		GroupGrantColumnCreationDate.identifier(),
+		GroupGrantColumnChangeDate.identifier(),
+		GroupGrantColumnSequence.identifier(),
+		GroupGrantColumnGrantID.identifier(),
+		GroupGrantColumnRoleKeys.identifier()+" AS "+userAndGroupGrantRoles.name,
+		strconv.Itoa(int(domain.UserGrantStateActive))+"::SMALLINT AS "+userAndGroupGrantState.name,
+		"NULL::TIMESTAMPTZ AS "+userAndGroupGrantValidFrom.name,
+		"NULL::TIMESTAMPTZ AS "+userAndGroupGrantValidUntil.name,
+		GroupMemberColumnUserID.identifier(),
+		GroupGrantColumnResourceOwner.identifier(),
+		GroupGrantColumnInstanceID.identifier(),
+		GroupGrantColumnProjectID.identifier(),
+		GroupGrantColumnGroupID.identifier(),
+	).From(groupGrantsTable.identifier()).
+		Join(join(GroupMemberColumnGroupID, GroupGrantColumnGroupID)).
+		MustSql()
+	return stmt
+}
@@ -0,0 +1,229 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	userAndGroupGrantsStmt = regexp.QuoteMeta(
+		"SELECT user_and_group_grants.id" +
+			", user_and_group_grants.creation_date" +
+			", user_and_group_grants.change_date" +
+			", user_and_group_grants.sequence" +
+			", user_and_group_grants.grant_id" +
+			", user_and_group_grants.roles" +
+			", user_and_group_grants.state" +
+			", user_and_group_grants.valid_from" +
+			", user_and_group_grants.valid_until" +
+			", user_and_group_grants.user_id" +
+			", user_and_group_grants.resource_owner" +
+			", projections.orgs.name" +
+			", projections.orgs.primary_domain" +
+			", user_and_group_grants.project_id" +
+			", projections.projects2.name" +
+			", user_and_group_grants.group_id" +
+			", projections.groups.name" +
+			", COUNT(*) OVER ()" +
+			" FROM (" +
+			"SELECT projections.user_grants3.id" +
+			", projections.user_grants3.creation_date" +
+			", projections.user_grants3.change_date" +
+			", projections.user_grants3.sequence" +
+			", projections.user_grants3.grant_id" +
+			", projections.user_grants3.roles" +
+			", projections.user_grants3.state" +
+			", projections.user_grants3.valid_from" +
+			", projections.user_grants3.valid_until" +
+			", projections.user_grants3.user_id" +
+			", projections.user_grants3.resource_owner" +
+			", projections.user_grants3.instance_id" +
+			", projections.user_grants3.project_id" +
+			", NULL::TEXT AS group_id" +
+			" FROM projections.user_grants3" +
+			" UNION ALL " +
+			"SELECT projections.groups_grants.id" +
+			", projections.groups_grants.creation_date" +
+			", projections.groups_grants.change_date" +
+			", projections.groups_grants.sequence" +
+			", projections.groups_grants.grant_id" +
+			", projections.groups_grants.role_keys AS roles" +
+			", 1::SMALLINT AS state" +
+			", NULL::TIMESTAMPTZ AS valid_from" +
+			", NULL::TIMESTAMPTZ AS valid_until" +
+			", projections.groups_members.user_id" +
+			", projections.groups_grants.resource_owner" +
+			", projections.groups_grants.instance_id" +
+			", projections.groups_grants.project_id" +
+			", projections.groups_grants.group_id" +
+			" FROM projections.groups_grants" +
+			" JOIN projections.groups_members ON projections.groups_grants.group_id = projections.groups_members.group_id AND projections.groups_grants.instance_id = projections.groups_members.instance_id" +
+			") AS user_and_group_grants" +
+			" LEFT JOIN projections.orgs ON user_and_group_grants.resource_owner = projections.orgs.id AND user_and_group_grants.instance_id = projections.orgs.instance_id" +
+			" LEFT JOIN projections.projects2 ON user_and_group_grants.project_id = projections.projects2.id AND user_and_group_grants.instance_id = projections.projects2.instance_id" +
+			" LEFT JOIN projections.groups ON user_and_group_grants.group_id = projections.groups.id AND user_and_group_grants.instance_id = projections.groups.instance_id")
+	userAndGroupGrantsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"grant_id",
+		"roles",
+		"state",
+		"valid_from",
+		"valid_until",
+		"user_id",
+		"resource_owner",
+		"name", //org name
+		"primary_domain",
+		"project_id",
+		"name", //project name
+		"group_id",
+		"name", //group name
+		"count",
+	}
+)
+
+func Test_UserAndGroupGrantPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserAndGroupGrantsQuery no result",
+			prepare: prepareUserAndGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userAndGroupGrantsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &UserGrants{UserGrants: []*UserGrant{}},
+		},
+		{
+			name:    "prepareUserAndGroupGrantsQuery user and group grant",
+			prepare: prepareUserAndGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userAndGroupGrantsStmt,
+					userAndGroupGrantsCols,
+					[][]driver.Value{
+						{
+							"user-grant-id",
+							testNow,
+							testNow,
+							20211111,
+							"grant-id",
+							database.StringArray{"role-key"},
+							domain.UserGrantStateActive,
+							testNow,
+							nil,
+							"user-id",
+							"ro",
+							"org-name",
+							"primary-domain",
+							"project-id",
+							"project-name",
+							nil,
+							nil,
+						},
+						{
+							"group-grant-id",
+							testNow,
+							testNow,
+							20211112,
+							"",
+							database.StringArray{"role-key"},
+							domain.UserGrantStateActive,
+							nil,
+							nil,
+							"user-id",
+							"ro",
+							"org-name",
+							"primary-domain",
+							"project-id",
+							"project-name",
+							"group-id",
+							"group-name",
+						},
+					},
+				),
+			},
+			object: &UserGrants{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				UserGrants: []*UserGrant{
+					{
+						ID:               "user-grant-id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211111,
+						GrantID:          "grant-id",
+						Roles:            database.StringArray{"role-key"},
+						State:            domain.UserGrantStateActive,
+						ValidFrom:        testNow,
+						UserID:           "user-id",
+						ResourceOwner:    "ro",
+						OrgName:          "org-name",
+						OrgPrimaryDomain: "primary-domain",
+						ProjectID:        "project-id",
+						ProjectName:      "project-name",
+					},
+					{
+						ID:               "group-grant-id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211112,
+						Roles:            database.StringArray{"role-key"},
+						State:            domain.UserGrantStateActive,
+						UserID:           "user-id",
+						ResourceOwner:    "ro",
+						OrgName:          "org-name",
+						OrgPrimaryDomain: "primary-domain",
+						ProjectID:        "project-id",
+						ProjectName:      "project-name",
+						GroupID:          "group-id",
+						GroupName:        "group-name",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserAndGroupGrantsQuery sql err",
+			prepare: prepareUserAndGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userAndGroupGrantsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
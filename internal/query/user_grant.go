@@ -23,6 +23,8 @@ type UserGrant struct {
 	Roles        database.StringArray
 	GrantID      string
 	State        domain.UserGrantState
+	ValidFrom    time.Time
+	ValidUntil   time.Time
 
 	UserID             string
 	Username           string
@@ -41,6 +43,10 @@ type UserGrant struct {
 
 	ProjectID   string
 	ProjectName string
+
+	// GroupID and GroupName are set if the grant is inherited through the membership of a group
+	GroupID   string
+	GroupName string
 }
 
 type UserGrants struct {
@@ -121,6 +127,59 @@ func NewUserGrantRoleQuery(value string) (SearchQuery, error) {
 	return NewTextQuery(UserGrantRoles, value, TextListContains)
 }
 
+func NewUserGrantStateQuery(state domain.UserGrantState) (SearchQuery, error) {
+	return NewNumberQuery(UserGrantState, state, NumberEquals)
+}
+
+// NewUserGrantEffectiveQuery only returns user grants which are valid at the given point in time
+func NewUserGrantEffectiveQuery(now time.Time) (SearchQuery, error) {
+	if now.IsZero() {
+		return nil, ErrMissingColumn
+	}
+	return &userGrantEffectiveQuery{
+		validFrom:  UserGrantValidFrom,
+		validUntil: UserGrantValidUntil,
+		now:        now,
+	}, nil
+}
+
+type userGrantEffectiveQuery struct {
+	validFrom  Column
+	validUntil Column
+	now        time.Time
+}
+
+func (q *userGrantEffectiveQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *userGrantEffectiveQuery) comp() sq.Sqlizer {
+	return sq.And{
+		sq.Or{
+			sq.Eq{q.validFrom.identifier(): nil},
+			sq.LtOrEq{q.validFrom.identifier(): q.now},
+		},
+		sq.Or{
+			sq.Eq{q.validUntil.identifier(): nil},
+			sq.Gt{q.validUntil.identifier(): q.now},
+		},
+	}
+}
+
+// NewUserGrantActiveQueries returns the queries which restrict the user grants to the active ones valid at the given point in time,
+// so expired grants don't grant any role even before they are deactivated
+func NewUserGrantActiveQueries(now time.Time) ([]SearchQuery, error) {
+	stateQuery, err := NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	effectiveQuery, err := NewUserGrantEffectiveQuery(now)
+	if err != nil {
+		return nil, err
+	}
+	return []SearchQuery{stateQuery, effectiveQuery}, nil
+}
+
 func NewUserGrantWithGrantedQuery(owner string) (SearchQuery, error) {
 	orgQuery, err := NewUserGrantResourceOwnerSearchQuery(owner)
 	if err != nil {
@@ -190,6 +249,14 @@ var (
 		name:  projection.UserGrantState,
 		table: userGrantTable,
 	}
+	UserGrantValidFrom = Column{
+		name:  projection.UserGrantValidFrom,
+		table: userGrantTable,
+	}
+	UserGrantValidUntil = Column{
+		name:  projection.UserGrantValidUntil,
+		table: userGrantTable,
+	}
 )
 
 func (q *Queries) UserGrant(ctx context.Context, shouldTriggerBulk bool, queries ...SearchQuery) (*UserGrant, error) {
@@ -250,6 +317,8 @@ func prepareUserGrantQuery() (sq.SelectBuilder, func(*sql.Row) (*UserGrant, erro
 			UserGrantGrantID.identifier(),
 			UserGrantRoles.identifier(),
 			UserGrantState.identifier(),
+			UserGrantValidFrom.identifier(),
+			UserGrantValidUntil.identifier(),
 
 			UserGrantUserID.identifier(),
 			UserUsernameCol.identifier(),
@@ -282,6 +351,9 @@ func prepareUserGrantQuery() (sq.SelectBuilder, func(*sql.Row) (*UserGrant, erro
 			g := new(UserGrant)
 
 			var (
+				validFrom  sql.NullTime
+				validUntil sql.NullTime
+
 				username           sql.NullString
 				firstName          sql.NullString
 				userType           sql.NullInt32
@@ -306,6 +378,8 @@ func prepareUserGrantQuery() (sq.SelectBuilder, func(*sql.Row) (*UserGrant, erro
 				&g.GrantID,
 				&g.Roles,
 				&g.State,
+				&validFrom,
+				&validUntil,
 
 				&g.UserID,
 				&username,
@@ -332,6 +406,8 @@ func prepareUserGrantQuery() (sq.SelectBuilder, func(*sql.Row) (*UserGrant, erro
 				return nil, errors.ThrowInternal(err, "QUERY-oQPcP", "Errors.Internal")
 			}
 
+			g.ValidFrom = validFrom.Time
+			g.ValidUntil = validUntil.Time
 			g.Username = username.String
 			g.UserType = domain.UserType(userType.Int32)
 			g.UserResourceOwner = userOwner.String
@@ -358,6 +434,8 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 			UserGrantGrantID.identifier(),
 			UserGrantRoles.identifier(),
 			UserGrantState.identifier(),
+			UserGrantValidFrom.identifier(),
+			UserGrantValidUntil.identifier(),
 
 			UserGrantUserID.identifier(),
 			UserUsernameCol.identifier(),
@@ -395,6 +473,9 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 				g := new(UserGrant)
 
 				var (
+					validFrom  sql.NullTime
+					validUntil sql.NullTime
+
 					username           sql.NullString
 					userType           sql.NullInt32
 					userOwner          sql.NullString
@@ -419,6 +500,8 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 					&g.GrantID,
 					&g.Roles,
 					&g.State,
+					&validFrom,
+					&validUntil,
 
 					&g.UserID,
 					&username,
@@ -444,6 +527,8 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 					return nil, err
 				}
 
+				g.ValidFrom = validFrom.Time
+				g.ValidUntil = validUntil.Time
 				g.Username = username.String
 				g.UserType = domain.UserType(userType.Int32)
 				g.UserResourceOwner = userOwner.String
@@ -5,8 +5,10 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -15,14 +17,16 @@ import (
 
 var (
 	userGrantStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants2.id" +
-			", projections.user_grants2.creation_date" +
-			", projections.user_grants2.change_date" +
-			", projections.user_grants2.sequence" +
-			", projections.user_grants2.grant_id" +
-			", projections.user_grants2.roles" +
-			", projections.user_grants2.state" +
-			", projections.user_grants2.user_id" +
+		"SELECT projections.user_grants3.id" +
+			", projections.user_grants3.creation_date" +
+			", projections.user_grants3.change_date" +
+			", projections.user_grants3.sequence" +
+			", projections.user_grants3.grant_id" +
+			", projections.user_grants3.roles" +
+			", projections.user_grants3.state" +
+			", projections.user_grants3.valid_from" +
+			", projections.user_grants3.valid_until" +
+			", projections.user_grants3.user_id" +
 			", projections.users5.username" +
 			", projections.users5.type" +
 			", projections.users5.resource_owner" +
@@ -32,17 +36,17 @@ var (
 			", projections.users5_humans.display_name" +
 			", projections.users5_humans.avatar_key" +
 			", projections.login_names.login_name" +
-			", projections.user_grants2.resource_owner" +
+			", projections.user_grants3.resource_owner" +
 			", projections.orgs.name" +
 			", projections.orgs.primary_domain" +
-			", projections.user_grants2.project_id" +
+			", projections.user_grants3.project_id" +
 			", projections.projects2.name" +
-			" FROM projections.user_grants2" +
-			" LEFT JOIN projections.users5 ON projections.user_grants2.user_id = projections.users5.id AND projections.user_grants2.instance_id = projections.users5.instance_id" +
-			" LEFT JOIN projections.users5_humans ON projections.user_grants2.user_id = projections.users5_humans.user_id AND projections.user_grants2.instance_id = projections.users5_humans.instance_id" +
-			" LEFT JOIN projections.orgs ON projections.user_grants2.resource_owner = projections.orgs.id AND projections.user_grants2.instance_id = projections.orgs.instance_id" +
-			" LEFT JOIN projections.projects2 ON projections.user_grants2.project_id = projections.projects2.id AND projections.user_grants2.instance_id = projections.projects2.instance_id" +
-			" LEFT JOIN projections.login_names ON projections.user_grants2.user_id = projections.login_names.user_id AND projections.user_grants2.instance_id = projections.login_names.instance_id" +
+			" FROM projections.user_grants3" +
+			" LEFT JOIN projections.users5 ON projections.user_grants3.user_id = projections.users5.id AND projections.user_grants3.instance_id = projections.users5.instance_id" +
+			" LEFT JOIN projections.users5_humans ON projections.user_grants3.user_id = projections.users5_humans.user_id AND projections.user_grants3.instance_id = projections.users5_humans.instance_id" +
+			" LEFT JOIN projections.orgs ON projections.user_grants3.resource_owner = projections.orgs.id AND projections.user_grants3.instance_id = projections.orgs.instance_id" +
+			" LEFT JOIN projections.projects2 ON projections.user_grants3.project_id = projections.projects2.id AND projections.user_grants3.instance_id = projections.projects2.instance_id" +
+			" LEFT JOIN projections.login_names ON projections.user_grants3.user_id = projections.login_names.user_id AND projections.user_grants3.instance_id = projections.login_names.instance_id" +
 			" WHERE projections.login_names.is_primary = $1")
 	userGrantCols = []string{
 		"id",
@@ -52,6 +56,8 @@ var (
 		"grant_id",
 		"roles",
 		"state",
+		"valid_from",
+		"valid_until",
 		"user_id",
 		"username",
 		"type",
@@ -69,14 +75,16 @@ var (
 		"name", //project name
 	}
 	userGrantsStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants2.id" +
-			", projections.user_grants2.creation_date" +
-			", projections.user_grants2.change_date" +
-			", projections.user_grants2.sequence" +
-			", projections.user_grants2.grant_id" +
-			", projections.user_grants2.roles" +
-			", projections.user_grants2.state" +
-			", projections.user_grants2.user_id" +
+		"SELECT projections.user_grants3.id" +
+			", projections.user_grants3.creation_date" +
+			", projections.user_grants3.change_date" +
+			", projections.user_grants3.sequence" +
+			", projections.user_grants3.grant_id" +
+			", projections.user_grants3.roles" +
+			", projections.user_grants3.state" +
+			", projections.user_grants3.valid_from" +
+			", projections.user_grants3.valid_until" +
+			", projections.user_grants3.user_id" +
 			", projections.users5.username" +
 			", projections.users5.type" +
 			", projections.users5.resource_owner" +
@@ -86,18 +94,18 @@ var (
 			", projections.users5_humans.display_name" +
 			", projections.users5_humans.avatar_key" +
 			", projections.login_names.login_name" +
-			", projections.user_grants2.resource_owner" +
+			", projections.user_grants3.resource_owner" +
 			", projections.orgs.name" +
 			", projections.orgs.primary_domain" +
-			", projections.user_grants2.project_id" +
+			", projections.user_grants3.project_id" +
 			", projections.projects2.name" +
 			", COUNT(*) OVER ()" +
-			" FROM projections.user_grants2" +
-			" LEFT JOIN projections.users5 ON projections.user_grants2.user_id = projections.users5.id AND projections.user_grants2.instance_id = projections.users5.instance_id" +
-			" LEFT JOIN projections.users5_humans ON projections.user_grants2.user_id = projections.users5_humans.user_id AND projections.user_grants2.instance_id = projections.users5_humans.instance_id" +
-			" LEFT JOIN projections.orgs ON projections.user_grants2.resource_owner = projections.orgs.id AND projections.user_grants2.instance_id = projections.orgs.instance_id" +
-			" LEFT JOIN projections.projects2 ON projections.user_grants2.project_id = projections.projects2.id AND projections.user_grants2.instance_id = projections.projects2.instance_id" +
-			" LEFT JOIN projections.login_names ON projections.user_grants2.user_id = projections.login_names.user_id AND projections.user_grants2.instance_id = projections.login_names.instance_id" +
+			" FROM projections.user_grants3" +
+			" LEFT JOIN projections.users5 ON projections.user_grants3.user_id = projections.users5.id AND projections.user_grants3.instance_id = projections.users5.instance_id" +
+			" LEFT JOIN projections.users5_humans ON projections.user_grants3.user_id = projections.users5_humans.user_id AND projections.user_grants3.instance_id = projections.users5_humans.instance_id" +
+			" LEFT JOIN projections.orgs ON projections.user_grants3.resource_owner = projections.orgs.id AND projections.user_grants3.instance_id = projections.orgs.instance_id" +
+			" LEFT JOIN projections.projects2 ON projections.user_grants3.project_id = projections.projects2.id AND projections.user_grants3.instance_id = projections.projects2.instance_id" +
+			" LEFT JOIN projections.login_names ON projections.user_grants3.user_id = projections.login_names.user_id AND projections.user_grants3.instance_id = projections.login_names.instance_id" +
 			" WHERE projections.login_names.is_primary = $1")
 	userGrantsCols = append(
 		userGrantCols,
@@ -149,6 +157,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.StringArray{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -207,6 +217,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.StringArray{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeMachine,
@@ -265,6 +277,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.StringArray{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -323,6 +337,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.StringArray{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -381,6 +397,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.StringArray{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -469,6 +487,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -536,6 +556,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeMachine,
@@ -603,6 +625,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeMachine,
@@ -670,6 +694,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -737,6 +763,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -804,6 +832,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -828,6 +858,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.StringArray{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -927,3 +959,22 @@ func Test_UserGrantPrepares(t *testing.T) {
 		})
 	}
 }
+
+func Test_userGrantEffectiveQuery_comp(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	query, err := NewUserGrantEffectiveQuery(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmt, args, err := query.comp().ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantStmt := "((projections.user_grants3.valid_from IS NULL OR projections.user_grants3.valid_from <= ?) AND (projections.user_grants3.valid_until IS NULL OR projections.user_grants3.valid_until > ?))"
+	if stmt != wantStmt {
+		t.Errorf("wrong stmt: want %q got %q", wantStmt, stmt)
+	}
+	if !reflect.DeepEqual(args, []interface{}{now, now}) {
+		t.Errorf("wrong args: got %v", args)
+	}
+}
@@ -95,6 +95,35 @@ func NewMembershipIsIAMQuery() (SearchQuery, error) {
 	return NewNotNullQuery(membershipIAMID)
 }
 
+// NewMembershipEffectiveQuery only returns memberships which are valid at the given point in time
+func NewMembershipEffectiveQuery(now time.Time) (SearchQuery, error) {
+	if now.IsZero() {
+		return nil, ErrMissingColumn
+	}
+	return &membershipEffectiveQuery{now: now}, nil
+}
+
+type membershipEffectiveQuery struct {
+	now time.Time
+}
+
+func (q *membershipEffectiveQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *membershipEffectiveQuery) comp() sq.Sqlizer {
+	return sq.And{
+		sq.Or{
+			sq.Eq{membershipValidFrom.identifier(): nil},
+			sq.LtOrEq{membershipValidFrom.identifier(): q.now},
+		},
+		sq.Or{
+			sq.Eq{membershipValidUntil.identifier(): nil},
+			sq.Gt{membershipValidUntil.identifier(): q.now},
+		},
+	}
+}
+
 func (q *MembershipSearchQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
 	query = q.SearchRequest.toQuery(query)
 	for _, q := range q.Queries {
@@ -179,6 +208,14 @@ var (
 		name:  projection.ProjectGrantMemberGrantIDCol,
 		table: membershipAlias,
 	}
+	membershipValidFrom = Column{
+		name:  projection.MemberValidFrom,
+		table: membershipAlias,
+	}
+	membershipValidUntil = Column{
+		name:  projection.MemberValidUntil,
+		table: membershipAlias,
+	}
 	membershipGrantGrantedOrgID = Column{
 		name:  projection.ProjectGrantColumnGrantedOrgID,
 		table: membershipAlias,
@@ -306,6 +343,8 @@ func prepareOrgMember() string {
 		"NULL::TEXT AS "+membershipIAMID.name,
 		"NULL::TEXT AS "+membershipProjectID.name,
 		"NULL::TEXT AS "+membershipGrantID.name,
+		OrgMemberValidFrom.identifier(),
+		OrgMemberValidUntil.identifier(),
 	).From(orgMemberTable.identifier()).MustSql()
 	return stmt
 }
@@ -323,6 +362,8 @@ func prepareIAMMember() string {
 		InstanceMemberIAMID.identifier(),
 		"NULL::TEXT AS "+membershipProjectID.name,
 		"NULL::TEXT AS "+membershipGrantID.name,
+		"NULL::TIMESTAMPTZ AS "+membershipValidFrom.name,
+		"NULL::TIMESTAMPTZ AS "+membershipValidUntil.name,
 	).From(instanceMemberTable.identifier()).MustSql()
 	return stmt
 }
@@ -340,6 +381,8 @@ func prepareProjectMember() string {
 		"NULL::TEXT AS "+membershipIAMID.name,
 		ProjectMemberProjectID.identifier(),
 		"NULL::TEXT AS "+membershipGrantID.name,
+		ProjectMemberValidFrom.identifier(),
+		ProjectMemberValidUntil.identifier(),
 	).From(projectMemberTable.identifier()).MustSql()
 
 	return stmt
@@ -358,6 +401,8 @@ func prepareProjectGrantMember() string {
 		"NULL::TEXT AS "+membershipIAMID.name,
 		ProjectGrantMemberProjectID.identifier(),
 		ProjectGrantMemberGrantID.identifier(),
+		"NULL::TIMESTAMPTZ AS "+membershipValidFrom.name,
+		"NULL::TIMESTAMPTZ AS "+membershipValidUntil.name,
 	).From(projectGrantMemberTable.identifier()).
 		MustSql()
 
@@ -5,8 +5,10 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/database"
 )
@@ -39,7 +41,9 @@ var (
 			", NULL::TEXT AS id" +
 			", NULL::TEXT AS project_id" +
 			", NULL::TEXT AS grant_id" +
-			" FROM projections.org_members2 AS members" +
+			", members.valid_from" +
+			", members.valid_until" +
+			" FROM projections.org_members3 AS members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
 			", members.roles" +
@@ -52,6 +56,8 @@ var (
 			", members.id" +
 			", NULL::TEXT AS project_id" +
 			", NULL::TEXT AS grant_id" +
+			", NULL::TIMESTAMPTZ AS valid_from" +
+			", NULL::TIMESTAMPTZ AS valid_until" +
 			" FROM projections.instance_members2 AS members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
@@ -65,7 +71,9 @@ var (
 			", NULL::TEXT AS id" +
 			", members.project_id" +
 			", NULL::TEXT AS grant_id" +
-			" FROM projections.project_members2 AS members" +
+			", members.valid_from" +
+			", members.valid_until" +
+			" FROM projections.project_members3 AS members" +
 			" UNION ALL " +
 			"SELECT members.user_id" +
 			", members.roles" +
@@ -78,6 +86,8 @@ var (
 			", NULL::TEXT AS id" +
 			", members.project_id" +
 			", members.grant_id" +
+			", NULL::TIMESTAMPTZ AS valid_from" +
+			", NULL::TIMESTAMPTZ AS valid_until" +
 			" FROM projections.project_grant_members2 AS members" +
 			") AS memberships" +
 			" LEFT JOIN projections.projects2 ON memberships.project_id = projections.projects2.id AND memberships.instance_id = projections.projects2.instance_id" +
@@ -445,3 +455,22 @@ func Test_MembershipPrepares(t *testing.T) {
 		})
 	}
 }
+
+func Test_membershipEffectiveQuery_comp(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	query, err := NewMembershipEffectiveQuery(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmt, args, err := query.comp().ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantStmt := "((memberships.valid_from IS NULL OR memberships.valid_from <= ?) AND (memberships.valid_until IS NULL OR memberships.valid_until > ?))"
+	if stmt != wantStmt {
+		t.Errorf("wrong stmt: want %q got %q", wantStmt, stmt)
+	}
+	if !reflect.DeepEqual(args, []interface{}{now, now}) {
+		t.Errorf("wrong args: got %v", args)
+	}
+}
@@ -44,6 +44,18 @@ func (q *Queries) GetHumanPassword(ctx context.Context, orgID, userID string) (p
 	return nil, "", nil
 }
 
+// PasswordCheckFailedCount returns the amount of failed password checks since the last successful check, change or unlock
+func (q *Queries) PasswordCheckFailedCount(ctx context.Context, orgID, userID string) (uint64, error) {
+	if userID == "" {
+		return 0, caos_errs.ThrowInvalidArgument(nil, "QUERY-Cp6tz", "Errors.User.UserIDMissing")
+	}
+	existingPassword, err := q.passwordWriteModel(ctx, userID, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return existingPassword.PasswordCheckFailedCount, nil
+}
+
 func (q *Queries) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
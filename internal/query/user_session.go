@@ -0,0 +1,304 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	userSessionsTable = table{
+		name:          projection.UserSessionProjectionTable,
+		instanceIDCol: projection.UserSessionColumnInstanceID,
+	}
+	UserSessionColumnUserAgentID = Column{
+		name:  projection.UserSessionColumnUserAgentID,
+		table: userSessionsTable,
+	}
+	UserSessionColumnUserID = Column{
+		name:  projection.UserSessionColumnUserID,
+		table: userSessionsTable,
+	}
+	UserSessionColumnInstanceID = Column{
+		name:  projection.UserSessionColumnInstanceID,
+		table: userSessionsTable,
+	}
+	UserSessionColumnCreationDate = Column{
+		name:  projection.UserSessionColumnCreationDate,
+		table: userSessionsTable,
+	}
+	UserSessionColumnChangeDate = Column{
+		name:  projection.UserSessionColumnChangeDate,
+		table: userSessionsTable,
+	}
+	UserSessionColumnSequence = Column{
+		name:  projection.UserSessionColumnSequence,
+		table: userSessionsTable,
+	}
+	UserSessionColumnResourceOwner = Column{
+		name:  projection.UserSessionColumnResourceOwner,
+		table: userSessionsTable,
+	}
+	UserSessionColumnState = Column{
+		name:  projection.UserSessionColumnState,
+		table: userSessionsTable,
+	}
+	UserSessionColumnSelectedIDPConfigID = Column{
+		name:  projection.UserSessionColumnSelectedIDPConfigID,
+		table: userSessionsTable,
+	}
+	UserSessionColumnPasswordVerification = Column{
+		name:  projection.UserSessionColumnPasswordVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnPasswordlessVerification = Column{
+		name:  projection.UserSessionColumnPasswordlessVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnMagicLinkVerification = Column{
+		name:  projection.UserSessionColumnMagicLinkVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnExternalLoginVerification = Column{
+		name:  projection.UserSessionColumnExternalLoginVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnSecondFactorVerification = Column{
+		name:  projection.UserSessionColumnSecondFactorVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnSecondFactorVerificationType = Column{
+		name:  projection.UserSessionColumnSecondFactorVerificationType,
+		table: userSessionsTable,
+	}
+	UserSessionColumnMultiFactorVerification = Column{
+		name:  projection.UserSessionColumnMultiFactorVerification,
+		table: userSessionsTable,
+	}
+	UserSessionColumnMultiFactorVerificationType = Column{
+		name:  projection.UserSessionColumnMultiFactorVerificationType,
+		table: userSessionsTable,
+	}
+)
+
+type UserSessions struct {
+	SearchResponse
+	UserSessions []*UserSession
+}
+
+type UserSession struct {
+	UserAgentID   string
+	UserID        string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	State         domain.UserSessionState
+
+	UserName                     string
+	LoginName                    string
+	DisplayName                  string
+	AvatarKey                    string
+	SelectedIDPConfigID          string
+	PasswordVerification         time.Time
+	PasswordlessVerification     time.Time
+	MagicLinkVerification        time.Time
+	ExternalLoginVerification    time.Time
+	SecondFactorVerification     time.Time
+	SecondFactorVerificationType domain.MFAType
+	MultiFactorVerification      time.Time
+	MultiFactorVerificationType  domain.MFAType
+}
+
+// UserSessionByIDs returns the session of the user on the given user agent
+func (q *Queries) UserSessionByIDs(ctx context.Context, agentID, userID string) (*UserSession, error) {
+	query, scan := prepareUserSessionsQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		UserSessionColumnUserAgentID.identifier(): agentID,
+		UserSessionColumnUserID.identifier():      userID,
+		UserSessionColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Hc9mz", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pn3ew", "Errors.Internal")
+	}
+	sessions, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions.UserSessions) != 1 {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Yx7rt", "Errors.UserSession.NotFound")
+	}
+	return sessions.UserSessions[0], nil
+}
+
+// UserSessionsByAgentID returns all sessions of the given user agent
+func (q *Queries) UserSessionsByAgentID(ctx context.Context, agentID string) (*UserSessions, error) {
+	query, scan := prepareUserSessionsQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		UserSessionColumnUserAgentID.identifier(): agentID,
+		UserSessionColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gm2sa", "Errors.Query.SQLStatment")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ud4kq", "Errors.Internal")
+	}
+	sessions, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	sessions.LatestSequence, err = q.latestSequence(ctx, userSessionsTable)
+	return sessions, err
+}
+
+// ActiveUserSessionsCount returns the amount of active sessions over all instances
+func (q *Queries) ActiveUserSessionsCount(ctx context.Context) (count uint64, err error) {
+	stmt, args, err := sq.Select(countColumn.identifier()).
+		From(userSessionsTable.identifier()).
+		Where(sq.Eq{
+			UserSessionColumnState.identifier(): domain.UserSessionStateActive,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "QUERY-Dv8pw", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, stmt, args...).Scan(&count)
+	if err != nil {
+		return 0, errors.ThrowInternal(err, "QUERY-Nh5eo", "Errors.Internal")
+	}
+	return count, nil
+}
+
+func prepareUserSessionsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserSessions, error)) {
+	preferredLoginNameQuery, preferredLoginNameArgs, err := sq.Select(
+		userPreferredLoginNameUserIDCol.identifier(),
+		userPreferredLoginNameCol.identifier(),
+		userPreferredLoginNameInstanceIDCol.identifier()).
+		From(userPreferredLoginNameTable.identifier()).
+		Where(
+			sq.Eq{
+				userPreferredLoginNameIsPrimaryCol.identifier(): true,
+			}).
+		ToSql()
+	if err != nil {
+		return sq.SelectBuilder{}, nil
+	}
+	return sq.Select(
+			UserSessionColumnUserAgentID.identifier(),
+			UserSessionColumnUserID.identifier(),
+			UserSessionColumnCreationDate.identifier(),
+			UserSessionColumnChangeDate.identifier(),
+			UserSessionColumnResourceOwner.identifier(),
+			UserSessionColumnSequence.identifier(),
+			UserSessionColumnState.identifier(),
+			UserUsernameCol.identifier(),
+			userPreferredLoginNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			HumanAvatarURLCol.identifier(),
+			MachineNameCol.identifier(),
+			UserSessionColumnSelectedIDPConfigID.identifier(),
+			UserSessionColumnPasswordVerification.identifier(),
+			UserSessionColumnPasswordlessVerification.identifier(),
+			UserSessionColumnMagicLinkVerification.identifier(),
+			UserSessionColumnExternalLoginVerification.identifier(),
+			UserSessionColumnSecondFactorVerification.identifier(),
+			UserSessionColumnSecondFactorVerificationType.identifier(),
+			UserSessionColumnMultiFactorVerification.identifier(),
+			UserSessionColumnMultiFactorVerificationType.identifier()).
+			From(userSessionsTable.identifier()).
+			LeftJoin(join(UserIDCol, UserSessionColumnUserID)).
+			LeftJoin(join(HumanUserIDCol, UserSessionColumnUserID)).
+			LeftJoin(join(MachineUserIDCol, UserSessionColumnUserID)).
+			LeftJoin("("+preferredLoginNameQuery+") AS "+userPreferredLoginNameTable.alias+" ON "+
+				userPreferredLoginNameUserIDCol.identifier()+" = "+UserSessionColumnUserID.identifier()+" AND "+
+				userPreferredLoginNameInstanceIDCol.identifier()+" = "+UserSessionColumnInstanceID.identifier(),
+				preferredLoginNameArgs...).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserSessions, error) {
+			sessions := make([]*UserSession, 0)
+			for rows.Next() {
+				s := new(UserSession)
+				var (
+					userName                  sql.NullString
+					loginName                 sql.NullString
+					displayName               sql.NullString
+					avatarKey                 sql.NullString
+					machineName               sql.NullString
+					passwordVerification      sql.NullTime
+					passwordlessVerification  sql.NullTime
+					magicLinkVerification     sql.NullTime
+					externalLoginVerification sql.NullTime
+					secondFactorVerification  sql.NullTime
+					multiFactorVerification   sql.NullTime
+				)
+				err := rows.Scan(
+					&s.UserAgentID,
+					&s.UserID,
+					&s.CreationDate,
+					&s.ChangeDate,
+					&s.ResourceOwner,
+					&s.Sequence,
+					&s.State,
+					&userName,
+					&loginName,
+					&displayName,
+					&avatarKey,
+					&machineName,
+					&s.SelectedIDPConfigID,
+					&passwordVerification,
+					&passwordlessVerification,
+					&magicLinkVerification,
+					&externalLoginVerification,
+					&secondFactorVerification,
+					&s.SecondFactorVerificationType,
+					&multiFactorVerification,
+					&s.MultiFactorVerificationType,
+				)
+				if err != nil {
+					return nil, err
+				}
+				s.UserName = userName.String
+				s.LoginName = loginName.String
+				s.DisplayName = displayName.String
+				if !displayName.Valid {
+					s.DisplayName = machineName.String
+				}
+				s.AvatarKey = avatarKey.String
+				s.PasswordVerification = passwordVerification.Time
+				s.PasswordlessVerification = passwordlessVerification.Time
+				s.MagicLinkVerification = magicLinkVerification.Time
+				s.ExternalLoginVerification = externalLoginVerification.Time
+				s.SecondFactorVerification = secondFactorVerification.Time
+				s.MultiFactorVerification = multiFactorVerification.Time
+				sessions = append(sessions, s)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Lw3ob", "Errors.Query.CloseRows")
+			}
+
+			return &UserSessions{
+				UserSessions: sessions,
+				SearchResponse: SearchResponse{
+					Count: uint64(len(sessions)),
+				},
+			}, nil
+		}
+}
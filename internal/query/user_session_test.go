@@ -0,0 +1,213 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	userSessionsStmt = regexp.QuoteMeta(
+		"SELECT projections.user_sessions2.user_agent_id," +
+			" projections.user_sessions2.user_id," +
+			" projections.user_sessions2.creation_date," +
+			" projections.user_sessions2.change_date," +
+			" projections.user_sessions2.resource_owner," +
+			" projections.user_sessions2.sequence," +
+			" projections.user_sessions2.state," +
+			" projections.users5.username," +
+			" preferred_login_name.login_name," +
+			" projections.users5_humans.display_name," +
+			" projections.users5_humans.avatar_key," +
+			" projections.users5_machines.name," +
+			" projections.user_sessions2.selected_idp_config_id," +
+			" projections.user_sessions2.password_verification," +
+			" projections.user_sessions2.passwordless_verification," +
+			" projections.user_sessions2.magic_link_verification," +
+			" projections.user_sessions2.external_login_verification," +
+			" projections.user_sessions2.second_factor_verification," +
+			" projections.user_sessions2.second_factor_verification_type," +
+			" projections.user_sessions2.multi_factor_verification," +
+			" projections.user_sessions2.multi_factor_verification_type" +
+			" FROM projections.user_sessions2" +
+			" LEFT JOIN projections.users5 ON projections.user_sessions2.user_id = projections.users5.id AND projections.user_sessions2.instance_id = projections.users5.instance_id" +
+			" LEFT JOIN projections.users5_humans ON projections.user_sessions2.user_id = projections.users5_humans.user_id AND projections.user_sessions2.instance_id = projections.users5_humans.instance_id" +
+			" LEFT JOIN projections.users5_machines ON projections.user_sessions2.user_id = projections.users5_machines.user_id AND projections.user_sessions2.instance_id = projections.users5_machines.instance_id" +
+			" LEFT JOIN" +
+			" (SELECT preferred_login_name.user_id, preferred_login_name.login_name, preferred_login_name.instance_id" +
+			" FROM projections.login_names AS preferred_login_name" +
+			" WHERE preferred_login_name.is_primary = $1) AS preferred_login_name" +
+			" ON preferred_login_name.user_id = projections.user_sessions2.user_id AND preferred_login_name.instance_id = projections.user_sessions2.instance_id")
+	userSessionsCols = []string{
+		"user_agent_id",
+		"user_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"username",
+		"login_name",
+		"display_name",
+		"avatar_key",
+		"name",
+		"selected_idp_config_id",
+		"password_verification",
+		"passwordless_verification",
+		"magic_link_verification",
+		"external_login_verification",
+		"second_factor_verification",
+		"second_factor_verification_type",
+		"multi_factor_verification",
+		"multi_factor_verification_type",
+	}
+)
+
+func Test_UserSessionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserSessionsQuery no result",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSessionsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &UserSessions{UserSessions: []*UserSession{}},
+		},
+		{
+			name:    "prepareUserSessionsQuery human and machine",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSessionsStmt,
+					userSessionsCols,
+					[][]driver.Value{
+						{
+							"agent-id",
+							"user-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211202),
+							domain.UserSessionStateActive,
+							"username",
+							"login@name",
+							"display name",
+							"avatar",
+							nil,
+							"idp-id",
+							testNow,
+							nil,
+							testNow,
+							testNow,
+							testNow,
+							domain.MFATypeU2F,
+							nil,
+							0,
+						},
+						{
+							"agent-id",
+							"machine-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211202),
+							domain.UserSessionStateTerminated,
+							"machine",
+							"machine@name",
+							nil,
+							nil,
+							"machine name",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							0,
+							nil,
+							0,
+						},
+					},
+				),
+			},
+			object: &UserSessions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				UserSessions: []*UserSession{
+					{
+						UserAgentID:                  "agent-id",
+						UserID:                       "user-id",
+						CreationDate:                 testNow,
+						ChangeDate:                   testNow,
+						ResourceOwner:                "ro",
+						Sequence:                     20211202,
+						State:                        domain.UserSessionStateActive,
+						UserName:                     "username",
+						LoginName:                    "login@name",
+						DisplayName:                  "display name",
+						AvatarKey:                    "avatar",
+						SelectedIDPConfigID:          "idp-id",
+						PasswordVerification:         testNow,
+						MagicLinkVerification:        testNow,
+						ExternalLoginVerification:    testNow,
+						SecondFactorVerification:     testNow,
+						SecondFactorVerificationType: domain.MFATypeU2F,
+					},
+					{
+						UserAgentID:   "agent-id",
+						UserID:        "machine-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211202,
+						State:         domain.UserSessionStateTerminated,
+						UserName:      "machine",
+						LoginName:     "machine@name",
+						DisplayName:   "machine name",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserSessionsQuery sql err",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userSessionsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
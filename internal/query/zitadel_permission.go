@@ -26,26 +26,55 @@ func (q *Queries) MyZitadelPermissions(ctx context.Context, orgID, userID string
 	if err != nil {
 		return nil, err
 	}
+	customRolePermissions, err := q.CustomRolePermissions(ctx, q.unmappedRoles(memberships.Memberships)...)
+	if err != nil {
+		return nil, err
+	}
 	permissions := &domain.Permissions{Permissions: []string{}}
 	for _, membership := range memberships.Memberships {
 		for _, role := range membership.Roles {
-			permissions = q.mapRoleToPermission(permissions, membership, role)
+			permissions = q.mapRoleToPermission(permissions, membership, role, customRolePermissions)
 		}
 	}
 	return permissions, nil
 }
 
-func (q *Queries) mapRoleToPermission(permissions *domain.Permissions, membership *Membership, role string) *domain.Permissions {
+// unmappedRoles returns the roles of the memberships which are not part of the static role mapping
+func (q *Queries) unmappedRoles(memberships []*Membership) []string {
+	roles := make([]string, 0)
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if !q.isZitadelRole(role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+func (q *Queries) isZitadelRole(role string) bool {
+	for _, mapping := range q.zitadelRoles {
+		if mapping.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Queries) mapRoleToPermission(permissions *domain.Permissions, membership *Membership, role string, customRolePermissions map[string][]string) *domain.Permissions {
+	ctxID := ""
+	if membership.Project != nil {
+		ctxID = membership.Project.ProjectID
+	} else if membership.ProjectGrant != nil {
+		ctxID = membership.ProjectGrant.GrantID
+	}
 	for _, mapping := range q.zitadelRoles {
 		if mapping.Role == role {
-			ctxID := ""
-			if membership.Project != nil {
-				ctxID = membership.Project.ProjectID
-			} else if membership.ProjectGrant != nil {
-				ctxID = membership.ProjectGrant.GrantID
-			}
 			permissions.AppendPermissions(ctxID, mapping.Permissions...)
 		}
 	}
+	if customPermissions, ok := customRolePermissions[role]; ok {
+		permissions.AppendPermissions(ctxID, customPermissions...)
+	}
 	return permissions
 }
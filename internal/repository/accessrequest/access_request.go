@@ -0,0 +1,190 @@
+package accessrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniquePendingAccessRequest = "pending_access_request"
+	eventTypePrefix            = eventstore.EventType("access_request.")
+	AddedType                  = eventTypePrefix + "added"
+	ApprovedType               = eventTypePrefix + "approved"
+	DeniedType                 = eventTypePrefix + "denied"
+)
+
+// NewAddPendingAccessRequestUniqueConstraint ensures a user has at most one pending request per project
+func NewAddPendingAccessRequestUniqueConstraint(userID, projectID string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniquePendingAccessRequest,
+		fmt.Sprintf("%s:%s", userID, projectID),
+		"Errors.AccessRequest.AlreadyPending")
+}
+
+func NewRemovePendingAccessRequestUniqueConstraint(userID, projectID string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniquePendingAccessRequest,
+		fmt.Sprintf("%s:%s", userID, projectID))
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID    string   `json:"userId"`
+	ProjectID string   `json:"projectId"`
+	RoleKeys  []string `json:"roleKeys"`
+	Reason    string   `json:"reason,omitempty"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddPendingAccessRequestUniqueConstraint(e.UserID, e.ProjectID)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID string,
+	roleKeys []string,
+	reason string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+		UserID:    userID,
+		ProjectID: projectID,
+		RoleKeys:  roleKeys,
+		Reason:    reason,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACCREQ-Gw2nf", "unable to unmarshal access request")
+	}
+
+	return e, nil
+}
+
+// ApprovedEvent is pushed together with the user grant created for the request
+type ApprovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserGrantID string    `json:"userGrantId"`
+	ValidFrom   time.Time `json:"validFrom,omitempty"`
+	ValidUntil  time.Time `json:"validUntil,omitempty"`
+
+	userID    string
+	projectID string
+}
+
+func (e *ApprovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ApprovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemovePendingAccessRequestUniqueConstraint(e.userID, e.projectID)}
+}
+
+func NewApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	userGrantID string,
+	validFrom,
+	validUntil time.Time,
+) *ApprovedEvent {
+	return &ApprovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApprovedType,
+		),
+		UserGrantID: userGrantID,
+		ValidFrom:   validFrom,
+		ValidUntil:  validUntil,
+		userID:      userID,
+		projectID:   projectID,
+	}
+}
+
+func ApprovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ApprovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACCREQ-Pq4md", "unable to unmarshal access request")
+	}
+
+	return e, nil
+}
+
+type DeniedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Reason string `json:"reason,omitempty"`
+
+	userID    string
+	projectID string
+}
+
+func (e *DeniedEvent) Data() interface{} {
+	return e
+}
+
+func (e *DeniedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemovePendingAccessRequestUniqueConstraint(e.userID, e.projectID)}
+}
+
+func NewDeniedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	reason string,
+) *DeniedEvent {
+	return &DeniedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeniedType,
+		),
+		Reason:    reason,
+		userID:    userID,
+		projectID: projectID,
+	}
+}
+
+func DeniedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DeniedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACCREQ-Ls9we", "unable to unmarshal access request")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,11 @@
+package accessrequest
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AddedType, AddedEventMapper).
+		RegisterFilterEventMapper(ApprovedType, ApprovedEventMapper).
+		RegisterFilterEventMapper(DeniedType, DeniedEventMapper)
+}
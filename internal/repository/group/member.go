@@ -0,0 +1,100 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	memberEventTypePrefix = groupEventTypePrefix + "member."
+	MemberAddedType       = memberEventTypePrefix + "added"
+	MemberRemovedType     = memberEventTypePrefix + "removed"
+)
+
+type MemberAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+}
+
+func (e *MemberAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MemberAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMemberAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+) *MemberAddedEvent {
+	return &MemberAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MemberAddedType,
+		),
+		UserID: userID,
+	}
+}
+
+func MemberAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MemberAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "GROUP-Kx4nd", "unable to unmarshal group member")
+	}
+
+	return e, nil
+}
+
+type MemberRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+}
+
+func (e *MemberRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *MemberRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewMemberRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+) *MemberRemovedEvent {
+	return &MemberRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MemberRemovedType,
+		),
+		UserID: userID,
+	}
+}
+
+func MemberRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MemberRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "GROUP-Jm2sl", "unable to unmarshal group member")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,184 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueCustomRoleType       = "custom_role"
+	customRolePrefix           = "custom.role."
+	CustomRoleAddedEventType   = instanceEventTypePrefix + customRolePrefix + "added"
+	CustomRoleChangedEventType = instanceEventTypePrefix + customRolePrefix + "changed"
+	CustomRoleRemovedEventType = instanceEventTypePrefix + customRolePrefix + "removed"
+)
+
+func NewAddCustomRoleUniqueConstraint(roleKey string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueCustomRoleType,
+		roleKey,
+		"Errors.CustomRole.AlreadyExists")
+}
+
+func NewRemoveCustomRoleUniqueConstraint(roleKey string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueCustomRoleType,
+		roleKey)
+}
+
+type CustomRoleAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key         string   `json:"key"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+func NewCustomRoleAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key,
+	displayName string,
+	permissions []string,
+) *CustomRoleAddedEvent {
+	return &CustomRoleAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleAddedEventType,
+		),
+		Key:         key,
+		DisplayName: displayName,
+		Permissions: permissions,
+	}
+}
+
+func (e *CustomRoleAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddCustomRoleUniqueConstraint(e.Key)}
+}
+
+func CustomRoleAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Gd3nw", "unable to unmarshal custom role added")
+	}
+
+	return e, nil
+}
+
+type CustomRoleChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key         string    `json:"key"`
+	DisplayName *string   `json:"displayName,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+func (e *CustomRoleChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCustomRoleChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+	changes []CustomRoleChanges,
+) (*CustomRoleChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Lk2mz", "Errors.NoChangesFound")
+	}
+	changeEvent := &CustomRoleChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleChangedEventType,
+		),
+		Key: key,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type CustomRoleChanges func(event *CustomRoleChangedEvent)
+
+func ChangeCustomRoleDisplayName(displayName string) func(event *CustomRoleChangedEvent) {
+	return func(e *CustomRoleChangedEvent) {
+		e.DisplayName = &displayName
+	}
+}
+
+func ChangeCustomRolePermissions(permissions []string) func(event *CustomRoleChangedEvent) {
+	return func(e *CustomRoleChangedEvent) {
+		e.Permissions = &permissions
+	}
+}
+
+func CustomRoleChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ux8wq", "unable to unmarshal custom role changed")
+	}
+
+	return e, nil
+}
+
+type CustomRoleRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key string `json:"key"`
+}
+
+func (e *CustomRoleRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CustomRoleRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveCustomRoleUniqueConstraint(e.Key)}
+}
+
+func NewCustomRoleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+) *CustomRoleRemovedEvent {
+	return &CustomRoleRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CustomRoleRemovedEventType,
+		),
+		Key: key,
+	}
+}
+
+func CustomRoleRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CustomRoleRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Pw5jc", "unable to unmarshal custom role removed")
+	}
+
+	return e, nil
+}
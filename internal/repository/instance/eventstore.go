@@ -28,6 +28,9 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(DebugNotificationProviderLogAddedEventType, DebugNotificationProviderLogAddedEventMapper).
 		RegisterFilterEventMapper(DebugNotificationProviderLogChangedEventType, DebugNotificationProviderLogChangedEventMapper).
 		RegisterFilterEventMapper(DebugNotificationProviderLogRemovedEventType, DebugNotificationProviderLogRemovedEventMapper).
+		RegisterFilterEventMapper(CustomRoleAddedEventType, CustomRoleAddedEventMapper).
+		RegisterFilterEventMapper(CustomRoleChangedEventType, CustomRoleChangedEventMapper).
+		RegisterFilterEventMapper(CustomRoleRemovedEventType, CustomRoleRemovedEventMapper).
 		RegisterFilterEventMapper(OIDCSettingsAddedEventType, OIDCSettingsAddedEventMapper).
 		RegisterFilterEventMapper(OIDCSettingsChangedEventType, OIDCSettingsChangedEventMapper).
 		RegisterFilterEventMapper(LabelPolicyAddedEventType, LabelPolicyAddedEventMapper).
@@ -54,6 +57,10 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(PasswordComplexityPolicyChangedEventType, PasswordComplexityPolicyChangedEventMapper).
 		RegisterFilterEventMapper(LockoutPolicyAddedEventType, LockoutPolicyAddedEventMapper).
 		RegisterFilterEventMapper(LockoutPolicyChangedEventType, LockoutPolicyChangedEventMapper).
+		RegisterFilterEventMapper(RiskPolicyAddedEventType, RiskPolicyAddedEventMapper).
+		RegisterFilterEventMapper(RiskPolicyChangedEventType, RiskPolicyChangedEventMapper).
+		RegisterFilterEventMapper(CaptchaPolicyAddedEventType, CaptchaPolicyAddedEventMapper).
+		RegisterFilterEventMapper(CaptchaPolicyChangedEventType, CaptchaPolicyChangedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyAddedEventType, PrivacyPolicyAddedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyChangedEventType, PrivacyPolicyChangedEventMapper).
 		RegisterFilterEventMapper(MemberAddedEventType, MemberAddedEventMapper).
@@ -0,0 +1,88 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	CaptchaPolicyAddedEventType   = instanceEventTypePrefix + policy.CaptchaPolicyAddedEventType
+	CaptchaPolicyChangedEventType = instanceEventTypePrefix + policy.CaptchaPolicyChangedEventType
+)
+
+type CaptchaPolicyAddedEvent struct {
+	policy.CaptchaPolicyAddedEvent
+}
+
+func NewCaptchaPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	providerType domain.CaptchaProviderType,
+	verifyURL,
+	siteKey string,
+	secret *crypto.CryptoValue,
+	requireOnRegistration,
+	requireOnPasswordReset bool,
+	failedLoginsThreshold uint64,
+) *CaptchaPolicyAddedEvent {
+	return &CaptchaPolicyAddedEvent{
+		CaptchaPolicyAddedEvent: *policy.NewCaptchaPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				CaptchaPolicyAddedEventType),
+			providerType,
+			verifyURL,
+			siteKey,
+			secret,
+			requireOnRegistration,
+			requireOnPasswordReset,
+			failedLoginsThreshold),
+	}
+}
+
+func CaptchaPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.CaptchaPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CaptchaPolicyAddedEvent{CaptchaPolicyAddedEvent: *e.(*policy.CaptchaPolicyAddedEvent)}, nil
+}
+
+type CaptchaPolicyChangedEvent struct {
+	policy.CaptchaPolicyChangedEvent
+}
+
+func NewCaptchaPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.CaptchaPolicyChanges,
+) (*CaptchaPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewCaptchaPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CaptchaPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &CaptchaPolicyChangedEvent{CaptchaPolicyChangedEvent: *changedEvent}, nil
+}
+
+func CaptchaPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.CaptchaPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CaptchaPolicyChangedEvent{CaptchaPolicyChangedEvent: *e.(*policy.CaptchaPolicyChangedEvent)}, nil
+}
@@ -0,0 +1,81 @@
+package instance
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	RiskPolicyAddedEventType   = instanceEventTypePrefix + policy.RiskPolicyAddedEventType
+	RiskPolicyChangedEventType = instanceEventTypePrefix + policy.RiskPolicyChangedEventType
+)
+
+type RiskPolicyAddedEvent struct {
+	policy.RiskPolicyAddedEvent
+}
+
+func NewRiskPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	mfaOnNewDevice,
+	mfaOnNewIPRange bool,
+	impossibleTravelWindow time.Duration,
+	failedAttemptsThreshold uint64,
+) *RiskPolicyAddedEvent {
+	return &RiskPolicyAddedEvent{
+		RiskPolicyAddedEvent: *policy.NewRiskPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				RiskPolicyAddedEventType),
+			mfaOnNewDevice,
+			mfaOnNewIPRange,
+			impossibleTravelWindow,
+			failedAttemptsThreshold),
+	}
+}
+
+func RiskPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskPolicyAddedEvent{RiskPolicyAddedEvent: *e.(*policy.RiskPolicyAddedEvent)}, nil
+}
+
+type RiskPolicyChangedEvent struct {
+	policy.RiskPolicyChangedEvent
+}
+
+func NewRiskPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.RiskPolicyChanges,
+) (*RiskPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewRiskPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RiskPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &RiskPolicyChangedEvent{RiskPolicyChangedEvent: *changedEvent}, nil
+}
+
+func RiskPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskPolicyChangedEvent{RiskPolicyChangedEvent: *e.(*policy.RiskPolicyChangedEvent)}, nil
+}
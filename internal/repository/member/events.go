@@ -3,6 +3,7 @@ package member
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -15,6 +16,7 @@ const (
 	ChangedEventType        = "member.changed"
 	RemovedEventType        = "member.removed"
 	CascadeRemovedEventType = "member.cascade.removed"
+	ValiditySetEventType    = "member.validity.set"
 )
 
 func NewAddMemberUniqueConstraint(aggregateID, userID string) *eventstore.EventUniqueConstraint {
@@ -187,3 +189,49 @@ func CascadeRemovedEventMapper(event *repository.Event) (eventstore.Event, error
 
 	return e, nil
 }
+
+// MemberValiditySetEvent restricts the period in which the membership is effective
+// zero values leave the period open on that side
+type MemberValiditySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID     string    `json:"userId"`
+	ValidFrom  time.Time `json:"validFrom,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *MemberValiditySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *MemberValiditySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewValiditySetEvent(
+	base *eventstore.BaseEvent,
+	userID string,
+	validFrom,
+	validUntil time.Time,
+) *MemberValiditySetEvent {
+
+	return &MemberValiditySetEvent{
+		BaseEvent:  *base,
+		UserID:     userID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}
+}
+
+func ValiditySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &MemberValiditySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "MEMBER-Vt3lf", "unable to unmarshal member validity")
+	}
+
+	return e, nil
+}
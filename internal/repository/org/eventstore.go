@@ -19,6 +19,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(MemberChangedEventType, MemberChangedEventMapper).
 		RegisterFilterEventMapper(MemberRemovedEventType, MemberRemovedEventMapper).
 		RegisterFilterEventMapper(MemberCascadeRemovedEventType, MemberCascadeRemovedEventMapper).
+		RegisterFilterEventMapper(MemberValiditySetEventType, MemberValiditySetEventMapper).
 		RegisterFilterEventMapper(LabelPolicyAddedEventType, LabelPolicyAddedEventMapper).
 		RegisterFilterEventMapper(LabelPolicyChangedEventType, LabelPolicyChangedEventMapper).
 		RegisterFilterEventMapper(LabelPolicyActivatedEventType, LabelPolicyActivatedEventMapper).
@@ -56,6 +57,12 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(LockoutPolicyAddedEventType, LockoutPolicyAddedEventMapper).
 		RegisterFilterEventMapper(LockoutPolicyChangedEventType, LockoutPolicyChangedEventMapper).
 		RegisterFilterEventMapper(LockoutPolicyRemovedEventType, LockoutPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(RiskPolicyAddedEventType, RiskPolicyAddedEventMapper).
+		RegisterFilterEventMapper(RiskPolicyChangedEventType, RiskPolicyChangedEventMapper).
+		RegisterFilterEventMapper(RiskPolicyRemovedEventType, RiskPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(CaptchaPolicyAddedEventType, CaptchaPolicyAddedEventMapper).
+		RegisterFilterEventMapper(CaptchaPolicyChangedEventType, CaptchaPolicyChangedEventMapper).
+		RegisterFilterEventMapper(CaptchaPolicyRemovedEventType, CaptchaPolicyRemovedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyAddedEventType, PrivacyPolicyAddedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyChangedEventType, PrivacyPolicyChangedEventMapper).
 		RegisterFilterEventMapper(PrivacyPolicyRemovedEventType, PrivacyPolicyRemovedEventMapper).
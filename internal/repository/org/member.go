@@ -2,6 +2,7 @@ package org
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -14,6 +15,7 @@ var (
 	MemberChangedEventType        = orgEventTypePrefix + member.ChangedEventType
 	MemberRemovedEventType        = orgEventTypePrefix + member.RemovedEventType
 	MemberCascadeRemovedEventType = orgEventTypePrefix + member.CascadeRemovedEventType
+	MemberValiditySetEventType    = orgEventTypePrefix + member.ValiditySetEventType
 )
 
 type MemberAddedEvent struct {
@@ -140,3 +142,38 @@ func MemberCascadeRemovedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MemberCascadeRemovedEvent{MemberCascadeRemovedEvent: *e.(*member.MemberCascadeRemovedEvent)}, nil
 }
+
+type MemberValiditySetEvent struct {
+	member.MemberValiditySetEvent
+}
+
+func NewMemberValiditySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+	validFrom,
+	validUntil time.Time,
+) *MemberValiditySetEvent {
+
+	return &MemberValiditySetEvent{
+		MemberValiditySetEvent: *member.NewValiditySetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				MemberValiditySetEventType,
+			),
+			userID,
+			validFrom,
+			validUntil,
+		),
+	}
+}
+
+func MemberValiditySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := member.ValiditySetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberValiditySetEvent{MemberValiditySetEvent: *e.(*member.MemberValiditySetEvent)}, nil
+}
@@ -32,7 +32,8 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -56,6 +57,7 @@ func NewLoginPolicyAddedEvent(
 			allowDomainDiscovery,
 			disableLoginWithEmail,
 			disableLoginWithPhone,
+			allowMagicLink,
 			passwordlessType,
 			defaultRedirectURI,
 			passwordCheckLifetime,
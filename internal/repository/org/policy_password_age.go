@@ -23,7 +23,8 @@ func NewPasswordAgePolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	expireWarnDays,
-	maxAgeDays uint64,
+	maxAgeDays,
+	historyCount uint64,
 ) *PasswordAgePolicyAddedEvent {
 	return &PasswordAgePolicyAddedEvent{
 		PasswordAgePolicyAddedEvent: *policy.NewPasswordAgePolicyAddedEvent(
@@ -32,7 +33,8 @@ func NewPasswordAgePolicyAddedEvent(
 				aggregate,
 				PasswordAgePolicyAddedEventType),
 			expireWarnDays,
-			maxAgeDays),
+			maxAgeDays,
+			historyCount),
 	}
 }
 
@@ -0,0 +1,109 @@
+package org
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	RiskPolicyAddedEventType   = orgEventTypePrefix + policy.RiskPolicyAddedEventType
+	RiskPolicyChangedEventType = orgEventTypePrefix + policy.RiskPolicyChangedEventType
+	RiskPolicyRemovedEventType = orgEventTypePrefix + policy.RiskPolicyRemovedEventType
+)
+
+type RiskPolicyAddedEvent struct {
+	policy.RiskPolicyAddedEvent
+}
+
+func NewRiskPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	mfaOnNewDevice,
+	mfaOnNewIPRange bool,
+	impossibleTravelWindow time.Duration,
+	failedAttemptsThreshold uint64,
+) *RiskPolicyAddedEvent {
+	return &RiskPolicyAddedEvent{
+		RiskPolicyAddedEvent: *policy.NewRiskPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				RiskPolicyAddedEventType),
+			mfaOnNewDevice,
+			mfaOnNewIPRange,
+			impossibleTravelWindow,
+			failedAttemptsThreshold),
+	}
+}
+
+func RiskPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskPolicyAddedEvent{RiskPolicyAddedEvent: *e.(*policy.RiskPolicyAddedEvent)}, nil
+}
+
+type RiskPolicyChangedEvent struct {
+	policy.RiskPolicyChangedEvent
+}
+
+func NewRiskPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.RiskPolicyChanges,
+) (*RiskPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewRiskPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RiskPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &RiskPolicyChangedEvent{RiskPolicyChangedEvent: *changedEvent}, nil
+}
+
+func RiskPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskPolicyChangedEvent{RiskPolicyChangedEvent: *e.(*policy.RiskPolicyChangedEvent)}, nil
+}
+
+type RiskPolicyRemovedEvent struct {
+	policy.RiskPolicyRemovedEvent
+}
+
+func NewRiskPolicyRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RiskPolicyRemovedEvent {
+	return &RiskPolicyRemovedEvent{
+		RiskPolicyRemovedEvent: *policy.NewRiskPolicyRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				RiskPolicyRemovedEventType),
+		),
+	}
+}
+
+func RiskPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.RiskPolicyRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskPolicyRemovedEvent{RiskPolicyRemovedEvent: *e.(*policy.RiskPolicyRemovedEvent)}, nil
+}
@@ -29,6 +29,7 @@ type LoginPolicyAddedEvent struct {
 	AllowDomainDiscovery       bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             bool                    `json:"allowMagicLink,omitempty"`
 	PasswordlessType           domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -56,7 +57,8 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -83,6 +85,7 @@ func NewLoginPolicyAddedEvent(
 		MultiFactorCheckLifetime:   multiFactorCheckLifetime,
 		DisableLoginWithEmail:      disableLoginWithEmail,
 		DisableLoginWithPhone:      disableLoginWithPhone,
+		AllowMagicLink:             allowMagicLink,
 	}
 }
 
@@ -111,6 +114,7 @@ type LoginPolicyChangedEvent struct {
 	AllowDomainDiscovery       *bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      *bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      *bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             *bool                    `json:"allowMagicLink,omitempty"`
 	PasswordlessType           *domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         *string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      *time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -242,6 +246,12 @@ func ChangeDisableLoginWithPhone(DisableLoginWithPhone bool) func(*LoginPolicyCh
 	}
 }
 
+func ChangeAllowMagicLink(allowMagicLink bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowMagicLink = &allowMagicLink
+	}
+}
+
 func LoginPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &LoginPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
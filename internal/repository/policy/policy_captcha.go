@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	CaptchaPolicyAddedEventType   = "policy.captcha.added"
+	CaptchaPolicyChangedEventType = "policy.captcha.changed"
+	CaptchaPolicyRemovedEventType = "policy.captcha.removed"
+)
+
+type CaptchaPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ProviderType           domain.CaptchaProviderType `json:"providerType,omitempty"`
+	VerifyURL              string                     `json:"verifyUrl,omitempty"`
+	SiteKey                string                     `json:"siteKey,omitempty"`
+	Secret                 *crypto.CryptoValue        `json:"secret,omitempty"`
+	RequireOnRegistration  bool                       `json:"requireOnRegistration,omitempty"`
+	RequireOnPasswordReset bool                       `json:"requireOnPasswordReset,omitempty"`
+	FailedLoginsThreshold  uint64                     `json:"failedLoginsThreshold,omitempty"`
+}
+
+func (e *CaptchaPolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CaptchaPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCaptchaPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	providerType domain.CaptchaProviderType,
+	verifyURL,
+	siteKey string,
+	secret *crypto.CryptoValue,
+	requireOnRegistration,
+	requireOnPasswordReset bool,
+	failedLoginsThreshold uint64,
+) *CaptchaPolicyAddedEvent {
+	return &CaptchaPolicyAddedEvent{
+		BaseEvent:              *base,
+		ProviderType:           providerType,
+		VerifyURL:              verifyURL,
+		SiteKey:                siteKey,
+		Secret:                 secret,
+		RequireOnRegistration:  requireOnRegistration,
+		RequireOnPasswordReset: requireOnPasswordReset,
+		FailedLoginsThreshold:  failedLoginsThreshold,
+	}
+}
+
+func CaptchaPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CaptchaPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Cp2x7", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type CaptchaPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ProviderType           *domain.CaptchaProviderType `json:"providerType,omitempty"`
+	VerifyURL              *string                     `json:"verifyUrl,omitempty"`
+	SiteKey                *string                     `json:"siteKey,omitempty"`
+	Secret                 *crypto.CryptoValue         `json:"secret,omitempty"`
+	RequireOnRegistration  *bool                       `json:"requireOnRegistration,omitempty"`
+	RequireOnPasswordReset *bool                       `json:"requireOnPasswordReset,omitempty"`
+	FailedLoginsThreshold  *uint64                     `json:"failedLoginsThreshold,omitempty"`
+}
+
+func (e *CaptchaPolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *CaptchaPolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCaptchaPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []CaptchaPolicyChanges,
+) (*CaptchaPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Cp3y8", "Errors.NoChangesFound")
+	}
+	changeEvent := &CaptchaPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type CaptchaPolicyChanges func(*CaptchaPolicyChangedEvent)
+
+func ChangeCaptchaProviderType(providerType domain.CaptchaProviderType) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.ProviderType = &providerType
+	}
+}
+
+func ChangeCaptchaVerifyURL(verifyURL string) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.VerifyURL = &verifyURL
+	}
+}
+
+func ChangeCaptchaSiteKey(siteKey string) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.SiteKey = &siteKey
+	}
+}
+
+func ChangeCaptchaSecret(secret *crypto.CryptoValue) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.Secret = secret
+	}
+}
+
+func ChangeCaptchaRequireOnRegistration(requireOnRegistration bool) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.RequireOnRegistration = &requireOnRegistration
+	}
+}
+
+func ChangeCaptchaRequireOnPasswordReset(requireOnPasswordReset bool) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.RequireOnPasswordReset = &requireOnPasswordReset
+	}
+}
+
+func ChangeCaptchaFailedLoginsThreshold(threshold uint64) func(*CaptchaPolicyChangedEvent) {
+	return func(e *CaptchaPolicyChangedEvent) {
+		e.FailedLoginsThreshold = &threshold
+	}
+}
+
+func CaptchaPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &CaptchaPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Cp4z9", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type CaptchaPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *CaptchaPolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *CaptchaPolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewCaptchaPolicyRemovedEvent(base *eventstore.BaseEvent) *CaptchaPolicyRemovedEvent {
+	return &CaptchaPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func CaptchaPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &CaptchaPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -19,6 +19,7 @@ type PasswordAgePolicyAddedEvent struct {
 
 	ExpireWarnDays uint64 `json:"expireWarnDays,omitempty"`
 	MaxAgeDays     uint64 `json:"maxAgeDays,omitempty"`
+	HistoryCount   uint64 `json:"historyCount,omitempty"`
 }
 
 func (e *PasswordAgePolicyAddedEvent) Data() interface{} {
@@ -32,13 +33,15 @@ func (e *PasswordAgePolicyAddedEvent) UniqueConstraints() []*eventstore.EventUni
 func NewPasswordAgePolicyAddedEvent(
 	base *eventstore.BaseEvent,
 	expireWarnDays,
-	maxAgeDays uint64,
+	maxAgeDays,
+	historyCount uint64,
 ) *PasswordAgePolicyAddedEvent {
 
 	return &PasswordAgePolicyAddedEvent{
 		BaseEvent:      *base,
 		ExpireWarnDays: expireWarnDays,
 		MaxAgeDays:     maxAgeDays,
+		HistoryCount:   historyCount,
 	}
 }
 
@@ -60,6 +63,7 @@ type PasswordAgePolicyChangedEvent struct {
 
 	ExpireWarnDays *uint64 `json:"expireWarnDays,omitempty"`
 	MaxAgeDays     *uint64 `json:"maxAgeDays,omitempty"`
+	HistoryCount   *uint64 `json:"historyCount,omitempty"`
 }
 
 func (e *PasswordAgePolicyChangedEvent) Data() interface{} {
@@ -100,6 +104,12 @@ func ChangeMaxAgeDays(maxAgeDays uint64) func(*PasswordAgePolicyChangedEvent) {
 	}
 }
 
+func ChangeHistoryCount(historyCount uint64) func(*PasswordAgePolicyChangedEvent) {
+	return func(e *PasswordAgePolicyChangedEvent) {
+		e.HistoryCount = &historyCount
+	}
+}
+
 func PasswordAgePolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &PasswordAgePolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -18,11 +18,12 @@ const (
 type PasswordComplexityPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MinLength    uint64 `json:"minLength,omitempty"`
-	HasLowercase bool   `json:"hasLowercase,omitempty"`
-	HasUppercase bool   `json:"hasUppercase,omitempty"`
-	HasNumber    bool   `json:"hasNumber,omitempty"`
-	HasSymbol    bool   `json:"hasSymbol,omitempty"`
+	MinLength      uint64 `json:"minLength,omitempty"`
+	HasLowercase   bool   `json:"hasLowercase,omitempty"`
+	HasUppercase   bool   `json:"hasUppercase,omitempty"`
+	HasNumber      bool   `json:"hasNumber,omitempty"`
+	HasSymbol      bool   `json:"hasSymbol,omitempty"`
+	RejectBreached bool   `json:"rejectBreached,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyAddedEvent) Data() interface{} {
@@ -39,15 +40,17 @@ func NewPasswordComplexityPolicyAddedEvent(
 	hasLowerCase,
 	hasUpperCase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	rejectBreached bool,
 ) *PasswordComplexityPolicyAddedEvent {
 	return &PasswordComplexityPolicyAddedEvent{
-		BaseEvent:    *base,
-		MinLength:    minLength,
-		HasLowercase: hasLowerCase,
-		HasUppercase: hasUpperCase,
-		HasNumber:    hasNumber,
-		HasSymbol:    hasSymbol,
+		BaseEvent:      *base,
+		MinLength:      minLength,
+		HasLowercase:   hasLowerCase,
+		HasUppercase:   hasUpperCase,
+		HasNumber:      hasNumber,
+		HasSymbol:      hasSymbol,
+		RejectBreached: rejectBreached,
 	}
 }
 
@@ -67,11 +70,12 @@ func PasswordComplexityPolicyAddedEventMapper(event *repository.Event) (eventsto
 type PasswordComplexityPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MinLength    *uint64 `json:"minLength,omitempty"`
-	HasLowercase *bool   `json:"hasLowercase,omitempty"`
-	HasUppercase *bool   `json:"hasUppercase,omitempty"`
-	HasNumber    *bool   `json:"hasNumber,omitempty"`
-	HasSymbol    *bool   `json:"hasSymbol,omitempty"`
+	MinLength      *uint64 `json:"minLength,omitempty"`
+	HasLowercase   *bool   `json:"hasLowercase,omitempty"`
+	HasUppercase   *bool   `json:"hasUppercase,omitempty"`
+	HasNumber      *bool   `json:"hasNumber,omitempty"`
+	HasSymbol      *bool   `json:"hasSymbol,omitempty"`
+	RejectBreached *bool   `json:"rejectBreached,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyChangedEvent) Data() interface{} {
@@ -130,6 +134,12 @@ func ChangeHasSymbol(hasSymbol bool) func(*PasswordComplexityPolicyChangedEvent)
 	}
 }
 
+func ChangeRejectBreached(rejectBreached bool) func(*PasswordComplexityPolicyChangedEvent) {
+	return func(e *PasswordComplexityPolicyChangedEvent) {
+		e.RejectBreached = &rejectBreached
+	}
+}
+
 func PasswordComplexityPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &PasswordComplexityPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
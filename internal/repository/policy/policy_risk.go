@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	RiskPolicyAddedEventType   = "policy.risk.added"
+	RiskPolicyChangedEventType = "policy.risk.changed"
+	RiskPolicyRemovedEventType = "policy.risk.removed"
+)
+
+type RiskPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MFAOnNewDevice          bool          `json:"mfaOnNewDevice,omitempty"`
+	MFAOnNewIPRange         bool          `json:"mfaOnNewIPRange,omitempty"`
+	ImpossibleTravelWindow  time.Duration `json:"impossibleTravelWindow,omitempty"`
+	FailedAttemptsThreshold uint64        `json:"failedAttemptsThreshold,omitempty"`
+}
+
+func (e *RiskPolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RiskPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRiskPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	mfaOnNewDevice,
+	mfaOnNewIPRange bool,
+	impossibleTravelWindow time.Duration,
+	failedAttemptsThreshold uint64,
+) *RiskPolicyAddedEvent {
+	return &RiskPolicyAddedEvent{
+		BaseEvent:               *base,
+		MFAOnNewDevice:          mfaOnNewDevice,
+		MFAOnNewIPRange:         mfaOnNewIPRange,
+		ImpossibleTravelWindow:  impossibleTravelWindow,
+		FailedAttemptsThreshold: failedAttemptsThreshold,
+	}
+}
+
+func RiskPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RiskPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Rk2m8", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type RiskPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MFAOnNewDevice          *bool          `json:"mfaOnNewDevice,omitempty"`
+	MFAOnNewIPRange         *bool          `json:"mfaOnNewIPRange,omitempty"`
+	ImpossibleTravelWindow  *time.Duration `json:"impossibleTravelWindow,omitempty"`
+	FailedAttemptsThreshold *uint64        `json:"failedAttemptsThreshold,omitempty"`
+}
+
+func (e *RiskPolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RiskPolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRiskPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []RiskPolicyChanges,
+) (*RiskPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Rk3n9", "Errors.NoChangesFound")
+	}
+	changeEvent := &RiskPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type RiskPolicyChanges func(*RiskPolicyChangedEvent)
+
+func ChangeMFAOnNewDevice(mfaOnNewDevice bool) func(*RiskPolicyChangedEvent) {
+	return func(e *RiskPolicyChangedEvent) {
+		e.MFAOnNewDevice = &mfaOnNewDevice
+	}
+}
+
+func ChangeMFAOnNewIPRange(mfaOnNewIPRange bool) func(*RiskPolicyChangedEvent) {
+	return func(e *RiskPolicyChangedEvent) {
+		e.MFAOnNewIPRange = &mfaOnNewIPRange
+	}
+}
+
+func ChangeImpossibleTravelWindow(window time.Duration) func(*RiskPolicyChangedEvent) {
+	return func(e *RiskPolicyChangedEvent) {
+		e.ImpossibleTravelWindow = &window
+	}
+}
+
+func ChangeFailedAttemptsThreshold(threshold uint64) func(*RiskPolicyChangedEvent) {
+	return func(e *RiskPolicyChangedEvent) {
+		e.FailedAttemptsThreshold = &threshold
+	}
+}
+
+func RiskPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RiskPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Rk4o0", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type RiskPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RiskPolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RiskPolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRiskPolicyRemovedEvent(base *eventstore.BaseEvent) *RiskPolicyRemovedEvent {
+	return &RiskPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func RiskPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RiskPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
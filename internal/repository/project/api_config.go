@@ -3,6 +3,7 @@ package project
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -27,6 +28,12 @@ type APIConfigAddedEvent struct {
 	ClientID       string                   `json:"clientId,omitempty"`
 	ClientSecret   *crypto.CryptoValue      `json:"clientSecret,omitempty"`
 	AuthMethodType domain.APIAuthMethodType `json:"authMethodType,omitempty"`
+
+	AccessTokenLifetime        time.Duration               `json:"accessTokenLifetime,omitempty"`
+	IDTokenLifetime            time.Duration               `json:"idTokenLifetime,omitempty"`
+	RefreshTokenIdleExpiration time.Duration               `json:"refreshTokenIdleExpiration,omitempty"`
+	RefreshTokenExpiration     time.Duration               `json:"refreshTokenExpiration,omitempty"`
+	RefreshTokenRotation       domain.RefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
 }
 
 func (e *APIConfigAddedEvent) Data() interface{} {
@@ -44,6 +51,7 @@ func NewAPIConfigAddedEvent(
 	clientID string,
 	clientSecret *crypto.CryptoValue,
 	authMethodType domain.APIAuthMethodType,
+	tokenSettings domain.AppTokenSettings,
 ) *APIConfigAddedEvent {
 	return &APIConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -55,6 +63,12 @@ func NewAPIConfigAddedEvent(
 		ClientID:       clientID,
 		ClientSecret:   clientSecret,
 		AuthMethodType: authMethodType,
+
+		AccessTokenLifetime:        tokenSettings.AccessTokenLifetime,
+		IDTokenLifetime:            tokenSettings.IDTokenLifetime,
+		RefreshTokenIdleExpiration: tokenSettings.RefreshTokenIdleExpiration,
+		RefreshTokenExpiration:     tokenSettings.RefreshTokenExpiration,
+		RefreshTokenRotation:       tokenSettings.RefreshTokenRotation,
 	}
 }
 
@@ -73,6 +87,21 @@ func (e *APIConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 	if e.AuthMethodType != c.AuthMethodType {
 		return false
 	}
+	if e.AccessTokenLifetime != c.AccessTokenLifetime {
+		return false
+	}
+	if e.IDTokenLifetime != c.IDTokenLifetime {
+		return false
+	}
+	if e.RefreshTokenIdleExpiration != c.RefreshTokenIdleExpiration {
+		return false
+	}
+	if e.RefreshTokenExpiration != c.RefreshTokenExpiration {
+		return false
+	}
+	if e.RefreshTokenRotation != c.RefreshTokenRotation {
+		return false
+	}
 
 	return true
 }
@@ -96,6 +125,12 @@ type APIConfigChangedEvent struct {
 	AppID          string                    `json:"appId"`
 	ClientSecret   *crypto.CryptoValue       `json:"clientSecret,omitempty"`
 	AuthMethodType *domain.APIAuthMethodType `json:"authMethodType,omitempty"`
+
+	AccessTokenLifetime        *time.Duration               `json:"accessTokenLifetime,omitempty"`
+	IDTokenLifetime            *time.Duration               `json:"idTokenLifetime,omitempty"`
+	RefreshTokenIdleExpiration *time.Duration               `json:"refreshTokenIdleExpiration,omitempty"`
+	RefreshTokenExpiration     *time.Duration               `json:"refreshTokenExpiration,omitempty"`
+	RefreshTokenRotation       *domain.RefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
 }
 
 func (e *APIConfigChangedEvent) Data() interface{} {
@@ -138,6 +173,36 @@ func ChangeAPIAuthMethodType(authMethodType domain.APIAuthMethodType) func(event
 	}
 }
 
+func ChangeAPIAccessTokenLifetime(accessTokenLifetime time.Duration) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.AccessTokenLifetime = &accessTokenLifetime
+	}
+}
+
+func ChangeAPIIDTokenLifetime(idTokenLifetime time.Duration) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.IDTokenLifetime = &idTokenLifetime
+	}
+}
+
+func ChangeAPIRefreshTokenIdleExpiration(refreshTokenIdleExpiration time.Duration) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.RefreshTokenIdleExpiration = &refreshTokenIdleExpiration
+	}
+}
+
+func ChangeAPIRefreshTokenExpiration(refreshTokenExpiration time.Duration) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.RefreshTokenExpiration = &refreshTokenExpiration
+	}
+}
+
+func ChangeAPIRefreshTokenRotation(refreshTokenRotation domain.RefreshTokenRotation) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.RefreshTokenRotation = &refreshTokenRotation
+	}
+}
+
 func APIConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &APIConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	ApplicationClaimMappingsSetType = applicationEventTypePrefix + "claim.mappings.set"
+)
+
+type ClaimMapping struct {
+	Source      domain.ClaimMappingSource `json:"source"`
+	SourceKey   string                    `json:"sourceKey,omitempty"`
+	Claim       string                    `json:"claim"`
+	Type        domain.ClaimMappingType   `json:"type,omitempty"`
+	IDToken     bool                      `json:"idToken,omitempty"`
+	AccessToken bool                      `json:"accessToken,omitempty"`
+	Userinfo    bool                      `json:"userinfo,omitempty"`
+}
+
+type ApplicationClaimMappingsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID         string          `json:"appId"`
+	ClaimMappings []*ClaimMapping `json:"claimMappings"`
+}
+
+func (e *ApplicationClaimMappingsSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ApplicationClaimMappingsSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewApplicationClaimMappingsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	claimMappings []*ClaimMapping,
+) *ApplicationClaimMappingsSetEvent {
+	return &ApplicationClaimMappingsSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationClaimMappingsSetType,
+		),
+		AppID:         appID,
+		ClaimMappings: claimMappings,
+	}
+}
+
+func ApplicationClaimMappingsSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ApplicationClaimMappingsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CLAIM-Ml2bd", "unable to unmarshal claim mappings")
+	}
+
+	return e, nil
+}
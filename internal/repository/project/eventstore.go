@@ -14,6 +14,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(MemberChangedType, MemberChangedEventMapper).
 		RegisterFilterEventMapper(MemberRemovedType, MemberRemovedEventMapper).
 		RegisterFilterEventMapper(MemberCascadeRemovedType, MemberCascadeRemovedEventMapper).
+		RegisterFilterEventMapper(MemberValiditySetType, MemberValiditySetEventMapper).
 		RegisterFilterEventMapper(RoleAddedType, RoleAddedEventMapper).
 		RegisterFilterEventMapper(RoleChangedType, RoleChangedEventMapper).
 		RegisterFilterEventMapper(RoleRemovedType, RoleRemovedEventMapper).
@@ -43,5 +44,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(ApplicationKeyAddedEventType, ApplicationKeyAddedEventMapper).
 		RegisterFilterEventMapper(ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper).
 		RegisterFilterEventMapper(SAMLConfigAddedType, SAMLConfigAddedEventMapper).
-		RegisterFilterEventMapper(SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+		RegisterFilterEventMapper(SAMLConfigChangedType, SAMLConfigChangedEventMapper).
+		RegisterFilterEventMapper(ApplicationClaimMappingsSetType, ApplicationClaimMappingsSetEventMapper).
+		RegisterFilterEventMapper(RelationTupleAddedType, RelationTupleAddedEventMapper).
+		RegisterFilterEventMapper(RelationTupleRemovedType, RelationTupleRemovedEventMapper)
 }
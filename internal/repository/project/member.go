@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -14,6 +15,7 @@ var (
 	MemberChangedType        = projectEventTypePrefix + member.ChangedEventType
 	MemberRemovedType        = projectEventTypePrefix + member.RemovedEventType
 	MemberCascadeRemovedType = projectEventTypePrefix + member.CascadeRemovedEventType
+	MemberValiditySetType    = projectEventTypePrefix + member.ValiditySetEventType
 )
 
 type MemberAddedEvent struct {
@@ -142,3 +144,38 @@ func MemberCascadeRemovedEventMapper(event *repository.Event) (eventstore.Event,
 
 	return &MemberCascadeRemovedEvent{MemberCascadeRemovedEvent: *e.(*member.MemberCascadeRemovedEvent)}, nil
 }
+
+type MemberValiditySetEvent struct {
+	member.MemberValiditySetEvent
+}
+
+func NewProjectMemberValiditySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+	validFrom,
+	validUntil time.Time,
+) *MemberValiditySetEvent {
+
+	return &MemberValiditySetEvent{
+		MemberValiditySetEvent: *member.NewValiditySetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				MemberValiditySetType,
+			),
+			userID,
+			validFrom,
+			validUntil,
+		),
+	}
+}
+
+func MemberValiditySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := member.ValiditySetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberValiditySetEvent{MemberValiditySetEvent: *e.(*member.MemberValiditySetEvent)}, nil
+}
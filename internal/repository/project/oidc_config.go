@@ -23,23 +23,32 @@ const (
 type OIDCConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                     `json:"appId"`
-	ClientID                 string                     `json:"clientId,omitempty"`
-	ClientSecret             *crypto.CryptoValue        `json:"clientSecret,omitempty"`
-	RedirectUris             []string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   []string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  bool                       `json:"devMode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
+	Version                            domain.OIDCVersion          `json:"oidcVersion,omitempty"`
+	AppID                              string                      `json:"appId"`
+	ClientID                           string                      `json:"clientId,omitempty"`
+	ClientSecret                       *crypto.CryptoValue         `json:"clientSecret,omitempty"`
+	RedirectUris                       []string                    `json:"redirectUris,omitempty"`
+	ResponseTypes                      []domain.OIDCResponseType   `json:"responseTypes,omitempty"`
+	GrantTypes                         []domain.OIDCGrantType      `json:"grantTypes,omitempty"`
+	ApplicationType                    domain.OIDCApplicationType  `json:"applicationType,omitempty"`
+	AuthMethodType                     domain.OIDCAuthMethodType   `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris             []string                    `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                            bool                        `json:"devMode,omitempty"`
+	AccessTokenType                    domain.OIDCTokenType        `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion           bool                        `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion               bool                        `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion           bool                        `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                          time.Duration               `json:"clockSkew,omitempty"`
+	AdditionalOrigins                  []string                    `json:"additionalOrigins,omitempty"`
+	RequirePushedAuthorizationRequests bool                        `json:"requirePushedAuthorizationRequests,omitempty"`
+	JWKS                               string                      `json:"jwks,omitempty"`
+	BackChannelLogoutURI               string                      `json:"backChannelLogoutUri,omitempty"`
+	FrontChannelLogoutURI              string                      `json:"frontChannelLogoutUri,omitempty"`
+	AccessTokenLifetime                time.Duration               `json:"accessTokenLifetime,omitempty"`
+	IDTokenLifetime                    time.Duration               `json:"idTokenLifetime,omitempty"`
+	RefreshTokenIdleExpiration         time.Duration               `json:"refreshTokenIdleExpiration,omitempty"`
+	RefreshTokenExpiration             time.Duration               `json:"refreshTokenExpiration,omitempty"`
+	RefreshTokenRotation               domain.RefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -70,6 +79,11 @@ func NewOIDCConfigAddedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
+	requirePushedAuthorizationRequests bool,
+	jwks string,
+	backChannelLogoutURI string,
+	frontChannelLogoutURI string,
+	tokenSettings domain.AppTokenSettings,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -77,23 +91,32 @@ func NewOIDCConfigAddedEvent(
 			aggregate,
 			OIDCConfigAddedType,
 		),
-		Version:                  version,
-		AppID:                    appID,
-		ClientID:                 clientID,
-		ClientSecret:             clientSecret,
-		RedirectUris:             redirectUris,
-		ResponseTypes:            responseTypes,
-		GrantTypes:               grantTypes,
-		ApplicationType:          applicationType,
-		AuthMethodType:           authMethodType,
-		PostLogoutRedirectUris:   postLogoutRedirectUris,
-		DevMode:                  devMode,
-		AccessTokenType:          accessTokenType,
-		AccessTokenRoleAssertion: accessTokenRoleAssertion,
-		IDTokenRoleAssertion:     idTokenRoleAssertion,
-		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
-		ClockSkew:                clockSkew,
-		AdditionalOrigins:        additionalOrigins,
+		Version:                            version,
+		AppID:                              appID,
+		ClientID:                           clientID,
+		ClientSecret:                       clientSecret,
+		RedirectUris:                       redirectUris,
+		ResponseTypes:                      responseTypes,
+		GrantTypes:                         grantTypes,
+		ApplicationType:                    applicationType,
+		AuthMethodType:                     authMethodType,
+		PostLogoutRedirectUris:             postLogoutRedirectUris,
+		DevMode:                            devMode,
+		AccessTokenType:                    accessTokenType,
+		AccessTokenRoleAssertion:           accessTokenRoleAssertion,
+		IDTokenRoleAssertion:               idTokenRoleAssertion,
+		IDTokenUserinfoAssertion:           idTokenUserinfoAssertion,
+		ClockSkew:                          clockSkew,
+		AdditionalOrigins:                  additionalOrigins,
+		RequirePushedAuthorizationRequests: requirePushedAuthorizationRequests,
+		JWKS:                               jwks,
+		BackChannelLogoutURI:               backChannelLogoutURI,
+		FrontChannelLogoutURI:              frontChannelLogoutURI,
+		AccessTokenLifetime:                tokenSettings.AccessTokenLifetime,
+		IDTokenLifetime:                    tokenSettings.IDTokenLifetime,
+		RefreshTokenIdleExpiration:         tokenSettings.RefreshTokenIdleExpiration,
+		RefreshTokenExpiration:             tokenSettings.RefreshTokenExpiration,
+		RefreshTokenRotation:               tokenSettings.RefreshTokenRotation,
 	}
 }
 
@@ -179,6 +202,33 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
+	if e.RequirePushedAuthorizationRequests != c.RequirePushedAuthorizationRequests {
+		return false
+	}
+	if e.JWKS != c.JWKS {
+		return false
+	}
+	if e.BackChannelLogoutURI != c.BackChannelLogoutURI {
+		return false
+	}
+	if e.FrontChannelLogoutURI != c.FrontChannelLogoutURI {
+		return false
+	}
+	if e.AccessTokenLifetime != c.AccessTokenLifetime {
+		return false
+	}
+	if e.IDTokenLifetime != c.IDTokenLifetime {
+		return false
+	}
+	if e.RefreshTokenIdleExpiration != c.RefreshTokenIdleExpiration {
+		return false
+	}
+	if e.RefreshTokenExpiration != c.RefreshTokenExpiration {
+		return false
+	}
+	if e.RefreshTokenRotation != c.RefreshTokenRotation {
+		return false
+	}
 
 	return true
 }
@@ -199,21 +249,30 @@ func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type OIDCConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                      `json:"appId"`
-	RedirectUris             *[]string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          *domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   *[]string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  *bool                       `json:"devMode,omitempty"`
-	AccessTokenType          *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion *bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     *bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
+	Version                            *domain.OIDCVersion          `json:"oidcVersion,omitempty"`
+	AppID                              string                       `json:"appId"`
+	RedirectUris                       *[]string                    `json:"redirectUris,omitempty"`
+	ResponseTypes                      *[]domain.OIDCResponseType   `json:"responseTypes,omitempty"`
+	GrantTypes                         *[]domain.OIDCGrantType      `json:"grantTypes,omitempty"`
+	ApplicationType                    *domain.OIDCApplicationType  `json:"applicationType,omitempty"`
+	AuthMethodType                     *domain.OIDCAuthMethodType   `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris             *[]string                    `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                            *bool                        `json:"devMode,omitempty"`
+	AccessTokenType                    *domain.OIDCTokenType        `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion           *bool                        `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion               *bool                        `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion           *bool                        `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                          *time.Duration               `json:"clockSkew,omitempty"`
+	AdditionalOrigins                  *[]string                    `json:"additionalOrigins,omitempty"`
+	RequirePushedAuthorizationRequests *bool                        `json:"requirePushedAuthorizationRequests,omitempty"`
+	JWKS                               *string                      `json:"jwks,omitempty"`
+	BackChannelLogoutURI               *string                      `json:"backChannelLogoutUri,omitempty"`
+	FrontChannelLogoutURI              *string                      `json:"frontChannelLogoutUri,omitempty"`
+	AccessTokenLifetime                *time.Duration               `json:"accessTokenLifetime,omitempty"`
+	IDTokenLifetime                    *time.Duration               `json:"idTokenLifetime,omitempty"`
+	RefreshTokenIdleExpiration         *time.Duration               `json:"refreshTokenIdleExpiration,omitempty"`
+	RefreshTokenExpiration             *time.Duration               `json:"refreshTokenExpiration,omitempty"`
+	RefreshTokenRotation               *domain.RefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -334,6 +393,60 @@ func ChangeAdditionalOrigins(additionalOrigins []string) func(event *OIDCConfigC
 	}
 }
 
+func ChangeRequirePushedAuthorizationRequests(requirePushedAuthorizationRequests bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePushedAuthorizationRequests = &requirePushedAuthorizationRequests
+	}
+}
+
+func ChangeJWKS(jwks string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.JWKS = &jwks
+	}
+}
+
+func ChangeBackChannelLogoutURI(backChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackChannelLogoutURI = &backChannelLogoutURI
+	}
+}
+
+func ChangeFrontChannelLogoutURI(frontChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.FrontChannelLogoutURI = &frontChannelLogoutURI
+	}
+}
+
+func ChangeAccessTokenLifetime(accessTokenLifetime time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.AccessTokenLifetime = &accessTokenLifetime
+	}
+}
+
+func ChangeIDTokenLifetime(idTokenLifetime time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.IDTokenLifetime = &idTokenLifetime
+	}
+}
+
+func ChangeRefreshTokenIdleExpiration(refreshTokenIdleExpiration time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenIdleExpiration = &refreshTokenIdleExpiration
+	}
+}
+
+func ChangeRefreshTokenExpiration(refreshTokenExpiration time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenExpiration = &refreshTokenExpiration
+	}
+}
+
+func ChangeRefreshTokenRotation(refreshTokenRotation domain.RefreshTokenRotation) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenRotation = &refreshTokenRotation
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
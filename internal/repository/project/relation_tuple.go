@@ -0,0 +1,130 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+var (
+	UniqueRelationTupleType      = "project_relation_tuple"
+	relationTupleEventTypePrefix = projectEventTypePrefix + "relation.tuple."
+	RelationTupleAddedType       = relationTupleEventTypePrefix + "added"
+	RelationTupleRemovedType     = relationTupleEventTypePrefix + "removed"
+)
+
+func NewAddRelationTupleUniqueConstraint(tuple, projectID string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRelationTupleType,
+		fmt.Sprintf("%s:%s", projectID, tuple),
+		"Errors.Project.RelationTuple.AlreadyExists")
+}
+
+func NewRemoveRelationTupleUniqueConstraint(tuple, projectID string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueRelationTupleType,
+		fmt.Sprintf("%s:%s", projectID, tuple))
+}
+
+type RelationTuple struct {
+	ObjectType      string `json:"objectType"`
+	ObjectID        string `json:"objectId"`
+	Relation        string `json:"relation"`
+	SubjectType     string `json:"subjectType"`
+	SubjectID       string `json:"subjectId"`
+	SubjectRelation string `json:"subjectRelation,omitempty"`
+}
+
+func (t *RelationTuple) String() string {
+	tuple := t.ObjectType + ":" + t.ObjectID + "#" + t.Relation + "@" + t.SubjectType + ":" + t.SubjectID
+	if t.SubjectRelation != "" {
+		tuple += "#" + t.SubjectRelation
+	}
+	return tuple
+}
+
+type RelationTupleAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	RelationTuple
+}
+
+func (e *RelationTupleAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RelationTupleAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddRelationTupleUniqueConstraint(e.String(), e.Aggregate().ID)}
+}
+
+func NewRelationTupleAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tuple RelationTuple,
+) *RelationTupleAddedEvent {
+	return &RelationTupleAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RelationTupleAddedType,
+		),
+		RelationTuple: tuple,
+	}
+}
+
+func RelationTupleAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RelationTupleAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Kd8sw", "unable to unmarshal relation tuple")
+	}
+
+	return e, nil
+}
+
+type RelationTupleRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	RelationTuple
+}
+
+func (e *RelationTupleRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RelationTupleRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveRelationTupleUniqueConstraint(e.String(), e.Aggregate().ID)}
+}
+
+func NewRelationTupleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tuple RelationTuple,
+) *RelationTupleRemovedEvent {
+	return &RelationTupleRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RelationTupleRemovedType,
+		),
+		RelationTuple: tuple,
+	}
+}
+
+func RelationTupleRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &RelationTupleRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Wq2nd", "unable to unmarshal relation tuple")
+	}
+
+	return e, nil
+}
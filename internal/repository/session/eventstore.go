@@ -0,0 +1,11 @@
+package session
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AddedType, AddedEventMapper).
+		RegisterFilterEventMapper(UserCheckedType, UserCheckedEventMapper).
+		RegisterFilterEventMapper(TerminatedType, TerminatedEventMapper)
+}
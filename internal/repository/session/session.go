@@ -0,0 +1,140 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	eventTypePrefix = eventstore.EventType("session.")
+	AddedType       = eventTypePrefix + "added"
+	UserCheckedType = eventTypePrefix + "user.checked"
+	TerminatedType  = eventTypePrefix + "terminated"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// AuthRequestID is set if the session was created for an OIDC or SAML auth request
+	AuthRequestID string              `json:"authRequestId,omitempty"`
+	Token         *crypto.CryptoValue `json:"token"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	authRequestID string,
+	token *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+		AuthRequestID: authRequestID,
+		Token:         token,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Ds2kd", "unable to unmarshal session added")
+	}
+
+	return e, nil
+}
+
+type UserCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID            string `json:"userId"`
+	UserResourceOwner string `json:"userResourceOwner"`
+}
+
+func (e *UserCheckedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserCheckedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	userResourceOwner string,
+) *UserCheckedEvent {
+	return &UserCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserCheckedType,
+		),
+		UserID:            userID,
+		UserResourceOwner: userResourceOwner,
+	}
+}
+
+func UserCheckedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserCheckedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SESSION-Ds3le", "unable to unmarshal session user checked")
+	}
+
+	return e, nil
+}
+
+type TerminatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *TerminatedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *TerminatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewTerminatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *TerminatedEvent {
+	return &TerminatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TerminatedType,
+		),
+	}
+}
+
+func TerminatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &TerminatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
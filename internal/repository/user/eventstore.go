@@ -56,6 +56,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(HumanInitializedCheckSucceededType, HumanInitializedCheckSucceededEventMapper).
 		RegisterFilterEventMapper(HumanInitializedCheckFailedType, HumanInitializedCheckFailedEventMapper).
 		RegisterFilterEventMapper(HumanSignedOutType, HumanSignedOutEventMapper).
+		RegisterFilterEventMapper(HumanBackChannelLogoutSentType, HumanBackChannelLogoutSentEventMapper).
 		RegisterFilterEventMapper(HumanPasswordChangedType, HumanPasswordChangedEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
@@ -86,6 +87,27 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSAddedType, HumanOTPSMSAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSRemovedType, HumanOTPSMSRemovedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCodeAddedType, HumanOTPSMSCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCodeSentType, HumanOTPSMSCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCheckSucceededType, HumanOTPSMSCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCheckFailedType, HumanOTPSMSCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailAddedType, HumanOTPEmailAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailRemovedType, HumanOTPEmailRemovedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCodeAddedType, HumanOTPEmailCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCodeSentType, HumanOTPEmailCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCheckSucceededType, HumanOTPEmailCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCheckFailedType, HumanOTPEmailCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodesAddedType, HumanRecoveryCodesAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodeCheckSucceededType, HumanRecoveryCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodeCheckFailedType, HumanRecoveryCodeCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodeNotificationSentType, HumanRecoveryCodeNotificationSentEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCodeAddedType, HumanMagicLinkCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCodeSentType, HumanMagicLinkCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCheckSucceededType, HumanMagicLinkCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCheckFailedType, HumanMagicLinkCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanRiskAssessedType, HumanRiskAssessedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -108,10 +130,33 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper).
 		RegisterFilterEventMapper(HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper).
 		RegisterFilterEventMapper(HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper).
+		RegisterFilterEventMapper(HumanRefreshTokenReusedType, HumanRefreshTokenReusedEventMapper).
+		RegisterFilterEventMapper(HumanRefreshTokenReuseNotificationSentType, HumanRefreshTokenReuseNotificationSentEventMapper).
 		RegisterFilterEventMapper(MachineAddedEventType, MachineAddedEventMapper).
 		RegisterFilterEventMapper(MachineChangedEventType, MachineChangedEventMapper).
 		RegisterFilterEventMapper(MachineKeyAddedEventType, MachineKeyAddedEventMapper).
 		RegisterFilterEventMapper(MachineKeyRemovedEventType, MachineKeyRemovedEventMapper).
 		RegisterFilterEventMapper(PersonalAccessTokenAddedType, PersonalAccessTokenAddedEventMapper).
-		RegisterFilterEventMapper(PersonalAccessTokenRemovedType, PersonalAccessTokenRemovedEventMapper)
+		RegisterFilterEventMapper(PersonalAccessTokenRemovedType, PersonalAccessTokenRemovedEventMapper).
+		RegisterPersonalData(HumanAddedType, humanPersonalDataFields...).
+		RegisterPersonalData(HumanRegisteredType, humanPersonalDataFields...).
+		RegisterPersonalData(HumanProfileChangedType, profilePersonalDataFields...).
+		RegisterPersonalData(HumanEmailChangedType, emailPersonalDataFields...).
+		RegisterPersonalData(HumanPhoneChangedType, phonePersonalDataFields...).
+		RegisterPersonalData(HumanAddressChangedType, addressPersonalDataFields...).
+		RegisterPersonalDataErasure(UserRemovedType)
 }
+
+// the fields of the payloads containing personal data
+// they are encrypted if a PersonalDataCrypto is set on the eventstore
+var (
+	humanPersonalDataFields = []string{
+		"firstName", "lastName", "nickName", "displayName", "gender",
+		"email", "phone",
+		"country", "locality", "postalCode", "region", "streetAddress",
+	}
+	profilePersonalDataFields = []string{"firstName", "lastName", "nickName", "displayName", "gender"}
+	emailPersonalDataFields   = []string{"email"}
+	phonePersonalDataFields   = []string{"phone"}
+	addressPersonalDataFields = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+)
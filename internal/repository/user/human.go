@@ -23,6 +23,7 @@ const (
 	HumanInitializedCheckSucceededType = humanEventPrefix + "initialization.check.succeeded"
 	HumanInitializedCheckFailedType    = humanEventPrefix + "initialization.check.failed"
 	HumanSignedOutType                 = humanEventPrefix + "signed.out"
+	HumanBackChannelLogoutSentType     = humanEventPrefix + "signed.out.backchannel.sent"
 )
 
 type HumanAddedEvent struct {
@@ -370,6 +371,8 @@ type HumanSignedOutEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	UserAgentID string `json:"userAgentID"`
+	// ClientIDs are the clients which were issued tokens in the terminated session of the user agent
+	ClientIDs []string `json:"clientIDs,omitempty"`
 }
 
 func (e *HumanSignedOutEvent) Data() interface{} {
@@ -384,6 +387,7 @@ func NewHumanSignedOutEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	userAgentID string,
+	clientIDs []string,
 ) *HumanSignedOutEvent {
 	return &HumanSignedOutEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -392,11 +396,63 @@ func NewHumanSignedOutEvent(
 			HumanSignedOutType,
 		),
 		UserAgentID: userAgentID,
+		ClientIDs:   clientIDs,
 	}
 }
 
 func HumanSignedOutEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &HumanSignedOutEvent{
+	signedOut := &HumanSignedOutEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	err := json.Unmarshal(event.Data, signedOut)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Wn8fq", "unable to unmarshal signed out")
+	}
+
+	return signedOut, nil
+}
+
+// HumanBackChannelLogoutSentEvent is pushed as soon as a client acknowledged the logout token of a sign out
+type HumanBackChannelLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID string `json:"userAgentID"`
+	ClientID    string `json:"clientID"`
+}
+
+func (e *HumanBackChannelLogoutSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanBackChannelLogoutSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	clientID string,
+) *HumanBackChannelLogoutSentEvent {
+	return &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutSentType,
+		),
+		UserAgentID: userAgentID,
+		ClientID:    clientID,
+	}
+}
+
+func HumanBackChannelLogoutSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	sent := &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, sent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Bc4lq", "unable to unmarshal back-channel logout sent")
+	}
+
+	return sent, nil
 }
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	magicLinkEventPrefix             = humanEventPrefix + "magiclink."
+	HumanMagicLinkCodeAddedType      = magicLinkEventPrefix + "code.added"
+	HumanMagicLinkCodeSentType       = magicLinkEventPrefix + "code.sent"
+	HumanMagicLinkCheckSucceededType = magicLinkEventPrefix + "check.succeeded"
+	HumanMagicLinkCheckFailedType    = magicLinkEventPrefix + "check.failed"
+)
+
+type HumanMagicLinkCodeAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Code   *crypto.CryptoValue `json:"code,omitempty"`
+	Expiry time.Duration       `json:"expiry,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCodeAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCodeAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCodeAddedEvent {
+	return &HumanMagicLinkCodeAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeAddedType,
+		),
+		Code:            code,
+		Expiry:          expiry,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCodeAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codeAdded := &HumanMagicLinkCodeAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codeAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ml3kd", "unable to unmarshal human magic link code added")
+	}
+	return codeAdded, nil
+}
+
+type HumanMagicLinkCodeSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanMagicLinkCodeSentEvent) Data() interface{} {
+	return nil
+}
+
+func (e *HumanMagicLinkCodeSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCodeSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanMagicLinkCodeSentEvent {
+	return &HumanMagicLinkCodeSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCodeSentType,
+		),
+	}
+}
+
+func HumanMagicLinkCodeSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &HumanMagicLinkCodeSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type HumanMagicLinkCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCheckSucceededEvent {
+	return &HumanMagicLinkCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCheckSucceededType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkSucceeded := &HumanMagicLinkCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkSucceeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ml4le", "unable to unmarshal human magic link check succeeded")
+	}
+	return checkSucceeded, nil
+}
+
+type HumanMagicLinkCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanMagicLinkCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanMagicLinkCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanMagicLinkCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanMagicLinkCheckFailedEvent {
+	return &HumanMagicLinkCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMagicLinkCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanMagicLinkCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkFailed := &HumanMagicLinkCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkFailed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ml5mf", "unable to unmarshal human magic link check failed")
+	}
+	return checkFailed, nil
+}
@@ -0,0 +1,190 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	recoveryCodesEventPrefix                 = mfaEventPrefix + "recoverycodes."
+	HumanMFARecoveryCodesAddedType           = recoveryCodesEventPrefix + "added"
+	HumanMFARecoveryCodeCheckSucceededType   = recoveryCodesEventPrefix + "check.succeeded"
+	HumanMFARecoveryCodeCheckFailedType      = recoveryCodesEventPrefix + "check.failed"
+	HumanMFARecoveryCodeNotificationSentType = recoveryCodesEventPrefix + "notification.sent"
+)
+
+// RecoveryCode is a single-use code, which can be used instead of a second factor
+type RecoveryCode struct {
+	ID   string              `json:"id"`
+	Code *crypto.CryptoValue `json:"code"`
+	// Lookup is the non-secret prefix of the code, so only the hash of the matching code has to be compared
+	Lookup string `json:"lookup,omitempty"`
+}
+
+// HumanRecoveryCodesAddedEvent replaces all previously added recovery codes of the user
+type HumanRecoveryCodesAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Codes []*RecoveryCode `json:"codes"`
+}
+
+func (e *HumanRecoveryCodesAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodesAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodesAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codes []*RecoveryCode,
+) *HumanRecoveryCodesAddedEvent {
+	return &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodesAddedType,
+		),
+		Codes: codes,
+	}
+}
+
+func HumanRecoveryCodesAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	codesAdded := &HumanRecoveryCodesAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, codesAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc2ka", "unable to unmarshal human recovery codes added")
+	}
+	return codesAdded, nil
+}
+
+type HumanRecoveryCodeCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeID string `json:"codeId"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckSucceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeID string,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckSucceededEvent {
+	return &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeCheckSucceededType,
+		),
+		CodeID:          codeID,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckSucceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkSucceeded := &HumanRecoveryCodeCheckSucceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkSucceeded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc3lb", "unable to unmarshal human recovery code check succeeded")
+	}
+	return checkSucceeded, nil
+}
+
+type HumanRecoveryCodeCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeCheckFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanRecoveryCodeCheckFailedEvent {
+	return &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRecoveryCodeCheckFailedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	checkFailed := &HumanRecoveryCodeCheckFailedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, checkFailed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc4mc", "unable to unmarshal human recovery code check failed")
+	}
+	return checkFailed, nil
+}
+
+type HumanRecoveryCodeNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CodeID string `json:"codeId"`
+}
+
+func (e *HumanRecoveryCodeNotificationSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRecoveryCodeNotificationSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRecoveryCodeNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	codeID string,
+) *HumanRecoveryCodeNotificationSentEvent {
+	return &HumanRecoveryCodeNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanMFARecoveryCodeNotificationSentType,
+		),
+		CodeID: codeID,
+	}
+}
+
+func HumanRecoveryCodeNotificationSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	notificationSent := &HumanRecoveryCodeNotificationSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, notificationSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rc5nd", "unable to unmarshal human recovery code notification sent")
+	}
+	return notificationSent, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 
 	"github.com/zitadel/zitadel/internal/errors"
@@ -16,6 +17,9 @@ const (
 	HumanRefreshTokenAddedType   = refreshTokenEventPrefix + "added"
 	HumanRefreshTokenRenewedType = refreshTokenEventPrefix + "renewed"
 	HumanRefreshTokenRemovedType = refreshTokenEventPrefix + "removed"
+	// HumanRefreshTokenReusedType is the security event of a superseded refresh token being used again
+	HumanRefreshTokenReusedType                = refreshTokenEventPrefix + "reused"
+	HumanRefreshTokenReuseNotificationSentType = refreshTokenEventPrefix + "reuse.notification.sent"
 )
 
 type HumanRefreshTokenAddedEvent struct {
@@ -31,6 +35,9 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	// Confirmation is the key the refresh token is bound to (DPoP or client certificate),
+	// which must be presented again on renewal
+	Confirmation *domain.TokenConfirmation `json:"cnf,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Data() interface{} {
@@ -58,6 +65,7 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	confirmation *domain.TokenConfirmation,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -75,6 +83,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		Confirmation:          confirmation,
 	}
 }
 
@@ -185,3 +194,91 @@ func HumanRefreshTokenRemovedEventEventMapper(event *repository.Event) (eventsto
 
 	return tokenAdded, nil
 }
+
+type HumanRefreshTokenReusedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID     string `json:"tokenId"`
+	ClientID    string `json:"clientId"`
+	UserAgentID string `json:"userAgentId"`
+}
+
+func (e *HumanRefreshTokenReusedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReusedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRefreshTokenReusedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	clientID,
+	userAgentID string,
+) *HumanRefreshTokenReusedEvent {
+	return &HumanRefreshTokenReusedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReusedType,
+		),
+		TokenID:     tokenID,
+		ClientID:    clientID,
+		UserAgentID: userAgentID,
+	}
+}
+
+func HumanRefreshTokenReusedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	tokenReused := &HumanRefreshTokenReusedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, tokenReused)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk3mz", "unable to unmarshal refresh token reused")
+	}
+
+	return tokenReused, nil
+}
+
+type HumanRefreshTokenReuseNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *HumanRefreshTokenReuseNotificationSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRefreshTokenReuseNotificationSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRefreshTokenReuseNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *HumanRefreshTokenReuseNotificationSentEvent {
+	return &HumanRefreshTokenReuseNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRefreshTokenReuseNotificationSentType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func HumanRefreshTokenReuseNotificationSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	notificationSent := &HumanRefreshTokenReuseNotificationSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, notificationSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk4nq", "unable to unmarshal refresh token reuse notification sent")
+	}
+
+	return notificationSent, nil
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	riskEventPrefix       = humanEventPrefix + "risk."
+	HumanRiskAssessedType = riskEventPrefix + "assessed"
+)
+
+// HumanRiskAssessedEvent records the decision of the risk policy for an auth request
+// including the signals which fired, so every decision can be audited
+type HumanRiskAssessedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Signals     []domain.RiskSignal `json:"signals,omitempty"`
+	MFARequired bool                `json:"mfaRequired,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanRiskAssessedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanRiskAssessedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanRiskAssessedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	signals []domain.RiskSignal,
+	mfaRequired bool,
+	info *AuthRequestInfo,
+) *HumanRiskAssessedEvent {
+	return &HumanRiskAssessedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanRiskAssessedType,
+		),
+		Signals:         signals,
+		MFARequired:     mfaRequired,
+		AuthRequestInfo: info,
+	}
+}
+
+func HumanRiskAssessedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	assessed := &HumanRiskAssessedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, assessed)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Rk5ma", "unable to unmarshal human risk assessed")
+	}
+	return assessed, nil
+}
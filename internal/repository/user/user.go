@@ -219,6 +219,8 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	// Confirmation is the key the token is bound to (DPoP or client certificate)
+	Confirmation *domain.TokenConfirmation `json:"cnf,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +242,7 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	confirmation *domain.TokenConfirmation,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -255,6 +258,7 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		Confirmation:      confirmation,
 	}
 }
 
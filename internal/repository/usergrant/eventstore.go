@@ -11,5 +11,6 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(UserGrantRemovedType, UserGrantRemovedEventMapper).
 		RegisterFilterEventMapper(UserGrantCascadeRemovedType, UserGrantCascadeRemovedEventMapper).
 		RegisterFilterEventMapper(UserGrantDeactivatedType, UserGrantDeactivatedEventMapper).
-		RegisterFilterEventMapper(UserGrantReactivatedType, UserGrantReactivatedEventMapper)
+		RegisterFilterEventMapper(UserGrantReactivatedType, UserGrantReactivatedEventMapper).
+		RegisterFilterEventMapper(UserGrantValiditySetType, UserGrantValiditySetEventMapper)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -21,6 +22,7 @@ const (
 	UserGrantCascadeRemovedType = userGrantEventTypePrefix + "cascade.removed"
 	UserGrantDeactivatedType    = userGrantEventTypePrefix + "deactivated"
 	UserGrantReactivatedType    = userGrantEventTypePrefix + "reactivated"
+	UserGrantValiditySetType    = userGrantEventTypePrefix + "validity.set"
 )
 
 func NewAddUserGrantUniqueConstraint(resourceOwner, userID, projectID, projectGrantID string) *eventstore.EventUniqueConstraint {
@@ -301,3 +303,49 @@ func UserGrantReactivatedEventMapper(event *repository.Event) (eventstore.Event,
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+// UserGrantValiditySetEvent restricts the period in which the user grant is effective
+// zero values leave the period open on that side
+type UserGrantValiditySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	ValidFrom            time.Time `json:"validFrom,omitempty"`
+	ValidUntil           time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *UserGrantValiditySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserGrantValiditySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserGrantValiditySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	validFrom,
+	validUntil time.Time,
+) *UserGrantValiditySetEvent {
+	return &UserGrantValiditySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserGrantValiditySetType,
+		),
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}
+}
+
+func UserGrantValiditySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &UserGrantValiditySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "UGRANT-Hv5ke", "unable to unmarshal user grant validity")
+	}
+
+	return e, nil
+}
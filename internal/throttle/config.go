@@ -0,0 +1,55 @@
+package throttle
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+)
+
+type Config struct {
+	// Enabled activates the throttling of failed password, OTP and login name checks
+	Enabled bool
+	// FreeAttempts is the amount of failed checks before further checks are delayed
+	FreeAttempts uint64
+	// Delay is the delay after the first failure exceeding the free attempts,
+	// it's doubled with every further failure up to MaxDelay
+	// a MaxDelay of 0 disables the delays
+	Delay    time.Duration
+	MaxDelay time.Duration
+	// LockoutAttempts is the amount of failed checks after which checks are locked for LockoutDuration
+	// users are never locked, only the checks of a user from an ip
+	// 0 disables the lockout
+	LockoutAttempts uint64
+	LockoutDuration time.Duration
+	// Window is the duration after the last failure, after which the failures are forgotten and removed
+	Window time.Duration
+	// Cache caches the throttles of the database in memory, nil disables the cache
+	// throttled subjects are always read from the database
+	Cache *bigcache.Config
+}
+
+func (c *Config) delay(failures uint64) time.Duration {
+	if failures <= c.FreeAttempts {
+		return 0
+	}
+	delay := c.Delay
+	for i := c.FreeAttempts + 1; i < failures && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxDelay {
+		return c.MaxDelay
+	}
+	return delay
+}
+
+func (c *Config) locked(failures uint64) bool {
+	return c.LockoutAttempts > 0 && failures >= c.LockoutAttempts
+}
+
+// maxThrottle is the longest duration a failure can throttle further checks
+func (c *Config) maxThrottle() time.Duration {
+	if c.LockoutDuration > c.MaxDelay {
+		return c.LockoutDuration
+	}
+	return c.MaxDelay
+}
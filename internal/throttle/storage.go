@@ -0,0 +1,106 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	getThrottleStmt = "SELECT failures, last_failure FROM auth.throttles" +
+		" WHERE instance_id = $1 AND kind = $2 AND key = $3"
+	// the failures are counted in the database, so concurrent failures on multiple instances of ZITADEL are not lost
+	// failures older than the window are forgotten
+	addFailureStmt = "INSERT INTO auth.throttles (instance_id, kind, key, failures, last_failure) VALUES ($1, $2, $3, 1, $4)" +
+		" ON CONFLICT (instance_id, kind, key) DO UPDATE SET" +
+		" failures = CASE WHEN auth.throttles.last_failure < $5 THEN 1 ELSE auth.throttles.failures + 1 END," +
+		" last_failure = $4" +
+		" RETURNING failures, last_failure"
+	removeThrottleStmt = "DELETE FROM auth.throttles" +
+		" WHERE instance_id = $1 AND kind = $2 AND key = $3"
+	// removeExpiredStmt removes the throttles of all instances
+	removeExpiredStmt = "DELETE FROM auth.throttles WHERE last_failure < $1"
+	listThrottlesStmt = "SELECT kind, key, failures, last_failure FROM auth.throttles" +
+		" WHERE instance_id = $1 AND last_failure > $2" +
+		" ORDER BY last_failure DESC"
+)
+
+func (t *Throttler) get(ctx context.Context, instanceID string, subject Subject) (*domain.Throttle, error) {
+	var (
+		failures    uint64
+		lastFailure time.Time
+	)
+	err := t.client.QueryRowContext(ctx, getThrottleStmt, instanceID, subject.Kind, subject.Key).Scan(&failures, &lastFailure)
+	if errs.Is(err, sql.ErrNoRows) {
+		return t.toThrottle(instanceID, subject, 0, lastFailure), nil
+	}
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "THROT-Nc8wq", "Errors.Internal")
+	}
+	return t.toThrottle(instanceID, subject, failures, lastFailure), nil
+}
+
+func (t *Throttler) addFailure(ctx context.Context, instanceID string, subject Subject, now time.Time) (*domain.Throttle, error) {
+	var (
+		failures    uint64
+		lastFailure time.Time
+		windowStart time.Time
+	)
+	if t.config.Window > 0 {
+		windowStart = now.Add(-t.config.Window)
+	}
+	err := t.client.QueryRowContext(ctx, addFailureStmt, instanceID, subject.Kind, subject.Key, now, windowStart).Scan(&failures, &lastFailure)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "THROT-Hs4fb", "Errors.Internal")
+	}
+	return t.toThrottle(instanceID, subject, failures, lastFailure), nil
+}
+
+func (t *Throttler) remove(ctx context.Context, instanceID string, subject Subject) (bool, error) {
+	t.deleteCache(instanceID, subject)
+	result, err := t.client.ExecContext(ctx, removeThrottleStmt, instanceID, subject.Kind, subject.Key)
+	if err != nil {
+		return false, errors.ThrowInternal(err, "THROT-Wm5zr", "Errors.Internal")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.ThrowInternal(err, "THROT-Lb6ty", "Errors.Internal")
+	}
+	return rows > 0, nil
+}
+
+func (t *Throttler) removeExpired(ctx context.Context, before time.Time) error {
+	_, err := t.client.ExecContext(ctx, removeExpiredStmt, before)
+	if err != nil {
+		return errors.ThrowInternal(err, "THROT-Fe8qa", "Errors.Internal")
+	}
+	return nil
+}
+
+func (t *Throttler) list(ctx context.Context, instanceID string, since time.Time) ([]*domain.Throttle, error) {
+	rows, err := t.client.QueryContext(ctx, listThrottlesStmt, instanceID, since)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "THROT-Ja1ke", "Errors.Internal")
+	}
+	defer rows.Close()
+	throttles := make([]*domain.Throttle, 0)
+	for rows.Next() {
+		var (
+			subject     Subject
+			failures    uint64
+			lastFailure time.Time
+		)
+		if err = rows.Scan(&subject.Kind, &subject.Key, &failures, &lastFailure); err != nil {
+			return nil, errors.ThrowInternal(err, "THROT-Ry3gd", "Errors.Internal")
+		}
+		throttles = append(throttles, t.toThrottle(instanceID, subject, failures, lastFailure))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "THROT-Vo7nc", "Errors.Internal")
+	}
+	return throttles, nil
+}
@@ -0,0 +1,218 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Throttler delays and locks password, OTP and login name checks of ips, user agents and users with too many failures.
+// Users are only delayed, the checks of a user are locked per ip.
+// The failures are stored in the database, so they are shared by all instances of ZITADEL.
+// A nil Throttler never throttles.
+type Throttler struct {
+	config Config
+	client *sql.DB
+	cache  cache.Cache
+	now    func() time.Time
+}
+
+// Subject is the ip, user agent, user or user from an ip a check is throttled by
+type Subject struct {
+	Kind domain.ThrottleKind
+	Key  string
+}
+
+// UserIPSubject returns the subject of the checks of the user from the ip
+func UserIPSubject(userID string, ip net.IP) Subject {
+	return Subject{Kind: domain.ThrottleKindUserIP, Key: userID + "@" + ip.String()}
+}
+
+// Start creates the throttler and removes the expired failures in the background until the context is done
+func Start(ctx context.Context, config Config, client *sql.DB) (*Throttler, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	throttler := &Throttler{
+		config: config,
+		client: client,
+		now:    time.Now,
+	}
+	if config.Cache != nil {
+		c, err := bigcache.NewBigcache(config.Cache)
+		if err != nil {
+			return nil, err
+		}
+		throttler.cache = c
+	}
+	if config.Window > 0 {
+		go throttler.cleanup(ctx)
+	}
+	return throttler, nil
+}
+
+// cleanup removes the failures older than the window, they aren't considered anymore
+func (t *Throttler) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(t.config.Window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := t.removeExpired(ctx, t.now().Add(-t.config.Window))
+			logging.OnError(err).Warn("unable to remove expired throttles")
+		}
+	}
+}
+
+// Check returns an error if checks of one of the subjects are currently not allowed
+func (t *Throttler) Check(ctx context.Context, subjects ...Subject) error {
+	if t == nil {
+		return nil
+	}
+	now := t.now()
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	for _, subject := range subjects {
+		throttle, err := t.throttle(ctx, instanceID, subject)
+		if err != nil {
+			return err
+		}
+		if throttle.IsThrottled(now) {
+			return errors.ThrowResourceExhausted(nil, "THROT-Sw3lp", "Errors.Throttle.TooManyAttempts")
+		}
+	}
+	return nil
+}
+
+// Failed records a failed check for all subjects
+// errors are only logged, so the result of the check is returned to the user
+func (t *Throttler) Failed(ctx context.Context, subjects ...Subject) {
+	if t == nil {
+		return
+	}
+	now := t.now()
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	for _, subject := range subjects {
+		throttle, err := t.addFailure(ctx, instanceID, subject, now)
+		if err != nil {
+			logging.WithFields("kind", subject.Kind).WithError(err).Warn("unable to record failed check")
+			continue
+		}
+		t.setCache(throttle)
+	}
+}
+
+// List returns the currently throttled ips, user agents and users of the instance
+func (t *Throttler) List(ctx context.Context) ([]*domain.Throttle, error) {
+	if t == nil {
+		return nil, nil
+	}
+	now := t.now()
+	throttles, err := t.list(ctx, authz.GetInstance(ctx).InstanceID(), now.Add(-t.config.maxThrottle()))
+	if err != nil {
+		return nil, err
+	}
+	current := make([]*domain.Throttle, 0, len(throttles))
+	for _, throttle := range throttles {
+		if throttle.IsThrottled(now) {
+			current = append(current, throttle)
+		}
+	}
+	return current, nil
+}
+
+// Remove resets the failures of the subject, so it can be checked again immediately
+func (t *Throttler) Remove(ctx context.Context, subject Subject) error {
+	if t == nil {
+		return errors.ThrowNotFound(nil, "THROT-Kd9sl", "Errors.Throttle.NotFound")
+	}
+	if !subject.Kind.Valid() || subject.Key == "" {
+		return errors.ThrowInvalidArgument(nil, "THROT-Ue7cm", "Errors.Throttle.Invalid")
+	}
+	removed, err := t.remove(ctx, authz.GetInstance(ctx).InstanceID(), subject)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.ThrowNotFound(nil, "THROT-Pq2xw", "Errors.Throttle.NotFound")
+	}
+	return nil
+}
+
+func (t *Throttler) throttle(ctx context.Context, instanceID string, subject Subject) (*domain.Throttle, error) {
+	if t.cache != nil {
+		entry := new(cacheEntry)
+		// the cache doesn't remove expired entries on read
+		// a cached throttle is always read again, so a throttle removed on another instance of ZITADEL doesn't block the checks anymore
+		if err := t.cache.Get(cacheKey(instanceID, subject), entry); err == nil &&
+			entry.CachedAt.After(t.now().Add(-t.config.Cache.CacheLifetime)) &&
+			!entry.Throttle.IsThrottled(t.now()) {
+			return entry.Throttle, nil
+		}
+	}
+	throttle, err := t.get(ctx, instanceID, subject)
+	if err != nil {
+		return nil, err
+	}
+	t.setCache(throttle)
+	return throttle, nil
+}
+
+// toThrottle computes the delay and lockout of the failures
+func (t *Throttler) toThrottle(instanceID string, subject Subject, failures uint64, lastFailure time.Time) *domain.Throttle {
+	throttle := &domain.Throttle{
+		InstanceID: instanceID,
+		Kind:       subject.Kind,
+		Key:        subject.Key,
+	}
+	if failures == 0 || t.expired(lastFailure) {
+		return throttle
+	}
+	throttle.Failures = failures
+	throttle.LastFailure = lastFailure
+	throttle.DelayedUntil = lastFailure.Add(t.config.delay(failures))
+	if subject.Kind.Lockable() && t.config.locked(failures) {
+		throttle.LockedUntil = lastFailure.Add(t.config.LockoutDuration)
+	}
+	return throttle
+}
+
+func (t *Throttler) expired(lastFailure time.Time) bool {
+	return t.config.Window > 0 && lastFailure.Before(t.now().Add(-t.config.Window))
+}
+
+func (t *Throttler) setCache(throttle *domain.Throttle) {
+	if t.cache == nil {
+		return
+	}
+	err := t.cache.Set(cacheKey(throttle.InstanceID, Subject{Kind: throttle.Kind, Key: throttle.Key}), &cacheEntry{Throttle: throttle, CachedAt: t.now()})
+	logging.OnError(err).Debug("unable to cache throttle")
+}
+
+func (t *Throttler) deleteCache(instanceID string, subject Subject) {
+	if t.cache == nil {
+		return
+	}
+	// the entry might not be cached, so the error is ignored
+	_ = t.cache.Delete(cacheKey(instanceID, subject))
+}
+
+type cacheEntry struct {
+	Throttle *domain.Throttle
+	CachedAt time.Time
+}
+
+func cacheKey(instanceID string, subject Subject) string {
+	return instanceID + ":" + strconv.Itoa(int(subject.Kind)) + ":" + subject.Key
+}
@@ -0,0 +1,306 @@
+package throttle
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	testNow    = time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	testConfig = Config{
+		Enabled:         true,
+		FreeAttempts:    3,
+		Delay:           time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 10,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+	ipSubject = Subject{Kind: domain.ThrottleKindIP, Key: "192.168.1.1"}
+)
+
+func TestConfig_delay(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		failures uint64
+		want     time.Duration
+	}{
+		{
+			name:     "free attempt",
+			config:   testConfig,
+			failures: 3,
+			want:     0,
+		},
+		{
+			name:     "first delay",
+			config:   testConfig,
+			failures: 4,
+			want:     time.Second,
+		},
+		{
+			name:     "doubled",
+			config:   testConfig,
+			failures: 6,
+			want:     4 * time.Second,
+		},
+		{
+			name:     "max delay",
+			config:   testConfig,
+			failures: 100,
+			want:     time.Minute,
+		},
+		{
+			name: "no max delay disables delays",
+			config: Config{
+				FreeAttempts: 3,
+				Delay:        time.Second,
+			},
+			failures: 10,
+			want:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.delay(tt.failures))
+		})
+	}
+}
+
+func TestThrottler_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        *sqlmock.Rows
+		wantErrFunc func(error) bool
+	}{
+		{
+			name: "no failures",
+			rows: sqlmock.NewRows([]string{"failures", "last_failure"}),
+		},
+		{
+			name: "free attempts",
+			rows: sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(3, testNow),
+		},
+		{
+			name:        "delayed",
+			rows:        sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(5, testNow.Add(-time.Second)),
+			wantErrFunc: errors.IsResourceExhausted,
+		},
+		{
+			name: "delay passed",
+			rows: sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(5, testNow.Add(-2*time.Second)),
+		},
+		{
+			name:        "locked",
+			rows:        sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(10, testNow.Add(-10*time.Minute)),
+			wantErrFunc: errors.IsResourceExhausted,
+		},
+		{
+			name: "failures expired",
+			rows: sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(10, testNow.Add(-2*time.Hour)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttler, mock := newTestThrottler(t)
+			mock.ExpectQuery(regexp.QuoteMeta(getThrottleStmt)).
+				WithArgs("instanceID", domain.ThrottleKindIP, "192.168.1.1").
+				WillReturnRows(tt.rows)
+
+			err := throttler.Check(authz.WithInstanceID(context.Background(), "instanceID"), ipSubject)
+			if tt.wantErrFunc == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErrFunc(err), "unexpected error: %v", err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestThrottler_Check_cached(t *testing.T) {
+	tests := []struct {
+		name     string
+		cached   *domain.Throttle
+		expectDB bool
+	}{
+		{
+			name:   "not throttled from cache",
+			cached: &domain.Throttle{InstanceID: "instanceID", Kind: ipSubject.Kind, Key: ipSubject.Key, Failures: 3, LastFailure: testNow},
+		},
+		{
+			name:     "throttled read from database",
+			cached:   &domain.Throttle{InstanceID: "instanceID", Kind: ipSubject.Kind, Key: ipSubject.Key, Failures: 5, LastFailure: testNow, DelayedUntil: testNow.Add(time.Second)},
+			expectDB: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttler, mock := newTestThrottler(t)
+			throttler.config.Cache = &bigcache.Config{MaxCacheSizeInMB: 1, CacheLifetime: time.Minute}
+			c, err := bigcache.NewBigcache(throttler.config.Cache)
+			require.NoError(t, err)
+			throttler.cache = c
+			throttler.setCache(tt.cached)
+			if tt.expectDB {
+				// the throttle was removed on another instance
+				mock.ExpectQuery(regexp.QuoteMeta(getThrottleStmt)).
+					WithArgs("instanceID", domain.ThrottleKindIP, "192.168.1.1").
+					WillReturnRows(sqlmock.NewRows([]string{"failures", "last_failure"}))
+			}
+
+			err = throttler.Check(authz.WithInstanceID(context.Background(), "instanceID"), ipSubject)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestThrottler_Failed(t *testing.T) {
+	throttler, mock := newTestThrottler(t)
+	mock.ExpectQuery(regexp.QuoteMeta(addFailureStmt)).
+		WithArgs("instanceID", domain.ThrottleKindIP, "192.168.1.1", testNow, testNow.Add(-time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(4, testNow))
+
+	throttler.Failed(authz.WithInstanceID(context.Background(), "instanceID"), ipSubject)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestThrottler_List(t *testing.T) {
+	throttler, mock := newTestThrottler(t)
+	mock.ExpectQuery(regexp.QuoteMeta(listThrottlesStmt)).
+		WithArgs("instanceID", testNow.Add(-15*time.Minute)).
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "key", "failures", "last_failure"}).
+			AddRow(domain.ThrottleKindUser, "userID", 10, testNow.Add(-time.Minute)).
+			AddRow(domain.ThrottleKindUserIP, "userID@192.168.1.1", 10, testNow.Add(-time.Minute)).
+			AddRow(domain.ThrottleKindIP, "192.168.1.1", 5, testNow.Add(-time.Second)).
+			AddRow(domain.ThrottleKindUserAgent, "agentID", 2, testNow.Add(-time.Second)),
+		)
+
+	throttles, err := throttler.List(authz.WithInstanceID(context.Background(), "instanceID"))
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Throttle{
+		{
+			InstanceID:   "instanceID",
+			Kind:         domain.ThrottleKindUserIP,
+			Key:          "userID@192.168.1.1",
+			Failures:     10,
+			LastFailure:  testNow.Add(-time.Minute),
+			DelayedUntil: testNow,
+			LockedUntil:  testNow.Add(14 * time.Minute),
+		},
+		{
+			InstanceID:   "instanceID",
+			Kind:         domain.ThrottleKindIP,
+			Key:          "192.168.1.1",
+			Failures:     5,
+			LastFailure:  testNow.Add(-time.Second),
+			DelayedUntil: testNow.Add(time.Second),
+		},
+	}, throttles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestThrottler_Remove(t *testing.T) {
+	tests := []struct {
+		name        string
+		subject     Subject
+		expect      func(sqlmock.Sqlmock)
+		wantErrFunc func(error) bool
+	}{
+		{
+			name:        "invalid subject",
+			subject:     Subject{Key: "192.168.1.1"},
+			expect:      func(sqlmock.Sqlmock) {},
+			wantErrFunc: errors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "not found",
+			subject: ipSubject,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(removeThrottleStmt)).
+					WithArgs("instanceID", domain.ThrottleKindIP, "192.168.1.1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrFunc: errors.IsNotFound,
+		},
+		{
+			name:    "removed",
+			subject: ipSubject,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(removeThrottleStmt)).
+					WithArgs("instanceID", domain.ThrottleKindIP, "192.168.1.1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttler, mock := newTestThrottler(t)
+			tt.expect(mock)
+
+			err := throttler.Remove(authz.WithInstanceID(context.Background(), "instanceID"), tt.subject)
+			if tt.wantErrFunc == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErrFunc(err), "unexpected error: %v", err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestThrottler_Check_userNotLocked(t *testing.T) {
+	throttler, mock := newTestThrottler(t)
+	mock.ExpectQuery(regexp.QuoteMeta(getThrottleStmt)).
+		WithArgs("instanceID", domain.ThrottleKindUser, "userID").
+		WillReturnRows(sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(10, testNow.Add(-10*time.Minute)))
+
+	err := throttler.Check(authz.WithInstanceID(context.Background(), "instanceID"), Subject{Kind: domain.ThrottleKindUser, Key: "userID"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestThrottler_removeExpired(t *testing.T) {
+	throttler, mock := newTestThrottler(t)
+	mock.ExpectExec(regexp.QuoteMeta(removeExpiredStmt)).
+		WithArgs(testNow.Add(-time.Hour)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	assert.NoError(t, throttler.removeExpired(context.Background(), testNow.Add(-time.Hour)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestThrottler_disabled(t *testing.T) {
+	throttler, err := Start(context.Background(), Config{Enabled: false}, nil)
+	require.NoError(t, err)
+	ctx := authz.WithInstanceID(context.Background(), "instanceID")
+
+	assert.NoError(t, throttler.Check(ctx, ipSubject))
+	throttler.Failed(ctx, ipSubject)
+	throttles, err := throttler.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, throttles)
+}
+
+func newTestThrottler(t *testing.T) (*Throttler, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return &Throttler{
+		config: testConfig,
+		client: db,
+		now:    func() time.Time { return testNow },
+	}, mock
+}
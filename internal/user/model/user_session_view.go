@@ -21,6 +21,7 @@ type UserSessionView struct {
 	SelectedIDPConfigID          string
 	PasswordVerification         time.Time
 	PasswordlessVerification     time.Time
+	MagicLinkVerification        time.Time
 	ExternalLoginVerification    time.Time
 	SecondFactorVerification     time.Time
 	SecondFactorVerificationType domain.MFAType
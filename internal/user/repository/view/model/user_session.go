@@ -37,6 +37,7 @@ type UserSessionView struct {
 	SelectedIDPConfigID          string    `json:"selectedIDPConfigID" gorm:"column:selected_idp_config_id"`
 	PasswordVerification         time.Time `json:"-" gorm:"column:password_verification"`
 	PasswordlessVerification     time.Time `json:"-" gorm:"column:passwordless_verification"`
+	MagicLinkVerification        time.Time `json:"-" gorm:"column:magic_link_verification"`
 	ExternalLoginVerification    time.Time `json:"-" gorm:"column:external_login_verification"`
 	SecondFactorVerification     time.Time `json:"-" gorm:"column:second_factor_verification"`
 	SecondFactorVerificationType int32     `json:"-" gorm:"column:second_factor_verification_type"`
@@ -70,6 +71,7 @@ func UserSessionToModel(userSession *UserSessionView) *model.UserSessionView {
 		SelectedIDPConfigID:          userSession.SelectedIDPConfigID,
 		PasswordVerification:         userSession.PasswordVerification,
 		PasswordlessVerification:     userSession.PasswordlessVerification,
+		MagicLinkVerification:        userSession.MagicLinkVerification,
 		ExternalLoginVerification:    userSession.ExternalLoginVerification,
 		SecondFactorVerification:     userSession.SecondFactorVerification,
 		SecondFactorVerificationType: domain.MFAType(userSession.SecondFactorVerificationType),
@@ -116,6 +118,11 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1PasswordCheckFailedType,
 		user.HumanPasswordCheckFailedType:
 		v.PasswordVerification = time.Time{}
+	case user.HumanMagicLinkCheckSucceededType:
+		v.MagicLinkVerification = event.CreationDate
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanMagicLinkCheckFailedType:
+		v.MagicLinkVerification = time.Time{}
 	case user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType:
 		data := new(es_model.PasswordChange)
@@ -138,10 +145,21 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1MFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTP)
+	case user.HumanMFAOTPSMSCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPSMS)
+	case user.HumanMFAOTPEmailCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPEmail)
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeRecoveryCode)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFAOTPEmailRemovedType,
+		user.HumanMFARecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType:
 		v.SecondFactorVerification = time.Time{}
@@ -161,6 +179,7 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.UserLockedType,
 		user.UserDeactivatedType:
 		v.PasswordlessVerification = time.Time{}
+		v.MagicLinkVerification = time.Time{}
 		v.PasswordVerification = time.Time{}
 		v.SecondFactorVerification = time.Time{}
 		v.SecondFactorVerificationType = int32(domain.MFALevelNotSetUp)
@@ -42,6 +42,29 @@ func WebAuthNLoginToSessionData(webAuthN *domain.WebAuthNLogin) webauthn.Session
 	}
 }
 
+// residentKeyFromDomain returns the requireResidentKey member of WebAuthn level 1,
+// which is only true if the resident key is required
+func residentKeyFromDomain(residentKey domain.ResidentKeyRequirement) *bool {
+	if residentKey == domain.ResidentKeyRequirementRequired {
+		return protocol.ResidentKeyRequired()
+	}
+	return protocol.ResidentKeyUnrequired()
+}
+
+// residentKeyRequirementFromDomain returns the residentKey member of WebAuthn level 2
+func residentKeyRequirementFromDomain(residentKey domain.ResidentKeyRequirement) residentKeyRequirement {
+	switch residentKey {
+	case domain.ResidentKeyRequirementDiscouraged:
+		return residentKeyRequirementDiscouraged
+	case domain.ResidentKeyRequirementPreferred:
+		return residentKeyRequirementPreferred
+	case domain.ResidentKeyRequirementRequired:
+		return residentKeyRequirementRequired
+	default:
+		return ""
+	}
+}
+
 func UserVerificationToDomain(verification protocol.UserVerificationRequirement) domain.UserVerificationRequirement {
 	switch verification {
 	case protocol.VerificationRequired:
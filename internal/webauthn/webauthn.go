@@ -3,6 +3,7 @@ package webauthn
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 
 	"github.com/duo-labs/webauthn/protocol"
@@ -52,7 +53,10 @@ func (u *webUser) WebAuthnCredentials() []webauthn.Credential {
 	return u.credentials
 }
 
-func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, isLoginUI bool, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
+// BeginRegistration creates the options for the registration of a new credential.
+// The residentKey requirement defines if the authenticator stores the credential (discoverable credential / passkey),
+// so it can later be used without the user entering the login name.
+func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, residentKey domain.ResidentKeyRequirement, isLoginUI bool, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
 	webAuthNServer, err := w.serverFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -74,6 +78,7 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
 			UserVerification:        UserVerificationFromDomain(userVerification),
 			AuthenticatorAttachment: AuthenticatorAttachmentFromDomain(authType),
+			RequireResidentKey:      residentKeyFromDomain(residentKey),
 		}),
 		webauthn.WithConveyancePreference(protocol.PreferNoAttestation),
 		webauthn.WithExclusions(existing),
@@ -81,7 +86,7 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "WEBAU-bM8sd", "Errors.User.WebAuthN.BeginRegisterFailed")
 	}
-	cred, err := json.Marshal(credentialOptions)
+	cred, err := json.Marshal(credentialCreationWithResidentKey(credentialOptions, residentKey))
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "WEBAU-D7cus", "Errors.User.WebAuthN.MarshalError")
 	}
@@ -93,6 +98,42 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 	}, nil
 }
 
+type residentKeyRequirement string
+
+const (
+	residentKeyRequirementDiscouraged residentKeyRequirement = "discouraged"
+	residentKeyRequirementPreferred   residentKeyRequirement = "preferred"
+	residentKeyRequirementRequired    residentKeyRequirement = "required"
+)
+
+// credentialCreation adds the residentKey member of WebAuthn level 2 to the authenticator selection,
+// which isn't supported by the webauthn library yet
+type credentialCreation struct {
+	Response credentialCreationOptions `json:"publicKey"`
+}
+
+type credentialCreationOptions struct {
+	protocol.PublicKeyCredentialCreationOptions
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection,omitempty"`
+}
+
+type authenticatorSelection struct {
+	protocol.AuthenticatorSelection
+	ResidentKey residentKeyRequirement `json:"residentKey,omitempty"`
+}
+
+func credentialCreationWithResidentKey(options *protocol.CredentialCreation, residentKey domain.ResidentKeyRequirement) *credentialCreation {
+	return &credentialCreation{
+		Response: credentialCreationOptions{
+			PublicKeyCredentialCreationOptions: options.Response,
+			AuthenticatorSelection: authenticatorSelection{
+				AuthenticatorSelection: options.Response.AuthenticatorSelection,
+				ResidentKey:            residentKeyRequirementFromDomain(residentKey),
+			},
+		},
+	}
+}
+
 func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNToken, tokenName string, credData []byte, isLoginUI bool) (*domain.WebAuthNToken, error) {
 	if webAuthN == nil {
 		return nil, caos_errs.ThrowInternal(nil, "WEBAU-5M9so", "Errors.User.WebAuthN.NotFound")
@@ -151,6 +192,50 @@ func (w *Config) BeginLogin(ctx context.Context, user *domain.Human, userVerific
 	}, nil
 }
 
+// BeginDiscoverableLogin creates the options for a login with a discoverable credential.
+// No credentials are allowed explicitly, so the authenticator (or browser with conditional mediation)
+// lets the user choose one of the stored credentials of the relying party.
+func (w *Config) BeginDiscoverableLogin(ctx context.Context, userVerification domain.UserVerificationRequirement) (*domain.WebAuthNLogin, error) {
+	webAuthNServer, err := w.serverFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := protocol.CreateChallenge()
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "WEBAU-Dk3ls", "Errors.User.WebAuthN.BeginLoginFailed")
+	}
+	assertion := protocol.CredentialAssertion{
+		Response: protocol.PublicKeyCredentialRequestOptions{
+			Challenge:        challenge,
+			Timeout:          webAuthNServer.Config.Timeout,
+			RelyingPartyID:   webAuthNServer.Config.RPID,
+			UserVerification: UserVerificationFromDomain(userVerification),
+		},
+	}
+	cred, err := json.Marshal(assertion)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "WEBAU-Dk4mt", "Errors.User.WebAuthN.MarshalError")
+	}
+	return &domain.WebAuthNLogin{
+		Challenge:               base64.RawURLEncoding.EncodeToString(challenge),
+		CredentialAssertionData: cred,
+		UserVerification:        userVerification,
+	}, nil
+}
+
+// UserIDFromCredentialData returns the id of the user the asserted credential belongs to.
+// It's taken from the user handle, which is set to the user id on registration.
+func UserIDFromCredentialData(credData []byte) (string, error) {
+	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
+	if err != nil {
+		return "", caos_errs.ThrowInvalidArgument(err, "WEBAU-Dk5nu", "Errors.User.WebAuthN.ValidateLoginFailed")
+	}
+	if len(assertionData.Response.UserHandle) == 0 {
+		return "", caos_errs.ThrowInvalidArgument(nil, "WEBAU-Dk6ov", "Errors.User.WebAuthN.UserHandleMissing")
+	}
+	return string(assertionData.Response.UserHandle), nil
+}
+
 func (w *Config) FinishLogin(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNLogin, credData []byte, webAuthNs ...*domain.WebAuthNToken) ([]byte, uint32, error) {
 	assertionData, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(credData))
 	if err != nil {
@@ -0,0 +1,125 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func credentialData(t *testing.T, userHandle string) []byte {
+	t.Helper()
+	encode := base64.RawURLEncoding.EncodeToString
+	clientData, err := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": "challenge",
+		"origin":    "https://zitadel.cloud",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"id":    encode([]byte("keyID")),
+		"rawId": encode([]byte("keyID")),
+		"type":  "public-key",
+		"response": map[string]string{
+			"authenticatorData": encode(make([]byte, 37)),
+			"clientDataJSON":    encode(clientData),
+			"signature":         encode([]byte("signature")),
+			"userHandle":        encode([]byte(userHandle)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestUserIDFromCredentialData(t *testing.T) {
+	tests := []struct {
+		name     string
+		credData []byte
+		want     string
+		wantErr  func(error) bool
+	}{
+		{
+			name:     "invalid credential, invalid argument error",
+			credData: []byte("invalid"),
+			wantErr:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:     "missing user handle, invalid argument error",
+			credData: credentialData(t, ""),
+			wantErr:  caos_errs.IsErrorInvalidArgument,
+		},
+		{
+			name:     "user handle, user id",
+			credData: credentialData(t, "userID"),
+			want:     "userID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserIDFromCredentialData(tt.credData)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCredentialCreationWithResidentKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		residentKey domain.ResidentKeyRequirement
+		want        string
+	}{
+		{
+			name:        "preferred, not required",
+			residentKey: domain.ResidentKeyRequirementPreferred,
+			want:        `{"requireResidentKey":false,"userVerification":"required","residentKey":"preferred"}`,
+		},
+		{
+			name:        "required",
+			residentKey: domain.ResidentKeyRequirementRequired,
+			want:        `{"requireResidentKey":true,"userVerification":"required","residentKey":"required"}`,
+		},
+		{
+			name:        "unspecified, omitted",
+			residentKey: domain.ResidentKeyRequirementUnspecified,
+			want:        `{"requireResidentKey":false,"userVerification":"required"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := &protocol.CredentialCreation{
+				Response: protocol.PublicKeyCredentialCreationOptions{
+					Challenge: []byte("challenge"),
+					AuthenticatorSelection: protocol.AuthenticatorSelection{
+						RequireResidentKey: residentKeyFromDomain(tt.residentKey),
+						UserVerification:   protocol.VerificationRequired,
+					},
+				},
+			}
+			data, err := json.Marshal(credentialCreationWithResidentKey(options, tt.residentKey))
+			assert.NoError(t, err)
+			var got struct {
+				PublicKey struct {
+					Challenge              string          `json:"challenge"`
+					AuthenticatorSelection json.RawMessage `json:"authenticatorSelection"`
+				} `json:"publicKey"`
+			}
+			assert.NoError(t, json.Unmarshal(data, &got))
+			assert.NotEmpty(t, got.PublicKey.Challenge)
+			assert.JSONEq(t, tt.want, string(got.PublicKey.AuthenticatorSelection))
+		})
+	}
+}